@@ -18,9 +18,14 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
@@ -98,3 +103,57 @@ func (a *ApiHandler) GetEthV1BeaconBlobSidecars(w http.ResponseWriter, r *http.R
 
 	return beaconhttp.NewBeaconResponse(resp), nil
 }
+
+// maxBlobSidecarsByVersionedHash - bound of the versioned hashes of a request
+const maxBlobSidecarsByVersionedHash = 128
+
+// GetErigonV1BlobSidecars returns the sidecars of the blobs with the versioned hashes of the versioned_hashes query
+// parameter, in the requested order: for the consumers which only know the hashes from the EL transactions. Unknown
+// blobs, and pruned blobs which aren't frozen, are skipped.
+func (a *ApiHandler) GetErigonV1BlobSidecars(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	ctx := r.Context()
+	strHashes, err := beaconhttp.StringListFromQueryParams(r, "versioned_hashes")
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	if len(strHashes) == 0 {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, errors.New("versioned_hashes is required"))
+	}
+	if len(strHashes) > maxBlobSidecarsByVersionedHash {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("too many versioned hashes: %d, max %d", len(strHashes), maxBlobSidecarsByVersionedHash))
+	}
+	versionedHashes := make([]common.Hash, len(strHashes))
+	for i, s := range strHashes {
+		if err := versionedHashes[i].UnmarshalText([]byte(s)); err != nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("invalid versioned hash %q: %w", s, err))
+		}
+	}
+
+	resp := solid.NewStaticListSSZ[*cltypes.BlobSidecar](696969, blobSidecarSSZLenght)
+	for _, versionedHash := range versionedHashes {
+		slot, blockRoot, idx, found, err := a.blobStoage.BlobSidecarLocation(ctx, versionedHash)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		var sidecars []*cltypes.BlobSidecar
+		if a.caplinSnapshots != nil && slot <= a.caplinSnapshots.FrozenBlobs() {
+			sidecars, err = a.caplinSnapshots.ReadBlobSidecars(slot)
+		} else {
+			sidecars, _, err = a.blobStoage.ReadBlobSidecars(ctx, slot, blockRoot)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, sidecar := range sidecars {
+			// the frozen sidecars of the slot are the canonical ones, not necessarily of the indexed block
+			if sidecar.Index == idx && common.Hash(kzg.KZGToVersionedHash(gokzg4844.KZGCommitment(sidecar.KzgCommitment))) == versionedHash {
+				resp.Append(sidecar)
+				break
+			}
+		}
+	}
+	return beaconhttp.NewBeaconResponse(resp), nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+const (
+	depositStatusPending   = "pending"   // in the pending deposits queue of the head state
+	depositStatusProcessed = "processed" // applied to the validator balance
+)
+
+type depositResponse struct {
+	PubKey                common.Bytes48 `json:"pubkey"`
+	WithdrawalCredentials common.Hash    `json:"withdrawal_credentials"`
+	Amount                uint64         `json:"amount,string"`
+	Slot                  uint64         `json:"slot,string"`
+	BlockRoot             common.Hash    `json:"block_root"`
+	RequestIndex          *string        `json:"request_index,omitempty"`
+	Status                string         `json:"status"`
+}
+
+type pendingDepositResponse struct {
+	PubKey                common.Bytes48 `json:"pubkey"`
+	WithdrawalCredentials common.Hash    `json:"withdrawal_credentials"`
+	Amount                uint64         `json:"amount,string"`
+	Slot                  uint64         `json:"slot,string"`
+}
+
+type depositsResponse struct {
+	ValidatorIndex  *string                  `json:"validator_index,omitempty"`
+	Deposits        []depositResponse        `json:"deposits"`
+	PendingDeposits []pendingDepositResponse `json:"pending_deposits"`
+}
+
+type pendingDepositKey struct {
+	pubKey                common.Bytes48
+	withdrawalCredentials common.Hash
+	amount, slot          uint64
+}
+
+// GetErigonV1Deposits joins deposit contract events included in canonical blocks with the pending deposits queue of
+// the head state. With `pubkey`, returns the deposits of that key with their status and its validator index; the
+// pending deposits are filtered by the key. Without it, returns the whole pending deposits queue.
+func (a *ApiHandler) GetErigonV1Deposits(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	if a.syncedData.Syncing() {
+		return nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, errors.New("beacon node is syncing"))
+	}
+	var pubKey *common.Bytes48
+	if s := r.URL.Query().Get("pubkey"); s != "" {
+		pubKey = new(common.Bytes48)
+		if err := pubKey.UnmarshalText([]byte(s)); err != nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+		}
+	}
+
+	depositsResp := depositsResponse{Deposits: []depositResponse{}, PendingDeposits: []pendingDepositResponse{}}
+	pending := map[pendingDepositKey]int{}
+	if err := a.syncedData.ViewHeadState(func(s *state.CachingBeaconState) error {
+		if pubKey != nil {
+			if idx, ok := s.ValidatorIndexByPubkey(*pubKey); ok {
+				validatorIndex := strconv.FormatUint(idx, 10)
+				depositsResp.ValidatorIndex = &validatorIndex
+			}
+		}
+		if s.PendingDeposits() == nil {
+			return nil
+		}
+		s.PendingDeposits().Range(func(_ int, d *solid.PendingDeposit, _ int) bool {
+			if pubKey != nil && d.PubKey != *pubKey {
+				return true
+			}
+			depositsResp.PendingDeposits = append(depositsResp.PendingDeposits, pendingDepositResponse{PubKey: d.PubKey, WithdrawalCredentials: d.WithdrawalCredentials, Amount: d.Amount, Slot: d.Slot})
+			pending[pendingDepositKey{d.PubKey, d.WithdrawalCredentials, d.Amount, d.Slot}]++
+			return true
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if pubKey == nil {
+		return newBeaconResponse(depositsResp), nil
+	}
+
+	tx, err := a.indiciesDB.BeginRo(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	deposits, err := beacon_indicies.ReadDepositsByPubKey(r.Context(), tx, *pubKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deposits {
+		resp := depositResponse{
+			PubKey:                d.PubKey,
+			WithdrawalCredentials: d.WithdrawalCredentials,
+			Amount:                d.Amount,
+			Slot:                  d.Slot,
+			BlockRoot:             d.BlockRoot,
+			Status:                depositStatusProcessed,
+		}
+		// queued deposit requests keep the slot of the including block, queued eth1 data voting deposits have the genesis slot
+		key := pendingDepositKey{d.PubKey, d.WithdrawalCredentials, d.Amount, d.Slot}
+		if d.RequestIndex != nil {
+			requestIndex := strconv.FormatUint(*d.RequestIndex, 10)
+			resp.RequestIndex = &requestIndex
+		} else {
+			key.slot = a.beaconChainCfg.GenesisSlot
+		}
+		if pending[key] > 0 {
+			pending[key]--
+			resp.Status = depositStatusPending
+		}
+		depositsResp.Deposits = append(depositsResp.Deposits, resp)
+	}
+	return newBeaconResponse(depositsResp), nil
+}
@@ -23,6 +23,7 @@ import (
 	"strconv"
 
 	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 )
 
 func (a *ApiHandler) GetEthV2DebugBeaconHeads(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
@@ -55,3 +56,38 @@ func (a *ApiHandler) GetEthV1DebugBeaconForkChoice(w http.ResponseWriter, r *htt
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+// GetEthV1DebugForkChoiceTree returns the recent fork tree of Caplin's fork choice: blocks with weights and,
+// for every slot, the selected head, competing leaves and checkpoints. Meant for fork choice visualization.
+func (a *ApiHandler) GetEthV1DebugForkChoiceTree(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	epochs, err := beaconhttp.Uint64FromQueryParams(r, "epochs")
+	if err != nil {
+		return nil, err
+	}
+	if epochs == nil {
+		epochs = new(uint64)
+	}
+	return newBeaconResponse(a.forkchoiceStore.ForkTree(*epochs)), nil
+}
+
+// GetEthV1DebugBlobSidecarEquivocations returns the evidence of conflicting blob sidecars observed on gossip,
+// optionally starting from slot `from_slot`
+func (a *ApiHandler) GetEthV1DebugBlobSidecarEquivocations(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	fromSlot, err := beaconhttp.Uint64FromQueryParams(r, "from_slot")
+	if err != nil {
+		return nil, err
+	}
+	if fromSlot == nil {
+		fromSlot = new(uint64)
+	}
+	tx, err := a.indiciesDB.BeginRo(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	equivocations, err := beacon_indicies.ReadBlobSidecarEquivocations(tx, *fromSlot)
+	if err != nil {
+		return nil, err
+	}
+	return newBeaconResponse(equivocations), nil
+}
@@ -217,6 +217,10 @@ func (a *ApiHandler) init() {
 			r.Get("/validator_inclusion/{epoch}/{validator_id}", beaconhttp.HandleEndpointFunc(a.GetLighthouseValidatorInclusion))
 		})
 	}
+	if a.routerCfg.Beacon {
+		r.Get("/erigon/v1/deposits", beaconhttp.HandleEndpointFunc(a.GetErigonV1Deposits))
+		r.Get("/erigon/v1/blob_sidecars", beaconhttp.HandleEndpointFunc(a.GetErigonV1BlobSidecars))
+	}
 	r.Route("/eth", func(r chi.Router) {
 		r.Route("/v1", func(r chi.Router) {
 			if a.routerCfg.Builder {
@@ -239,6 +243,8 @@ func (a *ApiHandler) init() {
 
 			if a.routerCfg.Debug {
 				r.Get("/debug/fork_choice", a.GetEthV1DebugBeaconForkChoice)
+				r.Get("/debug/fork_choice/tree", beaconhttp.HandleEndpointFunc(a.GetEthV1DebugForkChoiceTree))
+				r.Get("/debug/blob_sidecar_equivocations", beaconhttp.HandleEndpointFunc(a.GetEthV1DebugBlobSidecarEquivocations))
 			}
 			if a.routerCfg.Config {
 				r.Route("/config", func(r chi.Router) {
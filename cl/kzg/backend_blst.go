@@ -0,0 +1,83 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build cgo
+
+package kzg
+
+import (
+	"errors"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	ckzg "github.com/ethereum/c-kzg-4844/v2/bindings/go"
+
+	libkzg "github.com/erigontech/erigon-lib/crypto/kzg"
+)
+
+var errInvalidProof = errors.New("invalid kzg proof")
+
+func init() {
+	libkzg.RegisterBackend(blstBackend{})
+}
+
+// blstBackend - c-kzg-4844 over blst, with the trusted setup of InitKZG
+type blstBackend struct{}
+
+func (blstBackend) Name() string { return libkzg.BackendBlst }
+
+func (blstBackend) Init() error {
+	if libkzg.TrustedSetupFilePath() != "" {
+		return errors.New("the blst kzg backend supports only the embedded trusted setup")
+	}
+	InitKZG()
+	return nil
+}
+
+func (blstBackend) VerifyKZGProof(commitment gokzg4844.KZGCommitment, z, y gokzg4844.Scalar, proof gokzg4844.KZGProof) error {
+	ok, err := ckzg.VerifyKZGProof(ckzg.Bytes48(commitment), ckzg.Bytes32(z), ckzg.Bytes32(y), ckzg.Bytes48(proof))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidProof
+	}
+	return nil
+}
+
+func (blstBackend) VerifyBlobKZGProofBatch(blobs []gokzg4844.BlobRef, commitments []gokzg4844.KZGCommitment, proofs []gokzg4844.KZGProof) error {
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		return errors.New("mismatched lengths of blobs, commitments and proofs")
+	}
+	ckzgBlobs := make([]ckzg.Blob, len(blobs))
+	ckzgCommitments := make([]ckzg.Bytes48, len(blobs))
+	ckzgProofs := make([]ckzg.Bytes48, len(blobs))
+	for i := range blobs {
+		if len(blobs[i]) != len(ckzgBlobs[i]) {
+			return errors.New("invalid blob length")
+		}
+		copy(ckzgBlobs[i][:], blobs[i])
+		ckzgCommitments[i] = ckzg.Bytes48(commitments[i])
+		ckzgProofs[i] = ckzg.Bytes48(proofs[i])
+	}
+	ok, err := ckzg.VerifyBlobKZGProofBatch(ckzgBlobs, ckzgCommitments, ckzgProofs)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidProof
+	}
+	return nil
+}
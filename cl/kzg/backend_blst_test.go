@@ -0,0 +1,70 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build cgo
+
+package kzg
+
+import (
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libkzg "github.com/erigontech/erigon-lib/crypto/kzg"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func TestBackendsAgree(t *testing.T) {
+	require.Equal(t, []string{libkzg.BackendBlst, libkzg.BackendGnark}, libkzg.Backends())
+
+	txw := types.MakeWrappedBlobTxn(uint256.NewInt(1))
+	blobs := make([]gokzg4844.BlobRef, len(txw.Blobs))
+	commitments := make([]gokzg4844.KZGCommitment, len(txw.Blobs))
+	proofs := make([]gokzg4844.KZGProof, len(txw.Blobs))
+	for i := range txw.Blobs {
+		blobs[i] = txw.Blobs[i][:]
+		commitments[i] = gokzg4844.KZGCommitment(txw.Commitments[i])
+		proofs[i] = gokzg4844.KZGProof(txw.Proofs[i])
+	}
+	var z gokzg4844.Scalar
+	z[31] = 7
+	proof, y, err := libkzg.Ctx().ComputeKZGProof(blobs[0], z, 1)
+	require.NoError(t, err)
+
+	for _, name := range []string{libkzg.BackendGnark, libkzg.BackendBlst} {
+		backend, err := libkzg.SetBackend(name)
+		require.NoError(t, err)
+		require.Equal(t, name, backend.Name())
+
+		require.NoError(t, backend.VerifyBlobKZGProofBatch(blobs, commitments, proofs), name)
+		require.NoError(t, backend.VerifyKZGProof(commitments[0], z, y, proof), name)
+
+		require.Error(t, backend.VerifyBlobKZGProofBatch(blobs, commitments, []gokzg4844.KZGProof{proofs[1], proofs[0]}), name)
+		y[31] ^= 1
+		require.Error(t, backend.VerifyKZGProof(commitments[0], z, y, proof), name)
+		y[31] ^= 1
+	}
+
+	backend, err := libkzg.SetBackend(libkzg.BackendAuto)
+	require.NoError(t, err)
+	require.Contains(t, libkzg.Backends(), backend.Name())
+	require.Equal(t, backend, libkzg.ActiveBackend())
+
+	_, err = libkzg.SetBackend("unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon_indicies
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+)
+
+// noDepositRequestIndex marks deposits included through eth1 data voting: they have no deposit request index.
+const noDepositRequestIndex = math.MaxUint64
+
+// IndexedDeposit is a deposit contract event as included in a canonical beacon block.
+type IndexedDeposit struct {
+	PubKey                common.Bytes48
+	WithdrawalCredentials common.Hash
+	Amount                uint64 // Gwei
+	Slot                  uint64 // slot of the including block
+	BlockRoot             common.Hash
+	RequestIndex          *uint64 // EIP-6110 deposit request index, nil for deposits included through eth1 data voting
+}
+
+const depositKeyLen = length.Bytes48 + 8 + length.Hash + 4
+
+// depositKey - a public key may deposit several times in a block, so keys end with the position of the deposit in the block
+func depositKey(pubKey common.Bytes48, slot uint64, blockRoot common.Hash, position int) []byte {
+	key := make([]byte, 0, depositKeyLen)
+	key = append(key, pubKey[:]...)
+	key = binary.BigEndian.AppendUint64(key, slot)
+	key = append(key, blockRoot[:]...)
+	return binary.BigEndian.AppendUint32(key, uint32(position))
+}
+
+func writeDeposit(tx kv.RwTx, pubKey common.Bytes48, withdrawalCredentials common.Hash, amount, requestIndex, slot uint64, blockRoot common.Hash, position int) error {
+	v := make([]byte, 0, length.Hash+16)
+	v = append(v, withdrawalCredentials[:]...)
+	v = binary.BigEndian.AppendUint64(v, amount)
+	v = binary.BigEndian.AppendUint64(v, requestIndex)
+	return tx.Put(kv.DepositPubKeyToDeposits, depositKey(pubKey, slot, blockRoot, position), v)
+}
+
+// WriteDeposits indexes by public key the deposits of the block: eth1 data voting deposits and EIP-6110 deposit requests.
+func WriteDeposits(tx kv.RwTx, block *cltypes.BeaconBlock, blockRoot common.Hash) error {
+	var err error
+	position := 0
+	block.Body.Deposits.Range(func(_ int, d *cltypes.Deposit, _ int) bool {
+		err = writeDeposit(tx, d.Data.PubKey, d.Data.WithdrawalCredentials, d.Data.Amount, noDepositRequestIndex, block.Slot, blockRoot, position)
+		position++
+		return err == nil
+	})
+	if err != nil || block.Version() < clparams.ElectraVersion || block.Body.ExecutionRequests == nil {
+		return err
+	}
+	block.Body.ExecutionRequests.Deposits.Range(func(_ int, d *solid.DepositRequest, _ int) bool {
+		err = writeDeposit(tx, d.PubKey, d.WithdrawalCredentials, d.Amount, d.Index, block.Slot, blockRoot, position)
+		position++
+		return err == nil
+	})
+	return err
+}
+
+// ReadDepositsByPubKey returns the deposits of a public key included in canonical blocks, ordered by slot.
+func ReadDepositsByPubKey(ctx context.Context, tx kv.Tx, pubKey common.Bytes48) ([]IndexedDeposit, error) {
+	it, err := tx.Prefix(kv.DepositPubKeyToDeposits, pubKey[:])
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var deposits []IndexedDeposit
+	for it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if len(k) != depositKeyLen || len(v) != length.Hash+16 {
+			continue
+		}
+		slot := binary.BigEndian.Uint64(k[length.Bytes48:])
+		blockRoot := common.BytesToHash(k[length.Bytes48+8 : length.Bytes48+8+length.Hash])
+		canonical, err := ReadCanonicalBlockRoot(tx, slot)
+		if err != nil {
+			return nil, err
+		}
+		if canonical != blockRoot {
+			continue
+		}
+		d := IndexedDeposit{
+			PubKey:                pubKey,
+			WithdrawalCredentials: common.BytesToHash(v[:length.Hash]),
+			Amount:                binary.BigEndian.Uint64(v[length.Hash:]),
+			Slot:                  slot,
+			BlockRoot:             blockRoot,
+		}
+		if idx := binary.BigEndian.Uint64(v[length.Hash+8:]); idx != noDepositRequestIndex {
+			d.RequestIndex = &idx
+		}
+		deposits = append(deposits, d)
+	}
+	return deposits, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon_indicies
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/cl/cltypes"
+)
+
+const (
+	// BlobSidecarEquivocationProposer - verified sidecars of two different blocks signed by the proposer of the slot
+	BlobSidecarEquivocationProposer = "proposer"
+	// BlobSidecarEquivocationCommitment - sidecars of the same block and blob index with different kzg commitments
+	BlobSidecarEquivocationCommitment = "commitment"
+)
+
+// BlobSidecarEquivocation - evidence of conflicting blob sidecars observed on gossip
+type BlobSidecarEquivocation struct {
+	Kind          string                           `json:"kind"`
+	Slot          uint64                           `json:"slot,string"`
+	ProposerIndex uint64                           `json:"proposer_index,string"`
+	Index         uint64                           `json:"index,string"` // blob index, 0 for proposer equivocations
+	Header1       *cltypes.SignedBeaconBlockHeader `json:"signed_header_1"`
+	Header2       *cltypes.SignedBeaconBlockHeader `json:"signed_header_2"`
+	Commitment1   common.Bytes48                   `json:"kzg_commitment_1"`
+	Commitment2   common.Bytes48                   `json:"kzg_commitment_2"`
+}
+
+func blobSidecarEquivocationKey(e *BlobSidecarEquivocation) []byte {
+	key := make([]byte, 8+8+1+8)
+	binary.BigEndian.PutUint64(key, e.Slot)
+	binary.BigEndian.PutUint64(key[8:], e.ProposerIndex)
+	if e.Kind == BlobSidecarEquivocationCommitment {
+		key[16] = 1
+	}
+	binary.BigEndian.PutUint64(key[17:], e.Index)
+	return key
+}
+
+// WriteBlobSidecarEquivocation stores the evidence, the first one of (slot, proposer, kind, index) is kept
+func WriteBlobSidecarEquivocation(tx kv.RwTx, e *BlobSidecarEquivocation) error {
+	key := blobSidecarEquivocationKey(e)
+	has, err := tx.Has(kv.BlobSidecarEquivocations, key)
+	if err != nil || has {
+		return err
+	}
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return tx.Put(kv.BlobSidecarEquivocations, key, v)
+}
+
+// ReadBlobSidecarEquivocations returns the evidence of slots starting from fromSlot, ordered by slot
+func ReadBlobSidecarEquivocations(tx kv.Tx, fromSlot uint64) ([]*BlobSidecarEquivocation, error) {
+	c, err := tx.Cursor(kv.BlobSidecarEquivocations)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	res := []*BlobSidecarEquivocation{}
+	k, v, err := c.Seek(binary.BigEndian.AppendUint64(nil, fromSlot))
+	for ; k != nil && err == nil; k, v, err = c.Next() {
+		e := &BlobSidecarEquivocation{}
+		if err := json.Unmarshal(v, e); err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
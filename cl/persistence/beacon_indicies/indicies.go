@@ -353,6 +353,9 @@ func WriteBeaconBlockAndIndicies(ctx context.Context, tx kv.RwTx, block *cltypes
 	if err != nil {
 		return err
 	}
+	if err := WriteDeposits(tx, block.Block, blockRoot); err != nil {
+		return err
+	}
 	if block.Version() >= clparams.BellatrixVersion {
 		if err := WriteExecutionBlockNumber(tx, blockRoot, block.Block.Body.ExecutionPayload.BlockNumber); err != nil {
 			return err
@@ -25,6 +25,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv/memdb"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -188,3 +189,67 @@ func TestWriteExecutionBlockHash(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, tHash2, tHash3)
 }
+
+func TestReadDepositsByPubKey(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	tx, _ := db.BeginRw(context.Background())
+	defer tx.Rollback()
+
+	pubKey := common.Bytes48{1}
+	blockRoot, forkRoot := common.Hash{1}, common.Hash{2}
+	block := cltypes.NewBeaconBlock(&clparams.MainnetBeaconConfig, clparams.ElectraVersion)
+	block.Slot = 56
+	block.Body.Deposits.Append(&cltypes.Deposit{Proof: solid.NewHashVector(33), Data: &cltypes.DepositData{PubKey: pubKey, Amount: 32_000_000_000}})
+	block.Body.ExecutionRequests.Deposits.Append(&solid.DepositRequest{PubKey: pubKey, Amount: 1_000_000_000, Index: 7})
+	block.Body.ExecutionRequests.Deposits.Append(&solid.DepositRequest{PubKey: common.Bytes48{2}, Amount: 1_000_000_000, Index: 8})
+	require.NoError(t, WriteDeposits(tx, block, blockRoot))
+	require.NoError(t, MarkRootCanonical(context.Background(), tx, block.Slot, blockRoot))
+
+	// deposits of a non-canonical block are ignored
+	fork := cltypes.NewBeaconBlock(&clparams.MainnetBeaconConfig, clparams.ElectraVersion)
+	fork.Slot = 56
+	fork.Body.ExecutionRequests.Deposits.Append(&solid.DepositRequest{PubKey: pubKey, Amount: 2_000_000_000, Index: 7})
+	require.NoError(t, WriteDeposits(tx, fork, forkRoot))
+
+	deposits, err := ReadDepositsByPubKey(context.Background(), tx, pubKey)
+	require.NoError(t, err)
+	require.Len(t, deposits, 2)
+	require.Nil(t, deposits[0].RequestIndex)
+	require.Equal(t, uint64(32_000_000_000), deposits[0].Amount)
+	require.Equal(t, uint64(1_000_000_000), deposits[1].Amount)
+	require.Equal(t, uint64(7), *deposits[1].RequestIndex)
+	for _, d := range deposits {
+		require.Equal(t, uint64(56), d.Slot)
+		require.Equal(t, blockRoot, d.BlockRoot)
+	}
+}
+
+func TestBlobSidecarEquivocations(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	tx, _ := db.BeginRw(context.Background())
+	defer tx.Rollback()
+
+	header := func(slot uint64, bodyRoot byte) *cltypes.SignedBeaconBlockHeader {
+		return &cltypes.SignedBeaconBlockHeader{Header: &cltypes.BeaconBlockHeader{Slot: slot, ProposerIndex: 7, BodyRoot: common.Hash{bodyRoot}}}
+	}
+	proposer := &BlobSidecarEquivocation{Kind: BlobSidecarEquivocationProposer, Slot: 10, ProposerIndex: 7, Header1: header(10, 1), Header2: header(10, 2)}
+	commitment := &BlobSidecarEquivocation{Kind: BlobSidecarEquivocationCommitment, Slot: 10, ProposerIndex: 7, Index: 3,
+		Header1: header(10, 1), Header2: header(10, 1), Commitment1: common.Bytes48{1}, Commitment2: common.Bytes48{2}}
+	later := &BlobSidecarEquivocation{Kind: BlobSidecarEquivocationProposer, Slot: 12, ProposerIndex: 7, Header1: header(12, 1), Header2: header(12, 2)}
+	for _, e := range []*BlobSidecarEquivocation{later, commitment, proposer} {
+		require.NoError(t, WriteBlobSidecarEquivocation(tx, e))
+	}
+	// the first evidence is kept
+	require.NoError(t, WriteBlobSidecarEquivocation(tx, &BlobSidecarEquivocation{Kind: BlobSidecarEquivocationProposer, Slot: 10, ProposerIndex: 7,
+		Header1: header(10, 1), Header2: header(10, 3)}))
+
+	all, err := ReadBlobSidecarEquivocations(tx, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*BlobSidecarEquivocation{proposer, commitment, later}, all)
+
+	fromSlot, err := ReadBlobSidecarEquivocations(tx, 11)
+	require.NoError(t, err)
+	require.Equal(t, []*BlobSidecarEquivocation{later}, fromSlot)
+}
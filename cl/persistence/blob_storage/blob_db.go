@@ -17,6 +17,7 @@
 package blob_storage
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
@@ -30,6 +31,7 @@ import (
 
 	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon/cl/clparams"
@@ -51,6 +53,9 @@ type BlobStorage interface {
 	BlobSidecarExists(ctx context.Context, slot uint64, blockRoot common.Hash, idx uint64) (bool, error)
 	WriteStream(w io.Writer, slot uint64, blockRoot common.Hash, idx uint64) error // Used for P2P networking
 	KzgCommitmentsCount(ctx context.Context, blockRoot common.Hash) (uint32, error)
+	// BlobSidecarLocation returns the slot, the block root and the index of the last written sidecar of the blob
+	// with the versioned hash. The locations outlive the pruned sidecars: the frozen ones are in the snapshots.
+	BlobSidecarLocation(ctx context.Context, versionedHash common.Hash) (slot uint64, blockRoot common.Hash, idx uint64, found bool, err error)
 	Prune() error
 }
 
@@ -77,8 +82,19 @@ func blobSidecarFilePath(slot, index uint64, blockRoot common.Hash) (folderpath,
 file system layout: <slot/subdivisionSlot>/<blockRoot>_<index>
 indicies:
 - <blockRoot> -> kzg_commitments_length // block
+- <versionedHash> -> <slot><blockRoot><index> // blob, see blobSidecarLocation
 */
 
+// blobSidecarLocation - value of kv.BlobVersionedHashToSidecar: slot, block root and index of the sidecar
+func blobSidecarLocation(sidecar *cltypes.BlobSidecar, blockRoot common.Hash) (versionedHash common.Hash, location []byte) {
+	versionedHash = common.Hash(kzg.KZGToVersionedHash(gokzg4844.KZGCommitment(sidecar.KzgCommitment)))
+	location = make([]byte, 0, 8+length.Hash+8)
+	location = binary.BigEndian.AppendUint64(location, sidecar.SignedBlockHeader.Header.Slot)
+	location = append(location, blockRoot[:]...)
+	location = binary.BigEndian.AppendUint64(location, sidecar.Index)
+	return versionedHash, location
+}
+
 // WriteBlobSidecars writes the sidecars on the database. it assumes that all blobSidecars are for the same blockRoot and we have all of them.
 func (bs *BlobStore) WriteBlobSidecars(ctx context.Context, blockRoot common.Hash, blobSidecars []*cltypes.BlobSidecar) error {
 
@@ -113,6 +129,12 @@ func (bs *BlobStore) WriteBlobSidecars(ctx context.Context, blockRoot common.Has
 	if err := tx.Put(kv.BlockRootToKzgCommitments, blockRoot[:], val); err != nil {
 		return err
 	}
+	for _, blobSidecar := range blobSidecars {
+		versionedHash, location := blobSidecarLocation(blobSidecar, blockRoot)
+		if err := tx.Put(kv.BlobVersionedHashToSidecar, versionedHash[:], location); err != nil {
+			return err
+		}
+	}
 	return tx.Commit()
 }
 
@@ -228,6 +250,9 @@ func (bs *BlobStore) RemoveBlobSidecars(ctx context.Context, slot uint64, blockR
 	kzgCommitmentsLength := binary.LittleEndian.Uint32(val)
 	for i := uint32(0); i < kzgCommitmentsLength; i++ {
 		_, filePath := blobSidecarFilePath(slot, uint64(i), blockRoot)
+		if err := bs.removeBlobSidecarLocation(tx, filePath, blockRoot); err != nil {
+			return err
+		}
 		if err := bs.fs.Remove(filePath); err != nil {
 			return err
 		}
@@ -236,6 +261,48 @@ func (bs *BlobStore) RemoveBlobSidecars(ctx context.Context, slot uint64, blockR
 	return tx.Commit()
 }
 
+// removeBlobSidecarLocation removes the location of the sidecar of the file, unless the blob was written by another
+// block since
+func (bs *BlobStore) removeBlobSidecarLocation(tx kv.RwTx, filePath string, blockRoot common.Hash) error {
+	file, err := bs.fs.Open(filePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+	blobSidecar := &cltypes.BlobSidecar{}
+	if err := ssz_snappy.DecodeAndReadNoForkDigest(file, blobSidecar, clparams.DenebVersion); err != nil {
+		return err
+	}
+	versionedHash, location := blobSidecarLocation(blobSidecar, blockRoot)
+	stored, err := tx.GetOne(kv.BlobVersionedHashToSidecar, versionedHash[:])
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(stored, location) {
+		return nil
+	}
+	return tx.Delete(kv.BlobVersionedHashToSidecar, versionedHash[:])
+}
+
+func (bs *BlobStore) BlobSidecarLocation(ctx context.Context, versionedHash common.Hash) (uint64, common.Hash, uint64, bool, error) {
+	tx, err := bs.db.BeginRo(ctx)
+	if err != nil {
+		return 0, common.Hash{}, 0, false, err
+	}
+	defer tx.Rollback()
+	val, err := tx.GetOne(kv.BlobVersionedHashToSidecar, versionedHash[:])
+	if err != nil {
+		return 0, common.Hash{}, 0, false, err
+	}
+	if len(val) != 8+length.Hash+8 {
+		return 0, common.Hash{}, 0, false, nil
+	}
+	return binary.BigEndian.Uint64(val[:8]), common.Hash(val[8 : 8+length.Hash]), binary.BigEndian.Uint64(val[8+length.Hash:]), true, nil
+}
+
 type sidecarsPayload struct {
 	blockRoot common.Hash
 	sidecars  []*cltypes.BlobSidecar
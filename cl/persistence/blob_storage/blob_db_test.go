@@ -66,3 +66,43 @@ func TestBlobDB(t *testing.T) {
 	require.Equal(t, s1.SignedBlockHeader, sidecars[0].SignedBlockHeader)
 	require.Equal(t, s2.SignedBlockHeader, sidecars[1].SignedBlockHeader)
 }
+
+func TestBlobSidecarLocation(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	header := &cltypes.SignedBeaconBlockHeader{Header: &cltypes.BeaconBlockHeader{Slot: 1}}
+	s1 := cltypes.NewBlobSidecar(0, &cltypes.Blob{1}, common.Bytes48{2}, common.Bytes48{3}, header, solid.NewHashVector(cltypes.CommitmentBranchSize))
+	s2 := cltypes.NewBlobSidecar(1, &cltypes.Blob{3}, common.Bytes48{5}, common.Bytes48{9}, header, solid.NewHashVector(cltypes.CommitmentBranchSize))
+
+	bs := NewBlobStore(db, afero.NewMemMapFs(), 12, &clparams.MainnetBeaconConfig, nil)
+	blockRoot := common.Hash{1}
+	require.NoError(t, bs.WriteBlobSidecars(context.Background(), blockRoot, []*cltypes.BlobSidecar{s1, s2}))
+
+	versionedHash, _ := blobSidecarLocation(s2, blockRoot)
+	slot, root, idx, found, err := bs.BlobSidecarLocation(context.Background(), versionedHash)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(1), slot)
+	require.Equal(t, blockRoot, root)
+	require.Equal(t, uint64(1), idx)
+
+	_, _, _, found, err = bs.BlobSidecarLocation(context.Background(), common.Hash{1})
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// the blob of another block outlives the removal of the first one
+	otherRoot := common.Hash{2}
+	s3 := cltypes.NewBlobSidecar(0, &cltypes.Blob{3}, common.Bytes48{5}, common.Bytes48{9}, header, solid.NewHashVector(cltypes.CommitmentBranchSize))
+	require.NoError(t, bs.WriteBlobSidecars(context.Background(), otherRoot, []*cltypes.BlobSidecar{s3}))
+	require.NoError(t, bs.RemoveBlobSidecars(context.Background(), 1, blockRoot))
+	_, root, _, found, err = bs.BlobSidecarLocation(context.Background(), versionedHash)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, otherRoot, root)
+
+	require.NoError(t, bs.RemoveBlobSidecars(context.Background(), 1, otherRoot))
+	_, _, _, found, err = bs.BlobSidecarLocation(context.Background(), versionedHash)
+	require.NoError(t, err)
+	require.False(t, found)
+}
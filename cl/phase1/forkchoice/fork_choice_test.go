@@ -152,6 +152,19 @@ func TestForkChoiceBasic(t *testing.T) {
 	sd.OnHeadState(bs)
 
 	require.NoError(t, err)
+	// fork tree keeps the view at the end of every slot the head was computed in
+	headRoot, _, err = store.GetHead(nil)
+	require.NoError(t, err)
+	store.OnTick(48)
+	tree := store.ForkTree(0)
+	require.Len(t, tree.Slots, 2)
+	require.Equal(t, uint64(1), tree.Slots[0].Slot)
+	require.Equal(t, common.HexToHash("0xc9bd7bcb6dfa49dc4e5a67ca75e89062c36b5c300bc25a1b31db4e1a89306071"), tree.Slots[0].Head)
+	require.Equal(t, uint64(3), tree.Slots[1].Slot)
+	require.Equal(t, headRoot, tree.Slots[1].Head)
+	require.NotEmpty(t, tree.Slots[1].Heads)
+	require.NotEmpty(t, tree.Nodes)
+	require.Equal(t, *expectedCheckpoint, tree.FinalizedCheckpoint)
 }
 
 func TestForkChoiceChainBellatrix(t *testing.T) {
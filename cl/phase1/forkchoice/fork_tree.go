@@ -0,0 +1,150 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package forkchoice
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+)
+
+// ForkTreeRetentionEpochs is how many epochs of fork tree history are kept for debugging.
+const ForkTreeRetentionEpochs = 8
+
+// ForkTreeHead is a leaf of the fork tree as seen at the end of a slot.
+type ForkTreeHead struct {
+	BlockRoot common.Hash `json:"block_root"`
+	Slot      uint64      `json:"slot,string"`
+	Weight    uint64      `json:"weight,string"`
+}
+
+// ForkTreeSlot is the fork choice view at the end of a slot: the selected head, competing leaves and checkpoints.
+type ForkTreeSlot struct {
+	Slot                uint64           `json:"slot,string"`
+	Head                common.Hash      `json:"head"`
+	HeadSlot            uint64           `json:"head_slot,string"`
+	JustifiedCheckpoint solid.Checkpoint `json:"justified_checkpoint"`
+	FinalizedCheckpoint solid.Checkpoint `json:"finalized_checkpoint"`
+	Heads               []ForkTreeHead   `json:"heads"`
+}
+
+// ForkTree is the recent fork tree: every block seen in the retained slots with its last known weight
+// (blocks pruned by finalization included), and the per-slot fork choice views.
+type ForkTree struct {
+	JustifiedCheckpoint solid.Checkpoint `json:"justified_checkpoint"`
+	FinalizedCheckpoint solid.Checkpoint `json:"finalized_checkpoint"`
+	Nodes               []ForkNode       `json:"nodes"`
+	Slots               []ForkTreeSlot   `json:"slots"`
+}
+
+type forkTreeHistory struct {
+	mu    sync.Mutex
+	nodes map[common.Hash]ForkNode
+	slots []ForkTreeSlot // ascending by slot
+}
+
+// add records the view at the end of a slot and drops everything older than minSlot.
+func (h *forkTreeHistory) add(view ForkTreeSlot, nodes []ForkNode, minSlot uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.nodes == nil {
+		h.nodes = make(map[common.Hash]ForkNode)
+	}
+	for _, node := range nodes {
+		h.nodes[node.BlockRoot] = node
+	}
+	h.slots = append(h.slots, view)
+
+	for root, node := range h.nodes {
+		if node.Slot < minSlot {
+			delete(h.nodes, root)
+		}
+	}
+	i := sort.Search(len(h.slots), func(i int) bool { return h.slots[i].Slot >= minSlot })
+	h.slots = append(h.slots[:0], h.slots[i:]...)
+}
+
+// tree returns the nodes and views of slots starting from fromSlot, ordered by slot.
+func (h *forkTreeHistory) tree(fromSlot uint64) ([]ForkNode, []ForkTreeSlot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	nodes := make([]ForkNode, 0, len(h.nodes))
+	for _, node := range h.nodes {
+		if node.Slot >= fromSlot {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Slot != nodes[j].Slot {
+			return nodes[i].Slot < nodes[j].Slot
+		}
+		return nodes[i].BlockRoot.Cmp(nodes[j].BlockRoot) < 0
+	})
+	i := sort.Search(len(h.slots), func(i int) bool { return h.slots[i].Slot >= fromSlot })
+	return nodes, append([]ForkTreeSlot(nil), h.slots[i:]...)
+}
+
+// recordForkTree saves the fork choice view of a finished slot. Must be called with f.mu held.
+// Nothing is recorded if the head was not computed during the slot.
+func (f *ForkChoiceStore) recordForkTree(slot uint64) {
+	if f.headHash == (common.Hash{}) {
+		return
+	}
+	heads := make([]ForkTreeHead, 0, len(f.headSet))
+	for root := range f.headSet {
+		header, has := f.forkGraph.GetHeader(root)
+		if !has {
+			continue
+		}
+		heads = append(heads, ForkTreeHead{BlockRoot: root, Slot: header.Slot, Weight: f.weights[root]})
+	}
+	sort.Slice(heads, func(i, j int) bool { return heads[i].Weight > heads[j].Weight })
+
+	retention := ForkTreeRetentionEpochs * f.beaconCfg.SlotsPerEpoch
+	var minSlot uint64
+	if slot >= retention {
+		minSlot = slot - retention + 1
+	}
+	f.forkTree.add(ForkTreeSlot{
+		Slot:                slot,
+		Head:                f.headHash,
+		HeadSlot:            f.headSlot,
+		JustifiedCheckpoint: f.justifiedCheckpoint.Load().(solid.Checkpoint),
+		FinalizedCheckpoint: f.finalizedCheckpoint.Load().(solid.Checkpoint),
+		Heads:               heads,
+	}, f.forkNodes(), minSlot)
+}
+
+// ForkTree returns the fork tree of the last `epochs` epochs, at most ForkTreeRetentionEpochs. 0 - all retained epochs.
+func (f *ForkChoiceStore) ForkTree(epochs uint64) ForkTree {
+	if epochs == 0 || epochs > ForkTreeRetentionEpochs {
+		epochs = ForkTreeRetentionEpochs
+	}
+	var fromSlot uint64
+	if slot, window := f.Slot(), epochs*f.beaconCfg.SlotsPerEpoch; slot >= window {
+		fromSlot = slot - window + 1
+	}
+	nodes, slots := f.forkTree.tree(fromSlot)
+	return ForkTree{
+		JustifiedCheckpoint: f.JustifiedCheckpoint(),
+		FinalizedCheckpoint: f.FinalizedCheckpoint(),
+		Nodes:               nodes,
+		Slots:               slots,
+	}
+}
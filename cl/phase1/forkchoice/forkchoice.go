@@ -123,6 +123,8 @@ type ForkChoiceStore struct {
 	randaoDeltas     *lru.Cache[common.Hash, randaoDelta]       // small entry can be lots of elements.
 	// participation tracking
 	participation *lru.Cache[uint64, *solid.ParticipationBitList] // epoch -> [participation]
+	// recent fork tree for debugging
+	forkTree forkTreeHistory
 
 	mu sync.RWMutex
 
@@ -457,6 +459,10 @@ func (f *ForkChoiceStore) Participation(epoch uint64) (*solid.ParticipationBitLi
 func (f *ForkChoiceStore) ForkNodes() []ForkNode {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
+	return f.forkNodes()
+}
+
+func (f *ForkChoiceStore) forkNodes() []ForkNode {
 	forkNodes := make([]ForkNode, 0, len(f.weights))
 	for blockRoot, weight := range f.weights {
 		header, has := f.forkGraph.GetHeader(blockRoot)
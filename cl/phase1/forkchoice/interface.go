@@ -62,6 +62,7 @@ type ForkChoiceStorageReader interface {
 	TotalActiveBalance(root common.Hash) (uint64, bool)
 
 	ForkNodes() []ForkNode
+	ForkTree(epochs uint64) ForkTree
 	Synced() bool
 	GetLightClientBootstrap(blockRoot common.Hash) (*cltypes.LightClientBootstrap, bool)
 	NewestLightClientUpdate() *cltypes.LightClientUpdate
@@ -330,6 +330,15 @@ func (f *ForkChoiceStorageMock) ForkNodes() []forkchoice.ForkNode {
 	return f.WeightsMock
 }
 
+func (f *ForkChoiceStorageMock) ForkTree(epochs uint64) forkchoice.ForkTree {
+	return forkchoice.ForkTree{
+		JustifiedCheckpoint: f.JustifiedCheckpointVal,
+		FinalizedCheckpoint: f.FinalizedCheckpointVal,
+		Nodes:               f.WeightsMock,
+		Slots:               []forkchoice.ForkTreeSlot{},
+	}
+}
+
 func (f *ForkChoiceStorageMock) Synced() bool {
 	return true
 }
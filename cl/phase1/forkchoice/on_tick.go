@@ -40,6 +40,7 @@ func (f *ForkChoiceStore) onTickPerSlot(time uint64) {
 		return
 	}
 	f.mu.Lock()
+	f.recordForkTree(previousSlot)
 	f.headHash = common.Hash{}
 	f.mu.Unlock()
 	// If this is a new slot, reset store.proposer_boost_root
@@ -0,0 +1,134 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"sync"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
+)
+
+type slotProposer struct {
+	slot, proposerIndex uint64
+}
+
+type blockBlobIndex struct {
+	blockRoot common.Hash
+	index     uint64
+}
+
+type equivocationKey struct {
+	slotProposer
+	kind  string
+	index uint64
+}
+
+type blobSidecarSeen struct {
+	header     *cltypes.SignedBeaconBlockHeader
+	commitment common.Bytes48
+}
+
+// blobSidecarEquivocationDetector remembers the first verified sidecar header of every (slot, proposer) and the first
+// commitment of every (block root, blob index) of non-finalized slots and reports sidecars conflicting with them
+type blobSidecarEquivocationDetector struct {
+	mu          sync.Mutex
+	headers     map[slotProposer]*cltypes.SignedBeaconBlockHeader
+	commitments map[blockBlobIndex]blobSidecarSeen
+	reported    map[equivocationKey]struct{}
+}
+
+func newBlobSidecarEquivocationDetector() *blobSidecarEquivocationDetector {
+	return &blobSidecarEquivocationDetector{
+		headers:     map[slotProposer]*cltypes.SignedBeaconBlockHeader{},
+		commitments: map[blockBlobIndex]blobSidecarSeen{},
+		reported:    map[equivocationKey]struct{}{},
+	}
+}
+
+// observe returns the evidence of equivocations of a verified sidecar of block `blockRoot`, each one is reported once
+func (d *blobSidecarEquivocationDetector) observe(msg *cltypes.BlobSidecar, blockRoot common.Hash) []*beacon_indicies.BlobSidecarEquivocation {
+	header := msg.SignedBlockHeader.Header
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var res []*beacon_indicies.BlobSidecarEquivocation
+
+	sp := slotProposer{slot: header.Slot, proposerIndex: header.ProposerIndex}
+	if first, ok := d.headers[sp]; !ok {
+		d.headers[sp] = msg.SignedBlockHeader
+	} else if firstRoot, err := first.Header.HashSSZ(); err == nil && firstRoot != blockRoot {
+		if d.report(beacon_indicies.BlobSidecarEquivocationProposer, sp, 0) {
+			res = append(res, &beacon_indicies.BlobSidecarEquivocation{
+				Kind:          beacon_indicies.BlobSidecarEquivocationProposer,
+				Slot:          header.Slot,
+				ProposerIndex: header.ProposerIndex,
+				Header1:       first,
+				Header2:       msg.SignedBlockHeader,
+			})
+		}
+	}
+
+	bi := blockBlobIndex{blockRoot: blockRoot, index: msg.Index}
+	if first, ok := d.commitments[bi]; !ok {
+		d.commitments[bi] = blobSidecarSeen{header: msg.SignedBlockHeader, commitment: msg.KzgCommitment}
+	} else if first.commitment != msg.KzgCommitment {
+		if d.report(beacon_indicies.BlobSidecarEquivocationCommitment, sp, msg.Index) {
+			res = append(res, &beacon_indicies.BlobSidecarEquivocation{
+				Kind:          beacon_indicies.BlobSidecarEquivocationCommitment,
+				Slot:          header.Slot,
+				ProposerIndex: header.ProposerIndex,
+				Index:         msg.Index,
+				Header1:       first.header,
+				Header2:       msg.SignedBlockHeader,
+				Commitment1:   first.commitment,
+				Commitment2:   msg.KzgCommitment,
+			})
+		}
+	}
+	return res
+}
+
+func (d *blobSidecarEquivocationDetector) report(kind string, sp slotProposer, index uint64) bool {
+	key := equivocationKey{slotProposer: sp, kind: kind, index: index}
+	if _, ok := d.reported[key]; ok {
+		return false
+	}
+	d.reported[key] = struct{}{}
+	return true
+}
+
+// prune forgets sidecars of slots up to the finalized one: they are ignored on gossip
+func (d *blobSidecarEquivocationDetector) prune(finalizedSlot uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for sp := range d.headers {
+		if sp.slot <= finalizedSlot {
+			delete(d.headers, sp)
+		}
+	}
+	for bi, seen := range d.commitments {
+		if seen.header.Header.Slot <= finalizedSlot {
+			delete(d.commitments, bi)
+		}
+	}
+	for key := range d.reported {
+		if key.slot <= finalizedSlot {
+			delete(d.reported, key)
+		}
+	}
+}
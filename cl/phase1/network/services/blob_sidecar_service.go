@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/crypto/kzg"
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/cl/beacon/beaconevents"
 	"github.com/erigontech/erigon/cl/beacon/synced_data"
@@ -35,6 +37,7 @@ import (
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/fork"
 	"github.com/erigontech/erigon/cl/monitor"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
 	"github.com/erigontech/erigon/cl/phase1/forkchoice"
 	"github.com/erigontech/erigon/cl/utils"
@@ -47,6 +50,10 @@ type blobSidecarService struct {
 	syncedDataManager *synced_data.SyncedDataManager
 	ethClock          eth_clock.EthereumClock
 	emitters          *beaconevents.EventEmitter
+	db                kv.RwDB                 // stores evidence of equivocations, nil - not stored
+	proposerSlashings ProposerSlashingService // receives slashings of proposer equivocations, nil - not created
+	equivocations     *blobSidecarEquivocationDetector
+	prunedSlot        atomic.Uint64
 
 	blobSidecarsScheduledForLaterExecution sync.Map
 	test                                   bool
@@ -65,6 +72,8 @@ func NewBlobSidecarService(
 	syncedDataManager *synced_data.SyncedDataManager,
 	ethClock eth_clock.EthereumClock,
 	emitters *beaconevents.EventEmitter,
+	db kv.RwDB,
+	proposerSlashings ProposerSlashingService,
 	test bool,
 ) BlobSidecarsService {
 	b := &blobSidecarService{
@@ -74,6 +83,9 @@ func NewBlobSidecarService(
 		test:              test,
 		ethClock:          ethClock,
 		emitters:          emitters,
+		db:                db,
+		proposerSlashings: proposerSlashings,
+		equivocations:     newBlobSidecarEquivocationDetector(),
 	}
 	// go b.loop(ctx)
 	return b
@@ -153,10 +165,39 @@ func (b *blobSidecarService) verifyAndStoreBlobSidecar(msg *cltypes.BlobSidecar)
 		}
 	}
 	monitor.ObserveBlobVerificationTime(start)
+	b.detectEquivocations(msg)
 	// operation is not thread safe from here.
 	return b.forkchoiceStore.AddPreverifiedBlobSidecar(msg)
 }
 
+// detectEquivocations stores the evidence of equivocations of a verified sidecar and turns conflicting headers of a
+// proposer into a proposer slashing. Errors are logged only: the sidecar itself is valid.
+func (b *blobSidecarService) detectEquivocations(msg *cltypes.BlobSidecar) {
+	if finalizedSlot := b.forkchoiceStore.FinalizedSlot(); b.prunedSlot.Swap(finalizedSlot) != finalizedSlot {
+		b.equivocations.prune(finalizedSlot)
+	}
+	blockRoot, err := msg.SignedBlockHeader.Header.HashSSZ()
+	if err != nil {
+		return
+	}
+	for _, e := range b.equivocations.observe(msg, blockRoot) {
+		log.Warn("[Caplin] blob sidecar equivocation", "kind", e.Kind, "slot", e.Slot, "proposer", e.ProposerIndex, "index", e.Index)
+		if b.db != nil {
+			if err := b.db.Update(context.Background(), func(tx kv.RwTx) error {
+				return beacon_indicies.WriteBlobSidecarEquivocation(tx, e)
+			}); err != nil {
+				log.Warn("[Caplin] failed to store blob sidecar equivocation", "err", err)
+			}
+		}
+		if b.proposerSlashings != nil && e.Kind == beacon_indicies.BlobSidecarEquivocationProposer {
+			slashing := &cltypes.ProposerSlashing{Header1: e.Header1, Header2: e.Header2}
+			if err := b.proposerSlashings.ProcessMessage(context.Background(), nil, slashing); err != nil {
+				log.Debug("[Caplin] proposer slashing of blob sidecar equivocation not accepted", "err", err)
+			}
+		}
+	}
+}
+
 func (b *blobSidecarService) verifySidecarsSignature(header *cltypes.SignedBeaconBlockHeader) error {
 	parentHeader, ok := b.forkchoiceStore.GetHeader(header.Header.ParentRoot)
 	if !ok {
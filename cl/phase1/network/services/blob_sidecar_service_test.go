@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
 	"github.com/erigontech/erigon/cl/phase1/forkchoice/mock_services"
 	"github.com/erigontech/erigon/cl/utils"
@@ -76,7 +77,7 @@ func setupBlobSidecarService(t *testing.T, ctrl *gomock.Controller, test bool) (
 	ethClock := eth_clock.NewMockEthereumClock(ctrl)
 	forkchoiceMock := mock_services.NewForkChoiceStorageMock(t)
 	emitters := beaconevents.NewEventEmitter()
-	blockService := NewBlobSidecarService(ctx2, cfg, forkchoiceMock, syncedDataManager, ethClock, emitters, test)
+	blockService := NewBlobSidecarService(ctx2, cfg, forkchoiceMock, syncedDataManager, ethClock, emitters, nil, nil, test)
 	return blockService, syncedDataManager, ethClock, forkchoiceMock
 }
 
@@ -214,3 +215,43 @@ func TestBlobServiceSuccess(t *testing.T) {
 
 	require.NoError(t, blobService.ProcessMessage(context.Background(), &sn, blobSidecar))
 }
+
+func TestBlobSidecarEquivocationDetector(t *testing.T) {
+	sidecar := func(slot, index uint64, bodyRoot byte, commitment byte) (*cltypes.BlobSidecar, common.Hash) {
+		msg := &cltypes.BlobSidecar{
+			Index: index,
+			SignedBlockHeader: &cltypes.SignedBeaconBlockHeader{
+				Header: &cltypes.BeaconBlockHeader{Slot: slot, ProposerIndex: 7, BodyRoot: common.Hash{bodyRoot}},
+			},
+			KzgCommitment: common.Bytes48{commitment},
+		}
+		root, err := msg.SignedBlockHeader.Header.HashSSZ()
+		require.NoError(t, err)
+		return msg, root
+	}
+	d := newBlobSidecarEquivocationDetector()
+
+	require.Empty(t, d.observe(sidecar(10, 0, 1, 1)))
+	require.Empty(t, d.observe(sidecar(10, 1, 1, 2)))
+	require.Empty(t, d.observe(sidecar(10, 0, 1, 1))) // duplicate
+
+	evidence := d.observe(sidecar(10, 0, 2, 3)) // another block of the proposer
+	require.Len(t, evidence, 1)
+	require.Equal(t, beacon_indicies.BlobSidecarEquivocationProposer, evidence[0].Kind)
+	require.Equal(t, common.Hash{1}, evidence[0].Header1.Header.BodyRoot)
+	require.Equal(t, common.Hash{2}, evidence[0].Header2.Header.BodyRoot)
+	require.Empty(t, d.observe(sidecar(10, 1, 3, 3))) // reported already
+
+	evidence = d.observe(sidecar(10, 1, 1, 4)) // another commitment of the same blob
+	require.Len(t, evidence, 1)
+	require.Equal(t, beacon_indicies.BlobSidecarEquivocationCommitment, evidence[0].Kind)
+	require.Equal(t, uint64(1), evidence[0].Index)
+	require.Equal(t, common.Bytes48{2}, evidence[0].Commitment1)
+	require.Equal(t, common.Bytes48{4}, evidence[0].Commitment2)
+
+	d.prune(10)
+	require.Empty(t, d.headers)
+	require.Empty(t, d.commitments)
+	require.Empty(t, d.reported)
+	require.Empty(t, d.observe(sidecar(10, 0, 2, 3)))
+}
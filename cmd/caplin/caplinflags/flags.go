@@ -17,6 +17,8 @@
 package caplinflags
 
 import (
+	"time"
+
 	"github.com/erigontech/erigon/cmd/utils"
 	"github.com/urfave/cli/v2"
 )
@@ -118,3 +120,61 @@ var (
 		Value: "",
 	}
 )
+
+// MockCLFlags - flags of `caplin mock-cl`
+var MockCLFlags = []cli.Flag{
+	&EngineApiHostFlag,
+	&EngineApiPortFlag,
+	&JwtSecret,
+	&MockCLSlotTimeFlag,
+	&MockCLBuildTimeFlag,
+	&MockCLBlocksFlag,
+	&MockCLFeeRecipientFlag,
+	&MockCLMissEveryFlag,
+	&MockCLReorgEveryFlag,
+	&MockCLReorgDepthFlag,
+	&MockCLInvalidEveryFlag,
+	&MockCLFinalityLagFlag,
+}
+
+var (
+	MockCLSlotTimeFlag = cli.DurationFlag{
+		Name:  "mock-cl.slot-time",
+		Usage: "Time between slots",
+		Value: 12 * time.Second,
+	}
+	MockCLBuildTimeFlag = cli.DurationFlag{
+		Name:  "mock-cl.build-time",
+		Usage: "Time given to the EL to build a payload before getPayload",
+		Value: time.Second,
+	}
+	MockCLBlocksFlag = cli.Uint64Flag{
+		Name:  "mock-cl.blocks",
+		Usage: "Stop after producing this number of blocks, 0 - never",
+	}
+	MockCLFeeRecipientFlag = cli.StringFlag{
+		Name:  "mock-cl.fee-recipient",
+		Usage: "Suggested fee recipient of the payloads",
+	}
+	MockCLMissEveryFlag = cli.Uint64Flag{
+		Name:  "mock-cl.miss-every",
+		Usage: "Every n-th slot is missed (no block), 0 - no missed slots",
+	}
+	MockCLReorgEveryFlag = cli.Uint64Flag{
+		Name:  "mock-cl.reorg-every",
+		Usage: "Every n-th block replaces the last --mock-cl.reorg-depth blocks by another branch, 0 - no reorgs",
+	}
+	MockCLReorgDepthFlag = cli.Uint64Flag{
+		Name:  "mock-cl.reorg-depth",
+		Usage: "Number of blocks replaced by a reorg",
+		Value: 1,
+	}
+	MockCLInvalidEveryFlag = cli.Uint64Flag{
+		Name:  "mock-cl.invalid-every",
+		Usage: "Every n-th block is preceded by a payload with a wrong state root which the EL must reject, 0 - never",
+	}
+	MockCLFinalityLagFlag = cli.Uint64Flag{
+		Name:  "mock-cl.finality-lag",
+		Usage: "Number of blocks the safe and finalized blocks are behind the head, at least --mock-cl.reorg-depth",
+	}
+)
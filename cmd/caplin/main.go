@@ -44,6 +44,7 @@ import (
 
 func main() {
 	app := app.MakeApp("caplin", runCaplinNode, append(caplinflags.CliFlags, sentinelflags.CliFlags...))
+	app.Commands = append(app.Commands, &mockCLCommand)
 	if err := app.Run(os.Args); err != nil {
 		_, printErr := fmt.Fprintln(os.Stderr, err)
 		if printErr != nil {
@@ -0,0 +1,95 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cmd/caplin/caplincli"
+	"github.com/erigontech/erigon/cmd/caplin/caplinflags"
+	"github.com/erigontech/erigon/execution/engineapi"
+	"github.com/erigontech/erigon/execution/engineapi/engine_dev_cl"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var mockCLCommand = cli.Command{
+	Action: runMockCL,
+	Name:   "mock-cl",
+	Usage:  "Drive the engine API of an EL with simulated fork choice: missed slots, reorgs and invalid payloads",
+	Flags:  caplinflags.MockCLFlags,
+	Description: `
+Produces blocks through the engine API of a running EL on top of its current head, without a beacon chain:
+forkchoiceUpdated with payload attributes, getPayload, newPayload and forkchoiceUpdated of the new head every slot.
+--mock-cl.* flags add missed slots, reorgs and invalid payloads (with a wrong state root) to validate the setup of
+the EL or to load test it. The chain must have Cancun activated. Fails if the EL didn't reject an invalid payload.`,
+}
+
+func runMockCL(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	jwtSecret, err := caplincli.ObtainJwtSecret(cliCtx)
+	if err != nil {
+		return fmt.Errorf("jwt secret: %w", err)
+	}
+	host := cliCtx.String(caplinflags.EngineApiHostFlag.Name)
+	if !strings.HasPrefix(host, "http") {
+		host = "http://" + host
+	}
+	engine, err := engineapi.DialJsonRpcClient(fmt.Sprintf("%s:%d", host, cliCtx.Uint(caplinflags.EngineApiPortFlag.Name)), jwtSecret, logger)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	head, err := engine.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return fmt.Errorf("head of the EL: %w", err)
+	}
+
+	cl := engine_dev_cl.New(engine_dev_cl.Config{
+		FeeRecipient: common.HexToAddress(cliCtx.String(caplinflags.MockCLFeeRecipientFlag.Name)),
+		Period:       cliCtx.Duration(caplinflags.MockCLSlotTimeFlag.Name),
+		BuildTime:    cliCtx.Duration(caplinflags.MockCLBuildTimeFlag.Name),
+		MaxBlocks:    cliCtx.Uint64(caplinflags.MockCLBlocksFlag.Name),
+		Scenario: engine_dev_cl.Scenario{
+			MissEvery:    cliCtx.Uint64(caplinflags.MockCLMissEveryFlag.Name),
+			ReorgEvery:   cliCtx.Uint64(caplinflags.MockCLReorgEveryFlag.Name),
+			ReorgDepth:   cliCtx.Uint64(caplinflags.MockCLReorgDepthFlag.Name),
+			InvalidEvery: cliCtx.Uint64(caplinflags.MockCLInvalidEveryFlag.Name),
+			FinalityLag:  cliCtx.Uint64(caplinflags.MockCLFinalityLagFlag.Name),
+		},
+	}, engine, head, logger)
+	err = cl.Run(ctx)
+	stats := cl.Stats()
+	logger.Info("[mock-cl] done", "blocks", stats.Blocks, "missedSlots", stats.MissedSlots, "reorgs", stats.Reorgs,
+		"invalidRejected", stats.InvalidRejected, "invalidAccepted", stats.InvalidAccepted)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	if stats.InvalidAccepted > 0 {
+		return fmt.Errorf("EL didn't reject %d invalid payloads", stats.InvalidAccepted)
+	}
+	return nil
+}
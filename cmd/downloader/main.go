@@ -37,7 +37,6 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
@@ -53,6 +52,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/dir"
 	"github.com/erigontech/erigon-lib/common/paths"
 	proto_downloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/mdbx"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -91,6 +91,8 @@ var (
 	_verifyFiles                   string
 	verifyFiles                    []string
 	downloaderApiAddr              string
+	tlsCertFile, tlsKeyFile        string
+	tlsCACert, authTokenFile       string
 	natSetting                     string
 	torrentVerbosity               int
 	downloadRateStr, uploadRateStr string
@@ -117,6 +119,10 @@ func init() {
 	rootCmd.Flags().StringVar(&webseeds, utils.WebSeedsFlag.Name, utils.WebSeedsFlag.Value, utils.WebSeedsFlag.Usage)
 	rootCmd.Flags().StringVar(&natSetting, "nat", utils.NATFlag.Value, utils.NATFlag.Usage)
 	rootCmd.Flags().StringVar(&downloaderApiAddr, "downloader.api.addr", "127.0.0.1:9093", "external downloader api network address, for example: 127.0.0.1:9093 serves remote downloader interface")
+	rootCmd.Flags().StringVar(&tlsCertFile, "tls.cert", "", "certificate for server side TLS handshake for GRPC")
+	rootCmd.Flags().StringVar(&tlsKeyFile, "tls.key", "", "key file for server side TLS handshake for GRPC")
+	rootCmd.Flags().StringVar(&tlsCACert, "tls.cacert", "", "CA certificate of GRPC clients: client certificates are required")
+	rootCmd.Flags().StringVar(&authTokenFile, "grpc.auth.tokenfile", "", "file with tokens (one per line) authenticating GRPC calls, re-read on change")
 	rootCmd.Flags().StringVar(&downloadRateStr, "torrent.download.rate", utils.TorrentDownloadRateFlag.Value, utils.TorrentDownloadRateFlag.Usage)
 	rootCmd.Flags().StringVar(&uploadRateStr, "torrent.upload.rate", utils.TorrentUploadRateFlag.Value, utils.TorrentUploadRateFlag.Usage)
 	rootCmd.Flags().IntVar(&torrentVerbosity, "torrent.verbosity", utils.TorrentVerbosityFlag.Value, utils.TorrentVerbosityFlag.Usage)
@@ -345,7 +351,11 @@ func Downloader(ctx context.Context, logger log.Logger) error {
 		}
 	}
 
-	grpcServer, err := StartGrpc(bittorrentServer, downloaderApiAddr, nil /* transportCredentials */, logger)
+	sec, err := grpcutil.NewSecurity(tlsCACert, tlsCertFile, tlsKeyFile, authTokenFile)
+	if err != nil {
+		return err
+	}
+	grpcServer, err := StartGrpc(bittorrentServer, downloaderApiAddr, sec, logger)
 	if err != nil {
 		return err
 	}
@@ -657,7 +667,7 @@ func doPrintTorrentHashes(ctx context.Context, logger log.Logger) error {
 	return nil
 }
 
-func StartGrpc(snServer *downloader.GrpcServer, addr string, creds *credentials.TransportCredentials, logger log.Logger) (*grpc.Server, error) {
+func StartGrpc(snServer *downloader.GrpcServer, addr string, sec *grpcutil.Security, logger log.Logger) (*grpc.Server, error) {
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return nil, fmt.Errorf("could not create listener: %w, addr=%s", err, addr)
@@ -684,11 +694,7 @@ func StartGrpc(snServer *downloader.GrpcServer, addr string, creds *credentials.
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 	}
-	if creds == nil {
-		// no specific opts
-	} else {
-		opts = append(opts, grpc.Creds(*creds))
-	}
+	opts = append(opts, sec.ServerOptions()...)
 	grpcServer := grpc.NewServer(opts...)
 	reflection.Register(grpcServer) // Register reflection service on gRPC server.
 	if snServer != nil {
@@ -0,0 +1,218 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/execution/testutil"
+	"github.com/erigontech/erigon/tests"
+)
+
+var blockTestCommand = cli.Command{
+	Action:    blockTestCmd,
+	Name:      "blocktest",
+	Usage:     "executes the given blockchain tests",
+	ArgsUsage: "<file>",
+}
+
+// BlocktestResult contains the result of importing the blocks of a blockchain test and validating the
+// post state. Skipped tests use a fork this build doesn't support.
+type BlocktestResult struct {
+	Name    string `json:"name"`
+	Pass    bool   `json:"pass"`
+	Skipped bool   `json:"skipped,omitempty"`
+	Fork    string `json:"fork"`
+	Error   string `json:"error,omitempty"`
+}
+
+func blockTestCmd(ctx *cli.Context) error {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlWarn, log.StderrHandler))
+	if len(ctx.Args().First()) != 0 {
+		return runBlockTest(ctx.Args().First())
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fname := scanner.Text()
+		if len(fname) == 0 {
+			return nil
+		}
+		if err := runBlockTest(fname); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runBlockTest loads the blockchain-test given by fname, and executes the test.
+func runBlockTest(fname string) error {
+	src, err := os.ReadFile(fname)
+	if err != nil {
+		return err
+	}
+	var blockTests map[string]*tests.BlockTest
+	if err = json.Unmarshal(src, &blockTests); err != nil {
+		return err
+	}
+	out, _ := json.MarshalIndent(aggregateResultsFromBlockTests(blockTests), "", "  ")
+	fmt.Println(string(out))
+	return nil
+}
+
+func aggregateResultsFromBlockTests(blockTests map[string]*tests.BlockTest) []BlocktestResult {
+	results := make([]BlocktestResult, 0, len(blockTests))
+	for name, test := range blockTests {
+		result := BlocktestResult{Name: name, Fork: test.Network(), Pass: true}
+		err := runWithTB(name, func(tb testing.TB) error { return test.Run(tb, true) })
+		var unsupported testutil.UnsupportedForkError
+		if errors.As(err, &unsupported) {
+			result.Skipped = true
+		}
+		if err != nil {
+			result.Pass, result.Error = false, err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// runWithTB - runs f outside of `go test` with a testing.TB owning temporary directories and cleanups of the test,
+// as test helpers (e.g. mock sentry) expect. Like in `go test`, Fatal and FailNow stop f's goroutine.
+func runWithTB(name string, f func(tb testing.TB) error) (err error) {
+	tb := &cliTB{name: name}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if rec := recover(); rec != nil {
+				tb.Errorf("panic: %v", rec)
+			}
+		}()
+		err = f(tb)
+	}()
+	<-done
+	tb.cleanup()
+	if err == nil && tb.Failed() {
+		return errors.New(strings.Join(tb.errors, "; "))
+	}
+	return err
+}
+
+// cliTB - testing.TB of runWithTB. testing.TB is embedded only to satisfy its private method: it's nil
+type cliTB struct {
+	testing.TB
+	name string
+
+	mu       sync.Mutex
+	errors   []string
+	failed   bool
+	skipped  bool
+	tempDirs []string
+	cleanups []func()
+}
+
+func (t *cliTB) cleanup() {
+	t.mu.Lock()
+	cleanups := t.cleanups
+	t.cleanups = nil
+	t.mu.Unlock()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+	for _, dir := range t.tempDirs {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func (t *cliTB) Cleanup(f func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *cliTB) TempDir() string {
+	dir, err := os.MkdirTemp("", "erigon-blocktest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tempDirs = append(t.tempDirs, dir)
+	return dir
+}
+
+func (t *cliTB) Fail() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failed = true
+}
+
+func (t *cliTB) Failed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failed
+}
+
+func (t *cliTB) FailNow() {
+	t.Fail()
+	runtime.Goexit()
+}
+
+func (t *cliTB) Error(args ...any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failed = true
+	t.errors = append(t.errors, strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (t *cliTB) Errorf(format string, args ...any) { t.Error(fmt.Sprintf(format, args...)) }
+func (t *cliTB) Fatal(args ...any)                 { t.Error(args...); runtime.Goexit() }
+func (t *cliTB) Fatalf(format string, args ...any) { t.Errorf(format, args...); runtime.Goexit() }
+func (t *cliTB) Log(args ...any)                   { log.Debug("[blocktest] "+t.name, "msg", fmt.Sprint(args...)) }
+func (t *cliTB) Logf(format string, args ...any)   { t.Log(fmt.Sprintf(format, args...)) }
+func (t *cliTB) Helper()                           {}
+func (t *cliTB) Name() string                      { return t.name }
+func (t *cliTB) Context() context.Context          { return context.Background() }
+func (t *cliTB) Setenv(key, value string)          { t.Fatal("Setenv is not supported") }
+func (t *cliTB) Chdir(dir string)                  { t.Fatal("Chdir is not supported") }
+
+func (t *cliTB) Skip(args ...any)                 { t.Log(args...); t.SkipNow() }
+func (t *cliTB) Skipf(format string, args ...any) { t.Logf(format, args...); t.SkipNow() }
+func (t *cliTB) SkipNow() {
+	t.mu.Lock()
+	t.skipped = true
+	t.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (t *cliTB) Skipped() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.skipped
+}
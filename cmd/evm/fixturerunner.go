@@ -0,0 +1,242 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/testutil"
+	"github.com/erigontech/erigon/tests"
+)
+
+const (
+	FixtureKindState      = "state"
+	FixtureKindBlockchain = "blockchain"
+	FixtureKindEOF        = "eof"
+)
+
+var FixtureRunFlag = cli.StringFlag{
+	Name:  "run",
+	Usage: "run only tests with names matching the regular expression",
+}
+
+var fixtureTestCommand = cli.Command{
+	Action:    fixtureTestCmd,
+	Name:      "fixturetest",
+	Usage:     "executes ethereum/tests and execution-spec-tests fixtures (state, blockchain and EOF tests) found in given files and directories",
+	ArgsUsage: "<file or directory>...",
+	Flags:     []cli.Flag{&FixtureRunFlag},
+	Description: `Prints one JSON object per executed test (fork) to stdout and a summary to stderr.
+Fixture kind is detected from the file's content. Fails if any test failed.`,
+}
+
+// FixtureResult - outcome of one test of a fixture file, for a single fork. Skipped tests use a fork this build
+// doesn't support, or a kind of test it can't run (EOF is not implemented).
+type FixtureResult struct {
+	File    string `json:"file"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Fork    string `json:"fork"`
+	Pass    bool   `json:"pass"`
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type fixtureSummary struct {
+	Passed, Failed, Skipped int
+}
+
+func (s *fixtureSummary) add(r FixtureResult) {
+	switch {
+	case r.Skipped:
+		s.Skipped++
+	case r.Pass:
+		s.Passed++
+	default:
+		s.Failed++
+	}
+}
+
+func fixtureTestCmd(ctx *cli.Context) error {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlError, log.StderrHandler))
+	if ctx.NArg() == 0 {
+		return errors.New("no fixture files or directories given")
+	}
+	var filter *regexp.Regexp
+	if pattern := ctx.String(FixtureRunFlag.Name); pattern != "" {
+		var err error
+		if filter, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid --%s: %w", FixtureRunFlag.Name, err)
+		}
+	}
+
+	var files []string
+	for _, path := range ctx.Args().Slice() {
+		if err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && filepath.Ext(path) == ".json" {
+				files = append(files, path)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	var summary fixtureSummary
+	enc := json.NewEncoder(os.Stdout)
+	for _, file := range files {
+		results, err := runFixtureFile(file, filter)
+		if err != nil {
+			results = []FixtureResult{{File: file, Error: err.Error()}}
+		}
+		for _, r := range results {
+			summary.add(r)
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+	}
+	fmt.Fprintf(os.Stderr, "files: %d, passed: %d, failed: %d, skipped: %d\n", len(files), summary.Passed, summary.Failed, summary.Skipped)
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d tests failed", summary.Failed)
+	}
+	return nil
+}
+
+// fixtureKind - detects kind of fixture file by fields of its tests
+func fixtureKind(tests map[string]json.RawMessage) (string, error) {
+	for _, test := range tests {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(test, &fields); err != nil {
+			return "", err
+		}
+		switch {
+		case fields["blocks"] != nil:
+			return FixtureKindBlockchain, nil
+		case fields["transaction"] != nil && fields["post"] != nil:
+			return FixtureKindState, nil
+		case fields["vectors"] != nil:
+			return FixtureKindEOF, nil
+		}
+	}
+	return "", errors.New("unknown fixture format: expected state, blockchain or EOF tests")
+}
+
+func runFixtureFile(file string, filter *regexp.Regexp) ([]FixtureResult, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(src, &raw); err != nil {
+		return nil, err
+	}
+	for name := range raw {
+		if filter != nil && !filter.MatchString(name) {
+			delete(raw, name)
+		}
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	kind, err := fixtureKind(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []FixtureResult
+	switch kind {
+	case FixtureKindState:
+		stateTests := make(map[string]tests.StateTest, len(raw))
+		for name := range raw {
+			var test tests.StateTest
+			if err := json.Unmarshal(raw[name], &test); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			stateTests[name] = test
+		}
+		stResults, err := aggregateResultsFromStateTests(stateTests, vm.Config{}, false, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range stResults {
+			_, supported := testutil.Forks[r.Fork]
+			results = append(results, FixtureResult{Name: r.Name, Fork: r.Fork, Pass: r.Pass, Skipped: !supported, Error: r.Error})
+		}
+	case FixtureKindBlockchain:
+		blockTests := make(map[string]*tests.BlockTest, len(raw))
+		for name := range raw {
+			test := new(tests.BlockTest)
+			if err := json.Unmarshal(raw[name], test); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			blockTests[name] = test
+		}
+		for _, r := range aggregateResultsFromBlockTests(blockTests) {
+			results = append(results, FixtureResult{Name: r.Name, Fork: r.Fork, Pass: r.Pass, Skipped: r.Skipped, Error: r.Error})
+		}
+	case FixtureKindEOF:
+		for name, test := range raw {
+			var forks []string
+			var eofTest struct {
+				Vectors map[string]struct {
+					Results map[string]json.RawMessage `json:"results"`
+				} `json:"vectors"`
+			}
+			if err := json.Unmarshal(test, &eofTest); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			for _, v := range eofTest.Vectors {
+				for fork := range v.Results {
+					if !slices.Contains(forks, fork) {
+						forks = append(forks, fork)
+					}
+				}
+			}
+			for _, fork := range forks {
+				results = append(results, FixtureResult{Name: name, Fork: fork, Skipped: true, Error: "EOF is not supported"})
+			}
+		}
+	}
+
+	for i := range results {
+		results[i].File, results[i].Kind = file, kind
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		return strings.Compare(results[i].Fork, results[j].Fork) < 0
+	})
+	return results, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunFixtureFile(t *testing.T) {
+	// state test with zeroed expected state root
+	results, err := runFixtureFile("./testdata/statetest.json", nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, FixtureKindState, results[0].Kind)
+	require.Equal(t, "London", results[0].Fork)
+	require.False(t, results[0].Pass)
+	require.Contains(t, results[0].Error, "post state root mismatch")
+
+	results, err = runFixtureFile("./testdata/statetest.json", regexp.MustCompile("^other"))
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	dir := t.TempDir()
+	eofFile := filepath.Join(dir, "eof.json")
+	require.NoError(t, os.WriteFile(eofFile, []byte(`{"t":{"vectors":{"v0":{"code":"0xef00","results":{"Osaka":{"result":true}}}}}}`), 0o644))
+	results, err = runFixtureFile(eofFile, nil)
+	require.NoError(t, err)
+	require.Equal(t, []FixtureResult{{File: eofFile, Name: "t", Kind: FixtureKindEOF, Fork: "Osaka", Skipped: true, Error: "EOF is not supported"}}, results)
+
+	btFile := filepath.Join(dir, "bt.json")
+	require.NoError(t, os.WriteFile(btFile, []byte(`{"t":{"network":"NoSuchFork","genesisBlockHeader":{},"pre":{},"blocks":[]}}`), 0o644))
+	results, err = runFixtureFile(btFile, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, FixtureKindBlockchain, results[0].Kind)
+	require.True(t, results[0].Skipped)
+
+	unknownFile := filepath.Join(dir, "unknown.json")
+	require.NoError(t, os.WriteFile(unknownFile, []byte(`{"t":{"foo":1}}`), 0o644))
+	_, err = runFixtureFile(unknownFile, nil)
+	require.ErrorContains(t, err, "unknown fixture format")
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/eth/tracers/logger"
+	"github.com/erigontech/erigon/execution/chainspec"
+	"github.com/erigontech/erigon/execution/consensus/ethash"
+	"github.com/erigontech/erigon/execution/consensus/merge"
+	"github.com/erigontech/erigon/execution/forkstate"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+)
+
+var (
+	ForkRPCFlag = cli.StringFlag{
+		Name:     "rpc",
+		Usage:    "URL of the JSON-RPC endpoint of the archive node to fork from",
+		Required: true,
+	}
+	ForkBlockFlag = cli.StringFlag{
+		Name:  "block",
+		Usage: "number, hash or tag of the block to fork at, the call runs on its post-state",
+		Value: "latest",
+	}
+	ForkChainFlag = cli.StringFlag{
+		Name:  "chain",
+		Usage: "name of the chain (chain config source)",
+		Value: "mainnet",
+	}
+	ForkCallFlag = cli.StringFlag{
+		Name:     "call",
+		Usage:    `JSON object with the arguments of the call, as of eth_call: {"from":..,"to":..,"input":..}`,
+		Required: true,
+	}
+	ForkVerifyProofsFlag = cli.BoolFlag{
+		Name:  "verify-proofs",
+		Usage: "verify the accounts fetched from the remote node against the state root of the block",
+	}
+)
+
+var forkCallCommand = cli.Command{
+	Action: forkCallCmd,
+	Name:   "forkcall",
+	Usage:  "executes a call on the state of a block of a remote archive node, fetched lazily over JSON-RPC",
+	Flags: []cli.Flag{
+		&ForkRPCFlag,
+		&ForkBlockFlag,
+		&ForkChainFlag,
+		&ForkCallFlag,
+		&ForkVerifyProofsFlag,
+		&MachineFlag,
+		&DebugFlag,
+		&DisableMemoryFlag,
+		&DisableStackFlag,
+		&DisableStorageFlag,
+		&DisableReturnDataFlag,
+	},
+}
+
+type forkCallResult struct {
+	Block   common.Hash    `json:"block"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Return  hexutil.Bytes  `json:"return"`
+	Error   string         `json:"error,omitempty"`
+}
+
+func forkCallCmd(ctx *cli.Context) error {
+	chainConfig := chainspec.ChainConfigByChainName(ctx.String(ForkChainFlag.Name))
+	if chainConfig == nil {
+		return fmt.Errorf("unknown chain %s", ctx.String(ForkChainFlag.Name))
+	}
+	var block rpc.BlockNumberOrHash
+	if err := block.UnmarshalJSON([]byte(`"` + ctx.String(ForkBlockFlag.Name) + `"`)); err != nil {
+		return fmt.Errorf("block: %w", err)
+	}
+	var args ethapi.CallArgs
+	if err := json.Unmarshal([]byte(ctx.String(ForkCallFlag.Name)), &args); err != nil {
+		return fmt.Errorf("call: %w", err)
+	}
+
+	logconfig := &logger.LogConfig{
+		DisableMemory:     ctx.Bool(DisableMemoryFlag.Name),
+		DisableStack:      ctx.Bool(DisableStackFlag.Name),
+		DisableStorage:    ctx.Bool(DisableStorageFlag.Name),
+		DisableReturnData: ctx.Bool(DisableReturnDataFlag.Name),
+		Debug:             ctx.Bool(DebugFlag.Name),
+	}
+	var (
+		hooks       *tracing.Hooks
+		debugLogger *logger.StructLogger
+	)
+	if ctx.Bool(MachineFlag.Name) {
+		hooks = logger.NewJSONLogger(logconfig, os.Stderr).Tracer().Hooks
+	} else if ctx.Bool(DebugFlag.Name) {
+		debugLogger = logger.NewStructLogger(logconfig)
+		hooks = debugLogger.Tracer().Hooks
+	}
+
+	client, err := rpc.DialContext(ctx.Context, ctx.String(ForkRPCFlag.Name), log.New())
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// Merge engine can be used for pre-merge blocks as well, as it
+	// redirects to the ethash engine based on the block number
+	engine := merge.New(&ethash.FakeEthash{})
+	fork, err := forkstate.New(ctx.Context, client, chainConfig, engine, block, ctx.Bool(ForkVerifyProofsFlag.Name))
+	if err != nil {
+		return err
+	}
+	res, err := fork.Call(ctx.Context, args, hooks, false /* commit */)
+	if err != nil {
+		return err
+	}
+	if debugLogger != nil {
+		logger.WriteTrace(os.Stderr, debugLogger.StructLogs())
+	}
+
+	out := forkCallResult{
+		Block:   fork.Header().Hash(),
+		GasUsed: hexutil.Uint64(res.GasUsed),
+		Return:  res.Return(),
+	}
+	if res.Err != nil {
+		out.Error = res.Err.Error()
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
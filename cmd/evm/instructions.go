@@ -0,0 +1,68 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/tests"
+)
+
+var instructionsCommand = cli.Command{
+	Action:    instructionsCmd,
+	Name:      "instructions",
+	Usage:     "lists the instruction set of a fork, or the difference between the instruction sets of two forks",
+	ArgsUsage: "<fork> [<fork>]",
+	Description: `The fork is a fork name with optional extra EIPs, e.g. "Cancun" or "Shanghai+1153".
+Prints the active opcodes with their gas costs and stack requirements as json.
+With two forks prints the opcodes added, removed and changed by the second one.`,
+}
+
+func instructionsCmd(ctx *cli.Context) error {
+	if ctx.NArg() < 1 || ctx.NArg() > 2 {
+		return errors.New("expected one or two forks")
+	}
+	var sets [][]vm.OperationInfo
+	for _, fork := range ctx.Args().Slice() {
+		cfg, eips, err := tests.GetChainConfig(fork)
+		if err != nil {
+			return err
+		}
+		ops, err := vm.DescribeJumpTable(cfg.Rules(0, 0), eips...)
+		if err != nil {
+			return fmt.Errorf("fork %s: %w", fork, err)
+		}
+		sets = append(sets, ops)
+	}
+	var out []byte
+	var err error
+	if len(sets) == 1 {
+		out, err = json.MarshalIndent(sets[0], "", "  ")
+	} else {
+		out, err = json.MarshalIndent(vm.DiffJumpTables(sets[0], sets[1]), "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
@@ -187,9 +187,14 @@ func init() {
 	app.Commands = []*cli.Command{
 		&compileCommand,
 		&disasmCommand,
+		&instructionsCommand,
 		&runCommand,
 		&stateTestCommand,
+		&blockTestCommand,
+		&fixtureTestCommand,
 		&stateTransitionCommand,
+		&verifyWitnessCommand,
+		&forkCallCommand,
 	}
 }
 
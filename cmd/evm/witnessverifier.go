@@ -0,0 +1,166 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/execution/chainspec"
+	"github.com/erigontech/erigon/execution/consensus/ethash"
+	"github.com/erigontech/erigon/execution/consensus/merge"
+	"github.com/erigontech/erigon/execution/stateless"
+)
+
+var (
+	WitnessChainFlag = cli.StringFlag{
+		Name:  "chain",
+		Usage: "name of the chain (chain config source)",
+		Value: "mainnet",
+	}
+	WitnessBlockFlag = cli.StringFlag{
+		Name:     "block",
+		Usage:    "file with RLP-encoded block to verify",
+		Required: true,
+	}
+	WitnessParentFlag = cli.StringFlag{
+		Name:     "parent",
+		Usage:    "file with RLP-encoded parent header",
+		Required: true,
+	}
+	WitnessFileFlag = cli.StringFlag{
+		Name:     "witness",
+		Usage:    "file with witness of parent state: binary, 0x-prefixed hex or JSON-RPC response of eth_getWitness",
+		Required: true,
+	}
+	WitnessAncestorsFlag = cli.StringFlag{
+		Name:  "ancestors",
+		Usage: "optional file with RLP-encoded ancestor headers (concatenated), required for BLOCKHASH",
+	}
+)
+
+var verifyWitnessCommand = cli.Command{
+	Action: verifyWitnessCmd,
+	Name:   "verifywitness",
+	Usage:  "verifies a block using only its execution witness (stateless verifier)",
+	Flags: []cli.Flag{
+		&WitnessChainFlag,
+		&WitnessBlockFlag,
+		&WitnessParentFlag,
+		&WitnessFileFlag,
+		&WitnessAncestorsFlag,
+	},
+}
+
+func verifyWitnessCmd(ctx *cli.Context) error {
+	logger := log.New()
+	chainConfig := chainspec.ChainConfigByChainName(ctx.String(WitnessChainFlag.Name))
+	if chainConfig == nil {
+		return fmt.Errorf("unknown chain %s", ctx.String(WitnessChainFlag.Name))
+	}
+
+	var in stateless.Input
+	var err error
+	in.Block = new(types.Block)
+	if err = decodeRLPFile(ctx.String(WitnessBlockFlag.Name), in.Block); err != nil {
+		return fmt.Errorf("block: %w", err)
+	}
+	in.Parent = new(types.Header)
+	if err = decodeRLPFile(ctx.String(WitnessParentFlag.Name), in.Parent); err != nil {
+		return fmt.Errorf("parent: %w", err)
+	}
+	if in.Witness, err = readWitnessFile(ctx.String(WitnessFileFlag.Name)); err != nil {
+		return fmt.Errorf("witness: %w", err)
+	}
+	if ancestorsFile := ctx.String(WitnessAncestorsFlag.Name); ancestorsFile != "" {
+		data, err := os.ReadFile(ancestorsFile)
+		if err != nil {
+			return err
+		}
+		stream := rlp.NewStream(bytes.NewReader(data), 0)
+		for {
+			h := new(types.Header)
+			if err := stream.Decode(h); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return fmt.Errorf("ancestors: %w", err)
+			}
+			in.Ancestors = append(in.Ancestors, h)
+		}
+	}
+
+	// Merge engine can be used for pre-merge blocks as well, as it
+	// redirects to the ethash engine based on the block number
+	engine := merge.New(&ethash.FakeEthash{})
+	res, verifyErr := stateless.Verify(chainConfig, engine, in, logger)
+	if res != nil {
+		out, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	}
+	return verifyErr
+}
+
+func decodeRLPFile(fileName string, v interface{}) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	return rlp.DecodeBytes(data, v)
+}
+
+// readWitnessFile - accepts binary witness as well as eth_getWitness output saved as is:
+// a hex string, a JSON string or a whole JSON-RPC response
+func readWitnessFile(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	text := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(text, []byte("{")):
+		var resp struct {
+			Result hexutil.Bytes `json:"result"`
+		}
+		if err := json.Unmarshal(text, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Result, nil
+	case bytes.HasPrefix(text, []byte(`"`)):
+		var w hexutil.Bytes
+		if err := json.Unmarshal(text, &w); err != nil {
+			return nil, err
+		}
+		return w, nil
+	case bytes.HasPrefix(text, []byte("0x")):
+		return hexutil.Decode(string(text))
+	}
+	return data, nil
+}
@@ -1251,6 +1251,7 @@ func newSync(ctx context.Context, db kv.TemporalRwDB, miningConfig *params.Minin
 		chainConfig,
 		genesisBlock,
 		chainConfig.ChainID.Uint64(),
+		0, /* earliestBlock */
 		logger,
 	)
 
@@ -68,6 +68,7 @@ import (
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/core/vm/evmtypes"
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/eth/ethconfig/features"
@@ -101,7 +102,8 @@ var rootCmd = &cobra.Command{
 }
 
 var (
-	stateCacheStr string
+	stateCacheStr       string
+	opcodeOverridesFile string
 )
 
 type HeimdallReader interface {
@@ -126,6 +128,7 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().Uint64Var(&cfg.MaxTraces, "trace.maxtraces", 200, "Sets a limit on traces that can be returned in trace_filter")
 
 	rootCmd.PersistentFlags().StringVar(&cfg.RpcAllowListFilePath, utils.RpcAccessListFlag.Name, "", "Specify granular (method-by-method) API allowlist")
+	rootCmd.PersistentFlags().StringVar(&cfg.RpcAPIKeysFilePath, utils.RpcAPIKeysFlag.Name, "", utils.RpcAPIKeysFlag.Usage)
 	rootCmd.PersistentFlags().UintVar(&cfg.RpcBatchConcurrency, utils.RpcBatchConcurrencyFlag.Name, 2, utils.RpcBatchConcurrencyFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.RpcStreamingDisable, utils.RpcStreamingDisableFlag.Name, false, utils.RpcStreamingDisableFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.DebugSingleRequest, utils.HTTPDebugSingleFlag.Name, false, utils.HTTPDebugSingleFlag.Usage)
@@ -142,6 +145,7 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().StringVar(&cfg.TLSCertfile, "tls.cert", "", "certificate for client side TLS handshake for GRPC")
 	rootCmd.PersistentFlags().StringVar(&cfg.TLSKeyFile, "tls.key", "", "key file for client side TLS handshake for GRPC")
 	rootCmd.PersistentFlags().StringVar(&cfg.TLSCACert, "tls.cacert", "", "CA certificate for client side TLS handshake for GRPC")
+	rootCmd.PersistentFlags().StringVar(&cfg.GRPCAuthTokenFile, "grpc.auth.tokenfile", "", "file with tokens (one per line) authenticating GRPC calls, re-read on change")
 
 	rootCmd.PersistentFlags().StringSliceVar(&cfg.API, "http.api", []string{"eth", "erigon"}, "API's offered over the RPC interface: eth,erigon,web3,net,debug,trace,txpool,db. Supported methods: https://github.com/erigontech/erigon/tree/main/cmd/rpcdaemon")
 
@@ -151,10 +155,16 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().StringVar(&cfg.HttpURL, "http.url", "", "HTTP server listening url. will OVERRIDE http.addr and http.port. will NOT respect http paths. prefix supported are tcp, unix")
 	rootCmd.PersistentFlags().StringSliceVar(&cfg.HttpCORSDomain, "http.corsdomain", []string{}, "Comma separated list of domains from which to accept cross origin requests (browser enforced)")
 	rootCmd.PersistentFlags().StringSliceVar(&cfg.HttpVirtualHost, "http.vhosts", nodecfg.DefaultConfig.HTTPVirtualHosts, "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.")
+	rootCmd.PersistentFlags().StringVar(&cfg.HttpCertfile, utils.HTTPTLSCertFlag.Name, "", utils.HTTPTLSCertFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.HttpKeyFile, utils.HTTPTLSKeyFlag.Name, "", utils.HTTPTLSKeyFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.HttpCompression, "http.compression", true, "Enable http compression enabled by default. Use --http.compression=false to disable it")
 	rootCmd.PersistentFlags().BoolVar(&cfg.WebsocketEnabled, "ws", false, "Enable Websockets - Same port as HTTP[S]")
 	rootCmd.PersistentFlags().BoolVar(&cfg.WebsocketCompression, "ws.compression", true, "Enable Websocket compression (RFC 7692) enabled by default is Websockets is enabled. Use --ws.compression=false to disable it")
 	rootCmd.PersistentFlags().IntVar(&cfg.WebsocketPort, "ws.port", nodecfg.DefaultWSPort, "rpc WebSocket server listening port")
+	rootCmd.PersistentFlags().StringSliceVar(&cfg.WebsocketOrigins, utils.WSAllowedOriginsFlag.Name, []string{utils.WSAllowedOriginsFlag.Value}, utils.WSAllowedOriginsFlag.Usage)
+	rootCmd.PersistentFlags().StringSliceVar(&cfg.WebsocketVirtualHost, utils.WSVirtualHostsFlag.Name, []string{}, utils.WSVirtualHostsFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.WebsocketCertfile, utils.WSTLSCertFlag.Name, "", utils.WSTLSCertFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.WebsocketKeyFile, utils.WSTLSKeyFlag.Name, "", utils.WSTLSKeyFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.HttpsServerEnabled, "https.enabled", false, "Enable HTTPS server")
 	rootCmd.PersistentFlags().StringVar(&cfg.HttpsListenAddress, "https.addr", nodecfg.DefaultHTTPHost, "rpc HTTPS server listening interface")
 	rootCmd.PersistentFlags().IntVar(&cfg.HttpsPort, "https.port", 0, "rpc HTTPS server listening port. default to http.port + 363 if not set")
@@ -170,6 +180,9 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().DurationVar(&cfg.HTTPTimeouts.WriteTimeout, "http.timeouts.write", rpccfg.DefaultHTTPTimeouts.WriteTimeout, "Maximum duration before timing out writes of the response. It is reset whenever a new request's header is read")
 	rootCmd.PersistentFlags().DurationVar(&cfg.HTTPTimeouts.IdleTimeout, "http.timeouts.idle", rpccfg.DefaultHTTPTimeouts.IdleTimeout, "Maximum amount of time to wait for the next request when keep-alives are enabled. If http.timeouts.idle is zero, the value of http.timeouts.read is used")
 	rootCmd.PersistentFlags().DurationVar(&cfg.EvmCallTimeout, "rpc.evmtimeout", rpccfg.DefaultEvmCallTimeout, "Maximum amount of time to wait for the answer from EVM call.")
+	rootCmd.PersistentFlags().Uint64Var(&cfg.EvmMaxMemoryMB, "rpc.evm.maxmemory", 0, "Maximum memory (MB) of a single call frame of eth_call, eth_estimateGas and eth_callMany, independent of gas (0 = unlimited)")
+	rootCmd.PersistentFlags().StringVar(&opcodeOverridesFile, utils.OpcodeOverridesFlag.Name, "", utils.OpcodeOverridesFlag.Usage)
+	rootCmd.PersistentFlags().BoolVar(&cfg.TxLookupNonCanonical, "rpc.txlookup.noncanonical", false, "eth_getTransactionByHash: for a transaction of a block removed from the canonical chain by reorg, return an error with the block number and hash instead of null")
 	rootCmd.PersistentFlags().DurationVar(&cfg.OverlayGetLogsTimeout, "rpc.overlay.getlogstimeout", rpccfg.DefaultOverlayGetLogsTimeout, "Maximum amount of time to wait for the answer from the overlay_getLogs call.")
 	rootCmd.PersistentFlags().DurationVar(&cfg.OverlayReplayBlockTimeout, "rpc.overlay.replayblocktimeout", rpccfg.DefaultOverlayReplayBlockTimeout, "Maximum amount of time to wait for the answer to replay a single block when called from an overlay_getLogs call.")
 	rootCmd.PersistentFlags().IntVar(&cfg.RpcFiltersConfig.RpcSubscriptionFiltersMaxLogs, "rpc.subscription.filters.maxlogs", rpchelper.DefaultFiltersConfig.RpcSubscriptionFiltersMaxLogs, "Maximum number of logs to store per subscription.")
@@ -179,10 +192,14 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().IntVar(&cfg.RpcFiltersConfig.RpcSubscriptionFiltersMaxTopics, "rpc.subscription.filters.maxtopics", rpchelper.DefaultFiltersConfig.RpcSubscriptionFiltersMaxTopics, "Maximum number of topics per subscription to filter logs by.")
 	rootCmd.PersistentFlags().IntVar(&cfg.BatchLimit, utils.RpcBatchLimit.Name, utils.RpcBatchLimit.Value, utils.RpcBatchLimit.Usage)
 	rootCmd.PersistentFlags().IntVar(&cfg.ReturnDataLimit, utils.RpcReturnDataLimit.Name, utils.RpcReturnDataLimit.Value, utils.RpcReturnDataLimit.Usage)
+	rootCmd.PersistentFlags().IntVar(&cfg.MaxGetProofRewindBlockCount, utils.RpcMaxGetProofRewindBlockCount.Name, utils.RpcMaxGetProofRewindBlockCount.Value, utils.RpcMaxGetProofRewindBlockCount.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.AllowUnprotectedTxs, utils.AllowUnprotectedTxs.Name, utils.AllowUnprotectedTxs.Value, utils.AllowUnprotectedTxs.Usage)
 	rootCmd.PersistentFlags().Uint64Var(&cfg.OtsMaxPageSize, utils.OtsSearchMaxCapFlag.Name, utils.OtsSearchMaxCapFlag.Value, utils.OtsSearchMaxCapFlag.Usage)
 	rootCmd.PersistentFlags().DurationVar(&cfg.RPCSlowLogThreshold, utils.RPCSlowFlag.Name, utils.RPCSlowFlag.Value, utils.RPCSlowFlag.Usage)
 	rootCmd.PersistentFlags().IntVar(&cfg.WebsocketSubscribeLogsChannelSize, utils.WSSubscribeLogsChannelSize.Name, utils.WSSubscribeLogsChannelSize.Value, utils.WSSubscribeLogsChannelSize.Usage)
+	rootCmd.PersistentFlags().IntVar(&cfg.WebsocketSubscriptionBufferMB, utils.WSSubscriptionBufferFlag.Name, utils.WSSubscriptionBufferFlag.Value, utils.WSSubscriptionBufferFlag.Usage)
+	rootCmd.PersistentFlags().IntVar(&cfg.WebsocketClientBufferMB, utils.WSClientBufferFlag.Name, utils.WSClientBufferFlag.Value, utils.WSClientBufferFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.WebsocketSlowConsumer, utils.WSSlowConsumerFlag.Name, utils.WSSlowConsumerFlag.Value, utils.WSSlowConsumerFlag.Usage)
 
 	if err := rootCmd.MarkPersistentFlagFilename("rpc.accessList", "json"); err != nil {
 		panic(err)
@@ -215,6 +232,11 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 		if cfg.TxPoolApiAddr == "" {
 			cfg.TxPoolApiAddr = cfg.PrivateApiAddr
 		}
+		if opcodeOverridesFile != "" {
+			if cfg.OpcodeOverrides, err = vm.LoadOpcodeOverrides(opcodeOverridesFile); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 	rootCmd.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
@@ -342,11 +364,11 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 	if !cfg.WithDatadir && cfg.PrivateApiAddr == "" {
 		return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, errors.New("either remote db or local db must be specified")
 	}
-	creds, err := grpcutil.TLS(cfg.TLSCACert, cfg.TLSCertfile, cfg.TLSKeyFile)
+	sec, err := grpcutil.NewSecurity(cfg.TLSCACert, cfg.TLSCertfile, cfg.TLSKeyFile, cfg.GRPCAuthTokenFile)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, fmt.Errorf("open tls cert: %w", err)
 	}
-	conn, err := grpcutil.Connect(creds, cfg.PrivateApiAddr)
+	conn, err := grpcutil.Connect(sec, cfg.PrivateApiAddr)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, fmt.Errorf("could not connect to execution service privateApi: %w", err)
 	}
@@ -532,7 +554,7 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 
 	txpoolConn := conn
 	if cfg.TxPoolApiAddr != cfg.PrivateApiAddr {
-		txpoolConn, err = grpcutil.Connect(creds, cfg.TxPoolApiAddr)
+		txpoolConn, err = grpcutil.Connect(sec, cfg.TxPoolApiAddr)
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, fmt.Errorf("could not connect to txpool api: %w", err)
 		}
@@ -686,8 +708,24 @@ func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []r
 	}
 	srv.SetAllowList(allowListForRPC)
 
+	apiKeysForRPC, err := parseAPIKeysForRPC(cfg.RpcAPIKeysFilePath)
+	if err != nil {
+		return err
+	}
+	srv.SetAPIKeys(apiKeysForRPC)
+
 	srv.SetBatchLimit(cfg.BatchLimit)
 
+	slowConsumer, err := rpc.ParseSlowConsumerPolicy(cfg.WebsocketSlowConsumer)
+	if err != nil {
+		return err
+	}
+	srv.SetSubscriptionLimits(rpc.SubscriptionLimits{
+		MaxSubscriptionBytes: int64(cfg.WebsocketSubscriptionBufferMB) << 20,
+		MaxClientBytes:       int64(cfg.WebsocketClientBufferMB) << 20,
+		SlowConsumer:         slowConsumer,
+	})
+
 	defer srv.Stop()
 
 	var defaultAPIList []rpc.API
@@ -740,7 +778,7 @@ func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []r
 	httpHandler := node.NewHTTPHandlerStack(srv, cfg.HttpCORSDomain, cfg.HttpVirtualHost, cfg.HttpCompression)
 	var wsHandler http.Handler
 	if cfg.WebsocketEnabled {
-		wsHandler = srv.WebsocketHandler([]string{"*"}, nil, cfg.WebsocketCompression, logger)
+		wsHandler = srv.WebsocketHandler(cfg.WebsocketOrigins, nil, cfg.WebsocketCompression, logger)
 	}
 	graphQLHandler := graphql.CreateHandler(defaultAPIList)
 	apiHandler, err := createHandler(cfg, defaultAPIList, httpHandler, wsHandler, graphQLHandler, nil)
@@ -751,12 +789,21 @@ func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []r
 	// Separate Websocket handler if websocket port flag specified
 	if cfg.WebsocketEnabled && cfg.WebsocketPort != cfg.HttpPort {
 		wsEndpoint := fmt.Sprintf("tcp://%s:%d", cfg.HttpListenAddress, cfg.WebsocketPort)
-		wsApiHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsVirtualHost := cfg.WebsocketVirtualHost
+		if len(wsVirtualHost) == 0 {
+			wsVirtualHost = cfg.HttpVirtualHost
+		}
+		wsApiHandler := node.NewHTTPHandlerStack(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if isWebsocket(r) {
 				wsHandler.ServeHTTP(w, r)
 			}
-		})
-		wsListener, wsAddr, err := node.StartHTTPEndpoint(wsEndpoint, &node.HttpEndpointConfig{Timeouts: cfg.HTTPTimeouts}, wsApiHandler)
+		}), nil /* wsCors */, wsVirtualHost, false)
+		wsListener, wsAddr, err := node.StartHTTPEndpoint(wsEndpoint, &node.HttpEndpointConfig{
+			Timeouts: cfg.HTTPTimeouts,
+			HTTPS:    cfg.WebsocketCertfile != "",
+			CertFile: cfg.WebsocketCertfile,
+			KeyFile:  cfg.WebsocketKeyFile,
+		}, wsApiHandler)
 		if err != nil {
 			return fmt.Errorf("could not start separate Websocket RPC api at port %d: %w", cfg.WebsocketPort, err)
 		}
@@ -776,6 +823,9 @@ func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []r
 		}
 		listener, httpAddr, err := node.StartHTTPEndpoint(httpEndpoint, &node.HttpEndpointConfig{
 			Timeouts: cfg.HTTPTimeouts,
+			HTTPS:    cfg.HttpCertfile != "",
+			CertFile: cfg.HttpCertfile,
+			KeyFile:  cfg.HttpKeyFile,
 		}, apiHandler)
 		if err != nil {
 			return fmt.Errorf("could not start RPC api: %w", err)
@@ -926,13 +976,17 @@ func ObtainJWTSecret(cfg *httpcfg.HttpCfg, logger log.Logger) ([]byte, error) {
 }
 
 func createHandler(cfg *httpcfg.HttpCfg, apiList []rpc.API, httpHandler http.Handler, wsHandler http.Handler, graphQLHandler http.Handler, jwtSecret []byte) (http.Handler, error) {
+	var dirs *datadir.Dirs
+	if cfg.WithDatadir {
+		dirs = &cfg.Dirs
+	}
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if cfg.GraphQLEnabled && graphql.ProcessGraphQLcheckIfNeeded(graphQLHandler, w, r) {
 			return
 		}
 
 		// adding a healthcheck here
-		if health.ProcessHealthcheckIfNeeded(w, r, apiList) {
+		if health.ProcessHealthcheckIfNeeded(w, r, apiList, dirs) {
 			return
 		}
 		if cfg.WebsocketEnabled && wsHandler != nil && isWebsocket(r) {
@@ -966,7 +1020,7 @@ func createEngineListener(cfg *httpcfg.HttpCfg, engineApi []rpc.API, logger log.
 
 	wsHandler := engineSrv.WebsocketHandler([]string{"*"}, jwtSecret, cfg.WebsocketCompression, logger)
 
-	engineHttpHandler := node.NewHTTPHandlerStack(engineSrv, nil /* authCors */, cfg.AuthRpcVirtualHost, cfg.HttpCompression)
+	engineHttpHandler := node.NewHTTPHandlerStack(engineSrv, cfg.AuthRpcCORSDomain, cfg.AuthRpcVirtualHost, cfg.HttpCompression)
 
 	graphQLHandler := graphql.CreateHandler(engineApi)
 
@@ -977,6 +1031,9 @@ func createEngineListener(cfg *httpcfg.HttpCfg, engineApi []rpc.API, logger log.
 
 	engineListener, engineAddr, err := node.StartHTTPEndpoint(engineHttpEndpoint, &node.HttpEndpointConfig{
 		Timeouts: cfg.AuthRpcTimeouts,
+		HTTPS:    cfg.AuthRpcCertfile != "",
+		CertFile: cfg.AuthRpcCertfile,
+		KeyFile:  cfg.AuthRpcKeyFile,
 	}, engineApiHandler)
 	if err != nil {
 		return nil, nil, "", fmt.Errorf("could not start RPC api: %w", err)
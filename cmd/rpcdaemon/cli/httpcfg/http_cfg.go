@@ -19,6 +19,7 @@ package httpcfg
 import (
 	"time"
 
+	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon/eth/ethconfig"
@@ -37,6 +38,7 @@ type HttpCfg struct {
 	TLSCertfile              string
 	TLSCACert                string
 	TLSKeyFile               string
+	GRPCAuthTokenFile        string
 
 	HttpServerEnabled  bool
 	HttpURL            string
@@ -44,7 +46,12 @@ type HttpCfg struct {
 	HttpPort           int
 	HttpCORSDomain     []string
 	HttpVirtualHost    []string
+	HttpCertfile       string // TLS of the HTTP port, empty - plain HTTP
+	HttpKeyFile        string
+	AuthRpcCORSDomain  []string
 	AuthRpcVirtualHost []string
+	AuthRpcCertfile    string // TLS of the Engine API port, empty - plain HTTP
+	AuthRpcKeyFile     string
 	HttpCompression    bool
 
 	HttpsServerEnabled bool
@@ -64,8 +71,16 @@ type HttpCfg struct {
 	WebsocketPort                     int
 	WebsocketEnabled                  bool
 	WebsocketCompression              bool
+	WebsocketOrigins                  []string // origins of the websocket requests, of both the HTTP and the separate websocket port
+	WebsocketVirtualHost              []string // of the separate websocket port, empty - HttpVirtualHost
+	WebsocketCertfile                 string   // TLS of the separate websocket port, empty - plain websocket
+	WebsocketKeyFile                  string
 	WebsocketSubscribeLogsChannelSize int
+	WebsocketSubscriptionBufferMB     int    // notifications buffered for a subscription of a slow client
+	WebsocketClientBufferMB           int    // notifications buffered for all subscriptions of a slow client
+	WebsocketSlowConsumer             string // rpc.ParseSlowConsumerPolicy
 	RpcAllowListFilePath              string
+	RpcAPIKeysFilePath                string
 	RpcBatchConcurrency               uint
 	RpcStreamingDisable               bool
 	RpcFiltersConfig                  rpchelper.FiltersConfig
@@ -92,6 +107,9 @@ type HttpCfg struct {
 	HTTPTimeouts              rpccfg.HTTPTimeouts
 	AuthRpcTimeouts           rpccfg.HTTPTimeouts
 	EvmCallTimeout            time.Duration
+	EvmMaxMemoryMB            uint64                 // memory cap of a call frame of eth_call, eth_estimateGas and eth_callMany, 0 - unlimited
+	TxLookupNonCanonical      bool                   // eth_getTransactionByHash reports transactions of unwound blocks as an error instead of null
+	OpcodeOverrides           []chain.OpcodeOverride // of --evm.opcode-overrides, not stored with the chain config in the db
 	OverlayGetLogsTimeout     time.Duration
 	OverlayReplayBlockTimeout time.Duration
 
@@ -101,7 +119,7 @@ type HttpCfg struct {
 	BatchLimit                  int  // Maximum number of requests in a batch
 	ReturnDataLimit             int  // Maximum number of bytes returned from calls (like eth_call)
 	AllowUnprotectedTxs         bool // Whether to allow non EIP-155 protected transactions  txs over RPC
-	MaxGetProofRewindBlockCount int  //Max GetProof rewind block count
+	MaxGetProofRewindBlockCount int  // Maximum number of blocks behind the head served by eth_getWitness
 	// Ots API
 	OtsMaxPageSize uint64
 
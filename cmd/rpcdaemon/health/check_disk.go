@@ -0,0 +1,35 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package health
+
+import (
+	"errors"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon/turbo/diskforecast"
+)
+
+func checkFreeDiskDays(days float64, dirs *datadir.Dirs) error {
+	if dirs == nil {
+		return errors.New("datadir is not available: rpcdaemon is started without --datadir")
+	}
+	f, err := diskforecast.Plan(diskforecast.Config{Dirs: *dirs})
+	if err != nil {
+		return err
+	}
+	return f.Warning(days)
+}
@@ -26,6 +26,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/log/v3"
 
 	"github.com/erigontech/erigon/rpc"
@@ -43,6 +44,7 @@ const (
 	minPeerCount     = "min_peer_count"
 	checkBlock       = "check_block"
 	maxSecondsBehind = "max_seconds_behind"
+	minFreeDiskDays  = "min_free_disk_days"
 )
 
 var (
@@ -54,6 +56,7 @@ func ProcessHealthcheckIfNeeded(
 	w http.ResponseWriter,
 	r *http.Request,
 	rpcAPI []rpc.API,
+	dirs *datadir.Dirs, // nil - rpcdaemon has no datadir
 ) bool {
 	if !strings.EqualFold(r.URL.Path, urlPath) {
 		return false
@@ -63,7 +66,7 @@ func ProcessHealthcheckIfNeeded(
 
 	headers := r.Header.Values(healthHeader)
 	if len(headers) != 0 {
-		processFromHeaders(headers, ethAPI, netAPI, w, r)
+		processFromHeaders(headers, ethAPI, netAPI, dirs, w, r)
 	} else {
 		processFromBody(w, r, netAPI, ethAPI)
 	}
@@ -71,12 +74,13 @@ func ProcessHealthcheckIfNeeded(
 	return true
 }
 
-func processFromHeaders(headers []string, ethAPI EthAPI, netAPI NetAPI, w http.ResponseWriter, r *http.Request) {
+func processFromHeaders(headers []string, ethAPI EthAPI, netAPI NetAPI, dirs *datadir.Dirs, w http.ResponseWriter, r *http.Request) {
 	var (
 		errCheckSynced  = errCheckDisabled
 		errCheckPeer    = errCheckDisabled
 		errCheckBlock   = errCheckDisabled
 		errCheckSeconds = errCheckDisabled
+		errCheckDisk    = errCheckDisabled
 	)
 
 	for _, header := range headers {
@@ -113,9 +117,21 @@ func processFromHeaders(headers []string, ethAPI EthAPI, netAPI NetAPI, w http.R
 			now := time.Now().Unix()
 			errCheckSeconds = checkTime(r, int(now)-seconds, ethAPI)
 		}
+		if after, ok := strings.CutPrefix(lHeader, minFreeDiskDays); ok {
+			days, err := strconv.ParseFloat(after, 64)
+			if err != nil {
+				errCheckDisk = err
+				break
+			}
+			if days < 0 {
+				errCheckDisk = errBadHeaderValue
+				break
+			}
+			errCheckDisk = checkFreeDiskDays(days, dirs)
+		}
 	}
 
-	reportHealthFromHeaders(errCheckSynced, errCheckPeer, errCheckBlock, errCheckSeconds, w)
+	reportHealthFromHeaders(errCheckSynced, errCheckPeer, errCheckBlock, errCheckSeconds, errCheckDisk, w)
 }
 
 func processFromBody(w http.ResponseWriter, r *http.Request, netAPI NetAPI, ethAPI EthAPI) {
@@ -183,7 +199,7 @@ func reportHealthFromBody(errParse, errMinPeerCount, errCheckBlock error, w http
 	return writeResponse(w, errors, statusCode)
 }
 
-func reportHealthFromHeaders(errCheckSynced, errCheckPeer, errCheckBlock, errCheckSeconds error, w http.ResponseWriter) error {
+func reportHealthFromHeaders(errCheckSynced, errCheckPeer, errCheckBlock, errCheckSeconds, errCheckDisk error, w http.ResponseWriter) error {
 	statusCode := http.StatusOK
 	errs := make(map[string]string)
 
@@ -207,6 +223,11 @@ func reportHealthFromHeaders(errCheckSynced, errCheckPeer, errCheckBlock, errChe
 	}
 	errs[maxSecondsBehind] = errorStringOrOK(errCheckSeconds)
 
+	if shouldChangeStatusCode(errCheckDisk) {
+		statusCode = http.StatusInternalServerError
+	}
+	errs[minFreeDiskDays] = errorStringOrOK(errCheckDisk)
+
 	return writeResponse(w, errs, statusCode)
 }
 
@@ -348,6 +348,24 @@ func TestProcessHealthcheckIfNeeded_HeadersTests(t *testing.T) {
 				maxSecondsBehind: "HEALTHY",
 			},
 		},
+		// 16 - disk check - no datadir
+		{
+			headers:             []string{"min_free_disk_days7"},
+			netApiResponse:      hexutil.Uint(1),
+			netApiError:         nil,
+			ethApiBlockResult:   map[string]interface{}{},
+			ethApiBlockError:    nil,
+			ethApiSyncingResult: false,
+			ethApiSyncingError:  nil,
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedBody: map[string]string{
+				synced:           "DISABLED",
+				minPeerCount:     "DISABLED",
+				checkBlock:       "DISABLED",
+				maxSecondsBehind: "DISABLED",
+				minFreeDiskDays:  "ERROR: datadir is not available",
+			},
+		},
 	}
 
 	for idx, c := range cases {
@@ -387,7 +405,7 @@ func TestProcessHealthcheckIfNeeded_HeadersTests(t *testing.T) {
 		apis[0] = netAPI
 		apis[1] = ethAPI
 
-		ProcessHealthcheckIfNeeded(w, r, apis)
+		ProcessHealthcheckIfNeeded(w, r, apis, nil)
 
 		result := w.Result()
 		if result.StatusCode != c.expectedStatusCode {
@@ -547,7 +565,7 @@ func TestProcessHealthcheckIfNeeded_RequestBody(t *testing.T) {
 		apis[0] = netAPI
 		apis[1] = ethAPI
 
-		ProcessHealthcheckIfNeeded(w, r, apis)
+		ProcessHealthcheckIfNeeded(w, r, apis, nil)
 
 		result := w.Result()
 		if result.StatusCode != c.expectedStatusCode {
@@ -40,6 +40,7 @@ import (
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/nat"
 	"github.com/erigontech/erigon/polygon/heimdall"
 	"github.com/erigontech/erigon/turbo/privateapi"
 	"github.com/erigontech/erigon/turbo/services"
@@ -403,6 +404,14 @@ func (back *RemoteBackend) NodeInfo(ctx context.Context, limit uint32) ([]p2p.No
 			protocols[k] = v
 		}
 
+		var natStatus *nat.Status
+		if len(node.Nat) > 0 {
+			natStatus = new(nat.Status)
+			if err = json.Unmarshal(node.Nat, natStatus); err != nil {
+				return nil, fmt.Errorf("cannot decode nat status: %w", err)
+			}
+		}
+
 		ret = append(ret, p2p.NodeInfo{
 			Enode:      node.Enode,
 			ID:         node.Id,
@@ -418,6 +427,7 @@ func (back *RemoteBackend) NodeInfo(ctx context.Context, limit uint32) ([]p2p.No
 				Listener:  int(node.Ports.Listener),
 			},
 			Protocols: protocols,
+			NAT:       natStatus,
 		})
 	}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/paths"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	"github.com/erigontech/erigon/cmd/utils"
 	"github.com/erigontech/erigon/p2p/sentry"
 	"github.com/erigontech/erigon/turbo/debug"
@@ -50,7 +51,14 @@ var (
 	maxPeers     int
 	maxPendPeers int
 	healthCheck  bool
+	healthAddr   string
+	healthPeers  int
 	metrics      bool
+
+	tlsCertFile   string
+	tlsKeyFile    string
+	tlsCACert     string
+	authTokenFile string
 )
 
 func init() {
@@ -70,7 +78,13 @@ func init() {
 	rootCmd.Flags().IntVar(&maxPeers, utils.MaxPeersFlag.Name, utils.MaxPeersFlag.Value, utils.MaxPeersFlag.Usage)
 	rootCmd.Flags().IntVar(&maxPendPeers, utils.MaxPendingPeersFlag.Name, utils.MaxPendingPeersFlag.Value, utils.MaxPendingPeersFlag.Usage)
 	rootCmd.Flags().BoolVar(&healthCheck, utils.HealthCheckFlag.Name, false, utils.HealthCheckFlag.Usage)
+	rootCmd.Flags().StringVar(&healthAddr, "health.addr", "", "address of the HTTP liveness (/health) and readiness (/ready) probes, e.g. 0.0.0.0:9092 (disabled if empty)")
+	rootCmd.Flags().IntVar(&healthPeers, "health.minpeers", 0, "peers needed by the readiness probe (/ready)")
 	rootCmd.Flags().BoolVar(&metrics, utils.MetricsEnabledFlag.Name, false, utils.MetricsEnabledFlag.Usage)
+	rootCmd.Flags().StringVar(&tlsCertFile, "tls.cert", "", "certificate for server side TLS handshake for GRPC")
+	rootCmd.Flags().StringVar(&tlsKeyFile, "tls.key", "", "key file for server side TLS handshake for GRPC")
+	rootCmd.Flags().StringVar(&tlsCACert, "tls.cacert", "", "CA certificate of GRPC clients: client certificates are required")
+	rootCmd.Flags().StringVar(&authTokenFile, "grpc.auth.tokenfile", "", "file with tokens (one per line) authenticating GRPC calls, re-read on change")
 
 	if err := rootCmd.MarkFlagDirname(utils.DataDirFlag.Name); err != nil {
 		panic(err)
@@ -109,8 +123,13 @@ var rootCmd = &cobra.Command{
 			return err
 		}
 
+		sec, err := grpcutil.NewSecurity(tlsCACert, tlsCertFile, tlsKeyFile, authTokenFile)
+		if err != nil {
+			return err
+		}
+
 		logger := debug.SetupCobra(cmd, "sentry")
-		return sentry.Sentry(cmd.Context(), dirs, sentryAddr, discoveryDNS, p2pConfig, protocol, healthCheck, logger)
+		return sentry.Sentry(cmd.Context(), dirs, sentryAddr, discoveryDNS, p2pConfig, protocol, sec, healthCheck, healthAddr, healthPeers, logger)
 	},
 }
 
@@ -51,6 +51,9 @@ import (
 var (
 	sentryAddr     []string // Address of the sentry <host>:<port>
 	traceSenders   []string
+	priorityAddrs  []string
+	ordering       string
+	orderingAddr   string
 	privateApiAddr string
 	txpoolApiAddr  string
 	datadirCli     string // Path to td working dir
@@ -59,6 +62,8 @@ var (
 	TLSCACert   string
 	TLSKeyFile  string
 
+	authTokenFile string
+
 	pendingPoolLimit int
 	baseFeePoolLimit int
 	queuedPoolLimit  int
@@ -67,10 +72,15 @@ var (
 	accountSlots       uint64
 	blobSlots          uint64
 	totalBlobPoolLimit uint64
-	priceBump          uint64
-	blobPriceBump      uint64
+	blobMemoryLimit    uint64
+	senderPolicy       txpoolcfg.SenderPolicy
+	parking            txpoolcfg.Parking
+	queuedLifetime     time.Duration
+	peerIngestion      txpoolcfg.PeerIngestion
+	replacement        txpoolcfg.ReplacementPolicy
 
 	noTxGossip bool
+	noDump     bool
 
 	mdbxWriteMap bool
 
@@ -89,6 +99,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&TLSCertfile, "tls.cert", "", "certificate for client side TLS handshake")
 	rootCmd.PersistentFlags().StringVar(&TLSKeyFile, "tls.key", "", "key file for client side TLS handshake")
 	rootCmd.PersistentFlags().StringVar(&TLSCACert, "tls.cacert", "", "CA certificate for client side TLS handshake")
+	rootCmd.PersistentFlags().StringVar(&authTokenFile, "grpc.auth.tokenfile", "", "file with tokens (one per line) authenticating GRPC calls, re-read on change")
 
 	rootCmd.PersistentFlags().IntVar(&pendingPoolLimit, "txpool.globalslots", txpoolcfg.DefaultConfig.PendingSubPoolLimit, "Maximum number of executable transaction slots for all accounts")
 	rootCmd.PersistentFlags().IntVar(&baseFeePoolLimit, "txpool.globalbasefeeslots", txpoolcfg.DefaultConfig.BaseFeeSubPoolLimit, "Maximum number of non-executable transactions where only not enough baseFee")
@@ -97,12 +108,27 @@ func init() {
 	rootCmd.PersistentFlags().Uint64Var(&accountSlots, "txpool.accountslots", txpoolcfg.DefaultConfig.AccountSlots, "Minimum number of executable transaction slots guaranteed per account")
 	rootCmd.PersistentFlags().Uint64Var(&blobSlots, "txpool.blobslots", txpoolcfg.DefaultConfig.BlobSlots, "Max allowed total number of blobs (within type-3 txs) per account")
 	rootCmd.PersistentFlags().Uint64Var(&totalBlobPoolLimit, "txpool.totalblobpoollimit", txpoolcfg.DefaultConfig.TotalBlobPoolLimit, "Total limit of number of all blobs in txs within the txpool")
-	rootCmd.PersistentFlags().Uint64Var(&priceBump, "txpool.pricebump", txpoolcfg.DefaultConfig.PriceBump, "Price bump percentage to replace an already existing transaction")
-	rootCmd.PersistentFlags().Uint64Var(&blobPriceBump, "txpool.blobpricebump", txpoolcfg.DefaultConfig.BlobPriceBump, "Price bump percentage to replace an existing blob (type-3) transaction")
+	rootCmd.PersistentFlags().Uint64Var(&blobMemoryLimit, utils.TxPoolBlobMemoryLimitFlag.Name, utils.TxPoolBlobMemoryLimitFlag.Value, utils.TxPoolBlobMemoryLimitFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&senderPolicy.MaxSlots, utils.TxPoolSenderMaxSlotsFlag.Name, utils.TxPoolSenderMaxSlotsFlag.Value, utils.TxPoolSenderMaxSlotsFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&senderPolicy.MaxGas, utils.TxPoolSenderMaxGasFlag.Name, utils.TxPoolSenderMaxGasFlag.Value, utils.TxPoolSenderMaxGasFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&senderPolicy.MaxNonceGap, utils.TxPoolSenderMaxNonceGapFlag.Name, utils.TxPoolSenderMaxNonceGapFlag.Value, utils.TxPoolSenderMaxNonceGapFlag.Usage)
+	rootCmd.PersistentFlags().IntVar(&parking.Limit, utils.TxPoolParkingLimitFlag.Name, utils.TxPoolParkingLimitFlag.Value, utils.TxPoolParkingLimitFlag.Usage)
+	rootCmd.PersistentFlags().DurationVar(&parking.MaxAge, utils.TxPoolParkingMaxAgeFlag.Name, utils.TxPoolParkingMaxAgeFlag.Value, utils.TxPoolParkingMaxAgeFlag.Usage)
+	rootCmd.PersistentFlags().DurationVar(&queuedLifetime, utils.TxPoolQueuedLifetimeFlag.Name, utils.TxPoolQueuedLifetimeFlag.Value, utils.TxPoolQueuedLifetimeFlag.Usage)
+	rootCmd.PersistentFlags().Float64Var(&peerIngestion.TxnsPerSecond, utils.TxPoolPeerRateFlag.Name, utils.TxPoolPeerRateFlag.Value, utils.TxPoolPeerRateFlag.Usage)
+	rootCmd.PersistentFlags().IntVar(&peerIngestion.TxnsBurst, utils.TxPoolPeerBurstFlag.Name, utils.TxPoolPeerBurstFlag.Value, utils.TxPoolPeerBurstFlag.Usage)
+	rootCmd.PersistentFlags().IntVar(&peerIngestion.MaxPenalty, utils.TxPoolPeerMaxPenaltyFlag.Name, utils.TxPoolPeerMaxPenaltyFlag.Value, utils.TxPoolPeerMaxPenaltyFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&ordering, utils.TxPoolOrderingFlag.Name, utils.TxPoolOrderingFlag.Value, utils.TxPoolOrderingFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&orderingAddr, utils.TxPoolOrderingAddrFlag.Name, utils.TxPoolOrderingAddrFlag.Value, utils.TxPoolOrderingAddrFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&replacement.PriceBump, utils.TxPoolPriceBumpFlag.Name, utils.TxPoolPriceBumpFlag.Value, utils.TxPoolPriceBumpFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&replacement.BlobPriceBump, utils.TxPoolBlobPriceBumpFlag.Name, utils.TxPoolBlobPriceBumpFlag.Value, utils.TxPoolBlobPriceBumpFlag.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&replacement.BlobFeeBump, utils.TxPoolBlobFeeBumpFlag.Name, utils.TxPoolBlobFeeBumpFlag.Value, utils.TxPoolBlobFeeBumpFlag.Usage)
 	rootCmd.PersistentFlags().DurationVar(&commitEvery, utils.TxPoolCommitEveryFlag.Name, utils.TxPoolCommitEveryFlag.Value, utils.TxPoolCommitEveryFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&noTxGossip, utils.TxPoolGossipDisableFlag.Name, utils.TxPoolGossipDisableFlag.Value, utils.TxPoolGossipDisableFlag.Usage)
+	rootCmd.PersistentFlags().BoolVar(&noDump, utils.TxPoolDumpDisableFlag.Name, utils.TxPoolDumpDisableFlag.Value, utils.TxPoolDumpDisableFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&mdbxWriteMap, utils.DbWriteMapFlag.Name, utils.DbWriteMapFlag.Value, utils.DbWriteMapFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&traceSenders, utils.TxPoolTraceSendersFlag.Name, []string{}, utils.TxPoolTraceSendersFlag.Usage)
+	rootCmd.Flags().StringSliceVar(&priorityAddrs, utils.TxPoolPrioritySendersFlag.Name, []string{}, utils.TxPoolPrioritySendersFlag.Usage)
 }
 
 var rootCmd = &cobra.Command{
@@ -123,11 +149,11 @@ var rootCmd = &cobra.Command{
 }
 
 func doTxpool(ctx context.Context, logger log.Logger) error {
-	creds, err := grpcutil.TLS(TLSCACert, TLSCertfile, TLSKeyFile)
+	sec, err := grpcutil.NewSecurity(TLSCACert, TLSCertfile, TLSKeyFile, authTokenFile)
 	if err != nil {
 		return fmt.Errorf("could not connect to remoteKv: %w", err)
 	}
-	coreConn, err := grpcutil.Connect(creds, privateApiAddr)
+	coreConn, err := grpcutil.Connect(sec, privateApiAddr)
 	if err != nil {
 		return fmt.Errorf("could not connect to remoteKv: %w", err)
 	}
@@ -143,11 +169,7 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 
 	sentryClients := make([]proto_sentry.SentryClient, len(sentryAddr))
 	for i := range sentryAddr {
-		creds, err := grpcutil.TLS(TLSCACert, TLSCertfile, TLSKeyFile)
-		if err != nil {
-			return fmt.Errorf("could not connect to sentry: %w", err)
-		}
-		sentryConn, err := grpcutil.Connect(creds, sentryAddr[i])
+		sentryConn, err := grpcutil.Connect(sec, sentryAddr[i])
 		if err != nil {
 			return fmt.Errorf("could not connect to sentry: %w", err)
 		}
@@ -168,10 +190,17 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	cfg.AccountSlots = accountSlots
 	cfg.BlobSlots = blobSlots
 	cfg.TotalBlobPoolLimit = totalBlobPoolLimit
-	cfg.PriceBump = priceBump
-	cfg.BlobPriceBump = blobPriceBump
+	cfg.BlobMemoryLimit = blobMemoryLimit
+	cfg.SenderPolicy = senderPolicy
+	cfg.Parking = parking
+	cfg.QueuedLifetime = queuedLifetime
+	cfg.PeerIngestion = peerIngestion
+	cfg.Replacement = replacement
 	cfg.NoGossip = noTxGossip
+	cfg.NoDump = noDump
 	cfg.MdbxWriteMap = mdbxWriteMap
+	cfg.Ordering = ordering
+	cfg.OrderingAddr = orderingAddr
 
 	cacheConfig := kvcache.DefaultCoherentConfig
 	cacheConfig.MetricsLabel = "txpool"
@@ -181,6 +210,11 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 		sender := common.HexToAddress(senderHex)
 		cfg.TracedSenders[i] = string(sender[:])
 	}
+	cfg.PrioritySenders = make([]string, len(priorityAddrs))
+	for i, senderHex := range priorityAddrs {
+		sender := common.HexToAddress(senderHex)
+		cfg.PrioritySenders[i] = string(sender[:])
+	}
 
 	notifyMiner := func() {}
 	txPool, txpoolGrpcServer, err := txpool.Assemble(
@@ -199,7 +233,7 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	}
 
 	miningGrpcServer := privateapi.NewMiningServer(ctx, &rpcdaemontest.IsMiningMock{}, nil, logger)
-	grpcServer, err := txpool.StartGrpc(txpoolGrpcServer, miningGrpcServer, txpoolApiAddr, nil, logger)
+	grpcServer, err := txpool.StartGrpc(txpoolGrpcServer, miningGrpcServer, txpoolApiAddr, sec, logger)
 	if err != nil {
 		return err
 	}
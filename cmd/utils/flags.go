@@ -28,6 +28,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	g "github.com/anacrolix/generics"
@@ -44,6 +45,7 @@ import (
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/mem"
 	"github.com/erigontech/erigon-lib/common/metrics"
 	"github.com/erigontech/erigon-lib/common/paths"
 	"github.com/erigontech/erigon-lib/crypto"
@@ -53,6 +55,7 @@ import (
 	"github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/cl/clparams"
+	_ "github.com/erigontech/erigon/cl/kzg" // registers the blst kzg backend
 	"github.com/erigontech/erigon/cmd/downloader/downloadernat"
 	"github.com/erigontech/erigon/cmd/utils/flags"
 	"github.com/erigontech/erigon/core"
@@ -65,6 +68,7 @@ import (
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/nat"
 	"github.com/erigontech/erigon/p2p/netutil"
+	"github.com/erigontech/erigon/p2p/reputation"
 	params2 "github.com/erigontech/erigon/params"
 	"github.com/erigontech/erigon/polygon/heimdall"
 	"github.com/erigontech/erigon/rpc/rpccfg"
@@ -104,6 +108,14 @@ var (
 		Name:  "dev.period",
 		Usage: "Block period to use in developer mode (0 = mine only if transaction pending)",
 	}
+	DeveloperPoSFlag = cli.BoolFlag{
+		Name:  "dev.pos",
+		Usage: "Developer mode: proof-of-stake chain with all forks up to Prague, blocks are produced by the embedded mock consensus layer via engine API",
+	}
+	ChainRegistryFlag = cli.StringFlag{
+		Name:  "chain.registry",
+		Usage: "Path to JSON file with per-chain overrides of embedded bootnodes, static peers, webseeds and chainspec parameters: {\"mainnet\": {\"bootnodes\": [...], \"webseeds\": [...], \"config\": {\"osakaTime\": ...}}}",
+	}
 	ChainFlag = cli.StringFlag{
 		Name:  "chain",
 		Usage: "name of the network to join",
@@ -127,6 +139,11 @@ var (
 		Name:  "trusted-setup-file",
 		Usage: "Absolute path to trusted_setup.json file",
 	}
+	KZGBackendFlag = cli.StringFlag{
+		Name:  "crypto.kzg-backend",
+		Usage: "Implementation of KZG proof verification: gnark (pure Go), blst (cgo, only with the embedded trusted setup) or auto (the fastest by a benchmark at startup)",
+		Value: libkzg.DefaultBackend,
+	}
 	// Ethash settings
 	EthashCachesInMemoryFlag = cli.IntFlag{
 		Name:  "ethash.cachesinmem",
@@ -161,6 +178,11 @@ var (
 		Usage: "Disabling p2p gossip of txs. Any txs received by p2p - will be dropped. Some networks like 'Optimism execution engine'/'Optimistic Rollup' - using it to protect against MEV attacks",
 		Value: txpoolcfg.DefaultConfig.NoGossip,
 	}
+	TxPoolDumpDisableFlag = cli.BoolFlag{
+		Name:  "txpool.dump.disable",
+		Usage: "Don't dump the pool to <datadir>/txpool/pool.dump on shutdown, nor load it on startup. The transactions in the pool db are still loaded",
+		Value: txpoolcfg.DefaultConfig.NoDump,
+	}
 	TxPoolPriceLimitFlag = cli.Uint64Flag{
 		Name:  "txpool.pricelimit",
 		Usage: "Minimum gas price (fee cap) limit to enforce for acceptance into the pool",
@@ -168,13 +190,18 @@ var (
 	}
 	TxPoolPriceBumpFlag = cli.Uint64Flag{
 		Name:  "txpool.pricebump",
-		Usage: "Price bump percentage to replace an already existing transaction",
-		Value: txpoolcfg.DefaultConfig.PriceBump,
+		Usage: "Price bump percentage to replace an already existing transaction. Can be changed at runtime by the SetPolicy gRPC call",
+		Value: txpoolcfg.DefaultConfig.Replacement.PriceBump,
 	}
 	TxPoolBlobPriceBumpFlag = cli.Uint64Flag{
 		Name:  "txpool.blobpricebump",
-		Usage: "Price bump percentage to replace existing (type-3) blob transaction",
-		Value: txpoolcfg.DefaultConfig.BlobPriceBump,
+		Usage: "Price bump percentage of the tip and the fee cap to replace existing (type-3) blob transaction. Can be changed at runtime by the SetPolicy gRPC call",
+		Value: txpoolcfg.DefaultConfig.Replacement.BlobPriceBump,
+	}
+	TxPoolBlobFeeBumpFlag = cli.Uint64Flag{
+		Name:  "txpool.blobfeebump",
+		Usage: "Price bump percentage of the blob fee cap to replace existing (type-3) blob transaction. Can be changed at runtime by the SetPolicy gRPC call",
+		Value: txpoolcfg.DefaultConfig.Replacement.BlobFeeBump,
 	}
 	TxPoolAccountSlotsFlag = cli.Uint64Flag{
 		Name:  "txpool.accountslots",
@@ -191,6 +218,60 @@ var (
 		Usage: "Total limit of number of all blobs in txs within the txpool",
 		Value: txpoolcfg.DefaultConfig.TotalBlobPoolLimit,
 	}
+	TxPoolBlobMemoryLimitFlag = cli.Uint64Flag{
+		Name:  "txpool.blobmemorylimit",
+		Usage: "Number of blobs of pooled txs held in memory, blobs of the txs with the lowest blob fee cap above it are read from the txpool db when needed",
+		Value: txpoolcfg.DefaultConfig.BlobMemoryLimit,
+	}
+	TxPoolSenderMaxSlotsFlag = cli.Uint64Flag{
+		Name:  "txpool.sendermaxslots",
+		Usage: "Max number of transactions pooled per sender, local ones included (0 = no limit). Can be changed at runtime by the SetPolicy gRPC call",
+		Value: txpoolcfg.DefaultConfig.SenderPolicy.MaxSlots,
+	}
+	TxPoolSenderMaxGasFlag = cli.Uint64Flag{
+		Name:  "txpool.sendermaxgas",
+		Usage: "Max sum of gas limits of the transactions pooled per sender (0 = no limit)",
+		Value: txpoolcfg.DefaultConfig.SenderPolicy.MaxGas,
+	}
+	TxPoolSenderMaxNonceGapFlag = cli.Uint64Flag{
+		Name:  "txpool.sendermaxnoncegap",
+		Usage: "Max distance of a transaction nonce ahead of the sender nonce (0 = no limit)",
+		Value: txpoolcfg.DefaultConfig.SenderPolicy.MaxNonceGap,
+	}
+	TxPoolSimulateLocalFlag = cli.BoolFlag{
+		Name:  "txpool.simulatelocal",
+		Usage: "Execute local transactions against the latest state before adding them, reject the ones which revert (WOULD_REVERT)",
+	}
+	TxPoolParkingLimitFlag = cli.IntFlag{
+		Name:  "txpool.parking.limit",
+		Usage: "Max number of queued transactions parked on a nonce gap or insufficient balance, the ones parked longest are discarded above it (0 = no limit)",
+		Value: txpoolcfg.DefaultConfig.Parking.Limit,
+	}
+	TxPoolParkingMaxAgeFlag = cli.DurationFlag{
+		Name:  "txpool.parking.maxage",
+		Usage: "Queued transactions parked on a nonce gap or insufficient balance for longer are discarded (0 = no limit)",
+		Value: txpoolcfg.DefaultConfig.Parking.MaxAge,
+	}
+	TxPoolQueuedLifetimeFlag = cli.DurationFlag{
+		Name:  "txpool.queued.lifetime",
+		Usage: "Remote transactions staying in the queued sub-pool for longer are discarded (0 = no limit)",
+		Value: txpoolcfg.DefaultConfig.QueuedLifetime,
+	}
+	TxPoolPeerRateFlag = cli.Float64Flag{
+		Name:  "txpool.peer.rate",
+		Usage: "Max number of remote transactions per second accepted from a peer, its messages above it are dropped (0 = no limit)",
+		Value: txpoolcfg.DefaultConfig.PeerIngestion.TxnsPerSecond,
+	}
+	TxPoolPeerBurstFlag = cli.IntFlag{
+		Name:  "txpool.peer.burst",
+		Usage: "Max burst of remote transactions accepted from a peer above --txpool.peer.rate",
+		Value: txpoolcfg.DefaultConfig.PeerIngestion.TxnsBurst,
+	}
+	TxPoolPeerMaxPenaltyFlag = cli.IntFlag{
+		Name:  "txpool.peer.maxpenalty",
+		Usage: "Penalty points at which a peer is disconnected: 10 per malformed transactions message, 1 per invalid transaction or message above the rate. Halve every minute (0 = never)",
+		Value: txpoolcfg.DefaultConfig.PeerIngestion.MaxPenalty,
+	}
 	TxPoolGlobalSlotsFlag = cli.IntFlag{
 		Name:  "txpool.globalslots",
 		Usage: "Maximum number of executable transaction slots for all accounts",
@@ -211,11 +292,41 @@ var (
 		Usage: "Comma separated list of addresses, whose transactions will traced in transaction pool with debug printing",
 		Value: "",
 	}
+	TxPoolPrioritySendersFlag = cli.StringFlag{
+		Name:  "txpool.prioritysenders",
+		Usage: "Comma separated list of addresses, whose transactions are offered first to the block builder and aren't evicted for their fees (system and service transactions)",
+		Value: "",
+	}
+	TxPoolOrderingFlag = cli.StringFlag{
+		Name:  "txpool.ordering",
+		Usage: "Order of the pending transactions offered to the block builder: " + strings.Join(txpoolcfg.Orderings, ", "),
+		Value: txpoolcfg.DefaultConfig.Ordering,
+	}
+	TxPoolOrderingAddrFlag = cli.StringFlag{
+		Name:  "txpool.ordering.addr",
+		Usage: "Address of the gRPC scoring service (txpool.Scorer) of --txpool.ordering=" + txpoolcfg.OrderingGrpc,
+		Value: "",
+	}
 	TxPoolCommitEveryFlag = cli.DurationFlag{
 		Name:  "txpool.commit.every",
 		Usage: "How often transactions should be committed to the storage",
 		Value: txpoolcfg.DefaultConfig.CommitEvery,
 	}
+	TxPoolFeeMarketHistoryEveryFlag = cli.DurationFlag{
+		Name:  "txpool.feemarket.history.every",
+		Usage: "How often to persist a summary of pending pool fees (served by erigon_feeMarketHistory), e.g. 1m. 0 - disabled",
+		Value: txpoolcfg.DefaultConfig.FeeMarketHistoryEvery,
+	}
+	TxPoolFeeMarketHistoryLimitFlag = cli.IntFlag{
+		Name:  "txpool.feemarket.history.limit",
+		Usage: "How many newest fee market summaries to keep",
+		Value: txpoolcfg.DefaultConfig.FeeMarketHistoryLimit,
+	}
+	TxPoolPropagationWindowFlag = cli.DurationFlag{
+		Name:  "txpool.propagation.window",
+		Usage: "How long to keep propagation traces of transactions: first seen time, source peer, broadcasts (served by txpool_propagation), e.g. 10m. 0 - disabled",
+		Value: txpoolcfg.DefaultConfig.PropagationTraceWindow,
+	}
 	// Miner settings
 	MiningEnabledFlag = cli.BoolFlag{
 		Name:  "mine",
@@ -285,6 +396,56 @@ var (
 		Usage: "Reporting URL of a ethstats service (nodename:secret@host:port)",
 		Value: "",
 	}
+	SinkURLFlag = cli.StringFlag{
+		Name:  "sink.url",
+		Usage: "Publish block-executed events (header, receipts, state changes summary) to a message bus: nats://[user:password@|token@]host:4222/<subject> (JetStream, tls:// for TLS; nkey/JWT creds are not supported) or kafka+http(s)://<rest-proxy>/topics/<topic>",
+		Value: "",
+	}
+	SinkFromBlockFlag = cli.Uint64Flag{
+		Name:  "sink.from",
+		Usage: "First block to publish when the sink has no saved offsets (0 = the next executed block)",
+		Value: 0,
+	}
+	MaintenanceConfigFlag = cli.StringFlag{
+		Name:  "maintenance.config",
+		Usage: "TOML file of maintenance tasks (retire, prune, compact, integrity, backup, snapshots-update) run on cron-like schedules within daily windows",
+		Value: "",
+	}
+	SnapAutoUpdateFlag = cli.StringFlag{
+		Name:  "snap.autoupdate",
+		Usage: "Cron schedule of the download of the block snapshots newly published in the manifest, opened without restart, example: \"0 */6 * * *\" (empty - disabled)",
+		Value: "",
+	}
+	SnapAutoUpdateWindowFlag = cli.StringFlag{
+		Name:  "snap.autoupdate.window",
+		Usage: "Daily window of the snapshot auto-update downloads, example: 01:00-06:00 (empty - any time)",
+		Value: "",
+	}
+	OpcodeOverridesFlag = cli.StringFlag{
+		Name:  "evm.opcode-overrides",
+		Usage: "TOML file of opcode overrides for L2s and private chains: [[override]] entries of opcode, from, to (block range), gas or disabled",
+		Value: "",
+	}
+	WatchdogRSSFlag = cli.StringFlag{
+		Name:  "watchdog.rss",
+		Usage: "Save heap and goroutine profiles to <datadir>/watchdog when process RSS exceeds this size, for example 48GB (empty = disabled)",
+		Value: "",
+	}
+	WatchdogGoroutinesFlag = cli.IntFlag{
+		Name:  "watchdog.goroutines",
+		Usage: "Save heap and goroutine profiles to <datadir>/watchdog when goroutines count exceeds this number (0 = disabled)",
+		Value: 0,
+	}
+	WatchdogGCPauseFlag = cli.DurationFlag{
+		Name:  "watchdog.gcpause",
+		Usage: "Save heap and goroutine profiles to <datadir>/watchdog when a GC stop-the-world pause exceeds this duration (0 = disabled)",
+		Value: 0,
+	}
+	WatchdogFilesFlag = cli.IntFlag{
+		Name:  "watchdog.files",
+		Usage: "Amount of latest watchdog captures to keep",
+		Value: 10,
+	}
 	FakePoWFlag = cli.BoolFlag{
 		Name:  "fakepow",
 		Usage: "Disables proof-of-work verification",
@@ -365,6 +526,31 @@ var (
 		Usage: "Comma separated list of virtual hostnames from which to accept Engine API requests (server enforced). Accepts 'any' or '*' as wildcard.",
 		Value: strings.Join(nodecfg.DefaultConfig.HTTPVirtualHosts, ","),
 	}
+	AuthRpcCORSDomainFlag = cli.StringFlag{
+		Name:  "authrpc.corsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin Engine API requests (browser enforced)",
+		Value: "",
+	}
+	AuthRpcTLSCertFlag = cli.StringFlag{
+		Name:  "authrpc.tls.cert",
+		Usage: "Certificate of the Engine API port, serves https:// (re-read on SIGHUP)",
+		Value: "",
+	}
+	AuthRpcTLSKeyFlag = cli.StringFlag{
+		Name:  "authrpc.tls.key",
+		Usage: "Key file of the certificate of --authrpc.tls.cert",
+		Value: "",
+	}
+	HTTPTLSCertFlag = cli.StringFlag{
+		Name:  "http.tls.cert",
+		Usage: "Certificate of the HTTP-RPC port, serves https:// (re-read on SIGHUP)",
+		Value: "",
+	}
+	HTTPTLSKeyFlag = cli.StringFlag{
+		Name:  "http.tls.key",
+		Usage: "Key file of the certificate of --http.tls.cert",
+		Value: "",
+	}
 	HTTPApiFlag = cli.StringFlag{
 		Name:  "http.api",
 		Usage: "API's offered over the HTTP-RPC interface",
@@ -389,6 +575,11 @@ var (
 		Usage: "Maximum number of bytes returned from eth_call or similar invocations",
 		Value: 100_000,
 	}
+	RpcMaxGetProofRewindBlockCount = cli.IntFlag{
+		Name:  "rpc.maxgetproofrewindblockcount.limit",
+		Usage: "Maximum number of blocks behind the head served by eth_getWitness and eth_getTxWitness. The state is rewound with the changesets, which are kept for the last MAX_REORG_DEPTH (default 512) blocks only",
+		Value: 512,
+	}
 	HTTPTraceFlag = cli.BoolFlag{
 		Name:  "http.trace",
 		Usage: "Print all HTTP requests to logs with INFO level",
@@ -406,6 +597,10 @@ var (
 		Name:  "rpc.accessList",
 		Usage: "Specify granular (method-by-method) API allowlist",
 	}
+	RpcAPIKeysFlag = cli.StringFlag{
+		Name:  "rpc.apikeys",
+		Usage: "JSON file with API keys: per-key allowed namespaces, rate limit, concurrent traces and CORS origins. If set, every HTTP/WS request must present a key in X-API-Key header or `apikey` query parameter",
+	}
 
 	RpcGasCapFlag = cli.UintFlag{
 		Name:  "rpc.gascap",
@@ -473,7 +668,22 @@ var (
 	}
 	WSAllowedOriginsFlag = cli.StringFlag{
 		Name:  "ws.origins",
-		Usage: "Origins from which to accept websockets requests",
+		Usage: "Comma separated list of origins from which to accept websockets requests (server enforced). Accepts '*' as wildcard.",
+		Value: "*",
+	}
+	WSVirtualHostsFlag = cli.StringFlag{
+		Name:  "ws.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests on the separate --ws.port (server enforced). Accepts 'any' or '*' as wildcard. Defaults to --http.vhosts",
+		Value: "",
+	}
+	WSTLSCertFlag = cli.StringFlag{
+		Name:  "ws.tls.cert",
+		Usage: "Certificate of the separate --ws.port, serves wss:// (re-read on SIGHUP)",
+		Value: "",
+	}
+	WSTLSKeyFlag = cli.StringFlag{
+		Name:  "ws.tls.key",
+		Usage: "Key file of the certificate of --ws.tls.cert",
 		Value: "",
 	}
 	WSPathPrefixFlag = cli.StringFlag{
@@ -486,6 +696,21 @@ var (
 		Usage: "Size of the channel used for websocket logs subscriptions",
 		Value: 8192,
 	}
+	WSSubscriptionBufferFlag = cli.IntFlag{
+		Name:  "ws.subscription.buffer.mb",
+		Usage: "Megabytes of notifications buffered for a single websocket subscription of a client not reading them (0 = unlimited)",
+		Value: 16,
+	}
+	WSClientBufferFlag = cli.IntFlag{
+		Name:  "ws.client.buffer.mb",
+		Usage: "Megabytes of notifications buffered for all subscriptions of a websocket client not reading them (0 = unlimited)",
+		Value: 64,
+	}
+	WSSlowConsumerFlag = cli.StringFlag{
+		Name:  "ws.slowconsumer",
+		Usage: "What happens to a websocket client over --ws.subscription.buffer.mb or --ws.client.buffer.mb: disconnect or drop (its notifications)",
+		Value: "disconnect",
+	}
 	ExecFlag = cli.StringFlag{
 		Name:  "exec",
 		Usage: "Execute JavaScript statement",
@@ -515,6 +740,20 @@ var (
 		Usage: "Maximum number of TCP connections pending to become connected peers",
 		Value: nodecfg.DefaultConfig.P2P.MaxPendingPeers,
 	}
+	P2pMaxInboundHandshakesFlag = cli.IntFlag{
+		Name:  "p2p.inbound-handshakes",
+		Usage: "Maximum number of inbound connections in the handshake phase, connections above it are dropped (0 = --maxpendpeers)",
+	}
+	P2pInboundConnsPerIPFlag = cli.IntFlag{
+		Name:  "p2p.inbound-per-ip",
+		Usage: "Number of inbound connection attempts allowed from a single Internet IP within 30 seconds",
+		Value: 1,
+	}
+	P2pInboundHandshakeRateFlag = cli.Float64Flag{
+		Name:  "p2p.inbound-handshake-rate",
+		Usage: "Budget of inbound encryption handshakes per second, bounds the CPU taken by a handshake flood (negative = unlimited)",
+		Value: 100,
+	}
 	ListenPortFlag = cli.IntFlag{
 		Name:  "port",
 		Usage: "Network listening port",
@@ -538,6 +777,14 @@ var (
 		Name:  "sentry.log-peer-info",
 		Usage: "Log detailed peer info when a peer connects or disconnects. Enable to integrate with observer.",
 	}
+	NoPreMergeHistoryFlag = cli.BoolFlag{
+		Name:  "p2p.no-pre-merge-history",
+		Usage: "Don't serve headers, bodies and receipts of the pre-merge blocks to peers, and advertise the merge block as the earliest served one in the eth/69 status and ENR (EIP-4444 history expiry)",
+	}
+	P2pLogClientDiversityFlag = cli.BoolFlag{
+		Name:  "p2p.log-client-diversity",
+		Usage: "Periodically log the breakdown of connected peers by client (anonymized: no node ids or addresses)",
+	}
 	DownloaderAddrFlag = cli.StringFlag{
 		Name:  "downloader.api.addr",
 		Usage: "downloader address '<host>:<port>'",
@@ -579,6 +826,11 @@ var (
 `,
 		Value: "",
 	}
+	NATSTUNFallbackFlag = cli.StringFlag{
+		Name:  "nat.stun-fallback",
+		Usage: `STUN server (host:port) asked for the external IP when the UPnP/NAT-PMP gateway doesn't report a public one, "none" disables the fallback`,
+		Value: nat.STUNDefaultServerAddr,
+	}
 	NoDiscoverFlag = cli.BoolFlag{
 		Name:  "nodiscover",
 		Usage: "Disables the peer discovery mechanism (manual peer addition)",
@@ -587,6 +839,10 @@ var (
 		Name:  "v5disc",
 		Usage: "Enables the experimental RLPx V5 (Topic Discovery) mechanism",
 	}
+	DiscoveryV5TopicsFlag = cli.StringFlag{
+		Name:  "v5disc.topics",
+		Usage: "Comma separated discv5 topics (e.g. a network, a shard or the data served) to advertise the node under and to find peers by, requires --v5disc",
+	}
 	NetrestrictFlag = cli.StringFlag{
 		Name:  "netrestrict",
 		Usage: "Restricts network communication to the given IP networks (CIDR masks)",
@@ -595,6 +851,26 @@ var (
 		Name:  "discovery.dns",
 		Usage: "Sets DNS discovery entry points (use \"\" to disable DNS)",
 	}
+	DiscoveryPacketRateFlag = cli.Float64Flag{
+		Name:  "discovery.packet-rate",
+		Usage: "Discovery packets per second handled from a single source IP, packets over the limit are dropped (negative = unlimited)",
+		Value: 100,
+	}
+	DiscoveryPacketBurstFlag = cli.IntFlag{
+		Name:  "discovery.packet-burst",
+		Usage: "Burst of discovery packets allowed from a single source IP over --discovery.packet-rate",
+		Value: 200,
+	}
+	P2PBanViolationsFlag = cli.UintFlag{
+		Name:  "p2p.ban.violations",
+		Usage: "Protocol violations (invalid messages, downloader penalties) after which a peer is banned",
+		Value: reputation.DefaultBanViolations,
+	}
+	P2PBanDurationFlag = cli.DurationFlag{
+		Name:  "p2p.ban.duration",
+		Usage: "Duration of the first ban of a peer, doubled by every further ban",
+		Value: reputation.DefaultBanDuration,
+	}
 
 	// ATM the url is left to the user and deployment to
 	JSpathFlag = cli.StringFlag{
@@ -1260,6 +1536,8 @@ func NewP2PConfig(
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth67")
 	case direct.ETH68:
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth68")
+	case direct.ETH69:
+		enodeDBPath = filepath.Join(dirs.Nodes, "eth69")
 	default:
 		return nil, fmt.Errorf("unknown protocol: %v", protocol)
 	}
@@ -1362,6 +1640,9 @@ func setNAT(ctx *cli.Context, cfg *p2p.Config) {
 		cfg.NAT = natif
 		cfg.NATSpec = natSetting
 	}
+	if ctx.IsSet(NATSTUNFallbackFlag.Name) {
+		cfg.NATFallback = ctx.String(NATSTUNFallbackFlag.Name)
+	}
 }
 
 // setEtherbase retrieves the etherbase from the directly specified
@@ -1408,7 +1689,28 @@ func setEtherbase(ctx *cli.Context, cfg *ethconfig.Config) {
 	}
 }
 
+var applyChainRegistryOnce sync.Once
+
+// applyChainRegistry - overrides embedded chain parameters before any of them is read. Called by both p2p and eth config setup.
+func applyChainRegistry(ctx *cli.Context, logger log.Logger) {
+	filename := ctx.String(ChainRegistryFlag.Name)
+	if filename == "" {
+		return
+	}
+	applyChainRegistryOnce.Do(func() {
+		registry, err := chainspec.ReadRegistry(filename)
+		if err != nil {
+			Fatalf("Option %s: %v", ChainRegistryFlag.Name, err)
+		}
+		if err := chainspec.ApplyRegistry(registry); err != nil {
+			Fatalf("Option %s: %v", ChainRegistryFlag.Name, err)
+		}
+		logger.Info("Applied chain registry overrides", "file", filename, "chains", len(registry))
+	})
+}
+
 func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config, nodeName, datadir string, logger log.Logger) {
+	applyChainRegistry(ctx, logger)
 	cfg.Name = nodeName
 	setNodeKey(ctx, cfg, datadir)
 	setNAT(ctx, cfg)
@@ -1425,6 +1727,15 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config, nodeName, datadir string, l
 	if ctx.IsSet(MaxPendingPeersFlag.Name) {
 		cfg.MaxPendingPeers = ctx.Int(MaxPendingPeersFlag.Name)
 	}
+	if ctx.IsSet(P2pMaxInboundHandshakesFlag.Name) {
+		cfg.MaxInboundHandshakes = ctx.Int(P2pMaxInboundHandshakesFlag.Name)
+	}
+	if ctx.IsSet(P2pInboundConnsPerIPFlag.Name) {
+		cfg.InboundConnsPerIP = ctx.Int(P2pInboundConnsPerIPFlag.Name)
+	}
+	if ctx.IsSet(P2pInboundHandshakeRateFlag.Name) {
+		cfg.InboundHandshakeRate = ctx.Float64(P2pInboundHandshakeRateFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.NoDiscovery = true
 	}
@@ -1432,10 +1743,26 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config, nodeName, datadir string, l
 	if ctx.IsSet(DiscoveryV5Flag.Name) {
 		cfg.DiscoveryV5 = ctx.Bool(DiscoveryV5Flag.Name)
 	}
+	if ctx.IsSet(DiscoveryV5TopicsFlag.Name) {
+		cfg.DiscoveryV5Topics = common.CliString2Array(ctx.String(DiscoveryV5TopicsFlag.Name))
+	}
+	if ctx.IsSet(DiscoveryPacketRateFlag.Name) {
+		cfg.DiscoveryPacketRate = ctx.Float64(DiscoveryPacketRateFlag.Name)
+	}
+	if ctx.IsSet(DiscoveryPacketBurstFlag.Name) {
+		cfg.DiscoveryPacketBurst = ctx.Int(DiscoveryPacketBurstFlag.Name)
+	}
+	if ctx.IsSet(P2PBanViolationsFlag.Name) {
+		cfg.Reputation.BanViolations = uint32(ctx.Uint(P2PBanViolationsFlag.Name))
+	}
+	if ctx.IsSet(P2PBanDurationFlag.Name) {
+		cfg.Reputation.BanDuration = ctx.Duration(P2PBanDurationFlag.Name)
+	}
 
 	if ctx.IsSet(MetricsEnabledFlag.Name) {
 		cfg.MetricsEnabled = ctx.Bool(MetricsEnabledFlag.Name)
 	}
+	cfg.LogClientDiversity = ctx.Bool(P2pLogClientDiversityFlag.Name)
 
 	logger.Info("Maximum peer count", "total", cfg.MaxPeers)
 
@@ -1548,10 +1875,15 @@ func setTxPool(ctx *cli.Context, dbDir string, fullCfg *ethconfig.Config) {
 		cfg.MinFeeCap = ctx.Uint64(TxPoolPriceLimitFlag.Name)
 	}
 	if ctx.IsSet(TxPoolPriceBumpFlag.Name) {
-		cfg.PriceBump = ctx.Uint64(TxPoolPriceBumpFlag.Name)
+		cfg.Replacement.PriceBump = ctx.Uint64(TxPoolPriceBumpFlag.Name)
 	}
+	cfg.Ordering = ctx.String(TxPoolOrderingFlag.Name)
+	cfg.OrderingAddr = ctx.String(TxPoolOrderingAddrFlag.Name)
 	if ctx.IsSet(TxPoolBlobPriceBumpFlag.Name) {
-		cfg.BlobPriceBump = ctx.Uint64(TxPoolBlobPriceBumpFlag.Name)
+		cfg.Replacement.BlobPriceBump = ctx.Uint64(TxPoolBlobPriceBumpFlag.Name)
+	}
+	if ctx.IsSet(TxPoolBlobFeeBumpFlag.Name) {
+		cfg.Replacement.BlobFeeBump = ctx.Uint64(TxPoolBlobFeeBumpFlag.Name)
 	}
 	if ctx.IsSet(TxPoolAccountSlotsFlag.Name) {
 		cfg.AccountSlots = ctx.Uint64(TxPoolAccountSlotsFlag.Name)
@@ -1562,6 +1894,39 @@ func setTxPool(ctx *cli.Context, dbDir string, fullCfg *ethconfig.Config) {
 	if ctx.IsSet(TxPoolTotalBlobPoolLimit.Name) {
 		cfg.TotalBlobPoolLimit = ctx.Uint64(TxPoolTotalBlobPoolLimit.Name)
 	}
+	if ctx.IsSet(TxPoolBlobMemoryLimitFlag.Name) {
+		cfg.BlobMemoryLimit = ctx.Uint64(TxPoolBlobMemoryLimitFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSenderMaxSlotsFlag.Name) {
+		cfg.SenderPolicy.MaxSlots = ctx.Uint64(TxPoolSenderMaxSlotsFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSenderMaxGasFlag.Name) {
+		cfg.SenderPolicy.MaxGas = ctx.Uint64(TxPoolSenderMaxGasFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSenderMaxNonceGapFlag.Name) {
+		cfg.SenderPolicy.MaxNonceGap = ctx.Uint64(TxPoolSenderMaxNonceGapFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSimulateLocalFlag.Name) {
+		cfg.SimulateLocalTxns = ctx.Bool(TxPoolSimulateLocalFlag.Name)
+	}
+	if ctx.IsSet(TxPoolParkingLimitFlag.Name) {
+		cfg.Parking.Limit = ctx.Int(TxPoolParkingLimitFlag.Name)
+	}
+	if ctx.IsSet(TxPoolParkingMaxAgeFlag.Name) {
+		cfg.Parking.MaxAge = ctx.Duration(TxPoolParkingMaxAgeFlag.Name)
+	}
+	if ctx.IsSet(TxPoolQueuedLifetimeFlag.Name) {
+		cfg.QueuedLifetime = ctx.Duration(TxPoolQueuedLifetimeFlag.Name)
+	}
+	if ctx.IsSet(TxPoolPeerRateFlag.Name) {
+		cfg.PeerIngestion.TxnsPerSecond = ctx.Float64(TxPoolPeerRateFlag.Name)
+	}
+	if ctx.IsSet(TxPoolPeerBurstFlag.Name) {
+		cfg.PeerIngestion.TxnsBurst = ctx.Int(TxPoolPeerBurstFlag.Name)
+	}
+	if ctx.IsSet(TxPoolPeerMaxPenaltyFlag.Name) {
+		cfg.PeerIngestion.MaxPenalty = ctx.Int(TxPoolPeerMaxPenaltyFlag.Name)
+	}
 	if ctx.IsSet(TxPoolGlobalSlotsFlag.Name) {
 		cfg.PendingSubPoolLimit = ctx.Int(TxPoolGlobalSlotsFlag.Name)
 	}
@@ -1580,8 +1945,16 @@ func setTxPool(ctx *cli.Context, dbDir string, fullCfg *ethconfig.Config) {
 			cfg.TracedSenders[i] = string(sender[:])
 		}
 	}
+	if ctx.IsSet(TxPoolPrioritySendersFlag.Name) {
+		senderHexes := common.CliString2Array(ctx.String(TxPoolPrioritySendersFlag.Name))
+		cfg.PrioritySenders = make([]string, len(senderHexes))
+		for i, senderHex := range senderHexes {
+			sender := common.HexToAddress(senderHex)
+			cfg.PrioritySenders[i] = string(sender[:])
+		}
+	}
 	if ctx.IsSet(TxPoolBlobPriceBumpFlag.Name) {
-		cfg.BlobPriceBump = ctx.Uint64(TxPoolBlobPriceBumpFlag.Name)
+		cfg.Replacement.BlobPriceBump = ctx.Uint64(TxPoolBlobPriceBumpFlag.Name)
 	}
 	if ctx.IsSet(DbWriteMapFlag.Name) {
 		cfg.MdbxWriteMap = ctx.Bool(DbWriteMapFlag.Name)
@@ -1589,6 +1962,10 @@ func setTxPool(ctx *cli.Context, dbDir string, fullCfg *ethconfig.Config) {
 	if ctx.IsSet(TxPoolGossipDisableFlag.Name) {
 		cfg.NoGossip = ctx.Bool(TxPoolGossipDisableFlag.Name)
 	}
+	cfg.NoDump = ctx.Bool(TxPoolDumpDisableFlag.Name)
+	cfg.FeeMarketHistoryEvery = ctx.Duration(TxPoolFeeMarketHistoryEveryFlag.Name)
+	cfg.FeeMarketHistoryLimit = ctx.Int(TxPoolFeeMarketHistoryLimitFlag.Name)
+	cfg.PropagationTraceWindow = ctx.Duration(TxPoolPropagationWindowFlag.Name)
 	cfg.AllowAA = ctx.Bool(AAFlag.Name)
 	cfg.LogEvery = 3 * time.Minute
 	cfg.CommitEvery = common.RandomizeDuration(ctx.Duration(TxPoolCommitEveryFlag.Name))
@@ -1855,6 +2232,21 @@ func setSilkworm(ctx *cli.Context, cfg *ethconfig.Config) {
 	cfg.SilkwormRpcJsonCompatibility = ctx.Bool(SilkwormRpcJsonCompatibilityFlag.Name)
 }
 
+func setWatchdog(ctx *cli.Context, cfg *ethconfig.Config, nodeConfig *nodecfg.Config) {
+	cfg.Watchdog = mem.WatchdogCfg{
+		Dir:        filepath.Join(nodeConfig.Dirs.DataDir, "watchdog"),
+		Goroutines: ctx.Int(WatchdogGoroutinesFlag.Name),
+		GCPause:    ctx.Duration(WatchdogGCPauseFlag.Name),
+		MaxFiles:   ctx.Int(WatchdogFilesFlag.Name),
+	}
+	if v := ctx.String(WatchdogRSSFlag.Name); v != "" {
+		var err error
+		if cfg.Watchdog.RSS, err = datasize.ParseString(v); err != nil {
+			Fatalf("Option %s: %v", WatchdogRSSFlag.Name, err)
+		}
+	}
+}
+
 // CheckExclusive verifies that only a single instance of the provided flags was
 // set by the user. Each flag might optionally be followed by a string type to
 // specialize it further.
@@ -1898,6 +2290,7 @@ func CheckExclusive(ctx *cli.Context, args ...interface{}) {
 
 // SetEthConfig applies eth-related command line flags to the config.
 func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.Config, logger log.Logger) {
+	applyChainRegistry(ctx, logger)
 	cfg.CaplinConfig.CaplinDiscoveryAddr = ctx.String(CaplinDiscoveryAddrFlag.Name)
 	cfg.CaplinConfig.CaplinDiscoveryPort = ctx.Uint64(CaplinDiscoveryPortFlag.Name)
 	cfg.CaplinConfig.CaplinDiscoveryTCPPort = ctx.Uint64(CaplinDiscoveryTCPPortFlag.Name)
@@ -1975,6 +2368,13 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 
 	cfg.AllowAA = ctx.Bool(AAFlag.Name)
 	cfg.Ethstats = ctx.String(EthStatsURLFlag.Name)
+	cfg.SinkURL = ctx.String(SinkURLFlag.Name)
+	cfg.SinkFromBlock = ctx.Uint64(SinkFromBlockFlag.Name)
+	cfg.MaintenanceConfig = ctx.String(MaintenanceConfigFlag.Name)
+	cfg.SnapAutoUpdate = ctx.String(SnapAutoUpdateFlag.Name)
+	cfg.SnapAutoUpdateWindow = ctx.String(SnapAutoUpdateWindowFlag.Name)
+	cfg.OpcodeOverridesFile = ctx.String(OpcodeOverridesFlag.Name)
+	setWatchdog(ctx, cfg, nodeConfig)
 
 	if ctx.Bool(ExperimentalConcurrentCommitmentFlag.Name) {
 		// cfg.ExperimentalConcurrentCommitment = true
@@ -2002,6 +2402,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 			cfg.EthDiscoveryURLs = common.CliString2Array(urls)
 		}
 	}
+	cfg.NoPreMergeHistory = ctx.Bool(NoPreMergeHistoryFlag.Name)
 
 	// Override any default configs for hard coded networks.
 	switch chain {
@@ -2027,8 +2428,16 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		logger.Info("Using developer account", "address", developer)
 
 		// Create a new developer genesis block or reuse existing one
-		cfg.Genesis = chainspec.DeveloperGenesisBlock(uint64(ctx.Int(DeveloperPeriodFlag.Name)), developer)
-		logger.Info("Using custom developer period", "seconds", cfg.Genesis.Config.Clique.Period)
+		if ctx.Bool(DeveloperPoSFlag.Name) {
+			cfg.Genesis = chainspec.DeveloperPoSGenesisBlock(developer)
+			cfg.DevCL = true
+			cfg.DevPeriod = uint64(ctx.Int(DeveloperPeriodFlag.Name))
+			cfg.Miner.EnabledPOS = true
+			logger.Info("Using proof-of-stake developer chain", "period", cfg.DevPeriod)
+		} else {
+			cfg.Genesis = chainspec.DeveloperGenesisBlock(uint64(ctx.Int(DeveloperPeriodFlag.Name)), developer)
+			logger.Info("Using custom developer period", "seconds", cfg.Genesis.Config.Clique.Period)
+		}
 		if !ctx.IsSet(MinerGasPriceFlag.Name) {
 			cfg.Miner.GasPrice = big.NewInt(1)
 		}
@@ -2045,6 +2454,13 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	if ctx.IsSet(TrustedSetupFile.Name) {
 		libkzg.SetTrustedSetupFilePath(ctx.String(TrustedSetupFile.Name))
 	}
+	if ctx.IsSet(KZGBackendFlag.Name) {
+		backend, err := libkzg.SetBackend(ctx.String(KZGBackendFlag.Name))
+		if err != nil {
+			Fatalf("Option %s: %v", KZGBackendFlag.Name, err)
+		}
+		logger.Info("KZG backend", "backend", backend.Name())
+	}
 
 	// Do this after chain config as there are chain type registration
 	// dependencies for know config which need to be set-up
@@ -68,16 +68,17 @@ type RejectedTx struct {
 type RejectedTxs []*RejectedTx
 
 type EphemeralExecResult struct {
-	StateRoot        common.Hash           `json:"stateRoot"`
-	TxRoot           common.Hash           `json:"txRoot"`
-	ReceiptRoot      common.Hash           `json:"receiptsRoot"`
-	LogsHash         common.Hash           `json:"logsHash"`
-	Bloom            types.Bloom           `json:"logsBloom"        gencodec:"required"`
-	Receipts         types.Receipts        `json:"receipts"`
-	Rejected         RejectedTxs           `json:"rejected,omitempty"`
-	Difficulty       *math.HexOrDecimal256 `json:"currentDifficulty" gencodec:"required"`
-	GasUsed          math.HexOrDecimal64   `json:"gasUsed"`
-	StateSyncReceipt *types.Receipt        `json:"-"`
+	StateRoot        common.Hash             `json:"stateRoot"`
+	TxRoot           common.Hash             `json:"txRoot"`
+	ReceiptRoot      common.Hash             `json:"receiptsRoot"`
+	LogsHash         common.Hash             `json:"logsHash"`
+	Bloom            types.Bloom             `json:"logsBloom"        gencodec:"required"`
+	Receipts         types.Receipts          `json:"receipts"`
+	Rejected         RejectedTxs             `json:"rejected,omitempty"`
+	Difficulty       *math.HexOrDecimal256   `json:"currentDifficulty" gencodec:"required"`
+	GasUsed          math.HexOrDecimal64     `json:"gasUsed"`
+	StateSyncReceipt *types.Receipt          `json:"-"`
+	Witness          *state.ExecutionWitness `json:"witness,omitempty"` // with vm.Config.CollectWitness
 }
 
 // ExecuteBlockEphemerally runs a block from provided stateReader and
@@ -91,10 +92,19 @@ func ExecuteBlockEphemerally(
 	logger log.Logger,
 ) (res *EphemeralExecResult, executeBlockErr error) {
 	defer blockExecutionTimer.ObserveDuration(time.Now())
+	if dbg.EphemeralParallel && dbg.EphemeralParallelWorkers > 1 && vmConfig.Tracer == nil && getTracer == nil && !vmConfig.StatelessExec && !vmConfig.CollectWitness && block.Transactions().Len() > 1 {
+		return ExecuteBlockEphemerallyParallel(chainConfig, vmConfig, blockHashFunc, engine, block, stateReader, stateWriter, chainReader, dbg.EphemeralParallelWorkers, logger)
+	}
 	block.Uncles()
 	ibs := state.New(stateReader)
 	ibs.SetHooks(vmConfig.Tracer)
 	header := block.Header()
+	var witness *state.ExecutionWitness
+	if vmConfig.CollectWitness {
+		witness = state.NewExecutionWitness()
+		ibs.SetWitness(witness)
+		blockHashFunc = witness.RecordingBlockHashes(blockHashFunc)
+	}
 
 	gasUsed := new(uint64)
 	usedBlobGas := new(uint64)
@@ -125,6 +135,7 @@ func ExecuteBlockEphemerally(
 	includedTxs := make(types.Transactions, 0, block.Transactions().Len())
 	receipts := make(types.Receipts, 0, block.Transactions().Len())
 	blockNum := block.NumberU64()
+	types.RecoverBlockAuthorities(block.Transactions())
 
 	for i, txn := range block.Transactions() {
 		ibs.SetTxContext(blockNum, i)
@@ -156,6 +167,24 @@ func ExecuteBlockEphemerally(
 		}
 	}
 
+	res, err := completeBlockExecution(chainConfig, vmConfig, engine, block, stateReader, stateWriter, chainReader, ibs, receipts, includedTxs, rejectedTxs, *gasUsed, *usedBlobGas, logger)
+	if err != nil {
+		return nil, err
+	}
+	res.Witness = witness
+	return res, nil
+}
+
+// completeBlockExecution checks the outcome of the block transactions executed on ibs against the header
+// and finalizes the block
+func completeBlockExecution(
+	chainConfig *chain.Config, vmConfig *vm.Config, engine consensus.Engine, block *types.Block,
+	stateReader state.StateReader, stateWriter state.StateWriter, chainReader consensus.ChainReader, ibs *state.IntraBlockState,
+	receipts types.Receipts, includedTxs types.Transactions, rejectedTxs []*RejectedTx, gasUsed, usedBlobGas uint64,
+	logger log.Logger,
+) (*EphemeralExecResult, error) {
+	header := block.Header()
+
 	receiptSha := types.DeriveSha(receipts)
 	if !vmConfig.StatelessExec && chainConfig.IsByzantium(header.Number.Uint64()) && !vmConfig.NoReceipts && receiptSha != block.ReceiptHash() {
 		if dbg.LogHashMismatchReason() {
@@ -165,12 +194,12 @@ func ExecuteBlockEphemerally(
 		return nil, fmt.Errorf("mismatched receipt headers for block %d (%s != %s)", block.NumberU64(), receiptSha.Hex(), block.ReceiptHash().Hex())
 	}
 
-	if !vmConfig.StatelessExec && *gasUsed != header.GasUsed {
-		return nil, fmt.Errorf("gas used by execution: %d, in header: %d", *gasUsed, header.GasUsed)
+	if !vmConfig.StatelessExec && gasUsed != header.GasUsed {
+		return nil, fmt.Errorf("gas used by execution: %d, in header: %d", gasUsed, header.GasUsed)
 	}
 
-	if header.BlobGasUsed != nil && *usedBlobGas != *header.BlobGasUsed {
-		return nil, fmt.Errorf("blob gas used by execution: %d, in header: %d", *usedBlobGas, *header.BlobGasUsed)
+	if header.BlobGasUsed != nil && usedBlobGas != *header.BlobGasUsed {
+		return nil, fmt.Errorf("blob gas used by execution: %d, in header: %d", usedBlobGas, *header.BlobGasUsed)
 	}
 
 	var bloom types.Bloom
@@ -199,7 +228,7 @@ func ExecuteBlockEphemerally(
 		LogsHash:    rlpHash(blockLogs),
 		Receipts:    receipts,
 		Difficulty:  (*math.HexOrDecimal256)(header.Difficulty),
-		GasUsed:     math.HexOrDecimal64(*gasUsed),
+		GasUsed:     math.HexOrDecimal64(gasUsed),
 		Rejected:    rejectedTxs,
 	}
 
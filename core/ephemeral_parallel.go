@@ -0,0 +1,463 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/holiman/uint256"
+)
+
+// ExecuteBlockEphemerallyParallel is an experimental executor of ExecuteBlockEphemerally, running the transactions
+// of the block speculatively on `workers` goroutines with optimistic concurrency control (Block-STM):
+//
+//   - every transaction executes on its own IntraBlockState over a shared VersionMap: it reads the writes of
+//     the lower transactions executed so far, or the state before the block. Reading a value which a lower
+//     transaction is going to rewrite (estimate) aborts the execution, it is resumed when that transaction is done.
+//   - transactions are validated in block order: if a value read by a transaction was rewritten since (a lower
+//     transaction was re-executed), its writes become estimates and the transaction is executed again.
+//   - dependencies announced by the consensus engine (TxDependencies, if dbg.UseTxDependencies) delay the
+//     first execution of a transaction until the transactions it depends on are executed, saving the aborts.
+//
+// Transactions don't pay fees during speculation: every transaction would conflict on the coinbase balance.
+// The results are committed in block order to a single IntraBlockState, adding the fees: the values read by
+// every transaction are compared with the committed state and if any differs (e.g. the transaction reads
+// the coinbase balance, or creates a contract) the transaction is executed again, serially. So the result
+// is always the same as of the serial execution.
+//
+// The state reader and blockHashFunc are called from multiple goroutines, calls are serialized.
+// Tracing and stateless execution are not supported. It serves only the ephemeral path (eth_getWitness,
+// t8n, stateless verification): ExecuteBlockEphemerally runs it if opted in by EPHEMERAL_EXEC_PARALLEL, see
+// dbg.EphemeralParallel. Staged sync doesn't use it, its parallel execution is exec3's (EXEC3_PARALLEL).
+func ExecuteBlockEphemerallyParallel(
+	chainConfig *chain.Config, vmConfig *vm.Config,
+	blockHashFunc func(n uint64) (common.Hash, error),
+	engine consensus.Engine, block *types.Block,
+	stateReader state.StateReader, stateWriter state.StateWriter,
+	chainReader consensus.ChainReader, workers int,
+	logger log.Logger,
+) (*EphemeralExecResult, error) {
+	if vmConfig.Tracer != nil || vmConfig.StatelessExec {
+		return nil, errors.New("parallel execution doesn't support tracing and stateless execution")
+	}
+	header := block.Header()
+	blockNum := block.NumberU64()
+	rules := chainConfig.Rules(blockNum, header.Time)
+	cfg := *vmConfig
+	cfg.SkipAnalysis = SkipAnalysis(chainConfig, blockNum)
+
+	ibs := state.New(stateReader)
+	if err := InitializeBlockExecution(engine, chainReader, header, chainConfig, ibs, stateWriter, logger, nil); err != nil {
+		return nil, err
+	}
+
+	pe := &ephemeralParallelExecutor{
+		chainConfig: chainConfig,
+		vmConfig:    cfg,
+		engine:      engine,
+		signer:      types.MakeSigner(chainConfig, blockNum, header.Time),
+		header:      header,
+		reader:      &lockedStateReader{r: stateReader},
+		versionMap:  state.NewVersionMap(),
+		io:          state.NewVersionedIO(block.Transactions().Len() + 1),
+	}
+	var hashMu sync.Mutex
+	pe.blockContext = NewEVMBlockContext(header, func(n uint64) (common.Hash, error) {
+		hashMu.Lock()
+		defer hashMu.Unlock()
+		return blockHashFunc(n)
+	}, engine, nil, chainConfig)
+	pe.blockContext.PostApplyMessage = nil // applied on commit: it may depend on the coinbase balance
+	pe.cond = sync.NewCond(&pe.mu)
+
+	// system calls of the block start are the transaction 0 of the version map, the block transactions follow
+	initIbs := state.NewWithVersionMap(pe.reader, pe.versionMap)
+	initIbs.SetTxContext(blockNum, 0)
+	if err := InitializeBlockExecution(engine, chainReader, header, chainConfig, initIbs, nil, logger, nil); err != nil {
+		return nil, err
+	}
+	pe.versionMap.FlushVersionedWrites(initIbs.VersionedWrites(false), true, "")
+
+	var deps [][]int
+	if dbg.UseTxDependencies {
+		deps = engine.TxDependencies(header)
+	}
+	for i, txn := range block.Transactions() {
+		task := &parallelTask{txn: txn}
+		if i < len(deps) {
+			task.deps = deps[i]
+		}
+		pe.tasks = append(pe.tasks, task)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range min(workers, len(pe.tasks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i, incarnation, ok := pe.next()
+				if !ok {
+					return
+				}
+				pe.done(i, pe.execute(i, incarnation))
+			}
+		}()
+	}
+	wg.Wait()
+	speculation := time.Since(start)
+
+	// commit in block order
+	blockContext := NewEVMBlockContext(header, blockHashFunc, engine, nil, chainConfig)
+	gp := new(GasPool).AddGas(block.GasLimit()).AddBlobGas(chainConfig.GetMaxBlobGasPerBlock(block.Time()))
+	var gasUsed, usedBlobGas uint64
+	receipts := make(types.Receipts, 0, len(pe.tasks))
+	var serial int
+	for i, task := range pe.tasks {
+		ibs.SetTxContext(blockNum, i)
+		valid := task.err == nil && gp.Gas() >= task.msg.Gas() && gp.BlobGas() >= task.msg.BlobGas()
+		if valid {
+			var err error
+			if valid, err = ibs.ValidateReads(task.reads); err != nil {
+				return nil, err
+			}
+		}
+
+		var receipt *types.Receipt
+		var err error
+		if valid {
+			receipt, err = pe.commit(ibs, stateWriter, rules, blockContext, i, gp, &gasUsed, &usedBlobGas)
+		} else {
+			serial++
+			evm := vm.NewEVM(blockContext, evmtypes.TxContext{}, ibs, chainConfig, cfg)
+			receipt, _, err = applyTransaction(chainConfig, engine, gp, ibs, stateWriter, header, task.txn, &gasUsed, &usedBlobGas, evm, cfg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not apply txn %d from block %d [%v]: %w", i, blockNum, task.txn.Hash().Hex(), err)
+		}
+		if !cfg.NoReceipts {
+			receipts = append(receipts, receipt)
+		}
+	}
+	logger.Debug("[parallel exec] block executed", "block", blockNum, "txs", len(pe.tasks), "executions", pe.executions,
+		"serial", serial, "speculation", speculation, "total", time.Since(start))
+
+	return completeBlockExecution(chainConfig, vmConfig, engine, block, stateReader, stateWriter, chainReader, ibs, receipts, block.Transactions(), nil, gasUsed, usedBlobGas, logger)
+}
+
+type parallelTaskStatus uint8
+
+// ordered: a transaction is executed if the status is parallelExecuted or above
+const (
+	parallelPending   parallelTaskStatus = iota // waiting to be executed
+	parallelExecuting                           // executed by a worker
+	parallelBlocked                             // aborted, waits for a lower transaction to be executed
+	parallelExecuted                            // waits for the validation
+	parallelValidated                           // final: all lower transactions are validated and its reads are still valid
+)
+
+type parallelTask struct {
+	txn         types.Transaction
+	msg         *types.Message
+	deps        []int // lower transactions which this one is expected to depend on
+	status      parallelTaskStatus
+	incarnation int
+
+	// outcome of the last execution
+	result *evmtypes.ExecutionResult
+	err    error
+	reads  state.ReadSet
+	logs   types.Logs
+}
+
+// parallelOutcome - outcome of a single execution of a transaction
+type parallelOutcome struct {
+	dep    int // index of the transaction the execution was aborted on, -1 if it completed
+	result *evmtypes.ExecutionResult
+	err    error
+	reads  state.ReadSet
+	writes state.VersionedWrites
+	logs   types.Logs
+}
+
+type ephemeralParallelExecutor struct {
+	chainConfig  *chain.Config
+	vmConfig     vm.Config
+	engine       consensus.Engine
+	signer       *types.Signer
+	header       *types.Header
+	blockContext evmtypes.BlockContext
+	reader       state.StateReader
+	versionMap   *state.VersionMap
+	io           *state.VersionedIO // indexed by the version map transaction index: block transaction index + 1
+
+	mu         sync.Mutex
+	cond       *sync.Cond
+	tasks      []*parallelTask
+	waiters    map[int][]int // transaction index -> transactions blocked on it
+	validated  int           // transactions below are validated
+	executions int
+}
+
+// next returns the lowest transaction ready for execution, blocking until there is one.
+// Returns false when all transactions are validated.
+func (pe *ephemeralParallelExecutor) next() (i int, incarnation int, ok bool) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	for pe.validated < len(pe.tasks) {
+		for i := pe.validated; i < len(pe.tasks); i++ {
+			if task := pe.tasks[i]; task.status == parallelPending && pe.depsExecuted(i) {
+				task.status = parallelExecuting
+				pe.executions++
+				return i, task.incarnation, true
+			}
+		}
+		pe.cond.Wait()
+	}
+	return 0, 0, false
+}
+
+func (pe *ephemeralParallelExecutor) depsExecuted(i int) bool {
+	for _, dep := range pe.tasks[i].deps {
+		if dep >= 0 && dep < i && pe.tasks[dep].status < parallelExecuted {
+			return false
+		}
+	}
+	return true
+}
+
+// execute runs the transaction i on top of the version map, doesn't modify the scheduler state
+func (pe *ephemeralParallelExecutor) execute(i, incarnation int) (out parallelOutcome) {
+	task := pe.tasks[i]
+	ibs := state.NewWithVersionMap(pe.reader, pe.versionMap)
+	ibs.SetTxContext(pe.header.Number.Uint64(), i+1)
+	ibs.SetVersion(incarnation)
+	out.dep = -1
+
+	defer func() {
+		// dependencies found outside of the state transition, e.g. by the system call of a service transaction
+		if r := recover(); r != nil {
+			if r == state.ErrDependency && ibs.DepTxIndex() > 0 {
+				out = parallelOutcome{dep: ibs.DepTxIndex() - 1}
+				return
+			}
+			out = parallelOutcome{dep: -1, err: fmt.Errorf("parallel execution failure: %v", r)}
+		}
+	}()
+
+	if task.msg == nil {
+		msg, err := task.txn.AsMessage(*pe.signer, pe.header.BaseFee, pe.chainConfig.Rules(pe.header.Number.Uint64(), pe.header.Time))
+		if err != nil {
+			out.err = err
+			return out
+		}
+		msg.SetCheckNonce(true)
+		task.msg = msg
+	}
+
+	evm := vm.NewEVM(pe.blockContext, NewEVMTxContext(task.msg), ibs, pe.chainConfig, pe.vmConfig)
+	gp := new(GasPool).AddGas(task.msg.Gas()).AddBlobGas(task.msg.BlobGas())
+	out.result, out.err = ApplyMessageNoFeeBurnOrTip(evm, task.msg, gp, true /* refunds */, false /* gasBailout */, pe.engine)
+	var abort ErrExecAbortError
+	if errors.As(out.err, &abort) && abort.DependencyTxIndex > 0 {
+		return parallelOutcome{dep: abort.DependencyTxIndex - 1}
+	}
+	out.reads = ibs.VersionedReads()
+	out.writes = ibs.VersionedWrites(true)
+	out.logs = ibs.GetRawLogs(i + 1)
+	return out
+}
+
+// done records the outcome of the execution of the transaction i and validates the transactions which can be
+func (pe *ephemeralParallelExecutor) done(i int, out parallelOutcome) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	defer pe.cond.Broadcast()
+
+	task := pe.tasks[i]
+	task.incarnation++
+	if out.dep >= 0 {
+		if pe.tasks[out.dep].status >= parallelExecuted {
+			task.status = parallelPending
+		} else {
+			task.status = parallelBlocked
+			if pe.waiters == nil {
+				pe.waiters = map[int][]int{}
+			}
+			pe.waiters[out.dep] = append(pe.waiters[out.dep], i)
+		}
+		return
+	}
+
+	task.result, task.err, task.reads, task.logs = out.result, out.err, out.reads, out.logs
+	task.status = parallelExecuted
+	prev := pe.io.WriteSet(i + 1)
+	pe.io.RecordReads(i+1, out.reads)
+	pe.io.RecordWrites(i+1, out.writes)
+	pe.versionMap.FlushVersionedWrites(out.writes, true, "")
+	for _, w := range prev {
+		if !pe.io.HasWritten(i+1, w.Address, w.Path, w.Key) {
+			pe.versionMap.Delete(w.Address, w.Path, w.Key, i+1, false)
+		}
+	}
+	for _, waiter := range pe.waiters[i] {
+		pe.tasks[waiter].status = parallelPending
+	}
+	delete(pe.waiters, i)
+
+	for pe.validated < len(pe.tasks) && pe.tasks[pe.validated].status == parallelExecuted {
+		v := pe.validated
+		// reads from the state (and the synthetic reads of the account loading) carry the version of the reader,
+		// not of the value: they are left to the validation of the values on commit
+		valid := state.ValidateVersion(v+1, pe.io, pe.versionMap, func(source state.ReadSource, readVersion, writeVersion state.Version) bool {
+			return source == state.StorageRead || readVersion.TxIndex == writeVersion.TxIndex && readVersion.Incarnation == writeVersion.Incarnation
+		})
+		if !valid {
+			// higher transactions reading the writes wait for the re-execution instead of using stale values
+			for _, w := range pe.io.WriteSet(v + 1) {
+				pe.versionMap.MarkEstimate(w.Address, w.Path, w.Key, v+1)
+			}
+			pe.tasks[v].status = parallelPending
+			return
+		}
+		pe.tasks[v].status = parallelValidated
+		pe.validated++
+	}
+}
+
+// commit applies the outcome of the speculative execution of the transaction to ibs, paying the fees
+func (pe *ephemeralParallelExecutor) commit(ibs *state.IntraBlockState, stateWriter state.StateWriter, rules *chain.Rules, blockContext evmtypes.BlockContext,
+	i int, gp *GasPool, gasUsed, usedBlobGas *uint64) (*types.Receipt, error) {
+	task := pe.tasks[i]
+	msg, result := task.msg, task.result
+	if err := gp.SubGas(result.GasUsed); err != nil {
+		return nil, err
+	}
+	if err := gp.SubBlobGas(msg.BlobGas()); err != nil {
+		return nil, err
+	}
+
+	coinbase := blockContext.Coinbase
+	coinbaseInitBalance, err := ibs.GetBalance(coinbase)
+	if err != nil {
+		return nil, err
+	}
+	result.CoinbaseInitBalance = coinbaseInitBalance
+	if err := ibs.ApplyVersionedWrites(pe.io.WriteSet(i + 1)); err != nil {
+		return nil, err
+	}
+	for _, l := range task.logs {
+		ibs.AddLog(l)
+	}
+	if err := ibs.AddBalance(coinbase, result.FeeTipped, tracing.BalanceIncreaseRewardTransactionFee); err != nil {
+		return nil, err
+	}
+	if result.BurntContractAddress != (common.Address{}) {
+		if err := ibs.AddBalance(result.BurntContractAddress, result.FeeBurnt, tracing.BalanceChangeUnspecified); err != nil {
+			return nil, err
+		}
+	}
+	if blockContext.PostApplyMessage != nil {
+		blockContext.PostApplyMessage(ibs, msg.From(), coinbase, result)
+	}
+	if err := ibs.FinalizeTx(rules, stateWriter); err != nil {
+		return nil, err
+	}
+	*gasUsed += result.GasUsed
+	*usedBlobGas += task.txn.GetBlobGas()
+
+	receipt := &types.Receipt{Type: task.txn.Type(), CumulativeGasUsed: *gasUsed}
+	if result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	} else {
+		receipt.Status = types.ReceiptStatusSuccessful
+	}
+	receipt.TxHash = task.txn.Hash()
+	receipt.GasUsed = result.GasUsed
+	if msg.To() == nil {
+		receipt.ContractAddress = crypto.CreateAddress(msg.From(), task.txn.GetNonce())
+	}
+	receipt.Logs = ibs.GetLogs(ibs.TxnIndex(), task.txn.Hash(), pe.header.Number.Uint64(), pe.header.Hash())
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	receipt.BlockNumber = pe.header.Number
+	receipt.TransactionIndex = uint(ibs.TxnIndex())
+	return receipt, nil
+}
+
+// lockedStateReader serializes the reads of the workers
+type lockedStateReader struct {
+	mu sync.Mutex
+	r  state.StateReader
+}
+
+func (r *lockedStateReader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.ReadAccountData(address)
+}
+
+func (r *lockedStateReader) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.ReadAccountDataForDebug(address)
+}
+
+func (r *lockedStateReader) ReadAccountStorage(address common.Address, key common.Hash) (uint256.Int, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.ReadAccountStorage(address, key)
+}
+
+func (r *lockedStateReader) HasStorage(address common.Address) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.HasStorage(address)
+}
+
+func (r *lockedStateReader) ReadAccountCode(address common.Address) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.ReadAccountCode(address)
+}
+
+func (r *lockedStateReader) ReadAccountCodeSize(address common.Address) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.ReadAccountCodeSize(address)
+}
+
+func (r *lockedStateReader) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.ReadAccountIncarnation(address)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/stages/mock"
+)
+
+// recordingWriter collects the final value of every written account and slot
+type recordingWriter map[string]string
+
+func (w recordingWriter) UpdateAccountData(address common.Address, original, account *accounts.Account) error {
+	w[fmt.Sprintf("%x", address)] = fmt.Sprintf("nonce=%d balance=%d code=%x inc=%d", account.Nonce, &account.Balance, account.CodeHash, account.Incarnation)
+	return nil
+}
+
+func (w recordingWriter) UpdateAccountCode(address common.Address, incarnation uint64, codeHash common.Hash, code []byte) error {
+	w[fmt.Sprintf("%x/code", address)] = fmt.Sprintf("%x", code)
+	return nil
+}
+
+func (w recordingWriter) DeleteAccount(address common.Address, original *accounts.Account) error {
+	w[fmt.Sprintf("%x", address)] = "deleted"
+	return nil
+}
+
+func (w recordingWriter) WriteAccountStorage(address common.Address, incarnation uint64, key common.Hash, original, value uint256.Int) error {
+	w[fmt.Sprintf("%x/%x", address, key)] = value.Hex()
+	return nil
+}
+
+func (w recordingWriter) CreateContract(address common.Address) error {
+	return nil
+}
+
+// parallelTestBlock generates a block of 4 transactions per round conflicting on the counter contract and
+// the funded account, plus a read of the coinbase balance and a contract creation
+func parallelTestBlock(tb testing.TB, rounds int) (*mock.MockSentry, *types.Block) {
+	var keys []*ecdsa.PrivateKey
+	alloc := types.GenesisAlloc{}
+	for i := 0; i < 4; i++ {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		if i < 3 { // the last one is funded within the block
+			alloc[crypto.PubkeyToAddress(key.PublicKey)] = types.GenesisAccount{Balance: big.NewInt(1e18)}
+		}
+	}
+	counter := common.HexToAddress("0xc0")        // slot0++
+	coinbaseReader := common.HexToAddress("0xc1") // slot0 = coinbase.balance
+	alloc[counter] = types.GenesisAccount{Code: common.FromHex("60005460010160005500"), Balance: new(big.Int)}
+	alloc[coinbaseReader] = types.GenesisAccount{Code: common.FromHex("413160005500"), Balance: new(big.Int)}
+	coinbase := common.HexToAddress("0xcb")
+
+	gspec := &types.Genesis{Config: chain.AllProtocolChanges, Alloc: alloc, GasLimit: 30_000_000}
+	m := mock.MockWithGenesis(tb, gspec, keys[0], false)
+	signer := types.LatestSigner(m.ChainConfig)
+
+	chainPack, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(coinbase)
+		send := func(key *ecdsa.PrivateKey, to *common.Address, value uint64, data []byte) {
+			from := crypto.PubkeyToAddress(key.PublicKey)
+			txn := &types.DynamicFeeTransaction{
+				CommonTx: types.CommonTx{Nonce: b.TxNonce(from), GasLimit: 200_000, To: to, Value: uint256.NewInt(value), Data: data},
+				ChainID:  uint256.MustFromBig(m.ChainConfig.ChainID),
+				TipCap:   uint256.NewInt(2),
+				FeeCap:   uint256.MustFromBig(new(big.Int).Add(b.GetHeader().BaseFee, big.NewInt(2))),
+			}
+			signed, err := types.SignTx(txn, *signer, key)
+			require.NoError(tb, err)
+			b.AddTx(signed)
+		}
+		funded := crypto.PubkeyToAddress(keys[3].PublicKey)
+		for round := 0; round < rounds; round++ {
+			send(keys[0], &counter, 0, nil)
+			send(keys[1], &funded, 1e15, nil)
+			send(keys[2], &common.Address{byte(round + 1)}, 1, nil) // new accounts
+			send(keys[3], &counter, 1, nil)                         // spends what it received
+			if round == 2 {
+				send(keys[2], &coinbaseReader, 0, nil)              // sees the fees of the previous transactions
+				send(keys[1], nil, 0, common.FromHex("6001600055")) // contract creation
+			}
+		}
+	})
+	require.NoError(tb, err)
+	return m, chainPack.TopBlock
+}
+
+func TestExecuteBlockEphemerallyParallel(t *testing.T) {
+	m, block := parallelTestBlock(t, 5)
+
+	tx, err := m.DB.BeginTemporalRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	chainReader := &core.FakeChainReader{Cfg: m.ChainConfig}
+
+	serialWrites := recordingWriter{}
+	serial, err := core.ExecuteBlockEphemerally(m.ChainConfig, &vm.Config{}, func(uint64) (common.Hash, error) { return common.Hash{}, nil },
+		m.Engine, block, state.NewReaderV3(tx), serialWrites, chainReader, nil, log.New())
+	require.NoError(t, err)
+
+	for _, workers := range []int{2, 4, 16} {
+		parallelWrites := recordingWriter{}
+		parallel, err := core.ExecuteBlockEphemerallyParallel(m.ChainConfig, &vm.Config{}, func(uint64) (common.Hash, error) { return common.Hash{}, nil },
+			m.Engine, block, state.NewReaderV3(tx), parallelWrites, chainReader, workers, log.New())
+		require.NoError(t, err)
+		require.Equal(t, serial.ReceiptRoot, parallel.ReceiptRoot)
+		require.Equal(t, serial.GasUsed, parallel.GasUsed)
+		require.Equal(t, serial.LogsHash, parallel.LogsHash)
+		require.Equal(t, serialWrites, parallelWrites, "workers %d", workers)
+	}
+}
+
+// BenchmarkExecuteBlockEphemerallyParallel compares the serial execution of a block of 400 transactions
+// with the parallel one, the speedup depends on the number of CPUs
+func BenchmarkExecuteBlockEphemerallyParallel(b *testing.B) {
+	m, block := parallelTestBlock(b, 100)
+
+	tx, err := m.DB.BeginTemporalRo(context.Background())
+	require.NoError(b, err)
+	defer tx.Rollback()
+	chainReader := &core.FakeChainReader{Cfg: m.ChainConfig}
+	getHash := func(uint64) (common.Hash, error) { return common.Hash{}, nil }
+
+	b.Run("serial", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := core.ExecuteBlockEphemerally(m.ChainConfig, &vm.Config{}, getHash,
+				m.Engine, block, state.NewReaderV3(tx), state.NewNoopWriter(), chainReader, nil, log.New()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	for _, workers := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := core.ExecuteBlockEphemerallyParallel(m.ChainConfig, &vm.Config{}, getHash,
+					m.Engine, block, state.NewReaderV3(tx), state.NewNoopWriter(), chainReader, workers, log.New()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
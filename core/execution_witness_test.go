@@ -0,0 +1,111 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/stages/mock"
+)
+
+func TestExecuteBlockWitness(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	counter := common.HexToAddress("0xc0") // slot0++
+	prober := common.HexToAddress("0xc1")  // slot1 = blockhash(number-1), slot2 = extcodesize(counter)
+	gspec := &types.Genesis{
+		Config: chain.AllProtocolChanges,
+		Alloc: types.GenesisAlloc{
+			sender:  {Balance: big.NewInt(1e18)},
+			counter: {Code: common.FromHex("60005460010160005500"), Balance: new(big.Int)},
+			prober:  {Code: common.FromHex("6001430340600155" + "60c03b60025500"), Balance: new(big.Int)},
+		},
+		GasLimit: 30_000_000,
+	}
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	signer := types.LatestSigner(m.ChainConfig)
+
+	chainPack, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.HexToAddress("0xcb"))
+		send := func(to common.Address, value uint64) {
+			txn := &types.DynamicFeeTransaction{
+				CommonTx: types.CommonTx{Nonce: b.TxNonce(sender), GasLimit: 200_000, To: &to, Value: uint256.NewInt(value)},
+				ChainID:  uint256.MustFromBig(m.ChainConfig.ChainID),
+				TipCap:   uint256.NewInt(2),
+				FeeCap:   uint256.MustFromBig(new(big.Int).Add(b.GetHeader().BaseFee, big.NewInt(2))),
+			}
+			signed, err := types.SignTx(txn, *signer, key)
+			require.NoError(t, err)
+			b.AddTx(signed)
+		}
+		send(counter, 0)
+		send(prober, 0)
+		send(common.Address{0xee}, 1) // a new account
+	})
+	require.NoError(t, err)
+	block := chainPack.TopBlock
+
+	tx, err := m.DB.BeginTemporalRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	chainReader := &core.FakeChainReader{Cfg: m.ChainConfig}
+	blockHashes := func(n uint64) (common.Hash, error) { return m.Genesis.Hash(), nil }
+
+	writes := recordingWriter{}
+	res, err := core.ExecuteBlockEphemerally(m.ChainConfig, &vm.Config{CollectWitness: true}, blockHashes,
+		m.Engine, block, state.NewReaderV3(tx), writes, chainReader, nil, log.New())
+	require.NoError(t, err)
+	witness := res.Witness
+	require.NotNil(t, witness)
+
+	require.Contains(t, witness.Accounts, sender)
+	require.Contains(t, witness.Accounts, counter)
+	require.Nil(t, witness.Accounts[common.Address{0xee}]) // read as absent
+	require.Contains(t, witness.Storage[counter], common.Hash{})
+	require.Equal(t, gspec.Alloc[counter].Code, witness.Code[counter])
+	require.Equal(t, map[uint64]common.Hash{0: m.Genesis.Hash()}, witness.BlockHashes)
+	require.Len(t, witness.CodeChunks(prober), 1)
+	_, err = json.Marshal(witness)
+	require.NoError(t, err)
+
+	// the witness alone is enough to re-execute the block
+	statelessWrites := recordingWriter{}
+	stateless, err := core.ExecuteBlockEphemerally(m.ChainConfig, &vm.Config{}, witness.BlockHash,
+		m.Engine, block, witness, statelessWrites, chainReader, nil, log.New())
+	require.NoError(t, err)
+	require.Equal(t, res.ReceiptRoot, stateless.ReceiptRoot)
+	require.Equal(t, writes, statelessWrites)
+
+	delete(witness.Accounts, counter)
+	_, err = core.ExecuteBlockEphemerally(m.ChainConfig, &vm.Config{}, witness.BlockHash,
+		m.Engine, block, witness, state.NewNoopWriter(), chainReader, nil, log.New())
+	require.ErrorIs(t, err, state.ErrMissingWitness)
+}
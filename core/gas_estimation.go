@@ -0,0 +1,147 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus"
+)
+
+// EstimateGas binary searches the lowest gas limit up to hi with which msg executes successfully.
+//
+// All attempts run on the IntraBlockState of evm and are reverted to a snapshot taken before each of them:
+// accounts, storage slots and code read along the call path stay loaded, so only the first attempt goes to
+// the state reader. Access lists and transient storage are prepared anew by every attempt, gas accounting
+// of cold accesses is the same as in a fresh execution. State overrides applied to the IntraBlockState
+// beforehand are kept.
+//
+// The result of the execution with hi is returned: if it failed, the estimation is not performed.
+// Cancellation of ctx aborts the running attempt and ctx.Err() is returned.
+func EstimateGas(ctx context.Context, evm *vm.EVM, msg *types.Message, gasCap, hi uint64, engine consensus.EngineReader) (uint64, *evmtypes.ExecutionResult, error) {
+	estimate, err := EstimateGasBounds(ctx, evm, msg, gasCap, hi, engine)
+	if estimate == nil {
+		return 0, nil, err
+	}
+	return estimate.Gas, estimate.Result, err
+}
+
+// GasEstimate - result of EstimateGasBounds
+type GasEstimate struct {
+	Gas uint64 // the lowest gas limit with which the message executes successfully, 0 if it fails with hi
+	// LowerBound - optimistic lower bound of the gas limit: gas used by the execution with hi before the refund.
+	// No lower limit succeeds, the estimate is above it by the gas kept by the 63/64 rule of calls and gas checks
+	// of the code.
+	LowerBound uint64
+	Attempts   int                       // executions of the message, including the one with hi
+	Result     *evmtypes.ExecutionResult // of the execution with hi
+}
+
+// EstimateGasBounds is EstimateGas returning the lower bound of the search too. If the execution with hi fails,
+// Result has the failure and Gas is 0.
+func EstimateGasBounds(ctx context.Context, evm *vm.EVM, msg *types.Message, gasCap, hi uint64, engine consensus.EngineReader) (*GasEstimate, error) {
+	ibs := evm.IntraBlockState()
+	estimate := &GasEstimate{}
+	stop := context.AfterFunc(ctx, evm.Cancel)
+	defer stop()
+
+	call := func(gas uint64) (*evmtypes.ExecutionResult, error) {
+		estimate.Attempts++
+		msg.ChangeGas(gasCap, gas)
+		evm.Reset(NewEVMTxContext(msg), ibs) // also clears the abort flag, ctx is checked after it
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		snapshot := ibs.Snapshot()
+		defer ibs.RevertToSnapshot(snapshot, nil)
+
+		gp := new(GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas())
+		result, err := ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, engine)
+		if evm.Cancelled() {
+			return nil, ctx.Err()
+		}
+		return result, err
+	}
+
+	// First try with highest gas possible
+	result, err := call(hi)
+	if result == nil && err != nil {
+		return nil, err
+	}
+	estimate.Result = result
+	if err != nil || result.Failed() {
+		return estimate, err
+	}
+	// Assuming a contract can freely run all the instructions, we have
+	// the true amount of gas it wants to consume to execute fully.
+	// We want to ensure that the gas used doesn't fall below this
+	trueGas := result.GasUsed // Must not fall below this
+	lo := max(trueGas+result.EvmRefund-1, params.TxGas-1)
+	estimate.LowerBound = lo + 1
+
+	// failed reports whether the attempt with the gas limit failed. If the error is not nil (consensus error),
+	// the message will never be accepted no matter how much gas it is assigned, except of too low intrinsic gas.
+	failed := func(gas uint64) (bool, error) {
+		res, err := call(gas)
+		if errors.Is(err, ErrIntrinsicGas) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return res.Failed() || res.GasUsed < trueGas, nil
+	}
+
+	// Most transactions need only a little more than they use: the 63/64 rule of calls and the refund.
+	// Try this limit first, most often it cuts the search range to a few percents of the used gas.
+	if optimistic := (trueGas + result.EvmRefund + params.CallStipend) * 64 / 63; lo < optimistic && optimistic < hi {
+		fail, err := failed(optimistic)
+		if err != nil {
+			return estimate, err
+		}
+		if fail {
+			lo = optimistic
+		} else {
+			hi = optimistic
+		}
+	}
+
+	// Execute the binary search and hone in on an executable gas limit
+	for lo+1 < hi {
+		mid := (hi + lo) / 2
+		if mid < trueGas {
+			lo = mid
+			continue
+		}
+		fail, err := failed(mid)
+		if err != nil {
+			return estimate, err
+		}
+		if fail {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	estimate.Gas = hi
+	return estimate, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus"
+)
+
+// countingReader is the state of a single contract, counting the reads
+type countingReader struct {
+	contract common.Address
+	code     []byte
+	storage  map[common.Hash]uint256.Int
+	reads    int
+}
+
+func (r *countingReader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	r.reads++
+	if address != r.contract {
+		return nil, nil
+	}
+	acc := accounts.NewAccount()
+	acc.CodeHash = crypto.Keccak256Hash(r.code)
+	acc.Incarnation = 1
+	return &acc, nil
+}
+
+func (r *countingReader) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	return r.ReadAccountData(address)
+}
+
+func (r *countingReader) ReadAccountStorage(address common.Address, key common.Hash) (uint256.Int, bool, error) {
+	r.reads++
+	v, ok := r.storage[key]
+	return v, ok && address == r.contract, nil
+}
+
+func (r *countingReader) HasStorage(address common.Address) (bool, error) {
+	return address == r.contract && len(r.storage) > 0, nil
+}
+
+func (r *countingReader) ReadAccountCode(address common.Address) ([]byte, error) {
+	r.reads++
+	if address != r.contract {
+		return nil, nil
+	}
+	return r.code, nil
+}
+
+func (r *countingReader) ReadAccountCodeSize(address common.Address) (int, error) {
+	code, err := r.ReadAccountCode(address)
+	return len(code), err
+}
+
+func (r *countingReader) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	return 0, nil
+}
+
+func TestEstimateGas(t *testing.T) {
+	contract := common.HexToAddress("0xc0de")
+	// SLOAD 0, SLOAD 1, revert if less than 30000 gas left, otherwise SSTORE 2 = 1:
+	// the estimate is above the used gas, and attempts must not see the slot written by the previous ones
+	code := common.FromHex("60005450600154506175305a10601657600160025500" + "5b600080fd")
+	reader := &countingReader{contract: contract, code: code, storage: map[common.Hash]uint256.Int{
+		{}: *uint256.NewInt(7),
+	}}
+
+	newEVM := func(reader state.StateReader) (*vm.EVM, *types.Message) {
+		msg := types.NewMessage(common.Address{1}, &contract, 0, new(uint256.Int), 1_000_000, new(uint256.Int), new(uint256.Int), new(uint256.Int), nil, nil, false, false, nil)
+		blockCtx := evmtypes.BlockContext{
+			CanTransfer: core.CanTransfer,
+			Transfer:    consensus.Transfer,
+			GetHash:     func(uint64) (common.Hash, error) { return common.Hash{}, nil },
+			BlockNumber: 1,
+			Difficulty:  big.NewInt(0),
+			GasLimit:    30_000_000,
+			BaseFee:     new(uint256.Int),
+			BlobBaseFee: new(uint256.Int),
+		}
+		return vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), state.New(reader), chain.AllProtocolChanges, vm.Config{NoBaseFee: true}), msg
+	}
+
+	evm, msg := newEVM(reader)
+	gas, result, err := core.EstimateGas(context.Background(), evm, msg, 0, 1_000_000, nil)
+	require.NoError(t, err)
+	require.False(t, result.Failed())
+	require.Greater(t, gas, result.GasUsed)
+
+	// only the first attempt reads the state
+	firstReads := reader.reads
+	reader.reads = 0
+	evm, msg = newEVM(reader)
+	result, err = core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.Gas()), true, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, firstReads, reader.reads)
+
+	// the estimate is the lowest limit which succeeds on a fresh state
+	for limit, fails := range map[uint64]bool{gas: false, gas - 1: true} {
+		evm, msg = newEVM(reader)
+		msg.ChangeGas(0, limit)
+		result, err = core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.Gas()), true, false, nil)
+		require.NoError(t, err)
+		require.Equal(t, fails, result.Failed(), limit)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	evm, msg = newEVM(reader)
+	_, _, err = core.EstimateGas(ctx, evm, msg, 0, 1_000_000, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
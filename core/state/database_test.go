@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/crypto"
@@ -1740,17 +1741,22 @@ func TestTxLookupUnwind(t *testing.T) {
 	if err = m.InsertChain(chain2); err != nil {
 		t.Fatal(err)
 	}
-	var count uint64
+	// the unwound transaction is not canonical anymore: its entry is a tombstone of the block it was included into
+	txnHash := chain1.Blocks[1].Transactions()[0].Hash()
 	if err = m.DB.ViewTemporal(context.Background(), func(tx kv.TemporalTx) error {
-		var e error
-		if count, e = tx.Count(kv.TxLookup); e != nil {
-			return e
-		}
+		count, err := tx.Count(kv.TxLookup)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), count)
+		blockNum, _, err := rawdb.ReadTxLookupEntry(tx, txnHash)
+		require.NoError(t, err)
+		require.Nil(t, blockNum, "txlookup record expected to be tombstoned")
+		tombstoneNum, tombstoneHash, ok, err := rawdb.ReadTxLookupTombstone(tx, txnHash)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, chain1.Blocks[1].NumberU64(), tombstoneNum)
+		require.Equal(t, chain1.Blocks[1].Hash(), tombstoneHash)
 		return nil
 	}); err != nil {
 		t.Fatal(err)
 	}
-	if count != 0 {
-		t.Errorf("txlookup record expected to be deleted, got %d", count)
-	}
 }
@@ -0,0 +1,305 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// ErrMissingWitness - the execution read state not recorded in the witness it runs on
+var ErrMissingWitness = errors.New("state missing in the execution witness")
+
+// codeChunkSize - code bytes per chunk, the chunk is prefixed by a byte: number of leading bytes which are push data
+const codeChunkSize = 31
+
+// ExecutionWitness - the pre-state read by an execution: every account, storage slot and code read from the
+// state reader (the first read of each, later reads are served by IntraBlockState), and the block hashes read by
+// BLOCKHASH. It's enough to re-execute the block without the database: ExecutionWitness is a StateReader
+// serving the recorded state. Code is read whole, so all chunks of the code of the read accounts are in the
+// witness, see CodeChunks.
+type ExecutionWitness struct {
+	Accounts        map[common.Address]*accounts.Account // nil - the account doesn't exist
+	Storage         map[common.Address]map[common.Hash]uint256.Int
+	StoragePresence map[common.Address]bool // result of HasStorage
+	Code            map[common.Address][]byte
+	Incarnations    map[common.Address]uint64
+	BlockHashes     map[uint64]common.Hash
+}
+
+func NewExecutionWitness() *ExecutionWitness {
+	return &ExecutionWitness{
+		Accounts:        map[common.Address]*accounts.Account{},
+		Storage:         map[common.Address]map[common.Hash]uint256.Int{},
+		StoragePresence: map[common.Address]bool{},
+		Code:            map[common.Address][]byte{},
+		Incarnations:    map[common.Address]uint64{},
+		BlockHashes:     map[uint64]common.Hash{},
+	}
+}
+
+// RecordingBlockHashes wraps the block hash getter of the execution to record the hashes into the witness
+func (w *ExecutionWitness) RecordingBlockHashes(getHash func(n uint64) (common.Hash, error)) func(n uint64) (common.Hash, error) {
+	return func(n uint64) (common.Hash, error) {
+		hash, err := getHash(n)
+		if err == nil {
+			w.BlockHashes[n] = hash
+		}
+		return hash, err
+	}
+}
+
+// BlockHash serves the recorded block hashes, to re-execute from the witness
+func (w *ExecutionWitness) BlockHash(n uint64) (common.Hash, error) {
+	hash, ok := w.BlockHashes[n]
+	if !ok {
+		return common.Hash{}, fmt.Errorf("%w: hash of block %d", ErrMissingWitness, n)
+	}
+	return hash, nil
+}
+
+// CodeChunks returns the code of the account split to 32-byte chunks as EIP-6800 (Verkle tree) stores it:
+// 31 bytes of code, prefixed by the number of leading bytes which are push data of an instruction of the
+// previous chunk.
+func (w *ExecutionWitness) CodeChunks(addr common.Address) [][32]byte {
+	return ChunkifyCode(w.Code[addr])
+}
+
+// ChunkifyCode splits the code to 32-byte EIP-6800 chunks
+func ChunkifyCode(code []byte) [][32]byte {
+	chunks := make([][32]byte, (len(code)+codeChunkSize-1)/codeChunkSize)
+	pc := 0 // next instruction
+	for i := range chunks {
+		start := i * codeChunkSize
+		end := min(start+codeChunkSize, len(code))
+		if pc > start {
+			chunks[i][0] = byte(min(pc, end) - start)
+		}
+		copy(chunks[i][1:], code[start:end])
+		for pc < end {
+			if op := code[pc]; op >= 0x60 && op <= 0x7f { // PUSH1..PUSH32
+				pc += int(op-0x60) + 2
+			} else {
+				pc++
+			}
+		}
+	}
+	return chunks
+}
+
+func (w *ExecutionWitness) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	acc, ok := w.Accounts[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: account %x", ErrMissingWitness, address)
+	}
+	if acc == nil {
+		return nil, nil
+	}
+	cpy := *acc
+	return &cpy, nil
+}
+
+func (w *ExecutionWitness) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	return w.ReadAccountData(address)
+}
+
+func (w *ExecutionWitness) ReadAccountStorage(address common.Address, key common.Hash) (uint256.Int, bool, error) {
+	v, ok := w.Storage[address][key]
+	if !ok {
+		return uint256.Int{}, false, fmt.Errorf("%w: storage %x %x", ErrMissingWitness, address, key)
+	}
+	return v, !v.IsZero(), nil
+}
+
+func (w *ExecutionWitness) HasStorage(address common.Address) (bool, error) {
+	has, ok := w.StoragePresence[address]
+	if !ok {
+		return false, fmt.Errorf("%w: storage presence %x", ErrMissingWitness, address)
+	}
+	return has, nil
+}
+
+func (w *ExecutionWitness) ReadAccountCode(address common.Address) ([]byte, error) {
+	code, ok := w.Code[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: code %x", ErrMissingWitness, address)
+	}
+	return code, nil
+}
+
+func (w *ExecutionWitness) ReadAccountCodeSize(address common.Address) (int, error) {
+	code, err := w.ReadAccountCode(address)
+	return len(code), err
+}
+
+func (w *ExecutionWitness) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	inc, ok := w.Incarnations[address]
+	if !ok {
+		return 0, fmt.Errorf("%w: incarnation %x", ErrMissingWitness, address)
+	}
+	return inc, nil
+}
+
+type witnessAccountJson struct {
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	Balance     *hexutil.Big   `json:"balance"`
+	CodeHash    common.Hash    `json:"codeHash"`
+	Incarnation hexutil.Uint64 `json:"incarnation"`
+}
+
+type executionWitnessJson struct {
+	Accounts     map[common.Address]*witnessAccountJson         `json:"accounts"`
+	Storage      map[common.Address]map[common.Hash]common.Hash `json:"storage"`
+	HasStorage   map[common.Address]bool                        `json:"hasStorage"`
+	Code         map[common.Address]hexutil.Bytes               `json:"code"`
+	Incarnations map[common.Address]hexutil.Uint64              `json:"incarnations"`
+	BlockHashes  map[hexutil.Uint64]common.Hash                 `json:"blockHashes"`
+}
+
+func (w *ExecutionWitness) MarshalJSON() ([]byte, error) {
+	enc := executionWitnessJson{
+		Accounts:     make(map[common.Address]*witnessAccountJson, len(w.Accounts)),
+		Storage:      make(map[common.Address]map[common.Hash]common.Hash, len(w.Storage)),
+		HasStorage:   w.StoragePresence,
+		Code:         make(map[common.Address]hexutil.Bytes, len(w.Code)),
+		Incarnations: make(map[common.Address]hexutil.Uint64, len(w.Incarnations)),
+		BlockHashes:  make(map[hexutil.Uint64]common.Hash, len(w.BlockHashes)),
+	}
+	for addr, acc := range w.Accounts {
+		if acc == nil {
+			enc.Accounts[addr] = nil
+			continue
+		}
+		enc.Accounts[addr] = &witnessAccountJson{
+			Nonce:       hexutil.Uint64(acc.Nonce),
+			Balance:     (*hexutil.Big)(acc.Balance.ToBig()),
+			CodeHash:    acc.CodeHash,
+			Incarnation: hexutil.Uint64(acc.Incarnation),
+		}
+	}
+	for addr, slots := range w.Storage {
+		enc.Storage[addr] = make(map[common.Hash]common.Hash, len(slots))
+		for key, v := range slots {
+			enc.Storage[addr][key] = v.Bytes32()
+		}
+	}
+	for addr, code := range w.Code {
+		enc.Code[addr] = code
+	}
+	for addr, inc := range w.Incarnations {
+		enc.Incarnations[addr] = hexutil.Uint64(inc)
+	}
+	for n, hash := range w.BlockHashes {
+		enc.BlockHashes[hexutil.Uint64(n)] = hash
+	}
+	return json.Marshal(enc)
+}
+
+// witnessRecorder records the reads of the state reader into the witness
+type witnessRecorder struct {
+	reader  StateReader
+	witness *ExecutionWitness
+}
+
+func (r *witnessRecorder) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	acc, err := r.reader.ReadAccountData(address)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.witness.Accounts[address]; !ok {
+		if acc == nil {
+			r.witness.Accounts[address] = nil
+		} else {
+			cpy := *acc
+			r.witness.Accounts[address] = &cpy
+		}
+	}
+	return acc, nil
+}
+
+func (r *witnessRecorder) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	return r.reader.ReadAccountDataForDebug(address)
+}
+
+func (r *witnessRecorder) ReadAccountStorage(address common.Address, key common.Hash) (uint256.Int, bool, error) {
+	v, ok, err := r.reader.ReadAccountStorage(address, key)
+	if err != nil {
+		return v, ok, err
+	}
+	slots, exists := r.witness.Storage[address]
+	if !exists {
+		slots = map[common.Hash]uint256.Int{}
+		r.witness.Storage[address] = slots
+	}
+	if _, recorded := slots[key]; !recorded {
+		if ok {
+			slots[key] = v
+		} else {
+			slots[key] = uint256.Int{}
+		}
+	}
+	return v, ok, nil
+}
+
+func (r *witnessRecorder) HasStorage(address common.Address) (bool, error) {
+	has, err := r.reader.HasStorage(address)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := r.witness.StoragePresence[address]; !ok {
+		r.witness.StoragePresence[address] = has
+	}
+	return has, nil
+}
+
+func (r *witnessRecorder) ReadAccountCode(address common.Address) ([]byte, error) {
+	code, err := r.reader.ReadAccountCode(address)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.witness.Code[address]; !ok {
+		r.witness.Code[address] = common.CopyBytes(code)
+	}
+	return code, nil
+}
+
+// ReadAccountCodeSize records the code: the size alone can't be verified against the code hash
+func (r *witnessRecorder) ReadAccountCodeSize(address common.Address) (int, error) {
+	if _, ok := r.witness.Code[address]; !ok {
+		code, err := r.ReadAccountCode(address)
+		return len(code), err
+	}
+	return r.reader.ReadAccountCodeSize(address)
+}
+
+func (r *witnessRecorder) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	inc, err := r.reader.ReadAccountIncarnation(address)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := r.witness.Incarnations[address]; !ok {
+		r.witness.Incarnations[address] = inc
+	}
+	return inc, nil
+}
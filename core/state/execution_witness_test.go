@@ -0,0 +1,47 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkifyCode(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, ChunkifyCode(nil))
+
+	// PUSH4 at the end of the first chunk: its data starts the second chunk
+	code := append(bytes.Repeat([]byte{0x00}, 30), 0x63, 0x01, 0x02, 0x03, 0x04, 0x00)
+	chunks := ChunkifyCode(code)
+	require.Len(t, chunks, 2)
+	require.Equal(t, byte(0), chunks[0][0])
+	require.Equal(t, code[:31], chunks[0][1:])
+	require.Equal(t, byte(4), chunks[1][0])
+	require.Equal(t, code[31:], chunks[1][1:6])
+
+	// PUSH32 data spans the whole second chunk
+	code = append([]byte{0x00, 0x7f}, bytes.Repeat([]byte{0xff}, 64)...)
+	chunks = ChunkifyCode(code)
+	require.Len(t, chunks, 3)
+	require.Equal(t, byte(0), chunks[0][0])
+	require.Equal(t, byte(3), chunks[1][0]) // code[31:34] is push data, code[34] is the next instruction
+	require.Equal(t, byte(0), chunks[2][0])
+}
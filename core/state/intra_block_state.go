@@ -199,6 +199,12 @@ func (sdb *IntraBlockState) SetHooks(hooks *tracing.Hooks) {
 	sdb.tracingHooks = hooks
 }
 
+// SetWitness makes the state record every account, storage slot and code it reads from the state reader into w:
+// the pre-state the execution depends on
+func (sdb *IntraBlockState) SetWitness(w *ExecutionWitness) {
+	sdb.stateReader = &witnessRecorder{reader: sdb.stateReader, witness: w}
+}
+
 func (sdb *IntraBlockState) SetTrace(trace bool) {
 	sdb.trace = trace
 }
@@ -989,6 +995,14 @@ func (sdb *IntraBlockState) GetTransientState(addr common.Address, key common.Ha
 	return sdb.transientStorage.Get(addr, key)
 }
 
+// ForEachTransientStorage calls cb for every transient storage slot changed by the current transaction,
+// ordered by address and key, until cb returns false. Writes of the current value don't change the slot.
+// Slots whose changes were reverted are reported with the value restored by the revert. The transient storage is cleared when the next transaction is prepared,
+// so it can be inspected until then, e.g. at the end of the transaction.
+func (sdb *IntraBlockState) ForEachTransientStorage(cb func(addr common.Address, key common.Hash, value uint256.Int) bool) {
+	sdb.transientStorage.ForEach(cb)
+}
+
 func (sdb *IntraBlockState) getStateObject(addr common.Address) (*stateObject, error) {
 	if so, ok := sdb.stateObjects[addr]; ok {
 		return so, nil
@@ -1016,6 +1030,10 @@ func (sdb *IntraBlockState) getStateObject(addr common.Address) (*stateObject, e
 			readAccount, _, err = versionedRead[*accounts.Account](sdb, addr, AddressPath, common.Hash{}, false, nil, nil, nil)
 
 			if readAccount == nil || err != nil {
+				if err == nil {
+					// absence of the account is a read too: creation of it by a lower transaction invalidates this one
+					sdb.versionRead(addr, AddressPath, common.Hash{}, StorageRead, (*accounts.Account)(nil))
+				}
 				return nil, err
 			}
 
@@ -1170,8 +1188,11 @@ func (sdb *IntraBlockState) CreateAccount(addr common.Address, contractCreation
 
 	data := newObj.data
 	// for newly created files these synthetic reads are used so that account
-	// creation clashes between trnascations get detected
-	sdb.versionRead(addr, AddressPath, common.Hash{}, StorageRead, &data)
+	// creation clashes between trnascations get detected. The account as loaded
+	// by getStateObject (or its absence) is kept: it is what the creation depended on
+	if _, ok := sdb.versionedReads[addr][AccountKey{Path: AddressPath}]; !ok {
+		sdb.versionRead(addr, AddressPath, common.Hash{}, StorageRead, &data)
+	}
 	sdb.versionRead(addr, BalancePath, common.Hash{}, StorageRead, newObj.Balance())
 
 	sdb.versionWritten(addr, AddressPath, common.Hash{}, &data)
@@ -1692,6 +1713,25 @@ func (sdb *IntraBlockState) VersionedWrites(checkDirty bool) VersionedWrites {
 // Apply entries in a given write set to StateDB. Note that this function does not change MVHashMap nor write set
 // of the current StateDB.
 func (sdb *IntraBlockState) ApplyVersionedWrites(writes VersionedWrites) error {
+	// contract creations go first: they reset the storage the other writes of the account apply to
+	for i := range writes {
+		if writes[i].Path != AddressPath || writes[i].Val == nil {
+			continue
+		}
+		created, ok := writes[i].Val.(*accounts.Account)
+		if !ok || created == nil {
+			continue
+		}
+		incarnation, err := sdb.GetIncarnation(writes[i].Address)
+		if err != nil {
+			return err
+		}
+		if created.Incarnation > incarnation {
+			if err := sdb.CreateAccount(writes[i].Address, true); err != nil {
+				return err
+			}
+		}
+	}
 	for i := range writes {
 		path := writes[i].Path
 		val := writes[i].Val
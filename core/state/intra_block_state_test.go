@@ -456,6 +456,39 @@ func TestTransientStorage(t *testing.T) {
 	}
 }
 
+func TestForEachTransientStorage(t *testing.T) {
+	t.Parallel()
+	state := New(nil)
+
+	a, b := common.Address{0x02}, common.Address{0x01}
+	state.SetTransientState(a, common.Hash{0x02}, *uint256.NewInt(1))
+	state.SetTransientState(a, common.Hash{0x01}, *uint256.NewInt(2))
+	snapshot := state.Snapshot()
+	state.SetTransientState(b, common.Hash{0x03}, *uint256.NewInt(3))
+	state.SetTransientState(a, common.Hash{0x02}, *uint256.NewInt(4))
+	state.RevertToSnapshot(snapshot, nil)
+
+	type slot struct {
+		addr  common.Address
+		key   common.Hash
+		value uint64
+	}
+	var slots []slot
+	state.ForEachTransientStorage(func(addr common.Address, key common.Hash, value uint256.Int) bool {
+		slots = append(slots, slot{addr, key, value.Uint64()})
+		return true
+	})
+	// ordered by address and key, the reverted write is reported with the restored value
+	assert.Equal(t, []slot{{b, common.Hash{0x03}, 0}, {a, common.Hash{0x01}, 2}, {a, common.Hash{0x02}, 1}}, slots)
+
+	slots = slots[:0]
+	state.ForEachTransientStorage(func(addr common.Address, key common.Hash, value uint256.Int) bool {
+		slots = append(slots, slot{addr, key, value.Uint64()})
+		return false
+	})
+	assert.Len(t, slots, 1)
+}
+
 func TestVersionMapReadWriteDelete(t *testing.T) {
 	t.Parallel()
 
@@ -415,6 +415,8 @@ type Writer struct {
 	trace       bool
 	accumulator *shards.Accumulator
 	txNum       uint64
+	growth      *shards.TxStateGrowth // nil - not collected
+	writes      *uint64               // nil - not counted
 }
 
 func NewWriter(tx kv.TemporalPutDel, accumulator *shards.Accumulator, txNum uint64) *Writer {
@@ -429,6 +431,18 @@ func NewWriter(tx kv.TemporalPutDel, accumulator *shards.Accumulator, txNum uint
 func (w *Writer) SetTxNum(v uint64) { w.txNum = v }
 func (w *Writer) ResetWriteSet()    {}
 
+// SetStateGrowth - following writes are counted into g, nil - not counted
+func (w *Writer) SetStateGrowth(g *shards.TxStateGrowth) { w.growth = g }
+
+// SetWriteCounter - following writes of accounts, code and storage slots are counted into c, nil - not counted
+func (w *Writer) SetWriteCounter(c *uint64) { w.writes = c }
+
+func (w *Writer) countWrite() {
+	if w.writes != nil {
+		*w.writes++
+	}
+}
+
 func (w *Writer) WriteSet() map[string]*libstate.KvList {
 	return nil
 }
@@ -458,6 +472,10 @@ func (w *Writer) UpdateAccountData(address common.Address, original, account *ac
 	if err := w.tx.DomainPut(kv.AccountsDomain, address[:], value, w.txNum, nil, 0); err != nil {
 		return err
 	}
+	if w.growth != nil && !original.Initialised {
+		w.growth.AccountCreated()
+	}
+	w.countWrite()
 	return nil
 }
 
@@ -471,6 +489,10 @@ func (w *Writer) UpdateAccountCode(address common.Address, incarnation uint64, c
 	if w.accumulator != nil {
 		w.accumulator.ChangeCode(address, incarnation, code)
 	}
+	if w.growth != nil {
+		w.growth.CodeDeployed(address, len(code))
+	}
+	w.countWrite()
 	return nil
 }
 
@@ -488,6 +510,10 @@ func (w *Writer) DeleteAccount(address common.Address, original *accounts.Accoun
 	if err := w.tx.DomainDel(kv.AccountsDomain, address[:], w.txNum, nil, 0); err != nil {
 		return err
 	}
+	if w.growth != nil && original.Initialised {
+		w.growth.AccountDeleted()
+	}
+	w.countWrite()
 	// if w.accumulator != nil { TODO: investigate later. basically this will always panic. keeping this out should be fine anyway.
 	// 	w.accumulator.DeleteAccount(address)
 	// }
@@ -503,6 +529,10 @@ func (w *Writer) WriteAccountStorage(address common.Address, incarnation uint64,
 	if w.trace {
 		fmt.Printf("storage: %x,%x,%x\n", address, key, v)
 	}
+	if w.growth != nil {
+		w.growth.SlotWritten(address, original.IsZero(), len(v) == 0)
+	}
+	w.countWrite()
 	if len(v) == 0 {
 		return w.tx.DomainDel(kv.StorageDomain, composite, w.txNum, nil, 0)
 	}
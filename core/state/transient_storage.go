@@ -20,6 +20,9 @@
 package state
 
 import (
+	"bytes"
+	"slices"
+
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/holiman/uint256"
 )
@@ -48,3 +51,24 @@ func (t transientStorage) Get(addr common.Address, key common.Hash) uint256.Int
 	}
 	return val[key]
 }
+
+// ForEach calls cb for every slot in the storage, ordered by address and key, until cb returns false.
+func (t transientStorage) ForEach(cb func(addr common.Address, key common.Hash, value uint256.Int) bool) {
+	addrs := make([]common.Address, 0, len(t))
+	for addr := range t {
+		addrs = append(addrs, addr)
+	}
+	slices.SortFunc(addrs, func(a, b common.Address) int { return bytes.Compare(a[:], b[:]) })
+	for _, addr := range addrs {
+		keys := make([]common.Hash, 0, len(t[addr]))
+		for key := range t[addr] {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, func(a, b common.Hash) int { return bytes.Compare(a[:], b[:]) })
+		for _, key := range keys {
+			if !cb(addr, key, t[addr][key]) {
+				return
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -190,6 +191,66 @@ func valueString(path AccountPath, value any) string {
 
 var ErrDependency = errors.New("found dependency")
 
+// ValidateReads reports whether the values of reads, recorded by a speculative execution of a transaction,
+// are the same in sdb: if so, execution of the transaction on top of sdb has the same outcome.
+// Unlike ValidateVersion it compares values, not versions, so it doesn't depend on how the values got into sdb.
+func (sdb *IntraBlockState) ValidateReads(reads ReadSet) (bool, error) {
+	for addr, keys := range reads {
+		for key, read := range keys {
+			if ok, err := sdb.readMatches(addr, key, read.Val); !ok || err != nil {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func (sdb *IntraBlockState) readMatches(addr common.Address, key AccountKey, val any) (bool, error) {
+	switch key.Path {
+	case AddressPath:
+		acc, _ := val.(*accounts.Account)
+		so, err := sdb.getStateObject(addr)
+		if err != nil {
+			return false, err
+		}
+		if so == nil || so.deleted {
+			return acc == nil, nil
+		}
+		return acc != nil && acc.Nonce == so.data.Nonce && acc.Balance.Eq(&so.data.Balance) && acc.CodeHash == so.data.CodeHash, nil
+	case BalancePath:
+		v, ok := val.(uint256.Int)
+		balance, err := sdb.GetBalance(addr)
+		return ok && v.Eq(&balance), err
+	case NoncePath:
+		v, ok := val.(uint64)
+		nonce, err := sdb.GetNonce(addr)
+		return ok && v == nonce, err
+	case CodePath:
+		v, ok := val.([]byte)
+		code, err := sdb.GetCode(addr)
+		return ok && bytes.Equal(v, code), err
+	case CodeHashPath:
+		v, ok := val.(common.Hash)
+		hash, err := sdb.GetCodeHash(addr)
+		return ok && v == hash, err
+	case CodeSizePath:
+		v, ok := val.(int)
+		size, err := sdb.GetCodeSize(addr)
+		return ok && v == size, err
+	case SelfDestructPath:
+		v, ok := val.(bool)
+		destructed, err := sdb.HasSelfdestructed(addr)
+		return ok && v == destructed, err
+	case StatePath:
+		v, ok := val.(uint256.Int)
+		var value uint256.Int
+		err := sdb.GetState(addr, key.Key, &value)
+		return ok && v.Eq(&value), err
+	default:
+		return false, nil
+	}
+}
+
 type versionedStateReader struct {
 	txIndex     int
 	reads       ReadSet
@@ -20,7 +20,6 @@
 package core
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"slices"
@@ -385,6 +384,7 @@ func (st *StateTransition) ApplyFrame() (*evmtypes.ExecutionResult, error) {
 		ret   []byte
 		vmerr error // vm errors do not effect consensus and are therefore not assigned to err
 	)
+	opcodeStats, coverage := st.evm.ResetOpcodeStats(), st.evm.ResetCoverage()
 
 	ret, st.gasRemaining, vmerr = st.evm.Call(sender, st.to(), st.data, st.gasRemaining, st.value, false)
 
@@ -395,6 +395,8 @@ func (st *StateTransition) ApplyFrame() (*evmtypes.ExecutionResult, error) {
 		ReturnData:          ret,
 		SenderInitBalance:   senderInitBalance,
 		CoinbaseInitBalance: coinbaseInitBalance,
+		OpcodeStats:         opcodeStats,
+		Coverage:            coverage,
 	}
 
 	if st.evm.Context.PostApplyMessage != nil {
@@ -531,6 +533,7 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (result *
 		ret   []byte
 		vmerr error // vm errors do not effect consensus and are therefore not assigned to err
 	)
+	opcodeStats, coverage := st.evm.ResetOpcodeStats(), st.evm.ResetCoverage()
 
 	if contractCreation {
 		// The reason why we don't increment nonce here is that we need the original
@@ -610,6 +613,8 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (result *
 		FeeTipped:           *tipAmount,
 		FeeBurnt:            burnAmount,
 		EvmRefund:           st.state.GetRefund(),
+		OpcodeStats:         opcodeStats,
+		Coverage:            coverage,
 	}
 
 	if burntContractAddress != nil {
@@ -629,11 +634,8 @@ func (st *StateTransition) verifyAuthorities(auths []types.Authorization, contra
 		if contractCreation {
 			return nil, errors.New("contract creation not allowed with type4 txs")
 		}
-		var b [32]byte
-		data := bytes.NewBuffer(nil)
+		authorities, recoverErrs := types.RecoverAuthorities(auths)
 		for i, auth := range auths {
-			data.Reset()
-
 			// 1. chainId check
 			if !auth.ChainID.IsZero() && chainID != auth.ChainID.String() {
 				log.Debug("invalid chainID, skipping", "chainId", auth.ChainID, "auth index", i)
@@ -641,12 +643,11 @@ func (st *StateTransition) verifyAuthorities(auths []types.Authorization, contra
 			}
 
 			// 2. authority recover
-			authorityPtr, err := auth.RecoverSigner(data, b[:])
-			if err != nil {
+			if err := recoverErrs[i]; err != nil {
 				log.Debug("authority recover failed, skipping", "err", err, "auth index", i)
 				continue
 			}
-			authority := *authorityPtr
+			authority := authorities[i]
 
 			// 3. add authority account to accesses_addresses
 			verifiedAuthorities = append(verifiedAuthorities, authority)
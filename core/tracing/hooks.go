@@ -50,6 +50,8 @@ type IntraBlockState interface {
 	GetState(addr common.Address, key common.Hash, value *uint256.Int) error
 	Exist(common.Address) (bool, error)
 	GetRefund() uint64
+	GetTransientState(addr common.Address, key common.Hash) uint256.Int
+	ForEachTransientStorage(cb func(addr common.Address, key common.Hash, value uint256.Int) bool)
 }
 
 // VMContext provides the context for the EVM execution.
@@ -0,0 +1,158 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+)
+
+var (
+	// BasicBlocksCacheLimit - number of (code, instruction set) analyses kept for Config.BlockFusion
+	BasicBlocksCacheLimit = dbg.EnvInt("BB_LRU", 1024)
+	// blockFusionDefault - enables Config.BlockFusion for all interpreters, e.g. of the execution stage
+	blockFusionDefault = dbg.EnvBool("EVM_BLOCK_FUSION", false)
+
+	basicBlocksCache = newBasicBlocksCache(BasicBlocksCacheLimit)
+)
+
+// basicBlock - straight-line sequence of instructions: it's entered only at its first instruction and all its
+// instructions are executed unless one of them fails
+type basicBlock struct {
+	end      uint64 // pc of the last instruction
+	gas      uint64 // sum of constant gas of the instructions
+	stackMin int    // stack items the instructions need
+	stackMax int    // max stack length at the entry which doesn't overflow in the block
+}
+
+// basicBlocks - result of basic blocks analysis of legacy code for a jump table
+type basicBlocks struct {
+	blocks []basicBlock
+	index  []uint32 // pc -> index+1 in blocks of the block starting at pc, 0 - no block starts at pc
+}
+
+// at returns the block starting at pc, nil if pc is not a start of a block
+func (bb *basicBlocks) at(pc uint64) *basicBlock {
+	if pc >= uint64(len(bb.index)) || bb.index[pc] == 0 {
+		return nil
+	}
+	return &bb.blocks[bb.index[pc]-1]
+}
+
+// endsBasicBlock - instructions after which a new block starts: besides control flow, instructions
+// which observe remaining gas - it must not include constant gas of the instructions following them
+func endsBasicBlock(op OpCode, operation *operation) bool {
+	switch op {
+	case STOP, JUMP, JUMPI, RETURN, REVERT, SELFDESTRUCT, INVALID,
+		GAS, SSTORE, CALL, CALLCODE, DELEGATECALL, STATICCALL, CREATE, CREATE2:
+		return true
+	}
+	return operation.undefined
+}
+
+// analyzeBasicBlocks splits legacy code into basic blocks: a block starts at pc 0, at JUMPDEST
+// and after an instruction ending a block
+func analyzeBasicBlocks(code []byte, jt *JumpTable) *basicBlocks {
+	bb := &basicBlocks{index: make([]uint32, len(code))}
+	var (
+		cur    *basicBlock
+		height int // stack height relative to the entry of the current block
+	)
+	for pc := uint64(0); pc < uint64(len(code)); pc++ {
+		op := OpCode(code[pc])
+		operation := jt[op]
+		if cur == nil || op == JUMPDEST {
+			bb.blocks = append(bb.blocks, basicBlock{stackMax: int(params.StackLimit)})
+			bb.index[pc] = uint32(len(bb.blocks))
+			cur, height = &bb.blocks[len(bb.blocks)-1], 0
+		}
+		cur.end = pc
+		cur.gas += operation.constantGas
+		cur.stackMin = max(cur.stackMin, operation.numPop-height)
+		height += operation.numPush - operation.numPop
+		cur.stackMax = min(cur.stackMax, int(params.StackLimit)-height)
+		if op >= PUSH1 && op <= PUSH32 {
+			pc += uint64(op - PUSH0)
+		}
+		if endsBasicBlock(op, operation) {
+			cur = nil
+		}
+	}
+	return bb
+}
+
+type basicBlocksKey struct {
+	codeHash common.Hash
+	jt       *JumpTable // constant gas differs between forks
+}
+
+type basicBlocksCacheT struct {
+	*lru.Cache[basicBlocksKey, *basicBlocks]
+}
+
+func newBasicBlocksCache(limit int) *basicBlocksCacheT {
+	c, err := lru.New[basicBlocksKey, *basicBlocks](limit)
+	if err != nil {
+		panic(err)
+	}
+	return &basicBlocksCacheT{Cache: c}
+}
+
+// basicBlocksOf returns the analysis of contract's code, shared by all frames running the same code
+func basicBlocksOf(contract *Contract, jt *JumpTable) *basicBlocks {
+	// initcode not in state - analysis is done for the frame only
+	if contract.CodeHash == (common.Hash{}) {
+		return analyzeBasicBlocks(contract.Code, jt)
+	}
+	key := basicBlocksKey{codeHash: contract.CodeHash, jt: jt}
+	if bb, ok := basicBlocksCache.Get(key); ok {
+		return bb
+	}
+	bb := analyzeBasicBlocks(contract.Code, jt)
+	basicBlocksCache.Add(key, bb)
+	return bb
+}
+
+// runBasicBlock executes block b starting at *pc. Constant gas of the block is already charged and its stack
+// bounds checked at the entry, so only dynamic gas is charged per instruction. On return *pc is the pc of
+// the next instruction to execute.
+func (in *EVMInterpreter) runBasicBlock(b *basicBlock, pc *uint64, jt *JumpTable, scope *ScopeContext) (res []byte, err error) {
+	code := scope.Contract.Code
+	for {
+		last := *pc == b.end
+		operation := jt[code[*pc]]
+		var memorySize uint64
+		if operation.dynamicGas != nil {
+			if memorySize, _, err = in.useDynamicGas(operation, scope); err != nil {
+				return nil, err
+			}
+			if memorySize > 0 {
+				scope.Memory.Resize(memorySize)
+			}
+		}
+		if res, err = operation.execute(pc, in, scope); err != nil {
+			return res, err
+		}
+		*pc++
+		if last {
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeBasicBlocks(t *testing.T) {
+	t.Parallel()
+	code := []byte{
+		byte(PUSH1), byte(JUMPDEST), // 0: JUMPDEST in push data doesn't start a block
+		byte(ADD),         // 2: needs 1 more item than pushed
+		byte(PUSH1), 0x00, // 3
+		byte(JUMPI),              // 5: ends the block
+		byte(JUMPDEST),           // 6
+		byte(PUSH0), byte(PUSH0), // 7
+		byte(GAS),            // 9: ends the block
+		byte(POP), byte(POP), // 10
+	}
+	bb := analyzeBasicBlocks(code, &cancunInstructionSet)
+	require.Len(t, bb.blocks, 3)
+
+	b := bb.at(0)
+	require.NotNil(t, b)
+	require.Equal(t, uint64(5), b.end)
+	require.Equal(t, 3*GasFastestStep+GasSlowStep, b.gas)
+	require.Equal(t, 1, b.stackMin)
+	require.Equal(t, 1023, b.stackMax)
+
+	for pc := uint64(1); pc < 6; pc++ {
+		require.Nil(t, bb.at(pc))
+	}
+	b = bb.at(6)
+	require.NotNil(t, b)
+	require.Equal(t, uint64(9), b.end)
+	require.Equal(t, 0, b.stackMin)
+	require.Equal(t, 1021, b.stackMax)
+
+	b = bb.at(10)
+	require.NotNil(t, b)
+	require.Equal(t, uint64(11), b.end)
+	require.Equal(t, 2, b.stackMin)
+	require.Nil(t, bb.at(12))
+}
@@ -68,6 +68,9 @@ type Contract struct {
 
 	Gas   uint64
 	value *uint256.Int
+
+	eof         *eofContainer // nil for legacy code
+	returnStack []uint64      // EIP-4750: return addresses of CALLF
 }
 
 type JumpDestCache struct {
@@ -96,6 +99,14 @@ func (c *JumpDestCache) LogStats() {
 	log.Warn("[dbg] JumpDestCache", "hit", c.hit, "total", c.total, "limit", JumpDestCacheLimit, "ratio", fmt.Sprintf("%.2f", float64(c.hit)/float64(c.total)))
 }
 
+// Stats returns number of lookups of the cache which found the analysis and number of all lookups
+func (c *JumpDestCache) Stats() (hit, total int) {
+	if c == nil {
+		return 0, 0
+	}
+	return c.hit, c.total
+}
+
 // NewContract returns a new contract environment for the execution of EVM.
 func NewContract(caller ContractRef, addr common.Address, value *uint256.Int, gas uint64, skipAnalysis bool, jumpDest *JumpDestCache) *Contract {
 	return &Contract{
@@ -24,6 +24,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"math/big"
+	"slices"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
@@ -35,6 +36,7 @@ import (
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/common/math"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/crypto/blake2b"
@@ -236,8 +238,16 @@ func init() {
 	}
 }
 
-// ActivePrecompiles returns the precompiles enabled with the current configuration.
+// ActivePrecompiles returns the precompiles enabled with the current configuration, including registered ones.
 func ActivePrecompiles(rules *chain.Rules) []common.Address {
+	builtin := builtinPrecompileAddresses(rules)
+	if registered := registeredPrecompileAddresses(rules, builtin); len(registered) > 0 {
+		return append(slices.Clone(builtin), registered...)
+	}
+	return builtin
+}
+
+func builtinPrecompileAddresses(rules *chain.Rules) []common.Address {
 	switch {
 	case rules.IsOsaka:
 		return PrecompiledAddressesOsaka
@@ -260,6 +270,29 @@ func ActivePrecompiles(rules *chain.Rules) []common.Address {
 	}
 }
 
+func builtinPrecompiledContracts(rules *chain.Rules) map[common.Address]PrecompiledContract {
+	switch {
+	case rules.IsOsaka:
+		return PrecompiledContractsOsaka
+	case rules.IsBhilai:
+		return PrecompiledContractsBhilai
+	case rules.IsPrague:
+		return PrecompiledContractsPrague
+	case rules.IsNapoli:
+		return PrecompiledContractsNapoli
+	case rules.IsCancun:
+		return PrecompiledContractsCancun
+	case rules.IsBerlin:
+		return PrecompiledContractsBerlin
+	case rules.IsIstanbul:
+		return PrecompiledContractsIstanbul
+	case rules.IsByzantium:
+		return PrecompiledContractsByzantium
+	default:
+		return PrecompiledContractsHomestead
+	}
+}
+
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
 // It returns
 // - the returned bytes,
@@ -1074,6 +1107,137 @@ func (c *bls12381Pairing) Run(input []byte) ([]byte, error) {
 	return out, nil
 }
 
+// bls12381PairingCacheLimit bounds the number of points and pairs kept by bls12381BatchPairing
+const bls12381PairingCacheLimit = 1024
+
+// blsReferencePairingDefault - enables Config.BLSReferencePairing for all EVMs, e.g. of the execution stage
+var blsReferencePairingDefault = dbg.EnvBool("EVM_BLS_REFERENCE_PAIRING", false)
+
+// bls12381BatchPairing is the EIP-2537 Pairing precompile which amortizes work across calls of a transaction:
+// points which passed subgroup checks are not checked again, and Miller loops of pairs seen in earlier calls
+// (e.g. many signatures verified against one public key) are not recomputed. All pairs of a call
+// still share a single final exponentiation. Gas and results are the same as of bls12381Pairing.
+type bls12381BatchPairing struct {
+	bls12381Pairing
+	g1    map[[128]byte]bls12381.G1Affine // decoded points in the subgroup
+	g2    map[[256]byte]bls12381.G2Affine
+	seen  map[[384]byte]struct{}    // pairs of previous calls
+	loops map[[384]byte]bls12381.GT // Miller loops of pairs seen more than once
+}
+
+func newBLS12381BatchPairing() *bls12381BatchPairing {
+	return &bls12381BatchPairing{
+		g1:    map[[128]byte]bls12381.G1Affine{},
+		g2:    map[[256]byte]bls12381.G2Affine{},
+		seen:  map[[384]byte]struct{}{},
+		loops: map[[384]byte]bls12381.GT{},
+	}
+}
+
+func (c *bls12381BatchPairing) Run(input []byte) ([]byte, error) {
+	k := len(input) / 384
+	if len(input) == 0 || len(input)%384 != 0 {
+		return nil, errBLS12381InvalidInputLength
+	}
+
+	var (
+		p []bls12381.G1Affine // pairs without a known Miller loop, evaluated by a single multi-Miller loop
+		q []bls12381.G2Affine
+		f bls12381.GT
+	)
+	f.SetOne()
+	newPairs := make([][384]byte, 0, k)
+	for i := 0; i < k; i++ {
+		pair := [384]byte(input[384*i : 384*(i+1)])
+		if loop, ok := c.loops[pair]; ok {
+			f.Mul(&f, &loop)
+			continue
+		}
+		// same order of checks as of the reference implementation
+		g1, g2 := [128]byte(pair[:128]), [256]byte(pair[128:])
+		p1, checked1, err := c.decodeG1(g1)
+		if err != nil {
+			return nil, err
+		}
+		p2, checked2, err := c.decodeG2(g2)
+		if err != nil {
+			return nil, err
+		}
+		if !checked1 {
+			if !p1.IsInSubGroup() {
+				return nil, errBLS12381G1PointSubgroup
+			}
+			if len(c.g1) < bls12381PairingCacheLimit {
+				c.g1[g1] = p1
+			}
+		}
+		if !checked2 {
+			if !p2.IsInSubGroup() {
+				return nil, errBLS12381G2PointSubgroup
+			}
+			if len(c.g2) < bls12381PairingCacheLimit {
+				c.g2[g2] = p2
+			}
+		}
+		if _, ok := c.seen[pair]; ok && len(c.loops) < bls12381PairingCacheLimit {
+			loop, err := bls12381.MillerLoop([]bls12381.G1Affine{p1}, []bls12381.G2Affine{p2})
+			if err != nil {
+				return nil, err
+			}
+			c.loops[pair] = loop
+			f.Mul(&f, &loop)
+			continue
+		}
+		newPairs = append(newPairs, pair)
+		p = append(p, p1)
+		q = append(q, p2)
+	}
+	if len(p) > 0 {
+		loop, err := bls12381.MillerLoop(p, q)
+		if err != nil {
+			return nil, err
+		}
+		f.Mul(&f, &loop)
+	}
+	// Miller loops of these pairs are kept when they are seen again
+	for _, pair := range newPairs {
+		if len(c.seen) >= bls12381PairingCacheLimit {
+			break
+		}
+		c.seen[pair] = struct{}{}
+	}
+
+	out := make([]byte, 32)
+	if f = bls12381.FinalExponentiation(&f); f.IsOne() {
+		out[31] = 1
+	}
+	return out, nil
+}
+
+// decodeG1 decodes a G1 point, checked is true if the point is known to be in the subgroup
+func (c *bls12381BatchPairing) decodeG1(in [128]byte) (p bls12381.G1Affine, checked bool, err error) {
+	if p, ok := c.g1[in]; ok {
+		return p, true, nil
+	}
+	decoded, err := decodePointG1(in[:])
+	if err != nil {
+		return p, false, err
+	}
+	return *decoded, false, nil
+}
+
+// decodeG2 decodes a G2 point, checked is true if the point is known to be in the subgroup
+func (c *bls12381BatchPairing) decodeG2(in [256]byte) (p bls12381.G2Affine, checked bool, err error) {
+	if p, ok := c.g2[in]; ok {
+		return p, true, nil
+	}
+	decoded, err := decodePointG2(in[:])
+	if err != nil {
+		return p, false, err
+	}
+	return *decoded, false, nil
+}
+
 func decodePointG1(in []byte) (*bls12381.G1Affine, error) {
 	if len(in) != 128 {
 		return nil, errors.New("invalid g1 point length")
@@ -295,6 +295,35 @@ func TestPrecompiledModExpInputEip7823(t *testing.T) {
 	assert.ErrorIs(t, err, errModExpExponentLengthTooLarge)
 }
 
+// slowPrecompile - identity taking 2ms per call
+type slowPrecompile struct{ dataCopy }
+
+func (c *slowPrecompile) Run(input []byte) ([]byte, error) {
+	time.Sleep(2 * time.Millisecond)
+	return c.dataCopy.Run(input)
+}
+
+func TestPrecompileMetrics(t *testing.T) {
+	defer func(d time.Duration) { precompileSlowCall = d }(precompileSlowCall)
+	precompileSlowCall = time.Millisecond
+
+	addr := common.HexToAddress("0xfe")
+	input := make([]byte, 64)
+	gas := allPrecompiles[common.BytesToAddress([]byte{4})].RequiredGas(input)
+	ret, remaining, err := runPrecompiledContract(addr, &slowPrecompile{}, input, gas+10, nil)
+	require.NoError(t, err)
+	require.Equal(t, input, ret)
+	require.Equal(t, uint64(10), remaining)
+	// not enough gas for the call: it's counted, consuming all gas
+	_, _, err = runPrecompiledContract(addr, &slowPrecompile{}, input, gas-1, nil)
+	require.ErrorIs(t, err, ErrOutOfGas)
+
+	m := precompileMetricsOf(addr)
+	require.Equal(t, uint64(2), m.calls.GetValueUint64())
+	require.Equal(t, 2*gas-1, m.gas.GetValueUint64())
+	require.Equal(t, uint64(1), m.slowCalls.GetValueUint64())
+}
+
 // Tests the sample inputs from the elliptic curve scalar multiplication EIP 213.
 func TestPrecompiledBn256ScalarMul(t *testing.T)      { testJson("bn256ScalarMul", "07", t) }
 func BenchmarkPrecompiledBn256ScalarMul(b *testing.B) { benchJson("bn256ScalarMul", "07", b) }
@@ -446,3 +475,69 @@ func TestPrecompiledP256Verify(t *testing.T) {
 	testJson("p256Verify", "100", t)
 	testJson("p256Verify-EIP-7951", "a100", t)
 }
+
+// TestPrecompiledBLS12381BatchPairing runs the pairing vectors repeatedly against a single bls12381BatchPairing,
+// so that later calls hit its caches, and compares the results with the reference implementation.
+func TestPrecompiledBLS12381BatchPairing(t *testing.T) {
+	var tests []precompiledTest
+	for _, name := range []string{"blsPairing", "blsPairing-eip"} {
+		loaded, err := loadJson(name)
+		require.NoError(t, err)
+		tests = append(tests, loaded...)
+	}
+	var failures []precompiledFailureTest
+	for _, name := range []string{"blsPairing", "blsPairing-eip"} {
+		loaded, err := loadJsonFail(name)
+		require.NoError(t, err)
+		failures = append(failures, loaded...)
+	}
+
+	reference, batch := &bls12381Pairing{}, newBLS12381BatchPairing()
+	for round := 0; round < 3; round++ {
+		for _, test := range tests {
+			in := common.Hex2Bytes(test.Input)
+			require.Equal(t, reference.RequiredGas(in), batch.RequiredGas(in), test.Name)
+			res, err := batch.Run(in)
+			require.NoError(t, err, test.Name)
+			require.Equal(t, test.Expected, common.Bytes2Hex(res), "%s, round %d", test.Name, round)
+			require.Equal(t, common.Hex2Bytes(test.Input), in, "input modified")
+		}
+		for _, test := range failures {
+			in := common.Hex2Bytes(test.Input)
+			_, refErr := reference.Run(in)
+			_, err := batch.Run(in)
+			require.Equal(t, refErr, err, test.Name)
+		}
+	}
+	require.NotEmpty(t, batch.loops)
+
+	// a call mixing pairs with and without cached Miller loops
+	in := common.Hex2Bytes(tests[0].Input)
+	for _, test := range tests[1:] {
+		in = append(in, common.Hex2Bytes(test.Input)...)
+	}
+	want, err := reference.Run(in)
+	require.NoError(t, err)
+	res, err := batch.Run(in)
+	require.NoError(t, err)
+	require.Equal(t, want, res)
+}
+
+func BenchmarkPrecompiledBLS12381BatchPairing(b *testing.B) {
+	tests, err := loadJson("blsPairing")
+	if err != nil {
+		b.Fatal(err)
+	}
+	// repeated calls of a transaction, e.g. verification of signatures of one key
+	in := common.Hex2Bytes(tests[len(tests)-1].Input)
+	for _, p := range []PrecompiledContract{&bls12381Pairing{}, newBLS12381BatchPairing()} {
+		b.Run(fmt.Sprintf("%T", p), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := p.Run(in); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
+)
+
+// EOF (EVM Object Format) v1 container, EIP-3540:
+//
+//	magic(0xef00) version(0x01)
+//	kind_types(0x01) types_size(uint16)
+//	kind_code(0x02) num_code_sections(uint16) code_size(uint16)+
+//	kind_data(0xff) data_size(uint16)
+//	terminator(0x00)
+//	types: inputs(uint8) outputs(uint8) max_stack_height(uint16), one entry per code section
+//	code sections, data section
+//
+// Subcontainer sections (kind 0x03) are not supported.
+const (
+	eofVersion         = 0x01
+	eofKindTypes       = 0x01
+	eofKindCode        = 0x02
+	eofKindContainer   = 0x03
+	eofKindData        = 0xff
+	eofTerminator      = 0x00
+	eofTypeSize        = 4
+	eofMaxCodeSections = 1024
+	eofMaxIO           = 0x7f
+	eofNonReturning    = 0x80 // outputs of a section which never returns to its caller
+	eofMaxStackHeight  = 1023
+	eofMaxReturnStack  = 1024
+)
+
+type eofSectionType struct {
+	inputs, outputs uint8
+	maxStackHeight  uint16
+}
+
+type eofContainer struct {
+	types       []eofSectionType
+	codeOffsets []uint64 // offset of every code section in the container, the last element is the end of the last section
+}
+
+func hasEOFMagic(code []byte) bool {
+	return len(code) >= 2 && code[0] == 0xef && code[1] == 0x00
+}
+
+// eofInstructionSet returns instructions of EOF code, nil if EOF is not enabled
+func eofInstructionSet(rules *chain.Rules) *JumpTable {
+	switch {
+	case !rules.IsEOF:
+		return nil
+	case rules.IsOsaka:
+		return &osakaEOFInstructionSet
+	default:
+		return &pragueEOFInstructionSet
+	}
+}
+
+func readEOFUint16(b []byte, pos int) (uint16, bool) {
+	if pos+2 > len(b) {
+		return 0, false
+	}
+	return binary.BigEndian.Uint16(b[pos:]), true
+}
+
+func parseEOFHeader(b []byte) (*eofContainer, error) {
+	if !hasEOFMagic(b) {
+		return nil, fmt.Errorf("%w: invalid magic", ErrInvalidEOF)
+	}
+	if len(b) < 3 || b[2] != eofVersion {
+		return nil, fmt.Errorf("%w: unsupported version", ErrInvalidEOF)
+	}
+	pos := 3
+	if pos >= len(b) || b[pos] != eofKindTypes {
+		return nil, fmt.Errorf("%w: missing types header", ErrInvalidEOF)
+	}
+	typesSize, ok := readEOFUint16(b, pos+1)
+	if !ok {
+		return nil, fmt.Errorf("%w: truncated header", ErrInvalidEOF)
+	}
+	pos += 3
+	if pos >= len(b) || b[pos] != eofKindCode {
+		return nil, fmt.Errorf("%w: missing code header", ErrInvalidEOF)
+	}
+	numSections, ok := readEOFUint16(b, pos+1)
+	if !ok {
+		return nil, fmt.Errorf("%w: truncated header", ErrInvalidEOF)
+	}
+	pos += 3
+	if numSections == 0 || numSections > eofMaxCodeSections {
+		return nil, fmt.Errorf("%w: invalid number of code sections: %d", ErrInvalidEOF, numSections)
+	}
+	if int(typesSize) != eofTypeSize*int(numSections) {
+		return nil, fmt.Errorf("%w: types section size %d doesn't match %d code sections", ErrInvalidEOF, typesSize, numSections)
+	}
+	codeSizes := make([]uint16, numSections)
+	for i := range codeSizes {
+		if codeSizes[i], ok = readEOFUint16(b, pos); !ok {
+			return nil, fmt.Errorf("%w: truncated header", ErrInvalidEOF)
+		}
+		if codeSizes[i] == 0 {
+			return nil, fmt.Errorf("%w: code section %d is empty", ErrInvalidEOF, i)
+		}
+		pos += 2
+	}
+	if pos < len(b) && b[pos] == eofKindContainer {
+		return nil, fmt.Errorf("%w: subcontainers are not supported", ErrInvalidEOF)
+	}
+	if pos >= len(b) || b[pos] != eofKindData {
+		return nil, fmt.Errorf("%w: missing data header", ErrInvalidEOF)
+	}
+	dataSize, ok := readEOFUint16(b, pos+1)
+	if !ok {
+		return nil, fmt.Errorf("%w: truncated header", ErrInvalidEOF)
+	}
+	pos += 3
+	if pos >= len(b) || b[pos] != eofTerminator {
+		return nil, fmt.Errorf("%w: missing header terminator", ErrInvalidEOF)
+	}
+	pos++
+
+	c := &eofContainer{types: make([]eofSectionType, numSections), codeOffsets: make([]uint64, numSections+1)}
+	bodySize := int(typesSize) + int(dataSize)
+	for _, size := range codeSizes {
+		bodySize += int(size)
+	}
+	if pos+bodySize != len(b) {
+		return nil, fmt.Errorf("%w: container size %d doesn't match header %d", ErrInvalidEOF, len(b), pos+bodySize)
+	}
+	for i := range c.types {
+		typ := eofSectionType{inputs: b[pos], outputs: b[pos+1], maxStackHeight: binary.BigEndian.Uint16(b[pos+2:])}
+		switch {
+		case i == 0 && (typ.inputs != 0 || typ.outputs != eofNonReturning):
+			return nil, fmt.Errorf("%w: first code section must have 0 inputs and be non-returning", ErrInvalidEOF)
+		case typ.inputs > eofMaxIO || (typ.outputs > eofMaxIO && typ.outputs != eofNonReturning):
+			return nil, fmt.Errorf("%w: code section %d has too many inputs or outputs", ErrInvalidEOF, i)
+		case typ.maxStackHeight > eofMaxStackHeight:
+			return nil, fmt.Errorf("%w: code section %d max stack height %d exceeds limit", ErrInvalidEOF, i, typ.maxStackHeight)
+		}
+		c.types[i] = typ
+		pos += eofTypeSize
+	}
+	for i, size := range codeSizes {
+		c.codeOffsets[i] = uint64(pos)
+		pos += int(size)
+	}
+	c.codeOffsets[numSections] = uint64(pos)
+	return c, nil
+}
+
+// validateEOF parses the container and validates its code sections (EIP-3670, EIP-4200, EIP-4750, EIP-5450):
+// only instructions defined in EOF, no truncated immediates, relative jumps to instruction boundaries of the
+// same section, CALLF to returning sections, no unreachable code, consistent stack heights and last instruction
+// is terminating. The container may be executed without runtime stack underflow checks of its own frames.
+func validateEOF(b []byte, jt *JumpTable) (*eofContainer, error) {
+	c, err := parseEOFHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	for i := range c.types {
+		if err := c.validateSection(b[c.codeOffsets[i]:c.codeOffsets[i+1]], i, jt); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// eofInstructionSize returns size of the instruction at pos with its immediates, 0 if immediates are truncated
+func eofInstructionSize(code []byte, pos int) int {
+	size := 1
+	switch op := OpCode(code[pos]); {
+	case op >= PUSH1 && op <= PUSH32:
+		size += int(op-PUSH1) + 1
+	case op == RJUMP || op == RJUMPI || op == CALLF:
+		size += 2
+	case op == RJUMPV:
+		if pos+1 >= len(code) {
+			return 0
+		}
+		size += 1 + 2*(int(code[pos+1])+1)
+	}
+	if pos+size > len(code) {
+		return 0
+	}
+	return size
+}
+
+func isEOFTerminating(op OpCode) bool {
+	switch op {
+	case STOP, RETURN, REVERT, INVALID, RETF, RJUMP:
+		return true
+	}
+	return false
+}
+
+func (c *eofContainer) validateSection(code []byte, section int, jt *JumpTable) error {
+	typ := c.types[section]
+	starts := make([]bool, len(code))
+	var last OpCode
+	for pos := 0; pos < len(code); {
+		op := OpCode(code[pos])
+		if jt[op].undefined {
+			return fmt.Errorf("%w: section %d, pos %d: undefined instruction %s", ErrInvalidEOF, section, pos, op)
+		}
+		size := eofInstructionSize(code, pos)
+		if size == 0 {
+			return fmt.Errorf("%w: section %d, pos %d: truncated immediate of %s", ErrInvalidEOF, section, pos, op)
+		}
+		switch op {
+		case CALLF:
+			idx := binary.BigEndian.Uint16(code[pos+1:])
+			if int(idx) >= len(c.types) {
+				return fmt.Errorf("%w: section %d, pos %d: CALLF to non-existing section %d", ErrInvalidEOF, section, pos, idx)
+			}
+			if c.types[idx].outputs == eofNonReturning {
+				return fmt.Errorf("%w: section %d, pos %d: CALLF to non-returning section %d", ErrInvalidEOF, section, pos, idx)
+			}
+		case RETF:
+			if typ.outputs == eofNonReturning {
+				return fmt.Errorf("%w: section %d, pos %d: RETF in non-returning section", ErrInvalidEOF, section, pos)
+			}
+		}
+		starts[pos] = true
+		last = op
+		pos += size
+	}
+	if !isEOFTerminating(last) {
+		return fmt.Errorf("%w: section %d: last instruction %s is not terminating", ErrInvalidEOF, section, last)
+	}
+	return c.validateStack(code, section, jt, starts)
+}
+
+// validateStack - EIP-5450: every reachable instruction has the same stack height on all paths to it.
+// Heights are relative to the frame of the section, which starts with its inputs.
+func (c *eofContainer) validateStack(code []byte, section int, jt *JumpTable, starts []bool) error {
+	typ := c.types[section]
+	heights := make([]int, len(code))
+	for i := range heights {
+		heights[i] = -1
+	}
+	heights[0] = int(typ.inputs)
+	worklist := []int{0}
+	maxHeight, visited := int(typ.inputs), 0
+
+	visit := func(from, to, height int) error {
+		if to < 0 || to >= len(code) || !starts[to] {
+			return fmt.Errorf("%w: section %d, pos %d: invalid jump destination %d", ErrInvalidEOF, section, from, to)
+		}
+		if heights[to] == -1 {
+			heights[to] = height
+			worklist = append(worklist, to)
+		} else if heights[to] != height {
+			return fmt.Errorf("%w: section %d, pos %d: stack height %d differs from %d on another path", ErrInvalidEOF, section, to, height, heights[to])
+		}
+		return nil
+	}
+	for len(worklist) > 0 {
+		pos := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+		visited++
+		op, height := OpCode(code[pos]), heights[pos]
+		pop, push := jt[op].numPop, jt[op].numPush
+		if op == CALLF {
+			callee := c.types[binary.BigEndian.Uint16(code[pos+1:])]
+			pop, push = int(callee.inputs), int(callee.outputs)
+			if height+int(callee.maxStackHeight)-int(callee.inputs) > int(params.StackLimit) {
+				return fmt.Errorf("%w: section %d, pos %d: CALLF may overflow stack", ErrInvalidEOF, section, pos)
+			}
+		}
+		if height < pop {
+			return fmt.Errorf("%w: section %d, pos %d: stack underflow of %s", ErrInvalidEOF, section, pos, op)
+		}
+		next := height - pop + push
+		if next > int(params.StackLimit) {
+			return fmt.Errorf("%w: section %d, pos %d: stack overflow of %s", ErrInvalidEOF, section, pos, op)
+		}
+		maxHeight = max(maxHeight, next)
+
+		size := eofInstructionSize(code, pos)
+		var err error
+		switch op {
+		case RETF:
+			if height != int(typ.outputs) {
+				return fmt.Errorf("%w: section %d, pos %d: RETF with stack height %d, expected %d outputs", ErrInvalidEOF, section, pos, height, typ.outputs)
+			}
+		case STOP, RETURN, REVERT, INVALID:
+		case RJUMP:
+			err = visit(pos, pos+size+int(int16(binary.BigEndian.Uint16(code[pos+1:]))), next)
+		case RJUMPI:
+			if err = visit(pos, pos+size, next); err == nil {
+				err = visit(pos, pos+size+int(int16(binary.BigEndian.Uint16(code[pos+1:]))), next)
+			}
+		case RJUMPV:
+			err = visit(pos, pos+size, next)
+			for i := 0; err == nil && i <= int(code[pos+1]); i++ {
+				err = visit(pos, pos+size+int(int16(binary.BigEndian.Uint16(code[pos+2+2*i:]))), next)
+			}
+		default:
+			err = visit(pos, pos+size, next)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	instructions := 0
+	for _, start := range starts {
+		if start {
+			instructions++
+		}
+	}
+	if visited != instructions {
+		return fmt.Errorf("%w: section %d: unreachable code", ErrInvalidEOF, section)
+	}
+	if maxHeight != int(typ.maxStackHeight) {
+		return fmt.Errorf("%w: section %d: max stack height %d, declared %d", ErrInvalidEOF, section, maxHeight, typ.maxStackHeight)
+	}
+	return nil
+}
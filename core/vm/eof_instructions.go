@@ -0,0 +1,84 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+package vm
+
+import (
+	"encoding/binary"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+)
+
+// EOF code is validated before execution: immediates are not truncated, jump destinations
+// and called sections exist. pc is incremented by the interpreter after every instruction.
+
+func eofRelativeJump(code []byte, immediate, next uint64) uint64 {
+	offset := int16(binary.BigEndian.Uint16(code[immediate:]))
+	return uint64(int64(next)+int64(offset)) - 1
+}
+
+func opRjump(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	*pc = eofRelativeJump(scope.Contract.Code, *pc+1, *pc+3)
+	return nil, nil
+}
+
+func opRjumpi(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	cond := scope.Stack.pop()
+	if cond.IsZero() {
+		*pc += 2
+		return nil, nil
+	}
+	*pc = eofRelativeJump(scope.Contract.Code, *pc+1, *pc+3)
+	return nil, nil
+}
+
+func opRjumpv(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	code := scope.Contract.Code
+	count := uint64(code[*pc+1]) + 1
+	next := *pc + 2 + 2*count
+	idx := scope.Stack.pop()
+	if !idx.LtUint64(count) {
+		*pc = next - 1
+		return nil, nil
+	}
+	*pc = eofRelativeJump(code, *pc+2+2*idx.Uint64(), next)
+	return nil, nil
+}
+
+func opCallf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	contract := scope.Contract
+	idx := binary.BigEndian.Uint16(contract.Code[*pc+1:])
+	typ := contract.eof.types[idx]
+	if sLen := scope.Stack.len(); sLen+int(typ.maxStackHeight)-int(typ.inputs) > int(params.StackLimit) {
+		return nil, &ErrStackOverflow{stackLen: sLen, limit: int(params.StackLimit) - int(typ.maxStackHeight) + int(typ.inputs)}
+	}
+	if len(contract.returnStack) >= eofMaxReturnStack {
+		return nil, ErrReturnStackExceeded
+	}
+	contract.returnStack = append(contract.returnStack, *pc+3)
+	*pc = contract.eof.codeOffsets[idx] - 1
+	return nil, nil
+}
+
+func opRetf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	contract := scope.Contract
+	n := len(contract.returnStack)
+	if n == 0 {
+		return nil, ErrInvalidRetsub
+	}
+	*pc = contract.returnStack[n-1] - 1
+	contract.returnStack = contract.returnStack[:n-1]
+	return nil, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+)
+
+func TestValidateEOF(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name, code, err string
+	}{
+		{"functions and relative jumps", "ef000101000802000200150003ff00000000800003010100026003e3000180600614e10001fe60005260206000f38001e4", ""},
+		{"data section", "ef00010100040200010001ff00020000800000000102", ""},
+		{"jump table", "ef00010100040200010009ff000000008000016001e2010000000000", ""},
+		{"legacy code", "6000", "invalid magic"},
+		{"unsupported version", "ef0002", "unsupported version"},
+		{"truncated header", "ef000101", "truncated header"},
+		{"body size mismatch", "ef00010100040200010001ff000000008000000000", "container size"},
+		{"first section returns", "ef00010100040200010001ff00000000000000e4", "non-returning"},
+		{"not terminating", "ef00010100040200010002ff000000008000016000", "not terminating"},
+		{"truncated push", "ef00010100040200010002ff000000008000006100", "truncated immediate"},
+		{"dynamic jump", "ef00010100040200010002ff000000008000005600", "undefined instruction JUMP"},
+		{"jump into immediate", "ef00010100040200010006ff000000008000016000e0fffc00", "invalid jump destination"},
+		{"unreachable code", "ef00010100040200010002ff000000008000000000", "unreachable code"},
+		{"max stack height", "ef00010100040200010004ff0000000080000260005000", "declared 2"},
+		{"stack underflow", "ef00010100040200010002ff000000008000000100", "stack underflow"},
+		{"callf to missing section", "ef00010100040200010004ff00000000800000e3000100", "non-existing section"},
+		{"retf in first section", "ef00010100040200010001ff00000000800000e4", "RETF in non-returning section"},
+		{"stack height mismatch", "ef00010100040200010008ff000000008000026001e10002600000", "differs"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateEOF(hexutil.MustDecode("0x"+tt.code), &pragueEOFInstructionSet)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidEOF)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -45,7 +45,9 @@ var (
 	ErrInvalidRetsub            = errors.New("invalid retsub")
 	ErrReturnStackExceeded      = errors.New("return stack limit reached")
 	ErrInvalidCode              = errors.New("invalid code")
+	ErrInvalidEOF               = errors.New("invalid EOF container")
 	ErrNonceUintOverflow        = errors.New("nonce uint64 overflow")
+	ErrMemoryLimitExceeded      = errors.New("memory limit exceeded")
 
 	// errStopToken is an internal token indicating interpreter loop termination,
 	// never returned to outside callers.
@@ -160,6 +162,7 @@ const (
 	VMErrorInvalidSubroutineEntry
 	VMErrorInvalidRetsub
 	VMErrorReturnStackExceeded
+	VMErrorCodeMemoryLimitExceeded
 
 	// VMErrorCodeUnknown explicitly marks an error as unknown, this is useful when error is converted
 	// from an actual `error` in which case if the mapping is not known, we can use this value to indicate that.
@@ -190,7 +193,7 @@ func vmErrorCodeFromErr(err error) int {
 		return VMErrorCodeReturnDataOutOfBounds
 	case errors.Is(err, ErrGasUintOverflow):
 		return VMErrorCodeGasUintOverflow
-	case errors.Is(err, ErrInvalidCode):
+	case errors.Is(err, ErrInvalidCode), errors.Is(err, ErrInvalidEOF):
 		return VMErrorCodeInvalidCode
 	case errors.Is(err, ErrNonceUintOverflow):
 		return VMErrorCodeNonceUintOverflow
@@ -200,6 +203,8 @@ func vmErrorCodeFromErr(err error) int {
 		return VMErrorInvalidRetsub
 	case errors.Is(err, ErrReturnStackExceeded):
 		return VMErrorReturnStackExceeded
+	case errors.Is(err, ErrMemoryLimitExceeded):
+		return VMErrorCodeMemoryLimitExceeded
 
 	default:
 		// Dynamic errors
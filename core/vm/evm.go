@@ -41,28 +41,13 @@ import (
 var emptyHash = common.Hash{}
 
 func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
-	var precompiles map[common.Address]PrecompiledContract
-	switch {
-	case evm.chainRules.IsOsaka:
-		precompiles = PrecompiledContractsOsaka
-	case evm.chainRules.IsBhilai:
-		precompiles = PrecompiledContractsBhilai
-	case evm.chainRules.IsPrague:
-		precompiles = PrecompiledContractsPrague
-	case evm.chainRules.IsNapoli:
-		precompiles = PrecompiledContractsNapoli
-	case evm.chainRules.IsCancun:
-		precompiles = PrecompiledContractsCancun
-	case evm.chainRules.IsBerlin:
-		precompiles = PrecompiledContractsBerlin
-	case evm.chainRules.IsIstanbul:
-		precompiles = PrecompiledContractsIstanbul
-	case evm.chainRules.IsByzantium:
-		precompiles = PrecompiledContractsByzantium
-	default:
-		precompiles = PrecompiledContractsHomestead
-	}
-	p, ok := precompiles[addr]
+	p, ok := evm.precompiles[addr]
+	if _, pairing := p.(*bls12381Pairing); pairing && !evm.config.BLSReferencePairing && !blsReferencePairingDefault {
+		if evm.blsPairing == nil {
+			evm.blsPairing = newBLS12381BatchPairing()
+		}
+		return evm.blsPairing, true
+	}
 	return p, ok
 }
 
@@ -86,6 +71,8 @@ type EVM struct {
 	chainConfig *chain.Config
 	// chain rules contains the chain rules for the current epoch
 	chainRules *chain.Rules
+	// precompiles active with chainRules, see RegisterPrecompile
+	precompiles map[common.Address]PrecompiledContract
 	// virtual machine configuration options used to initialise the
 	// evm.
 	config Config
@@ -98,6 +85,12 @@ type EVM struct {
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
 	callGasTemp uint64
+	// opcodeStats of the current transaction, nil unless config.CollectOpcodeStats
+	opcodeStats *evmtypes.OpcodeStats
+	// coverage of the current transaction, nil unless config.Coverage
+	coverage *evmtypes.Coverage
+	// blsPairing amortizes EIP-2537 pairing calls of the current transaction, unless config.BLSReferencePairing
+	blsPairing *bls12381BatchPairing
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
@@ -116,11 +109,14 @@ func NewEVM(blockCtx evmtypes.BlockContext, txCtx evmtypes.TxContext, ibs *state
 		chainConfig:     chainConfig,
 		chainRules:      chainConfig.Rules(blockCtx.BlockNumber, blockCtx.Time),
 	}
+	evm.precompiles = ActivePrecompiledContracts(evm.chainRules)
 	if evm.config.JumpDestCache == nil {
 		evm.config.JumpDestCache = NewJumpDestCache(JumpDestCacheLimit)
 	}
 
 	evm.interpreter = NewEVMInterpreter(evm, vmConfig)
+	evm.ResetOpcodeStats()
+	evm.ResetCoverage()
 
 	return evm
 }
@@ -130,6 +126,7 @@ func NewEVM(blockCtx evmtypes.BlockContext, txCtx evmtypes.TxContext, ibs *state
 func (evm *EVM) Reset(txCtx evmtypes.TxContext, ibs *state.IntraBlockState) {
 	evm.TxContext = txCtx
 	evm.intraBlockState = ibs
+	evm.blsPairing = nil
 
 	// ensure the evm is reset to be used again
 	evm.abort.Store(false)
@@ -149,8 +146,12 @@ func (evm *EVM) ResetBetweenBlocks(blockCtx evmtypes.BlockContext, txCtx evmtype
 	}
 	evm.config = vmConfig
 	evm.chainRules = chainRules
+	evm.precompiles = ActivePrecompiledContracts(chainRules)
+	evm.blsPairing = nil
 
 	evm.interpreter = NewEVMInterpreter(evm, vmConfig)
+	evm.ResetOpcodeStats()
+	evm.ResetCoverage()
 
 	// ensure the evm is reset to be used again
 	evm.abort.Store(false)
@@ -173,6 +174,36 @@ func (evm *EVM) SetCallGasTemp(gas uint64) {
 	evm.callGasTemp = gas
 }
 
+// OpcodeStats returns stats collected since the last ResetOpcodeStats, nil unless Config.CollectOpcodeStats
+func (evm *EVM) OpcodeStats() *evmtypes.OpcodeStats {
+	return evm.opcodeStats
+}
+
+// ResetOpcodeStats starts collection of new stats (e.g. for the next transaction) and returns them. Previously
+// returned stats are not modified anymore.
+func (evm *EVM) ResetOpcodeStats() *evmtypes.OpcodeStats {
+	evm.opcodeStats = nil
+	if evm.config.CollectOpcodeStats {
+		evm.opcodeStats = &evmtypes.OpcodeStats{}
+	}
+	return evm.opcodeStats
+}
+
+// Coverage returns program counters executed since the last ResetCoverage, nil unless Config.Coverage
+func (evm *EVM) Coverage() *evmtypes.Coverage {
+	return evm.coverage
+}
+
+// ResetCoverage starts collection of new coverage (e.g. for the next transaction) and returns it. Previously
+// returned coverage is not modified anymore.
+func (evm *EVM) ResetCoverage() *evmtypes.Coverage {
+	evm.coverage = nil
+	if evm.config.Coverage {
+		evm.coverage = &evmtypes.Coverage{}
+	}
+	return evm.coverage
+}
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreter() Interpreter {
 	return evm.interpreter
@@ -252,7 +283,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 
 	// It is allowed to call precompiles, even via delegatecall
 	if isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config().Tracer)
+		ret, gas, err = runPrecompiledContract(addr, p, input, gas, evm.Config().Tracer)
 	} else if len(code) == 0 {
 		// If the account has no code, we can abort here
 		// The depth-check is already done, and precompiles handled above
@@ -439,6 +470,9 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 		return nil, address, gasRemaining, nil
 	}
 
+	// EOF initcode is validated by the interpreter before execution
+	eofJt := eofInstructionSet(evm.chainRules)
+	isEOF := eofJt != nil && hasEOFMagic(codeAndHash.code)
 	ret, err = evm.interpreter.Run(contract, nil, false)
 
 	// EIP-170: Contract code size limit
@@ -450,8 +484,15 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 		}
 	}
 
-	// Reject code starting with 0xEF if EIP-3541 is enabled.
-	if err == nil && evm.chainRules.IsLondon && len(ret) >= 1 && ret[0] == 0xEF {
+	// EOF initcode must deploy valid EOF code. Reject code starting with 0xEF if EIP-3541 is enabled,
+	// so legacy initcode can't deploy EOF code.
+	if err == nil && isEOF {
+		if !hasEOFMagic(ret) {
+			err = ErrInvalidCode
+		} else {
+			_, err = validateEOF(ret, eofJt)
+		}
+	} else if err == nil && evm.chainRules.IsLondon && len(ret) >= 1 && ret[0] == 0xEF {
 		err = ErrInvalidCode
 	}
 	// If the contract creation ran successfully and no errors were returned,
@@ -18,6 +18,7 @@ package evmtypes
 
 import (
 	"math/big"
+	"math/bits"
 
 	"github.com/holiman/uint256"
 
@@ -73,7 +74,60 @@ type ExecutionResult struct {
 	FeeTipped            uint256.Int
 	FeeBurnt             uint256.Int
 	BurntContractAddress common.Address
-	EvmRefund            uint64 // Gas refunded by EVM without considering refundQuotient
+	EvmRefund            uint64       // Gas refunded by EVM without considering refundQuotient
+	OpcodeStats          *OpcodeStats // nil unless vm.Config.CollectOpcodeStats
+	Coverage             *Coverage    // nil unless vm.Config.Coverage
+}
+
+// OpcodeStats - per-opcode number of executed instructions and gas charged for them, indexed by opcode.
+// Gas which CALL-like instructions forward to the callee is not included: it's accounted to the callee's instructions
+type OpcodeStats struct {
+	Count [256]uint64
+	Gas   [256]uint64
+}
+
+func (s *OpcodeStats) Add(op byte, gas uint64) {
+	s.Count[op]++
+	s.Gas[op] += gas
+}
+
+// TotalGas - gas charged by all instructions
+func (s *OpcodeStats) TotalGas() (total uint64) {
+	for _, gas := range s.Gas {
+		total += gas
+	}
+	return total
+}
+
+// Coverage - program counters executed by call frames of a transaction
+type Coverage struct {
+	Calls []*CallCoverage // in order of entering the frames
+}
+
+// CallCoverage - bit `pc` of Bitmap is set if the instruction at `pc` of the code was executed by the frame
+type CallCoverage struct {
+	Depth       int // 1 - the frame of the transaction
+	CodeAddress common.Address
+	CodeHash    common.Hash
+	Bitmap      []byte // (len(code)+7)/8 bytes
+}
+
+func NewCallCoverage(depth int, codeAddress common.Address, codeHash common.Hash, codeLen int) *CallCoverage {
+	return &CallCoverage{Depth: depth, CodeAddress: codeAddress, CodeHash: codeHash, Bitmap: make([]byte, (codeLen+7)/8)}
+}
+
+func (c *CallCoverage) Set(pc uint64) { c.Bitmap[pc>>3] |= 1 << (pc & 7) }
+
+func (c *CallCoverage) Executed(pc uint64) bool {
+	return pc>>3 < uint64(len(c.Bitmap)) && c.Bitmap[pc>>3]&(1<<(pc&7)) != 0
+}
+
+// Covered - number of executed instructions of the code
+func (c *CallCoverage) Covered() (n int) {
+	for _, b := range c.Bitmap {
+		n += bits.OnesCount8(b)
+	}
+	return n
 }
 
 // Unwrap returns the internal evm error which allows us for further
@@ -0,0 +1,265 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+)
+
+// Forks of mainnet in order of activation: index in fuzzForks is the fork number of the reference gas table
+const (
+	forkFrontier = iota
+	forkHomestead
+	forkTangerineWhistle
+	forkSpuriousDragon
+	forkByzantium
+	forkConstantinople
+	forkIstanbul
+	forkBerlin
+	forkLondon
+	forkShanghai
+	forkCancun
+	forkPrague
+	forkOsaka
+)
+
+var fuzzForks = []*JumpTable{
+	&frontierInstructionSet, &homesteadInstructionSet, &tangerineWhistleInstructionSet, &spuriousDragonInstructionSet,
+	&byzantiumInstructionSet, &constantinopleInstructionSet, &istanbulInstructionSet, &berlinInstructionSet,
+	&londonInstructionSet, &shanghaiInstructionSet, &cancunInstructionSet, &pragueInstructionSet, &osakaInstructionSet,
+}
+
+// fuzzGasLimit - gas of the fuzzed frame: memory expansion beyond it fails
+const fuzzGasLimit = 10_000_000
+
+// refOp - reference gas schedule of an instruction (Yellow Paper, appendix G and H, and the EIPs changing it),
+// written independently of the jump tables
+type refOp struct {
+	since int // fork which introduced the instruction
+	pops  int
+	gas   func(fork int, stack []uint256.Int) *big.Int // stack[0] - top, nil - the instruction must fail
+}
+
+func refConst(gas uint64) func(int, []uint256.Int) *big.Int {
+	return func(int, []uint256.Int) *big.Int { return new(big.Int).SetUint64(gas) }
+}
+
+func refWords(size *uint256.Int) *big.Int {
+	words := new(big.Int).Add(size.ToBig(), big.NewInt(31))
+	return words.Rsh(words, 5)
+}
+
+// refMemory returns the cost of expansion of empty memory to offset+size, nil if it's not affordable
+func refMemory(offset, size *uint256.Int) *big.Int {
+	if size.IsZero() {
+		return new(big.Int)
+	}
+	end := new(uint256.Int)
+	if _, overflow := end.AddOverflow(offset, size); overflow {
+		return nil
+	}
+	words := refWords(end)
+	if words.Cmp(big.NewInt(fuzzGasLimit)) > 0 {
+		return nil
+	}
+	// 3 * words + words^2 / 512
+	cost := new(big.Int).Mul(words, words)
+	cost.Div(cost, big.NewInt(512))
+	return cost.Add(cost, new(big.Int).Mul(words, big.NewInt(3)))
+}
+
+// refCopy - gas of instructions copying `size` bytes to memory at `offset`
+func refCopy(base, perWord int64, offset, size *uint256.Int) *big.Int {
+	mem := refMemory(offset, size)
+	if mem == nil {
+		return nil
+	}
+	gas := new(big.Int).Mul(refWords(size), big.NewInt(perWord))
+	return gas.Add(gas, mem).Add(gas, big.NewInt(base))
+}
+
+var refOps = func() map[OpCode]refOp {
+	ops := map[OpCode]refOp{
+		STOP:         {pops: 0, gas: refConst(0)},
+		JUMPDEST:     {pops: 0, gas: refConst(1)},
+		ADDMOD:       {pops: 3, gas: refConst(8)},
+		MULMOD:       {pops: 3, gas: refConst(8)},
+		SHL:          {since: forkConstantinople, pops: 2, gas: refConst(3)},
+		SHR:          {since: forkConstantinople, pops: 2, gas: refConst(3)},
+		SAR:          {since: forkConstantinople, pops: 2, gas: refConst(3)},
+		PUSH0:        {since: forkShanghai, pops: 0, gas: refConst(2)},
+		CLZ:          {since: forkOsaka, pops: 1, gas: refConst(5)},
+		NOT:          {pops: 1, gas: refConst(3)},
+		ISZERO:       {pops: 1, gas: refConst(3)},
+		CALLDATALOAD: {pops: 1, gas: refConst(3)},
+		EXP: {pops: 2, gas: func(fork int, stack []uint256.Int) *big.Int {
+			perByte := int64(10)
+			if fork >= forkSpuriousDragon { // EIP-160
+				perByte = 50
+			}
+			return big.NewInt(10 + perByte*int64(stack[1].ByteLen()))
+		}},
+		KECCAK256: {pops: 2, gas: func(fork int, stack []uint256.Int) *big.Int {
+			return refCopy(30, 6, &stack[0], &stack[1])
+		}},
+		MLOAD: {pops: 1, gas: func(fork int, stack []uint256.Int) *big.Int {
+			return refCopy(3, 0, &stack[0], uint256.NewInt(32))
+		}},
+		MSTORE: {pops: 2, gas: func(fork int, stack []uint256.Int) *big.Int {
+			return refCopy(3, 0, &stack[0], uint256.NewInt(32))
+		}},
+		MSTORE8: {pops: 2, gas: func(fork int, stack []uint256.Int) *big.Int {
+			return refCopy(3, 0, &stack[0], uint256.NewInt(1))
+		}},
+		CALLDATACOPY: {pops: 3, gas: func(fork int, stack []uint256.Int) *big.Int {
+			return refCopy(3, 3, &stack[0], &stack[2])
+		}},
+		CODECOPY: {pops: 3, gas: func(fork int, stack []uint256.Int) *big.Int {
+			return refCopy(3, 3, &stack[0], &stack[2])
+		}},
+		MCOPY: {since: forkCancun, pops: 3, gas: func(fork int, stack []uint256.Int) *big.Int {
+			offset := &stack[0]
+			if stack[1].Gt(offset) {
+				offset = &stack[1]
+			}
+			return refCopy(3, 3, offset, &stack[2])
+		}},
+	}
+	for _, op := range []OpCode{ADDRESS, CALLER, CALLVALUE, CALLDATASIZE, CODESIZE, PC, MSIZE, GAS} {
+		ops[op] = refOp{pops: 0, gas: refConst(2)}
+	}
+	ops[POP] = refOp{pops: 1, gas: refConst(2)}
+	for _, op := range []OpCode{ADD, SUB, LT, GT, SLT, SGT, EQ, AND, OR, XOR, BYTE} {
+		ops[op] = refOp{pops: 2, gas: refConst(3)}
+	}
+	for _, op := range []OpCode{MUL, DIV, SDIV, MOD, SMOD, SIGNEXTEND} {
+		ops[op] = refOp{pops: 2, gas: refConst(5)}
+	}
+	for i := 0; i < 32; i++ {
+		ops[PUSH1+OpCode(i)] = refOp{pops: 0, gas: refConst(3)}
+	}
+	for i := 0; i < 16; i++ {
+		ops[DUP1+OpCode(i)] = refOp{pops: i + 1, gas: refConst(3)}
+		ops[SWAP1+OpCode(i)] = refOp{pops: i + 2, gas: refConst(3)}
+	}
+	return ops
+}()
+
+// fuzzStack decodes stack items from `data`: a byte with the high bit unset starts a 15-bit item (memory offsets
+// and sizes), otherwise the following (up to) 32 bytes are the item
+func fuzzStack(data []byte, n int) []uint256.Int {
+	stack := make([]uint256.Int, n)
+	for i := range stack {
+		if len(data) == 0 {
+			break
+		}
+		b := data[0]
+		data = data[1:]
+		if b&0x80 == 0 {
+			v := uint64(b) << 8
+			if len(data) > 0 {
+				v, data = v|uint64(data[0]), data[1:]
+			}
+			stack[i].SetUint64(v)
+			continue
+		}
+		l := min(32, len(data))
+		stack[i].SetBytes(data[:l])
+		data = data[l:]
+	}
+	return stack
+}
+
+// runFuzzedOp executes `op` of jump table `jt` with `stack` by the interpreter and returns the used gas
+func runFuzzedOp(jt *JumpTable, op OpCode, stack []uint256.Int, blockFusion bool) (uint64, error) {
+	code := make([]byte, 0, 33*len(stack)+2)
+	for i := len(stack) - 1; i >= 0; i-- {
+		b := stack[i].Bytes32()
+		code = append(append(code, byte(PUSH32)), b[:]...)
+	}
+	code = append(code, byte(op))
+	if op >= PUSH1 && op <= PUSH32 {
+		code = append(code, make([]byte, op-PUSH0)...) // push data, zeros
+	}
+	code = append(code, byte(STOP))
+
+	evm := NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, nil, chain.TestChainConfig, Config{BlockFusion: blockFusion})
+	in := evm.interpreter.(*EVMInterpreter)
+	in.jt = jt
+	contract := NewContract(contractRef{common.Address{1}}, common.Address{2}, new(uint256.Int), fuzzGasLimit, true, nil)
+	contract.Code = code
+	_, err := in.Run(contract, nil, false)
+	return fuzzGasLimit - contract.Gas, err
+}
+
+// FuzzExecution runs an instruction of a jump table of a mainnet fork with a fuzzed stack and checks
+// the used gas against the reference gas schedule (with and without block fusion). Instructions not
+// introduced by the fork must be invalid. Instructions reading or writing state are not covered.
+func FuzzExecution(f *testing.F) {
+	for fork := range fuzzForks {
+		for op := range refOps {
+			f.Add(uint8(fork), uint8(op), []byte{0x00, 0x20, 0x00, 0x40, 0x01, 0x00})
+			f.Add(uint8(fork), uint8(op), []byte{0xff, 0xff, 0xff, 0x00, 0x41, 0x7f, 0xff})
+		}
+	}
+	f.Fuzz(func(t *testing.T, fork uint8, opByte uint8, data []byte) {
+		op := OpCode(opByte)
+		ref, ok := refOps[op]
+		if !ok || int(fork) >= len(fuzzForks) {
+			return
+		}
+		jt := fuzzForks[fork]
+		stack := fuzzStack(data, ref.pops)
+		gas, err := runFuzzedOp(jt, op, stack, false)
+		fusedGas, fusedErr := runFuzzedOp(jt, op, stack, true)
+		if (err == nil) != (fusedErr == nil) || (err == nil && gas != fusedGas) {
+			t.Fatalf("%v at fork %d: block fusion used %d gas, err %v; expected %d gas, err %v", op, fork, fusedGas, fusedErr, gas, err)
+		}
+
+		if int(fork) < ref.since {
+			var invalid *ErrInvalidOpCode
+			if !errors.As(err, &invalid) {
+				t.Fatalf("%v at fork %d is expected to be invalid, got err %v", op, fork, err)
+			}
+			return
+		}
+		expected := ref.gas(int(fork), stack)
+		if expected != nil {
+			expected.Add(expected, big.NewInt(int64(3*len(stack)))) // PUSH32s
+		}
+		if expected == nil || expected.Cmp(big.NewInt(fuzzGasLimit)) > 0 {
+			if err == nil {
+				t.Fatalf("%v at fork %d with stack %v is expected to fail, used %d gas", op, fork, stack, gas)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("%v at fork %d with stack %v: %v", op, fork, stack, err)
+		}
+		if gas != expected.Uint64() {
+			t.Fatalf("%v at fork %d with stack %v: used %d gas, reference %d", op, fork, stack, gas, expected.Uint64())
+		}
+	})
+}
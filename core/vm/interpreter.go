@@ -33,10 +33,13 @@ import (
 	"github.com/erigontech/erigon-lib/log/v3"
 
 	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
 )
 
 // Config are the configuration options for the Interpreter
 type Config struct {
+	// Tracer - execution hooks. Traces of long transactions may be too big to be kept in memory: tracers such as
+	// logger.JSONLogger write every step to an io.Writer as a JSON line instead
 	Tracer        *tracing.Hooks
 	JumpDestCache *JumpDestCache
 	NoRecursion   bool // Disables call, callcode, delegate call and create
@@ -47,6 +50,25 @@ type Config struct {
 	ReadOnly      bool // Do no perform any block finalisation
 	StatelessExec bool // true is certain conditions (like state trie root hash matching) need to be relaxed for stateless EVM execution
 	RestoreState  bool // Revert all changes made to the state (useful for constant system calls)
+	// MaxMemory - cap of memory of a single call frame in bytes, 0 - unlimited. For simulation endpoints: with high
+	// gas caps the gas-based limit still allows multi-GB memory. Exceeding it fails the frame with ErrMemoryLimitExceeded
+	MaxMemory uint64
+	// BlockFusion - legacy code is executed a basic block at a time: constant gas and stack bounds are checked once
+	// per block instead of per instruction. Gas and state are the same, but a failing frame may report a different
+	// error. Not used with tracers. EVM_BLOCK_FUSION=true enables it for all interpreters
+	BlockFusion bool
+	// CollectOpcodeStats - per-opcode counts and gas of a transaction are collected into
+	// ExecutionResult.OpcodeStats (see EVM.OpcodeStats). Disables BlockFusion
+	CollectOpcodeStats bool
+	// Coverage - program counters executed by every call frame of a transaction are collected into
+	// ExecutionResult.Coverage (see EVM.Coverage). For fuzzers guided by coverage. Disables BlockFusion
+	Coverage bool
+	// BLSReferencePairing - EIP-2537 pairing calls are evaluated independently by the reference implementation,
+	// instead of reusing subgroup checks and Miller loops of earlier calls of the transaction. For differential testing
+	BLSReferencePairing bool
+	// CollectWitness - the pre-state read by the block (accounts, storage, code, block hashes) is recorded into
+	// EphemeralExecResult.Witness, see state.ExecutionWitness. For stateless clients and prover inputs
+	CollectWitness bool
 
 	ExtraEips []int // Additional EIPS that are to be enabled
 
@@ -175,43 +197,20 @@ func copyJumpTable(jt *JumpTable) *JumpTable {
 type EVMInterpreter struct {
 	*VM
 	jt    *JumpTable // EVM instruction table
+	eofJt *JumpTable // instruction table of EOF code, nil if EOF is not enabled
 	depth int
 }
 
 // NewEVMInterpreter returns a new instance of the Interpreter.
 func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
-	var jt *JumpTable
-	switch {
-	case evm.chainRules.IsOsaka:
-		jt = &osakaInstructionSet
-	case evm.ChainRules().IsBhilai:
-		jt = &bhilaiInstructionSet
-	case evm.ChainRules().IsPrague:
-		jt = &pragueInstructionSet
-	case evm.ChainRules().IsCancun:
-		jt = &cancunInstructionSet
-	case evm.ChainRules().IsNapoli:
-		jt = &napoliInstructionSet
-	case evm.ChainRules().IsShanghai:
-		jt = &shanghaiInstructionSet
-	case evm.ChainRules().IsLondon:
-		jt = &londonInstructionSet
-	case evm.ChainRules().IsBerlin:
-		jt = &berlinInstructionSet
-	case evm.ChainRules().IsIstanbul:
-		jt = &istanbulInstructionSet
-	case evm.ChainRules().IsConstantinople:
-		jt = &constantinopleInstructionSet
-	case evm.ChainRules().IsByzantium:
-		jt = &byzantiumInstructionSet
-	case evm.ChainRules().IsSpuriousDragon:
-		jt = &spuriousDragonInstructionSet
-	case evm.ChainRules().IsTangerineWhistle:
-		jt = &tangerineWhistleInstructionSet
-	case evm.ChainRules().IsHomestead:
-		jt = &homesteadInstructionSet
-	default:
-		jt = &frontierInstructionSet
+	jt := instructionSetForRules(evm.chainRules)
+	eofJt := eofInstructionSet(evm.chainRules)
+	if overrides := evm.ChainConfig().OpcodeOverrides; len(overrides) > 0 {
+		blockNum := evm.Context.BlockNumber
+		jt = withOpcodeOverrides(jt, overrides, blockNum)
+		if eofJt != nil {
+			eofJt = withOpcodeOverrides(eofJt, overrides, blockNum)
+		}
 	}
 	if len(cfg.ExtraEips) > 0 {
 		jt = copyJumpTable(jt)
@@ -224,12 +223,15 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 		}
 	}
 
+	cfg.BlockFusion = cfg.BlockFusion || blockFusionDefault
+
 	return &EVMInterpreter{
 		VM: &VM{
 			evm: evm,
 			cfg: cfg,
 		},
-		jt: jt,
+		jt:    jt,
+		eofJt: eofJt,
 	}
 }
 
@@ -271,10 +273,32 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		res     []byte // result of the opcode execution function
 		debug   = in.cfg.Tracer != nil && (in.cfg.Tracer.OnOpcode != nil || in.cfg.Tracer.OnGasChange != nil || in.cfg.Tracer.OnFault != nil)
 		trace   = dbg.TraceInstructions && in.evm.intraBlockState.Trace()
+		stats   = in.evm.opcodeStats
+		cov     *evmtypes.CallCoverage // of this frame
 	)
 
 	contract.Input = input
 
+	jt := in.jt
+	if in.eofJt != nil && hasEOFMagic(contract.Code) {
+		if contract.eof, err = validateEOF(contract.Code, in.eofJt); err != nil {
+			return nil, err
+		}
+		jt, _pc = in.eofJt, contract.eof.codeOffsets[0]
+	}
+	var blocks *basicBlocks // nil - per instruction dispatch
+	if in.evm.coverage != nil {
+		codeAddr := contract.Address()
+		if contract.CodeAddr != nil {
+			codeAddr = *contract.CodeAddr
+		}
+		cov = evmtypes.NewCallCoverage(in.Depth()+1, codeAddr, contract.CodeHash, len(contract.Code))
+		in.evm.coverage.Calls = append(in.evm.coverage.Calls, cov)
+	}
+	if in.cfg.BlockFusion && contract.eof == nil && !debug && !trace && stats == nil && cov == nil {
+		blocks = basicBlocksOf(contract, jt)
+	}
+
 	// Make sure the readOnly is only set if we aren't in readOnly yet.
 	// This makes also sure that the readOnly flag isn't removed for child calls.
 	restoreReadonly := readOnly && !in.readOnly
@@ -313,6 +337,18 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		if steps%5000 == 0 && in.evm.Cancelled() {
 			break
 		}
+		if blocks != nil {
+			if b := blocks.at(_pc); b != nil {
+				if sLen := locStack.len(); sLen >= b.stackMin && sLen <= b.stackMax && contract.Gas >= b.gas {
+					contract.Gas -= b.gas
+					if res, err = in.runBasicBlock(b, pc, jt, callContext); err != nil {
+						break
+					}
+					continue
+				}
+				// not enough gas or stack for the whole block: execute it per instruction to fail at the same instruction
+			}
+		}
 		if debug {
 			// Capture pre-execution values for tracing.
 			logged, pcCopy, gasCopy = false, _pc, contract.Gas
@@ -320,8 +356,11 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		// Get the operation from the jump table and validate the stack to ensure there are
 		// enough stack items available to perform the operation.
 		op = contract.GetOp(_pc)
-		operation := in.jt[op]
+		operation := jt[op]
 		cost = operation.constantGas // For tracing
+		if cov != nil && _pc < uint64(len(contract.Code)) {
+			cov.Set(_pc)
+		}
 		// Validate stack
 		if sLen := locStack.len(); sLen < operation.numPop {
 			return nil, &ErrStackUnderflow{stackLen: sLen, required: operation.numPop}
@@ -335,31 +374,12 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		// All ops with a dynamic memory usage also has a dynamic gas cost.
 		var memorySize uint64
 		if operation.dynamicGas != nil {
-			// calculate the new memory size and expand the memory to fit
-			// the operation
-			// Memory check needs to be done prior to evaluating the dynamic gas portion,
-			// to detect calculation overflows
-			if operation.memorySize != nil {
-				memSize, overflow := operation.memorySize(locStack)
-				if overflow {
-					return nil, ErrGasUintOverflow
-				}
-				// memory is expanded in words of 32 bytes. Gas
-				// is also calculated in words.
-				if memorySize, overflow = math.SafeMul(ToWordSize(memSize), 32); overflow {
-					return nil, ErrGasUintOverflow
-				}
-			}
-			// Consume the gas and return an error if not enough gas is available.
 			// cost is explicitly set so that the capture state defer method can get the proper cost
 			var dynamicCost uint64
-			dynamicCost, err = operation.dynamicGas(in.evm, contract, locStack, mem, memorySize)
+			memorySize, dynamicCost, err = in.useDynamicGas(operation, callContext)
 			cost += dynamicCost // for tracing
 			if err != nil {
-				return nil, fmt.Errorf("%w: %v", ErrOutOfGas, err)
-			}
-			if !contract.UseGas(dynamicCost, in.cfg.Tracer, tracing.GasChangeIgnored) {
-				return nil, ErrOutOfGas
+				return nil, err
 			}
 		}
 
@@ -386,6 +406,15 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 			fmt.Printf("(%d.%d) %5d %5d %s\n", in.evm.intraBlockState.TxIndex(), in.evm.intraBlockState.Incarnation(), _pc, cost, str)
 		}
 
+		if stats != nil {
+			gas := cost
+			switch op {
+			case CALL, CALLCODE, DELEGATECALL, STATICCALL:
+				gas -= in.evm.callGasTemp // executed by the callee
+			}
+			stats.Add(byte(op), gas)
+		}
+
 		if memorySize > 0 {
 			mem.Resize(memorySize)
 		}
@@ -407,6 +436,37 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 	return
 }
 
+// useDynamicGas charges dynamic gas of the operation and returns the memory size it needs
+func (in *EVMInterpreter) useDynamicGas(operation *operation, scope *ScopeContext) (memorySize uint64, dynamicCost uint64, err error) {
+	// calculate the new memory size and expand the memory to fit
+	// the operation
+	// Memory check needs to be done prior to evaluating the dynamic gas portion,
+	// to detect calculation overflows
+	if operation.memorySize != nil {
+		memSize, overflow := operation.memorySize(scope.Stack)
+		if overflow {
+			return 0, 0, ErrGasUintOverflow
+		}
+		// memory is expanded in words of 32 bytes. Gas
+		// is also calculated in words.
+		if memorySize, overflow = math.SafeMul(ToWordSize(memSize), 32); overflow {
+			return 0, 0, ErrGasUintOverflow
+		}
+		if in.cfg.MaxMemory > 0 && memorySize > in.cfg.MaxMemory {
+			return 0, 0, ErrMemoryLimitExceeded
+		}
+	}
+	// Consume the gas and return an error if not enough gas is available.
+	dynamicCost, err = operation.dynamicGas(in.evm, scope.Contract, scope.Stack, scope.Memory, memorySize)
+	if err != nil {
+		return 0, dynamicCost, fmt.Errorf("%w: %v", ErrOutOfGas, err)
+	}
+	if !scope.Contract.UseGas(dynamicCost, in.cfg.Tracer, tracing.GasChangeIgnored) {
+		return 0, dynamicCost, ErrOutOfGas
+	}
+	return memorySize, dynamicCost, nil
+}
+
 // Depth returns the current call stack depth.
 func (in *EVMInterpreter) Depth() int { return in.depth }
 
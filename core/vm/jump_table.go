@@ -52,6 +52,7 @@ type operation struct {
 	// memorySize returns the memory size required for the operation
 	memorySize memorySizeFunc
 	string     stringer
+	undefined  bool // rejected by EOF code validation
 }
 
 var (
@@ -70,8 +71,16 @@ var (
 	cancunInstructionSet           = newCancunInstructionSet()
 	pragueInstructionSet           = newPragueInstructionSet()
 	osakaInstructionSet            = newOsakaInstructionSet()
+
+	// initialized in init: contract creation depends on them, and instruction sets depend on contract creation
+	pragueEOFInstructionSet, osakaEOFInstructionSet JumpTable
 )
 
+func init() {
+	pragueEOFInstructionSet = newEOFInstructionSet(pragueInstructionSet)
+	osakaEOFInstructionSet = newEOFInstructionSet(osakaInstructionSet)
+}
+
 // JumpTable contains the EVM opcodes supported at a given fork.
 type JumpTable [256]*operation
 
@@ -298,6 +307,40 @@ func newOsakaInstructionSet() JumpTable {
 	return instructionSet
 }
 
+// newEOFInstructionSet returns instructions of EOF code on top of the given fork: EIP-4200 relative jumps and
+// EIP-4750 functions are added; dynamic jumps, self-destruction and instructions observing code or gas are removed.
+func newEOFInstructionSet(base JumpTable) JumpTable {
+	instructionSet := *copyJumpTable(&base)
+	for _, op := range []OpCode{JUMP, JUMPI, PC, GAS, CODESIZE, CODECOPY, EXTCODESIZE, EXTCODECOPY, EXTCODEHASH, CALLCODE, CREATE, CREATE2, SELFDESTRUCT} {
+		instructionSet[op] = &operation{execute: opUndefined, undefined: true}
+	}
+	instructionSet[INVALID] = &operation{execute: opUndefined} // designated invalid instruction is a valid terminating one
+	instructionSet[RJUMP] = &operation{
+		execute:     opRjump,
+		constantGas: GasQuickStep,
+	}
+	instructionSet[RJUMPI] = &operation{
+		execute:     opRjumpi,
+		constantGas: 4,
+		numPop:      1,
+	}
+	instructionSet[RJUMPV] = &operation{
+		execute:     opRjumpv,
+		constantGas: 4,
+		numPop:      1,
+	}
+	instructionSet[CALLF] = &operation{
+		execute:     opCallf,
+		constantGas: GasFastStep,
+	}
+	instructionSet[RETF] = &operation{
+		execute:     opRetf,
+		constantGas: GasFastestStep,
+	}
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
 // newFrontierInstructionSet returns the frontier instructions
 // that can be executed during the frontier phase.
 func newFrontierInstructionSet() JumpTable {
@@ -1330,7 +1373,7 @@ func newFrontierInstructionSet() JumpTable {
 	// Fill all unassigned slots with opUndefined.
 	for i, entry := range tbl {
 		if entry == nil {
-			tbl[i] = &operation{execute: opUndefined}
+			tbl[i] = &operation{execute: opUndefined, undefined: true}
 		}
 	}
 
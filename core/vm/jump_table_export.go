@@ -0,0 +1,137 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"github.com/erigontech/erigon-lib/chain"
+)
+
+// instructionSetForRules returns the instruction set of legacy (non-EOF) code active under the rules
+func instructionSetForRules(rules *chain.Rules) *JumpTable {
+	switch {
+	case rules.IsOsaka:
+		return &osakaInstructionSet
+	case rules.IsBhilai:
+		return &bhilaiInstructionSet
+	case rules.IsPrague:
+		return &pragueInstructionSet
+	case rules.IsCancun:
+		return &cancunInstructionSet
+	case rules.IsNapoli:
+		return &napoliInstructionSet
+	case rules.IsShanghai:
+		return &shanghaiInstructionSet
+	case rules.IsLondon:
+		return &londonInstructionSet
+	case rules.IsBerlin:
+		return &berlinInstructionSet
+	case rules.IsIstanbul:
+		return &istanbulInstructionSet
+	case rules.IsConstantinople:
+		return &constantinopleInstructionSet
+	case rules.IsByzantium:
+		return &byzantiumInstructionSet
+	case rules.IsSpuriousDragon:
+		return &spuriousDragonInstructionSet
+	case rules.IsTangerineWhistle:
+		return &tangerineWhistleInstructionSet
+	case rules.IsHomestead:
+		return &homesteadInstructionSet
+	default:
+		return &frontierInstructionSet
+	}
+}
+
+// OperationInfo describes an opcode of the instruction set
+type OperationInfo struct {
+	Opcode      OpCode `json:"opcode"`
+	Name        string `json:"name"`
+	ConstantGas uint64 `json:"constantGas"`
+	DynamicGas  bool   `json:"dynamicGas"` // gas depends on the operands, memory expansion or accessed state
+	StackPop    int    `json:"stackPop"`
+	StackPush   int    `json:"stackPush"`
+	MaxStack    int    `json:"maxStack"` // the stack must not be larger before the operation, not to overflow
+	Memory      bool   `json:"memory"`   // the operation may expand the memory
+}
+
+// DescribeJumpTable returns the opcodes active in legacy (non-EOF) code under the rules, as the interpreter
+// executes them, with the extra EIPs enabled as by Config.ExtraEips. Undefined opcodes are omitted,
+// INVALID is included: it's a defined terminating instruction.
+func DescribeJumpTable(rules *chain.Rules, extraEips ...int) ([]OperationInfo, error) {
+	jt := instructionSetForRules(rules)
+	if len(extraEips) > 0 {
+		jt = copyJumpTable(jt)
+		for _, eip := range extraEips {
+			if err := EnableEIP(eip, jt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var ops []OperationInfo
+	for i, op := range jt {
+		if op == nil || op.undefined {
+			continue
+		}
+		ops = append(ops, OperationInfo{
+			Opcode:      OpCode(i),
+			Name:        OpCode(i).String(),
+			ConstantGas: op.constantGas,
+			DynamicGas:  op.dynamicGas != nil,
+			StackPop:    op.numPop,
+			StackPush:   op.numPush,
+			MaxStack:    op.maxStack,
+			Memory:      op.memorySize != nil,
+		})
+	}
+	return ops, nil
+}
+
+// OperationChange - an opcode active in both instruction sets, with different properties
+type OperationChange struct {
+	From OperationInfo `json:"from"`
+	To   OperationInfo `json:"to"`
+}
+
+// JumpTableDiff - difference between two instruction sets, ordered by opcode
+type JumpTableDiff struct {
+	Added   []OperationInfo   `json:"added"`
+	Removed []OperationInfo   `json:"removed"`
+	Changed []OperationChange `json:"changed"`
+}
+
+// DiffJumpTables compares the instruction sets described by DescribeJumpTable
+func DiffJumpTables(from, to []OperationInfo) JumpTableDiff {
+	var fromOps, toOps [256]*OperationInfo
+	for i := range from {
+		fromOps[from[i].Opcode] = &from[i]
+	}
+	for i := range to {
+		toOps[to[i].Opcode] = &to[i]
+	}
+	diff := JumpTableDiff{Added: []OperationInfo{}, Removed: []OperationInfo{}, Changed: []OperationChange{}}
+	for op := range 256 {
+		switch f, t := fromOps[op], toOps[op]; {
+		case f == nil && t != nil:
+			diff.Added = append(diff.Added, *t)
+		case f != nil && t == nil:
+			diff.Removed = append(diff.Removed, *f)
+		case f != nil && *f != *t:
+			diff.Changed = append(diff.Changed, OperationChange{From: *f, To: *t})
+		}
+	}
+	return diff
+}
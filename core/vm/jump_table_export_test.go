@@ -0,0 +1,58 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+)
+
+func TestDiffJumpTables(t *testing.T) {
+	shanghai := &chain.Rules{IsHomestead: true, IsTangerineWhistle: true, IsSpuriousDragon: true, IsByzantium: true,
+		IsConstantinople: true, IsPetersburg: true, IsIstanbul: true, IsBerlin: true, IsLondon: true, IsShanghai: true}
+	cancun := *shanghai
+	cancun.IsCancun = true
+
+	from, err := DescribeJumpTable(shanghai)
+	require.NoError(t, err)
+	to, err := DescribeJumpTable(&cancun)
+	require.NoError(t, err)
+
+	diff := DiffJumpTables(from, to)
+	var added []OpCode
+	for _, op := range diff.Added {
+		added = append(added, op.Opcode)
+	}
+	assert.Equal(t, []OpCode{BLOBHASH, BLOBBASEFEE, TLOAD, TSTORE, MCOPY}, added)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Changed)
+
+	// extra EIPs are applied as by Config.ExtraEips
+	withTransient, err := DescribeJumpTable(shanghai, 1153)
+	require.NoError(t, err)
+	diff = DiffJumpTables(from, withTransient)
+	require.Len(t, diff.Added, 2)
+	assert.Equal(t, OperationInfo{Opcode: TLOAD, Name: "TLOAD", ConstantGas: 100, StackPop: 1, StackPush: 1, MaxStack: 1024}, diff.Added[0])
+	assert.Equal(t, TSTORE, diff.Added[1].Opcode)
+
+	diff = DiffJumpTables(to, from)
+	assert.Len(t, diff.Removed, 5)
+}
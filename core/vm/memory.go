@@ -23,10 +23,23 @@ import (
 	"sync"
 
 	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// Memory and Stack of call frames are taken from pools on the frame start and returned on its exit.
+// Hit rate of a pool is 1 - misses/gets: misses are allocations of new objects
+var (
+	mxMemoryPoolGets     = metrics.GetOrCreateCounter(`evm_pool_gets{pool="memory"}`)
+	mxMemoryPoolMisses   = metrics.GetOrCreateCounter(`evm_pool_misses{pool="memory"}`)
+	mxMemoryPoolDiscards = metrics.GetOrCreateCounter(`evm_pool_discards{pool="memory"}`) // too big to keep
+	mxStackPoolGets      = metrics.GetOrCreateCounter(`evm_pool_gets{pool="stack"}`)
+	mxStackPoolMisses    = metrics.GetOrCreateCounter(`evm_pool_misses{pool="stack"}`)
 )
 
 var memoryPool = sync.Pool{
 	New: func() any {
+		mxMemoryPoolMisses.Inc()
 		return &Memory{}
 	},
 }
@@ -39,20 +52,23 @@ type Memory struct {
 
 // NewMemory returns a new memory model.
 func NewMemory() *Memory {
+	mxMemoryPoolGets.Inc()
 	m := memoryPool.Get().(*Memory)
 	m.reset()
 	return m
 }
 
-// Free returns the memory to the pool.
+// free returns the memory to the pool.
 func (m *Memory) free() {
 	// To reduce peak allocation, return only smaller memory instances to the pool.
 	const maxBufferSize = 16 << 10
-	if cap(m.store) <= maxBufferSize {
-		m.store = m.store[:0]
-		m.lastGasCost = 0
-		memoryPool.Put(m)
+	if cap(m.store) > maxBufferSize {
+		mxMemoryPoolDiscards.Inc()
+		return
 	}
+	m.store = m.store[:0]
+	m.lastGasCost = 0
+	memoryPool.Put(m)
 }
 
 // Set sets offset + size to value
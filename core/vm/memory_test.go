@@ -84,3 +84,35 @@ func TestMemoryCopy(t *testing.T) {
 		}
 	}
 }
+
+func TestMemoryPool(t *testing.T) {
+	gets := mxMemoryPoolGets.GetValueUint64()
+	m := NewMemory()
+	m.Resize(64)
+	m.Set(0, 64, bytes.Repeat([]byte{0xff}, 64))
+	m.lastGasCost = 42
+	m.free()
+
+	// a memory taken from the pool is empty and doesn't expose data of the previous frame
+	m = NewMemory()
+	if m.Len() != 0 || m.lastGasCost != 0 {
+		t.Fatalf("memory from the pool is not reset: len %d, lastGasCost %d", m.Len(), m.lastGasCost)
+	}
+	m.Resize(64)
+	if !bytes.Equal(m.Data(), make([]byte, 64)) {
+		t.Fatalf("resized memory from the pool is not zeroed: %x", m.Data())
+	}
+	m.free()
+
+	if got := mxMemoryPoolGets.GetValueUint64() - gets; got < 2 {
+		t.Fatalf("expected at least 2 gets, got %d", got)
+	}
+
+	discards := mxMemoryPoolDiscards.GetValueUint64()
+	m = NewMemory()
+	m.Resize(64 << 10)
+	m.free()
+	if mxMemoryPoolDiscards.GetValueUint64() == discards {
+		t.Fatal("big memory is expected to be discarded")
+	}
+}
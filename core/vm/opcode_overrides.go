@@ -0,0 +1,142 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
+)
+
+// OpcodeOverrideConfig is an override of an opcode in the TOML file of --evm.opcode-overrides, e.g.:
+//
+//	[[override]]
+//	opcode = "SELFDESTRUCT"
+//	from = 1000000
+//	disabled = true
+//
+//	[[override]]
+//	opcode = "SLOAD"
+//	from = 0
+//	to = 999999
+//	gas = 800
+//
+// Overrides of the same opcode active in the same block are applied in the order of the file.
+type OpcodeOverrideConfig struct {
+	Opcode   string  `toml:"opcode"`   // name, e.g. "SLOAD"
+	From     uint64  `toml:"from"`     // first block
+	To       *uint64 `toml:"to"`       // last block, inclusive, no end if not set
+	Gas      *uint64 `toml:"gas"`      // constant gas, dynamic gas is unchanged
+	Disabled bool    `toml:"disabled"` // the opcode is an invalid instruction
+}
+
+// OpcodeOverridesConfig is the TOML file of --evm.opcode-overrides
+type OpcodeOverridesConfig struct {
+	Overrides []OpcodeOverrideConfig `toml:"override"`
+}
+
+// LoadOpcodeOverrides reads and validates the TOML file of opcode overrides, to be set to chain.Config.OpcodeOverrides
+func LoadOpcodeOverrides(path string) ([]chain.OpcodeOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg OpcodeOverridesConfig
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("opcode overrides %s: %w", path, err)
+	}
+	overrides, err := cfg.Parse()
+	if err != nil {
+		return nil, fmt.Errorf("opcode overrides %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// Parse resolves the opcode names
+func (cfg *OpcodeOverridesConfig) Parse() ([]chain.OpcodeOverride, error) {
+	overrides := make([]chain.OpcodeOverride, 0, len(cfg.Overrides))
+	for i, o := range cfg.Overrides {
+		op, ok := stringToOp[strings.ToUpper(o.Opcode)]
+		if !ok {
+			return nil, fmt.Errorf("override %d: unknown opcode %q", i, o.Opcode)
+		}
+		if o.To != nil && *o.To < o.From {
+			return nil, fmt.Errorf("override %d (%s): to %d is before from %d", i, op, *o.To, o.From)
+		}
+		if o.Gas == nil && !o.Disabled {
+			return nil, fmt.Errorf("override %d (%s): neither gas nor disabled is set", i, op)
+		}
+		if o.Gas != nil && o.Disabled {
+			return nil, fmt.Errorf("override %d (%s): gas of a disabled opcode", i, op)
+		}
+		overrides = append(overrides, chain.OpcodeOverride{Opcode: byte(op), From: o.From, To: o.To, Gas: o.Gas, Disabled: o.Disabled})
+	}
+	return overrides, nil
+}
+
+type overriddenJumpTableKey struct {
+	base      *JumpTable
+	overrides string // the active overrides
+}
+
+// overriddenJumpTables - jump tables with the overrides applied are shared by all interpreters: the analysis of basic
+// blocks is cached by the jump table
+var overriddenJumpTables sync.Map // overriddenJumpTableKey -> *JumpTable
+
+// withOpcodeOverrides returns the jump table with the overrides active in the block applied. Gas of an opcode
+// undefined in the jump table is not overridden: the opcode stays invalid.
+func withOpcodeOverrides(jt *JumpTable, overrides []chain.OpcodeOverride, blockNum uint64) *JumpTable {
+	var key strings.Builder
+	for i := range overrides {
+		o := &overrides[i]
+		if !o.Active(blockNum) {
+			continue
+		}
+		if o.Disabled {
+			fmt.Fprintf(&key, "%d:disabled;", o.Opcode)
+		} else {
+			fmt.Fprintf(&key, "%d:%d;", o.Opcode, *o.Gas)
+		}
+	}
+	if key.Len() == 0 {
+		return jt
+	}
+	k := overriddenJumpTableKey{base: jt, overrides: key.String()}
+	if cached, ok := overriddenJumpTables.Load(k); ok {
+		return cached.(*JumpTable)
+	}
+	overridden := copyJumpTable(jt)
+	for i := range overrides {
+		o := &overrides[i]
+		if !o.Active(blockNum) {
+			continue
+		}
+		if o.Disabled {
+			overridden[o.Opcode] = &operation{execute: opUndefined, maxStack: int(params.StackLimit), undefined: true}
+		} else if op := overridden[o.Opcode]; op != nil && !op.undefined {
+			op.constantGas = *o.Gas
+		}
+	}
+	cached, _ := overriddenJumpTables.LoadOrStore(k, overridden)
+	return cached.(*JumpTable)
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+)
+
+const testOpcodeOverrides = `
+[[override]]
+opcode = "add"
+from = 10
+to = 19
+gas = 100
+
+[[override]]
+opcode = "MUL"
+from = 20
+disabled = true
+`
+
+func TestOpcodeOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.toml")
+	require.NoError(t, os.WriteFile(path, []byte(testOpcodeOverrides), 0o600))
+	overrides, err := LoadOpcodeOverrides(path)
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+
+	config := &chain.Config{ChainID: big.NewInt(1337), OpcodeOverrides: overrides}
+
+	// PUSH1 1, PUSH1 2, ADD, PUSH1 3, MUL, STOP
+	code := []byte{byte(PUSH1), 1, byte(PUSH1), 2, byte(ADD), byte(PUSH1), 3, byte(MUL), byte(STOP)}
+	run := func(blockNum uint64) (uint64, error) {
+		evm := NewEVM(evmtypes.BlockContext{BlockNumber: blockNum}, evmtypes.TxContext{}, nil, config, Config{})
+		contract := NewContract(contractRef{common.Address{1}}, common.Address{2}, new(uint256.Int), 1000, true, nil)
+		contract.Code = code
+		_, err := evm.interpreter.Run(contract, nil, false)
+		return 1000 - contract.Gas, err
+	}
+
+	used, err := run(9)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3+3+3+3+5), used)
+
+	used, err = run(19)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3+3+100+3+5), used)
+
+	_, err = run(20)
+	var invalid *ErrInvalidOpCode
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, MUL, invalid.opcode)
+
+	// the base instruction set is not modified, and the overridden one is shared by interpreters
+	in := NewEVM(evmtypes.BlockContext{BlockNumber: 0}, evmtypes.TxContext{}, nil, chain.TestChainConfig, Config{}).interpreter.(*EVMInterpreter)
+	assert.Equal(t, uint64(3), in.jt[ADD].constantGas)
+	in1 := NewEVM(evmtypes.BlockContext{BlockNumber: 10}, evmtypes.TxContext{}, nil, config, Config{}).interpreter.(*EVMInterpreter)
+	in2 := NewEVM(evmtypes.BlockContext{BlockNumber: 15}, evmtypes.TxContext{}, nil, config, Config{}).interpreter.(*EVMInterpreter)
+	assert.Same(t, in1.jt, in2.jt)
+	assert.NotSame(t, in.jt, in1.jt)
+}
+
+func TestOpcodeOverridesInvalid(t *testing.T) {
+	to := uint64(5)
+	gas := uint64(1)
+	for name, cfg := range map[string]OpcodeOverridesConfig{
+		"unknown opcode": {Overrides: []OpcodeOverrideConfig{{Opcode: "FOO", Gas: &gas}}},
+		"empty range":    {Overrides: []OpcodeOverrideConfig{{Opcode: "ADD", From: 6, To: &to, Gas: &gas}}},
+		"no change":      {Overrides: []OpcodeOverrideConfig{{Opcode: "ADD"}}},
+		"gas disabled":   {Overrides: []OpcodeOverrideConfig{{Opcode: "ADD", Gas: &gas, Disabled: true}}},
+	} {
+		_, err := cfg.Parse()
+		assert.Error(t, err, name)
+	}
+}
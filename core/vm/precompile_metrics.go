@@ -0,0 +1,80 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon/core/tracing"
+)
+
+// precompileSlowCall - precompile calls running longer are logged with their input, 0 - disabled
+var precompileSlowCall = dbg.EnvDuration("EVM_PRECOMPILE_SLOW_CALL", 100*time.Millisecond)
+
+// precompileSlowCallInput - bytes of the input of a slow call logged, its hash is logged too
+const precompileSlowCallInput = 1024
+
+// precompileMetrics - calls, gas and latency of a precompile, by address
+type precompileMetrics struct {
+	calls     metrics.Counter
+	gas       metrics.Counter
+	slowCalls metrics.Counter
+	duration  metrics.Summary
+}
+
+var precompilesMetrics sync.Map // common.Address => *precompileMetrics
+
+func precompileMetricsOf(addr common.Address) *precompileMetrics {
+	if m, ok := precompilesMetrics.Load(addr); ok {
+		return m.(*precompileMetrics)
+	}
+	label := fmt.Sprintf(`{address="%x"}`, addr)
+	m, _ := precompilesMetrics.LoadOrStore(addr, &precompileMetrics{
+		calls:     metrics.GetOrCreateCounter("evm_precompile_calls" + label),
+		gas:       metrics.GetOrCreateCounter("evm_precompile_gas" + label),
+		slowCalls: metrics.GetOrCreateCounter("evm_precompile_slow_calls" + label),
+		duration:  metrics.GetOrCreateSummary("evm_precompile_duration_seconds" + label),
+	})
+	return m.(*precompileMetrics)
+}
+
+// runPrecompiledContract - RunPrecompiledContract of the precompile at addr, accounted in its metrics. Calls
+// running longer than precompileSlowCall are logged: to find the inputs a node is stuck on
+func runPrecompiledContract(addr common.Address, p PrecompiledContract, input []byte, suppliedGas uint64, tracer *tracing.Hooks,
+) (ret []byte, remainingGas uint64, err error) {
+	start := time.Now()
+	ret, remainingGas, err = RunPrecompiledContract(p, input, suppliedGas, tracer)
+	took := time.Since(start)
+
+	m := precompileMetricsOf(addr)
+	m.calls.Inc()
+	m.gas.AddUint64(suppliedGas - remainingGas)
+	m.duration.Observe(took.Seconds())
+	if precompileSlowCall > 0 && took > precompileSlowCall {
+		m.slowCalls.Inc()
+		log.Warn("[evm] slow precompile call", "address", addr, "took", took, "gas", suppliedGas-remainingGas,
+			"inputLen", len(input), "inputHash", crypto.Keccak256Hash(input), "input", fmt.Sprintf("%x", input[:min(len(input), precompileSlowCallInput)]), "err", err)
+	}
+	return ret, remainingGas, err
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+package vm
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+)
+
+type precompileFork struct {
+	name   string
+	active func(*chain.Rules) bool
+}
+
+// precompileForks - forks a registered precompile can be activated at, named as in chain config
+var precompileForks = []precompileFork{
+	{"frontier", func(*chain.Rules) bool { return true }},
+	{"homestead", func(r *chain.Rules) bool { return r.IsHomestead }},
+	{"byzantium", func(r *chain.Rules) bool { return r.IsByzantium }},
+	{"constantinople", func(r *chain.Rules) bool { return r.IsConstantinople }},
+	{"istanbul", func(r *chain.Rules) bool { return r.IsIstanbul }},
+	{"berlin", func(r *chain.Rules) bool { return r.IsBerlin }},
+	{"london", func(r *chain.Rules) bool { return r.IsLondon }},
+	{"shanghai", func(r *chain.Rules) bool { return r.IsShanghai }},
+	{"cancun", func(r *chain.Rules) bool { return r.IsCancun }},
+	{"napoli", func(r *chain.Rules) bool { return r.IsNapoli }},
+	{"bhilai", func(r *chain.Rules) bool { return r.IsBhilai }},
+	{"prague", func(r *chain.Rules) bool { return r.IsPrague }},
+	{"osaka", func(r *chain.Rules) bool { return r.IsOsaka }},
+}
+
+type registeredPrecompile struct {
+	addr common.Address
+	fork int // index in precompileForks
+	impl PrecompiledContract
+}
+
+var (
+	precompileRegistryLock sync.RWMutex
+	precompileRegistry     []registeredPrecompile // sorted by fork
+)
+
+// RegisterPrecompile - adds a precompiled contract active from the given fork on, for L2 forks and devnets
+// which need custom precompiles. Registered precompiles override built-in ones at the same address, and
+// a registration of a later fork overrides an earlier one. Registrations apply to every chain of the process
+// and are resolved when an EVM is created: register them before, e.g. in `init`.
+func RegisterPrecompile(addr common.Address, fork string, impl PrecompiledContract) error {
+	forkIdx := slices.IndexFunc(precompileForks, func(f precompileFork) bool { return f.name == fork })
+	if forkIdx < 0 {
+		return fmt.Errorf("unknown fork %q", fork)
+	}
+	if impl == nil {
+		return fmt.Errorf("nil precompile at %x", addr)
+	}
+	precompileRegistryLock.Lock()
+	defer precompileRegistryLock.Unlock()
+	precompileRegistry = append(precompileRegistry, registeredPrecompile{addr: addr, fork: forkIdx, impl: impl})
+	slices.SortStableFunc(precompileRegistry, func(a, b registeredPrecompile) int { return a.fork - b.fork })
+	return nil
+}
+
+// ActivePrecompiledContracts returns the precompiles enabled with the current configuration, including registered ones.
+// Callers must not modify the returned map.
+func ActivePrecompiledContracts(rules *chain.Rules) map[common.Address]PrecompiledContract {
+	builtin := builtinPrecompiledContracts(rules)
+	precompileRegistryLock.RLock()
+	defer precompileRegistryLock.RUnlock()
+	if len(precompileRegistry) == 0 {
+		return builtin
+	}
+	active := maps.Clone(builtin)
+	for _, p := range precompileRegistry {
+		if precompileForks[p.fork].active(rules) {
+			active[p.addr] = p.impl
+		}
+	}
+	return active
+}
+
+// registeredPrecompileAddresses - addresses of registered precompiles enabled with the current configuration,
+// which are not built-in ones
+func registeredPrecompileAddresses(rules *chain.Rules, builtin []common.Address) []common.Address {
+	precompileRegistryLock.RLock()
+	defer precompileRegistryLock.RUnlock()
+	var addrs []common.Address
+	for _, p := range precompileRegistry {
+		if precompileForks[p.fork].active(rules) && !slices.Contains(builtin, p.addr) && !slices.Contains(addrs, p.addr) {
+			addrs = append(addrs, p.addr)
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+)
+
+type constPrecompile struct{ out []byte }
+
+func (c *constPrecompile) RequiredGas(input []byte) uint64  { return 1 }
+func (c *constPrecompile) Run(input []byte) ([]byte, error) { return c.out, nil }
+
+func TestRegisterPrecompile(t *testing.T) {
+	// not parallel: registry is global
+	precompileRegistryLock.Lock()
+	saved := precompileRegistry
+	precompileRegistry = nil
+	precompileRegistryLock.Unlock()
+	t.Cleanup(func() {
+		precompileRegistryLock.Lock()
+		precompileRegistry = saved
+		precompileRegistryLock.Unlock()
+	})
+
+	custom := common.HexToAddress("0x0b00")
+	ecrecoverAddr := common.BytesToAddress([]byte{1})
+	customImpl, overrideImpl := &constPrecompile{out: []byte{1}}, &constPrecompile{out: []byte{2}}
+	require.ErrorContains(t, RegisterPrecompile(custom, "unknown", customImpl), "unknown fork")
+	require.NoError(t, RegisterPrecompile(custom, "prague", customImpl))
+	require.NoError(t, RegisterPrecompile(ecrecoverAddr, "osaka", overrideImpl))
+
+	cancun := &chain.Rules{IsBerlin: true, IsCancun: true}
+	prague := &chain.Rules{IsBerlin: true, IsCancun: true, IsPrague: true}
+	osaka := &chain.Rules{IsBerlin: true, IsCancun: true, IsPrague: true, IsOsaka: true}
+
+	require.NotContains(t, ActivePrecompiledContracts(cancun), custom)
+	require.NotContains(t, ActivePrecompiles(cancun), custom)
+	require.Equal(t, customImpl, ActivePrecompiledContracts(prague)[custom])
+	require.Contains(t, ActivePrecompiles(prague), custom)
+	require.Len(t, ActivePrecompiles(prague), len(PrecompiledAddressesPrague)+1)
+	require.IsType(t, &ecrecover{}, ActivePrecompiledContracts(prague)[ecrecoverAddr])
+
+	// override of a built-in precompile, built-in set is not modified
+	require.Equal(t, overrideImpl, ActivePrecompiledContracts(osaka)[ecrecoverAddr])
+	require.Len(t, ActivePrecompiles(osaka), len(PrecompiledAddressesOsaka)+1)
+	require.IsType(t, &ecrecover{}, PrecompiledContractsOsaka[ecrecoverAddr])
+
+	// active set is resolved from chain config at EVM construction
+	evm := NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, nil, chain.AllProtocolChanges, Config{})
+	p, ok := evm.precompile(custom)
+	require.True(t, ok)
+	require.Equal(t, customImpl, p)
+}
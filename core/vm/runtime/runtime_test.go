@@ -22,6 +22,7 @@ package runtime
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -36,6 +37,7 @@ import (
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/memdb"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
@@ -152,6 +154,74 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecuteMaxMemory(t *testing.T) {
+	t.Parallel()
+	// MSTORE at offset 1MB: memory of the frame is expanded to 1MB+32 bytes
+	code := []byte{
+		byte(vm.PUSH1), 10,
+		byte(vm.PUSH3), 0x10, 0x00, 0x00,
+		byte(vm.MSTORE),
+		byte(vm.STOP),
+	}
+	execute := func(maxMemory uint64) error {
+		cfg := &Config{EVMConfig: vm.Config{MaxMemory: maxMemory}}
+		setDefaults(cfg)
+		_, _, err := Execute(code, nil, cfg, t.TempDir())
+		return err
+	}
+	err := execute(1 << 20)
+	if !errors.Is(err, vm.ErrMemoryLimitExceeded) {
+		t.Fatal("expected memory limit error, got", err)
+	}
+	err = execute(2 << 20)
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+}
+
+func TestExecuteEOF(t *testing.T) {
+	t.Parallel()
+	// section 0 calls section 1 (doubles its input), checks the result with RJUMPI and returns it
+	code := common.FromHex("ef000101000802000200150003ff00000000800003010100026003e3000180600614e10001fe60005260206000f38001e4")
+	execute := func(eof bool) ([]byte, error) {
+		cfg := &Config{}
+		setDefaults(cfg)
+		if eof {
+			cfg.ChainConfig.EOFTime = new(big.Int)
+		}
+		ret, _, err := Execute(code, nil, cfg, t.TempDir())
+		return ret, err
+	}
+	ret, err := execute(true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), new(uint256.Int).SetBytes(ret).Uint64())
+
+	_, err = execute(false)
+	require.ErrorIs(t, err, &vm.ErrInvalidOpCode{})
+}
+
+func TestCreateEOF(t *testing.T) {
+	// EOF initcode returning EOF code (STOP) and legacy code (20 zero bytes)
+	eofInitcode := common.FromHex("ef0001010004020001001dff0000000080000273ef00010100040200010001ff00000000800000006000526014600cf3")
+	legacyInitcode := common.FromHex("ef0001010004020001001dff000000008000027300000000000000000000000000000000000000006000526014600cf3")
+	create := func(initcode []byte) ([]byte, error) {
+		cfg := &Config{}
+		setDefaults(cfg)
+		cfg.ChainConfig.EOFTime = new(big.Int)
+		code, _, _, err := Create(initcode, cfg, 0)
+		return code, err
+	}
+	code, err := create(eofInitcode)
+	require.NoError(t, err)
+	require.Equal(t, common.FromHex("ef00010100040200010001ff0000000080000000"), code)
+
+	_, err = create(legacyInitcode)
+	require.ErrorIs(t, err, vm.ErrInvalidCode)
+
+	_, err = create(code[:len(code)-1]) // invalid initcode
+	require.ErrorIs(t, err, vm.ErrInvalidEOF)
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 	_, tx, _ := NewTestTemporalDb(t)
@@ -788,3 +858,181 @@ func BenchmarkEVM_SWAP1(b *testing.B) {
 		}
 	})
 }
+
+// blockFusionPrograms - a countdown loop returning remaining gas, the same with memory and keccak in the loop body,
+// stack underflow and stack overflow
+func blockFusionPrograms() map[string][]byte {
+	p, lbl := program.New().Push(100).Jumpdest()
+	countdown := p.Push(1).Op(vm.SWAP1, vm.SUB, vm.DUP1).Push(lbl).Op(vm.JUMPI).
+		Op(vm.POP, vm.GAS).Push(0).Op(vm.MSTORE).Return(0, 32).Bytes()
+
+	p, lbl = program.New().Push(100).Jumpdest()
+	keccak := p.Op(vm.DUP1).Push(64).Op(vm.MSTORE).Push(96).Push(0).Op(vm.KECCAK256, vm.POP).
+		Push(1).Op(vm.SWAP1, vm.SUB, vm.DUP1).Push(lbl).Op(vm.JUMPI).
+		Op(vm.POP, vm.GAS).Push(0).Op(vm.MSTORE).Return(0, 32).Bytes()
+
+	p, lbl = program.New().Jumpdest()
+	overflow := p.Op(vm.PUSH0, vm.PUSH0, vm.PUSH0).Jump(lbl).Bytes()
+
+	return map[string][]byte{
+		"countdown": countdown,
+		"keccak":    keccak,
+		"underflow": program.New().Push(1).Op(vm.ADD).Bytes(),
+		"overflow":  overflow,
+	}
+}
+
+func TestBlockFusion(t *testing.T) {
+	t.Parallel()
+	_, tx, _ := NewTestTemporalDb(t)
+	domains, err := stateLib.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+	state := state.New(state.NewReaderV3(domains.AsGetter(tx)))
+
+	for name, code := range blockFusionPrograms() {
+		contractAddr := common.BytesToAddress([]byte(name))
+		state.SetCode(contractAddr, code)
+		// small limits run out of gas in every block of the program
+		for gas := uint64(1); gas < 50_000; gas += 37 {
+			ret, left, err := Call(contractAddr, nil, &Config{State: state, GasLimit: gas})
+			fusedRet, fusedLeft, fusedErr := Call(contractAddr, nil, &Config{State: state, GasLimit: gas, EVMConfig: vm.Config{BlockFusion: true}})
+			require.Equal(t, ret, fusedRet, "%s, gas %d", name, gas)
+			require.Equal(t, left, fusedLeft, "%s, gas %d", name, gas)
+			require.Equal(t, fmt.Sprint(err), fmt.Sprint(fusedErr), "%s, gas %d", name, gas)
+		}
+	}
+}
+
+// BenchmarkBlockFusion compares per-instruction and per-block dispatch of compute-heavy loops
+//
+// go test -bench=BenchmarkBlockFusion -run=Benchmark ./core/vm/runtime
+func BenchmarkBlockFusion(b *testing.B) {
+	p, lbl := program.New().Push(1).Jumpdest()
+	arithmetic := p.Op(vm.DUP1, vm.DUP1, vm.MUL, vm.ADD, vm.DUP1).Push(3).Op(vm.SHL, vm.XOR).Push(7).Op(vm.OR, vm.ISZERO, vm.ISZERO).
+		Op(vm.DUP1).Push(0x1f).Op(vm.AND, vm.POP).Jump(lbl).Bytes()
+
+	_, tx, _ := NewTestTemporalDb(b)
+	domains, err := stateLib.NewSharedDomains(tx, log.New())
+	require.NoError(b, err)
+	defer domains.Close()
+	state := state.New(state.NewReaderV3(domains.AsGetter(tx)))
+	contractAddr := common.BytesToAddress([]byte("contract"))
+	state.SetCode(contractAddr, arithmetic)
+
+	for _, fused := range []bool{false, true} {
+		b.Run(fmt.Sprintf("arithmetic-loop-10M/fused=%t", fused), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Call(contractAddr, nil, &Config{State: state, GasLimit: 10_000_000, EVMConfig: vm.Config{BlockFusion: fused}}) // nolint:errcheck
+			}
+		})
+	}
+}
+
+func TestOpcodeStats(t *testing.T) {
+	t.Parallel()
+	_, tx, _ := NewTestTemporalDb(t)
+	domains, err := stateLib.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+	state := state.New(state.NewReaderV3(domains.AsGetter(tx)))
+
+	callee := common.BytesToAddress([]byte("callee"))
+	state.SetCode(callee, program.New().Push(1).Push(2).Op(vm.ADD, vm.POP, vm.STOP).Bytes())
+	caller := common.BytesToAddress([]byte("caller"))
+	state.SetCode(caller, program.New().Call(uint256.NewInt(10_000), callee, 0, 0, 0, 0, 0).Op(vm.POP, vm.STOP).Bytes())
+
+	cfg := &Config{State: state, GasLimit: 100_000, EVMConfig: vm.Config{CollectOpcodeStats: true}}
+	setDefaults(cfg)
+	evm := NewEnv(cfg)
+	_, left, err := evm.Call(vm.AccountRef(cfg.Origin), caller, nil, cfg.GasLimit, cfg.Value, false)
+	require.NoError(t, err)
+
+	stats := evm.OpcodeStats()
+	require.NotNil(t, stats)
+	require.Equal(t, uint64(1), stats.Count[vm.CALL])
+	require.Equal(t, uint64(1), stats.Count[vm.ADD])
+	require.Equal(t, uint64(2), stats.Count[vm.STOP])
+	require.Equal(t, vm.GasFastestStep, stats.Gas[vm.ADD])
+	// gas forwarded to the callee is accounted to its instructions only
+	require.Equal(t, cfg.GasLimit-left, stats.TotalGas())
+
+	require.NotSame(t, stats, evm.ResetOpcodeStats())
+	noStats := *cfg
+	noStats.EVMConfig = vm.Config{}
+	require.Nil(t, NewEnv(&noStats).OpcodeStats())
+}
+
+func TestCoverage(t *testing.T) {
+	t.Parallel()
+	_, tx, _ := NewTestTemporalDb(t)
+	domains, err := stateLib.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+	state := state.New(state.NewReaderV3(domains.AsGetter(tx)))
+
+	// PUSH1 1, PUSH1 7, JUMPI, INVALID, JUMPDEST (7), STOP: the INVALID at 5 is skipped
+	callee := common.BytesToAddress([]byte("callee"))
+	state.SetCode(callee, []byte{byte(vm.PUSH1), 1, byte(vm.PUSH1), 7, byte(vm.JUMPI), byte(vm.INVALID), byte(vm.INVALID), byte(vm.JUMPDEST), byte(vm.STOP)})
+	caller := common.BytesToAddress([]byte("caller"))
+	callerCode := program.New().Call(uint256.NewInt(10_000), callee, 0, 0, 0, 0, 0).Op(vm.POP, vm.STOP).Bytes()
+	state.SetCode(caller, callerCode)
+
+	cfg := &Config{State: state, GasLimit: 100_000, EVMConfig: vm.Config{Coverage: true, BlockFusion: true}}
+	setDefaults(cfg)
+	evm := NewEnv(cfg)
+	_, _, err = evm.Call(vm.AccountRef(cfg.Origin), caller, nil, cfg.GasLimit, cfg.Value, false)
+	require.NoError(t, err)
+
+	cov := evm.Coverage()
+	require.NotNil(t, cov)
+	require.Len(t, cov.Calls, 2)
+	require.Equal(t, 1, cov.Calls[0].Depth)
+	require.Equal(t, caller, cov.Calls[0].CodeAddress)
+	require.True(t, cov.Calls[0].Executed(0))
+	require.True(t, cov.Calls[0].Executed(uint64(len(callerCode)-1)))
+	require.Equal(t, 2, cov.Calls[1].Depth)
+	require.Equal(t, callee, cov.Calls[1].CodeAddress)
+	for pc, executed := range []bool{true, false, true, false, true, false, false, true, true} {
+		require.Equal(t, executed, cov.Calls[1].Executed(uint64(pc)), "pc %d", pc)
+	}
+	require.Equal(t, 5, cov.Calls[1].Covered())
+
+	require.NotSame(t, cov, evm.ResetCoverage())
+	noCoverage := *cfg
+	noCoverage.EVMConfig = vm.Config{}
+	require.Nil(t, NewEnv(&noCoverage).Coverage())
+}
+
+// TestDelegationDesignation - EIP-7702: EXTCODE* instructions operate on the delegation designator itself, while
+// calls execute the code of the delegate
+func TestDelegationDesignation(t *testing.T) {
+	t.Parallel()
+	_, tx, _ := NewTestTemporalDb(t)
+	domains, err := stateLib.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+	state := state.New(state.NewReaderV3(domains.AsGetter(tx)))
+
+	delegate := common.BytesToAddress([]byte("delegate"))
+	state.SetCode(delegate, program.New().Push(0x2a).Push(0).Op(vm.MSTORE).Return(0, 32).Bytes())
+	delegated := common.BytesToAddress([]byte("delegated"))
+	designation := types.AddressToDelegation(delegate)
+	state.SetCode(delegated, designation)
+
+	caller := common.BytesToAddress([]byte("caller"))
+	state.SetCode(caller, program.New().
+		Push(delegated).Op(vm.EXTCODESIZE).Push(0).Op(vm.MSTORE).
+		Push(delegated).Op(vm.EXTCODEHASH).Push(32).Op(vm.MSTORE).
+		ExtcodeCopy(delegated, 64, 0, types.DelegateDesignationCodeSize).
+		Call(nil, delegated, 0, 0, 0, 96, 32).Op(vm.POP).
+		Return(0, 128).Bytes())
+
+	ret, _, err := Call(caller, nil, &Config{State: state})
+	require.NoError(t, err)
+	require.Equal(t, uint64(types.DelegateDesignationCodeSize), new(uint256.Int).SetBytes(ret[:32]).Uint64())
+	require.Equal(t, crypto.Keccak256(designation), ret[32:64])
+	require.Equal(t, designation, ret[64:64+types.DelegateDesignationCodeSize])
+	require.Equal(t, uint64(0x2a), new(uint256.Int).SetBytes(ret[96:128]).Uint64())
+}
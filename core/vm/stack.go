@@ -29,6 +29,7 @@ import (
 
 var stackPool = sync.Pool{
 	New: func() interface{} {
+		mxStackPoolMisses.Inc()
 		return &Stack{data: make([]uint256.Int, 0, 16)}
 	},
 }
@@ -41,6 +42,7 @@ type Stack struct {
 }
 
 func New() *Stack {
+	mxStackPoolGets.Inc()
 	stack, ok := stackPool.Get().(*Stack)
 	if !ok {
 		log.Error("Type assertion failure", "err", "cannot get Stack pointer from stackPool")
@@ -136,6 +136,7 @@ func SetupEndpoints(ctx *cli.Context, node *node.ErigonNode, diagMux *http.Serve
 	SetupCmdLineAccess(diagMux)
 	SetupFlagsAccess(ctx, diagMux)
 	SetupVersionAccess(diagMux)
+	SetupBuildInfoAccess(ctx, diagMux, node)
 	SetupBlockBodyDownload(diagMux)
 	SetupHeaderDownloadStats(diagMux)
 	SetupNodeInfoAccess(diagMux, node)
@@ -26,14 +26,14 @@ import (
 	"github.com/c2h5oh/datasize"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_downloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	prototypes "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
 
-func NewClient(ctx context.Context, downloaderAddr string) (proto_downloader.DownloaderClient, error) {
+func NewClient(ctx context.Context, downloaderAddr string, sec *grpcutil.Security) (proto_downloader.DownloaderClient, error) {
 	// creating grpc client connection
 	var dialOpts []grpc.DialOption
 
@@ -46,7 +46,7 @@ func NewClient(ctx context.Context, downloaderAddr string) (proto_downloader.Dow
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{}),
 	}
 
-	dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	dialOpts = append(dialOpts, sec.DialOptions()...)
 	conn, err := grpc.DialContext(ctx, downloaderAddr, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating client connection to sentry P2P: %w", err)
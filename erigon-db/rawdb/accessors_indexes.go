@@ -23,6 +23,7 @@ import (
 	"encoding/binary"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
@@ -66,6 +67,68 @@ func WriteTxLookupEntries(db kv.Putter, block *types.Block, txNum uint64) {
 	}
 }
 
+// TxLookupTombstone - value of TxLookup entry of a transaction unwound from the canonical chain: number and hash
+// of the block it was included into. It has different length than canonical entry, so canonical readers skip it.
+func TxLookupTombstone(blockNum uint64, blockHash common.Hash) []byte {
+	data := make([]byte, 8+length.Hash)
+	binary.BigEndian.PutUint64(data[:8], blockNum)
+	copy(data[8:], blockHash[:])
+	return data
+}
+
+// WriteTxLookupTombstoneIndex - records that TxLookup entry of `txnHash` was tombstoned at `blockNum`,
+// so PruneTxLookupTombstones can find it without TxLookup table scan
+func WriteTxLookupTombstoneIndex(db kv.Putter, blockNum uint64, txnHash common.Hash) error {
+	k := make([]byte, 8+length.Hash)
+	binary.BigEndian.PutUint64(k, blockNum)
+	copy(k[8:], txnHash[:])
+	return db.Put(kv.TxLookupTombstones, k, nil)
+}
+
+// PruneTxLookupTombstones - deletes tombstones of blocks before `toBlock`. Entries of transactions which
+// became canonical again are kept.
+func PruneTxLookupTombstones(tx kv.RwTx, toBlock uint64) error {
+	c, err := tx.RwCursor(kv.TxLookupTombstones)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	for k, _, err := c.First(); k != nil; k, _, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		if binary.BigEndian.Uint64(k) >= toBlock {
+			break
+		}
+		v, err := tx.GetOne(kv.TxLookup, k[8:])
+		if err != nil {
+			return err
+		}
+		if len(v) == 8+length.Hash && binary.BigEndian.Uint64(v) == binary.BigEndian.Uint64(k) {
+			if err := tx.Delete(kv.TxLookup, k[8:]); err != nil {
+				return err
+			}
+		}
+		if err := c.DeleteCurrent(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadTxLookupTombstone retrieves the non-canonical block a transaction was included into before it was unwound.
+// ok=false if transaction is canonical or unknown.
+func ReadTxLookupTombstone(db kv.Getter, txnHash common.Hash) (blockNum uint64, blockHash common.Hash, ok bool, err error) {
+	data, err := db.GetOne(kv.TxLookup, txnHash.Bytes())
+	if err != nil {
+		return 0, common.Hash{}, false, err
+	}
+	if len(data) != 8+length.Hash {
+		return 0, common.Hash{}, false, nil
+	}
+	return binary.BigEndian.Uint64(data[:8]), common.BytesToHash(data[8:]), true, nil
+}
+
 // DeleteTxLookupEntry removes all transaction data associated with a hash.
 func DeleteTxLookupEntry(db kv.Putter, hash common.Hash) error {
 	return db.Delete(kv.TxLookup, hash.Bytes())
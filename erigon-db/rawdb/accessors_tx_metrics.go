@@ -0,0 +1,84 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+)
+
+// TxMetrics - resources used by execution of a transaction, beyond gas
+type TxMetrics struct {
+	ExecutionTime time.Duration
+	StateReads    uint64 // accounts, storage slots and code read from the state
+	StateWrites   uint64 // accounts, storage slots and code written to the state
+	CallDepth     uint64 // max depth of EVM calls, 1 - no internal calls
+}
+
+const txMetricsSize = 4 * 8
+
+// WriteTxMetrics stores metrics of the transaction with number `txNum`
+func WriteTxMetrics(db kv.Putter, txNum uint64, m *TxMetrics) error {
+	v := make([]byte, txMetricsSize)
+	binary.BigEndian.PutUint64(v, uint64(m.ExecutionTime))
+	binary.BigEndian.PutUint64(v[8:], m.StateReads)
+	binary.BigEndian.PutUint64(v[16:], m.StateWrites)
+	binary.BigEndian.PutUint64(v[24:], m.CallDepth)
+	return db.Put(kv.TxMetrics, hexutil.EncodeTs(txNum), v)
+}
+
+// ReadTxMetrics retrieves metrics of the transaction with number `txNum`, nil if they weren't collected
+func ReadTxMetrics(db kv.Getter, txNum uint64) (*TxMetrics, error) {
+	v, err := db.GetOne(kv.TxMetrics, hexutil.EncodeTs(txNum))
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	if len(v) != txMetricsSize {
+		return nil, fmt.Errorf("invalid tx metrics of txNum %d: length %d", txNum, len(v))
+	}
+	return &TxMetrics{
+		ExecutionTime: time.Duration(binary.BigEndian.Uint64(v)),
+		StateReads:    binary.BigEndian.Uint64(v[8:]),
+		StateWrites:   binary.BigEndian.Uint64(v[16:]),
+		CallDepth:     binary.BigEndian.Uint64(v[24:]),
+	}, nil
+}
+
+// TruncateTxMetrics deletes metrics of transactions from `fromTxNum`, on unwind of execution
+func TruncateTxMetrics(tx kv.RwTx, fromTxNum uint64) error {
+	c, err := tx.RwCursor(kv.TxMetrics)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	for k, _, err := c.Seek(hexutil.EncodeTs(fromTxNum)); k != nil; k, _, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		if err := c.DeleteCurrent(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
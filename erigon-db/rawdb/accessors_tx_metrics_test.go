@@ -0,0 +1,66 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+)
+
+func TestTxMetrics(t *testing.T) {
+	require := require.New(t)
+	_, tx := memdb.NewTestTx(t)
+
+	m, err := ReadTxMetrics(tx, 1)
+	require.NoError(err)
+	require.Nil(m)
+
+	for txNum := uint64(1); txNum <= 5; txNum++ {
+		require.NoError(WriteTxMetrics(tx, txNum, &TxMetrics{
+			ExecutionTime: time.Duration(txNum) * time.Microsecond,
+			StateReads:    txNum * 10,
+			StateWrites:   txNum * 2,
+			CallDepth:     txNum,
+		}))
+	}
+	m, err = ReadTxMetrics(tx, 3)
+	require.NoError(err)
+	require.Equal(&TxMetrics{ExecutionTime: 3 * time.Microsecond, StateReads: 30, StateWrites: 6, CallDepth: 3}, m)
+
+	// unwind of execution to txNum 3
+	require.NoError(TruncateTxMetrics(tx, 4))
+	for txNum := uint64(1); txNum <= 5; txNum++ {
+		m, err = ReadTxMetrics(tx, txNum)
+		require.NoError(err)
+		if txNum < 4 {
+			require.NotNil(m, txNum)
+			require.Equal(txNum, m.CallDepth)
+		} else {
+			require.Nil(m, txNum)
+		}
+	}
+
+	require.NoError(tx.Put(kv.TxMetrics, hexutil.EncodeTs(7), []byte{1}))
+	_, err = ReadTxMetrics(tx, 7)
+	require.ErrorContains(err, "invalid tx metrics of txNum 7")
+}
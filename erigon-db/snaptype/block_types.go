@@ -28,11 +28,11 @@ import (
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/compress"
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/recsplit"
-	"github.com/erigontech/erigon-lib/rlp"
 	"github.com/erigontech/erigon-lib/seg"
 	"github.com/erigontech/erigon-lib/snaptype"
 	"github.com/erigontech/erigon-lib/types"
@@ -197,6 +197,9 @@ var (
 				}()
 				firstBlockNum := sn.From
 
+				if err := compress.LoadDicts(DictsDir(filepath.Dir(sn.Path))); err != nil {
+					return err
+				}
 				bodiesSegment, err := seg.NewDecompressor(sn.As(Bodies).Path)
 				if err != nil {
 					return fmt.Errorf("can't open %s for indexing: %w", sn.As(Bodies).Name(), err)
@@ -257,6 +260,7 @@ var (
 				txnHash2BlockNumIdx.LogLvl(log.LvlDebug)
 
 				bodyBuf, word := make([]byte, 0, 4096), make([]byte, 0, 4096)
+				var decodedBuf []byte
 
 				defer d.MadvSequential().DisableReadAhead()
 				defer bodiesSegment.MadvSequential().DisableReadAhead()
@@ -268,7 +272,7 @@ var (
 					body := &types.BodyForStorage{}
 
 					bodyBuf, _ = bodyGetter.Next(bodyBuf[:0])
-					if err := rlp.DecodeBytes(bodyBuf, body); err != nil {
+					if decodedBuf, err = DecodeBody(decodedBuf, bodyBuf, body); err != nil {
 						return err
 					}
 
@@ -291,7 +295,7 @@ var (
 							}
 
 							bodyBuf, _ = bodyGetter.Next(bodyBuf[:0])
-							if err := rlp.DecodeBytes(bodyBuf, body); err != nil {
+							if decodedBuf, err = DecodeBody(decodedBuf, bodyBuf, body); err != nil {
 								return err
 							}
 
@@ -414,7 +418,8 @@ func TxsAmountBasedOnBodiesSnapshots(bodiesSegment *seg.Decompressor, len uint64
 	gg := bodiesSegment.MakeGetter()
 	buf, _ := gg.Next(nil)
 	firstBody := &types.BodyForStorage{}
-	if err = rlp.DecodeBytes(buf, firstBody); err != nil {
+	var decodedBuf []byte
+	if decodedBuf, err = DecodeBody(decodedBuf, buf, firstBody); err != nil {
 		return
 	}
 	baseTxID = firstBody.BaseTxnID
@@ -425,7 +430,7 @@ func TxsAmountBasedOnBodiesSnapshots(bodiesSegment *seg.Decompressor, len uint64
 		i++
 		if i == len {
 			buf, _ = gg.Next(buf[:0])
-			if err = rlp.DecodeBytes(buf, lastBody); err != nil {
+			if _, err = DecodeBody(decodedBuf, buf, lastBody); err != nil {
 				return
 			}
 			if gg.HasNext() {
@@ -17,9 +17,13 @@
 package snaptype_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/erigontech/erigon-db/snaptype"
+	"github.com/erigontech/erigon-lib/common/compress"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
 )
 
 func TestEnumeration(t *testing.T) {
@@ -52,3 +56,57 @@ func TestNames(t *testing.T) {
 		t.Fatal("name mismatch", snaptype.Transactions, snaptype.Transactions.Name(), snaptype.Enums.Transactions.String())
 	}
 }
+
+func TestDecodeBody(t *testing.T) {
+	var words [][]byte
+	for i := uint64(0); i < 1000; i++ {
+		b := &types.BodyForStorage{BaseTxnID: types.BaseTxnID(i * 100), TxCount: uint32(i%50 + 2)}
+		if i%2 == 0 {
+			b.Withdrawals = types.Withdrawals{{Index: i, Validator: i * 3, Amount: 32_000_000_000}}
+		}
+		word, err := rlp.EncodeToBytes(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		words = append(words, word)
+	}
+	raw, err := compress.TrainDict(words, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := compress.RegisterDict(snaptype.BodiesDict, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// id is the content address: a changed dictionary is rejected
+	tampered := append([]byte{}, raw...)
+	tampered[len(tampered)-1] ^= 0xff
+	if _, err := compress.RegisterDict(snaptype.BodiesDict, tampered); err == nil {
+		t.Fatal("expected error on the tampered dictionary")
+	}
+
+	var encoded int
+	for i, word := range words {
+		_, enc := compress.EncodeWord(nil, word, d)
+		if enc[0] == compress.CodecZstd {
+			encoded++
+		}
+		for _, w := range [][]byte{word, enc} { // old files keep the plain words
+			var b types.BodyForStorage
+			if _, err := snaptype.DecodeBody(nil, w, &b); err != nil {
+				t.Fatal(i, err)
+			}
+			if b.BaseTxnID.U64() != uint64(i)*100 || b.TxCount != uint32(i%50+2) || len(b.Withdrawals) != 1-i%2 {
+				t.Fatal("body mismatch", i, b.BaseTxnID, b.TxCount, len(b.Withdrawals))
+			}
+		}
+	}
+	if encoded == 0 {
+		t.Fatal("no words encoded with the dictionary")
+	}
+
+	var b types.BodyForStorage
+	if _, err := snaptype.DecodeBody(nil, []byte{0x7f, 1, 2}, &b); !errors.Is(err, compress.ErrUnknownCodec) {
+		t.Fatal("expected unknown codec error", err)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snaptype
+
+import (
+	"path/filepath"
+
+	"github.com/erigontech/erigon-lib/common/compress"
+	"github.com/erigontech/erigon-lib/rlp"
+)
+
+// BodiesDict - name of the zstd dictionaries of the words of the bodies files. Produced and published files stay
+// plain RLP: only `seg codec-reencode` encodes the local files with a dictionary of DictsDir.
+const BodiesDict = "bodies"
+
+// DictsDir - directory of the zstd dictionaries of the words of the files, must be kept together with the re-encoded files
+func DictsDir(snapDir string) string { return filepath.Join(snapDir, "dicts") }
+
+// DecodeBody - decodes the body word of the bodies file, written with any codec, into `b`
+func DecodeBody(buf, word []byte, b interface{}) ([]byte, error) {
+	buf, word, err := compress.DecodeWord(buf, word)
+	if err != nil {
+		return buf, err
+	}
+	return buf, rlp.DecodeBytes(word, b)
+}
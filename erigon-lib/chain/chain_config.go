@@ -75,6 +75,10 @@ type Config struct {
 	PragueTime   *big.Int `json:"pragueTime,omitempty"`
 	OsakaTime    *big.Int `json:"osakaTime,omitempty"`
 
+	// (Optional) EOF: EIP-3540, EIP-3670, EIP-4200, EIP-4750 and EIP-5450. Not scheduled on public networks,
+	// enables EOF contracts on test and developer chains. Requires Prague.
+	EOFTime *big.Int `json:"eofTime,omitempty"`
+
 	// Optional EIP-4844 parameters (see also EIP-7691, EIP-7840, EIP-7892)
 	MinBlobGasPrice       *uint64                       `json:"minBlobGasPrice,omitempty"`
 	BlobSchedule          map[string]*params.BlobConfig `json:"blobSchedule,omitempty"`
@@ -108,6 +112,24 @@ type Config struct {
 
 	// Account Abstraction
 	AllowAA bool
+
+	// OpcodeOverrides - operator's changes of opcodes (L2s, private chains), not a part of the chain spec:
+	// loaded from the file of --evm.opcode-overrides, see vm.LoadOpcodeOverrides
+	OpcodeOverrides []OpcodeOverride `json:"-"`
+}
+
+// OpcodeOverride - change of an opcode in blocks From..To
+type OpcodeOverride struct {
+	Opcode   byte
+	From     uint64
+	To       *uint64 // inclusive, nil - no end
+	Gas      *uint64 // constant gas, nil - unchanged
+	Disabled bool    // the opcode is an invalid instruction
+}
+
+// Active returns true if the override applies to the block
+func (o *OpcodeOverride) Active(num uint64) bool {
+	return num >= o.From && (o.To == nil || num <= *o.To)
 }
 
 var (
@@ -341,6 +363,11 @@ func (c *Config) IsOsaka(time uint64) bool {
 	return isForked(c.OsakaTime, time)
 }
 
+// IsEOF returns whether EOF contracts are enabled at the given time.
+func (c *Config) IsEOF(time uint64) bool {
+	return isForked(c.EOFTime, time) && c.IsPrague(time)
+}
+
 func (c *Config) GetBurntContract(num uint64) *common.Address {
 	if len(c.BurntContract) == 0 {
 		return nil
@@ -666,7 +693,7 @@ type Rules struct {
 	IsByzantium, IsConstantinople, IsPetersburg       bool
 	IsIstanbul, IsBerlin, IsLondon, IsShanghai        bool
 	IsCancun, IsNapoli, IsBhilai                      bool
-	IsPrague, IsOsaka                                 bool
+	IsPrague, IsOsaka, IsEOF                          bool
 	IsAura                                            bool
 }
 
@@ -694,6 +721,7 @@ func (c *Config) Rules(num uint64, time uint64) *Rules {
 		IsBhilai:           c.IsBhilai(num),
 		IsPrague:           c.IsPrague(time) || c.IsBhilai(num),
 		IsOsaka:            c.IsOsaka(time),
+		IsEOF:              c.IsEOF(time),
 		IsAura:             c.Aura != nil,
 	}
 }
@@ -243,3 +243,25 @@ func TestBlobParameterDencunAndPectraAtGenesis(t *testing.T) {
 	assert.Equal(t, uint64(9), c.GetMaxBlobsPerBlock(0))
 	assert.Equal(t, uint64(5007716), c.GetBlobGasPriceUpdateFraction(0))
 }
+
+func TestForks(t *testing.T) {
+	c := &Config{
+		HomesteadBlock:          big.NewInt(0),
+		ConstantinopleBlock:     big.NewInt(10),
+		PetersburgBlock:         big.NewInt(20),
+		TerminalTotalDifficulty: big.NewInt(0),
+		CancunTime:              big.NewInt(1000),
+		Bpo1Time:                big.NewInt(2000),
+	}
+	var names []string
+	for _, f := range c.Forks() {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"homestead", "constantinople", "petersburg", "paris", "cancun", "bpo1"}, names)
+
+	assert.Contains(t, c.ActiveEIPs(15, 0), 1283)
+	assert.NotContains(t, c.ActiveEIPs(20, 0), 1283) // reverted by Petersburg
+	assert.Contains(t, c.ActiveEIPs(20, 0), 1014)
+	assert.NotContains(t, c.ActiveEIPs(30, 999), 4844)
+	assert.Contains(t, c.ActiveEIPs(30, 1000), 4844)
+}
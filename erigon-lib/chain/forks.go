@@ -0,0 +1,144 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package chain
+
+import (
+	"math/big"
+	"slices"
+)
+
+// Fork is a scheduled network upgrade, activated at Block or, after The Merge, at Time
+type Fork struct {
+	Name    string
+	Block   *big.Int
+	Time    *big.Int
+	EIPs    []int // of the execution layer, introduced by the fork
+	Removed []int // EIPs of earlier forks reverted by the fork
+}
+
+// Active returns true if the fork is active at the block
+func (f Fork) Active(num, time uint64) bool {
+	if f.Block != nil {
+		return isForked(f.Block, num)
+	}
+	return isForked(f.Time, time)
+}
+
+// forkEIPs are the EIPs of the execution layer introduced by the forks (blob parameter only forks have none),
+// see https://github.com/ethereum/execution-specs/tree/master/network-upgrades/mainnet-upgrades
+var forkEIPs = map[string][]int{
+	"homestead":        {2, 7},
+	"dao":              {779},
+	"tangerineWhistle": {150},
+	"spuriousDragon":   {155, 160, 161, 170},
+	"byzantium":        {100, 140, 196, 197, 198, 211, 214, 649, 658},
+	"constantinople":   {145, 1014, 1052, 1234, 1283},
+	"istanbul":         {152, 1108, 1344, 1884, 2028, 2200},
+	"muirGlacier":      {2384},
+	"berlin":           {2565, 2718, 2929, 2930},
+	"london":           {1559, 3198, 3529, 3541, 3554},
+	"arrowGlacier":     {4345},
+	"grayGlacier":      {5133},
+	"paris":            {3675, 4399},
+	"shanghai":         {3651, 3855, 3860, 4895, 6049},
+	"cancun":           {1153, 4788, 4844, 5656, 6780, 7044, 7045, 7514, 7516},
+	"prague":           {2537, 2935, 6110, 7002, 7251, 7549, 7623, 7685, 7691, 7702, 7840},
+	"osaka":            {7594, 7823, 7825, 7883, 7892, 7918, 7934, 7939, 7951},
+	"eof":              {3540, 3670, 4200, 4750, 5450},
+	// Polygon
+	"agra":   {3855, 3860}, // Shanghai without withdrawals
+	"napoli": {1153, 4788, 5656, 7516},
+	"bhilai": {2537, 7623, 7702},
+}
+
+// Forks returns the scheduled forks in the order of activation, block based ones first
+func (c *Config) Forks() []Fork {
+	var paris *big.Int
+	if c.MergeHeight != nil {
+		paris = c.MergeHeight
+	} else if c.TerminalTotalDifficulty != nil && c.TerminalTotalDifficulty.Sign() == 0 {
+		paris = big.NewInt(0)
+	}
+	forks := []Fork{
+		{Name: "homestead", Block: c.HomesteadBlock},
+		{Name: "dao", Block: c.DAOForkBlock},
+		{Name: "tangerineWhistle", Block: c.TangerineWhistleBlock},
+		{Name: "spuriousDragon", Block: c.SpuriousDragonBlock},
+		{Name: "byzantium", Block: c.ByzantiumBlock},
+		{Name: "constantinople", Block: c.ConstantinopleBlock},
+		{Name: "petersburg", Block: c.PetersburgBlock, Removed: []int{1283}},
+		{Name: "istanbul", Block: c.IstanbulBlock},
+		{Name: "muirGlacier", Block: c.MuirGlacierBlock},
+		{Name: "berlin", Block: c.BerlinBlock},
+		{Name: "london", Block: c.LondonBlock},
+		{Name: "arrowGlacier", Block: c.ArrowGlacierBlock},
+		{Name: "grayGlacier", Block: c.GrayGlacierBlock},
+		{Name: "paris", Block: paris},
+		{Name: "mergeNetsplit", Block: c.MergeNetsplitBlock},
+	}
+	if c.Bor != nil {
+		forks = append(forks,
+			Fork{Name: "agra", Block: c.Bor.GetAgraBlock()},
+			Fork{Name: "napoli", Block: c.Bor.GetNapoliBlock()},
+			Fork{Name: "ahmedabad", Block: c.Bor.GetAhmedabadBlock()},
+			Fork{Name: "bhilai", Block: c.Bor.GetBhilaiBlock()},
+		)
+	}
+	forks = append(forks,
+		Fork{Name: "shanghai", Time: c.ShanghaiTime},
+		Fork{Name: "cancun", Time: c.CancunTime},
+		Fork{Name: "prague", Time: c.PragueTime},
+		Fork{Name: "osaka", Time: c.OsakaTime},
+		Fork{Name: "bpo1", Time: c.Bpo1Time},
+		Fork{Name: "bpo2", Time: c.Bpo2Time},
+		Fork{Name: "bpo3", Time: c.Bpo3Time},
+		Fork{Name: "bpo4", Time: c.Bpo4Time},
+		Fork{Name: "bpo5", Time: c.Bpo5Time},
+		Fork{Name: "eof", Time: c.EOFTime},
+	)
+	scheduled := forks[:0]
+	for _, f := range forks {
+		if f.Block == nil && f.Time == nil {
+			continue
+		}
+		f.EIPs = forkEIPs[f.Name]
+		scheduled = append(scheduled, f)
+	}
+	return scheduled
+}
+
+// ActiveEIPs returns the sorted EIPs of the execution layer active at the block
+func (c *Config) ActiveEIPs(num, time uint64) []int {
+	active := map[int]struct{}{}
+	for _, f := range c.Forks() {
+		if !f.Active(num, time) {
+			continue
+		}
+		for _, eip := range f.EIPs {
+			active[eip] = struct{}{}
+		}
+		for _, eip := range f.Removed {
+			delete(active, eip)
+		}
+	}
+	eips := make([]int, 0, len(active))
+	for eip := range active {
+		eips = append(eips, eip)
+	}
+	slices.Sort(eips)
+	return eips
+}
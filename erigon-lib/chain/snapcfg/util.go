@@ -21,6 +21,7 @@ import (
 	_ "embed"
 	"encoding/json"
 	"errors"
+	"maps"
 	"path/filepath"
 	"slices"
 	"strconv"
@@ -496,6 +497,15 @@ var KnownWebseeds = map[string][]string{
 	networkname.Hoodi:      webseedsParse(webseed.Hoodi),
 }
 
+// webseedsOverride - operator-supplied webseeds, survive re-loading of remote preverified hashes
+var webseedsOverride = map[string][]string{}
+
+// SetWebseeds - replaces known webseeds of the network
+func SetWebseeds(networkName string, webseeds []string) {
+	webseedsOverride[networkName] = webseeds
+	KnownWebseeds[networkName] = webseeds
+}
+
 func webseedsParse(in []byte) (res []string) {
 	a := map[string]string{}
 	if err := toml.Unmarshal(in, &a); err != nil {
@@ -508,6 +518,9 @@ func webseedsParse(in []byte) (res []string) {
 	return res
 }
 
+// GitBranch is the branch of erigontech/erigon-snapshot the remote preverified hashes are loaded from
+func GitBranch() string { return snapshotGitBranch }
+
 func LoadRemotePreverified(ctx context.Context) (err error) {
 	// Can't log in erigon-snapshot repo due to erigon-lib module import path.
 	log.Info("Loading remote snapshot hashes")
@@ -543,6 +556,7 @@ func LoadRemotePreverified(ctx context.Context) (err error) {
 		networkname.Holesky:    webseedsParse(webseed.Holesky),
 		networkname.Hoodi:      webseedsParse(webseed.Hoodi),
 	}
+	maps.Copy(KnownWebseeds, webseedsOverride)
 
 	knownPreverified = map[string]Preverified{
 		networkname.Mainnet:    Mainnet,
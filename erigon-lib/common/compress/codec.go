@@ -0,0 +1,255 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package compress
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec of the stored words which are RLP lists: bodies of the block files, receipts of the receipts cache.
+// Words stored before the codec are RLP lists as is - their first byte is >= 0xc0. So a lower first byte is the
+// version of the codec the rest of the word is encoded with, and the old files stay readable.
+const (
+	CodecZstd byte = 0x01 // zstd frame, may refer to a registered dictionary by its ID
+
+	codecLegacy byte = 0xc0 // first byte of any RLP list
+)
+
+// DictExt - extension of the files of the zstd dictionaries: <name>-<id>.zdict
+const DictExt = ".zdict"
+
+// IDs below are reserved by zstd, see DictID
+const minDictID = 32768
+
+var ErrUnknownCodec = errors.New("unknown codec of the word")
+
+// Dict - zstd dictionary of the words of one kind (name). ID is derived from the content (see DictID), so the same
+// ID means the same dictionary on any node.
+type Dict struct {
+	Name string
+	ID   uint32
+	Raw  []byte
+
+	enc *zstd.Encoder
+}
+
+func (d *Dict) FileName() string { return DictFileName(d.Name, d.ID) }
+
+func DictFileName(name string, id uint32) string {
+	return fmt.Sprintf("%s-%d%s", name, id, DictExt)
+}
+
+// dictDecoders - decoders aware of all the registered dictionaries. Replaced on registration of a new dictionary.
+type dictDecoders struct {
+	raw  [][]byte
+	pool sync.Pool
+}
+
+func newDictDecoders(raw [][]byte) *dictDecoders {
+	d := &dictDecoders{raw: raw}
+	d.pool.New = func() interface{} {
+		dec, _ := zstd.NewReader(nil, zstd.WithDecoderDicts(raw...))
+		return dec
+	}
+	return d
+}
+
+var (
+	dictsLock sync.Mutex
+	dicts     = map[uint32]*Dict{}
+	decoders  atomic.Pointer[dictDecoders]
+)
+
+func init() {
+	decoders.Store(newDictDecoders(nil))
+}
+
+// DictID - content address of the zstd dictionary `raw`: hash of everything after its magic and ID fields
+func DictID(raw []byte) (uint32, error) {
+	if len(raw) < 8 {
+		return 0, errors.New("dictionary too short")
+	}
+	h := sha256.Sum256(raw[8:])
+	return minDictID + binary.BigEndian.Uint32(h[:])%(1<<31-minDictID), nil
+}
+
+// TrainDict - builds a zstd dictionary of `size` bytes from the sample words, its ID is set to DictID. The last
+// samples are the most valuable: they make the history of the dictionary, so pass the samples in order of age.
+func TrainDict(samples [][]byte, size int) ([]byte, error) {
+	var history []byte
+	for i := len(samples) - 1; i >= 0 && len(history) < size; i-- {
+		s := samples[i]
+		if len(s) > size-len(history) {
+			s = s[len(s)-(size-len(history)):]
+		}
+		history = append(s[:len(s):len(s)], history...)
+	}
+	raw, err := zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       minDictID, // replaced by the content address below
+		Contents: samples,
+		History:  history,
+		Offsets:  [3]int{1, 4, 8},
+		Level:    zstd.SpeedBetterCompression,
+	})
+	if err != nil {
+		return nil, err
+	}
+	id, err := DictID(raw)
+	if err != nil {
+		return nil, err
+	}
+	binary.LittleEndian.PutUint32(raw[4:8], id) // magic, then ID
+	return raw, nil
+}
+
+// RegisterDict - makes the dictionary known to DecodeWord, and returns it to encode the words of its kind
+func RegisterDict(name string, raw []byte) (*Dict, error) {
+	info, err := zstd.InspectDictionary(raw)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary %s: %w", name, err)
+	}
+	id, err := DictID(raw)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary %s: %w", name, err)
+	}
+	if info.ID() != id {
+		return nil, fmt.Errorf("dictionary %s: id %d doesn't match its content %d, corrupted or not built by TrainDict", name, info.ID(), id)
+	}
+
+	dictsLock.Lock()
+	defer dictsLock.Unlock()
+	if d, ok := dicts[info.ID()]; ok {
+		if d.Name != name {
+			return nil, fmt.Errorf("dictionary %s: id %d is taken by %s", name, info.ID(), d.Name)
+		}
+		return d, nil
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderDict(raw), zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	if err != nil {
+		return nil, fmt.Errorf("dictionary %s: %w", name, err)
+	}
+	d := &Dict{Name: name, ID: info.ID(), Raw: raw, enc: enc}
+	dicts[d.ID] = d
+
+	all := make([][]byte, 0, len(dicts))
+	for _, d := range dicts {
+		all = append(all, d.Raw)
+	}
+	decoders.Store(newDictDecoders(all))
+	return d, nil
+}
+
+// LoadDicts - registers the dictionaries of the directory, missing directory means no dictionaries
+func LoadDicts(dir string) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != DictExt {
+			continue
+		}
+		name, id, ok := parseDictFileName(f.Name())
+		if !ok || registered(id) {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return err
+		}
+		d, err := RegisterDict(name, raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		if d.ID != id {
+			return fmt.Errorf("%s: file name doesn't match dictionary id %d", f.Name(), d.ID)
+		}
+	}
+	return nil
+}
+
+func registered(id uint32) bool {
+	dictsLock.Lock()
+	defer dictsLock.Unlock()
+	_, ok := dicts[id]
+	return ok
+}
+
+func parseDictFileName(fileName string) (name string, id uint32, ok bool) {
+	base := strings.TrimSuffix(fileName, DictExt)
+	i := strings.LastIndexByte(base, '-')
+	if i <= 0 {
+		return "", 0, false
+	}
+	n, err := strconv.ParseUint(base[i+1:], 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+	return base[:i], uint32(n), true
+}
+
+// EncodeWord - encodes the RLP list `word` with the dictionary into buf. Returns the word unchanged if the
+// dictionary is nil or if compression doesn't make it shorter: such words stay readable by the older versions.
+func EncodeWord(buf, word []byte, d *Dict) (outBuf []byte, encoded []byte) {
+	if d == nil || len(word) == 0 {
+		return buf, word
+	}
+	buf = growslice(buf, 1+len(word)+len(word)/255+16)
+	buf[0] = CodecZstd
+	buf = d.enc.EncodeAll(word, buf[:1])
+	if len(buf) >= len(word) {
+		return buf, word
+	}
+	return buf, buf
+}
+
+// DecodeWord - decodes the word stored by EncodeWord into buf, legacy words are returned unchanged
+func DecodeWord(buf, word []byte) (outBuf []byte, decoded []byte, err error) {
+	if len(word) == 0 || word[0] >= codecLegacy {
+		return buf, word, nil
+	}
+	switch word[0] {
+	case CodecZstd:
+		d := decoders.Load()
+		dec := d.pool.Get().(*zstd.Decoder)
+		defer func() {
+			_ = dec.Reset(nil)
+			d.pool.Put(dec)
+		}()
+		out, err := dec.DecodeAll(word[1:], growslice(buf, 3*len(word))[:0])
+		if err != nil {
+			return buf, nil, fmt.Errorf("zstd word: %w", err)
+		}
+		return out, out, nil
+	default:
+		return buf, nil, fmt.Errorf("%w: 0x%x", ErrUnknownCodec, word[0])
+	}
+}
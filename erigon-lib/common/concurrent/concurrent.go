@@ -93,3 +93,10 @@ func (m *SyncMap[K, T]) Delete(k K) (t T, deleted bool) {
 	delete(m.m, k)
 	return val, true
 }
+
+// Len returns the number of key-value pairs in the map.
+func (m *SyncMap[K, T]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.m)
+}
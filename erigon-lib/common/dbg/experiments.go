@@ -73,6 +73,11 @@ var (
 	numWorkers    = runtime.NumCPU() / 2
 	Exec3Workers  = EnvInt("EXEC3_WORKERS", numWorkers)
 
+	// experimental Block-STM executor of core.ExecuteBlockEphemerally (eth_getWitness, t8n, stateless verification) only,
+	// staged sync doesn't use it: see EXEC3_PARALLEL
+	EphemeralParallel        = EnvBool("EPHEMERAL_EXEC_PARALLEL", false)
+	EphemeralParallelWorkers = EnvInt("EPHEMERAL_EXEC_WORKERS", numWorkers)
+
 	TraceAccounts        = EnvStrings("TRACE_ACCOUNTS", ",", nil)
 	TraceStateKeys       = EnvStrings("TRACE_STATE_KEYS", ",", nil)
 	TraceInstructions    = EnvBool("TRACE_INSTRUCTIONS", false)
@@ -0,0 +1,203 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package mem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/shirou/gopsutil/v4/process"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// WatchdogCfg - zero threshold disables its check
+type WatchdogCfg struct {
+	Dir        string
+	RSS        datasize.ByteSize
+	Goroutines int
+	GCPause    time.Duration // longest stop-the-world pause since previous check
+	MaxFiles   int           // captures to keep in Dir, older are removed
+	Interval   time.Duration
+	Cooldown   time.Duration // min time between captures of the same reason: problems usually last longer than a check interval
+}
+
+func (cfg WatchdogCfg) Enabled() bool {
+	return cfg.Dir != "" && (cfg.RSS > 0 || cfg.Goroutines > 0 || cfg.GCPause > 0)
+}
+
+// Watchdog - saves heap and goroutine profiles at the moment RSS, goroutines count or GC pause exceed thresholds:
+// post-incident debugging usually lacks profiles from the time things went wrong.
+type Watchdog struct {
+	cfg    WatchdogCfg
+	logger log.Logger
+
+	proc     *process.Process
+	numGC    uint32
+	captured map[string]time.Time // reason -> time of last capture
+}
+
+func NewWatchdog(cfg WatchdogCfg, logger log.Logger) (*Watchdog, error) {
+	if cfg.Interval == 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.Cooldown == 0 {
+		cfg.Cooldown = 10 * time.Minute
+	}
+	if cfg.MaxFiles == 0 {
+		cfg.MaxFiles = 10
+	}
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, err
+	}
+	w := &Watchdog{cfg: cfg, logger: logger, captured: map[string]time.Time{}}
+	if cfg.RSS > 0 {
+		proc, err := process.NewProcess(int32(os.Getpid()))
+		if err != nil {
+			return nil, err
+		}
+		w.proc = proc
+	}
+	if cfg.GCPause > 0 {
+		var m runtime.MemStats
+		dbg.ReadMemStats(&m)
+		w.numGC = m.NumGC // pauses before start are not watchdog's business
+	}
+	return w, nil
+}
+
+func (w *Watchdog) Run(ctx context.Context) {
+	w.logger.Info("[watchdog] started", "dir", w.cfg.Dir, "rss", w.cfg.RSS, "goroutines", w.cfg.Goroutines, "gcPause", w.cfg.GCPause)
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check(time.Now())
+		}
+	}
+}
+
+func (w *Watchdog) check(now time.Time) {
+	if w.cfg.RSS > 0 {
+		if info, err := w.proc.MemoryInfo(); err != nil {
+			w.logger.Debug("[watchdog] can't read rss", "err", err)
+		} else if info.RSS > w.cfg.RSS.Bytes() {
+			w.trigger(now, "rss", common.ByteCount(info.RSS), w.cfg.RSS.HR())
+		}
+	}
+	if w.cfg.Goroutines > 0 {
+		if n := runtime.NumGoroutine(); n > w.cfg.Goroutines {
+			w.trigger(now, "goroutines", n, w.cfg.Goroutines)
+		}
+	}
+	if w.cfg.GCPause > 0 {
+		var m runtime.MemStats
+		dbg.ReadMemStats(&m)
+		if pause := maxPauseSince(&m, w.numGC); pause > w.cfg.GCPause {
+			w.trigger(now, "gcpause", pause, w.cfg.GCPause)
+		}
+		w.numGC = m.NumGC
+	}
+}
+
+// maxPauseSince - longest pause of GC cycles after `numGC`. Only the last 256 pauses are kept by runtime.
+func maxPauseSince(m *runtime.MemStats, numGC uint32) time.Duration {
+	var res time.Duration
+	for n := max(numGC, m.NumGC-min(m.NumGC, uint32(len(m.PauseNs)))); n < m.NumGC; n++ {
+		res = max(res, time.Duration(m.PauseNs[n%uint32(len(m.PauseNs))]))
+	}
+	return res
+}
+
+func (w *Watchdog) trigger(now time.Time, reason string, value, threshold any) {
+	if last, ok := w.captured[reason]; ok && now.Sub(last) < w.cfg.Cooldown {
+		return
+	}
+	w.captured[reason] = now
+	files, err := w.capture(now, reason)
+	if err != nil {
+		w.logger.Warn("[watchdog] threshold exceeded, failed to save profiles", "reason", reason, "value", value, "threshold", threshold, "err", err)
+		return
+	}
+	w.logger.Warn("[watchdog] threshold exceeded, profiles saved", "reason", reason, "value", value, "threshold", threshold, "files", files)
+	if err := w.rotate(); err != nil {
+		w.logger.Warn("[watchdog] failed to remove old profiles", "err", err)
+	}
+}
+
+// capture - files of one capture share `<time>-<reason>` prefix, so they sort by time and are rotated together
+func (w *Watchdog) capture(now time.Time, reason string) ([]string, error) {
+	prefix := fmt.Sprintf("%s-%s", now.UTC().Format("20060102-150405"), reason)
+	var files []string
+	for _, name := range []string{"heap", "goroutine"} {
+		file := filepath.Join(w.cfg.Dir, fmt.Sprintf("%s-%s.pprof", prefix, name))
+		if err := writeProfile(name, file); err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func writeProfile(name, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := pprof.Lookup(name).WriteTo(f, 0); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// rotate - keeps MaxFiles latest captures
+func (w *Watchdog) rotate() error {
+	entries, err := os.ReadDir(w.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	var captures []string
+	for _, e := range entries {
+		if prefix, ok := strings.CutSuffix(e.Name(), "-heap.pprof"); ok {
+			captures = append(captures, prefix)
+		}
+	}
+	slices.Sort(captures)
+	for len(captures) > w.cfg.MaxFiles {
+		for _, name := range []string{"heap", "goroutine"} {
+			if err := os.Remove(filepath.Join(w.cfg.Dir, fmt.Sprintf("%s-%s.pprof", captures[0], name))); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		captures = captures[1:]
+	}
+	return nil
+}
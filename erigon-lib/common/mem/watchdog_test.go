@@ -0,0 +1,63 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package mem
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestWatchdog(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWatchdog(WatchdogCfg{Dir: dir, Goroutines: 1, MaxFiles: 2, Cooldown: time.Minute}, log.New())
+	require.NoError(t, err)
+
+	countFiles := func() int {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		return len(entries)
+	}
+	now := time.Now()
+	w.check(now)
+	require.Equal(t, 2, countFiles()) // heap and goroutine
+
+	w.check(now.Add(time.Second)) // cooldown
+	require.Equal(t, 2, countFiles())
+
+	w.check(now.Add(2 * time.Minute))
+	w.check(now.Add(4 * time.Minute))
+	require.Equal(t, 4, countFiles()) // 2 latest captures are kept
+	_, err = os.Stat(dir + "/" + now.Add(4*time.Minute).UTC().Format("20060102-150405") + "-goroutines-heap.pprof")
+	require.NoError(t, err)
+}
+
+func TestMaxPauseSince(t *testing.T) {
+	var m runtime.MemStats
+	m.NumGC = 300
+	for i := range m.PauseNs {
+		m.PauseNs[i] = uint64(i)
+	}
+	require.Equal(t, time.Duration(299%256), maxPauseSince(&m, 299))
+	require.Equal(t, time.Duration(0), maxPauseSince(&m, 300))
+	require.Equal(t, time.Duration(255), maxPauseSince(&m, 0)) // only 256 last pauses are known
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// Implementations of public key recovery, see EcrecoverBatch
+const (
+	EcrecoverLibsecp256k1 = "libsecp256k1" // one signature at a time, C library (pure Go fallback without cgo)
+	EcrecoverBatched      = "batch"        // EcrecoverBatch
+)
+
+var EcrecoverBackends = []string{EcrecoverLibsecp256k1, EcrecoverBatched}
+
+var (
+	errBatchSigLength  = errors.New("invalid signature length")
+	errBatchRecoveryID = errors.New("invalid signature recovery id")
+	errBatchSigValues  = errors.New("invalid signature: r or s is zero or >= group order")
+	errBatchSigPoint   = errors.New("invalid signature: r is not a valid curve point")
+	errBatchInfinity   = errors.New("invalid signature: recovered public key is the point at infinity")
+)
+
+// secp256k1 group order as a field element: for recovery ids with the overflow bit, x of R is r+N
+var orderAsFieldVal = func() secp256k1.FieldVal {
+	var f secp256k1.FieldVal
+	f.SetByteSlice(secp256k1.Params().N.Bytes())
+	return f
+}()
+
+// EcrecoverBatch returns uncompressed public keys which created signatures [R || S || V] of the given hashes,
+// like Ecrecover does for one signature. It's pure Go: u1*G uses precomputed tables of the generator and u2*R
+// uses GLV endomorphism, while the two field inversions of every recovery (1/r and affine conversion of the
+// result) are done once per batch with Montgomery's trick. errs[i] is set for invalid signatures, pubs[i] is nil then.
+func EcrecoverBatch(hashes, sigs [][]byte) (pubs [][]byte, errs []error) {
+	if len(hashes) != len(sigs) {
+		panic(fmt.Sprintf("EcrecoverBatch: %d hashes, %d signatures", len(hashes), len(sigs)))
+	}
+	n := len(sigs)
+	pubs, errs = make([][]byte, n), make([]error, n)
+
+	type recovery struct {
+		i    int
+		r, s secp256k1.ModNScalar
+		e    secp256k1.ModNScalar
+		R, Q secp256k1.JacobianPoint
+	}
+	recs := make([]recovery, 0, n)
+	for i, sig := range sigs {
+		if len(sig) != SignatureLength {
+			errs[i] = errBatchSigLength
+			continue
+		}
+		recID := sig[RecoveryIDOffset]
+		if recID > 3 {
+			errs[i] = errBatchRecoveryID
+			continue
+		}
+		rec := recovery{i: i}
+		if rec.r.SetByteSlice(sig[:32]) || rec.r.IsZero() || rec.s.SetByteSlice(sig[32:64]) || rec.s.IsZero() {
+			errs[i] = errBatchSigValues
+			continue
+		}
+		var rBytes [32]byte
+		rec.r.PutBytes(&rBytes)
+		rec.R.X.SetBytes(&rBytes)
+		if recID&2 != 0 {
+			if rec.R.X.IsGtOrEqPrimeMinusOrder() {
+				errs[i] = errBatchSigPoint
+				continue
+			}
+			rec.R.X.Add(&orderAsFieldVal).Normalize()
+		}
+		if !secp256k1.DecompressY(&rec.R.X, recID&1 != 0, &rec.R.Y) {
+			errs[i] = errBatchSigPoint
+			continue
+		}
+		rec.R.Y.Normalize()
+		rec.R.Z.SetInt(1)
+		rec.e.SetByteSlice(hashes[i])
+		recs = append(recs, rec)
+	}
+	if len(recs) == 0 {
+		return pubs, errs
+	}
+
+	// w = 1/r for all signatures, one inversion
+	prefix := make([]secp256k1.ModNScalar, len(recs))
+	prefix[0].Set(&recs[0].r)
+	for k := 1; k < len(recs); k++ {
+		prefix[k].Mul2(&prefix[k-1], &recs[k].r)
+	}
+	var inv, w secp256k1.ModNScalar
+	inv.InverseValNonConst(&prefix[len(recs)-1])
+	for k := len(recs) - 1; k >= 0; k-- {
+		if k > 0 {
+			w.Mul2(&inv, &prefix[k-1])
+			inv.Mul(&recs[k].r)
+		} else {
+			w.Set(&inv)
+		}
+		// Q = u1*G + u2*R, where u1 = -e/r and u2 = s/r
+		var u1, u2 secp256k1.ModNScalar
+		u1.Mul2(&recs[k].e, &w).Negate()
+		u2.Mul2(&recs[k].s, &w)
+		var u1G, u2R secp256k1.JacobianPoint
+		secp256k1.ScalarBaseMultNonConst(&u1, &u1G)
+		secp256k1.ScalarMultNonConst(&u2, &recs[k].R, &u2R)
+		secp256k1.AddNonConst(&u1G, &u2R, &recs[k].Q)
+	}
+
+	// affine conversion of all results: 1/Z, one inversion
+	valid := recs[:0]
+	for _, rec := range recs {
+		if (rec.Q.X.IsZero() && rec.Q.Y.IsZero()) || rec.Q.Z.IsZero() {
+			errs[rec.i] = errBatchInfinity
+			continue
+		}
+		valid = append(valid, rec)
+	}
+	if len(valid) == 0 {
+		return pubs, errs
+	}
+	zPrefix := make([]secp256k1.FieldVal, len(valid))
+	zPrefix[0].Set(&valid[0].Q.Z)
+	for k := 1; k < len(valid); k++ {
+		zPrefix[k].Mul2(&zPrefix[k-1], &valid[k].Q.Z)
+	}
+	var zInvAll, zInv, zInv2 secp256k1.FieldVal
+	zInvAll.Set(&zPrefix[len(valid)-1]).Inverse()
+	for k := len(valid) - 1; k >= 0; k-- {
+		Q := &valid[k].Q
+		if k > 0 {
+			zInv.Mul2(&zInvAll, &zPrefix[k-1])
+			zInvAll.Mul(&Q.Z)
+		} else {
+			zInv.Set(&zInvAll)
+		}
+		zInv2.SquareVal(&zInv)
+		Q.X.Mul(&zInv2).Normalize()
+		Q.Y.Mul(zInv2.Mul(&zInv)).Normalize()
+
+		pub := make([]byte, 65)
+		pub[0] = 4
+		Q.X.PutBytesUnchecked(pub[1:33])
+		Q.Y.PutBytesUnchecked(pub[33:])
+		pubs[valid[k].i] = pub
+	}
+	return pubs, errs
+}
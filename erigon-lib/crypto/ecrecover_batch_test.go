@@ -0,0 +1,86 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomSignatures(t testing.TB, n int) (hashes, sigs [][]byte) {
+	for i := 0; i < n; i++ {
+		key, err := GenerateKey()
+		require.NoError(t, err)
+		hash := make([]byte, 32)
+		_, err = rand.Read(hash)
+		require.NoError(t, err)
+		sig, err := Sign(hash, key)
+		require.NoError(t, err)
+		hashes, sigs = append(hashes, hash), append(sigs, sig)
+	}
+	return hashes, sigs
+}
+
+func TestEcrecoverBatch(t *testing.T) {
+	hashes, sigs := randomSignatures(t, 64)
+	hashes, sigs = append(hashes, testmsg), append(sigs, testsig)
+
+	// invalid signatures in between valid ones
+	zeroR := bytes.Clone(testsig)
+	copy(zeroR[:32], make([]byte, 32))
+	badRecID := bytes.Clone(testsig)
+	badRecID[RecoveryIDOffset] = 4
+	hashes = append(hashes, testmsg, testmsg, testmsg)
+	sigs = append(sigs, zeroR, badRecID, testsig[:64])
+	hashes, sigs = append(hashes, testmsg), append(sigs, testsig)
+
+	pubs, errs := EcrecoverBatch(hashes, sigs)
+	for i := range sigs {
+		want, wantErr := Ecrecover(hashes[i], sigs[i])
+		if wantErr != nil {
+			require.Error(t, errs[i], i)
+			require.Nil(t, pubs[i], i)
+			continue
+		}
+		require.NoError(t, errs[i], i)
+		require.Equal(t, want, pubs[i], i)
+	}
+	require.Equal(t, testpubkey, pubs[len(pubs)-1])
+
+	pubs, errs = EcrecoverBatch(nil, nil)
+	require.Empty(t, pubs)
+	require.Empty(t, errs)
+}
+
+func BenchmarkEcrecover(b *testing.B) {
+	hashes, sigs := randomSignatures(b, 256)
+	b.Run("one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range sigs {
+				_, _ = Ecrecover(hashes[j], sigs[j])
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = EcrecoverBatch(hashes, sigs)
+		}
+	})
+}
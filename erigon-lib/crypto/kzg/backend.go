@@ -0,0 +1,189 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+const (
+	// BackendGnark - crate-crypto/go-kzg-4844 over gnark-crypto: pure Go, no cgo
+	BackendGnark = "gnark"
+	// BackendBlst - ethereum/c-kzg-4844 over supranational/blst: cgo, registered by cl/kzg in cgo builds
+	BackendBlst = "blst"
+	// BackendAuto - the fastest of the registered backends, by a benchmark of proof verification
+	BackendAuto = "auto"
+)
+
+// DefaultBackend - the backend used unless SetBackend is called, can be changed at build time:
+// -ldflags "-X github.com/erigontech/erigon-lib/crypto/kzg.DefaultBackend=auto"
+var DefaultBackend = BackendGnark
+
+// Backend - implementation of the EIP-4844 KZG proof verification over BLS12-381: used by the point evaluation
+// precompile and the validation of blob transactions
+type Backend interface {
+	Name() string
+	// Init loads the trusted setup, it's called once before the first use
+	Init() error
+	VerifyKZGProof(commitment gokzg4844.KZGCommitment, z, y gokzg4844.Scalar, proof gokzg4844.KZGProof) error
+	VerifyBlobKZGProofBatch(blobs []gokzg4844.BlobRef, commitments []gokzg4844.KZGCommitment, proofs []gokzg4844.KZGProof) error
+}
+
+var (
+	backendsMu    sync.Mutex
+	backends      = map[string]Backend{}
+	backendInited = map[string]error{}
+	activeBackend atomic.Pointer[Backend]
+)
+
+func init() {
+	RegisterBackend(gnarkBackend{})
+}
+
+// RegisterBackend makes the backend available to SetBackend, a backend of the same name is replaced
+func RegisterBackend(b Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[b.Name()] = b
+}
+
+// Backends returns the names of the registered backends
+func Backends() []string {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetBackend selects the backend by name, BackendAuto selects the fastest of the registered ones.
+// The backend is initialized: the trusted setup is loaded.
+func SetBackend(name string) (Backend, error) {
+	var b Backend
+	var err error
+	if name == BackendAuto {
+		b, err = fastestBackend()
+	} else {
+		b, err = initBackend(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	activeBackend.Store(&b)
+	return b, nil
+}
+
+// ActiveBackend returns the selected backend, DefaultBackend if none was selected
+func ActiveBackend() Backend {
+	if b := activeBackend.Load(); b != nil {
+		return *b
+	}
+	b, err := SetBackend(DefaultBackend)
+	if err != nil {
+		panic(fmt.Sprintf("kzg backend %q: %v", DefaultBackend, err))
+	}
+	return b
+}
+
+func initBackend(name string) (Backend, error) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	b, ok := backends[name]
+	if !ok {
+		names := make([]string, 0, len(backends))
+		for name := range backends {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown kzg backend %q, available: %v", name, names)
+	}
+	err, inited := backendInited[name]
+	if !inited {
+		err = b.Init()
+		backendInited[name] = err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// benchmarkRounds - verifications timed per backend by the auto selection
+const benchmarkRounds = 8
+
+// fastestBackend benchmarks verification of a proof by every registered backend which can be initialized
+func fastestBackend() (Backend, error) {
+	// the proof of the zero polynomial: commitment and proof are the point at infinity
+	var commitment gokzg4844.KZGCommitment
+	commitment[0] = 0xc0
+	proof := gokzg4844.KZGProof(commitment)
+	var z, y gokzg4844.Scalar
+	z[31] = 1
+
+	var fastest Backend
+	var fastestTime time.Duration
+	for _, name := range Backends() {
+		b, err := initBackend(name)
+		if err != nil {
+			continue
+		}
+		start := time.Now()
+		for i := 0; i < benchmarkRounds; i++ {
+			if err = b.VerifyKZGProof(commitment, z, y, proof); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			continue // a backend failing a valid proof can't be used
+		}
+		if elapsed := time.Since(start); fastest == nil || elapsed < fastestTime {
+			fastest, fastestTime = b, elapsed
+		}
+	}
+	if fastest == nil {
+		return nil, errors.New("no usable kzg backend")
+	}
+	return fastest, nil
+}
+
+// gnarkBackend - the go-kzg-4844 context of Ctx
+type gnarkBackend struct{}
+
+func (gnarkBackend) Name() string { return BackendGnark }
+
+func (gnarkBackend) Init() error {
+	InitKZGCtx()
+	return nil
+}
+
+func (gnarkBackend) VerifyKZGProof(commitment gokzg4844.KZGCommitment, z, y gokzg4844.Scalar, proof gokzg4844.KZGProof) error {
+	return Ctx().VerifyKZGProof(commitment, z, y, proof)
+}
+
+func (gnarkBackend) VerifyBlobKZGProofBatch(blobs []gokzg4844.BlobRef, commitments []gokzg4844.KZGCommitment, proofs []gokzg4844.KZGProof) error {
+	return Ctx().VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+}
@@ -56,6 +56,11 @@ func SetTrustedSetupFilePath(path string) {
 	trustedSetupFile = path
 }
 
+// TrustedSetupFilePath returns the trusted setup set by SetTrustedSetupFilePath, empty if the embedded one is used
+func TrustedSetupFilePath() string {
+	return trustedSetupFile
+}
+
 // InitKZGCtx initializes the global context object returned via CryptoCtx
 func InitKZGCtx() {
 	initCryptoCtx.Do(func() {
@@ -128,8 +133,7 @@ func PointEvaluationPrecompile(input []byte) ([]byte, error) {
 	var quotientKZG [48]byte
 	copy(quotientKZG[:], input[144:PrecompileInputLength])
 
-	cryptoCtx := Ctx()
-	err := cryptoCtx.VerifyKZGProof(dataKZG, x, y, quotientKZG)
+	err := ActiveBackend().VerifyKZGProof(dataKZG, x, y, quotientKZG)
 	if err != nil {
 		return nil, fmt.Errorf("verify_kzg_proof error: %w", err)
 	}
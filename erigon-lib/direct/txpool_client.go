@@ -112,6 +112,58 @@ func (c *TxPoolOnAddC) Context() context.Context { return c.ctx }
 
 // -- end OnAdd
 
+// -- start OnDrop
+
+func (s *TxPoolClient) OnDrop(ctx context.Context, in *txpool_proto.OnDropRequest, opts ...grpc.CallOption) (txpool_proto.Txpool_OnDropClient, error) {
+	ch := make(chan *onDropReply, 16384)
+	streamServer := &TxPoolOnDropS{ch: ch, ctx: ctx}
+	go func() {
+		defer close(ch)
+		streamServer.Err(s.server.OnDrop(in, streamServer))
+	}()
+	return &TxPoolOnDropC{ch: ch, ctx: ctx}, nil
+}
+
+type onDropReply struct {
+	r   *txpool_proto.OnDropReply
+	err error
+}
+
+type TxPoolOnDropS struct {
+	ch  chan *onDropReply
+	ctx context.Context
+	grpc.ServerStream
+}
+
+func (s *TxPoolOnDropS) Send(m *txpool_proto.OnDropReply) error {
+	s.ch <- &onDropReply{r: m}
+	return nil
+}
+func (s *TxPoolOnDropS) Context() context.Context { return s.ctx }
+func (s *TxPoolOnDropS) Err(err error) {
+	if err == nil {
+		return
+	}
+	s.ch <- &onDropReply{err: err}
+}
+
+type TxPoolOnDropC struct {
+	ch  chan *onDropReply
+	ctx context.Context
+	grpc.ClientStream
+}
+
+func (c *TxPoolOnDropC) Recv() (*txpool_proto.OnDropReply, error) {
+	m, ok := <-c.ch
+	if !ok || m == nil {
+		return nil, io.EOF
+	}
+	return m.r, m.err
+}
+func (c *TxPoolOnDropC) Context() context.Context { return c.ctx }
+
+// -- end OnDrop
+
 func (s *TxPoolClient) Status(ctx context.Context, in *txpool_proto.StatusRequest, opts ...grpc.CallOption) (*txpool_proto.StatusReply, error) {
 	return s.server.Status(ctx, in)
 }
@@ -123,3 +175,7 @@ func (s *TxPoolClient) Nonce(ctx context.Context, in *txpool_proto.NonceRequest,
 func (s *TxPoolClient) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest, opts ...grpc.CallOption) (*txpool_proto.GetBlobsReply, error) {
 	return s.server.GetBlobs(ctx, in)
 }
+
+func (s *TxPoolClient) SetPolicy(ctx context.Context, in *txpool_proto.SetPolicyRequest, opts ...grpc.CallOption) (*txpool_proto.SetPolicyReply, error) {
+	return s.server.SetPolicy(ctx, in)
+}
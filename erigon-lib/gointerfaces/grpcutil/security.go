@@ -0,0 +1,338 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package grpcutil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Security - transport security and token authentication of private gRPC interfaces (remote kv, txpool, sentry, downloader).
+// The same settings are used by servers and clients. Nil Security means plaintext without authentication.
+type Security struct {
+	creds credentials.TransportCredentials
+	auth  *TokenAuth
+}
+
+// NewSecurity - all parameters are optional:
+//   - certFile/keyFile: own certificate (server certificate, or client certificate for mutual TLS)
+//   - caCert: CA of peers' certificates. Servers require client certificates signed by it, clients verify server certificates against it
+//   - tokenFile: shared tokens, see TokenAuth
+//
+// Files are re-read when they change: certificates and tokens can be rotated without restart.
+func NewSecurity(caCert, certFile, keyFile, tokenFile string) (*Security, error) {
+	creds, err := TLS(caCert, certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := NewTokenAuth(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Security{creds: creds, auth: auth}, nil
+}
+
+func (s *Security) Creds() credentials.TransportCredentials {
+	if s == nil {
+		return nil
+	}
+	return s.creds
+}
+
+// ServerOptions - for servers which are not created by NewServer
+func (s *Security) ServerOptions() []grpc.ServerOption {
+	if s == nil {
+		return nil
+	}
+	var opts []grpc.ServerOption
+	if s.creds != nil {
+		opts = append(opts, grpc.Creds(s.creds))
+	}
+	if s.auth != nil {
+		opts = append(opts, grpc.ChainUnaryInterceptor(s.auth.UnaryServerInterceptor), grpc.ChainStreamInterceptor(s.auth.StreamServerInterceptor))
+	}
+	return opts
+}
+
+func (s *Security) DialOptions() []grpc.DialOption {
+	if s == nil {
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	var opts []grpc.DialOption
+	if s.creds == nil {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(s.creds))
+	}
+	if s.auth != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(s.auth))
+	}
+	return opts
+}
+
+// fileStamp - changes when any of files is modified
+func fileStamp(files ...string) (string, error) {
+	var sb strings.Builder
+	for _, f := range files {
+		if f == "" {
+			continue
+		}
+		st, err := os.Stat(f)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "%s:%d:%d;", f, st.ModTime().UnixNano(), st.Size())
+	}
+	return sb.String(), nil
+}
+
+// certReloader - own certificate and CA pool, re-read when files change. If new files can't be loaded
+// (for example, rotation wrote only the certificate so far), previous ones are used and loading is retried on next handshake.
+type certReloader struct {
+	caFile, certFile, keyFile string
+
+	mu    sync.Mutex
+	stamp string
+	cert  *tls.Certificate
+	pool  *x509.CertPool
+}
+
+func (r *certReloader) load() (*tls.Certificate, *x509.CertPool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stamp, err := fileStamp(r.caFile, r.certFile, r.keyFile)
+	if err == nil && stamp == r.stamp {
+		return r.cert, r.pool, nil
+	}
+	cert, pool, err := r.read()
+	if err != nil {
+		if r.stamp != "" {
+			return r.cert, r.pool, nil
+		}
+		return nil, nil, err
+	}
+	r.stamp, r.cert, r.pool = stamp, cert, pool
+	return cert, pool, nil
+}
+
+func (r *certReloader) read() (*tls.Certificate, *x509.CertPool, error) {
+	var cert *tls.Certificate
+	if r.certFile != "" || r.keyFile != "" {
+		peerCert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load peer cert/key error:%w", err)
+		}
+		cert = &peerCert
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		caCert, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read ca cert file error:%w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, nil, fmt.Errorf("no certificates in ca cert file %s", r.caFile)
+		}
+	}
+	return cert, pool, nil
+}
+
+// tlsConfig - works on both sides of connection: server side is configured per handshake by GetConfigForClient,
+// client side by GetClientCertificate and VerifyPeerCertificate
+func (r *certReloader) tlsConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool, err := r.load()
+			if err != nil {
+				return nil, err
+			}
+			if cert == nil {
+				return nil, errors.New("no server certificate")
+			}
+			serverCfg := &tls.Config{Certificates: []tls.Certificate{*cert}, MinVersion: tls.VersionTLS12, NextProtos: []string{"h2"}}
+			if pool != nil {
+				serverCfg.ClientCAs = pool
+				serverCfg.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return serverCfg, nil
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _, err := r.load()
+			if err != nil {
+				return nil, err
+			}
+			if cert == nil {
+				return &tls.Certificate{}, nil // no client certificate
+			}
+			return cert, nil
+		},
+	}
+	if r.caFile == "" {
+		return cfg // client verifies server by system roots and host name
+	}
+	//nolint:gosec
+	cfg.InsecureSkipVerify = true // Common Name of certificates of split deployments doesn't match: chain is verified by VerifyPeerCertificate
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		_, pool, err := r.load()
+		if err != nil {
+			return err
+		}
+		if len(rawCerts) == 0 {
+			return errors.New("no peer certificate")
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			if certs[i], err = x509.ParseCertificate(raw); err != nil {
+				return err
+			}
+		}
+		intermediates := x509.NewCertPool()
+		for _, c := range certs[1:] {
+			intermediates.AddCert(c)
+		}
+		_, err = certs[0].Verify(x509.VerifyOptions{Roots: pool, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+		return err
+	}
+	return cfg
+}
+
+// TokenAuth - shared-secret authentication of gRPC calls. Token file has one token per line: clients send the first one
+// in `authorization: Bearer <token>` metadata of every call, servers accept any of them. The file is re-read when changed,
+// so a token is rotated by adding a new line on servers, switching clients to it, then removing the old line.
+// Health checks are not authenticated: orchestrators probe them without credentials.
+type TokenAuth struct {
+	file string
+
+	mu     sync.Mutex
+	stamp  string
+	tokens []string
+}
+
+// NewTokenAuth - nil if file is empty
+func NewTokenAuth(file string) (*TokenAuth, error) {
+	if file == "" {
+		return nil, nil
+	}
+	a := &TokenAuth{file: file}
+	if _, err := a.load(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *TokenAuth) load() ([]string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	stamp, err := fileStamp(a.file)
+	if err == nil && stamp == a.stamp {
+		return a.tokens, nil
+	}
+	tokens, err := readTokens(a.file)
+	if err != nil {
+		if a.stamp != "" {
+			return a.tokens, nil
+		}
+		return nil, err
+	}
+	a.stamp, a.tokens = stamp, tokens
+	return tokens, nil
+}
+
+func readTokens(file string) ([]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if t := strings.TrimSpace(scanner.Text()); t != "" && !strings.HasPrefix(t, "#") {
+			tokens = append(tokens, t)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens in %s", file)
+	}
+	return tokens, nil
+}
+
+// GetRequestMetadata - implements credentials.PerRPCCredentials
+func (a *TokenAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	tokens, err := a.load()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + tokens[0]}, nil
+}
+
+// RequireTransportSecurity - implements credentials.PerRPCCredentials. Tokens are allowed over plaintext for loopback deployments.
+func (a *TokenAuth) RequireTransportSecurity() bool { return false }
+
+func (a *TokenAuth) authorize(ctx context.Context, fullMethod string) error {
+	if strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") {
+		return nil
+	}
+	tokens, err := a.load()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		got, ok := strings.CutPrefix(v, "Bearer ")
+		if !ok {
+			continue
+		}
+		for _, token := range tokens {
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+				return nil
+			}
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid or missing token")
+}
+
+func (a *TokenAuth) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *TokenAuth) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
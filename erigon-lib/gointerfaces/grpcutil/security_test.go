@@ -0,0 +1,81 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package grpcutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenAuth(t *testing.T) {
+	t.Parallel()
+	file := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(file, []byte("# comment\nold\n"), 0600))
+	auth, err := NewTokenAuth(file)
+	require.NoError(t, err)
+
+	call := func(token string) codes.Code {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		return status.Code(auth.authorize(ctx, "/remote.KV/Version"))
+	}
+	require.Equal(t, codes.OK, call("old"))
+	require.Equal(t, codes.Unauthenticated, call("new"))
+	require.Equal(t, codes.Unauthenticated, call(""))
+	require.NoError(t, auth.authorize(context.Background(), "/grpc.health.v1.Health/Check"))
+
+	md, err := auth.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Bearer old", md["authorization"])
+
+	// rotation: new token is accepted without restart
+	require.NoError(t, os.WriteFile(file, []byte("new\nold\n"), 0600))
+	require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Second)))
+	require.Equal(t, codes.OK, call("new"))
+	require.Equal(t, codes.OK, call("old"))
+	md, err = auth.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Bearer new", md["authorization"])
+
+	// broken file: previous tokens are kept
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	require.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(2*time.Second)))
+	require.Equal(t, codes.OK, call("new"))
+}
+
+func TestNilSecurity(t *testing.T) {
+	t.Parallel()
+	var sec *Security
+	require.Nil(t, sec.Creds())
+	require.Empty(t, sec.ServerOptions())
+	require.Len(t, sec.DialOptions(), 1)
+
+	sec, err := NewSecurity("", "", "", "")
+	require.NoError(t, err)
+	require.Nil(t, sec.Creds())
+	require.Empty(t, sec.ServerOptions())
+}
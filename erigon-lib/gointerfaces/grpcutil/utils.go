@@ -18,12 +18,8 @@ package grpcutil
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"errors"
-	"fmt"
 	"io"
-	"os"
 	"strings"
 	"time"
 
@@ -39,36 +35,19 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// TLS - credentials for both sides of a connection, certificates are re-read when files change (see NewSecurity). Nil if no files given.
 func TLS(tlsCACert, tlsCertFile, tlsKeyFile string) (credentials.TransportCredentials, error) {
-	// load peer cert/key, ca cert
-	if tlsCACert == "" {
-		if tlsCertFile == "" && tlsKeyFile == "" {
-			return nil, nil
-		}
-		return credentials.NewServerTLSFromFile(tlsCertFile, tlsKeyFile)
+	if tlsCACert == "" && tlsCertFile == "" && tlsKeyFile == "" {
+		return nil, nil
 	}
-	var caCert []byte
-	peerCert, err := tls.LoadX509KeyPair(tlsCertFile, tlsKeyFile)
-	if err != nil {
-		return nil, fmt.Errorf("load peer cert/key error:%w", err)
+	r := &certReloader{caFile: tlsCACert, certFile: tlsCertFile, keyFile: tlsKeyFile}
+	if _, _, err := r.load(); err != nil {
+		return nil, err
 	}
-	caCert, err = os.ReadFile(tlsCACert)
-	if err != nil {
-		return nil, fmt.Errorf("read ca cert file error:%w", err)
-	}
-	caCertPool := x509.NewCertPool()
-	caCertPool.AppendCertsFromPEM(caCert)
-	return credentials.NewTLS(&tls.Config{
-		Certificates: []tls.Certificate{peerCert},
-		ClientCAs:    caCertPool,
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		MinVersion:   tls.VersionTLS12,
-		//nolint:gosec
-		InsecureSkipVerify: true, // This is to make it work when Common Name does not match - remove when procedure is updated for common name
-	}), nil
+	return credentials.NewTLS(r.tlsConfig()), nil
 }
 
-func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.Server {
+func NewServer(rateLimit uint32, sec *Security) *grpc.Server {
 	var (
 		streamInterceptors []grpc.StreamServerInterceptor
 		unaryInterceptors  []grpc.UnaryServerInterceptor
@@ -93,8 +72,8 @@ func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.S
 		}),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
-		grpc.Creds(creds),
 	}
+	opts = append(opts, sec.ServerOptions()...)
 	grpcServer := grpc.NewServer(opts...)
 	reflection.Register(grpcServer)
 
@@ -105,7 +84,7 @@ func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.S
 	return grpcServer
 }
 
-func Connect(creds credentials.TransportCredentials, dialAddress string) (*grpc.ClientConn, error) {
+func Connect(sec *Security, dialAddress string) (*grpc.ClientConn, error) {
 	var dialOpts []grpc.DialOption
 
 	backoffCfg := backoff.DefaultConfig
@@ -116,11 +95,7 @@ func Connect(creds credentials.TransportCredentials, dialAddress string) (*grpc.
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(200 * datasize.MB))),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{}),
 	}
-	if creds == nil {
-		dialOpts = append(dialOpts, grpc.WithInsecure())
-	} else {
-		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
-	}
+	dialOpts = append(dialOpts, sec.DialOptions()...)
 
 	//if opts.inMemConn != nil {
 	//	dialOpts = append(dialOpts, grpc.WithContextDialer(func(ctx context.Context, url string) (net.Conn, error) {
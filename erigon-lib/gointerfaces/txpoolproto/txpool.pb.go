@@ -32,6 +32,7 @@ const (
 	ImportResult_STALE          ImportResult = 3
 	ImportResult_INVALID        ImportResult = 4
 	ImportResult_INTERNAL_ERROR ImportResult = 5
+	ImportResult_WOULD_REVERT   ImportResult = 6 // Reverts when simulated against the latest state
 )
 
 // Enum value maps for ImportResult.
@@ -43,6 +44,7 @@ var (
 		3: "STALE",
 		4: "INVALID",
 		5: "INTERNAL_ERROR",
+		6: "WOULD_REVERT",
 	}
 	ImportResult_value = map[string]int32{
 		"SUCCESS":        0,
@@ -51,6 +53,7 @@ var (
 		"STALE":          3,
 		"INVALID":        4,
 		"INTERNAL_ERROR": 5,
+		"WOULD_REVERT":   6,
 	}
 )
 
@@ -127,7 +130,7 @@ func (x AllReply_TxnType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AllReply_TxnType.Descriptor instead.
 func (AllReply_TxnType) EnumDescriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{8, 0}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{11, 0}
 }
 
 type TxHashes struct {
@@ -438,15 +441,170 @@ func (x *OnAddReply) GetRplTxs() [][]byte {
 	return nil
 }
 
+// Subscribes to the transactions removed from the pool, with the reason
+type OnDropRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnDropRequest) Reset() {
+	*x = OnDropRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnDropRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnDropRequest) ProtoMessage() {}
+
+func (x *OnDropRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnDropRequest.ProtoReflect.Descriptor instead.
+func (*OnDropRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{7}
+}
+
+type DroppedTxn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          *typesproto.H256       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`                            // Name of the discard reason
+	ReasonCode    uint32                 `protobuf:"varint,3,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"` // Numeric code of the discard reason
+	ReplacedBy    *typesproto.H256       `protobuf:"bytes,4,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`  // Set if the transaction was replaced by another one with the same sender and nonce
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DroppedTxn) Reset() {
+	*x = DroppedTxn{}
+	mi := &file_txpool_txpool_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DroppedTxn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DroppedTxn) ProtoMessage() {}
+
+func (x *DroppedTxn) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DroppedTxn.ProtoReflect.Descriptor instead.
+func (*DroppedTxn) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DroppedTxn) GetHash() *typesproto.H256 {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *DroppedTxn) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DroppedTxn) GetReasonCode() uint32 {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return 0
+}
+
+func (x *DroppedTxn) GetReplacedBy() *typesproto.H256 {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return nil
+}
+
+type OnDropReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txns          []*DroppedTxn          `protobuf:"bytes,1,rep,name=txns,proto3" json:"txns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnDropReply) Reset() {
+	*x = OnDropReply{}
+	mi := &file_txpool_txpool_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnDropReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnDropReply) ProtoMessage() {}
+
+func (x *OnDropReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnDropReply.ProtoReflect.Descriptor instead.
+func (*OnDropReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OnDropReply) GetTxns() []*DroppedTxn {
+	if x != nil {
+		return x.Txns
+	}
+	return nil
+}
+
+// Page of the pooled transactions, ordered by sender and nonce. Unset filters match all transactions
 type AllRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        []byte                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`                                                          // Position to continue from: next_cursor of the previous page, unset - from the start
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                                           // Max transactions in the reply, 0 - no limit
+	Senders       []*typesproto.H160     `protobuf:"bytes,3,rep,name=senders,proto3" json:"senders,omitempty"`                                                        // Only the transactions of these senders
+	SubPools      []AllReply_TxnType     `protobuf:"varint,4,rep,packed,name=sub_pools,json=subPools,proto3,enum=txpool.AllReply_TxnType" json:"sub_pools,omitempty"` // Only the transactions of these sub-pools
+	Types         []uint32               `protobuf:"varint,5,rep,packed,name=types,proto3" json:"types,omitempty"`                                                    // Only the transactions of these EIP-2718 types
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AllRequest) Reset() {
 	*x = AllRequest{}
-	mi := &file_txpool_txpool_proto_msgTypes[7]
+	mi := &file_txpool_txpool_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -458,7 +616,7 @@ func (x *AllRequest) String() string {
 func (*AllRequest) ProtoMessage() {}
 
 func (x *AllRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[7]
+	mi := &file_txpool_txpool_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,19 +629,55 @@ func (x *AllRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllRequest.ProtoReflect.Descriptor instead.
 func (*AllRequest) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{7}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AllRequest) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+func (x *AllRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AllRequest) GetSenders() []*typesproto.H160 {
+	if x != nil {
+		return x.Senders
+	}
+	return nil
+}
+
+func (x *AllRequest) GetSubPools() []AllReply_TxnType {
+	if x != nil {
+		return x.SubPools
+	}
+	return nil
+}
+
+func (x *AllRequest) GetTypes() []uint32 {
+	if x != nil {
+		return x.Types
+	}
+	return nil
 }
 
 type AllReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Txs           []*AllReply_Tx         `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	NextCursor    []byte                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Cursor of the next page, unset - this is the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AllReply) Reset() {
 	*x = AllReply{}
-	mi := &file_txpool_txpool_proto_msgTypes[8]
+	mi := &file_txpool_txpool_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -495,7 +689,7 @@ func (x *AllReply) String() string {
 func (*AllReply) ProtoMessage() {}
 
 func (x *AllReply) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[8]
+	mi := &file_txpool_txpool_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,7 +702,7 @@ func (x *AllReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllReply.ProtoReflect.Descriptor instead.
 func (*AllReply) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{8}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AllReply) GetTxs() []*AllReply_Tx {
@@ -518,6 +712,13 @@ func (x *AllReply) GetTxs() []*AllReply_Tx {
 	return nil
 }
 
+func (x *AllReply) GetNextCursor() []byte {
+	if x != nil {
+		return x.NextCursor
+	}
+	return nil
+}
+
 type PendingReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Txs           []*PendingReply_Tx     `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
@@ -527,7 +728,7 @@ type PendingReply struct {
 
 func (x *PendingReply) Reset() {
 	*x = PendingReply{}
-	mi := &file_txpool_txpool_proto_msgTypes[9]
+	mi := &file_txpool_txpool_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -539,7 +740,7 @@ func (x *PendingReply) String() string {
 func (*PendingReply) ProtoMessage() {}
 
 func (x *PendingReply) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[9]
+	mi := &file_txpool_txpool_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,7 +753,7 @@ func (x *PendingReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PendingReply.ProtoReflect.Descriptor instead.
 func (*PendingReply) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{9}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PendingReply) GetTxs() []*PendingReply_Tx {
@@ -570,7 +771,7 @@ type StatusRequest struct {
 
 func (x *StatusRequest) Reset() {
 	*x = StatusRequest{}
-	mi := &file_txpool_txpool_proto_msgTypes[10]
+	mi := &file_txpool_txpool_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -582,7 +783,7 @@ func (x *StatusRequest) String() string {
 func (*StatusRequest) ProtoMessage() {}
 
 func (x *StatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[10]
+	mi := &file_txpool_txpool_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -595,7 +796,7 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
 func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{10}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{13}
 }
 
 type StatusReply struct {
@@ -609,7 +810,7 @@ type StatusReply struct {
 
 func (x *StatusReply) Reset() {
 	*x = StatusReply{}
-	mi := &file_txpool_txpool_proto_msgTypes[11]
+	mi := &file_txpool_txpool_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -621,7 +822,7 @@ func (x *StatusReply) String() string {
 func (*StatusReply) ProtoMessage() {}
 
 func (x *StatusReply) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[11]
+	mi := &file_txpool_txpool_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -634,7 +835,7 @@ func (x *StatusReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusReply.ProtoReflect.Descriptor instead.
 func (*StatusReply) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{11}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *StatusReply) GetPendingCount() uint32 {
@@ -667,7 +868,7 @@ type NonceRequest struct {
 
 func (x *NonceRequest) Reset() {
 	*x = NonceRequest{}
-	mi := &file_txpool_txpool_proto_msgTypes[12]
+	mi := &file_txpool_txpool_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -679,7 +880,7 @@ func (x *NonceRequest) String() string {
 func (*NonceRequest) ProtoMessage() {}
 
 func (x *NonceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[12]
+	mi := &file_txpool_txpool_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +893,7 @@ func (x *NonceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NonceRequest.ProtoReflect.Descriptor instead.
 func (*NonceRequest) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{12}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *NonceRequest) GetAddress() *typesproto.H160 {
@@ -712,7 +913,7 @@ type NonceReply struct {
 
 func (x *NonceReply) Reset() {
 	*x = NonceReply{}
-	mi := &file_txpool_txpool_proto_msgTypes[13]
+	mi := &file_txpool_txpool_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -724,7 +925,7 @@ func (x *NonceReply) String() string {
 func (*NonceReply) ProtoMessage() {}
 
 func (x *NonceReply) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[13]
+	mi := &file_txpool_txpool_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -737,7 +938,7 @@ func (x *NonceReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NonceReply.ProtoReflect.Descriptor instead.
 func (*NonceReply) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{13}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *NonceReply) GetFound() bool {
@@ -763,7 +964,7 @@ type GetBlobsRequest struct {
 
 func (x *GetBlobsRequest) Reset() {
 	*x = GetBlobsRequest{}
-	mi := &file_txpool_txpool_proto_msgTypes[14]
+	mi := &file_txpool_txpool_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -775,7 +976,7 @@ func (x *GetBlobsRequest) String() string {
 func (*GetBlobsRequest) ProtoMessage() {}
 
 func (x *GetBlobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[14]
+	mi := &file_txpool_txpool_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +989,7 @@ func (x *GetBlobsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlobsRequest.ProtoReflect.Descriptor instead.
 func (*GetBlobsRequest) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{14}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetBlobsRequest) GetBlobHashes() []*typesproto.H256 {
@@ -799,16 +1000,17 @@ func (x *GetBlobsRequest) GetBlobHashes() []*typesproto.H256 {
 }
 
 type GetBlobsReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Blobs         [][]byte               `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"`
-	Proofs        [][]byte               `protobuf:"bytes,2,rep,name=proofs,proto3" json:"proofs,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Blobs          [][]byte               `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"` // Flattened blobs and proofs: the proofs of a missing blob are a single empty one. Deprecated, see blobs_and_proofs
+	Proofs         [][]byte               `protobuf:"bytes,2,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	BlobsAndProofs []*BlobAndProofs       `protobuf:"bytes,3,rep,name=blobs_and_proofs,json=blobsAndProofs,proto3" json:"blobs_and_proofs,omitempty"` // Per requested blob hash, in the order of the request
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetBlobsReply) Reset() {
 	*x = GetBlobsReply{}
-	mi := &file_txpool_txpool_proto_msgTypes[15]
+	mi := &file_txpool_txpool_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -820,7 +1022,7 @@ func (x *GetBlobsReply) String() string {
 func (*GetBlobsReply) ProtoMessage() {}
 
 func (x *GetBlobsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[15]
+	mi := &file_txpool_txpool_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -833,7 +1035,7 @@ func (x *GetBlobsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlobsReply.ProtoReflect.Descriptor instead.
 func (*GetBlobsReply) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{15}
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetBlobsReply) GetBlobs() [][]byte {
@@ -850,30 +1052,38 @@ func (x *GetBlobsReply) GetProofs() [][]byte {
 	return nil
 }
 
-type AllReply_Tx struct {
+func (x *GetBlobsReply) GetBlobsAndProofs() []*BlobAndProofs {
+	if x != nil {
+		return x.BlobsAndProofs
+	}
+	return nil
+}
+
+// Per-sender limits of the transactions added to the pool, 0 - no limit
+type SenderPolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TxnType       AllReply_TxnType       `protobuf:"varint,1,opt,name=txn_type,json=txnType,proto3,enum=txpool.AllReply_TxnType" json:"txn_type,omitempty"`
-	Sender        *typesproto.H160       `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
-	RlpTx         []byte                 `protobuf:"bytes,3,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	MaxSlots      uint64                 `protobuf:"varint,1,opt,name=max_slots,json=maxSlots,proto3" json:"max_slots,omitempty"`            // Transactions pooled per sender
+	MaxGas        uint64                 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`                  // Sum of gas limits of the transactions pooled per sender
+	MaxNonceGap   uint64                 `protobuf:"varint,3,opt,name=max_nonce_gap,json=maxNonceGap,proto3" json:"max_nonce_gap,omitempty"` // Distance of a transaction nonce ahead of the sender nonce
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AllReply_Tx) Reset() {
-	*x = AllReply_Tx{}
-	mi := &file_txpool_txpool_proto_msgTypes[16]
+func (x *SenderPolicy) Reset() {
+	*x = SenderPolicy{}
+	mi := &file_txpool_txpool_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AllReply_Tx) String() string {
+func (x *SenderPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AllReply_Tx) ProtoMessage() {}
+func (*SenderPolicy) ProtoMessage() {}
 
-func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[16]
+func (x *SenderPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -884,56 +1094,55 @@ func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AllReply_Tx.ProtoReflect.Descriptor instead.
-func (*AllReply_Tx) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{8, 0}
+// Deprecated: Use SenderPolicy.ProtoReflect.Descriptor instead.
+func (*SenderPolicy) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *AllReply_Tx) GetTxnType() AllReply_TxnType {
+func (x *SenderPolicy) GetMaxSlots() uint64 {
 	if x != nil {
-		return x.TxnType
+		return x.MaxSlots
 	}
-	return AllReply_PENDING
+	return 0
 }
 
-func (x *AllReply_Tx) GetSender() *typesproto.H160 {
+func (x *SenderPolicy) GetMaxGas() uint64 {
 	if x != nil {
-		return x.Sender
+		return x.MaxGas
 	}
-	return nil
+	return 0
 }
 
-func (x *AllReply_Tx) GetRlpTx() []byte {
+func (x *SenderPolicy) GetMaxNonceGap() uint64 {
 	if x != nil {
-		return x.RlpTx
+		return x.MaxNonceGap
 	}
-	return nil
+	return 0
 }
 
-type PendingReply_Tx struct {
+type SetPolicyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sender        *typesproto.H160       `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
-	RlpTx         []byte                 `protobuf:"bytes,2,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
-	IsLocal       bool                   `protobuf:"varint,3,opt,name=is_local,json=isLocal,proto3" json:"is_local,omitempty"`
+	Policy        *SenderPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`           // Policy to apply, unset - only read the policy in effect
+	Replacement   *ReplacementPolicy     `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"` // Replacement policy to apply, unset - only read the policy in effect
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PendingReply_Tx) Reset() {
-	*x = PendingReply_Tx{}
-	mi := &file_txpool_txpool_proto_msgTypes[17]
+func (x *SetPolicyRequest) Reset() {
+	*x = SetPolicyRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PendingReply_Tx) String() string {
+func (x *SetPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PendingReply_Tx) ProtoMessage() {}
+func (*SetPolicyRequest) ProtoMessage() {}
 
-func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[17]
+func (x *SetPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -944,41 +1153,533 @@ func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PendingReply_Tx.ProtoReflect.Descriptor instead.
-func (*PendingReply_Tx) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{9, 0}
+// Deprecated: Use SetPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *PendingReply_Tx) GetSender() *typesproto.H160 {
+func (x *SetPolicyRequest) GetPolicy() *SenderPolicy {
 	if x != nil {
-		return x.Sender
+		return x.Policy
 	}
 	return nil
 }
 
-func (x *PendingReply_Tx) GetRlpTx() []byte {
+func (x *SetPolicyRequest) GetReplacement() *ReplacementPolicy {
 	if x != nil {
-		return x.RlpTx
+		return x.Replacement
 	}
 	return nil
 }
 
-func (x *PendingReply_Tx) GetIsLocal() bool {
-	if x != nil {
-		return x.IsLocal
-	}
-	return false
+type SetPolicyReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *SenderPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`           // Policy in effect
+	Replacement   *ReplacementPolicy     `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"` // Replacement policy in effect
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-var File_txpool_txpool_proto protoreflect.FileDescriptor
+func (x *SetPolicyReply) Reset() {
+	*x = SetPolicyReply{}
+	mi := &file_txpool_txpool_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-const file_txpool_txpool_proto_rawDesc = "" +
-	"\n" +
-	"\x13txpool/txpool.proto\x12\x06txpool\x1a\x1bgoogle/protobuf/empty.proto\x1a\x11types/types.proto\"/\n" +
-	"\bTxHashes\x12#\n" +
-	"\x06hashes\x18\x01 \x03(\v2\v.types.H256R\x06hashes\"%\n" +
-	"\n" +
-	"AddRequest\x12\x17\n" +
+func (x *SetPolicyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPolicyReply) ProtoMessage() {}
+
+func (x *SetPolicyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPolicyReply.ProtoReflect.Descriptor instead.
+func (*SetPolicyReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetPolicyReply) GetPolicy() *SenderPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SetPolicyReply) GetReplacement() *ReplacementPolicy {
+	if x != nil {
+		return x.Replacement
+	}
+	return nil
+}
+
+// Blob and its proofs, if the blob is found in the pool
+type BlobAndProofs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Blob          []byte                 `protobuf:"bytes,2,opt,name=blob,proto3" json:"blob,omitempty"`
+	Proofs        [][]byte               `protobuf:"bytes,3,rep,name=proofs,proto3" json:"proofs,omitempty"` // Proof of the blob (wrapper version 0), or its cell proofs (wrapper version 1: after Fulu)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlobAndProofs) Reset() {
+	*x = BlobAndProofs{}
+	mi := &file_txpool_txpool_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlobAndProofs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobAndProofs) ProtoMessage() {}
+
+func (x *BlobAndProofs) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobAndProofs.ProtoReflect.Descriptor instead.
+func (*BlobAndProofs) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BlobAndProofs) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *BlobAndProofs) GetBlob() []byte {
+	if x != nil {
+		return x.Blob
+	}
+	return nil
+}
+
+func (x *BlobAndProofs) GetProofs() [][]byte {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+// Pending transaction to score, see Scorer
+type ScoreTxn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          []byte                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Sender        []byte                 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Nonce         uint64                 `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Gas           uint64                 `protobuf:"varint,4,opt,name=gas,proto3" json:"gas,omitempty"`
+	Tip           uint64                 `protobuf:"varint,5,opt,name=tip,proto3" json:"tip,omitempty"`                    // Minimal tip of the transaction
+	FeeCap        []byte                 `protobuf:"bytes,6,opt,name=fee_cap,json=feeCap,proto3" json:"fee_cap,omitempty"` // Fee cap of the transaction, big-endian
+	BlobCount     uint32                 `protobuf:"varint,7,opt,name=blob_count,json=blobCount,proto3" json:"blob_count,omitempty"`
+	Size          uint32                 `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"` // Size of the RLP of the transaction
+	Local         bool                   `protobuf:"varint,9,opt,name=local,proto3" json:"local,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreTxn) Reset() {
+	*x = ScoreTxn{}
+	mi := &file_txpool_txpool_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreTxn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreTxn) ProtoMessage() {}
+
+func (x *ScoreTxn) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreTxn.ProtoReflect.Descriptor instead.
+func (*ScoreTxn) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ScoreTxn) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *ScoreTxn) GetSender() []byte {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *ScoreTxn) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *ScoreTxn) GetGas() uint64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+func (x *ScoreTxn) GetTip() uint64 {
+	if x != nil {
+		return x.Tip
+	}
+	return 0
+}
+
+func (x *ScoreTxn) GetFeeCap() []byte {
+	if x != nil {
+		return x.FeeCap
+	}
+	return nil
+}
+
+func (x *ScoreTxn) GetBlobCount() uint32 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+func (x *ScoreTxn) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ScoreTxn) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+type ScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseFee       uint64                 `protobuf:"varint,1,opt,name=base_fee,json=baseFee,proto3" json:"base_fee,omitempty"`
+	Txns          []*ScoreTxn            `protobuf:"bytes,2,rep,name=txns,proto3" json:"txns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreRequest) Reset() {
+	*x = ScoreRequest{}
+	mi := &file_txpool_txpool_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreRequest) ProtoMessage() {}
+
+func (x *ScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreRequest.ProtoReflect.Descriptor instead.
+func (*ScoreRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ScoreRequest) GetBaseFee() uint64 {
+	if x != nil {
+		return x.BaseFee
+	}
+	return 0
+}
+
+func (x *ScoreRequest) GetTxns() []*ScoreTxn {
+	if x != nil {
+		return x.Txns
+	}
+	return nil
+}
+
+type ScoreReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scores        []uint64               `protobuf:"varint,1,rep,packed,name=scores,proto3" json:"scores,omitempty"` // Per transaction, in the order of the request: the higher the earlier the transaction is offered for the block
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreReply) Reset() {
+	*x = ScoreReply{}
+	mi := &file_txpool_txpool_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreReply) ProtoMessage() {}
+
+func (x *ScoreReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreReply.ProtoReflect.Descriptor instead.
+func (*ScoreReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ScoreReply) GetScores() []uint64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+// Price bumps in percent a transaction needs to replace a pooled one with the same sender and nonce
+type ReplacementPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceBump     uint64                 `protobuf:"varint,1,opt,name=price_bump,json=priceBump,proto3" json:"price_bump,omitempty"`               // Tip and fee cap of a non-blob transaction
+	BlobPriceBump uint64                 `protobuf:"varint,2,opt,name=blob_price_bump,json=blobPriceBump,proto3" json:"blob_price_bump,omitempty"` // Tip and fee cap of a blob transaction
+	BlobFeeBump   uint64                 `protobuf:"varint,3,opt,name=blob_fee_bump,json=blobFeeBump,proto3" json:"blob_fee_bump,omitempty"`       // Blob fee cap of a blob transaction
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplacementPolicy) Reset() {
+	*x = ReplacementPolicy{}
+	mi := &file_txpool_txpool_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplacementPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplacementPolicy) ProtoMessage() {}
+
+func (x *ReplacementPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplacementPolicy.ProtoReflect.Descriptor instead.
+func (*ReplacementPolicy) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReplacementPolicy) GetPriceBump() uint64 {
+	if x != nil {
+		return x.PriceBump
+	}
+	return 0
+}
+
+func (x *ReplacementPolicy) GetBlobPriceBump() uint64 {
+	if x != nil {
+		return x.BlobPriceBump
+	}
+	return 0
+}
+
+func (x *ReplacementPolicy) GetBlobFeeBump() uint64 {
+	if x != nil {
+		return x.BlobFeeBump
+	}
+	return 0
+}
+
+type AllReply_Tx struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxnType       AllReply_TxnType       `protobuf:"varint,1,opt,name=txn_type,json=txnType,proto3,enum=txpool.AllReply_TxnType" json:"txn_type,omitempty"`
+	Sender        *typesproto.H160       `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	RlpTx         []byte                 `protobuf:"bytes,3,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllReply_Tx) Reset() {
+	*x = AllReply_Tx{}
+	mi := &file_txpool_txpool_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllReply_Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllReply_Tx) ProtoMessage() {}
+
+func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllReply_Tx.ProtoReflect.Descriptor instead.
+func (*AllReply_Tx) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *AllReply_Tx) GetTxnType() AllReply_TxnType {
+	if x != nil {
+		return x.TxnType
+	}
+	return AllReply_PENDING
+}
+
+func (x *AllReply_Tx) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *AllReply_Tx) GetRlpTx() []byte {
+	if x != nil {
+		return x.RlpTx
+	}
+	return nil
+}
+
+type PendingReply_Tx struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sender        *typesproto.H160       `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	RlpTx         []byte                 `protobuf:"bytes,2,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	IsLocal       bool                   `protobuf:"varint,3,opt,name=is_local,json=isLocal,proto3" json:"is_local,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingReply_Tx) Reset() {
+	*x = PendingReply_Tx{}
+	mi := &file_txpool_txpool_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingReply_Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingReply_Tx) ProtoMessage() {}
+
+func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingReply_Tx.ProtoReflect.Descriptor instead.
+func (*PendingReply_Tx) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *PendingReply_Tx) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *PendingReply_Tx) GetRlpTx() []byte {
+	if x != nil {
+		return x.RlpTx
+	}
+	return nil
+}
+
+func (x *PendingReply_Tx) GetIsLocal() bool {
+	if x != nil {
+		return x.IsLocal
+	}
+	return false
+}
+
+var File_txpool_txpool_proto protoreflect.FileDescriptor
+
+const file_txpool_txpool_proto_rawDesc = "" +
+	"\n" +
+	"\x13txpool/txpool.proto\x12\x06txpool\x1a\x1bgoogle/protobuf/empty.proto\x1a\x11types/types.proto\"/\n" +
+	"\bTxHashes\x12#\n" +
+	"\x06hashes\x18\x01 \x03(\v2\v.types.H256R\x06hashes\"%\n" +
+	"\n" +
+	"AddRequest\x12\x17\n" +
 	"\arlp_txs\x18\x01 \x03(\fR\x06rlpTxs\"T\n" +
 	"\bAddReply\x120\n" +
 	"\bimported\x18\x01 \x03(\x0e2\x14.txpool.ImportResultR\bimported\x12\x16\n" +
@@ -990,11 +1691,29 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\fOnAddRequest\"%\n" +
 	"\n" +
 	"OnAddReply\x12\x17\n" +
-	"\arpl_txs\x18\x01 \x03(\fR\x06rplTxs\"\f\n" +
+	"\arpl_txs\x18\x01 \x03(\fR\x06rplTxs\"\x0f\n" +
+	"\rOnDropRequest\"\x94\x01\n" +
+	"\n" +
+	"DroppedTxn\x12\x1f\n" +
+	"\x04hash\x18\x01 \x01(\v2\v.types.H256R\x04hash\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vreason_code\x18\x03 \x01(\rR\n" +
+	"reasonCode\x12,\n" +
+	"\vreplaced_by\x18\x04 \x01(\v2\v.types.H256R\n" +
+	"replacedBy\"5\n" +
+	"\vOnDropReply\x12&\n" +
+	"\x04txns\x18\x01 \x03(\v2\x12.txpool.DroppedTxnR\x04txns\"\xae\x01\n" +
 	"\n" +
-	"AllRequest\"\xda\x01\n" +
+	"AllRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\fR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\x12%\n" +
+	"\asenders\x18\x03 \x03(\v2\v.types.H160R\asenders\x125\n" +
+	"\tsub_pools\x18\x04 \x03(\x0e2\x18.txpool.AllReply.TxnTypeR\bsubPools\x12\x14\n" +
+	"\x05types\x18\x05 \x03(\rR\x05types\"\xfb\x01\n" +
 	"\bAllReply\x12%\n" +
-	"\x03txs\x18\x01 \x03(\v2\x13.txpool.AllReply.TxR\x03txs\x1au\n" +
+	"\x03txs\x18\x01 \x03(\v2\x13.txpool.AllReply.TxR\x03txs\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\fR\n" +
+	"nextCursor\x1au\n" +
 	"\x02Tx\x123\n" +
 	"\btxn_type\x18\x01 \x01(\x0e2\x18.txpool.AllReply.TxnTypeR\atxnType\x12#\n" +
 	"\x06sender\x18\x02 \x01(\v2\v.types.H160R\x06sender\x12\x15\n" +
@@ -1023,17 +1742,55 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\x05nonce\x18\x02 \x01(\x04R\x05nonce\"?\n" +
 	"\x0fGetBlobsRequest\x12,\n" +
 	"\vblob_hashes\x18\x01 \x03(\v2\v.types.H256R\n" +
-	"blobHashes\"=\n" +
+	"blobHashes\"~\n" +
 	"\rGetBlobsReply\x12\x14\n" +
 	"\x05blobs\x18\x01 \x03(\fR\x05blobs\x12\x16\n" +
-	"\x06proofs\x18\x02 \x03(\fR\x06proofs*l\n" +
+	"\x06proofs\x18\x02 \x03(\fR\x06proofs\x12?\n" +
+	"\x10blobs_and_proofs\x18\x03 \x03(\v2\x15.txpool.BlobAndProofsR\x0eblobsAndProofs\"h\n" +
+	"\fSenderPolicy\x12\x1b\n" +
+	"\tmax_slots\x18\x01 \x01(\x04R\bmaxSlots\x12\x17\n" +
+	"\amax_gas\x18\x02 \x01(\x04R\x06maxGas\x12\"\n" +
+	"\rmax_nonce_gap\x18\x03 \x01(\x04R\vmaxNonceGap\"}\n" +
+	"\x10SetPolicyRequest\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.txpool.SenderPolicyR\x06policy\x12;\n" +
+	"\vreplacement\x18\x02 \x01(\v2\x19.txpool.ReplacementPolicyR\vreplacement\"{\n" +
+	"\x0eSetPolicyReply\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.txpool.SenderPolicyR\x06policy\x12;\n" +
+	"\vreplacement\x18\x02 \x01(\v2\x19.txpool.ReplacementPolicyR\vreplacement\"Q\n" +
+	"\rBlobAndProofs\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x12\n" +
+	"\x04blob\x18\x02 \x01(\fR\x04blob\x12\x16\n" +
+	"\x06proofs\x18\x03 \x03(\fR\x06proofs\"\xd2\x01\n" +
+	"\bScoreTxn\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\x12\x16\n" +
+	"\x06sender\x18\x02 \x01(\fR\x06sender\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\x04R\x05nonce\x12\x10\n" +
+	"\x03gas\x18\x04 \x01(\x04R\x03gas\x12\x10\n" +
+	"\x03tip\x18\x05 \x01(\x04R\x03tip\x12\x17\n" +
+	"\afee_cap\x18\x06 \x01(\fR\x06feeCap\x12\x1d\n" +
+	"\n" +
+	"blob_count\x18\a \x01(\rR\tblobCount\x12\x12\n" +
+	"\x04size\x18\b \x01(\rR\x04size\x12\x14\n" +
+	"\x05local\x18\t \x01(\bR\x05local\"O\n" +
+	"\fScoreRequest\x12\x19\n" +
+	"\bbase_fee\x18\x01 \x01(\x04R\abaseFee\x12$\n" +
+	"\x04txns\x18\x02 \x03(\v2\x10.txpool.ScoreTxnR\x04txns\"$\n" +
+	"\n" +
+	"ScoreReply\x12\x16\n" +
+	"\x06scores\x18\x01 \x03(\x04R\x06scores\"~\n" +
+	"\x11ReplacementPolicy\x12\x1d\n" +
+	"\n" +
+	"price_bump\x18\x01 \x01(\x04R\tpriceBump\x12&\n" +
+	"\x0fblob_price_bump\x18\x02 \x01(\x04R\rblobPriceBump\x12\"\n" +
+	"\rblob_fee_bump\x18\x03 \x01(\x04R\vblobFeeBump*~\n" +
 	"\fImportResult\x12\v\n" +
 	"\aSUCCESS\x10\x00\x12\x12\n" +
 	"\x0eALREADY_EXISTS\x10\x01\x12\x0f\n" +
 	"\vFEE_TOO_LOW\x10\x02\x12\t\n" +
 	"\x05STALE\x10\x03\x12\v\n" +
 	"\aINVALID\x10\x04\x12\x12\n" +
-	"\x0eINTERNAL_ERROR\x10\x052\xa8\x04\n" +
+	"\x0eINTERNAL_ERROR\x10\x05\x12\x10\n" +
+	"\fWOULD_REVERT\x10\x062\x9f\x05\n" +
 	"\x06Txpool\x126\n" +
 	"\aVersion\x12\x16.google.protobuf.Empty\x1a\x13.types.VersionReply\x121\n" +
 	"\vFindUnknown\x12\x10.txpool.TxHashes\x1a\x10.txpool.TxHashes\x12+\n" +
@@ -1041,10 +1798,14 @@ const file_txpool_txpool_proto_rawDesc = "" +
 	"\fTransactions\x12\x1b.txpool.TransactionsRequest\x1a\x19.txpool.TransactionsReply\x12+\n" +
 	"\x03All\x12\x12.txpool.AllRequest\x1a\x10.txpool.AllReply\x127\n" +
 	"\aPending\x12\x16.google.protobuf.Empty\x1a\x14.txpool.PendingReply\x123\n" +
-	"\x05OnAdd\x12\x14.txpool.OnAddRequest\x1a\x12.txpool.OnAddReply0\x01\x124\n" +
+	"\x05OnAdd\x12\x14.txpool.OnAddRequest\x1a\x12.txpool.OnAddReply0\x01\x126\n" +
+	"\x06OnDrop\x12\x15.txpool.OnDropRequest\x1a\x13.txpool.OnDropReply0\x01\x124\n" +
 	"\x06Status\x12\x15.txpool.StatusRequest\x1a\x13.txpool.StatusReply\x121\n" +
 	"\x05Nonce\x12\x14.txpool.NonceRequest\x1a\x12.txpool.NonceReply\x12:\n" +
-	"\bGetBlobs\x12\x17.txpool.GetBlobsRequest\x1a\x15.txpool.GetBlobsReplyB\x16Z\x14./txpool;txpoolprotob\x06proto3"
+	"\bGetBlobs\x12\x17.txpool.GetBlobsRequest\x1a\x15.txpool.GetBlobsReply\x12=\n" +
+	"\tSetPolicy\x12\x18.txpool.SetPolicyRequest\x1a\x16.txpool.SetPolicyReply2;\n" +
+	"\x06Scorer\x121\n" +
+	"\x05Score\x12\x14.txpool.ScoreRequest\x1a\x12.txpool.ScoreReplyB\x16Z\x14./txpool;txpoolprotob\x06proto3"
 
 var (
 	file_txpool_txpool_proto_rawDescOnce sync.Once
@@ -1059,7 +1820,7 @@ func file_txpool_txpool_proto_rawDescGZIP() []byte {
 }
 
 var file_txpool_txpool_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_txpool_txpool_proto_goTypes = []any{
 	(ImportResult)(0),               // 0: txpool.ImportResult
 	(AllReply_TxnType)(0),           // 1: txpool.AllReply.TxnType
@@ -1070,58 +1831,86 @@ var file_txpool_txpool_proto_goTypes = []any{
 	(*TransactionsReply)(nil),       // 6: txpool.TransactionsReply
 	(*OnAddRequest)(nil),            // 7: txpool.OnAddRequest
 	(*OnAddReply)(nil),              // 8: txpool.OnAddReply
-	(*AllRequest)(nil),              // 9: txpool.AllRequest
-	(*AllReply)(nil),                // 10: txpool.AllReply
-	(*PendingReply)(nil),            // 11: txpool.PendingReply
-	(*StatusRequest)(nil),           // 12: txpool.StatusRequest
-	(*StatusReply)(nil),             // 13: txpool.StatusReply
-	(*NonceRequest)(nil),            // 14: txpool.NonceRequest
-	(*NonceReply)(nil),              // 15: txpool.NonceReply
-	(*GetBlobsRequest)(nil),         // 16: txpool.GetBlobsRequest
-	(*GetBlobsReply)(nil),           // 17: txpool.GetBlobsReply
-	(*AllReply_Tx)(nil),             // 18: txpool.AllReply.Tx
-	(*PendingReply_Tx)(nil),         // 19: txpool.PendingReply.Tx
-	(*typesproto.H256)(nil),         // 20: types.H256
-	(*typesproto.H160)(nil),         // 21: types.H160
-	(*emptypb.Empty)(nil),           // 22: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 23: types.VersionReply
+	(*OnDropRequest)(nil),           // 9: txpool.OnDropRequest
+	(*DroppedTxn)(nil),              // 10: txpool.DroppedTxn
+	(*OnDropReply)(nil),             // 11: txpool.OnDropReply
+	(*AllRequest)(nil),              // 12: txpool.AllRequest
+	(*AllReply)(nil),                // 13: txpool.AllReply
+	(*PendingReply)(nil),            // 14: txpool.PendingReply
+	(*StatusRequest)(nil),           // 15: txpool.StatusRequest
+	(*StatusReply)(nil),             // 16: txpool.StatusReply
+	(*NonceRequest)(nil),            // 17: txpool.NonceRequest
+	(*NonceReply)(nil),              // 18: txpool.NonceReply
+	(*GetBlobsRequest)(nil),         // 19: txpool.GetBlobsRequest
+	(*GetBlobsReply)(nil),           // 20: txpool.GetBlobsReply
+	(*SenderPolicy)(nil),            // 21: txpool.SenderPolicy
+	(*SetPolicyRequest)(nil),        // 22: txpool.SetPolicyRequest
+	(*SetPolicyReply)(nil),          // 23: txpool.SetPolicyReply
+	(*BlobAndProofs)(nil),           // 24: txpool.BlobAndProofs
+	(*ScoreTxn)(nil),                // 25: txpool.ScoreTxn
+	(*ScoreRequest)(nil),            // 26: txpool.ScoreRequest
+	(*ScoreReply)(nil),              // 27: txpool.ScoreReply
+	(*ReplacementPolicy)(nil),       // 28: txpool.ReplacementPolicy
+	(*AllReply_Tx)(nil),             // 29: txpool.AllReply.Tx
+	(*PendingReply_Tx)(nil),         // 30: txpool.PendingReply.Tx
+	(*typesproto.H256)(nil),         // 31: types.H256
+	(*typesproto.H160)(nil),         // 32: types.H160
+	(*emptypb.Empty)(nil),           // 33: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil), // 34: types.VersionReply
 }
 var file_txpool_txpool_proto_depIdxs = []int32{
-	20, // 0: txpool.TxHashes.hashes:type_name -> types.H256
+	31, // 0: txpool.TxHashes.hashes:type_name -> types.H256
 	0,  // 1: txpool.AddReply.imported:type_name -> txpool.ImportResult
-	20, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
-	18, // 3: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
-	19, // 4: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
-	21, // 5: txpool.NonceRequest.address:type_name -> types.H160
-	20, // 6: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
-	1,  // 7: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
-	21, // 8: txpool.AllReply.Tx.sender:type_name -> types.H160
-	21, // 9: txpool.PendingReply.Tx.sender:type_name -> types.H160
-	22, // 10: txpool.Txpool.Version:input_type -> google.protobuf.Empty
-	2,  // 11: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
-	3,  // 12: txpool.Txpool.Add:input_type -> txpool.AddRequest
-	5,  // 13: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
-	9,  // 14: txpool.Txpool.All:input_type -> txpool.AllRequest
-	22, // 15: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
-	7,  // 16: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
-	12, // 17: txpool.Txpool.Status:input_type -> txpool.StatusRequest
-	14, // 18: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
-	16, // 19: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
-	23, // 20: txpool.Txpool.Version:output_type -> types.VersionReply
-	2,  // 21: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
-	4,  // 22: txpool.Txpool.Add:output_type -> txpool.AddReply
-	6,  // 23: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
-	10, // 24: txpool.Txpool.All:output_type -> txpool.AllReply
-	11, // 25: txpool.Txpool.Pending:output_type -> txpool.PendingReply
-	8,  // 26: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
-	13, // 27: txpool.Txpool.Status:output_type -> txpool.StatusReply
-	15, // 28: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
-	17, // 29: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
-	20, // [20:30] is the sub-list for method output_type
-	10, // [10:20] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	31, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
+	31, // 3: txpool.DroppedTxn.hash:type_name -> types.H256
+	31, // 4: txpool.DroppedTxn.replaced_by:type_name -> types.H256
+	10, // 5: txpool.OnDropReply.txns:type_name -> txpool.DroppedTxn
+	32, // 6: txpool.AllRequest.senders:type_name -> types.H160
+	1,  // 7: txpool.AllRequest.sub_pools:type_name -> txpool.AllReply.TxnType
+	29, // 8: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
+	30, // 9: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
+	32, // 10: txpool.NonceRequest.address:type_name -> types.H160
+	31, // 11: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
+	24, // 12: txpool.GetBlobsReply.blobs_and_proofs:type_name -> txpool.BlobAndProofs
+	21, // 13: txpool.SetPolicyRequest.policy:type_name -> txpool.SenderPolicy
+	28, // 14: txpool.SetPolicyRequest.replacement:type_name -> txpool.ReplacementPolicy
+	21, // 15: txpool.SetPolicyReply.policy:type_name -> txpool.SenderPolicy
+	28, // 16: txpool.SetPolicyReply.replacement:type_name -> txpool.ReplacementPolicy
+	25, // 17: txpool.ScoreRequest.txns:type_name -> txpool.ScoreTxn
+	1,  // 18: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
+	32, // 19: txpool.AllReply.Tx.sender:type_name -> types.H160
+	32, // 20: txpool.PendingReply.Tx.sender:type_name -> types.H160
+	33, // 21: txpool.Txpool.Version:input_type -> google.protobuf.Empty
+	2,  // 22: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
+	3,  // 23: txpool.Txpool.Add:input_type -> txpool.AddRequest
+	5,  // 24: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
+	12, // 25: txpool.Txpool.All:input_type -> txpool.AllRequest
+	33, // 26: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
+	7,  // 27: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
+	9,  // 28: txpool.Txpool.OnDrop:input_type -> txpool.OnDropRequest
+	15, // 29: txpool.Txpool.Status:input_type -> txpool.StatusRequest
+	17, // 30: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
+	19, // 31: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
+	22, // 32: txpool.Txpool.SetPolicy:input_type -> txpool.SetPolicyRequest
+	26, // 33: txpool.Scorer.Score:input_type -> txpool.ScoreRequest
+	34, // 34: txpool.Txpool.Version:output_type -> types.VersionReply
+	2,  // 35: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
+	4,  // 36: txpool.Txpool.Add:output_type -> txpool.AddReply
+	6,  // 37: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
+	13, // 38: txpool.Txpool.All:output_type -> txpool.AllReply
+	14, // 39: txpool.Txpool.Pending:output_type -> txpool.PendingReply
+	8,  // 40: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
+	11, // 41: txpool.Txpool.OnDrop:output_type -> txpool.OnDropReply
+	16, // 42: txpool.Txpool.Status:output_type -> txpool.StatusReply
+	18, // 43: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
+	20, // 44: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
+	23, // 45: txpool.Txpool.SetPolicy:output_type -> txpool.SetPolicyReply
+	27, // 46: txpool.Scorer.Score:output_type -> txpool.ScoreReply
+	34, // [34:47] is the sub-list for method output_type
+	21, // [21:34] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_txpool_txpool_proto_init() }
@@ -1135,9 +1924,9 @@ func file_txpool_txpool_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_txpool_txpool_proto_rawDesc), len(file_txpool_txpool_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   18,
+			NumMessages:   29,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_txpool_txpool_proto_goTypes,
 		DependencyIndexes: file_txpool_txpool_proto_depIdxs,
@@ -28,9 +28,11 @@ const (
 	Txpool_All_FullMethodName          = "/txpool.Txpool/All"
 	Txpool_Pending_FullMethodName      = "/txpool.Txpool/Pending"
 	Txpool_OnAdd_FullMethodName        = "/txpool.Txpool/OnAdd"
+	Txpool_OnDrop_FullMethodName       = "/txpool.Txpool/OnDrop"
 	Txpool_Status_FullMethodName       = "/txpool.Txpool/Status"
 	Txpool_Nonce_FullMethodName        = "/txpool.Txpool/Nonce"
 	Txpool_GetBlobs_FullMethodName     = "/txpool.Txpool/GetBlobs"
+	Txpool_SetPolicy_FullMethodName    = "/txpool.Txpool/SetPolicy"
 )
 
 // TxpoolClient is the client API for Txpool service.
@@ -52,12 +54,16 @@ type TxpoolClient interface {
 	Pending(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PendingReply, error)
 	// subscribe to new transactions add event
 	OnAdd(ctx context.Context, in *OnAddRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OnAddReply], error)
+	// subscribe to transactions drop event: the reason and the replacing transaction
+	OnDrop(ctx context.Context, in *OnDropRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OnDropReply], error)
 	// returns high level status
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error)
 	// returns nonce for given account
 	Nonce(ctx context.Context, in *NonceRequest, opts ...grpc.CallOption) (*NonceReply, error)
 	// returns the list of blobs and proofs for a given list of blob hashes
 	GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error)
+	// sets the per-sender limits of the pool, returns the limits in effect
+	SetPolicy(ctx context.Context, in *SetPolicyRequest, opts ...grpc.CallOption) (*SetPolicyReply, error)
 }
 
 type txpoolClient struct {
@@ -147,6 +153,25 @@ func (c *txpoolClient) OnAdd(ctx context.Context, in *OnAddRequest, opts ...grpc
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Txpool_OnAddClient = grpc.ServerStreamingClient[OnAddReply]
 
+func (c *txpoolClient) OnDrop(ctx context.Context, in *OnDropRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OnDropReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Txpool_ServiceDesc.Streams[1], Txpool_OnDrop_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[OnDropRequest, OnDropReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Txpool_OnDropClient = grpc.ServerStreamingClient[OnDropReply]
+
 func (c *txpoolClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StatusReply)
@@ -177,6 +202,16 @@ func (c *txpoolClient) GetBlobs(ctx context.Context, in *GetBlobsRequest, opts .
 	return out, nil
 }
 
+func (c *txpoolClient) SetPolicy(ctx context.Context, in *SetPolicyRequest, opts ...grpc.CallOption) (*SetPolicyReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPolicyReply)
+	err := c.cc.Invoke(ctx, Txpool_SetPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxpoolServer is the server API for Txpool service.
 // All implementations must embed UnimplementedTxpoolServer
 // for forward compatibility.
@@ -196,12 +231,16 @@ type TxpoolServer interface {
 	Pending(context.Context, *emptypb.Empty) (*PendingReply, error)
 	// subscribe to new transactions add event
 	OnAdd(*OnAddRequest, grpc.ServerStreamingServer[OnAddReply]) error
+	// subscribe to transactions drop event: the reason and the replacing transaction
+	OnDrop(*OnDropRequest, grpc.ServerStreamingServer[OnDropReply]) error
 	// returns high level status
 	Status(context.Context, *StatusRequest) (*StatusReply, error)
 	// returns nonce for given account
 	Nonce(context.Context, *NonceRequest) (*NonceReply, error)
 	// returns the list of blobs and proofs for a given list of blob hashes
 	GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error)
+	// sets the per-sender limits of the pool, returns the limits in effect
+	SetPolicy(context.Context, *SetPolicyRequest) (*SetPolicyReply, error)
 	mustEmbedUnimplementedTxpoolServer()
 }
 
@@ -233,6 +272,9 @@ func (UnimplementedTxpoolServer) Pending(context.Context, *emptypb.Empty) (*Pend
 func (UnimplementedTxpoolServer) OnAdd(*OnAddRequest, grpc.ServerStreamingServer[OnAddReply]) error {
 	return status.Errorf(codes.Unimplemented, "method OnAdd not implemented")
 }
+func (UnimplementedTxpoolServer) OnDrop(*OnDropRequest, grpc.ServerStreamingServer[OnDropReply]) error {
+	return status.Errorf(codes.Unimplemented, "method OnDrop not implemented")
+}
 func (UnimplementedTxpoolServer) Status(context.Context, *StatusRequest) (*StatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
@@ -242,6 +284,9 @@ func (UnimplementedTxpoolServer) Nonce(context.Context, *NonceRequest) (*NonceRe
 func (UnimplementedTxpoolServer) GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobs not implemented")
 }
+func (UnimplementedTxpoolServer) SetPolicy(context.Context, *SetPolicyRequest) (*SetPolicyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPolicy not implemented")
+}
 func (UnimplementedTxpoolServer) mustEmbedUnimplementedTxpoolServer() {}
 func (UnimplementedTxpoolServer) testEmbeddedByValue()                {}
 
@@ -382,6 +427,17 @@ func _Txpool_OnAdd_Handler(srv interface{}, stream grpc.ServerStream) error {
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Txpool_OnAddServer = grpc.ServerStreamingServer[OnAddReply]
 
+func _Txpool_OnDrop_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OnDropRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxpoolServer).OnDrop(m, &grpc.GenericServerStream[OnDropRequest, OnDropReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Txpool_OnDropServer = grpc.ServerStreamingServer[OnDropReply]
+
 func _Txpool_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatusRequest)
 	if err := dec(in); err != nil {
@@ -436,6 +492,24 @@ func _Txpool_GetBlobs_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Txpool_SetPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).SetPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_SetPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).SetPolicy(ctx, req.(*SetPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Txpool_ServiceDesc is the grpc.ServiceDesc for Txpool service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -479,6 +553,10 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlobs",
 			Handler:    _Txpool_GetBlobs_Handler,
 		},
+		{
+			MethodName: "SetPolicy",
+			Handler:    _Txpool_SetPolicy_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -486,6 +564,119 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Txpool_OnAdd_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "OnDrop",
+			Handler:       _Txpool_OnDrop_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "txpool/txpool.proto",
+}
+
+const (
+	Scorer_Score_FullMethodName = "/txpool.Scorer/Score"
+)
+
+// ScorerClient is the client API for Scorer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Scorer - external ordering of the pending transactions offered for the blocks (--txpool.ordering=grpc).
+// Transactions of a sender are offered in the order of their nonces whatever their scores are.
+type ScorerClient interface {
+	Score(ctx context.Context, in *ScoreRequest, opts ...grpc.CallOption) (*ScoreReply, error)
+}
+
+type scorerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScorerClient(cc grpc.ClientConnInterface) ScorerClient {
+	return &scorerClient{cc}
+}
+
+func (c *scorerClient) Score(ctx context.Context, in *ScoreRequest, opts ...grpc.CallOption) (*ScoreReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScoreReply)
+	err := c.cc.Invoke(ctx, Scorer_Score_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScorerServer is the server API for Scorer service.
+// All implementations must embed UnimplementedScorerServer
+// for forward compatibility.
+//
+// Scorer - external ordering of the pending transactions offered for the blocks (--txpool.ordering=grpc).
+// Transactions of a sender are offered in the order of their nonces whatever their scores are.
+type ScorerServer interface {
+	Score(context.Context, *ScoreRequest) (*ScoreReply, error)
+	mustEmbedUnimplementedScorerServer()
+}
+
+// UnimplementedScorerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScorerServer struct{}
+
+func (UnimplementedScorerServer) Score(context.Context, *ScoreRequest) (*ScoreReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Score not implemented")
+}
+func (UnimplementedScorerServer) mustEmbedUnimplementedScorerServer() {}
+func (UnimplementedScorerServer) testEmbeddedByValue()                {}
+
+// UnsafeScorerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScorerServer will
+// result in compilation errors.
+type UnsafeScorerServer interface {
+	mustEmbedUnimplementedScorerServer()
+}
+
+func RegisterScorerServer(s grpc.ServiceRegistrar, srv ScorerServer) {
+	// If the following call pancis, it indicates UnimplementedScorerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Scorer_ServiceDesc, srv)
+}
+
+func _Scorer_Score_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScorerServer).Score(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scorer_Score_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScorerServer).Score(ctx, req.(*ScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Scorer_ServiceDesc is the grpc.ServiceDesc for Scorer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Scorer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "txpool.Scorer",
+	HandlerType: (*ScorerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Score",
+			Handler:    _Scorer_Score_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "txpool/txpool.proto",
 }
@@ -813,6 +813,7 @@ type NodeInfoReply struct {
 	Ports         *NodeInfoPorts         `protobuf:"bytes,5,opt,name=ports,proto3" json:"ports,omitempty"`
 	ListenerAddr  string                 `protobuf:"bytes,6,opt,name=listener_addr,json=listenerAddr,proto3" json:"listener_addr,omitempty"`
 	Protocols     []byte                 `protobuf:"bytes,7,opt,name=protocols,proto3" json:"protocols,omitempty"`
+	Nat           []byte                 `protobuf:"bytes,8,opt,name=nat,proto3" json:"nat,omitempty"` // JSON of the state of the detection of the external endpoint
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -896,6 +897,13 @@ func (x *NodeInfoReply) GetProtocols() []byte {
 	return nil
 }
 
+func (x *NodeInfoReply) GetNat() []byte {
+	if x != nil {
+		return x.Nat
+	}
+	return nil
+}
+
 type PeerInfo struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1434,7 +1442,7 @@ const file_types_types_proto_rawDesc = "" +
 	"\brequests\x18\x01 \x03(\fR\brequests\"I\n" +
 	"\rNodeInfoPorts\x12\x1c\n" +
 	"\tdiscovery\x18\x01 \x01(\rR\tdiscovery\x12\x1a\n" +
-	"\blistener\x18\x02 \x01(\rR\blistener\"\xca\x01\n" +
+	"\blistener\x18\x02 \x01(\rR\blistener\"\xdc\x01\n" +
 	"\rNodeInfoReply\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -1442,7 +1450,8 @@ const file_types_types_proto_rawDesc = "" +
 	"\x03enr\x18\x04 \x01(\tR\x03enr\x12*\n" +
 	"\x05ports\x18\x05 \x01(\v2\x14.types.NodeInfoPortsR\x05ports\x12#\n" +
 	"\rlistener_addr\x18\x06 \x01(\tR\flistenerAddr\x12\x1c\n" +
-	"\tprotocols\x18\a \x01(\fR\tprotocols\"\xb2\x02\n" +
+	"\tprotocols\x18\a \x01(\fR\tprotocols\x12\x10\n" +
+	"\x03nat\x18\b \x01(\fR\x03nat\"\xb2\x02\n" +
 	"\bPeerInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
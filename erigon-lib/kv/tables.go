@@ -93,7 +93,10 @@ const (
 	EthTx    = "BlockTransaction" // tx_id_u64 -> rlp(tx)
 	MaxTxNum = "MaxTxNum"         // block_number_u64 -> max_tx_num_in_block_u64
 
-	TxLookup = "BlockTransactionLookup" // hash -> transaction/receipt lookup metadata
+	TxLookup           = "BlockTransactionLookup"           // hash -> transaction/receipt lookup metadata
+	TxLookupTombstones = "BlockTransactionLookupTombstones" // block_num_u64 + hash -> empty: TxLookup entries of unwound blocks, for pruning
+
+	TxMetrics = "TxMetrics" // tx_num_u64 -> execution_time_ns_u64 + state_reads_u64 + state_writes_u64 + call_depth_u64 (--sync.tx-metrics)
 
 	ConfigTable = "Config" // config prefix for the db
 
@@ -232,6 +235,12 @@ const (
 	BlockRootToKzgCommitments  = "BlockRootToKzgCommitments"
 	BlockRootToDataColumnCount = "BlockRootToDataColumnCount"
 
+	// [Versioned Hash] => [slot + block root + blob index]
+	BlobVersionedHashToSidecar = "BlobVersionedHashToSidecar"
+
+	// [slot + proposer index + kind + blob index] => [equivocation evidence (json)]
+	BlobSidecarEquivocations = "BlobSidecarEquivocations"
+
 	// [Block Root] => [Parent Root]
 	BlockRootToParentRoot  = "BlockRootToParentRoot"
 	ParentRootToBlockRoots = "ParentRootToBlockRoots"
@@ -241,6 +250,9 @@ const (
 	// BlockRoot => Beacon Block Header
 	BeaconBlockHeaders = "BeaconBlockHeaders"
 
+	// [pubkey + slot + block root + position in block] => [withdrawal credentials + amount + deposit request index]
+	DepositPubKeyToDeposits = "DepositPubKeyToDeposits"
+
 	// Beacon historical data
 	// ValidatorIndex => [Field]
 	ValidatorPublicKeys         = "ValidatorPublickeys"
@@ -324,6 +336,8 @@ var ChaindataTables = []string{
 	BadHeaderNumber,
 	BlockBody,
 	TxLookup,
+	TxLookupTombstones,
+	TxMetrics,
 	ConfigTable,
 	DatabaseInfo,
 	IncarnationMap,
@@ -413,9 +427,12 @@ var ChaindataTables = []string{
 	BlockRootToBlockNumber,
 	LastBeaconSnapshot,
 	ParentRootToBlockRoots,
+	DepositPubKeyToDeposits,
 	// Blob Storage
 	BlockRootToKzgCommitments,
 	BlockRootToDataColumnCount,
+	BlobVersionedHashToSidecar,
+	BlobSidecarEquivocations,
 	// State Reconstitution
 	ValidatorEffectiveBalance,
 	ValidatorBalance,
@@ -454,12 +471,14 @@ const (
 	RecentLocalTransaction = "RecentLocalTransaction" // sequence_u64 -> tx_hash
 	PoolTransaction        = "PoolTransaction"        // txHash -> sender+tx_rlp
 	PoolInfo               = "PoolInfo"               // option_key -> option_value
+	PoolFeeMarketHistory   = "PoolFeeMarketHistory"   // timestamp_u64 -> fee_market_snapshot_json
 )
 
 var TxPoolTables = []string{
 	RecentLocalTransaction,
 	PoolTransaction,
 	PoolInfo,
+	PoolFeeMarketHistory,
 }
 var SentryTables = []string{
 	Inodes,
@@ -0,0 +1,97 @@
+package sentry
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-freelru"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+const (
+	AnnouncementFilterLimit = 1 << 16
+	// AnnouncementFilterLifetime - sentries connected to the same network receive announcements of a hash within
+	// a few seconds. Later ones are processed again: the peer which announced it first may not deliver
+	AnnouncementFilterLifetime = 10 * time.Second
+)
+
+var (
+	mxBlockAnnouncementsSuppressed = metrics.GetOrCreateCounter(`p2p_announcements_suppressed{kind="block"}`)
+	mxTxnAnnouncementsSuppressed   = metrics.GetOrCreateCounter(`p2p_announcements_suppressed{kind="txn"}`)
+)
+
+// AnnouncementFilter - LRU of recently announced block and transaction hashes shared by the message loops of all
+// sentries of a consumer: an announcement which already came via another sentry is not processed again. Announcements
+// via the same sentry (from different peers) are not filtered. nil filter (single sentry) passes everything.
+type AnnouncementFilter struct {
+	mu  sync.Mutex
+	lru *freelru.LRU[common.Hash, sentryproto.SentryClient]
+}
+
+func NewAnnouncementFilter(limit uint32, lifetime time.Duration) *AnnouncementFilter {
+	lru, err := freelru.New[common.Hash, sentryproto.SentryClient](limit, func(h common.Hash) uint32 {
+		return binary.BigEndian.Uint32(h[:4])
+	})
+	if err != nil {
+		panic(err)
+	}
+	lru.SetLifetime(lifetime)
+	return &AnnouncementFilter{lru: lru}
+}
+
+// NewAnnouncementFilterFor returns the filter for the consumer of `sentries`, nil if there is only one sentry
+func NewAnnouncementFilterFor(sentries []sentryproto.SentryClient) *AnnouncementFilter {
+	if len(sentries) < 2 {
+		return nil
+	}
+	return NewAnnouncementFilter(AnnouncementFilterLimit, AnnouncementFilterLifetime)
+}
+
+// seen returns true if `hash` was announced via another sentry recently, otherwise remembers it as announced via `from`
+func (f *AnnouncementFilter) seen(hash common.Hash, from sentryproto.SentryClient) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if by, ok := f.lru.Get(hash); ok {
+		return by != from
+	}
+	f.lru.Add(hash, from)
+	return false
+}
+
+// SeenBlock - block announcement (NewBlockHashes, NewBlock) of `hash` via `from` is a duplicate
+func (f *AnnouncementFilter) SeenBlock(hash common.Hash, from sentryproto.SentryClient) bool {
+	if f == nil || !f.seen(hash, from) {
+		return false
+	}
+	mxBlockAnnouncementsSuppressed.Inc()
+	return true
+}
+
+// FilterTxnHashes returns concatenated 32-byte transaction hashes announced via `from` which didn't come via
+// another sentry recently. `hashes` is returned as is if nothing is filtered.
+func (f *AnnouncementFilter) FilterTxnHashes(hashes []byte, from sentryproto.SentryClient) []byte {
+	if f == nil {
+		return hashes
+	}
+	var res []byte
+	for i := 0; i < len(hashes); i += 32 {
+		if f.seen(common.BytesToHash(hashes[i:i+32]), from) {
+			mxTxnAnnouncementsSuppressed.Inc()
+			if res == nil {
+				res = append(make([]byte, 0, len(hashes)), hashes[:i]...)
+			}
+			continue
+		}
+		if res != nil {
+			res = append(res, hashes[i:i+32]...)
+		}
+	}
+	if res == nil {
+		return hashes
+	}
+	return res
+}
@@ -0,0 +1,38 @@
+package sentry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/p2p/sentry"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAnnouncementFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	a, b := direct.NewMockSentryClient(ctrl), direct.NewMockSentryClient(ctrl)
+
+	require.Nil(t, sentry.NewAnnouncementFilterFor([]sentryproto.SentryClient{a}))
+	var single *sentry.AnnouncementFilter
+	require.False(t, single.SeenBlock(common.Hash{1}, a))
+
+	f := sentry.NewAnnouncementFilter(16, time.Hour)
+	require.False(t, f.SeenBlock(common.Hash{1}, a))
+	require.False(t, f.SeenBlock(common.Hash{1}, a)) // same sentry, another peer
+	require.True(t, f.SeenBlock(common.Hash{1}, b))
+
+	h1, h2, h3 := common.Hash{1}, common.Hash{2}, common.Hash{3}
+	hashes := append(append(h2.Bytes(), h1.Bytes()...), h3.Bytes()...)
+	require.Equal(t, hashes, f.FilterTxnHashes(hashes, a))
+	require.Equal(t, append(h2.Bytes(), h3.Bytes()...), f.FilterTxnHashes(append(h2.Bytes(), h3.Bytes()...), a))
+	require.Empty(t, f.FilterTxnHashes(append(h2.Bytes(), h1.Bytes()...), b))
+
+	expiring := sentry.NewAnnouncementFilter(16, time.Millisecond)
+	require.False(t, expiring.SeenBlock(common.Hash{1}, a))
+	time.Sleep(5 * time.Millisecond)
+	require.False(t, expiring.SeenBlock(common.Hash{1}, b))
+}
@@ -1830,6 +1830,54 @@ func (dt *DomainRoTx) GetLatest(key []byte, roTx kv.Tx) ([]byte, uint64, bool, e
 	return v, endTxNum / dt.aggStep, foundInFile, nil
 }
 
+// getLatestUntilStep returns the latest value of key, ignoring the values of the steps after maxStep kept in db:
+// the value GetLatest returns once the domain is unwound to maxStep.
+func (dt *DomainRoTx) getLatestUntilStep(key []byte, maxStep uint64, roTx kv.Tx) ([]byte, bool, error) {
+	if dt.d.disable {
+		return nil, false, nil
+	}
+	invMaxStep := make([]byte, 8)
+	binary.BigEndian.PutUint64(invMaxStep, ^maxStep)
+
+	var v, foundInvStep []byte
+	if dt.d.largeValues {
+		c, err := roTx.Cursor(dt.d.valuesTable)
+		if err != nil {
+			return nil, false, err
+		}
+		defer c.Close()
+		fullkey, val, err := c.Seek(append(common.Copy(key), invMaxStep...))
+		if err != nil {
+			return nil, false, fmt.Errorf("valsCursor.Seek: %w", err)
+		}
+		if len(fullkey) > 0 && bytes.Equal(fullkey[:len(fullkey)-8], key) {
+			v, foundInvStep = val, fullkey[len(fullkey)-8:]
+		}
+	} else {
+		c, err := roTx.CursorDupSort(dt.d.valuesTable)
+		if err != nil {
+			return nil, false, err
+		}
+		defer c.Close()
+		stepWithVal, err := c.SeekBothRange(key, invMaxStep)
+		if err != nil {
+			return nil, false, fmt.Errorf("valsCursor.SeekBothRange: %w", err)
+		}
+		if len(stepWithVal) > 0 {
+			v, foundInvStep = stepWithVal[8:], stepWithVal[:8]
+		}
+	}
+	if foundInvStep != nil && lastTxNumOfStep(^binary.BigEndian.Uint64(foundInvStep), dt.aggStep) >= dt.files.EndTxNum() {
+		return common.Copy(v), true, nil
+	}
+
+	v, found, _, _, err := dt.getLatestFromFiles(key, 0)
+	if err != nil {
+		return nil, false, fmt.Errorf("getLatestFromFiles: %w", err)
+	}
+	return v, found, nil
+}
+
 // RangeAsOf - if key doesn't exists in history - then look in latest state
 func (dt *DomainRoTx) RangeAsOf(ctx context.Context, tx kv.Tx, fromKey, toKey []byte, ts uint64, asc order.By, limit int) (it stream.KV, err error) {
 	if !asc {
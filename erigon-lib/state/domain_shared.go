@@ -201,6 +201,34 @@ func (sd *SharedDomains) GetDiffset(tx kv.RwTx, blockHash common.Hash, blockNumb
 	return ReadDiffSet(tx, blockNumber, blockHash)
 }
 
+// UnwindInMemory reverts the changeset (diffsets of the blocks after blockNum, merged) in memory only: the values
+// read through sd become the ones of txNum, the first txnum after blockNum. Nothing is written to the db, the
+// commitment updates aren't touched.
+func (sd *SharedDomains) UnwindInMemory(tx kv.Tx, changeset *[kv.DomainLen][]kv.DomainEntryDiff, blockNum, txNum uint64) error {
+	aggTx := AggTx(tx)
+	for d, diffs := range changeset {
+		for i, diff := range diffs {
+			// key is suffixed by the inverted step of the change: the oldest change of a key is its last diff
+			key, stepBytes := diff.Key[:len(diff.Key)-8], diff.Key[len(diff.Key)-8:]
+			if next := i + 1; next < len(diffs) && diffs[next].Key[:len(diffs[next].Key)-8] == key {
+				continue
+			}
+			value := diff.Value
+			if stepBytes != string(diff.PrevStepBytes) {
+				// the value before the changes was written in an older step, the diff doesn't keep it
+				var err error
+				if value, _, err = aggTx.d[d].getLatestUntilStep(toBytesZeroCopy(key), ^binary.BigEndian.Uint64(diff.PrevStepBytes), tx); err != nil {
+					return fmt.Errorf("unwind %s in memory: %w", kv.Domain(d), err)
+				}
+			}
+			sd.put(kv.Domain(d), key, value, txNum)
+		}
+	}
+	sd.SetTxNum(txNum)
+	sd.SetBlockNum(blockNum)
+	return nil
+}
+
 func (sd *SharedDomains) ClearRam(resetCommitment bool) {
 	sd.muMaps.Lock()
 	defer sd.muMaps.Unlock()
@@ -213,6 +213,99 @@ Loop:
 	goto Loop
 }
 
+func TestSharedDomain_UnwindInMemory(t *testing.T) {
+	t.Parallel()
+
+	stepSize := uint64(5)
+	_db, agg := testDbAndAggregatorv3(t, stepSize)
+	db := wrapDbWithCtx(_db, agg)
+
+	ctx := context.Background()
+	rwTx, err := db.BeginTemporalRw(ctx)
+	require.NoError(t, err)
+	defer rwTx.Rollback()
+
+	domains, err := NewSharedDomains(rwTx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+
+	acc := func(nonce uint64) []byte {
+		return accounts3.SerialiseV3(&accounts3.Account{Nonce: nonce, Balance: *uint256.NewInt(nonce * 100)})
+	}
+	k0, k1, k2 := make([]byte, length.Addr), make([]byte, length.Addr), make([]byte, length.Addr)
+	k0[0], k1[0], k2[0] = 1, 2, 3
+	slot := composite(k0, make([]byte, length.Hash))
+
+	// block 1: k0, its storage slot and k2 are created
+	domains.SetTxNum(1)
+	require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, k0, acc(1), 1, nil, 0))
+	require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, k2, acc(1), 1, nil, 0))
+	require.NoError(t, domains.DomainPut(kv.StorageDomain, rwTx, slot, []byte{1}, 1, nil, 0))
+	require.NoError(t, domains.Flush(ctx, rwTx))
+
+	// blocks 2..4: k0 and the slot are updated in the step of block 1 and in the next ones, k2 only in a next
+	// step, k1 is created. Unwound, the values of k0 come from the changeset, the one of k2 from the db.
+	changeset := &StateChangeSet{}
+	domains.SetChangesetAccumulator(changeset)
+	for _, txNum := range []uint64{3, 7, 12} {
+		domains.SetTxNum(txNum)
+		pv, step, err := domains.GetLatest(kv.AccountsDomain, rwTx, k0)
+		require.NoError(t, err)
+		require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, k0, acc(txNum), txNum, pv, step))
+		pv, step, err = domains.GetLatest(kv.StorageDomain, rwTx, slot)
+		require.NoError(t, err)
+		require.NoError(t, domains.DomainPut(kv.StorageDomain, rwTx, slot, []byte{byte(txNum)}, txNum, pv, step))
+	}
+	pv, step, err := domains.GetLatest(kv.AccountsDomain, rwTx, k2)
+	require.NoError(t, err)
+	require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, k2, acc(7), 7, pv, step))
+	require.NoError(t, domains.DomainPut(kv.AccountsDomain, rwTx, k1, acc(12), 12, nil, 0))
+	domains.SetChangesetAccumulator(nil)
+	require.NoError(t, domains.Flush(ctx, rwTx))
+	domains.Close()
+	require.NoError(t, rwTx.Commit())
+
+	var diffs [kv.DomainLen][]kv.DomainEntryDiff
+	for idx, d := range changeset.Diffs {
+		diffs[idx] = d.GetDiffSet()
+	}
+
+	roTx, err := db.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer roTx.Rollback()
+
+	unwound, err := NewSharedDomains(roTx, log.New())
+	require.NoError(t, err)
+	defer unwound.Close()
+	require.NoError(t, unwound.UnwindInMemory(roTx, &diffs, 1, 2))
+	require.Equal(t, uint64(1), unwound.BlockNum())
+	require.Equal(t, uint64(2), unwound.TxNum())
+
+	v, _, err := unwound.GetLatest(kv.AccountsDomain, roTx, k0)
+	require.NoError(t, err)
+	require.Equal(t, acc(1), v)
+	v, _, err = unwound.GetLatest(kv.StorageDomain, roTx, slot)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, v)
+	v, _, err = unwound.GetLatest(kv.AccountsDomain, roTx, k2)
+	require.NoError(t, err)
+	require.Equal(t, acc(1), v)
+	v, _, err = unwound.GetLatest(kv.AccountsDomain, roTx, k1)
+	require.NoError(t, err)
+	require.Empty(t, v)
+
+	// the db keeps the latest state
+	latest, err := NewSharedDomains(roTx, log.New())
+	require.NoError(t, err)
+	defer latest.Close()
+	v, _, err = latest.GetLatest(kv.AccountsDomain, roTx, k0)
+	require.NoError(t, err)
+	require.Equal(t, acc(12), v)
+	v, _, err = latest.GetLatest(kv.AccountsDomain, roTx, k1)
+	require.NoError(t, err)
+	require.Equal(t, acc(12), v)
+}
+
 func composite(k, k2 []byte) []byte {
 	return append(common.Copy(k), k2...)
 }
@@ -37,8 +37,16 @@ func (ath *Authorization) copy() *Authorization {
 }
 
 func (ath *Authorization) RecoverSigner(data *bytes.Buffer, buf []byte) (*common.Address, error) {
+	if err := ath.encodeSigningPayload(data, buf); err != nil {
+		return nil, err
+	}
+	return RecoverSignerFromRLP(data.Bytes(), ath.YParity, ath.R, ath.S)
+}
+
+// encodeSigningPayload writes rlp([chainId, address, nonce]) - the payload signed by the authority
+func (ath *Authorization) encodeSigningPayload(data *bytes.Buffer, buf []byte) error {
 	if ath.Nonce == math.MaxUint64 {
-		return nil, errors.New("failed assertion: auth.nonce < 2**64 - 1")
+		return errors.New("failed assertion: auth.nonce < 2**64 - 1")
 	}
 
 	authLen := (1 + rlp.Uint256LenExcludingHead(&ath.ChainID))
@@ -46,56 +54,64 @@ func (ath *Authorization) RecoverSigner(data *bytes.Buffer, buf []byte) (*common
 	authLen += rlp.U64Len(ath.Nonce)
 
 	if err := rlp.EncodeStructSizePrefix(authLen, data, buf); err != nil {
-		return nil, err
+		return err
 	}
 
 	// chainId, address, nonce
 	if err := rlp.EncodeUint256(&ath.ChainID, data, buf); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := rlp.EncodeOptionalAddress(&ath.Address, data, buf); err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := rlp.EncodeInt(ath.Nonce, data, buf); err != nil {
+	return rlp.EncodeInt(ath.Nonce, data, buf)
+}
+
+func RecoverSignerFromRLP(rlp []byte, yParity uint8, r uint256.Int, s uint256.Int) (*common.Address, error) {
+	key, err := newAuthorityKey(rlp, yParity, &r, &s)
+	if err != nil {
 		return nil, err
 	}
+	if authority, ok := authoritiesCache.Get(key); ok {
+		return &authority, nil
+	}
 
-	return RecoverSignerFromRLP(data.Bytes(), ath.YParity, ath.R, ath.S)
+	pubKey, err := crypto.Ecrecover(key.hash[:], key.sig[:])
+	if err != nil {
+		return nil, err
+	}
+	authority, err := pubkeyToAddress(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	authoritiesCache.Add(key, authority)
+	return &authority, nil
 }
 
-func RecoverSignerFromRLP(rlp []byte, yParity uint8, r uint256.Int, s uint256.Int) (*common.Address, error) {
-	hashData := []byte{params.SetCodeMagicPrefix}
+// newAuthorityKey returns the signing hash and the signature of an authorization, or the error of an invalid signature
+func newAuthorityKey(rlp []byte, yParity uint8, r, s *uint256.Int) (authorityKey, error) {
+	var key authorityKey
+	hashData := make([]byte, 0, 1+len(rlp))
+	hashData = append(hashData, params.SetCodeMagicPrefix)
 	hashData = append(hashData, rlp...)
-	hash := crypto.Keccak256Hash(hashData)
+	key.hash = crypto.Keccak256Hash(hashData)
 
-	var sig [65]byte
 	rBytes := r.Bytes()
 	sBytes := s.Bytes()
-	copy(sig[32-len(rBytes):32], rBytes)
-	copy(sig[64-len(sBytes):64], sBytes)
+	copy(key.sig[32-len(rBytes):32], rBytes)
+	copy(key.sig[64-len(sBytes):64], sBytes)
 
 	if yParity > 1 {
-		return nil, fmt.Errorf("invalid y parity value: %d", yParity)
+		return key, fmt.Errorf("invalid y parity value: %d", yParity)
 	}
-	sig[64] = yParity
+	key.sig[64] = yParity
 
-	if !crypto.TransactionSignatureIsValid(sig[64], &r, &s, false /* allowPreEip2s */) {
-		return nil, errors.New("invalid signature")
+	if !crypto.TransactionSignatureIsValid(key.sig[64], r, s, false /* allowPreEip2s */) {
+		return key, errors.New("invalid signature")
 	}
-
-	pubKey, err := crypto.Ecrecover(hash.Bytes(), sig[:])
-	if err != nil {
-		return nil, err
-	}
-	if len(pubKey) == 0 || pubKey[0] != 4 {
-		return nil, errors.New("invalid public key")
-	}
-
-	var authority common.Address
-	copy(authority[:], crypto.Keccak256(pubKey[1:])[12:])
-	return &authority, nil
+	return key, nil
 }
 
 func authorizationSize(auth Authorization) (authLen int) {
@@ -0,0 +1,139 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/elastic/go-freelru"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+const (
+	// authoritiesCacheSize - recovered authorities kept by signature: the authorizations recovered by the pool are
+	// found when their transactions are executed, the repeated ones of a block are recovered once
+	authoritiesCacheSize = 16 * 1024
+)
+
+// authorityKey - signing hash and signature [R || S || V] of an authorization
+type authorityKey struct {
+	hash common.Hash
+	sig  [crypto.SignatureLength]byte
+}
+
+var authoritiesCache = func() *freelru.ShardedLRU[authorityKey, common.Address] {
+	c, err := freelru.NewSharded[authorityKey, common.Address](authoritiesCacheSize, func(k authorityKey) uint32 {
+		return binary.BigEndian.Uint32(k.hash[:4]) ^ binary.BigEndian.Uint32(k.sig[:4])
+	})
+	if err != nil {
+		panic(err)
+	}
+	return c
+}()
+
+// RecoverAuthorities returns the authorities of the authorizations, like RecoverSigner does for one of them, but
+// recovers the signatures missing in the cache by parallel workers (crypto.Ecrecover: libsecp256k1 in cgo builds).
+// errs[i] is set if the authority of auths[i] can't be recovered.
+func RecoverAuthorities(auths []Authorization) (authorities []common.Address, errs []error) {
+	authorities, errs = make([]common.Address, len(auths)), make([]error, len(auths))
+
+	var (
+		data    bytes.Buffer
+		buf     [32]byte
+		keys    []authorityKey
+		missing = make(map[authorityKey][]int) // indices of the authorizations of every signature to recover
+	)
+	for i := range auths {
+		data.Reset()
+		if errs[i] = auths[i].encodeSigningPayload(&data, buf[:]); errs[i] != nil {
+			continue
+		}
+		key, err := newAuthorityKey(data.Bytes(), auths[i].YParity, &auths[i].R, &auths[i].S)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		if authority, ok := authoritiesCache.Get(key); ok {
+			authorities[i] = authority
+			continue
+		}
+		if _, ok := missing[key]; !ok {
+			keys = append(keys, key)
+		}
+		missing[key] = append(missing[key], i)
+	}
+	if len(keys) == 0 {
+		return authorities, errs
+	}
+
+	recovered, recoverErrs := make([]common.Address, len(keys)), make([]error, len(keys))
+	recoverKey := func(k int) {
+		pub, err := crypto.Ecrecover(keys[k].hash[:], keys[k].sig[:])
+		if err != nil {
+			recoverErrs[k] = err
+			return
+		}
+		if recovered[k], recoverErrs[k] = pubkeyToAddress(pub); recoverErrs[k] == nil {
+			authoritiesCache.Add(keys[k], recovered[k])
+		}
+	}
+	if workers := min(len(keys), runtime.GOMAXPROCS(0)); workers <= 1 {
+		for k := range keys {
+			recoverKey(k)
+		}
+	} else {
+		var next atomic.Int64
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for k := int(next.Add(1) - 1); k < len(keys); k = int(next.Add(1) - 1) {
+					recoverKey(k)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	for k, key := range keys {
+		for _, i := range missing[key] {
+			authorities[i], errs[i] = recovered[k], recoverErrs[k]
+		}
+	}
+	return authorities, errs
+}
+
+// RecoverBlockAuthorities recovers the authorities of all the authorizations of the set-code transactions into the
+// cache, so that their execution finds them
+func RecoverBlockAuthorities(txns []Transaction) {
+	var auths []Authorization
+	for _, txn := range txns {
+		if setCode, ok := txn.(*SetCodeTransaction); ok {
+			auths = append(auths, setCode.GetAuthorizations()...)
+		}
+	}
+	if len(auths) > 0 {
+		RecoverAuthorities(auths)
+	}
+}
@@ -18,10 +18,15 @@ package types
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 
-	"github.com/erigontech/erigon-lib/common"
 	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
 )
 
 // Tests that the correct signer is recovered from an Authorization object
@@ -49,3 +54,81 @@ func TestRecoverSigner(t *testing.T) {
 	}
 
 }
+
+func TestRecoverAuthorities(t *testing.T) {
+	t.Parallel()
+
+	const n = 197 // several workers
+	auths, want := signedAuthorizations(t, n)
+	auths = append(auths, auths[7]) // repeated authorization
+	want = append(want, want[7])
+	invalid := auths[0]
+	invalid.YParity = 2
+	auths = append(auths, invalid)
+
+	authorities, errs := RecoverAuthorities(auths)
+	for i := 0; i < n+1; i++ {
+		require.NoError(t, errs[i], i)
+		require.Equal(t, want[i], authorities[i], i)
+
+		authority, err := auths[i].RecoverSigner(bytes.NewBuffer(nil), make([]byte, 32))
+		require.NoError(t, err)
+		require.Equal(t, want[i], *authority)
+	}
+	require.Error(t, errs[n+1])
+
+	// the second time all of them are cached
+	authorities, errs = RecoverAuthorities(auths[:n])
+	for i := 0; i < n; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, want[i], authorities[i])
+	}
+}
+
+func signedAuthorizations(tb testing.TB, n int) (auths []Authorization, authorities []common.Address) {
+	for i := 0; i < n; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(tb, err)
+		auth := Authorization{ChainID: *uint256.NewInt(1), Address: common.Address{byte(i)}, Nonce: uint64(i)}
+		var data bytes.Buffer
+		require.NoError(tb, auth.encodeSigningPayload(&data, make([]byte, 32)))
+		sig, err := crypto.Sign(crypto.Keccak256(append([]byte{params.SetCodeMagicPrefix}, data.Bytes()...)), key)
+		require.NoError(tb, err)
+		auth.R.SetBytes(sig[:32])
+		auth.S.SetBytes(sig[32:64])
+		auth.YParity = sig[64]
+		auths = append(auths, auth)
+		authorities = append(authorities, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return auths, authorities
+}
+
+// BenchmarkRecoverAuthorities - recovery of the authorizations of a block missing in the cache: one by one (as
+// before RecoverAuthorities) and by RecoverAuthorities
+func BenchmarkRecoverAuthorities(b *testing.B) {
+	for _, n := range []int{1, 8, 64, 512} {
+		auths, _ := signedAuthorizations(b, n)
+		b.Run(fmt.Sprintf("n=%d/sequential", n), func(b *testing.B) {
+			data, buf := bytes.NewBuffer(nil), make([]byte, 32)
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				authoritiesCache.Purge()
+				b.StartTimer()
+				for j := range auths {
+					data.Reset()
+					if _, err := auths[j].RecoverSigner(data, buf); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("n=%d/RecoverAuthorities", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				authoritiesCache.Purge()
+				b.StartTimer()
+				RecoverAuthorities(auths)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/holiman/uint256"
+)
+
+// SignerScheme - replay protection of the legacy transactions of a chain which doesn't follow EIP-155: how V of the
+// signature encodes the chain id and the recovery id. The signing hash of a protected transaction is the EIP-155
+// one, of an unprotected one is the Homestead one.
+//
+// A scheme registered for a chain id by RegisterSignerScheme is used by the signers of MakeSigner, LatestSigner and
+// LatestSignerForChainID and by the txpool parser, so the stages, the txpool and the RPC agree on the senders.
+type SignerScheme interface {
+	// DecodeLegacyV returns the chain id, nil if the signature is unprotected, and the recovery id (0 or 1) of V
+	DecodeLegacyV(v *uint256.Int) (chainID *uint256.Int, recoveryID byte, err error)
+	// EncodeLegacyV returns V of the signature with the recovery id, unprotected if chainID is nil
+	EncodeLegacyV(chainID *uint256.Int, recoveryID byte) *uint256.Int
+	// Protected - whether the new transactions of the chain are signed with the chain id
+	Protected() bool
+}
+
+var ErrUnsupportedLegacyV = errors.New("v of legacy txn is not supported by signer scheme")
+
+var (
+	signerSchemesLock sync.RWMutex
+	signerSchemes     = map[uint64]SignerScheme{}
+)
+
+// RegisterSignerScheme sets the scheme of the legacy transactions of the chain, it must be called before the
+// signers of the chain are made, e.g. in init. A nil scheme restores EIP-155.
+func RegisterSignerScheme(chainID uint64, s SignerScheme) {
+	signerSchemesLock.Lock()
+	defer signerSchemesLock.Unlock()
+	if s == nil {
+		delete(signerSchemes, chainID)
+		return
+	}
+	signerSchemes[chainID] = s
+}
+
+// SignerSchemeOf returns the scheme registered for the chain, nil for EIP-155
+func SignerSchemeOf(chainID *uint256.Int) SignerScheme {
+	if chainID == nil || !chainID.IsUint64() {
+		return nil
+	}
+	signerSchemesLock.RLock()
+	defer signerSchemesLock.RUnlock()
+	return signerSchemes[chainID.Uint64()]
+}
+
+// LegacyChainID returns the chain id of the signature of the legacy transaction on the chain, nil if it's
+// unprotected. V is decoded by the scheme registered for the chain, by EIP-155 otherwise.
+func LegacyChainID(chainID *big.Int, txn *LegacyTx) *uint256.Int {
+	if chainID != nil {
+		id, overflow := uint256.FromBig(chainID)
+		if s := SignerSchemeOf(id); s != nil && !overflow {
+			txnChainID, _, err := s.DecodeLegacyV(&txn.V)
+			if err != nil {
+				return nil
+			}
+			return txnChainID
+		}
+	}
+	if !txn.Protected() {
+		return nil
+	}
+	return DeriveChainId(&txn.V)
+}
+
+// PreEIP155Scheme - chains without replay protection: V is 27 or 28, whatever the fork
+type PreEIP155Scheme struct{}
+
+func (PreEIP155Scheme) DecodeLegacyV(v *uint256.Int) (*uint256.Int, byte, error) {
+	if !v.IsUint64() || (v.Uint64() != 27 && v.Uint64() != 28) {
+		return nil, 0, ErrUnsupportedLegacyV
+	}
+	return nil, byte(v.Uint64() - 27), nil
+}
+
+func (PreEIP155Scheme) EncodeLegacyV(_ *uint256.Int, recoveryID byte) *uint256.Int {
+	return uint256.NewInt(27 + uint64(recoveryID))
+}
+
+func (PreEIP155Scheme) Protected() bool { return false }
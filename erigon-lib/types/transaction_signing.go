@@ -93,6 +93,7 @@ func MakeSigner(config *chain.Config, blockNumber uint64, blockTime uint64) *Sig
 		// Only allow malleable transactions in Frontier
 		signer.malleable = true
 	}
+	signer.scheme = SignerSchemeOf(&chainId)
 	return &signer
 }
 
@@ -138,6 +139,7 @@ func LatestSigner(config *chain.Config) *Signer {
 		if config.Bor != nil && config.Bor.GetBhilaiBlock() != nil {
 			signer.setCode = true
 		}
+		signer.scheme = SignerSchemeOf(chainId)
 	}
 	return &signer
 }
@@ -166,12 +168,13 @@ func LatestSignerForChainID(chainID *big.Int) *Signer {
 	signer.dynamicFee = true
 	signer.blob = true
 	signer.setCode = true
+	signer.scheme = SignerSchemeOf(chainId)
 	return &signer
 }
 
 // SignTx signs the transaction using the given signer and private key.
 func SignTx(txn Transaction, s Signer, prv *ecdsa.PrivateKey) (Transaction, error) {
-	h := txn.SigningHash(s.chainID.ToBig())
+	h := txn.SigningHash(s.signingChainID(txn))
 	sig, err := crypto.Sign(h[:], prv)
 	if err != nil {
 		return nil, err
@@ -181,7 +184,7 @@ func SignTx(txn Transaction, s Signer, prv *ecdsa.PrivateKey) (Transaction, erro
 
 // SignNewTx creates a transaction and signs it.
 func SignNewTx(prv *ecdsa.PrivateKey, s Signer, txn Transaction) (Transaction, error) {
-	h := txn.SigningHash(s.chainID.ToBig())
+	h := txn.SigningHash(s.signingChainID(txn))
 	sig, err := crypto.Sign(h[:], prv)
 	if err != nil {
 		return nil, err
@@ -214,6 +217,8 @@ type Signer struct {
 	dynamicFee          bool // Whether this signer should allow transactions with base fee and tip (instead of gasprice), supersedes accessList
 	blob                bool // Whether this signer should allow blob transactions
 	setCode             bool // Whether this signer should allow set code transactions
+
+	scheme SignerScheme // V of legacy transactions of chains not following EIP-155, see RegisterSignerScheme
 }
 
 func (sg Signer) String() string {
@@ -228,39 +233,104 @@ func (sg Signer) Sender(tx Transaction) (common.Address, error) {
 
 // SenderWithContext returns the sender address of the transaction.
 func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction) (common.Address, error) {
-	var V uint256.Int
-	var R, S *uint256.Int
+	if _, ok := txn.(*AccountAbstractionTransaction); ok {
+		return txn.Sender(Signer{})
+	}
+	sighash, R, S, V, err := sg.recoveryValues(txn)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return recoverPlain(context, sighash, R, S, V, !sg.malleable)
+}
+
+// SendersBatch returns sender addresses of the transactions, recovered with crypto.EcrecoverBatch. On error `failed`
+// is the index of the transaction which sender can't be recovered.
+func (sg Signer) SendersBatch(txns []Transaction) (senders []common.Address, failed int, err error) {
+	senders = make([]common.Address, len(txns))
+	hashes, sigs := make([][]byte, 0, len(txns)), make([][]byte, 0, len(txns))
+	idx := make([]int, 0, len(txns)) // txns[idx[j]] has signature sigs[j]
+	for i, txn := range txns {
+		if _, ok := txn.(*AccountAbstractionTransaction); ok {
+			if senders[i], err = txn.Sender(Signer{}); err != nil {
+				return nil, i, err
+			}
+			continue
+		}
+		sighash, R, S, V, err := sg.recoveryValues(txn)
+		if err != nil {
+			return nil, i, err
+		}
+		sig, err := plainSignature(R, S, V, !sg.malleable)
+		if err != nil {
+			return nil, i, err
+		}
+		hashes, sigs, idx = append(hashes, sighash[:]), append(sigs, sig), append(idx, i)
+	}
+	pubs, errs := crypto.EcrecoverBatch(hashes, sigs)
+	for j, i := range idx {
+		if errs[j] != nil {
+			return nil, i, errs[j]
+		}
+		if senders[i], err = pubkeyToAddress(pubs[j]); err != nil {
+			return nil, i, err
+		}
+	}
+	return senders, 0, nil
+}
+
+// recoveryValues - signing hash and signature of the transaction as expected by recoverPlain: V is 27 or 28
+func (sg Signer) recoveryValues(txn Transaction) (sighash common.Hash, R, S, V *uint256.Int, err error) {
+	V = new(uint256.Int)
 	signChainID := sg.chainID.ToBig() // This is reset to nil if txn is unprotected
 	// recoverPlain below will subtract 27 from V
 	switch t := txn.(type) {
 	case *LegacyTx:
-		if !t.Protected() {
+		if sg.scheme != nil {
+			chainID, recoveryID, err := sg.scheme.DecodeLegacyV(&t.V)
+			if err != nil {
+				return common.Hash{}, nil, nil, nil, err
+			}
+			if chainID == nil {
+				if !sg.unprotected {
+					return common.Hash{}, nil, nil, nil, fmt.Errorf("unprotected txn is not supported by signer %s", sg)
+				}
+				signChainID = nil
+			} else {
+				if !sg.protected {
+					return common.Hash{}, nil, nil, nil, fmt.Errorf("protected txn is not supported by signer %s", sg)
+				}
+				if !chainID.Eq(&sg.chainID) {
+					return common.Hash{}, nil, nil, nil, ErrInvalidChainId
+				}
+			}
+			V.SetUint64(27 + uint64(recoveryID))
+		} else if !t.Protected() {
 			if !sg.unprotected {
-				return common.Address{}, fmt.Errorf("unprotected txn is not supported by signer %s", sg)
+				return common.Hash{}, nil, nil, nil, fmt.Errorf("unprotected txn is not supported by signer %s", sg)
 			}
 			signChainID = nil
 			V.Set(&t.V)
 		} else {
 			if !sg.protected {
-				return common.Address{}, fmt.Errorf("protected txn is not supported by signer %s", sg)
+				return common.Hash{}, nil, nil, nil, fmt.Errorf("protected txn is not supported by signer %s", sg)
 			}
 			if !DeriveChainId(&t.V).Eq(&sg.chainID) {
-				return common.Address{}, ErrInvalidChainId
+				return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 			}
 			V.Sub(&t.V, &sg.chainIDMul)
-			V.Sub(&V, u256.Num8)
+			V.Sub(V, u256.Num8)
 		}
 		R, S = &t.R, &t.S
 	case *AccessListTx:
 		if !sg.accessList {
-			return common.Address{}, fmt.Errorf("accessList txn is not supported by signer %s", sg)
+			return common.Hash{}, nil, nil, nil, fmt.Errorf("accessList txn is not supported by signer %s", sg)
 		}
 		if t.ChainID == nil {
 			if !sg.chainID.IsZero() {
-				return common.Address{}, ErrInvalidChainId
+				return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 			}
 		} else if !t.ChainID.Eq(&sg.chainID) {
-			return common.Address{}, ErrInvalidChainId
+			return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 		}
 		// ACL txs are defined to use 0 and 1 as their recovery id, add
 		// 27 to become equivalent to unprotected Homestead signatures.
@@ -268,14 +338,14 @@ func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction)
 		R, S = &t.R, &t.S
 	case *DynamicFeeTransaction:
 		if !sg.dynamicFee {
-			return common.Address{}, fmt.Errorf("dynamicFee txn is not supported by signer %s", sg)
+			return common.Hash{}, nil, nil, nil, fmt.Errorf("dynamicFee txn is not supported by signer %s", sg)
 		}
 		if t.ChainID == nil {
 			if !sg.chainID.IsZero() {
-				return common.Address{}, ErrInvalidChainId
+				return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 			}
 		} else if !t.ChainID.Eq(&sg.chainID) {
-			return common.Address{}, ErrInvalidChainId
+			return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 		}
 		// ACL and DynamicFee txs are defined to use 0 and 1 as their recovery
 		// id, add 27 to become equivalent to unprotected Homestead signatures.
@@ -283,14 +353,14 @@ func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction)
 		R, S = &t.R, &t.S
 	case *BlobTx:
 		if !sg.blob {
-			return common.Address{}, fmt.Errorf("blob txn is not supported by signer %s", sg)
+			return common.Hash{}, nil, nil, nil, fmt.Errorf("blob txn is not supported by signer %s", sg)
 		}
 		if t.ChainID == nil {
 			if !sg.chainID.IsZero() {
-				return common.Address{}, ErrInvalidChainId
+				return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 			}
 		} else if !t.ChainID.Eq(&sg.chainID) {
-			return common.Address{}, ErrInvalidChainId
+			return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 		}
 		// ACL, DynamicFee, and blob txs are defined to use 0 and 1 as their recovery
 		// id, add 27 to become equivalent to unprotected Homestead signatures.
@@ -298,25 +368,23 @@ func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction)
 		R, S = &t.R, &t.S
 	case *SetCodeTransaction:
 		if !sg.setCode {
-			return common.Address{}, fmt.Errorf("setCode tx is not supported by signer %s", sg)
+			return common.Hash{}, nil, nil, nil, fmt.Errorf("setCode tx is not supported by signer %s", sg)
 		}
 		if t.ChainID == nil {
 			if !sg.chainID.IsZero() {
-				return common.Address{}, ErrInvalidChainId
+				return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 			}
 		} else if !t.ChainID.Eq(&sg.chainID) {
-			return common.Address{}, ErrInvalidChainId
+			return common.Hash{}, nil, nil, nil, ErrInvalidChainId
 		}
 		// ACL, DynamicFee, blob, and setCode txs are defined to use 0 and 1 as their recovery
 		// id, add 27 to become equivalent to unprotected Homestead signatures.
 		V.Add(&t.V, u256.Num27)
 		R, S = &t.R, &t.S
-	case *AccountAbstractionTransaction:
-		return txn.Sender(Signer{})
 	default:
-		return common.Address{}, ErrTxTypeNotSupported
+		return common.Hash{}, nil, nil, nil, ErrTxTypeNotSupported
 	}
-	return recoverPlain(context, txn.SigningHash(signChainID), R, S, &V, !sg.malleable)
+	return txn.SigningHash(signChainID), R, S, V, nil
 }
 
 // SignatureValues returns the raw R, S, V values corresponding to the
@@ -325,7 +393,13 @@ func (sg Signer) SignatureValues(txn Transaction, sig []byte) (R, S, V *uint256.
 	switch t := txn.(type) {
 	case *LegacyTx:
 		R, S, V = decodeSignature(sig)
-		if sg.chainID.IsZero() {
+		if sg.scheme != nil {
+			var chainID *uint256.Int
+			if sg.scheme.Protected() && !sg.chainID.IsZero() {
+				chainID = &sg.chainID
+			}
+			V = sg.scheme.EncodeLegacyV(chainID, sig[64])
+		} else if sg.chainID.IsZero() {
 			V.Add(V, u256.Num27)
 		} else {
 			V.Add(V, u256.Num35)
@@ -349,7 +423,17 @@ func (sg Signer) ChainID() *uint256.Int {
 	return &sg.chainID
 }
 
-// Equal returns true if the given signer is the same as the receiver.
+// signingChainID returns the chain id of the signing hash of the transaction: nil for a legacy one of a chain which
+// signer scheme isn't protected
+func (sg Signer) signingChainID(txn Transaction) *big.Int {
+	if _, ok := txn.(*LegacyTx); ok && sg.scheme != nil && !sg.scheme.Protected() {
+		return nil
+	}
+	return sg.chainID.ToBig()
+}
+
+// Equal returns true if the given signer is the same as the receiver. Signer schemes are registered per
+// chain id, so they aren't compared themselves: they may be values of uncomparable types.
 func (sg Signer) Equal(other Signer) bool {
 	return sg.chainID.Eq(&other.chainID) &&
 		sg.malleable == other.malleable &&
@@ -358,7 +442,8 @@ func (sg Signer) Equal(other Signer) bool {
 		sg.accessList == other.accessList &&
 		sg.dynamicFee == other.dynamicFee &&
 		sg.blob == other.blob &&
-		sg.setCode == other.setCode
+		sg.setCode == other.setCode &&
+		(sg.scheme == nil) == (other.scheme == nil)
 }
 
 func decodeSignature(sig []byte) (r, s, v *uint256.Int) {
@@ -372,24 +457,36 @@ func decodeSignature(sig []byte) (r, s, v *uint256.Int) {
 }
 
 func recoverPlain(context *secp256k1.Context, sighash common.Hash, R, S, Vb *uint256.Int, homestead bool) (common.Address, error) {
+	sig, err := plainSignature(R, S, Vb, homestead)
+	if err != nil {
+		return common.Address{}, err
+	}
+	// recover the public key from the signature
+	pub, err := crypto.EcrecoverWithContext(context, sighash[:], sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return pubkeyToAddress(pub)
+}
+
+// plainSignature - validates and encodes the signature in uncompressed format [R || S || V], V is 0 or 1
+func plainSignature(R, S, Vb *uint256.Int, homestead bool) ([]byte, error) {
 	if Vb.BitLen() > 8 {
-		return common.Address{}, ErrInvalidSig
+		return nil, ErrInvalidSig
 	}
 	V := byte(Vb.Uint64() - 27)
 	if !crypto.TransactionSignatureIsValid(V, R, S, !homestead) {
-		return common.Address{}, ErrInvalidSig
+		return nil, ErrInvalidSig
 	}
-	// encode the signature in uncompressed format
 	r, s := R.Bytes(), S.Bytes()
 	sig := make([]byte, crypto.SignatureLength)
 	copy(sig[32-len(r):32], r)
 	copy(sig[64-len(s):64], s)
 	sig[64] = V
-	// recover the public key from the signature
-	pub, err := crypto.EcrecoverWithContext(context, sighash[:], sig)
-	if err != nil {
-		return common.Address{}, err
-	}
+	return sig, nil
+}
+
+func pubkeyToAddress(pub []byte) (common.Address, error) {
 	if len(pub) == 0 || pub[0] != 4 {
 		return common.Address{}, errors.New("invalid public key")
 	}
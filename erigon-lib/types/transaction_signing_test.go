@@ -51,6 +51,44 @@ func TestEIP1559Signing(t *testing.T) {
 	}
 }
 
+func TestSendersBatch(t *testing.T) {
+	t.Parallel()
+	chainId := uint256.NewInt(18)
+	signer := LatestSignerForChainID(chainId.ToBig())
+
+	var txns []Transaction
+	var addrs []common.Address
+	for i := 0; i < 8; i++ {
+		key, _ := crypto.GenerateKey()
+		addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+		var unsigned Transaction = NewTransaction(uint64(i), common.Address{}, new(uint256.Int), 0, new(uint256.Int), nil)
+		if i%2 == 1 {
+			unsigned = NewEIP1559Transaction(*chainId, uint64(i), common.Address{}, new(uint256.Int), 0, new(uint256.Int), new(uint256.Int), new(uint256.Int), nil)
+		}
+		txn, err := SignTx(unsigned, *signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txns = append(txns, txn)
+	}
+
+	senders, _, err := signer.SendersBatch(txns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range txns {
+		if senders[i] != addrs[i] {
+			t.Errorf("txn %d: got sender %x want %x", i, senders[i], addrs[i])
+		}
+	}
+
+	// signed for another chain
+	otherSigner := LatestSignerForChainID(big.NewInt(19))
+	if _, failed, err := otherSigner.SendersBatch(txns); err == nil || failed != 0 {
+		t.Errorf("expected error for txn 0, got failed=%d, err=%v", failed, err)
+	}
+}
+
 func TestEIP155Signing(t *testing.T) {
 	t.Parallel()
 	key, _ := crypto.GenerateKey()
@@ -165,3 +203,112 @@ func TestChainId(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+// offsetScheme - V = ChainID * 2 + 1000 + recoveryID for protected signatures
+type offsetScheme struct{}
+
+func (offsetScheme) DecodeLegacyV(v *uint256.Int) (*uint256.Int, byte, error) {
+	if v.Eq(uint256.NewInt(27)) || v.Eq(uint256.NewInt(28)) {
+		return nil, byte(v.Uint64() - 27), nil
+	}
+	if v.LtUint64(1000) {
+		return nil, 0, ErrUnsupportedLegacyV
+	}
+	chainID := new(uint256.Int).SubUint64(v, 1000)
+	recoveryID := byte(chainID.Uint64() % 2)
+	return chainID.Rsh(chainID, 1), recoveryID, nil
+}
+
+func (offsetScheme) EncodeLegacyV(chainID *uint256.Int, recoveryID byte) *uint256.Int {
+	if chainID == nil {
+		return uint256.NewInt(27 + uint64(recoveryID))
+	}
+	v := new(uint256.Int).Lsh(chainID, 1)
+	return v.AddUint64(v, 1000+uint64(recoveryID))
+}
+
+func (offsetScheme) Protected() bool { return true }
+
+func TestSignerScheme(t *testing.T) {
+	t.Parallel()
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	newTxn := func() Transaction {
+		return NewTransaction(0, addr, new(uint256.Int), 0, new(uint256.Int), nil)
+	}
+
+	chainID := big.NewInt(4242)
+	eip155Signer := LatestSignerForChainID(chainID)
+	eip155Txn, err := SignTx(newTxn(), *eip155Signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterSignerScheme(chainID.Uint64(), offsetScheme{})
+	t.Cleanup(func() { RegisterSignerScheme(chainID.Uint64(), nil) })
+
+	signer := LatestSignerForChainID(chainID)
+	if signer.Equal(*eip155Signer) {
+		t.Fatal("expected signers of the schemes to differ")
+	}
+	txn, err := SignTx(newTxn(), *signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _, _ := txn.RawSignatureValues()
+	if v.Uint64() != 2*4242+1000 && v.Uint64() != 2*4242+1001 {
+		t.Fatalf("expected V of the scheme, got %d", v)
+	}
+	if id := LegacyChainID(chainID, txn.(*LegacyTx)); id == nil || id.Uint64() != 4242 {
+		t.Fatalf("expected chain id 4242, got %v", id)
+	}
+	from, err := txn.Sender(*signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+	if _, err = signer.Sender(eip155Txn); err == nil {
+		t.Error("expected EIP-155 signature to be rejected by the scheme")
+	}
+
+	// pre EIP-155 only
+	preEIP155ChainID := big.NewInt(4343)
+	RegisterSignerScheme(preEIP155ChainID.Uint64(), PreEIP155Scheme{})
+	t.Cleanup(func() { RegisterSignerScheme(preEIP155ChainID.Uint64(), nil) })
+	signer = LatestSignerForChainID(preEIP155ChainID)
+	txn, err = SignTx(newTxn(), *signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if LegacyChainID(preEIP155ChainID, txn.(*LegacyTx)) != nil {
+		t.Error("expected unprotected signature")
+	}
+	from, err = signer.Sender(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+}
+
+// uncomparableScheme can't be compared with ==, Signer.Equal must not panic on it
+type uncomparableScheme struct {
+	offsetScheme
+	_ []byte
+}
+
+func TestSignerSchemeEqual(t *testing.T) {
+	t.Parallel()
+	chainID := big.NewInt(4444)
+	RegisterSignerScheme(chainID.Uint64(), uncomparableScheme{})
+	t.Cleanup(func() { RegisterSignerScheme(chainID.Uint64(), nil) })
+
+	if !LatestSignerForChainID(chainID).Equal(*LatestSignerForChainID(chainID)) {
+		t.Fatal("expected signers of the same chain to be equal")
+	}
+	if LatestSignerForChainID(chainID).Equal(*LatestSignerForChainID(big.NewInt(4445))) {
+		t.Fatal("expected signers of the chains to differ")
+	}
+}
@@ -42,7 +42,6 @@ import (
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/erigontech/erigon-db/downloader"
@@ -104,6 +103,7 @@ import (
 	"github.com/erigontech/erigon/execution/consensus/merge"
 	"github.com/erigontech/erigon/execution/engineapi"
 	"github.com/erigontech/erigon/execution/engineapi/engine_block_downloader"
+	"github.com/erigontech/erigon/execution/engineapi/engine_dev_cl"
 	"github.com/erigontech/erigon/execution/engineapi/engine_helpers"
 	"github.com/erigontech/erigon/execution/eth1"
 	"github.com/erigontech/erigon/execution/eth1/eth1_chain_reader"
@@ -127,12 +127,17 @@ import (
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/contracts"
 	"github.com/erigontech/erigon/rpc/jsonrpc"
+	"github.com/erigontech/erigon/rpc/jsonrpc/receipts"
 	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/diskforecast"
+	"github.com/erigontech/erigon/turbo/execsink"
+	"github.com/erigontech/erigon/turbo/maintenance"
 	privateapi2 "github.com/erigontech/erigon/turbo/privateapi"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/shards"
 	"github.com/erigontech/erigon/turbo/silkworm"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+	"github.com/erigontech/erigon/turbo/transactions"
 	"github.com/erigontech/erigon/txnprovider"
 	"github.com/erigontech/erigon/txnprovider/shutter"
 	"github.com/erigontech/erigon/txnprovider/txpool"
@@ -187,6 +192,8 @@ type Ethereum struct {
 	sentriesClient *sentry_multi_client.MultiClient
 	sentryServers  []*sentry.GrpcServer
 
+	earliestServedBlock uint64 // to the peers, the earlier blocks are not served
+
 	stagedSync         *stagedsync.Sync
 	pipelineStagedSync *stagedsync.Sync
 	syncStages         []*stagedsync.Stage
@@ -199,6 +206,13 @@ type Ethereum struct {
 
 	unsubscribeEthstat func()
 
+	grpcSecurity *grpcutil.Security // TLS and token auth of private gRPC interfaces: remote kv, sentries, downloader
+
+	execSink            *execsink.Sink
+	unsubscribeExecSink func()
+
+	maintenance *maintenance.Scheduler
+
 	waitForStageLoopStop chan struct{}
 	waitForMiningStop    chan struct{}
 
@@ -393,6 +407,12 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		panic(err)
 	}
 	chainConfig.AllowAA = config.AllowAA
+	if config.OpcodeOverridesFile != "" {
+		if chainConfig.OpcodeOverrides, err = vm.LoadOpcodeOverrides(config.OpcodeOverridesFile); err != nil {
+			return nil, err
+		}
+		logger.Warn("Opcode overrides loaded: the chain diverges from its spec", "file", config.OpcodeOverridesFile, "overrides", len(chainConfig.OpcodeOverrides))
+	}
 	backend.chainConfig = chainConfig
 	backend.genesisBlock = genesis
 	backend.genesisHash = genesis.Hash()
@@ -430,6 +450,9 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 
 	kvRPC := remotedbserver.NewKvServer(ctx, backend.chainDB, allSnapshots, allBorSnapshots, agg, logger)
 	backend.notifications = shards.NewNotifications(kvRPC)
+	if config.Sync.StateGrowthWindow > 0 {
+		backend.notifications.StateGrowth = shards.NewStateGrowthTracker(config.Sync.StateGrowthWindow)
+	}
 	backend.kvRPC = kvRPC
 
 	backend.gasPrice, _ = uint256.FromBig(config.Miner.GasPrice)
@@ -445,11 +468,16 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		}
 	}
 
+	backend.grpcSecurity, err = privateGrpcSecurity(stack.Config())
+	if err != nil {
+		return nil, err
+	}
+
 	p2pConfig := stack.Config().P2P
 	var sentries []protosentry.SentryClient
 	if len(p2pConfig.SentryAddr) > 0 {
 		for _, addr := range p2pConfig.SentryAddr {
-			sentryClient, err := sentry_multi_client.GrpcClient(backend.sentryCtx, addr)
+			sentryClient, err := sentry_multi_client.GrpcClient(backend.sentryCtx, addr, backend.grpcSecurity)
 			if err != nil {
 				return nil, err
 			}
@@ -483,7 +511,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		silkwormSentryService := silkworm.NewSentryService(backend.silkworm, settings)
 		backend.silkwormSentryService = &silkwormSentryService
 
-		sentryClient, err := sentry_multi_client.GrpcClient(backend.sentryCtx, apiAddr)
+		sentryClient, err := sentry_multi_client.GrpcClient(backend.sentryCtx, apiAddr, nil /* in-process */)
 		if err != nil {
 			return nil, err
 		}
@@ -578,6 +606,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	go mem.LogMemStats(ctx, logger)
 	go disk.UpdateDiskStats(ctx, logger)
 	go dbg.SaveHeapProfileNearOOMPeriodically(ctx, dbg.SaveHeapWithLogger(&logger))
+	if config.Watchdog.Enabled() {
+		watchdog, err := mem.NewWatchdog(config.Watchdog, logger)
+		if err != nil {
+			return nil, err
+		}
+		go watchdog.Run(ctx)
+	}
 	go kv.CollectTableSizesPeriodically(ctx, backend.chainDB, kv.ChainDB, logger)
 
 	var currentBlock *types.Block
@@ -685,11 +720,19 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	}
 	backend.forkValidator = engine_helpers.NewForkValidator(ctx, currentBlockNumber, inMemoryExecution, tmpdir, backend.blockReader)
 
+	if config.NoPreMergeHistory {
+		if chainConfig.MergeHeight != nil {
+			backend.earliestServedBlock = chainConfig.MergeHeight.Uint64()
+		} else {
+			logger.Warn("Serving the pre-merge history: merge block of the chain unknown")
+		}
+	}
 	statusDataProvider := sentry.NewStatusDataProvider(
 		backend.chainDB,
 		chainConfig,
 		genesis,
 		backend.config.NetworkID,
+		backend.earliestServedBlock,
 		logger,
 	)
 
@@ -761,6 +804,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			default:
 			}
 		}
+		txPoolOpts := []txpool.Option{
+			txpool.WithTxnSimulator(transactions.NewTxnSimulator(backend.chainDB, backend.engine, blockReader, chainConfig)),
+		}
+		if chainConfig.Aura != nil {
+			// only AuRa certifies service txns, the other engines don't have them
+			txPoolOpts = append(txPoolOpts, txpool.WithServiceTxnChecker(transactions.NewServiceTxnChecker(backend.chainDB, backend.engine, blockReader, chainConfig)))
+		}
 		backend.txPool, backend.txPoolGrpcServer, err = txpool.Assemble(
 			ctx,
 			config.TxPool,
@@ -771,6 +821,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			blockBuilderNotifyNewTxns,
 			logger,
 			direct.NewEthBackendClientDirect(backend.ethBackendRPC),
+			txPoolOpts...,
 		)
 		if err != nil {
 			return nil, err
@@ -896,6 +947,11 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 
 	// proof-of-stake mining
 	assembleBlockPOS := func(param *core.BlockBuilderParameters, interrupt *int32) (*types.BlockWithReceipts, error) {
+		latestBuiltStore := latestBlockBuiltStore
+		if param.DryRun { // dry-run block must not become the pending block
+
+			latestBuiltStore = nil
+		}
 		miningStatePos := stagedsync.NewMiningState(&config.Miner)
 		miningStatePos.MiningConfig.Etherbase = param.SuggestedFeeRecipient
 		proposingSync := stagedsync.New(
@@ -921,7 +977,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 				),
 				stagedsync.StageSendersCfg(backend.chainDB, chainConfig, config.Sync, false, dirs.Tmp, config.Prune, blockReader, backend.sentriesClient.Hd),
 				stagedsync.StageMiningExecCfg(backend.chainDB, miningStatePos, backend.notifications.Events, backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, interrupt, param.PayloadId, txnProvider, blockReader),
-				stagedsync.StageMiningFinishCfg(backend.chainDB, backend.chainConfig, backend.engine, miningStatePos, backend.miningSealingQuit, backend.blockReader, latestBlockBuiltStore),
+				stagedsync.StageMiningFinishCfg(backend.chainDB, backend.chainConfig, backend.engine, miningStatePos, backend.miningSealingQuit, backend.blockReader, latestBuiltStore),
 				astridEnabled,
 			), stagedsync.MiningUnwindOrder, stagedsync.MiningPruneOrder, logger, stages.ModeBlockProduction)
 		// We start the mining step
@@ -933,14 +989,38 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	}
 
 	blockRetire := freezeblocks.NewBlockRetire(1, dirs, blockReader, blockWriter, backend.chainDB, heimdallStore, bridgeStore, backend.chainConfig, config, backend.notifications.Events, segmentsBuildLimiter, logger)
-	var creds credentials.TransportCredentials
-	if stack.Config().PrivateApiAddr != "" {
-		if stack.Config().TLSConnection {
-			creds, err = grpcutil.TLS(stack.Config().TLSCACert, stack.Config().TLSCertFile, stack.Config().TLSKeyFile)
-			if err != nil {
+	if config.MaintenanceConfig != "" || config.SnapAutoUpdate != "" {
+		maintenanceCfg := &maintenance.Config{}
+		if config.MaintenanceConfig != "" {
+			if maintenanceCfg, err = maintenance.LoadConfig(config.MaintenanceConfig); err != nil {
 				return nil, err
 			}
 		}
+		if config.SnapAutoUpdate != "" {
+			maintenanceCfg.Tasks = append(maintenanceCfg.Tasks, maintenance.TaskConfig{
+				Name:     "snapshots-auto-update",
+				Job:      "snapshots-update",
+				Schedule: config.SnapAutoUpdate,
+				Window:   config.SnapAutoUpdateWindow,
+			})
+		}
+		var snapDownloader protodownloader.DownloaderClient
+		if !config.Snapshot.NoDownloader {
+			snapDownloader = backend.downloaderClient
+		}
+		jobs := (&maintenance.Node{
+			DB: backend.chainDB, Agg: agg, BlockReader: blockReader, BlockRetire: blockRetire, Logger: logger,
+			Dirs: dirs, ChainConfig: chainConfig, Downloader: snapDownloader,
+			OnNewSnapshots: func() {
+				backend.notifications.Events.OnNewSnapshot()
+				backend.publishSnapshotRanges()
+			},
+		}).Jobs()
+		if backend.maintenance, err = maintenance.New(maintenanceCfg, jobs, logger); err != nil {
+			return nil, err
+		}
+	}
+	if stack.Config().PrivateApiAddr != "" {
 		backend.privateAPI, err = privateapi2.StartGrpc(
 			kvRPC,
 			backend.ethBackendRPC,
@@ -950,7 +1030,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			heimdallRPC,
 			stack.Config().PrivateApiAddr,
 			stack.Config().PrivateApiRateLimit,
-			creds,
+			backend.grpcSecurity,
 			stack.Config().HealthCheck,
 			logger)
 		if err != nil {
@@ -1023,7 +1103,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	checkStateRoot := true
 	pipelineStages := stages2.NewPipelineStages(ctx, backend.chainDB, config, p2pConfig, backend.sentriesClient, backend.notifications, backend.downloaderClient, blockReader, blockRetire, backend.silkworm, backend.forkValidator, logger, tracer, checkStateRoot)
 	backend.pipelineStagedSync = stagedsync.New(config.Sync, pipelineStages, stagedsync.PipelineUnwindOrder, stagedsync.PipelinePruneOrder, logger, stages.ModeApplyingBlocks)
-	backend.eth1ExecutionServer = eth1.NewEthereumExecutionModule(blockReader, backend.chainDB, backend.pipelineStagedSync, backend.forkValidator, chainConfig, assembleBlockPOS, hook, backend.notifications.Accumulator, backend.notifications.RecentLogs, backend.notifications.StateChangesConsumer, logger, backend.engine, config.Sync, ctx)
+	var badBlocks *eth1.BadBlocks
+	if config.Sync.BadBlocksLimit > 0 {
+		if badBlocks, err = eth1.OpenBadBlocks(filepath.Join(dirs.DataDir, eth1.BadBlocksDir), int(config.Sync.BadBlocksLimit), logger); err != nil {
+			return nil, err
+		}
+	}
+	backend.eth1ExecutionServer = eth1.NewEthereumExecutionModule(blockReader, backend.chainDB, backend.pipelineStagedSync, backend.forkValidator, chainConfig, assembleBlockPOS, hook, backend.notifications.Accumulator, backend.notifications.RecentLogs, backend.notifications.StateChangesConsumer, logger, backend.engine, config.Sync, badBlocks, ctx)
 	executionRpc := direct.NewExecutionClientDirect(backend.eth1ExecutionServer)
 
 	var executionEngine executionclient.ExecutionEngine
@@ -1067,7 +1153,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		}
 		go func() {
 			eth1Getter := getters.NewExecutionSnapshotReader(ctx, blockReader, backend.chainDB)
-			if err := caplin1.RunCaplinService(ctx, executionEngine, config.CaplinConfig, dirs, eth1Getter, backend.downloaderClient, creds, segmentsBuildLimiter); err != nil {
+			if err := caplin1.RunCaplinService(ctx, executionEngine, config.CaplinConfig, dirs, eth1Getter, backend.downloaderClient, backend.grpcSecurity.Creds(), segmentsBuildLimiter); err != nil {
 				logger.Error("could not start caplin", "err", err)
 			}
 			ctxCancel()
@@ -1178,8 +1264,44 @@ func (s *Ethereum) Init(stack *node.Node, config *ethconfig.Config, chainConfig
 			return err
 		}
 	}
+	if config.SinkURL != "" {
+		var headCh chan [][]byte
+		headCh, s.unsubscribeExecSink = s.notifications.Events.AddHeaderSubscription()
+		s.execSink, err = execsink.New(execsink.Config{
+			URL:         config.SinkURL,
+			OffsetsFile: filepath.Join(config.Dirs.DataDir, "execsink", "offsets.json"),
+			FromBlock:   config.SinkFromBlock,
+		}, s.chainDB, blockReader, chainConfig, receipts.NewGenerator(blockReader, s.engine), headCh, s.logger)
+		if err != nil {
+			return err
+		}
+	}
 
-	s.apiList = jsonrpc.APIList(chainKv, s.ethRpcClient, s.txPoolRpcClient, s.miningRpcClient, s.rpcFilters, s.rpcDaemonStateCache, blockReader, &httpRpcCfg, s.engine, s.logger, s.polygonBridge, s.heimdallService)
+	feeMarket, _ := s.txPoolGrpcServer.(txpool.FeeMarketHistoryReader) // internal txpool only
+	httpRpcCfg.OpcodeOverrides = chainConfig.OpcodeOverrides
+	s.apiList = jsonrpc.APIList(chainKv, s.ethRpcClient, s.txPoolRpcClient, s.miningRpcClient, s.rpcFilters, s.rpcDaemonStateCache, blockReader, &httpRpcCfg, s.engine, s.logger, s.polygonBridge, s.heimdallService, feeMarket, s.notifications.StateGrowth)
+	if slices.Contains(httpRpcCfg.API, "admin") {
+		backfill := stagedsync.NewReceiptsBackfill(ctx, stagedsync.StageCustomTraceCfg(nil, s.chainDB, config.Dirs, blockReader, chainConfig, s.engine, config.Genesis, config.Sync), s.logger)
+		s.apiList = append(s.apiList, rpc.API{
+			Namespace: "admin",
+			Public:    false,
+			Service:   jsonrpc.ReceiptsBackfillAPI(jsonrpc.NewReceiptsBackfillAPI(backfill)),
+			Version:   "1.0",
+		}, rpc.API{
+			Namespace: "admin",
+			Public:    false,
+			Service:   jsonrpc.BlockBuildingAPI(jsonrpc.NewBlockBuildingAPI(s.eth1ExecutionServer)),
+			Version:   "1.0",
+		})
+	}
+	if s.txPool != nil && config.TxPool.PropagationTraceWindow > 0 && slices.Contains(httpRpcCfg.API, "txpool") {
+		s.apiList = append(s.apiList, rpc.API{
+			Namespace: "txpool",
+			Public:    false,
+			Service:   jsonrpc.TxPoolPropagationAPI(jsonrpc.NewTxPoolPropagationAPI(s.txPool)),
+			Version:   "1.0",
+		})
+	}
 
 	if config.SilkwormRpcDaemon && httpRpcCfg.Enabled {
 		interface_log_settings := silkworm.RpcInterfaceLogSettings{
@@ -1482,6 +1604,7 @@ func (s *Ethereum) setUpSnapDownloader(ctx context.Context, nodeCfg *nodecfg.Con
 		s.logger.Warn("files changed...sending notification")
 		events := s.notifications.Events
 		events.OnNewSnapshot()
+		s.publishSnapshotRanges()
 		if downloaderCfg != nil && downloaderCfg.ChainName == "" {
 			return
 		}
@@ -1509,7 +1632,7 @@ func (s *Ethereum) setUpSnapDownloader(ctx context.Context, nodeCfg *nodecfg.Con
 
 	if s.config.Snapshot.DownloaderAddr != "" {
 		// connect to external Downloader
-		s.downloaderClient, err = downloadergrpc.NewClient(ctx, s.config.Snapshot.DownloaderAddr)
+		s.downloaderClient, err = downloadergrpc.NewClient(ctx, s.config.Snapshot.DownloaderAddr, s.grpcSecurity)
 	} else {
 		// start embedded Downloader
 		if uploadFs := s.config.Sync.UploadLocation; len(uploadFs) > 0 {
@@ -1621,10 +1744,38 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 	return protocols
 }
 
+// diskForecastConfig - growth of the datadir is forecasted up to the head known by the Headers stage
+func (s *Ethereum) diskForecastConfig() diskforecast.Config {
+	cfg := diskforecast.Config{Dirs: s.config.Dirs, Prune: s.config.Prune, SecondsPerBlock: s.chainConfig.SecondsPerSlot()}
+	if err := s.chainDB.View(s.sentryCtx, func(tx kv.Tx) (err error) {
+		if cfg.ExecutedBlock, err = stages.GetStageProgress(tx, stages.Execution); err != nil {
+			return err
+		}
+		cfg.HeadBlock, err = stages.GetStageProgress(tx, stages.Headers)
+		return err
+	}); err != nil {
+		s.logger.Debug("[disk] reading sync progress", "err", err)
+	}
+	return cfg
+}
+
+// publishSnapshotRanges advertises the blocks served from the snapshot files in the node records of the sentries
+func (s *Ethereum) publishSnapshotRanges() {
+	var frozenTo uint64 // FrozenBlocks is the last frozen block, 0 without snapshots
+	if frozen := s.blockReader.FrozenBlocks(); frozen > 0 {
+		frozenTo = frozen + 1
+	}
+	ranges := enode.NewSnapshotRanges([]enode.BlockRange{{From: s.earliestServedBlock, To: frozenTo}})
+	for _, srv := range s.sentryServers {
+		srv.SetSnapshotRanges(ranges)
+	}
+}
+
 // Start implements node.Lifecycle, starting all internal goroutines needed by the
 // Ethereum protocol implementation.
 func (s *Ethereum) Start() error {
 	s.sentriesClient.StartStreamLoops(s.sentryCtx)
+	s.publishSnapshotRanges()
 	time.Sleep(10 * time.Millisecond) // just to reduce logs order confusion
 
 	hook := stages2.NewHook(s.sentryCtx, s.chainDB, s.notifications, s.stagedSync, s.blockReader, s.chainConfig, s.logger, s.sentriesClient.SetStatus)
@@ -1713,6 +1864,11 @@ func (s *Ethereum) Start() error {
 		})
 	}
 
+	s.bgComponentsEg.Go(func() error {
+		diskforecast.Watch(s.sentryCtx, s.diskForecastConfig, time.Hour, s.logger)
+		return nil
+	})
+
 	if s.shutterPool != nil {
 		s.bgComponentsEg.Go(func() error {
 			defer s.logger.Info("[shutter] pool goroutine terminated")
@@ -1724,9 +1880,85 @@ func (s *Ethereum) Start() error {
 		})
 	}
 
+	if s.execSink != nil {
+		s.bgComponentsEg.Go(func() error {
+			defer s.logger.Info("[sink] goroutine terminated")
+			err := s.execSink.Run(s.sentryCtx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				s.logger.Error("[sink] Run error", "err", err)
+			}
+			return err
+		})
+	}
+
+	if s.maintenance != nil {
+		s.bgComponentsEg.Go(func() error {
+			defer s.logger.Info("[maintenance] goroutine terminated")
+			err := s.maintenance.Run(s.sentryCtx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				s.logger.Error("[maintenance] Run error", "err", err)
+			}
+			return err
+		})
+	}
+
+	if s.config.DevCL && s.engineBackendRPC != nil {
+		s.bgComponentsEg.Go(func() error {
+			defer s.logger.Info("[dev-cl] goroutine terminated")
+			err := s.runDevCL(s.sentryCtx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				s.logger.Error("[dev-cl] Run error", "err", err)
+			}
+			return err
+		})
+	}
+
 	return nil
 }
 
+// privateGrpcSecurity - nil if neither TLS nor token auth is configured
+func privateGrpcSecurity(cfg *nodecfg.Config) (*grpcutil.Security, error) {
+	var caCert, certFile, keyFile string
+	if cfg.TLSConnection {
+		caCert, certFile, keyFile = cfg.TLSCACert, cfg.TLSCertFile, cfg.TLSKeyFile
+	}
+	if caCert == "" && certFile == "" && keyFile == "" && cfg.GRPCAuthTokenFile == "" {
+		return nil, nil
+	}
+	return grpcutil.NewSecurity(caCert, certFile, keyFile, cfg.GRPCAuthTokenFile)
+}
+
+// runDevCL - produces blocks of the proof-of-stake developer chain through the in-process engine API
+func (s *Ethereum) runDevCL(ctx context.Context) error {
+	var head *types.Header
+	if err := s.chainDB.View(ctx, func(tx kv.Tx) error {
+		head = rawdb.ReadCurrentHeader(tx)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if head == nil {
+		return errors.New("no current header")
+	}
+	etherbase, err := s.Etherbase()
+	if err != nil {
+		return err
+	}
+	cl := engine_dev_cl.New(engine_dev_cl.Config{
+		ChainConfig:  s.chainConfig,
+		FeeRecipient: etherbase,
+		Period:       time.Duration(s.config.DevPeriod) * time.Second,
+		HasPending: func(ctx context.Context) (bool, error) {
+			status, err := s.txPoolRpcClient.Status(ctx, &txpoolproto.StatusRequest{})
+			if err != nil {
+				return false, err
+			}
+			return status.PendingCount > 0, nil
+		},
+	}, s.engineBackendRPC, head, s.logger)
+	return cl.Run(ctx)
+}
+
 // Stop implements node.Service, terminating all internal goroutines used by the
 // Ethereum protocol.
 func (s *Ethereum) Stop() error {
@@ -1735,6 +1967,9 @@ func (s *Ethereum) Stop() error {
 	if s.unsubscribeEthstat != nil {
 		s.unsubscribeEthstat()
 	}
+	if s.unsubscribeExecSink != nil {
+		s.unsubscribeExecSink()
+	}
 	if s.downloader != nil {
 		s.downloader.Close()
 	}
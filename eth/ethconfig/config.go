@@ -36,6 +36,8 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/common/mem"
+	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/kv/prune"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/cl/clparams"
@@ -92,6 +94,9 @@ var Defaults = Config{
 		ParallelStateFlushing:    true,
 		ChaosMonkey:              false,
 		AlwaysGenerateChangesets: !dbg.BatchCommitments,
+		SendersEcrecover:         crypto.EcrecoverLibsecp256k1,
+		CrossCheckEpoch:          1024,
+		BadBlocksLimit:           16,
 	},
 	Ethash: ethashcfg.Config{
 		CachesInMem:      2,
@@ -191,6 +196,10 @@ type Config struct {
 	// for nodes to connect to.
 	EthDiscoveryURLs []string
 
+	// NoPreMergeHistory - don't serve the headers, bodies and receipts of the blocks before the merge to the
+	// peers, and advertise the merge block as the earliest served one (EIP-4444 history expiry)
+	NoPreMergeHistory bool
+
 	Prune     prune.Mode
 	BatchSize datasize.ByteSize // Batch size for execution stage
 
@@ -243,8 +252,24 @@ type Config struct {
 
 	// Ethstats service
 	Ethstats string
+	// SinkURL - message bus to publish block-executed events to, empty - disabled
+	SinkURL string
+	// SinkFromBlock - first block published by a new sink, 0 - the next executed block
+	SinkFromBlock uint64
+	// MaintenanceConfig - TOML file of scheduled maintenance tasks, empty - disabled
+	MaintenanceConfig string
+	// SnapAutoUpdate - cron schedule of the download of newly published block snapshots, empty - disabled
+	SnapAutoUpdate string
+	// SnapAutoUpdateWindow - daily window of the snapshot auto-update, empty - any time
+	SnapAutoUpdateWindow string
+	// Watchdog - thresholds of automatic heap and goroutine profiles capture, its Dir is set from datadir
+	Watchdog mem.WatchdogCfg
 	// Consensus layer
 	InternalCL bool
+	// DevCL - embedded mock consensus layer producing blocks of the proof-of-stake developer chain
+	DevCL bool
+	// DevPeriod - seconds between blocks produced by DevCL, 0 - a block as soon as a transaction is pending
+	DevPeriod uint64
 
 	OverrideOsakaTime *big.Int `toml:",omitempty"`
 
@@ -268,6 +293,10 @@ type Config struct {
 
 	// Account Abstraction
 	AllowAA bool
+
+	// OpcodeOverridesFile - TOML file of operator's changes of opcode gas costs and disabled opcodes per block range
+	// (L2s, private chains), empty - none
+	OpcodeOverridesFile string
 }
 
 type Sync struct {
@@ -290,4 +319,29 @@ type Sync struct {
 	AlwaysGenerateChangesets bool
 	KeepExecutionProofs      bool
 	PersistReceiptsCacheV2   bool
+
+	// ExecCheckpointInterval - if > 0, execution stage commits its progress at least every N blocks
+	// during initial sync (in addition to the batch-size based commits). Serial execution only.
+	ExecCheckpointInterval uint64
+
+	// StateGrowthWindow - if > 0, execution collects state growth analytics (shards.StateGrowthTracker) keeping
+	// per-block stats of the N most recent blocks
+	StateGrowthWindow uint64
+
+	// TxMetrics - execution stores per-transaction resource usage (execution time, state reads/writes, call depth)
+	// into kv.TxMetrics, served by erigon_getTransactionMetrics
+	TxMetrics bool
+
+	// SendersEcrecover - implementation of public key recovery used by senders stage: crypto.EcrecoverBackends
+	SendersEcrecover string
+
+	// CrossCheckSamples - if > 0, the CrossCheck stage re-executes so many random blocks of every CrossCheckEpoch
+	// executed blocks: on the history state and then on the execution witness only, and alerts if the results
+	// diverge from each other, from the header or from the state written by the Execution stage
+	CrossCheckSamples uint64
+	CrossCheckEpoch   uint64
+
+	// BadBlocksLimit - if > 0, the last N invalid blocks (received via engine_newPayload or p2p) are kept with their
+	// validation error in the datadir (eth1.BadBlocks), served by debug_getBadBlocks and the bad-blocks command
+	BadBlocksLimit uint64
 }
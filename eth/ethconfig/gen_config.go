@@ -28,6 +28,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Genesis                             *types.Genesis `toml:",omitempty"`
 		NetworkID                           uint64
 		EthDiscoveryURLs                    []string
+		NoPreMergeHistory                   bool
 		Prune                               prune.Mode
 		BatchSize                           datasize.ByteSize
 		ImportMode                          bool
@@ -72,6 +73,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Genesis = c.Genesis
 	enc.NetworkID = c.NetworkID
 	enc.EthDiscoveryURLs = c.EthDiscoveryURLs
+	enc.NoPreMergeHistory = c.NoPreMergeHistory
 	enc.Prune = c.Prune
 	enc.BatchSize = c.BatchSize
 	enc.ImportMode = c.ImportMode
@@ -120,6 +122,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Genesis                             *types.Genesis `toml:",omitempty"`
 		NetworkID                           *uint64
 		EthDiscoveryURLs                    []string
+		NoPreMergeHistory                   *bool
 		Prune                               *prune.Mode
 		BatchSize                           *datasize.ByteSize
 		ImportMode                          *bool
@@ -174,6 +177,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.EthDiscoveryURLs != nil {
 		c.EthDiscoveryURLs = dec.EthDiscoveryURLs
 	}
+	if dec.NoPreMergeHistory != nil {
+		c.NoPreMergeHistory = *dec.NoPreMergeHistory
+	}
 	if dec.Prune != nil {
 		c.Prune = *dec.Prune
 	}
@@ -40,8 +40,8 @@ func MarshalReceipt(
 	var chainId *big.Int
 	switch t := txn.(type) {
 	case *types.LegacyTx:
-		if t.Protected() {
-			chainId = types.DeriveChainId(&t.V).ToBig()
+		if id := types.LegacyChainID(chainConfig.ChainID, t); id != nil {
+			chainId = id.ToBig()
 		}
 	default:
 		chainId = txn.GetChainID().ToBig()
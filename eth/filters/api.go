@@ -44,6 +44,13 @@ type LogFilterOptions struct {
 	IgnoreTopicsOrder bool   `json:"ignoreTopicsOrder,omitempty"`
 }
 
+// LogSubscriptionOptions - optional 3rd parameter of eth_subscribe("logs", ...)
+type LogSubscriptionOptions struct {
+	// Cursor - name of the persistent subscription cursor. Resubscribing with the same cursor delivers
+	// `removed` logs for blocks reorged out since the previous subscription and the logs missed meanwhile.
+	Cursor string `json:"cursor,omitempty"`
+}
+
 func DefaultLogFilterOptions() LogFilterOptions {
 	return LogFilterOptions{
 		BlockCount: 1,
@@ -0,0 +1,302 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+// TemporalCfg - the Temporal check is designed to run next to a live node: keys and blocks are sampled,
+// reads are throttled and read transactions are kept short (one per key prefix or block).
+type TemporalCfg struct {
+	FailFast      bool
+	KeysSampling  int // check every N-th key of the latest state
+	Blocks        int // amount of blocks, evenly distributed over available commitment history, to check restored state root of
+	KeysPerSecond int // IO throttling: sampled keys and blocks per second, 0 - unlimited
+}
+
+var temporalDomains = []struct {
+	domain kv.Domain
+	idx    kv.InvertedIdx
+}{
+	{kv.AccountsDomain, kv.AccountsHistoryIdx},
+	{kv.StorageDomain, kv.StorageHistoryIdx},
+	{kv.CodeDomain, kv.CodeHistoryIdx},
+}
+
+// TemporalConsistency - cross-checks domains vs history vs inverted indices vs commitment:
+//   - every sampled key of latest state is present in inverted index (if history is not pruned)
+//   - txNums of inverted index are strictly increasing and not ahead of executed blocks
+//   - history has a value for the last txNum of inverted index and nothing after it
+//   - commitment restored from history as of sampled blocks has the header's state root. It checks that
+//     commitment history is consistent with headers, not that state root recomputed from domains matches
+func TemporalConsistency(ctx context.Context, db kv.TemporalRwDB, br services.FullBlockReader, cfg TemporalCfg) error {
+	defer func(t time.Time) { log.Info("[integrity] Temporal done", "took", time.Since(t)) }(time.Now())
+	if cfg.KeysSampling <= 0 {
+		cfg.KeysSampling = 1
+	}
+	limit := rate.Inf
+	if cfg.KeysPerSecond > 0 {
+		limit = rate.Limit(cfg.KeysPerSecond)
+	}
+	c := &temporalChecker{cfg: cfg, db: db, br: br, limiter: rate.NewLimiter(limit, 1), txNumsReader: br.TxnumReader(ctx)}
+	c.logEvery = time.NewTicker(20 * time.Second)
+	defer c.logEvery.Stop()
+
+	g, gCtx := errgroup.WithContext(ctx)
+	for _, d := range temporalDomains {
+		g.Go(func() error { return c.checkDomain(gCtx, d.domain, d.idx) })
+	}
+	g.Go(func() error { return c.checkCommitment(gCtx) })
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if n := c.problems.Load(); n > 0 {
+		return fmt.Errorf("[integrity] Temporal: %d problems found", n)
+	}
+	return nil
+}
+
+type temporalChecker struct {
+	cfg          TemporalCfg
+	db           kv.TemporalRwDB
+	br           services.FullBlockReader
+	txNumsReader rawdbv3.TxNumsReader
+	limiter      *rate.Limiter
+	logEvery     *time.Ticker
+
+	keys, blocks, problems atomic.Uint64
+}
+
+func (c *temporalChecker) fail(err error) error {
+	if c.cfg.FailFast {
+		return err
+	}
+	c.problems.Add(1)
+	log.Warn("[integrity] Temporal", "err", err)
+	return nil
+}
+
+// executedTxNum - last txNum of the last executed block, as seen by `tx`
+func (c *temporalChecker) executedTxNum(tx kv.Tx) (blockNum, txNum uint64, err error) {
+	blockNum, err = stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return 0, 0, err
+	}
+	txNum, err = c.txNumsReader.Max(tx, blockNum)
+	if err != nil {
+		return 0, 0, err
+	}
+	return blockNum, txNum, nil
+}
+
+func (c *temporalChecker) checkDomain(ctx context.Context, domain kv.Domain, idx kv.InvertedIdx) error {
+	var keyI int
+	for prefix := 0; prefix < 256; prefix++ {
+		from, to := []byte{byte(prefix)}, []byte{byte(prefix + 1)}
+		if prefix == 255 {
+			to = nil
+		}
+		// new read tx per prefix: long-living read tx of online check would prevent db from re-using pages
+		if err := c.db.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+			_, maxTxNum, err := c.executedTxNum(tx)
+			if err != nil {
+				return err
+			}
+			historyFrom := tx.Debug().HistoryStartFrom(domain)
+
+			keys, err := tx.Debug().RangeLatest(domain, from, to, -1)
+			if err != nil {
+				return err
+			}
+			defer keys.Close()
+			for keys.HasNext() {
+				key, _, err := keys.Next()
+				if err != nil {
+					return err
+				}
+				keyI++
+				if keyI%c.cfg.KeysSampling != 0 {
+					continue
+				}
+				if err := c.limiter.Wait(ctx); err != nil {
+					return err
+				}
+				if err := c.checkKey(tx, domain, idx, key, historyFrom, maxTxNum); err != nil {
+					return err
+				}
+				c.keys.Add(1)
+
+				select {
+				case <-c.logEvery.C:
+					log.Info("[integrity] Temporal", "domain", domain, "prefix", fmt.Sprintf("%x", prefix), "keys", common.PrettyCounter(c.keys.Load()), "blocks", c.blocks.Load())
+				default:
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *temporalChecker) checkKey(tx kv.TemporalTx, domain kv.Domain, idx kv.InvertedIdx, key []byte, historyFrom, maxTxNum uint64) error {
+	it, err := tx.IndexRange(idx, key, -1, -1, order.Asc, -1)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	var cnt, last uint64
+	for it.HasNext() {
+		txNum, err := it.Next()
+		if err != nil {
+			return err
+		}
+		if cnt > 0 && txNum <= last {
+			if err := c.fail(fmt.Errorf("%s: txNums are not increasing: %d after %d, key=%x", idx, txNum, last, key)); err != nil {
+				return err
+			}
+		}
+		if txNum > maxTxNum {
+			if err := c.fail(fmt.Errorf("%s: txNum=%d is ahead of executed txNum=%d, key=%x", idx, txNum, maxTxNum, key)); err != nil {
+				return err
+			}
+		}
+		cnt++
+		last = txNum
+	}
+
+	if cnt == 0 {
+		if historyFrom == 0 {
+			return c.fail(fmt.Errorf("%s: key is in latest state but not in %s, key=%x", domain, idx, key))
+		}
+		return nil
+	}
+	if last < historyFrom {
+		return nil
+	}
+	if _, ok, err := tx.HistorySeek(domain, key, last); err != nil {
+		return err
+	} else if !ok {
+		return c.fail(fmt.Errorf("%s: no history for txNum=%d of %s, key=%x", domain, last, idx, key))
+	}
+	if _, ok, err := tx.HistorySeek(domain, key, last+1); err != nil {
+		return err
+	} else if ok {
+		return c.fail(fmt.Errorf("%s: history has changes after last txNum=%d of %s, key=%x", domain, last, idx, key))
+	}
+	return nil
+}
+
+// checkCommitment - restores commitment trie as of sampled blocks (the same way eth_getProof does) and compares its root with header.
+// No keys are touched, so root is not recomputed: it's the root stored in commitment history.
+func (c *temporalChecker) checkCommitment(ctx context.Context) error {
+	var fromBlock, toBlock uint64
+	if err := c.db.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+		var err error
+		toBlock, _, err = c.executedTxNum(tx)
+		if err != nil {
+			return err
+		}
+		historyFrom := tx.Debug().HistoryStartFrom(kv.CommitmentDomain)
+		fromBlock, _, err = c.txNumsReader.FindBlockNum(tx, historyFrom)
+		return err
+	}); err != nil {
+		return err
+	}
+	// block is checkable if its state after execution is in history: first block may be only partially available
+	fromBlock++
+	if c.cfg.Blocks <= 0 || fromBlock > toBlock {
+		return nil
+	}
+
+	step := max((toBlock-fromBlock)/uint64(c.cfg.Blocks), 1)
+	for i := uint64(0); i < uint64(c.cfg.Blocks); i++ {
+		if i*step > toBlock-fromBlock {
+			break
+		}
+		blockNum := toBlock - i*step
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		if err := c.db.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+			return c.checkRestoredRoot(ctx, tx, blockNum)
+		}); err != nil {
+			return err
+		}
+		c.blocks.Add(1)
+	}
+	return nil
+}
+
+func (c *temporalChecker) checkRestoredRoot(ctx context.Context, tx kv.TemporalTx, blockNum uint64) error {
+	header, err := c.br.HeaderByNumber(ctx, tx, blockNum)
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		return c.fail(fmt.Errorf("header not found: %d", blockNum))
+	}
+
+	domains, err := state.NewSharedDomains(tx, log.New())
+	if err != nil {
+		return err
+	}
+	defer domains.Close()
+
+	executedBlock, _, err := c.executedTxNum(tx)
+	if err != nil {
+		return err
+	}
+	if blockNum < executedBlock {
+		// first txNum of next block: state as of `blockNum` fully executed
+		txNum, err := c.txNumsReader.Min(tx, blockNum+1)
+		if err != nil {
+			return err
+		}
+		domains.GetCommitmentContext().SetLimitReadAsOfTxNum(txNum, false)
+	}
+	if err := domains.SeekCommitment(ctx, tx); err != nil {
+		return err
+	}
+	rootHash, err := domains.ComputeCommitment(ctx, false, blockNum, 0, "[integrity] Temporal")
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rootHash, header.Root[:]) {
+		return c.fail(fmt.Errorf("commitment: restored state root mismatch at block %d: restored %x, header %x", blockNum, rootHash, header.Root))
+	}
+	return nil
+}
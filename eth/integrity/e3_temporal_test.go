@@ -0,0 +1,68 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package integrity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/state"
+)
+
+func TestTemporalCheckKey(t *testing.T) {
+	ctx := context.Background()
+	db := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	tx, err := db.BeginTemporalRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	doms, err := state.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	defer doms.Close()
+
+	key := []byte{0x01, 0x02}
+	require.NoError(t, doms.DomainPut(kv.CodeDomain, tx, key, []byte{0xa}, 1, nil, 0))
+	require.NoError(t, doms.DomainPut(kv.CodeDomain, tx, key, []byte{0xb}, 3, []byte{0xa}, 0))
+	require.NoError(t, doms.Flush(ctx, tx))
+
+	check := func(failFast bool, key []byte, maxTxNum uint64) (uint64, error) {
+		c := &temporalChecker{cfg: TemporalCfg{FailFast: failFast}}
+		err := c.checkKey(tx, kv.CodeDomain, kv.CodeHistoryIdx, key, 0, maxTxNum)
+		return c.problems.Load(), err
+	}
+
+	problems, err := check(true, key, 10)
+	require.NoError(t, err)
+	require.Zero(t, problems)
+
+	// change at txNum=3 is ahead of executed txNum=2
+	problems, err = check(false, key, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), problems)
+	_, err = check(true, key, 2)
+	require.ErrorContains(t, err, "ahead of executed txNum")
+
+	// key of latest state without history
+	_, err = check(true, []byte{0x03}, 10)
+	require.ErrorContains(t, err, "not in")
+}
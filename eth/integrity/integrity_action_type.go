@@ -30,6 +30,7 @@ const (
 	BorSpans           Check = "BorSpans"
 	BorCheckpoints     Check = "BorCheckpoints"
 	BorMilestones      Check = "BorMilestones" // this check is informational, and we don't run it by default (e.g. gaps may exist but that is ok)
+	Temporal           Check = "Temporal"
 )
 
 var AllChecks = []Check{
@@ -39,4 +40,5 @@ var AllChecks = []Check{
 var NonDefaultChecks = []Check{
 	BorMilestones,
 	RCacheNoDups,
+	Temporal,
 }
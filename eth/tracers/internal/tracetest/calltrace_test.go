@@ -130,54 +130,7 @@ func testCallTracer(tracerName string, dirPath string, t *testing.T) {
 			} else if err := json.Unmarshal(blob, test); err != nil {
 				t.Fatalf("failed to parse testcase: %v", err)
 			}
-			tx, err := types.UnmarshalTransactionFromBinary(common.FromHex(test.Input), false /* blobTxnsAreWrappedWithBlobs */)
-			if err != nil {
-				t.Fatalf("failed to parse testcase input: %v", err)
-			}
-			// Configure a blockchain with the given prestate
-			signer := types.MakeSigner(test.Genesis.Config, uint64(test.Context.Number), uint64(test.Context.Time))
-			context := evmtypes.BlockContext{
-				CanTransfer: core.CanTransfer,
-				Transfer:    consensus.Transfer,
-				Coinbase:    test.Context.Miner,
-				BlockNumber: uint64(test.Context.Number),
-				Time:        uint64(test.Context.Time),
-				Difficulty:  (*big.Int)(test.Context.Difficulty),
-				GasLimit:    uint64(test.Context.GasLimit),
-			}
-			if test.Context.BaseFee != nil {
-				context.BaseFee, _ = uint256.FromBig((*big.Int)(test.Context.BaseFee))
-			}
-			rules := test.Genesis.Config.Rules(context.BlockNumber, context.Time)
-
-			m := mock.Mock(t)
-			dbTx, err := m.DB.BeginTemporalRw(m.Ctx)
-			require.NoError(t, err)
-			defer dbTx.Rollback()
-			statedb, err := tests.MakePreState(rules, dbTx, test.Genesis.Alloc, uint64(test.Context.Number))
-			require.NoError(t, err)
-			tracer, err := tracers.New(tracerName, new(tracers.Context), test.TracerConfig)
-			if err != nil {
-				t.Fatalf("failed to create call tracer: %v", err)
-			}
-			statedb.SetHooks(tracer.Hooks)
-			msg, err := tx.AsMessage(*signer, (*big.Int)(test.Context.BaseFee), rules)
-			if err != nil {
-				t.Fatalf("failed to prepare transaction for tracing: %v", err)
-			}
-			txContext := core.NewEVMTxContext(msg)
-			evm := vm.NewEVM(context, txContext, statedb, test.Genesis.Config, vm.Config{Tracer: tracer.Hooks})
-			tracer.OnTxStart(evm.GetVMContext(), tx, msg.From())
-			vmRet, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(tx.GetGasLimit()).AddBlobGas(tx.GetBlobGas()), true /* refunds */, false /* gasBailout */, nil /* engine */)
-			if err != nil {
-				t.Fatalf("failed to execute transaction: %v", err)
-			}
-			tracer.OnTxEnd(&types.Receipt{GasUsed: vmRet.GasUsed}, err)
-			// Retrieve the trace result and compare against the expected.
-			res, err := tracer.GetResult()
-			if err != nil {
-				t.Fatalf("failed to retrieve trace result: %v", err)
-			}
+			res, vmRet := runCallTracerTest(t, tracerName, new(tracers.Context), test.TracerConfig, test)
 			// The legacy javascript calltracer marshals json in js, which
 			// is not deterministic (as opposed to the golang json encoder).
 			if isLegacy {
@@ -211,6 +164,158 @@ func testCallTracer(tracerName string, dirPath string, t *testing.T) {
 	}
 }
 
+// flatCallTrace is a single frame of a flatCallTracer run.
+type flatCallTrace struct {
+	Action struct {
+		SelfDestructed *common.Address `json:"address"`
+		CallType       string          `json:"callType"`
+		From           *common.Address `json:"from"`
+		RefundAddress  *common.Address `json:"refundAddress"`
+		To             *common.Address `json:"to"`
+	} `json:"action"`
+	BlockNumber uint64 `json:"blockNumber"`
+	Error       string `json:"error"`
+	Result      *struct {
+		Address *common.Address `json:"address"`
+		GasUsed *hexutil.Uint64 `json:"gasUsed"`
+	} `json:"result"`
+	Subtraces       int          `json:"subtraces"`
+	TraceAddress    []int        `json:"traceAddress"`
+	TransactionHash *common.Hash `json:"transactionHash"`
+	Type            string       `json:"type"`
+}
+
+// TestFlatCallTracerNative checks that the flatCallTracer output is the flattened callTracer output.
+func TestFlatCallTracerNative(t *testing.T) {
+	dirPath := filepath.Join("testdata", "call_tracer")
+	files, err := dir.ReadDir(dirPath)
+	if err != nil {
+		t.Fatalf("failed to retrieve tracer test suite: %v", err)
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		file := file // capture range variable
+		t.Run(camel(strings.TrimSuffix(file.Name(), ".json")), func(t *testing.T) {
+			t.Parallel()
+
+			test := new(callTracerTest)
+			blob, err := os.ReadFile(filepath.Join(dirPath, file.Name()))
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(blob, test))
+			if test.TracerConfig != nil {
+				t.Skip("result depends on the callTracer config")
+			}
+			txHash := common.HexToHash("0x01")
+			res, vmRet := runCallTracerTest(t, "flatCallTracer", &tracers.Context{TxHash: txHash}, json.RawMessage(`{"includePrecompiles":true}`), test)
+
+			var have []flatCallTrace
+			require.NoError(t, json.Unmarshal(res, &have))
+			var want []*callTrace
+			var wantAddress [][]int
+			var flatten func(call *callTrace, traceAddress []int)
+			flatten = func(call *callTrace, traceAddress []int) {
+				want, wantAddress = append(want, call), append(wantAddress, traceAddress)
+				for i := range call.Calls {
+					flatten(&call.Calls[i], append(append([]int{}, traceAddress...), i))
+				}
+			}
+			flatten(test.Result, []int{})
+			require.Len(t, have, len(want))
+			for i, call := range want {
+				frame := have[i]
+				require.Equal(t, wantAddress[i], frame.TraceAddress)
+				require.Equal(t, len(call.Calls), frame.Subtraces)
+				require.Equal(t, call.Error, frame.Error)
+				require.Equal(t, uint64(test.Context.Number), frame.BlockNumber)
+				require.Equal(t, txHash, *frame.TransactionHash)
+				switch call.Type {
+				case "CREATE", "CREATE2":
+					require.Equal(t, "create", frame.Type)
+					require.Equal(t, call.From, *frame.Action.From)
+					if frame.Result != nil && call.Error == "" {
+						require.Equal(t, call.To, *frame.Result.Address)
+					}
+				case "SELFDESTRUCT":
+					require.Equal(t, "suicide", frame.Type)
+					require.Equal(t, call.From, *frame.Action.SelfDestructed)
+					require.Equal(t, call.To, *frame.Action.RefundAddress)
+					require.Nil(t, frame.Result)
+					continue
+				default:
+					require.Equal(t, "call", frame.Type)
+					require.Equal(t, strings.ToLower(call.Type), frame.Action.CallType)
+					require.Equal(t, call.From, *frame.Action.From)
+					require.Equal(t, call.To, *frame.Action.To)
+				}
+				if frame.Result != nil {
+					require.Equal(t, *call.GasUsed, *frame.Result.GasUsed)
+				} else {
+					require.NotEmpty(t, call.Error, "result is dropped on errors only")
+				}
+			}
+			if have[0].Result != nil {
+				require.Equal(t, vmRet.GasUsed, uint64(*have[0].Result.GasUsed))
+			}
+		})
+	}
+}
+
+// runCallTracerTest executes the transaction of the test case with the tracer attached
+// and returns the trace result.
+func runCallTracerTest(t *testing.T, tracerName string, tracerCtx *tracers.Context, config json.RawMessage, test *callTracerTest) (json.RawMessage, *evmtypes.ExecutionResult) {
+	t.Helper()
+	tx, err := types.UnmarshalTransactionFromBinary(common.FromHex(test.Input), false /* blobTxnsAreWrappedWithBlobs */)
+	if err != nil {
+		t.Fatalf("failed to parse testcase input: %v", err)
+	}
+	// Configure a blockchain with the given prestate
+	signer := types.MakeSigner(test.Genesis.Config, uint64(test.Context.Number), uint64(test.Context.Time))
+	context := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    consensus.Transfer,
+		Coinbase:    test.Context.Miner,
+		BlockNumber: uint64(test.Context.Number),
+		Time:        uint64(test.Context.Time),
+		Difficulty:  (*big.Int)(test.Context.Difficulty),
+		GasLimit:    uint64(test.Context.GasLimit),
+	}
+	if test.Context.BaseFee != nil {
+		context.BaseFee, _ = uint256.FromBig((*big.Int)(test.Context.BaseFee))
+	}
+	rules := test.Genesis.Config.Rules(context.BlockNumber, context.Time)
+
+	m := mock.Mock(t)
+	dbTx, err := m.DB.BeginTemporalRw(m.Ctx)
+	require.NoError(t, err)
+	defer dbTx.Rollback()
+	statedb, err := tests.MakePreState(rules, dbTx, test.Genesis.Alloc, uint64(test.Context.Number))
+	require.NoError(t, err)
+	tracer, err := tracers.New(tracerName, tracerCtx, config)
+	if err != nil {
+		t.Fatalf("failed to create call tracer: %v", err)
+	}
+	statedb.SetHooks(tracer.Hooks)
+	msg, err := tx.AsMessage(*signer, (*big.Int)(test.Context.BaseFee), rules)
+	if err != nil {
+		t.Fatalf("failed to prepare transaction for tracing: %v", err)
+	}
+	txContext := core.NewEVMTxContext(msg)
+	evm := vm.NewEVM(context, txContext, statedb, test.Genesis.Config, vm.Config{Tracer: tracer.Hooks})
+	tracer.OnTxStart(evm.GetVMContext(), tx, msg.From())
+	vmRet, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(tx.GetGasLimit()).AddBlobGas(tx.GetBlobGas()), true /* refunds */, false /* gasBailout */, nil /* engine */)
+	if err != nil {
+		t.Fatalf("failed to execute transaction: %v", err)
+	}
+	tracer.OnTxEnd(&types.Receipt{GasUsed: vmRet.GasUsed}, err)
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve trace result: %v", err)
+	}
+	return res, vmRet
+}
+
 func BenchmarkTracers(b *testing.B) {
 	files, err := dir.ReadDir(filepath.Join("testdata", "call_tracer"))
 	if err != nil {
@@ -34,14 +34,22 @@ import (
 
 type JSONLogger struct {
 	encoder *json.Encoder
+	flusher flusher // nil if writer is not buffered
 	cfg     *LogConfig
 	env     *tracing.VMContext
 }
 
+// flusher - buffered writers, e.g. bufio.Writer
+type flusher interface {
+	Flush() error
+}
+
 // NewJSONLogger creates a new EVM tracer that prints execution steps as JSON objects
-// into the provided stream.
+// into the provided stream. Nothing is buffered by the logger: every step is written as soon as it's executed,
+// so it can be used for traces too big to be kept in memory. Buffered writers are flushed at the end of the transaction.
 func NewJSONLogger(cfg *LogConfig, writer io.Writer) *JSONLogger {
-	l := &JSONLogger{json.NewEncoder(writer), cfg, nil}
+	l := &JSONLogger{encoder: json.NewEncoder(writer), cfg: cfg}
+	l.flusher, _ = writer.(flusher)
 	if l.cfg == nil {
 		l.cfg = &LogConfig{}
 	}
@@ -112,4 +120,7 @@ func (l *JSONLogger) OnExit(depth int, output []byte, gasUsed uint64, err error,
 		errMsg = err.Error()
 	}
 	_ = l.encoder.Encode(endLog{common.Bytes2Hex(output), math.HexOrDecimal64(gasUsed), errMsg})
+	if l.flusher != nil {
+		_ = l.flusher.Flush()
+	}
 }
@@ -20,7 +20,11 @@
 package logger
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/holiman/uint256"
@@ -76,6 +80,44 @@ func TestStoreCapture(t *testing.T) {
 	}
 }
 
+func TestJSONLoggerStreaming(t *testing.T) {
+	c := vm.NewJumpDestCache(128)
+	ibs := state.New(state.NewNoopReader())
+	var out bytes.Buffer
+	w := bufio.NewWriterSize(&out, 16)
+
+	var (
+		logger   = NewJSONLogger(&LogConfig{DisableMemory: true}, w)
+		evm      = vm.NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, ibs, chain.TestChainConfig, vm.Config{Tracer: logger.Tracer().Hooks})
+		contract = vm.NewContract(&dummyContractRef{}, common.Address{}, new(uint256.Int), 100000, false /* skipAnalysis */, c)
+	)
+	contract.Code = []byte{byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.SSTORE)}
+	logger.OnTxStart(evm.GetVMContext(), nil, common.Address{})
+	_, err := evm.Interpreter().Run(contract, []byte{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() == 0 {
+		t.Fatal("expected steps to be written during execution")
+	}
+	logger.OnExit(0, nil, 100, nil, false)
+	if w.Buffered() != 0 {
+		t.Fatal("expected writer to be flushed at the end of the transaction")
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 { // 3 steps, implicit STOP and the result
+		t.Fatalf("expected 5 JSON lines, got %d: %s", len(lines), out.String())
+	}
+	var step StructLog
+	if err := json.Unmarshal([]byte(lines[2]), &step); err != nil {
+		t.Fatal(err)
+	}
+	if step.Op != vm.SSTORE || len(step.Stack) != 2 {
+		t.Errorf("unexpected 3rd step: %s", lines[2])
+	}
+}
+
 //func TestStoreCapture(t *testing.T) {
 //	c := vm.NewJumpDestCache()
 //	var (
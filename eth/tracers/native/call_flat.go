@@ -0,0 +1,300 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+func init() {
+	register("flatCallTracer", newFlatCallTracer)
+}
+
+var parityErrorMapping = map[string]string{
+	"contract creation code storage out of gas": "Out of gas",
+	"out of gas":                      "Out of gas",
+	"gas uint64 overflow":             "Out of gas",
+	"max code size exceeded":          "Out of gas",
+	"invalid jump destination":        "Bad jump destination",
+	"execution reverted":              "Reverted",
+	"return data out of bounds":       "Out of bounds",
+	"stack limit reached 1024 (1023)": "Out of stack",
+	"precompiled failed":              "Built-in failed",
+	"invalid input length":            "Built-in failed",
+}
+
+var parityErrorMappingStartingWith = map[string]string{
+	"invalid opcode:": "Bad instruction",
+	"stack underflow": "Stack underflow",
+}
+
+// flatCallFrame is a call frame of the parity-style (trace_* namespace) format
+type flatCallFrame struct {
+	Action              flatCallAction  `json:"action"`
+	BlockHash           *common.Hash    `json:"blockHash"`
+	BlockNumber         uint64          `json:"blockNumber"`
+	Error               string          `json:"error,omitempty"`
+	Result              *flatCallResult `json:"result,omitempty"`
+	Subtraces           int             `json:"subtraces"`
+	TraceAddress        []int           `json:"traceAddress"`
+	TransactionHash     *common.Hash    `json:"transactionHash"`
+	TransactionPosition uint64          `json:"transactionPosition"`
+	Type                string          `json:"type"`
+}
+
+type flatCallAction struct {
+	SelfDestructed *common.Address `json:"address,omitempty"`
+	Balance        *hexutil.Big    `json:"balance,omitempty"`
+	CallType       string          `json:"callType,omitempty"`
+	From           *common.Address `json:"from,omitempty"`
+	Gas            *hexutil.Uint64 `json:"gas,omitempty"`
+	Init           *hexutil.Bytes  `json:"init,omitempty"`
+	Input          *hexutil.Bytes  `json:"input,omitempty"`
+	RefundAddress  *common.Address `json:"refundAddress,omitempty"`
+	To             *common.Address `json:"to,omitempty"`
+	Value          *hexutil.Big    `json:"value,omitempty"`
+}
+
+type flatCallResult struct {
+	Address *common.Address `json:"address,omitempty"`
+	Code    *hexutil.Bytes  `json:"code,omitempty"`
+	GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	Output  *hexutil.Bytes  `json:"output,omitempty"`
+}
+
+type flatCallTracerConfig struct {
+	ConvertParityErrors bool `json:"convertParityErrors"` // If true, errors are converted to the parity format
+	IncludePrecompiles  bool `json:"includePrecompiles"`  // If true, calls to precompiles are included (parity omits them)
+}
+
+// flatCallTracer - the callTracer whose nested frames are flattened into the parity format
+type flatCallTracer struct {
+	tracer      *callTracer
+	config      flatCallTracerConfig
+	ctx         *tracers.Context
+	blockNumber uint64
+	interrupt   atomic.Bool
+}
+
+func newFlatCallTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, error) {
+	var config flatCallTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	// onlyTopCall and withLog of the inner tracer are not supported by the format
+	inner := &callTracer{callstack: make([]callFrame, 0, 1), config: callTracerConfig{IncludePrecompiles: config.IncludePrecompiles}}
+	t := &flatCallTracer{tracer: inner, config: config, ctx: ctx}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnTxEnd:   t.OnTxEnd,
+			OnEnter:   t.OnEnter,
+			OnExit:    t.OnExit,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *flatCallTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if t.interrupt.Load() {
+		return
+	}
+	size := len(t.tracer.callstack)
+	t.tracer.OnEnter(depth, typ, from, to, precompile, input, gas, value, code)
+	// Child calls must have a value, even if it's zero: STATICCALL has none
+	if depth > 0 && len(t.tracer.callstack) > size && t.tracer.callstack[size].Value == nil {
+		t.tracer.callstack[size].Value = new(big.Int)
+	}
+}
+
+func (t *flatCallTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.tracer.OnExit(depth, output, gasUsed, err, reverted)
+}
+
+func (t *flatCallTracer) OnTxStart(env *tracing.VMContext, tx types.Transaction, from common.Address) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.blockNumber = env.BlockNumber
+	t.tracer.OnTxStart(env, tx, from)
+}
+
+func (t *flatCallTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.tracer.OnTxEnd(receipt, err)
+}
+
+// GetResult returns the json-encoded list of flat call traces, and any error arising from the encoding or
+// forceful termination (via `Stop`).
+func (t *flatCallTracer) GetResult() (json.RawMessage, error) {
+	if len(t.tracer.callstack) == 0 && !t.config.IncludePrecompiles {
+		// the top-level call is a call to a precompile
+		return json.RawMessage("[]"), nil
+	}
+	if len(t.tracer.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	flat, err := t.flatFromNested(&t.tracer.callstack[0], []int{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := json.Marshal(flat)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.tracer.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *flatCallTracer) Stop(err error) {
+	t.tracer.Stop(err)
+	t.interrupt.Store(true)
+}
+
+func (t *flatCallTracer) flatFromNested(input *callFrame, traceAddress []int) ([]flatCallFrame, error) {
+	var frame *flatCallFrame
+	switch input.Type {
+	case vm.CREATE, vm.CREATE2:
+		frame = newFlatCreate(input)
+	case vm.SELFDESTRUCT:
+		frame = newFlatSelfdestruct(input)
+	case vm.CALL, vm.STATICCALL, vm.CALLCODE, vm.DELEGATECALL:
+		frame = newFlatCall(input)
+	default:
+		return nil, errors.New("unrecognized call frame type: " + input.Type.String())
+	}
+
+	if input.Error != "" {
+		frame.Error = input.Error
+		if t.config.ConvertParityErrors {
+			frame.Error = convertErrorToParity(frame.Error)
+		}
+		// Revert output contains useful information (revert reason), otherwise the result is discarded
+		if input.Error != vm.ErrExecutionReverted.Error() {
+			frame.Result = nil
+		}
+	}
+
+	frame.TraceAddress = traceAddress
+	frame.Subtraces = len(input.Calls)
+	if t.ctx != nil {
+		if t.ctx.BlockHash != (common.Hash{}) {
+			frame.BlockHash = &t.ctx.BlockHash
+		}
+		if t.ctx.TxHash != (common.Hash{}) {
+			frame.TransactionHash = &t.ctx.TxHash
+		}
+		frame.TransactionPosition = uint64(t.ctx.TxIndex)
+	}
+	frame.BlockNumber = t.blockNumber
+	output := []flatCallFrame{*frame}
+
+	for i := range input.Calls {
+		childAddress := append(append(make([]int, 0, len(traceAddress)+1), traceAddress...), i)
+		flat, err := t.flatFromNested(&input.Calls[i], childAddress)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, flat...)
+	}
+	return output, nil
+}
+
+func newFlatCreate(input *callFrame) *flatCallFrame {
+	init, code, gas, gasUsed := hexutil.Bytes(input.Input), hexutil.Bytes(input.Output), hexutil.Uint64(input.Gas), hexutil.Uint64(input.GasUsed)
+	var to *common.Address
+	if input.To != (common.Address{}) {
+		to = &input.To
+	}
+	return &flatCallFrame{
+		Type: strings.ToLower(vm.CREATE.String()),
+		Action: flatCallAction{
+			From:  &input.From,
+			Gas:   &gas,
+			Value: (*hexutil.Big)(input.Value),
+			Init:  &init,
+		},
+		Result: &flatCallResult{
+			GasUsed: &gasUsed,
+			Address: to,
+			Code:    &code,
+		},
+	}
+}
+
+func newFlatCall(input *callFrame) *flatCallFrame {
+	in, out, gas, gasUsed := hexutil.Bytes(input.Input), hexutil.Bytes(input.Output), hexutil.Uint64(input.Gas), hexutil.Uint64(input.GasUsed)
+	return &flatCallFrame{
+		Type: strings.ToLower(vm.CALL.String()),
+		Action: flatCallAction{
+			From:     &input.From,
+			To:       &input.To,
+			Gas:      &gas,
+			Value:    (*hexutil.Big)(input.Value),
+			CallType: strings.ToLower(input.Type.String()),
+			Input:    &in,
+		},
+		Result: &flatCallResult{
+			GasUsed: &gasUsed,
+			Output:  &out,
+		},
+	}
+}
+
+func newFlatSelfdestruct(input *callFrame) *flatCallFrame {
+	return &flatCallFrame{
+		Type: "suicide",
+		Action: flatCallAction{
+			SelfDestructed: &input.From,
+			Balance:        (*hexutil.Big)(input.Value),
+			RefundAddress:  &input.To,
+		},
+	}
+}
+
+func convertErrorToParity(err string) string {
+	if parityError, ok := parityErrorMapping[err]; ok {
+		return parityError
+	}
+	for gethError, parityError := range parityErrorMappingStartingWith {
+		if strings.HasPrefix(err, gethError) {
+			return parityError
+		}
+	}
+	return err
+}
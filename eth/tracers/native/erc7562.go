@@ -0,0 +1,480 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+func init() {
+	register("erc7562", newErc7562Tracer)
+	register("erc7562Tracer", newErc7562Tracer)
+}
+
+var (
+	// well-known EntryPoint deployments, used when `entryPoint` is not configured
+	entryPointV06 = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	entryPointV07 = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+
+	selectorValidateUserOpV06          = [4]byte{0x3a, 0x87, 0x1c, 0xdd}
+	selectorValidateUserOpV07          = [4]byte{0x19, 0x82, 0x2f, 0x7c}
+	selectorValidatePaymasterUserOpV06 = [4]byte{0xf4, 0x65, 0xc7, 0x7e}
+	selectorValidatePaymasterUserOpV07 = [4]byte{0x52, 0xb7, 0x51, 0x2c}
+	selectorCreateSender               = [4]byte{0x57, 0x0e, 0x1a, 0x36}
+	selectorDepositTo                  = [4]byte{0xb7, 0x60, 0xfa, 0xf9}
+
+	// OP-011: opcodes which are not allowed during validation
+	erc7562BannedOpcodes = map[vm.OpCode]bool{
+		vm.GASPRICE: true, vm.GASLIMIT: true, vm.DIFFICULTY: true, vm.TIMESTAMP: true, vm.BASEFEE: true,
+		vm.BLOCKHASH: true, vm.NUMBER: true, vm.ORIGIN: true, vm.COINBASE: true, vm.SELFDESTRUCT: true,
+		vm.BLOBHASH: true, vm.BLOBBASEFEE: true, vm.INVALID: true,
+		vm.BALANCE: true, vm.SELFBALANCE: true, // OP-080: allowed for staked entities
+	}
+)
+
+const (
+	erc7562EntityAccount   = "account"
+	erc7562EntityPaymaster = "paymaster"
+	erc7562EntityFactory   = "factory"
+
+	// slots `keccak(A || x) + n` with n up to this value are associated with address A
+	erc7562AssociatedSlotOffset = 128
+	// KECCAK256 inputs longer than this are not considered for the storage association
+	erc7562MaxKeccakInput = 1024
+)
+
+type erc7562TracerConfig struct {
+	EntryPoint     *common.Address  `json:"entryPoint"`     // EntryPoint contract, v0.6 and v0.7 deployments by default
+	StakedEntities []common.Address `json:"stakedEntities"` // staked factories/paymasters/accounts, relaxing storage and opcode rules
+}
+
+type erc7562Entity struct {
+	Kind    string         `json:"kind"`
+	Address common.Address `json:"address"`
+	Staked  bool           `json:"staked"`
+}
+
+type erc7562Violation struct {
+	Rule          string          `json:"rule"`
+	Entity        string          `json:"entity"`
+	EntityAddress common.Address  `json:"entityAddress"`
+	Address       common.Address  `json:"address"` // contract in which the violation happened
+	Opcode        string          `json:"opcode,omitempty"`
+	Target        *common.Address `json:"target,omitempty"`
+	Slot          *common.Hash    `json:"slot,omitempty"`
+	Pc            uint64          `json:"pc"`
+	Depth         int             `json:"depth"`
+	Reason        string          `json:"reason"`
+}
+
+type erc7562Result struct {
+	Entities   []erc7562Entity    `json:"entities"`
+	Violations []erc7562Violation `json:"violations"`
+}
+
+type erc7562Frame struct {
+	address common.Address
+	entity  int  // index in entities, -1 outside of validation
+	creator bool // frame of SenderCreator.createSender: the next call from it enters the factory
+}
+
+// storage and code accesses are checked when the tracing is finished: the sender isn't known during
+// the factory phase yet
+type erc7562StorageAccess struct {
+	entity  int
+	address common.Address
+	slot    common.Hash
+	write   bool
+	pc      uint64
+	depth   int
+}
+
+type erc7562CodeAccess struct {
+	entity  int
+	address common.Address
+	target  common.Address
+	opcode  vm.OpCode
+	pc      uint64
+	depth   int
+}
+
+type erc7562PendingGas struct {
+	entity  int
+	address common.Address
+	pc      uint64
+	depth   int
+}
+
+// erc7562Tracer checks the validation phase of ERC-4337 user operations - calls made by the EntryPoint
+// into the account, the paymaster and the factory - against the ERC-7562 validation scope rules:
+// banned opcodes, storage access and code access constraints. It is meant to be used with
+// debug_traceCall of EntryPoint simulation calls.
+type erc7562Tracer struct {
+	env         *tracing.VMContext
+	config      erc7562TracerConfig
+	precompiles map[common.Address]bool
+
+	frames     []erc7562Frame
+	entities   []erc7562Entity
+	create2    map[int]int
+	keccak     map[common.Hash]common.Hash // keccak result -> first 32 bytes of its input
+	storage    []erc7562StorageAccess
+	code       []erc7562CodeAccess
+	pendingGas *erc7562PendingGas
+
+	violations []erc7562Violation
+	seen       map[string]struct{}
+
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+func newErc7562Tracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, error) {
+	var config erc7562TracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	t := &erc7562Tracer{
+		config:  config,
+		create2: map[int]int{},
+		keccak:  map[common.Hash]common.Hash{},
+		seen:    map[string]struct{}{},
+	}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnEnter:   t.OnEnter,
+			OnExit:    t.OnExit,
+			OnOpcode:  t.OnOpcode,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *erc7562Tracer) OnTxStart(env *tracing.VMContext, tx types.Transaction, from common.Address) {
+	t.env = env
+	rules := env.ChainConfig.Rules(env.BlockNumber, env.Time)
+	t.precompiles = map[common.Address]bool{}
+	for _, addr := range vm.ActivePrecompiles(rules) {
+		t.precompiles[addr] = true
+	}
+}
+
+func (t *erc7562Tracer) isEntryPoint(addr common.Address) bool {
+	if t.config.EntryPoint != nil {
+		return addr == *t.config.EntryPoint
+	}
+	return addr == entryPointV06 || addr == entryPointV07
+}
+
+func (t *erc7562Tracer) isStaked(addr common.Address) bool {
+	for _, a := range t.config.StakedEntities {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *erc7562Tracer) currentEntity() int {
+	if len(t.frames) == 0 {
+		return -1
+	}
+	return t.frames[len(t.frames)-1].entity
+}
+
+func (t *erc7562Tracer) startEntity(kind string, addr common.Address) int {
+	t.entities = append(t.entities, erc7562Entity{Kind: kind, Address: addr, Staked: t.isStaked(addr)})
+	return len(t.entities) - 1
+}
+
+func (t *erc7562Tracer) sender() (common.Address, bool) {
+	for _, e := range t.entities {
+		if e.Kind == erc7562EntityAccount {
+			return e.Address, true
+		}
+	}
+	return common.Address{}, false
+}
+
+func (t *erc7562Tracer) violation(rule string, entity int, addr common.Address, opcode vm.OpCode, target *common.Address, slot *common.Hash, pc uint64, depth int, reason string) {
+	e := t.entities[entity]
+	key := fmt.Sprintf("%s-%d-%x-%d-%d", rule, entity, addr, opcode, pc)
+	if target != nil {
+		key += target.Hex()
+	}
+	if slot != nil {
+		key += slot.Hex()
+	}
+	if _, ok := t.seen[key]; ok {
+		return
+	}
+	t.seen[key] = struct{}{}
+	v := erc7562Violation{
+		Rule:          rule,
+		Entity:        e.Kind,
+		EntityAddress: e.Address,
+		Address:       addr,
+		Target:        target,
+		Slot:          slot,
+		Pc:            pc,
+		Depth:         depth,
+		Reason:        reason,
+	}
+	if opcode != vm.STOP {
+		v.Opcode = opcode.String()
+	}
+	t.violations = append(t.violations, v)
+}
+
+func (t *erc7562Tracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if t.interrupt.Load() {
+		return
+	}
+	op := vm.OpCode(typ)
+	parent := t.currentEntity()
+	frame := erc7562Frame{address: to, entity: parent}
+	if op == vm.DELEGATECALL || op == vm.CALLCODE {
+		frame.address = from // storage of the caller is used
+	}
+
+	var selector [4]byte
+	if len(input) >= 4 {
+		copy(selector[:], input[:4])
+	}
+	switch {
+	case parent < 0 && t.isEntryPoint(from) && (selector == selectorValidateUserOpV06 || selector == selectorValidateUserOpV07):
+		frame.entity = t.startEntity(erc7562EntityAccount, to)
+	case parent < 0 && t.isEntryPoint(from) && (selector == selectorValidatePaymasterUserOpV06 || selector == selectorValidatePaymasterUserOpV07):
+		frame.entity = t.startEntity(erc7562EntityPaymaster, to)
+	case parent < 0 && t.isEntryPoint(from) && selector == selectorCreateSender:
+		frame.creator = true
+	case parent < 0 && len(t.frames) > 0 && t.frames[len(t.frames)-1].creator:
+		frame.entity = t.startEntity(erc7562EntityFactory, to)
+	case parent >= 0:
+		t.checkCall(parent, op, from, to, precompile, selector, len(input), value, code, depth)
+	}
+	t.frames = append(t.frames, frame)
+}
+
+func (t *erc7562Tracer) checkCall(entity int, op vm.OpCode, from, to common.Address, precompile bool, selector [4]byte, inputLen int, value *uint256.Int, code []byte, depth int) {
+	if op == vm.CALL && value != nil && !value.IsZero() && !t.isEntryPoint(to) {
+		t.violation("OP-061", entity, from, op, &to, nil, 0, depth, "call with value to a contract other than the EntryPoint")
+	}
+	if t.isEntryPoint(to) && inputLen > 0 && selector != selectorDepositTo {
+		t.violation("OP-052", entity, from, op, &to, nil, 0, depth, "EntryPoint may only be called with depositTo or the fallback function")
+	}
+	if precompile {
+		// only the original precompiles and RIP-7212 secp256r1 verification
+		if n := new(uint256.Int).SetBytes(to[:]); !(n.LtUint64(10) || n.Eq(uint256.NewInt(0x100))) {
+			t.violation("OP-062", entity, from, op, &to, nil, 0, depth, "call to unsupported precompile")
+		}
+		return
+	}
+	// code of a contract being created isn't deployed yet
+	if len(code) == 0 && !t.isEntryPoint(to) && op != vm.CREATE && op != vm.CREATE2 {
+		t.code = append(t.code, erc7562CodeAccess{entity: entity, address: from, target: to, opcode: op, depth: depth})
+	}
+}
+
+func (t *erc7562Tracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	// OnEnter doesn't push frames after Stop
+	if t.interrupt.Load() || len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	if frame.entity >= 0 && errors.Is(err, vm.ErrOutOfGas) {
+		t.violation("OP-020", frame.entity, frame.address, vm.STOP, nil, nil, 0, depth, "validation ran out of gas")
+	}
+}
+
+func isCallOpcode(op vm.OpCode) bool {
+	return op == vm.CALL || op == vm.CALLCODE || op == vm.DELEGATECALL || op == vm.STATICCALL
+}
+
+func (t *erc7562Tracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.interrupt.Load() {
+		return
+	}
+	op := vm.OpCode(opcode)
+	if g := t.pendingGas; g != nil {
+		t.pendingGas = nil
+		if !isCallOpcode(op) {
+			t.violation("OP-012", g.entity, g.address, vm.GAS, nil, nil, g.pc, g.depth, "GAS must be immediately followed by a call")
+		}
+	}
+
+	stack := scope.StackData()
+	if op == vm.KECCAK256 && len(stack) >= 2 {
+		t.recordKeccak(scope.MemoryData(), stack[len(stack)-1], stack[len(stack)-2])
+	}
+
+	entity := t.currentEntity()
+	if entity < 0 {
+		return
+	}
+	addr := scope.Address()
+	staked := t.entities[entity].Staked
+	switch {
+	case erc7562BannedOpcodes[op]:
+		if (op == vm.BALANCE || op == vm.SELFBALANCE) && staked {
+			return
+		}
+		t.violation("OP-011", entity, addr, op, nil, nil, pc, depth, "opcode is not allowed during validation")
+	case op == vm.GAS:
+		t.pendingGas = &erc7562PendingGas{entity: entity, address: addr, pc: pc, depth: depth}
+	case op == vm.CREATE:
+		t.violation("OP-031", entity, addr, op, nil, nil, pc, depth, "CREATE is not allowed during validation")
+	case op == vm.CREATE2:
+		t.create2[entity]++
+		if t.entities[entity].Kind != erc7562EntityFactory || t.create2[entity] > 1 {
+			t.violation("OP-031", entity, addr, op, nil, nil, pc, depth, "CREATE2 is only allowed once, by the factory")
+		}
+	case (op == vm.SLOAD || op == vm.SSTORE) && len(stack) >= 1:
+		t.storage = append(t.storage, erc7562StorageAccess{
+			entity: entity, address: addr, slot: stack[len(stack)-1].Bytes32(), write: op == vm.SSTORE, pc: pc, depth: depth,
+		})
+	case (op == vm.EXTCODESIZE || op == vm.EXTCODEHASH || op == vm.EXTCODECOPY) && len(stack) >= 1:
+		target := common.Address(stack[len(stack)-1].Bytes20())
+		if t.precompiles[target] {
+			return
+		}
+		if code, _ := t.env.IntraBlockState.GetCode(target); len(code) == 0 {
+			t.code = append(t.code, erc7562CodeAccess{entity: entity, address: addr, target: target, opcode: op, pc: pc, depth: depth})
+		}
+	}
+}
+
+func (t *erc7562Tracer) recordKeccak(memory []byte, offset, size uint256.Int) {
+	if !offset.IsUint64() || !size.IsUint64() || size.Uint64() < 32 || size.Uint64() > erc7562MaxKeccakInput {
+		return
+	}
+	input, err := tracers.GetMemoryCopyPadded(memory, int64(offset.Uint64()), int64(size.Uint64()))
+	if err != nil {
+		return
+	}
+	t.keccak[common.BytesToHash(crypto.Keccak256(input))] = common.BytesToHash(input[:32])
+}
+
+// isAssociated - slot is `addr` itself or `keccak(addr || x) + n`
+func (t *erc7562Tracer) isAssociated(slot common.Hash, addr common.Address) bool {
+	padded := common.BytesToHash(addr[:])
+	if slot == padded {
+		return true
+	}
+	s := new(uint256.Int).SetBytes(slot[:])
+	var base uint256.Int
+	for n := uint64(0); n <= erc7562AssociatedSlotOffset; n++ {
+		if s.LtUint64(n) {
+			break
+		}
+		base.SubUint64(s, n)
+		if first, ok := t.keccak[base.Bytes32()]; ok && first == padded {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *erc7562Tracer) checkStorage(sender common.Address) {
+	for _, a := range t.storage {
+		e := t.entities[a.entity]
+		slot := a.slot
+		var rule, reason string
+		switch {
+		case a.address == sender: // STO-010
+			continue
+		case t.isAssociated(a.slot, sender): // STO-021
+			continue
+		case a.address == e.Address:
+			if e.Staked {
+				continue
+			}
+			rule, reason = "STO-031", "access to the entity's own storage requires stake"
+		case t.isAssociated(a.slot, e.Address):
+			if e.Staked {
+				continue
+			}
+			rule, reason = "STO-032", "access to storage associated with the entity requires stake"
+		case e.Staked && !a.write: // STO-033
+			continue
+		case e.Staked:
+			rule, reason = "STO-033", "staked entity may only read unassociated storage"
+		default:
+			rule, reason = "STO-021", "access to storage not associated with the sender"
+		}
+		op := vm.SLOAD
+		if a.write {
+			op = vm.SSTORE
+		}
+		t.violation(rule, a.entity, a.address, op, nil, &slot, a.pc, a.depth, reason)
+	}
+}
+
+func (t *erc7562Tracer) checkCode(sender common.Address) {
+	for _, a := range t.code {
+		if a.target == sender {
+			continue
+		}
+		target := a.target
+		t.violation("OP-041", a.entity, a.address, a.opcode, &target, nil, a.pc, a.depth, "access to an address without deployed code")
+	}
+}
+
+// GetResult returns the json-encoded entities detected in the traced call and the violations
+// of the validation rules, and any error arising from the encoding or forceful termination (via `Stop`).
+func (t *erc7562Tracer) GetResult() (json.RawMessage, error) {
+	// if the account's validation wasn't traced no address is exempt as the sender
+	sender, _ := t.sender()
+	t.checkStorage(sender)
+	t.checkCode(sender)
+
+	res := erc7562Result{Entities: t.entities, Violations: t.violations}
+	if res.Entities == nil {
+		res.Entities = []erc7562Entity{}
+	}
+	if res.Violations == nil {
+		res.Violations = []erc7562Violation{}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return data, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *erc7562Tracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
@@ -46,10 +46,24 @@ func init() {
 type state = map[common.Address]*account
 
 type account struct {
-	Balance *big.Int                    `json:"balance,omitempty"`
-	Code    []byte                      `json:"code,omitempty"`
-	Nonce   uint64                      `json:"nonce,omitempty"`
-	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+	Balance *big.Int `json:"balance,omitempty"`
+	Code    []byte   `json:"code,omitempty"`
+	// DelegatedStorage is the storage of other accounts (by owner) accessed by the account's code
+	// via DELEGATECALL or CALLCODE, reported with prestateTracerConfig.DelegatedStorage
+	DelegatedStorage map[common.Address]map[common.Hash]common.Hash `json:"delegatedStorage,omitempty"`
+	Nonce            uint64                                         `json:"nonce,omitempty"`
+	Storage          map[common.Hash]common.Hash                    `json:"storage,omitempty"`
+}
+
+// setDelegatedStorage attributes the slot of owner's storage to the account's code
+func (a *account) setDelegatedStorage(owner common.Address, key, val common.Hash) {
+	if a.DelegatedStorage == nil {
+		a.DelegatedStorage = make(map[common.Address]map[common.Hash]common.Hash)
+	}
+	if a.DelegatedStorage[owner] == nil {
+		a.DelegatedStorage[owner] = make(map[common.Hash]common.Hash)
+	}
+	a.DelegatedStorage[owner][key] = val
 }
 
 func (a *account) exists() bool {
@@ -73,12 +87,18 @@ type prestateTracer struct {
 	reason    error  // Textual reason for the interruption
 	created   map[common.Address]bool
 	deleted   map[common.Address]bool
+
+	codeAddrs []common.Address                                           // addresses of the code executed by the call frames
+	delegated map[common.Address]map[common.Address]map[common.Hash]bool // code address -> storage owner -> slots written
 }
 
 type prestateTracerConfig struct {
 	DiffMode       bool `json:"diffMode"`       // If true, this tracer will return state modifications
 	DisableCode    bool `json:"disableCode"`    // If true, this tracer will not return the contract code
 	DisableStorage bool `json:"disableStorage"` // If true, this tracer will not return the contract storage
+	// If true, storage accessed via DELEGATECALL or CALLCODE is attributed to the executing code's account too,
+	// under `delegatedStorage` by the storage owner. In diff mode only the modified slots are attributed.
+	DelegatedStorage bool `json:"delegatedStorage"`
 }
 
 func newPrestateTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, error) {
@@ -89,19 +109,25 @@ func newPrestateTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Trac
 		}
 	}
 	t := &prestateTracer{
-		pre:     state{},
-		post:    state{},
-		config:  config,
-		created: make(map[common.Address]bool),
-		deleted: make(map[common.Address]bool),
+		pre:       state{},
+		post:      state{},
+		config:    config,
+		created:   make(map[common.Address]bool),
+		deleted:   make(map[common.Address]bool),
+		delegated: make(map[common.Address]map[common.Address]map[common.Hash]bool),
 	}
 
+	hooks := &tracing.Hooks{
+		OnTxStart: t.OnTxStart,
+		OnTxEnd:   t.OnTxEnd,
+		OnOpcode:  t.OnOpcode,
+	}
+	if config.DelegatedStorage && !config.DisableStorage {
+		hooks.OnEnter = t.OnEnter
+		hooks.OnExit = t.OnExit
+	}
 	return &tracers.Tracer{
-		Hooks: &tracing.Hooks{
-			OnTxStart: t.OnTxStart,
-			OnTxEnd:   t.OnTxEnd,
-			OnOpcode:  t.OnOpcode,
-		},
+		Hooks:     hooks,
 		GetResult: t.GetResult,
 		Stop:      t.Stop,
 	}, nil
@@ -132,6 +158,9 @@ func (t *prestateTracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scop
 	case stackLen >= 1 && (op == vm.SLOAD || op == vm.SSTORE):
 		slot := common.Hash(stackData[stackLen-1].Bytes32())
 		t.lookupStorage(caller, slot)
+		if len(t.codeAddrs) > 0 && t.codeAddrs[len(t.codeAddrs)-1] != caller {
+			t.lookupDelegatedStorage(t.codeAddrs[len(t.codeAddrs)-1], caller, slot, op == vm.SSTORE)
+		}
 	case stackLen >= 1 && (op == vm.EXTCODECOPY || op == vm.EXTCODEHASH || op == vm.EXTCODESIZE || op == vm.BALANCE || op == vm.SELFDESTRUCT):
 		addr := common.Address(stackData[stackLen-1].Bytes20())
 		t.lookupAccount(addr)
@@ -162,6 +191,17 @@ func (t *prestateTracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scop
 	}
 }
 
+// OnEnter tracks the code address of the call frame, it is not the storage owner for DELEGATECALL and CALLCODE
+func (t *prestateTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.codeAddrs = append(t.codeAddrs, to)
+}
+
+func (t *prestateTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(t.codeAddrs) > 0 {
+		t.codeAddrs = t.codeAddrs[:len(t.codeAddrs)-1]
+	}
+}
+
 func (t *prestateTracer) OnTxStart(env *tracing.VMContext, tx types.Transaction, from common.Address) {
 	t.env = env
 
@@ -189,6 +229,24 @@ func (t *prestateTracer) OnTxEnd(receipt *types.Receipt, err error) {
 		return
 	}
 
+	// slots modified via delegated calls, before unchanged slots are removed from the prestate
+	type change struct {
+		owner          common.Address
+		key, pre, post common.Hash
+	}
+	delegatedChanges := make(map[common.Address][]change)
+	for codeAddr, owners := range t.delegated {
+		for owner, slots := range owners {
+			for key := range slots {
+				var newVal uint256.Int
+				t.env.IntraBlockState.GetState(owner, key, &newVal)
+				if preVal := t.pre[owner].Storage[key]; !new(uint256.Int).SetBytes(preVal[:]).Eq(&newVal) {
+					delegatedChanges[codeAddr] = append(delegatedChanges[codeAddr], change{owner, key, preVal, newVal.Bytes32()})
+				}
+			}
+		}
+	}
+
 	for addr, state := range t.pre {
 		// The deleted account's state is pruned from `post` but kept in `pre`
 		if _, ok := t.deleted[addr]; ok {
@@ -237,6 +295,17 @@ func (t *prestateTracer) OnTxEnd(receipt *types.Receipt, err error) {
 			}
 		}
 
+		for _, c := range delegatedChanges[addr] {
+			modified = true
+			// empty slots are omitted like in the storage of the owner
+			if c.pre != (common.Hash{}) {
+				t.pre[addr].setDelegatedStorage(c.owner, c.key, c.pre)
+			}
+			if c.post != (common.Hash{}) {
+				postAccount.setDelegatedStorage(c.owner, c.key, c.post)
+			}
+		}
+
 		if modified {
 			t.post[addr] = postAccount
 		} else {
@@ -317,3 +386,26 @@ func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
 	t.env.IntraBlockState.GetState(addr, key, &val)
 	t.pre[addr].Storage[key] = val.Bytes32()
 }
+
+// lookupDelegatedStorage attributes the slot of owner's storage accessed by the code at codeAddr.
+// In diff mode written slots are collected to be compared at the end of the transaction.
+func (t *prestateTracer) lookupDelegatedStorage(codeAddr, owner common.Address, key common.Hash, write bool) {
+	t.lookupAccount(codeAddr)
+	if t.config.DiffMode {
+		if !write {
+			return
+		}
+		if t.delegated[codeAddr] == nil {
+			t.delegated[codeAddr] = make(map[common.Address]map[common.Hash]bool)
+		}
+		if t.delegated[codeAddr][owner] == nil {
+			t.delegated[codeAddr][owner] = make(map[common.Hash]bool)
+		}
+		t.delegated[codeAddr][owner][key] = true
+		return
+	}
+	if _, ok := t.pre[codeAddr].DelegatedStorage[owner][key]; ok {
+		return
+	}
+	t.pre[codeAddr].setDelegatedStorage(owner, key, t.pre[owner].Storage[key])
+}
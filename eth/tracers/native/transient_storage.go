@@ -0,0 +1,95 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+func init() {
+	register("transientStorageTracer", newTransientStorageTracer)
+}
+
+// transientStorageTracer reports the EIP-1153 transient storage slots changed by the transaction (TSTORE),
+// with their values at the end of the transaction. Slots whose changes were reverted are reported with the
+// restored value.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "transientStorageTracer"})
+//	{
+//	  "0x5fbdb2315678afecb367f032d93f642f64180aa3": {
+//	    "0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"
+//	  }
+//	}
+type transientStorageTracer struct {
+	env    *tracing.VMContext
+	slots  map[common.Address]map[common.Hash]common.Hash
+	reason error
+}
+
+func newTransientStorageTracer(ctx *tracers.Context, _ json.RawMessage) (*tracers.Tracer, error) {
+	t := &transientStorageTracer{slots: map[common.Address]map[common.Hash]common.Hash{}}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnTxEnd:   t.OnTxEnd,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *transientStorageTracer) OnTxStart(env *tracing.VMContext, tx types.Transaction, from common.Address) {
+	t.env = env
+}
+
+// OnTxEnd collects the slots: the transient storage is cleared only when the next transaction is prepared
+func (t *transientStorageTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if t.env == nil || t.env.IntraBlockState == nil {
+		return
+	}
+	t.env.IntraBlockState.ForEachTransientStorage(func(addr common.Address, key common.Hash, value uint256.Int) bool {
+		if _, ok := t.slots[addr]; !ok {
+			t.slots[addr] = map[common.Hash]common.Hash{}
+		}
+		t.slots[addr][key] = value.Bytes32()
+		return true
+	})
+}
+
+// GetResult returns the json-encoded transient slots by address, and any error arising from the encoding
+// or forceful termination (via `Stop`).
+func (t *transientStorageTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.slots)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *transientStorageTracer) Stop(err error) {
+	t.reason = err
+}
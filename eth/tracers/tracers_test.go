@@ -37,6 +37,7 @@ import (
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/core/vm/program"
 	"github.com/erigontech/erigon/eth/tracers"
 	"github.com/erigontech/erigon/execution/consensus"
 	"github.com/erigontech/erigon/execution/stages/mock"
@@ -139,3 +140,240 @@ func TestPrestateTracerCreate2(t *testing.T) {
 		t.Fatalf("Expected 0x60f3f640a8508fc6a86d45df051962668e1e8ac7 in result")
 	}
 }
+
+type erc7562TestResult struct {
+	Entities []struct {
+		Kind    string         `json:"kind"`
+		Address common.Address `json:"address"`
+	} `json:"entities"`
+	Violations []struct {
+		Rule   string `json:"rule"`
+		Entity string `json:"entity"`
+		Opcode string `json:"opcode"`
+	} `json:"violations"`
+}
+
+// violations - rule -> "entity opcode" of each reported violation
+func (r erc7562TestResult) violations() map[string][]string {
+	res := map[string][]string{}
+	for _, v := range r.Violations {
+		res[v.Rule] = append(res[v.Rule], v.Entity+" "+v.Opcode)
+	}
+	return res
+}
+
+// runErc7562Tracer - sends a transaction to the EntryPoint and returns result of the erc7562 tracer
+func runErc7562Tracer(t *testing.T, entryPoint common.Address, alloc types.GenesisAlloc, cfg string) erc7562TestResult {
+	t.Helper()
+	unsignedTx := types.NewTransaction(1, entryPoint, uint256.NewInt(0), 5000000, uint256.NewInt(1), []byte{})
+
+	privateKeyECDSA, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	txn, err := types.SignTx(unsignedTx, *signer, privateKeyECDSA)
+	require.NoError(t, err)
+	origin, _ := signer.Sender(txn)
+	txContext := evmtypes.TxContext{
+		Origin:   origin,
+		GasPrice: uint256.NewInt(1),
+	}
+	context := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    consensus.Transfer,
+		Coinbase:    common.Address{},
+		BlockNumber: 8000000,
+		Time:        5,
+		Difficulty:  big.NewInt(0x30000),
+		GasLimit:    uint64(6000000),
+		BaseFee:     uint256.NewInt(0),
+		BlobBaseFee: uint256.NewInt(50000),
+	}
+	alloc[origin] = types.GenesisAccount{
+		Nonce:   1,
+		Code:    []byte{},
+		Balance: big.NewInt(500000000000000),
+	}
+
+	m := mock.Mock(t)
+	tx, err := m.DB.BeginTemporalRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	rules := chain.AllProtocolChanges.Rules(context.BlockNumber, context.Time)
+	statedb, _ := tests.MakePreState(rules, tx, alloc, context.BlockNumber)
+
+	tracer, err := tracers.New("erc7562", new(tracers.Context), json.RawMessage(cfg))
+	require.NoError(t, err)
+	evm := vm.NewEVM(context, txContext, statedb, chain.AllProtocolChanges, vm.Config{Tracer: tracer.Hooks})
+	msg, err := txn.AsMessage(*signer, nil, rules)
+	require.NoError(t, err)
+
+	tracer.OnTxStart(evm.GetVMContext(), txn, msg.From())
+	st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(txn.GetGasLimit()).AddBlobGas(txn.GetBlobGas()))
+	_, err = st.TransitionDb(false, false)
+	require.NoError(t, err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	var ret erc7562TestResult
+	require.NoError(t, json.Unmarshal(res, &ret))
+	return ret
+}
+
+// callWithSelector - calls `to` with 4-byte calldata
+func callWithSelector(p *program.Program, to common.Address, selector []byte) *program.Program {
+	return p.MstoreSmall(selector, 0).Call(nil, to, 0, 28, 4, 0, 0).Op(vm.POP)
+}
+
+func TestErc7562Tracer(t *testing.T) {
+	entryPoint := common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+	account := common.HexToAddress("0x00000000000000000000000000000000000aa001")
+	paymaster := common.HexToAddress("0x00000000000000000000000000000000000bb001")
+	token := common.HexToAddress("0x00000000000000000000000000000000000cc001")
+	senderCreator := common.HexToAddress("0x00000000000000000000000000000000000dd001")
+	factory := common.HexToAddress("0x00000000000000000000000000000000000ee001")
+
+	validateUserOp := []byte{0x19, 0x82, 0x2f, 0x7c}
+	validatePaymasterUserOp := []byte{0x52, 0xb7, 0x51, 0x2c}
+	createSender := []byte{0x57, 0x0e, 0x1a, 0x36}
+
+	t.Run("account opcodes", func(t *testing.T) {
+		alloc := types.GenesisAlloc{
+			entryPoint: {Nonce: 1, Code: callWithSelector(program.New(), account, validateUserOp).Op(vm.STOP).Bytes()},
+			// TIMESTAMP, SLOAD(1) of own storage, EXTCODESIZE(0xdead), GAS not followed by a call
+			account: {Nonce: 1, Code: hexutil.MustDecode("0x42506001545061dead3b505a5000")},
+		}
+		res := runErc7562Tracer(t, entryPoint, alloc, "{}")
+		require.Len(t, res.Entities, 1)
+		require.Equal(t, "account", res.Entities[0].Kind)
+		require.Equal(t, account, res.Entities[0].Address)
+		require.Equal(t, map[string][]string{
+			"OP-011": {"account TIMESTAMP"},
+			"OP-012": {"account GAS"},
+			"OP-041": {"account EXTCODESIZE"},
+		}, res.violations())
+	})
+
+	// account validation followed by paymaster validation: the paymaster reads its own storage,
+	// storage of a token associated with the sender (balances[sender]) and an unassociated slot of the token
+	paymasterAlloc := func() types.GenesisAlloc {
+		ep := callWithSelector(program.New(), account, validateUserOp)
+		ep = callWithSelector(ep, paymaster, validatePaymasterUserOp).Op(vm.STOP)
+		tokenCode := program.New().
+			Push(account).Push(0).Op(vm.MSTORE).
+			Push(64).Push(0).Op(vm.KECCAK256).Op(vm.SLOAD, vm.POP). // keccak(sender || 0)
+			Push(5).Op(vm.SLOAD, vm.POP).
+			Op(vm.STOP)
+		return types.GenesisAlloc{
+			entryPoint: {Nonce: 1, Code: ep.Bytes()},
+			account:    {Nonce: 1, Code: program.New().Push(1).Op(vm.SLOAD, vm.POP, vm.STOP).Bytes()},
+			paymaster:  {Nonce: 1, Code: program.New().Push(0).Op(vm.SLOAD, vm.POP).StaticCall(nil, token, 0, 0, 0, 0).Op(vm.POP, vm.STOP).Bytes()},
+			token:      {Nonce: 1, Code: tokenCode.Bytes()},
+		}
+	}
+	t.Run("paymaster storage", func(t *testing.T) {
+		res := runErc7562Tracer(t, entryPoint, paymasterAlloc(), "{}")
+		require.Len(t, res.Entities, 2)
+		require.Equal(t, "account", res.Entities[0].Kind)
+		require.Equal(t, "paymaster", res.Entities[1].Kind)
+		require.Equal(t, paymaster, res.Entities[1].Address)
+		require.Equal(t, map[string][]string{
+			"STO-031": {"paymaster SLOAD"}, // own storage, unstaked
+			"STO-021": {"paymaster SLOAD"}, // token slot 5 is not associated with the sender
+		}, res.violations())
+	})
+	t.Run("staked paymaster storage", func(t *testing.T) {
+		res := runErc7562Tracer(t, entryPoint, paymasterAlloc(), `{"stakedEntities":["`+paymaster.Hex()+`"]}`)
+		require.Len(t, res.Entities, 2)
+		require.Empty(t, res.violations())
+	})
+
+	// SenderCreator.createSender enters the factory, which deploys twice and writes its own storage
+	factoryAlloc := func() types.GenesisAlloc {
+		initCode := []byte{byte(vm.STOP)}
+		factoryCode := program.New().
+			Create2(initCode, 1).Op(vm.POP).
+			Create2(initCode, 2).Op(vm.POP).
+			Sstore(7, 1).
+			Op(vm.STOP)
+		return types.GenesisAlloc{
+			entryPoint:    {Nonce: 1, Code: callWithSelector(program.New(), senderCreator, createSender).Op(vm.STOP).Bytes()},
+			senderCreator: {Nonce: 1, Code: program.New().Call(nil, factory, 0, 0, 0, 0, 0).Op(vm.POP, vm.STOP).Bytes()},
+			factory:       {Nonce: 1, Code: factoryCode.Bytes()},
+		}
+	}
+	t.Run("factory", func(t *testing.T) {
+		res := runErc7562Tracer(t, entryPoint, factoryAlloc(), "{}")
+		require.Len(t, res.Entities, 1)
+		require.Equal(t, "factory", res.Entities[0].Kind)
+		require.Equal(t, factory, res.Entities[0].Address)
+		require.Equal(t, map[string][]string{
+			"OP-031":  {"factory CREATE2"}, // only the 2nd CREATE2
+			"STO-031": {"factory SSTORE"},
+		}, res.violations())
+	})
+	t.Run("staked factory", func(t *testing.T) {
+		res := runErc7562Tracer(t, entryPoint, factoryAlloc(), `{"stakedEntities":["`+factory.Hex()+`"]}`)
+		require.Equal(t, map[string][]string{"OP-031": {"factory CREATE2"}}, res.violations())
+	})
+}
+
+func TestTransientStorageTracer(t *testing.T) {
+	contract := common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+	unsignedTx := types.NewTransaction(1, contract, uint256.NewInt(0), 5000000, uint256.NewInt(1), []byte{})
+	privateKeyECDSA, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	txn, err := types.SignTx(unsignedTx, *signer, privateKeyECDSA)
+	require.NoError(t, err)
+	origin, _ := signer.Sender(txn)
+	txContext := evmtypes.TxContext{
+		Origin:   origin,
+		GasPrice: uint256.NewInt(1),
+	}
+	context := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    consensus.Transfer,
+		Coinbase:    common.Address{},
+		BlockNumber: 8000000,
+		Time:        5,
+		Difficulty:  big.NewInt(0x30000),
+		GasLimit:    uint64(6000000),
+		BaseFee:     uint256.NewInt(0),
+		BlobBaseFee: uint256.NewInt(50000),
+	}
+	alloc := types.GenesisAlloc{
+		// TSTORE(1, 2), TSTORE(3, 0): the latter doesn't change the slot
+		contract: {Nonce: 1, Code: hexutil.MustDecode("0x600260015d600060035d00"), Balance: big.NewInt(1)},
+		origin:   {Nonce: 1, Code: []byte{}, Balance: big.NewInt(500000000000000)},
+	}
+
+	m := mock.Mock(t)
+	tx, err := m.DB.BeginTemporalRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	rules := chain.AllProtocolChanges.Rules(context.BlockNumber, context.Time)
+	statedb, _ := tests.MakePreState(rules, tx, alloc, context.BlockNumber)
+
+	tracer, err := tracers.New("transientStorageTracer", new(tracers.Context), json.RawMessage("{}"))
+	require.NoError(t, err)
+	evm := vm.NewEVM(context, txContext, statedb, chain.AllProtocolChanges, vm.Config{Tracer: tracer.Hooks})
+	msg, err := txn.AsMessage(*signer, nil, rules)
+	require.NoError(t, err)
+
+	tracer.OnTxStart(evm.GetVMContext(), txn, msg.From())
+	st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(txn.GetGasLimit()).AddBlobGas(txn.GetBlobGas()))
+	exeRes, err := st.TransitionDb(false, false)
+	require.NoError(t, err)
+	require.False(t, exeRes.Failed())
+	tracer.OnTxEnd(&types.Receipt{GasUsed: exeRes.GasUsed}, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	var slots map[common.Address]map[common.Hash]common.Hash
+	require.NoError(t, json.Unmarshal(res, &slots))
+	require.Equal(t, map[common.Address]map[common.Hash]common.Hash{
+		contract: {
+			common.BigToHash(big.NewInt(1)): common.BigToHash(big.NewInt(2)),
+		},
+	}, slots)
+}
@@ -144,7 +144,12 @@ func BootnodeURLsOfChain(chain string) []string {
 	return bootNodeURLsByChainName[chain]
 }
 
+var staticPeerURLsByChainName = make(map[string][]string)
+
 func StaticPeerURLsOfChain(chain string) []string {
+	if urls, ok := staticPeerURLsByChainName[chain]; ok {
+		return urls
+	}
 	switch chain {
 	case networkname.Sepolia:
 		return SepoliaStaticPeers
@@ -29,6 +29,7 @@ import (
 	"github.com/jinzhu/copier"
 
 	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/crypto"
@@ -146,6 +147,35 @@ func DeveloperGenesisBlock(period uint64, faucet common.Address) *types.Genesis
 	}
 }
 
+// DeveloperPoSGenesisBlock returns the genesis of the proof-of-stake developer chain (`--chain=dev --dev.pos`):
+// all forks up to Prague are active from genesis, system contracts (beacon roots, history storage,
+// withdrawal and consolidation requests, deposit contract) are deployed and the faucet is pre-funded.
+// Blocks are produced by the embedded mock consensus layer.
+func DeveloperPoSGenesisBlock(faucet common.Address) *types.Genesis {
+	var config chain.Config
+	copier.Copy(&config, chain.AllProtocolChanges)
+	config.DepositContract = devDepositContract
+
+	alloc := ReadPrealloc(allocs, "allocs/dev.json")
+	hoodi := ReadPrealloc(allocs, "allocs/hoodi.json")
+	for _, addr := range []common.Address{params.BeaconRootsAddress, params.HistoryStorageAddress,
+		params.WithdrawalRequestAddress, params.ConsolidationRequestAddress, devDepositContract} {
+		alloc[addr] = hoodi[addr]
+	}
+	if _, ok := alloc[faucet]; !ok {
+		alloc[faucet] = types.GenesisAccount{Balance: new(big.Int).Lsh(big.NewInt(1), 100)}
+	}
+	return &types.Genesis{
+		Config:     &config,
+		GasLimit:   36_000_000,
+		Difficulty: big.NewInt(0),
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Alloc:      alloc,
+	}
+}
+
+var devDepositContract = common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+
 var genesisBlockByChainName = make(map[string]*types.Genesis)
 
 func GenesisBlockByChainName(chain string) *types.Genesis {
@@ -0,0 +1,114 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package chainspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/snapcfg"
+)
+
+// RegistryEntry - operator-supplied overrides of embedded parameters of a known chain. Lists replace
+// embedded ones (nil - keep embedded), Config is merged over embedded chain config field by field.
+type RegistryEntry struct {
+	Bootnodes   []string        `json:"bootnodes,omitempty"`
+	StaticPeers []string        `json:"staticPeers,omitempty"`
+	Webseeds    []string        `json:"webseeds,omitempty"` // snapshot manifests sources
+	Config      json.RawMessage `json:"config,omitempty"`   // chainspec parameters, for example `{"osakaTime": 1760000000}`
+}
+
+// Registry - overrides by chain name. Embedded lists go stale between releases: registry file allows to update them without a new release.
+type Registry map[string]RegistryEntry
+
+func ReadRegistry(filename string) (Registry, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var registry Registry
+	if err := decoder.Decode(&registry); err != nil {
+		return nil, fmt.Errorf("could not parse chain registry %s: %w", filename, err)
+	}
+	return registry, nil
+}
+
+// ApplyRegistry - must be called at startup, before chain config, bootnodes and webseeds are read by name or genesis hash
+func ApplyRegistry(registry Registry) error {
+	for name, entry := range registry {
+		genesisHash := GenesisHashByChainName(name)
+		if genesisHash == nil {
+			return fmt.Errorf("chain registry: unknown chain %q", name)
+		}
+		if len(entry.Config) > 0 {
+			config, err := overrideChainConfig(chainConfigByName[name], entry.Config)
+			if err != nil {
+				return fmt.Errorf("chain registry: %s: %w", name, err)
+			}
+			chainConfigByName[name] = config
+			chainConfigByGenesisHash[*genesisHash] = config
+			if genesis := genesisBlockByChainName[name]; genesis != nil {
+				genesisCopy := *genesis
+				genesisCopy.Config = config
+				genesisBlockByChainName[name] = &genesisCopy
+			}
+		}
+		if entry.Bootnodes != nil {
+			bootNodeURLsByChainName[name] = entry.Bootnodes
+			bootNodeURLsByGenesisHash[*genesisHash] = entry.Bootnodes
+		}
+		if entry.StaticPeers != nil {
+			staticPeerURLsByChainName[name] = entry.StaticPeers
+		}
+		if entry.Webseeds != nil {
+			snapcfg.SetWebseeds(name, entry.Webseeds)
+		}
+	}
+	return nil
+}
+
+// overrideChainConfig - returns a copy of embedded config with fields present in `override` replaced. Embedded config is not modified.
+func overrideChainConfig(embedded *chain.Config, override json.RawMessage) (*chain.Config, error) {
+	data, err := json.Marshal(embedded)
+	if err != nil {
+		return nil, err
+	}
+	config := &chain.Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(override, config); err != nil {
+		return nil, err
+	}
+	if config.ChainID.Cmp(embedded.ChainID) != 0 {
+		return nil, fmt.Errorf("chainId can't be overridden: %d -> %d", embedded.ChainID, config.ChainID)
+	}
+	if config.ChainName != embedded.ChainName {
+		return nil, fmt.Errorf("chainName can't be overridden: %s -> %s", embedded.ChainName, config.ChainName)
+	}
+	if !bytes.Equal(config.BorJSON, embedded.BorJSON) {
+		return nil, errors.New("bor config can't be overridden")
+	}
+	config.Bor = embedded.Bor
+	return config, nil
+}
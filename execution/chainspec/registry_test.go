@@ -0,0 +1,84 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package chainspec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain/networkname"
+	"github.com/erigontech/erigon-lib/chain/snapcfg"
+)
+
+func TestOverrideChainConfig(t *testing.T) {
+	t.Parallel()
+	embeddedOsaka := SepoliaChainConfig.OsakaTime
+	config, err := overrideChainConfig(SepoliaChainConfig, []byte(`{"osakaTime": 1760000000}`))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1760000000), config.OsakaTime.Uint64())
+	require.Equal(t, SepoliaChainConfig.CancunTime, config.CancunTime)
+	require.Equal(t, embeddedOsaka, SepoliaChainConfig.OsakaTime) // embedded config is not modified
+
+	_, err = overrideChainConfig(SepoliaChainConfig, []byte(`{"chainId": 1}`))
+	require.ErrorContains(t, err, "chainId")
+	_, err = overrideChainConfig(SepoliaChainConfig, []byte(`{"osakaTime": "x"}`))
+	require.Error(t, err)
+}
+
+func TestApplyRegistry(t *testing.T) {
+	bootnodes, genesis := BootnodeURLsOfChain(networkname.Test), GenesisBlockByChainName(networkname.Test)
+	config := ChainConfigByChainName(networkname.Test)
+	t.Cleanup(func() {
+		bootNodeURLsByChainName[networkname.Test] = bootnodes
+		bootNodeURLsByGenesisHash[TestGenesisHash] = bootnodes
+		delete(staticPeerURLsByChainName, networkname.Test)
+		chainConfigByName[networkname.Test] = config
+		chainConfigByGenesisHash[TestGenesisHash] = config
+		genesisBlockByChainName[networkname.Test] = genesis
+		snapcfg.SetWebseeds(networkname.Test, nil)
+	})
+
+	filename := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{
+		"test": {
+			"bootnodes": ["enode://a@127.0.0.1:30303"],
+			"staticPeers": ["enode://b@127.0.0.1:30304"],
+			"webseeds": ["https://example.com/test/"],
+			"config": {"pragueTime": 100}
+		}
+	}`), 0644))
+	registry, err := ReadRegistry(filename)
+	require.NoError(t, err)
+	require.NoError(t, ApplyRegistry(registry))
+
+	require.Equal(t, []string{"enode://a@127.0.0.1:30303"}, BootnodeURLsOfChain(networkname.Test))
+	require.Equal(t, []string{"enode://a@127.0.0.1:30303"}, BootnodeURLsByGenesisHash(TestGenesisHash))
+	require.Equal(t, []string{"enode://b@127.0.0.1:30304"}, StaticPeerURLsOfChain(networkname.Test))
+	require.Equal(t, []string{"https://example.com/test/"}, snapcfg.KnownWebseeds[networkname.Test])
+	require.Equal(t, uint64(100), ChainConfigByChainName(networkname.Test).PragueTime.Uint64())
+	require.Equal(t, uint64(100), ChainConfigByGenesisHash(TestGenesisHash).PragueTime.Uint64())
+	require.Equal(t, uint64(100), GenesisBlockByChainName(networkname.Test).Config.PragueTime.Uint64())
+	require.NotSame(t, genesis, GenesisBlockByChainName(networkname.Test)) // embedded genesis is not modified
+
+	require.ErrorContains(t, ApplyRegistry(Registry{"no-such-chain": {}}), "unknown chain")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"test": {"bootnode": []}}`), 0644))
+	_, err = ReadRegistry(filename)
+	require.Error(t, err)
+}
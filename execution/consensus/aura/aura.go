@@ -1127,21 +1127,27 @@ func (c *AuRa) CalculateRewards(_ *chain.Config, header *types.Header, _ []*type
 	return []consensus.Reward{r}, nil
 }
 
-// See https://github.com/gnosischain/specs/blob/master/execution/withdrawals.md
-func (c *AuRa) ExecuteSystemWithdrawals(withdrawals []*types.Withdrawal, syscall consensus.SystemCall) error {
-	if c.cfg.WithdrawalContractAddress == nil {
-		return nil
-	}
+// MaxFailedWithdrawalsToProcess - how many previously failed withdrawals the contract retries per block
+const MaxFailedWithdrawalsToProcess = 4
 
-	maxFailedWithdrawalsToProcess := big.NewInt(4)
+// PackSystemWithdrawals - calldata of the `executeSystemWithdrawals` system call of the withdrawal contract
+func PackSystemWithdrawals(withdrawals []*types.Withdrawal) ([]byte, error) {
 	amounts := make([]uint64, 0, len(withdrawals))
 	addresses := make([]common.Address, 0, len(withdrawals))
 	for _, w := range withdrawals {
 		amounts = append(amounts, w.Amount)
 		addresses = append(addresses, w.Address)
 	}
+	return withdrawalAbi().Pack("executeSystemWithdrawals", big.NewInt(MaxFailedWithdrawalsToProcess), amounts, addresses)
+}
+
+// See https://github.com/gnosischain/specs/blob/master/execution/withdrawals.md
+func (c *AuRa) ExecuteSystemWithdrawals(withdrawals []*types.Withdrawal, syscall consensus.SystemCall) error {
+	if c.cfg.WithdrawalContractAddress == nil {
+		return nil
+	}
 
-	packed, err := withdrawalAbi().Pack("executeSystemWithdrawals", maxFailedWithdrawalsToProcess, amounts, addresses)
+	packed, err := PackSystemWithdrawals(withdrawals)
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ package engineapi
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/jwt"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
 	enginetypes "github.com/erigontech/erigon/execution/engineapi/engine_types"
 	"github.com/erigontech/erigon/rpc"
 )
@@ -264,6 +266,21 @@ func (c *JsonRpcClient) GetClientVersionV1(ctx context.Context, callerVersion *e
 	}, c.backOff(ctx))
 }
 
+// HeaderByNumber - eth_getBlockByNumber, which the engine API endpoint serves too
+func (c *JsonRpcClient) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	return backoff.RetryWithData(func() (*types.Header, error) {
+		var result *types.Header
+		err := c.rpcClient.CallContext(ctx, &result, "eth_getBlockByNumber", number, false)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return nil, backoff.Permanent(fmt.Errorf("block %s not found", number))
+		}
+		return result, nil
+	}, c.backOff(ctx))
+}
+
 func (c *JsonRpcClient) backOff(ctx context.Context) backoff.BackOff {
 	var backOff backoff.BackOff
 	backOff = backoff.NewConstantBackOff(c.retryBackOff)
@@ -0,0 +1,417 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engine_dev_cl
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/execution/consensus/merge"
+	"github.com/erigontech/erigon/execution/engineapi/engine_types"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// pendingPollInterval - how often the pool is checked for pending transactions when blocks are produced on demand
+const pendingPollInterval = 100 * time.Millisecond
+
+// Engine - subset of engine API used to produce blocks. Implemented by the in-process
+// engineapi.EngineServer and by engineapi.JsonRpcClient.
+type Engine interface {
+	ForkchoiceUpdatedV3(ctx context.Context, forkChoiceState *engine_types.ForkChoiceState, payloadAttributes *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error)
+	GetPayloadV3(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.GetPayloadResponse, error)
+	GetPayloadV4(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.GetPayloadResponse, error)
+	NewPayloadV3(ctx context.Context, executionPayload *engine_types.ExecutionPayload, expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash) (*engine_types.PayloadStatus, error)
+	NewPayloadV4(ctx context.Context, executionPayload *engine_types.ExecutionPayload, expectedBlobHashes []common.Hash, parentBeaconBlockRoot *common.Hash, executionRequests []hexutil.Bytes) (*engine_types.PayloadStatus, error)
+}
+
+type Config struct {
+	ChainConfig  *chain.Config // nil - engine API versions are detected by UnsupportedForkError
+	FeeRecipient common.Address
+	// Period - time between blocks, 0 - a block is produced as soon as HasPending reports pending transactions
+	Period     time.Duration
+	HasPending func(ctx context.Context) (bool, error)
+	// BuildTime - how long the block builder is given to fill the payload
+	BuildTime time.Duration
+	// MaxBlocks - Run returns after producing this number of blocks, 0 - no limit
+	MaxBlocks uint64
+	Scenario  Scenario
+}
+
+// Scenario - fork choice patterns to exercise the EL with. Zero value - a valid block every slot, immediately finalized.
+type Scenario struct {
+	MissEvery    uint64 // every n-th slot has no block, 0 - no missed slots
+	ReorgEvery   uint64 // every n-th block replaces the last ReorgDepth blocks by blocks of another branch, 0 - no reorgs
+	ReorgDepth   uint64 // 1 if not set
+	InvalidEvery uint64 // every n-th block is preceded by a payload with a wrong state root which must be rejected, 0 - none
+	FinalityLag  uint64 // safe and finalized blocks are behind the head by this number of blocks, at least ReorgDepth
+}
+
+// Stats - counters of what DevCL did, for reporting of load tests
+type Stats struct {
+	Blocks          uint64
+	MissedSlots     uint64
+	Reorgs          uint64
+	InvalidRejected uint64 // invalid payloads the EL rejected
+	InvalidAccepted uint64 // invalid payloads the EL didn't reject: bug of the EL
+}
+
+type blockRef struct {
+	hash      common.Hash
+	num, time uint64
+}
+
+// DevCL - mock consensus layer for single-node proof-of-stake developer chains. It drives block production
+// with the same engine API calls as a real CL: forkchoiceUpdated with payload attributes, getPayload,
+// newPayload and forkchoiceUpdated of the new head, which is immediately safe and finalized unless
+// Scenario sets finality lag.
+type DevCL struct {
+	cfg    Config
+	engine Engine
+	logger log.Logger
+
+	chain     []blockRef // recent canonical blocks, the last one is the head
+	finalized blockRef
+	withdrawn uint64 // index of the next withdrawal
+	slots     uint64
+	prague    bool // engine API version of the last payload, used when ChainConfig is nil
+	stats     Stats
+
+	mu          sync.Mutex
+	withdrawals []*types.Withdrawal
+}
+
+func New(cfg Config, engine Engine, head *types.Header, logger log.Logger) *DevCL {
+	if cfg.BuildTime == 0 {
+		cfg.BuildTime = 50 * time.Millisecond
+	}
+	if cfg.Scenario.ReorgEvery > 0 {
+		cfg.Scenario.ReorgDepth = max(cfg.Scenario.ReorgDepth, 1)
+		cfg.Scenario.FinalityLag = max(cfg.Scenario.FinalityLag, cfg.Scenario.ReorgDepth)
+	}
+	ref := blockRef{hash: head.Hash(), num: head.Number.Uint64(), time: head.Time}
+	return &DevCL{cfg: cfg, engine: engine, logger: logger, chain: []blockRef{ref}, finalized: ref, prague: true}
+}
+
+// AddWithdrawal - queues a withdrawal (amount in gwei) to be included into the next block
+func (cl *DevCL) AddWithdrawal(address common.Address, amount uint64) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	cl.withdrawals = append(cl.withdrawals, &types.Withdrawal{Address: address, Amount: amount})
+}
+
+func (cl *DevCL) takeWithdrawals() []*types.Withdrawal {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	res := make([]*types.Withdrawal, 0, len(cl.withdrawals))
+	for _, w := range cl.withdrawals {
+		w.Index = cl.withdrawn
+		w.Validator = 0
+		cl.withdrawn++
+		res = append(res, w)
+	}
+	cl.withdrawals = nil
+	return res
+}
+
+func (cl *DevCL) Stats() Stats { return cl.stats }
+
+func (cl *DevCL) head() blockRef { return cl.chain[len(cl.chain)-1] }
+
+func (cl *DevCL) Run(ctx context.Context) error {
+	interval := cl.cfg.Period
+	if interval == 0 {
+		interval = pendingPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	cl.logger.Info("[dev-cl] started", "period", cl.cfg.Period, "head", cl.head().num)
+	for cl.cfg.MaxBlocks == 0 || cl.stats.Blocks < cl.cfg.MaxBlocks {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if cl.cfg.Period == 0 && cl.cfg.HasPending != nil {
+			pending, err := cl.cfg.HasPending(ctx)
+			if err != nil {
+				return err
+			}
+			if !pending {
+				continue
+			}
+		}
+		if err := cl.Slot(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			cl.logger.Warn("[dev-cl] failed to produce block", "parent", cl.head().num, "err", err)
+		}
+	}
+	return nil
+}
+
+// Slot - produces the block of the next slot following Scenario: the slot can be missed, the block can
+// be preceded by an invalid payload or be the last one of a reorg
+func (cl *DevCL) Slot(ctx context.Context) error {
+	cl.slots++
+	sc := cl.cfg.Scenario
+	if sc.MissEvery > 0 && cl.slots%sc.MissEvery == 0 {
+		cl.stats.MissedSlots++
+		cl.logger.Info("[dev-cl] missed slot", "slot", cl.slots)
+		return nil
+	}
+	next := cl.stats.Blocks + 1
+	if sc.InvalidEvery > 0 && next%sc.InvalidEvery == 0 {
+		if err := cl.proposeInvalid(ctx); err != nil {
+			return err
+		}
+	}
+	if sc.ReorgEvery > 0 && next%sc.ReorgEvery == 0 && uint64(len(cl.chain)) > sc.ReorgDepth {
+		return cl.reorg(ctx, sc.ReorgDepth)
+	}
+	_, err := cl.BuildBlock(ctx)
+	return err
+}
+
+// reorg - replaces the last `depth` blocks by the same number of blocks built on their parent
+func (cl *DevCL) reorg(ctx context.Context, depth uint64) error {
+	abandoned := cl.head()
+	cl.chain = cl.chain[:uint64(len(cl.chain))-depth]
+	cl.stats.Reorgs++
+	for i := uint64(0); i < depth; i++ {
+		if _, err := cl.BuildBlock(ctx); err != nil {
+			return err
+		}
+	}
+	cl.logger.Info("[dev-cl] reorg", "depth", depth, "abandoned", abandoned.hash, "head", cl.head().hash)
+	return nil
+}
+
+// parentBeaconBlockRoot - there is no beacon chain: a deterministic stand-in derived from the block number
+func parentBeaconBlockRoot(num uint64) common.Hash {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], num)
+	return crypto.Keccak256Hash([]byte("dev-cl-beacon-root"), b[:])
+}
+
+type builtPayload struct {
+	payload    *engine_types.ExecutionPayload
+	requests   []hexutil.Bytes // nil - pre-Prague
+	beaconRoot common.Hash
+	blobHashes []common.Hash
+}
+
+// buildPayload - asks the EL to build a payload on top of the head
+func (cl *DevCL) buildPayload(ctx context.Context) (*builtPayload, error) {
+	head := cl.head()
+	timestamp := max(uint64(time.Now().Unix()), head.time+1)
+	beaconRoot := parentBeaconBlockRoot(head.num + 1)
+	var randao common.Hash
+	binary.BigEndian.PutUint64(randao[16:], cl.stats.Reorgs) // blocks of another branch differ
+	binary.BigEndian.PutUint64(randao[24:], head.num+1)
+
+	fcuRes, err := cl.engine.ForkchoiceUpdatedV3(ctx, cl.forkChoice(), &engine_types.PayloadAttributes{
+		Timestamp:             hexutil.Uint64(timestamp),
+		PrevRandao:            randao,
+		SuggestedFeeRecipient: cl.cfg.FeeRecipient,
+		Withdrawals:           cl.takeWithdrawals(),
+		ParentBeaconBlockRoot: &beaconRoot,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fcuRes.PayloadStatus.Status != engine_types.ValidStatus {
+		return nil, fmt.Errorf("forkchoiceUpdated with payload attributes: status %s", fcuRes.PayloadStatus.Status)
+	}
+	if fcuRes.PayloadId == nil {
+		return nil, errors.New("forkchoiceUpdated with payload attributes: no payload id")
+	}
+
+	if err := common.Sleep(ctx, cl.cfg.BuildTime); err != nil {
+		return nil, err
+	}
+
+	if cl.cfg.ChainConfig != nil {
+		cl.prague = cl.cfg.ChainConfig.IsPrague(timestamp)
+	}
+	payloadRes, err := cl.getPayload(ctx, *fcuRes.PayloadId)
+	if err != nil {
+		return nil, err
+	}
+	res := &builtPayload{payload: payloadRes.ExecutionPayload, beaconRoot: beaconRoot}
+	if cl.prague {
+		if res.requests = payloadRes.ExecutionRequests; res.requests == nil {
+			res.requests = []hexutil.Bytes{}
+		}
+	}
+	if res.blobHashes, err = expectedBlobHashes(res.payload); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (cl *DevCL) getPayload(ctx context.Context, id hexutil.Bytes) (*engine_types.GetPayloadResponse, error) {
+	get := func() (*engine_types.GetPayloadResponse, error) {
+		if cl.prague {
+			return cl.engine.GetPayloadV4(ctx, id)
+		}
+		return cl.engine.GetPayloadV3(ctx, id)
+	}
+	res, err := get()
+	var unsupported rpc.Error
+	if cl.cfg.ChainConfig == nil && errors.As(err, &unsupported) && unsupported.ErrorCode() == (&rpc.UnsupportedForkError{}).ErrorCode() {
+		cl.prague = !cl.prague
+		return get()
+	}
+	return res, err
+}
+
+func (cl *DevCL) newPayload(ctx context.Context, p *builtPayload) (*engine_types.PayloadStatus, error) {
+	if p.requests != nil {
+		return cl.engine.NewPayloadV4(ctx, p.payload, p.blobHashes, &p.beaconRoot, p.requests)
+	}
+	return cl.engine.NewPayloadV3(ctx, p.payload, p.blobHashes, &p.beaconRoot)
+}
+
+func (cl *DevCL) forkChoice() *engine_types.ForkChoiceState {
+	head := cl.head().hash
+	return &engine_types.ForkChoiceState{HeadHash: head, SafeBlockHash: cl.finalized.hash, FinalizedBlockHash: cl.finalized.hash}
+}
+
+// BuildBlock - produces and inserts a block on top of the current head and makes it the head
+func (cl *DevCL) BuildBlock(ctx context.Context) (*engine_types.ExecutionPayload, error) {
+	p, err := cl.buildPayload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payload := p.payload
+	status, err := cl.newPayload(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if status.Status != engine_types.ValidStatus {
+		return nil, fmt.Errorf("newPayload: status %s, err %v", status.Status, status.ValidationError)
+	}
+
+	cl.chain = append(cl.chain, blockRef{hash: payload.BlockHash, num: uint64(payload.BlockNumber), time: uint64(payload.Timestamp)})
+	lag := cl.cfg.Scenario.FinalityLag
+	if n := uint64(len(cl.chain)); n > lag && cl.chain[n-1-lag].num > cl.finalized.num {
+		cl.finalized = cl.chain[n-1-lag]
+	}
+	if keep := max(lag, cl.cfg.Scenario.ReorgDepth) + 1; uint64(len(cl.chain)) > keep {
+		cl.chain = slices.Delete(cl.chain, 0, len(cl.chain)-int(keep))
+	}
+	fcuRes, err := cl.engine.ForkchoiceUpdatedV3(ctx, cl.forkChoice(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if fcuRes.PayloadStatus.Status != engine_types.ValidStatus {
+		return nil, fmt.Errorf("forkchoiceUpdated: status %s", fcuRes.PayloadStatus.Status)
+	}
+
+	cl.stats.Blocks++
+	cl.logger.Info("[dev-cl] produced block", "number", payload.BlockNumber, "hash", payload.BlockHash, "txs", len(payload.Transactions), "blobs", len(p.blobHashes))
+	return payload, nil
+}
+
+// proposeInvalid - sends a payload built on the head with a wrong state root (and consistent block hash),
+// the EL must find it invalid by execution
+func (cl *DevCL) proposeInvalid(ctx context.Context) error {
+	p, err := cl.buildPayload(ctx)
+	if err != nil {
+		return err
+	}
+	p.payload.StateRoot = crypto.Keccak256Hash(p.payload.StateRoot[:])
+	p.payload.BlockHash = payloadHeader(p).Hash()
+	status, err := cl.newPayload(ctx, p)
+	if err != nil {
+		return err
+	}
+	if status.Status != engine_types.InvalidStatus {
+		cl.stats.InvalidAccepted++
+		cl.logger.Error("[dev-cl] invalid payload is not rejected", "number", p.payload.BlockNumber, "hash", p.payload.BlockHash, "status", status.Status)
+		return nil
+	}
+	cl.stats.InvalidRejected++
+	cl.logger.Info("[dev-cl] invalid payload rejected", "number", p.payload.BlockNumber, "hash", p.payload.BlockHash, "err", status.ValidationError)
+	return nil
+}
+
+// payloadHeader - header of the block of the payload, as the EL assembles it
+func payloadHeader(p *builtPayload) *types.Header {
+	payload := p.payload
+	txs := make([][]byte, len(payload.Transactions))
+	for i, txn := range payload.Transactions {
+		txs[i] = txn
+	}
+	withdrawalsHash := types.DeriveSha(types.Withdrawals(payload.Withdrawals))
+	h := &types.Header{
+		ParentHash:            payload.ParentHash,
+		UncleHash:             empty.UncleHash,
+		Coinbase:              payload.FeeRecipient,
+		Root:                  payload.StateRoot,
+		TxHash:                types.DeriveSha(types.BinaryTransactions(txs)),
+		ReceiptHash:           payload.ReceiptsRoot,
+		Bloom:                 types.BytesToBloom(payload.LogsBloom),
+		Difficulty:            merge.ProofOfStakeDifficulty,
+		Number:                new(big.Int).SetUint64(uint64(payload.BlockNumber)),
+		GasLimit:              uint64(payload.GasLimit),
+		GasUsed:               uint64(payload.GasUsed),
+		Time:                  uint64(payload.Timestamp),
+		Extra:                 payload.ExtraData,
+		MixDigest:             payload.PrevRandao,
+		Nonce:                 merge.ProofOfStakeNonce,
+		BaseFee:               (*big.Int)(payload.BaseFeePerGas),
+		WithdrawalsHash:       &withdrawalsHash,
+		BlobGasUsed:           (*uint64)(payload.BlobGasUsed),
+		ExcessBlobGas:         (*uint64)(payload.ExcessBlobGas),
+		ParentBeaconBlockRoot: &p.beaconRoot,
+	}
+	if p.requests != nil {
+		requests := make(types.FlatRequests, 0, len(p.requests))
+		for _, r := range p.requests {
+			requests = append(requests, types.FlatRequest{Type: r[0], RequestData: r[1:]})
+		}
+		h.RequestsHash = requests.Hash()
+	}
+	return h
+}
+
+func expectedBlobHashes(payload *engine_types.ExecutionPayload) ([]common.Hash, error) {
+	hashes := []common.Hash{}
+	for _, enc := range payload.Transactions {
+		txn, err := types.DecodeTransaction(enc)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, txn.GetBlobHashes()...)
+	}
+	return hashes, nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engine_dev_cl
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/execution/consensus/merge"
+	"github.com/erigontech/erigon/execution/engineapi"
+	"github.com/erigontech/erigon/execution/engineapi/engine_types"
+)
+
+var _ Engine = (*engineapi.EngineServer)(nil)
+var _ Engine = (*engineapi.JsonRpcClient)(nil)
+
+// fakeEngine - builds an empty payload on top of the fork choice head
+type fakeEngine struct {
+	calls      []string
+	head       common.Hash
+	attributes *engine_types.PayloadAttributes
+	parent     uint64 // number of built payloads
+
+	nums          map[common.Hash]uint64 // block numbers of built payloads
+	finalized     uint64
+	acceptInvalid bool
+}
+
+func (e *fakeEngine) ForkchoiceUpdatedV3(ctx context.Context, fcs *engine_types.ForkChoiceState, attrs *engine_types.PayloadAttributes) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	e.calls = append(e.calls, "fcu")
+	e.head = fcs.HeadHash
+	if n := e.nums[fcs.FinalizedBlockHash]; n < e.finalized {
+		return nil, fmt.Errorf("finalized block moved back from %d to %d", e.finalized, n)
+	} else {
+		e.finalized = n
+	}
+	res := &engine_types.ForkChoiceUpdatedResponse{PayloadStatus: &engine_types.PayloadStatus{Status: engine_types.ValidStatus}}
+	if attrs != nil {
+		e.attributes = attrs
+		id := hexutil.Bytes{1}
+		res.PayloadId = &id
+	}
+	return res, nil
+}
+
+func (e *fakeEngine) getPayload(name string) (*engine_types.GetPayloadResponse, error) {
+	e.calls = append(e.calls, name)
+	e.parent++
+	if e.nums == nil {
+		e.nums = map[common.Hash]uint64{}
+	}
+	hash := common.Hash{byte(e.parent)}
+	e.nums[hash] = e.nums[e.head] + 1
+	return &engine_types.GetPayloadResponse{ExecutionPayload: &engine_types.ExecutionPayload{
+		ParentHash:   e.head,
+		BlockHash:    hash,
+		BlockNumber:  hexutil.Uint64(e.nums[hash]),
+		Timestamp:    e.attributes.Timestamp,
+		Withdrawals:  e.attributes.Withdrawals,
+		Transactions: []hexutil.Bytes{},
+	}}, nil
+}
+
+func (e *fakeEngine) GetPayloadV3(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.GetPayloadResponse, error) {
+	return e.getPayload("getPayloadV3")
+}
+
+func (e *fakeEngine) GetPayloadV4(ctx context.Context, payloadID hexutil.Bytes) (*engine_types.GetPayloadResponse, error) {
+	return e.getPayload("getPayloadV4")
+}
+
+func (e *fakeEngine) NewPayloadV3(ctx context.Context, payload *engine_types.ExecutionPayload, blobHashes []common.Hash, beaconRoot *common.Hash) (*engine_types.PayloadStatus, error) {
+	e.calls = append(e.calls, "newPayloadV3")
+	return &engine_types.PayloadStatus{Status: engine_types.ValidStatus}, nil
+}
+
+func (e *fakeEngine) NewPayloadV4(ctx context.Context, payload *engine_types.ExecutionPayload, blobHashes []common.Hash, beaconRoot *common.Hash, requests []hexutil.Bytes) (*engine_types.PayloadStatus, error) {
+	e.calls = append(e.calls, "newPayloadV4")
+	if payload.StateRoot != (common.Hash{}) && !e.acceptInvalid { // tampered by proposeInvalid
+		return &engine_types.PayloadStatus{Status: engine_types.InvalidStatus}, nil
+	}
+	return &engine_types.PayloadStatus{Status: engine_types.ValidStatus}, nil
+}
+
+func TestDevCL_BuildBlock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	genesis := &types.Header{Number: big.NewInt(0), Time: uint64(time.Now().Unix()) + 100}
+	engine := &fakeEngine{}
+	recipient := common.Address{0xfe}
+	cl := New(Config{ChainConfig: chain.AllProtocolChanges, FeeRecipient: recipient, BuildTime: time.Millisecond}, engine, genesis, log.New())
+
+	cl.AddWithdrawal(common.Address{1}, 10)
+	cl.AddWithdrawal(common.Address{2}, 20)
+	payload, err := cl.BuildBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fcu", "getPayloadV4", "newPayloadV4", "fcu"}, engine.calls)
+	require.Equal(t, genesis.Hash(), payload.ParentHash)
+	require.Equal(t, recipient, engine.attributes.SuggestedFeeRecipient)
+	require.Equal(t, genesis.Time+1, uint64(payload.Timestamp)) // genesis is in the future: timestamps must grow anyway
+	require.NotNil(t, engine.attributes.ParentBeaconBlockRoot)
+	require.Len(t, payload.Withdrawals, 2)
+	require.Equal(t, uint64(0), payload.Withdrawals[0].Index)
+	require.Equal(t, uint64(1), payload.Withdrawals[1].Index)
+	require.Equal(t, payload.BlockHash, engine.head) // new block is the head
+
+	cl.AddWithdrawal(common.Address{3}, 30)
+	payload2, err := cl.BuildBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, payload.BlockHash, payload2.ParentHash)
+	require.Greater(t, payload2.Timestamp, payload.Timestamp)
+	require.Len(t, payload2.Withdrawals, 1)
+	require.Equal(t, uint64(2), payload2.Withdrawals[0].Index)
+}
+
+func TestDevCL_PrePrague(t *testing.T) {
+	t.Parallel()
+	cfg := &chain.Config{ChainID: big.NewInt(1337), ShanghaiTime: big.NewInt(0), CancunTime: big.NewInt(0)}
+	engine := &fakeEngine{}
+	cl := New(Config{ChainConfig: cfg, BuildTime: time.Millisecond}, engine, &types.Header{Number: big.NewInt(0)}, log.New())
+	_, err := cl.BuildBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"fcu", "getPayloadV3", "newPayloadV3", "fcu"}, engine.calls)
+}
+
+func TestDevCL_RunOnDemand(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	engine := &fakeEngine{}
+	pending := 0
+	cl := New(Config{
+		ChainConfig: chain.AllProtocolChanges,
+		BuildTime:   time.Millisecond,
+		HasPending: func(ctx context.Context) (bool, error) {
+			pending++
+			if pending == 3 { // 1 block after a few polls, then stop
+				return true, nil
+			}
+			if pending > 5 {
+				cancel()
+			}
+			return false, nil
+		},
+	}, engine, &types.Header{Number: big.NewInt(0)}, log.New())
+	require.ErrorIs(t, cl.Run(ctx), context.Canceled)
+	require.Equal(t, uint64(1), engine.parent)
+}
+
+func TestDevCL_Scenario(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	engine := &fakeEngine{}
+	cl := New(Config{
+		ChainConfig: chain.AllProtocolChanges,
+		BuildTime:   time.Millisecond,
+		Scenario:    Scenario{MissEvery: 3, ReorgEvery: 4, ReorgDepth: 2, InvalidEvery: 5},
+	}, engine, &types.Header{Number: big.NewInt(0)}, log.New())
+	for i := 0; i < 12; i++ {
+		require.NoError(t, cl.Slot(ctx))
+	}
+	// slots 3, 6, 9, 12 are missed; blocks 4-5 and 8-9 replace the last 2 blocks; block 10 follows an invalid payload
+	require.Equal(t, Stats{Blocks: 10, MissedSlots: 4, Reorgs: 2, InvalidRejected: 1}, cl.Stats())
+	require.Equal(t, uint64(6), engine.nums[engine.head])
+	require.Equal(t, uint64(4), engine.finalized) // finality lag is raised to the reorg depth
+	require.Equal(t, uint64(11), engine.parent)   // 10 blocks and the invalid payload
+}
+
+func TestDevCL_InvalidAccepted(t *testing.T) {
+	t.Parallel()
+	engine := &fakeEngine{acceptInvalid: true}
+	cl := New(Config{
+		ChainConfig: chain.AllProtocolChanges,
+		BuildTime:   time.Millisecond,
+		Scenario:    Scenario{InvalidEvery: 1},
+	}, engine, &types.Header{Number: big.NewInt(0)}, log.New())
+	require.NoError(t, cl.Slot(context.Background()))
+	require.Equal(t, Stats{Blocks: 1, InvalidAccepted: 1}, cl.Stats())
+}
+
+func TestPayloadHeader(t *testing.T) {
+	t.Parallel()
+	header := &types.Header{
+		ParentHash: common.Hash{1}, UncleHash: empty.UncleHash, Root: common.Hash{2}, TxHash: empty.RootHash,
+		ReceiptHash: empty.RootHash, Difficulty: merge.ProofOfStakeDifficulty, Number: big.NewInt(7), GasLimit: 30_000_000,
+		Time: 100, Nonce: merge.ProofOfStakeNonce, BaseFee: big.NewInt(7), WithdrawalsHash: &empty.RootHash,
+		BlobGasUsed: new(uint64), ExcessBlobGas: new(uint64), ParentBeaconBlockRoot: &common.Hash{3},
+		RequestsHash: &empty.RequestsHash,
+	}
+	p := &builtPayload{
+		payload: &engine_types.ExecutionPayload{
+			ParentHash: header.ParentHash, StateRoot: header.Root, ReceiptsRoot: header.ReceiptHash,
+			LogsBloom: header.Bloom[:], BlockNumber: 7, GasLimit: 30_000_000, Timestamp: 100, ExtraData: []byte{},
+			BaseFeePerGas: (*hexutil.Big)(header.BaseFee), Transactions: []hexutil.Bytes{}, Withdrawals: []*types.Withdrawal{},
+			BlobGasUsed: new(hexutil.Uint64), ExcessBlobGas: new(hexutil.Uint64),
+		},
+		beaconRoot: common.Hash{3},
+		requests:   []hexutil.Bytes{},
+	}
+	require.Equal(t, header.Hash(), payloadHeader(p).Hash())
+}
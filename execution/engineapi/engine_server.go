@@ -939,7 +939,10 @@ func (e *EngineServer) SetConsuming(consuming bool) {
 	e.consuming.Store(consuming)
 }
 
-func (e *EngineServer) getBlobs(ctx context.Context, blobHashes []common.Hash, version clparams.StateVersion) (any, error) {
+// getBlobs - blobs and proofs of the pool by their versioned hashes. Missing ones are nil: after Fulu, the whole
+// response is nil if any is missing, unless partial (engine_getBlobsV3). The blobs of the txns with the proofs of
+// the other wrapper version than the one of the fork are missing too.
+func (e *EngineServer) getBlobs(ctx context.Context, blobHashes []common.Hash, version clparams.StateVersion, partial bool) (any, error) {
 	if len(blobHashes) > 128 {
 		return nil, &engine_helpers.TooLargeRequestErr
 	}
@@ -951,40 +954,40 @@ func (e *EngineServer) getBlobs(ctx context.Context, blobHashes []common.Hash, v
 	if err != nil {
 		return nil, err
 	}
+	if len(res.BlobsAndProofs) != len(blobHashes) { // Some fault in the underlying txpool
+		e.logger.Warn("[GetBlobs] txpool returned unexpected number of blobs in response, returning nil blobs list", "expected", len(blobHashes), "got", len(res.BlobsAndProofs))
+		if version == clparams.CapellaVersion {
+			return make([]*engine_types.BlobAndProofV1, len(blobHashes)), nil
+		}
+		return nil, nil
+	}
 	logLine := []string{}
 
 	if version == clparams.FuluVersion {
 		ret := make([]*engine_types.BlobAndProofV2, len(blobHashes))
-		if len(blobHashes) != len(res.Blobs) || len(blobHashes)*int(params.CellsPerExtBlob) != len(res.Proofs) {
-			log.Warn("[GetBlobsV2] txpool returned unexpected number of blobs and proofs in response, returning nil blobs list")
-			return nil, nil
-		}
-		for i := range res.Blobs {
-			if res.Blobs[i] == nil {
-				// We return a "null" response
-				ret = nil
-				logLine = append(logLine, fmt.Sprintf(" %d:", i), " nil, returning nil")
-				break
-			} else {
-				ret[i] = &engine_types.BlobAndProofV2{Blob: res.Blobs[i], CellProofs: make([]hexutil.Bytes, params.CellsPerExtBlob)}
-				for c := range params.CellsPerExtBlob {
-					ret[i].CellProofs[c] = res.Proofs[i*int(params.CellsPerExtBlob)+int(c)]
+		for i, bp := range res.BlobsAndProofs {
+			if !bp.Found || len(bp.Proofs) != int(params.CellsPerExtBlob) {
+				logLine = append(logLine, fmt.Sprintf(" %d:", i), fmt.Sprintf(" hash=%x nil, found=%t len(proofs)=%d", blobHashes[i], bp.Found, len(bp.Proofs)))
+				if !partial {
+					e.logger.Debug("[GetBlobsV2]", "Responses", logLine, "result", "nil")
+					return nil, nil
 				}
-				logLine = append(logLine, fmt.Sprintf(" %d:", i), fmt.Sprintf(" hash=%x len(blob)=%d len(cellProofs)=%d ", blobHashes[i], len(res.Blobs[i]), len(ret[i].CellProofs)))
+				continue
+			}
+			ret[i] = &engine_types.BlobAndProofV2{Blob: bp.Blob, CellProofs: make([]hexutil.Bytes, len(bp.Proofs))}
+			for c := range bp.Proofs {
+				ret[i].CellProofs[c] = bp.Proofs[c]
 			}
+			logLine = append(logLine, fmt.Sprintf(" %d:", i), fmt.Sprintf(" hash=%x len(blob)=%d len(cellProofs)=%d ", blobHashes[i], len(bp.Blob), len(ret[i].CellProofs)))
 		}
-		e.logger.Debug("[GetBlobsV2]", "Responses", logLine)
+		e.logger.Debug("[GetBlobsV2]", "partial", partial, "Responses", logLine)
 		return ret, nil
 	} else if version == clparams.CapellaVersion {
 		ret := make([]*engine_types.BlobAndProofV1, len(blobHashes))
-		if len(blobHashes) != len(res.Blobs) || len(blobHashes) != len(res.Proofs) { // Some fault in the underlying txpool, but still return sane resp
-			log.Warn("[GetBlobsV1] txpool returned unexpected number of blobs and proofs in response, returning nil blobs list")
-			return ret, nil
-		}
-		for i := range res.Blobs {
-			if res.Blobs[i] != nil {
-				ret[i] = &engine_types.BlobAndProofV1{Blob: res.Blobs[i], Proof: res.Proofs[i]}
-				logLine = append(logLine, fmt.Sprintf(" %d:", i), fmt.Sprintf(" hash=%x len(blob)=%d len(proof)=%d ", blobHashes[i], len(res.Blobs[i]), len(res.Proofs[i])))
+		for i, bp := range res.BlobsAndProofs {
+			if bp.Found && len(bp.Proofs) == 1 {
+				ret[i] = &engine_types.BlobAndProofV1{Blob: bp.Blob, Proof: bp.Proofs[0]}
+				logLine = append(logLine, fmt.Sprintf(" %d:", i), fmt.Sprintf(" hash=%x len(blob)=%d len(proof)=%d ", blobHashes[i], len(bp.Blob), len(bp.Proofs[0])))
 			} else {
 				logLine = append(logLine, fmt.Sprintf(" %d:", i), " nil")
 			}
@@ -166,6 +166,15 @@ func TestGetBlobsV2(t *testing.T) {
 	require.NoError(err)
 	require.Nil(blobsResp) // Any one blob not found makes the whole response nil
 
+	// V3 returns the found ones
+	blobsResp, err = engineServer.GetBlobsV3(ctx, blobHashes)
+	require.NoError(err)
+	require.Len(blobsResp, 3)
+	require.Nil(blobsResp[0])
+	require.Equal(blobsResp[1].Blob, hexutil.Bytes(wrappedTxn.Blobs[0][:]))
+	require.Equal(blobsResp[2].Blob, hexutil.Bytes(wrappedTxn.Blobs[1][:]))
+	require.Len(blobsResp[2].CellProofs, 128)
+
 	blobHashes = blobHashes[1:]
 	blobsResp, err = engineServer.GetBlobsV2(ctx, blobHashes)
 	require.NoError(err)
@@ -0,0 +1,230 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// BadBlocksDir - directory of the bad block pool, in the datadir
+const BadBlocksDir = "badblocks"
+
+// Sources of bad blocks
+const (
+	BadBlockSourceValidation = "validation" // engine_newPayload (ValidateChain)
+	BadBlockSourceSync       = "sync"       // staged sync: blocks from p2p and forkchoice updates
+)
+
+// BadBlock - an invalid block kept for incident response, with the validation error
+type BadBlock struct {
+	Hash   common.Hash   `json:"hash"`
+	Number uint64        `json:"number"`
+	RLP    hexutil.Bytes `json:"rlp"`
+	Error  string        `json:"error"`
+	Field  string        `json:"field,omitempty"` // offending field of the header, empty if not known
+	Source string        `json:"source"`
+	Time   time.Time     `json:"time"`
+}
+
+// Block decodes the kept block
+func (b *BadBlock) Block() (*types.Block, error) {
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(b.RLP, block); err != nil {
+		return nil, fmt.Errorf("decode bad block %x: %w", b.Hash, err)
+	}
+	return block, nil
+}
+
+func (b *BadBlock) fileName() string {
+	return fmt.Sprintf("%d-%x.json", b.Number, b.Hash)
+}
+
+// BadBlocks - pool of the last invalid blocks. Invalid blocks are purged from the db, so the pool keeps them
+// in files of its directory (one json per block): they survive restarts and can be dumped while the node is down.
+type BadBlocks struct {
+	dir    string
+	limit  int
+	logger log.Logger
+
+	lock   sync.Mutex
+	blocks []*BadBlock // oldest first
+}
+
+// OpenBadBlocks loads the pool kept in dir, limit - number of blocks kept
+func OpenBadBlocks(dir string, limit int, logger log.Logger) (*BadBlocks, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("bad blocks limit must be > 0, got %d", limit)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	blocks, err := ReadBadBlocks(dir)
+	if err != nil {
+		return nil, err
+	}
+	p := &BadBlocks{dir: dir, limit: limit, logger: logger, blocks: blocks}
+	p.prune()
+	return p, nil
+}
+
+// Add keeps the block, replacing the oldest one beyond the limit. Failures to persist it are logged only:
+// the pool must not fail validation. nil pool - no-op.
+func (p *BadBlocks) Add(block *types.Block, validationErr error, source string) {
+	if p == nil || block == nil {
+		return
+	}
+	encoded, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		p.logger.Warn("[bad blocks] failed to encode block", "hash", block.Hash(), "err", err)
+		return
+	}
+	b := &BadBlock{
+		Hash:   block.Hash(),
+		Number: block.NumberU64(),
+		RLP:    encoded,
+		Source: source,
+		Time:   time.Now().UTC(),
+	}
+	if validationErr != nil {
+		b.Error = validationErr.Error()
+		b.Field = OffendingField(b.Error)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, known := range p.blocks {
+		if known.Hash == b.Hash {
+			p.blocks = append(p.blocks[:i], p.blocks[i+1:]...)
+			break
+		}
+	}
+	p.blocks = append(p.blocks, b)
+	if err := writeBadBlock(p.dir, b); err != nil {
+		p.logger.Warn("[bad blocks] failed to write block", "hash", b.Hash, "err", err)
+	}
+	p.prune()
+	p.logger.Info("[bad blocks] kept invalid block", "number", b.Number, "hash", b.Hash, "source", source, "field", b.Field, "err", b.Error)
+}
+
+// List returns the kept blocks, the most recent first
+func (p *BadBlocks) List() []*BadBlock {
+	if p == nil {
+		return nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	res := make([]*BadBlock, len(p.blocks))
+	for i, b := range p.blocks {
+		res[len(res)-1-i] = b
+	}
+	return res
+}
+
+// prune drops the oldest blocks beyond the limit, with their files
+func (p *BadBlocks) prune() {
+	for len(p.blocks) > p.limit {
+		if err := os.Remove(filepath.Join(p.dir, p.blocks[0].fileName())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			p.logger.Warn("[bad blocks] failed to remove block", "hash", p.blocks[0].Hash, "err", err)
+		}
+		p.blocks = p.blocks[1:]
+	}
+}
+
+func writeBadBlock(dir string, b *BadBlock) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, b.fileName())
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// ReadBadBlocks reads the pool kept in dir, oldest first. Missing dir - empty pool.
+func ReadBadBlocks(dir string) ([]*BadBlock, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]*BadBlock, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		b := new(BadBlock)
+		if err := json.Unmarshal(data, b); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		blocks = append(blocks, b)
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if !blocks[i].Time.Equal(blocks[j].Time) {
+			return blocks[i].Time.Before(blocks[j].Time)
+		}
+		return blocks[i].Number < blocks[j].Number
+	})
+	return blocks, nil
+}
+
+// offendingFields - messages of the validation errors of a header field, see core.BlockPostValidation and
+// the consensus engines
+var offendingFields = []struct {
+	message, field string
+}{
+	{"invalid state root hash", "stateRoot"},
+	{"receiptHash mismatch", "receiptsRoot"},
+	{"invalid bloom", "logsBloom"},
+	{"blobGasUsed by execution", "blobGasUsed"},
+	{"gas used by execution", "gasUsed"},
+	{"invalid gasUsed", "gasUsed"},
+	{"invalid gasLimit", "gasLimit"},
+	{"invalid gas limit", "gasLimit"},
+	{"invalid baseFee", "baseFeePerGas"},
+	{"invalid excessBlobGas", "excessBlobGas"},
+	{"invalid blobGasUsed", "blobGasUsed"},
+	{"invalid parentBeaconBlockRoot", "parentBeaconBlockRoot"},
+	{"invalid requests root hash", "requestsHash"},
+	{"invalid block number", "number"},
+	{"INVALID_BLOCK_HASH", "blockHash"},
+}
+
+// OffendingField returns the header field which failed the validation, empty if not known
+func OffendingField(validationErr string) string {
+	for _, f := range offendingFields {
+		if strings.Contains(validationErr, f.message) {
+			return f.field
+		}
+	}
+	return ""
+}
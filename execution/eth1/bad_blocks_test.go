@@ -0,0 +1,75 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth1
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func TestBadBlocks(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), BadBlocksDir)
+	pool, err := OpenBadBlocks(dir, 2, log.New())
+	require.NoError(t, err)
+
+	blocks := make([]*types.Block, 3)
+	for i := range blocks {
+		header := &types.Header{Number: big.NewInt(int64(i + 1)), Difficulty: big.NewInt(0), Extra: []byte{byte(i)}}
+		blocks[i] = types.NewBlockFromNetwork(header, &types.Body{})
+	}
+	pool.Add(blocks[0], errors.New("invalid state root hash"), BadBlockSourceSync)
+	pool.Add(blocks[1], fmt.Errorf("receiptHash mismatch: %x != %x", []byte{1}, []byte{2}), BadBlockSourceValidation)
+	pool.Add(blocks[1], errors.New("invalid bloom"), BadBlockSourceValidation) // replaces the previous one
+	pool.Add(blocks[2], errors.New("unknown failure"), BadBlockSourceValidation)
+
+	list := pool.List()
+	require.Len(t, list, 2) // the oldest is dropped
+	require.Equal(t, blocks[2].Hash(), list[0].Hash)
+	require.Empty(t, list[0].Field)
+	require.Equal(t, blocks[1].Hash(), list[1].Hash)
+	require.Equal(t, "logsBloom", list[1].Field)
+	require.Equal(t, "invalid bloom", list[1].Error)
+
+	block, err := list[1].Block()
+	require.NoError(t, err)
+	require.Equal(t, blocks[1].Hash(), block.Hash())
+
+	// the pool survives restarts
+	kept, err := ReadBadBlocks(dir)
+	require.NoError(t, err)
+	require.Len(t, kept, 2)
+	require.Equal(t, blocks[1].Hash(), kept[0].Hash)
+	reopened, err := OpenBadBlocks(dir, 1, log.New())
+	require.NoError(t, err)
+	require.Len(t, reopened.List(), 1)
+	require.Equal(t, blocks[2].Hash(), reopened.List()[0].Hash)
+	kept, err = ReadBadBlocks(dir)
+	require.NoError(t, err)
+	require.Len(t, kept, 1)
+
+	require.Equal(t, "stateRoot", OffendingField("[4/6 Execution] invalid state root hash"))
+	require.Equal(t, "blobGasUsed", OffendingField("blobGasUsed by execution: 1, in header: 2"))
+	require.Equal(t, "gasUsed", OffendingField("gas used by execution: 1, in header: 2"))
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/execution/builder"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// MaxDryRunBuildTime - dry-run building competes with real payload building for CPU and txpool
+const MaxDryRunBuildTime = 12 * time.Second
+
+// ErrDryRunBusy - execution module is inserting blocks, updating forkchoice or starting a payload build
+var ErrDryRunBusy = errors.New("execution module is busy, retry later")
+
+// DryRunBlock - payload built by BuildBlockDryRun, with the data validators compare with relay bids
+type DryRunBlock struct {
+	BlockHash    common.Hash      `json:"blockHash"`
+	ParentHash   common.Hash      `json:"parentHash"`
+	Number       hexutil.Uint64   `json:"number"`
+	Timestamp    hexutil.Uint64   `json:"timestamp"`
+	FeeRecipient common.Address   `json:"feeRecipient"`
+	GasLimit     hexutil.Uint64   `json:"gasLimit"`
+	GasUsed      hexutil.Uint64   `json:"gasUsed"`
+	BlobGasUsed  *hexutil.Uint64  `json:"blobGasUsed,omitempty"`
+	BaseFee      *hexutil.Big     `json:"baseFeePerGas"`
+	Value        *hexutil.Big     `json:"value"` // expected to be received by the fee recipient, wei
+	Requests     int              `json:"executionRequests"`
+	Transactions []DryRunBlockTxn `json:"transactions"`
+}
+
+type DryRunBlockTxn struct {
+	Hash         common.Hash    `json:"hash"`
+	Type         hexutil.Uint64 `json:"type"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	EffectiveTip *hexutil.Big   `json:"effectiveTip"`
+}
+
+// BuildBlockDryRun - builds a payload on top of the current head the same way AssembleBlock does, but without
+// forkchoice interaction: the block is not inserted, no payload id is allocated and it doesn't become the pending block.
+// Zero values of `param` are defaulted: parent - current head, timestamp - now (or parent's + 1), withdrawals - empty
+// after Shanghai, parent beacon block root - zero hash after Cancun.
+// Only the current head is supported as parent: the block builder executes transactions on top of the latest state.
+func (e *EthereumExecutionModule) BuildBlockDryRun(ctx context.Context, param core.BlockBuilderParameters, buildTime time.Duration) (*DryRunBlock, error) {
+	if buildTime <= 0 || buildTime > MaxDryRunBuildTime {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("build time must be in (0, %s]", MaxDryRunBuildTime)}
+	}
+	var head *types.Header
+	if err := e.db.View(ctx, func(tx kv.Tx) error {
+		executed, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		head = rawdb.ReadHeaderByNumber(tx, executed)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, errors.New("head block not found")
+	}
+	if param.ParentHash == (common.Hash{}) {
+		param.ParentHash = head.Hash()
+	}
+	if param.ParentHash != head.Hash() {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("parent %x is not the current head %x: only building on top of the head is supported", param.ParentHash, head.Hash())}
+	}
+	if param.Timestamp == 0 {
+		param.Timestamp = max(uint64(time.Now().Unix()), head.Time+1)
+	}
+	if param.Timestamp <= head.Time {
+		return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("timestamp %d is not after parent's %d", param.Timestamp, head.Time)}
+	}
+	if param.Withdrawals == nil && e.config.IsShanghai(param.Timestamp) {
+		param.Withdrawals = []*types.Withdrawal{}
+	}
+	if err := e.checkWithdrawalsPresence(param.Timestamp, param.Withdrawals); err != nil {
+		return nil, err
+	}
+	if param.ParentBeaconBlockRoot == nil && e.config.IsCancun(param.Timestamp) {
+		param.ParentBeaconBlockRoot = &common.Hash{}
+	}
+	param.PayloadId = 0
+	param.DryRun = true
+
+	// the same locking as AssembleBlock: building doesn't start while blocks are inserted or forkchoice is updated
+	if !e.semaphore.TryAcquire(1) {
+		return nil, ErrDryRunBusy
+	}
+	e.logger.Info("[BuildBlockDryRun] building", "parent", head.Number.Uint64(), "feeRecipient", param.SuggestedFeeRecipient, "buildTime", buildTime)
+	b := builder.NewBlockBuilder(e.builderFunc, &param)
+	e.semaphore.Release(1)
+	select {
+	case <-time.After(buildTime):
+	case <-ctx.Done():
+	}
+	result, err := b.Stop()
+	if err != nil {
+		return nil, err
+	}
+	return newDryRunBlock(result), nil
+}
+
+func newDryRunBlock(result *types.BlockWithReceipts) *DryRunBlock {
+	header := result.Block.Header()
+	baseFee := new(uint256.Int)
+	baseFee.SetFromBig(header.BaseFee)
+
+	res := &DryRunBlock{
+		BlockHash:    header.Hash(),
+		ParentHash:   header.ParentHash,
+		Number:       hexutil.Uint64(header.Number.Uint64()),
+		Timestamp:    hexutil.Uint64(header.Time),
+		FeeRecipient: header.Coinbase,
+		GasLimit:     hexutil.Uint64(header.GasLimit),
+		GasUsed:      hexutil.Uint64(header.GasUsed),
+		BlobGasUsed:  (*hexutil.Uint64)(header.BlobGasUsed),
+		BaseFee:      (*hexutil.Big)(baseFee.ToBig()),
+		Value:        (*hexutil.Big)(blockValue(result, baseFee).ToBig()),
+		Requests:     len(result.Requests),
+		Transactions: make([]DryRunBlockTxn, 0, len(result.Block.Transactions())),
+	}
+	for i, txn := range result.Block.Transactions() {
+		res.Transactions = append(res.Transactions, DryRunBlockTxn{
+			Hash:         txn.Hash(),
+			Type:         hexutil.Uint64(txn.Type()),
+			GasUsed:      hexutil.Uint64(result.Receipts[i].GasUsed),
+			EffectiveTip: (*hexutil.Big)(txn.GetEffectiveGasTip(baseFee).ToBig()),
+		})
+	}
+	return res
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/execution/eth1"
+	"github.com/erigontech/erigon/execution/stages/mock"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestBuildBlockDryRun_InvalidParams(t *testing.T) {
+	m := mock.Mock(t)
+	ctx := context.Background()
+	var invalidParams *rpc.InvalidParamsError
+
+	_, err := m.Eth1ExecutionService.BuildBlockDryRun(ctx, core.BlockBuilderParameters{}, 0)
+	require.ErrorAs(t, err, &invalidParams)
+	_, err = m.Eth1ExecutionService.BuildBlockDryRun(ctx, core.BlockBuilderParameters{}, eth1.MaxDryRunBuildTime+time.Second)
+	require.ErrorAs(t, err, &invalidParams)
+
+	// building is possible only on top of the current head
+	_, err = m.Eth1ExecutionService.BuildBlockDryRun(ctx, core.BlockBuilderParameters{ParentHash: common.Hash{1}}, time.Millisecond)
+	require.ErrorAs(t, err, &invalidParams)
+	require.ErrorContains(t, err, "is not the current head")
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"golang.org/x/sync/semaphore"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/erigontech/erigon-db/rawdb"
@@ -102,6 +103,7 @@ type EthereumExecutionModule struct {
 	semaphore         *semaphore.Weighted
 	executionPipeline *stagedsync.Sync
 	forkValidator     *engine_helpers.ForkValidator
+	badBlocks         *BadBlocks // nil - invalid blocks aren't kept
 
 	logger log.Logger
 	// Block building
@@ -127,6 +129,8 @@ type EthereumExecutionModule struct {
 	// metrics for average mgas/sec
 	avgMgasSec float64
 
+	bodiesByRange singleflight.Group // coalesces concurrent GetBodiesByRange of the same range
+
 	execution.UnimplementedExecutionServer
 }
 
@@ -138,9 +142,10 @@ func NewEthereumExecutionModule(blockReader services.FullBlockReader, db kv.Temp
 	stateChangeConsumer shards.StateChangeConsumer,
 	logger log.Logger, engine consensus.Engine,
 	syncCfg ethconfig.Sync,
+	badBlocks *BadBlocks,
 	ctx context.Context,
 ) *EthereumExecutionModule {
-	return &EthereumExecutionModule{
+	e := &EthereumExecutionModule{
 		blockReader:         blockReader,
 		db:                  db,
 		executionPipeline:   executionPipeline,
@@ -156,8 +161,28 @@ func NewEthereumExecutionModule(blockReader services.FullBlockReader, db kv.Temp
 		stateChangeConsumer: stateChangeConsumer,
 		engine:              engine,
 		syncCfg:             syncCfg,
+		badBlocks:           badBlocks,
 		bacgroundCtx:        ctx,
 	}
+	if badBlocks != nil && executionPipeline != nil {
+		executionPipeline.OnBadBlock(e.keepBadBlock)
+	}
+	return e
+}
+
+// keepBadBlock adds the block of a bad block unwind of the pipeline to the pool
+func (e *EthereumExecutionModule) keepBadBlock(tx kv.Tx, hash common.Hash, validationErr error) {
+	number, err := e.blockReader.HeaderNumber(e.bacgroundCtx, tx, hash)
+	if err != nil || number == nil {
+		e.logger.Warn("[bad blocks] unknown bad block", "hash", hash, "err", err)
+		return
+	}
+	block, _, err := e.blockReader.BlockWithSenders(e.bacgroundCtx, tx, hash, *number)
+	if err != nil || block == nil {
+		e.logger.Warn("[bad blocks] failed to read bad block", "hash", hash, "number", *number, "err", err)
+		return
+	}
+	e.badBlocks.Add(block, validationErr, BadBlockSourceSync)
 }
 
 func (e *EthereumExecutionModule) getHeader(ctx context.Context, tx kv.Tx, blockHash common.Hash, blockNumber uint64) (*types.Header, error) {
@@ -315,6 +340,13 @@ func (e *EthereumExecutionModule) ValidateChain(ctx context.Context, req *execut
 	if isInvalidChain {
 		e.logger.Warn("ethereumExecutionModule.ValidateChain: chain is invalid", "hash", common.Hash(blockHash))
 		validationStatus = execution.ExecutionStatus_BadBlock
+		if lvh != blockHash {
+			badBlockErr := validationError
+			if badBlockErr == nil {
+				badBlockErr = errors.New(string(status))
+			}
+			e.badBlocks.Add(types.NewBlockFromStorage(blockHash, header, body.Transactions, body.Uncles, body.Withdrawals), badBlockErr, BadBlockSourceValidation)
+		}
 	}
 	validationReceipt := &execution.ValidationReceipt{
 		ValidationStatus: validationStatus,
@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/execution/eth1/eth1_utils"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
 )
 
 var errNotFound = errors.New("notfound")
@@ -121,6 +122,32 @@ func (e *EthereumExecutionModule) GetHeader(ctx context.Context, req *execution.
 	return &execution.GetHeaderResponse{Header: eth1_utils.HeaderToHeaderRPC(header)}, nil
 }
 
+// frozenBodiesReader - block reader serving the bodies of the blocks in the snapshot files without decoding the txns
+type frozenBodiesReader interface {
+	FrozenBlocks() uint64
+	FrozenBodiesBinary(from, count uint64) ([]*freezeblocks.FrozenBody, error)
+}
+
+// frozenBodies reads the bodies of blocks [from, from+count) from the snapshot files, nil if they aren't there
+func (e *EthereumExecutionModule) frozenBodies(from, count uint64) ([]*execution.BlockBody, error) {
+	reader, ok := e.blockReader.(frozenBodiesReader)
+	if !ok || from > reader.FrozenBlocks() {
+		return nil, nil
+	}
+	frozen, err := reader.FrozenBodiesBinary(from, count)
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]*execution.BlockBody, len(frozen))
+	for i, body := range frozen {
+		bodies[i] = &execution.BlockBody{
+			Transactions: body.Transactions,
+			Withdrawals:  eth1_utils.ConvertWithdrawalsToRpc(body.Withdrawals),
+		}
+	}
+	return bodies, nil
+}
+
 func (e *EthereumExecutionModule) GetBodiesByHashes(ctx context.Context, req *execution.GetBodiesByHashesRequest) (*execution.GetBodiesBatchResponse, error) {
 	tx, err := e.db.BeginRo(ctx)
 	if err != nil {
@@ -140,6 +167,18 @@ func (e *EthereumExecutionModule) GetBodiesByHashes(ctx context.Context, req *ex
 			bodies = append(bodies, nil)
 			continue
 		}
+		if canonical, err := e.canonicalHash(ctx, tx, *number); err != nil {
+			return nil, fmt.Errorf("ethereumExecutionModule.GetBodiesByHashes: ReadCanonicalHash error %w", err)
+		} else if canonical == h { // only canonical blocks are in the snapshot files
+			frozen, err := e.frozenBodies(*number, 1)
+			if err != nil {
+				return nil, fmt.Errorf("ethereumExecutionModule.GetBodiesByHashes: frozenBodies error %w", err)
+			}
+			if len(frozen) == 1 {
+				bodies = append(bodies, frozen[0])
+				continue
+			}
+		}
 		body, err := e.getBody(ctx, tx, h, *number)
 		if err != nil {
 			return nil, fmt.Errorf("ethereumExecutionModule.GetBodiesByHashes: getBody error %w", err)
@@ -162,17 +201,36 @@ func (e *EthereumExecutionModule) GetBodiesByHashes(ctx context.Context, req *ex
 	return &execution.GetBodiesBatchResponse{Bodies: bodies}, nil
 }
 
+// GetBodiesByRange serves the blocks in the snapshot files from the files, concurrent requests of the same range
+// (consensus clients backfilling) are coalesced into one read.
 func (e *EthereumExecutionModule) GetBodiesByRange(ctx context.Context, req *execution.GetBodiesByRangeRequest) (*execution.GetBodiesBatchResponse, error) {
+	key := fmt.Sprintf("%d-%d", req.Start, req.Count)
+	res, err, _ := e.bodiesByRange.Do(key, func() (interface{}, error) {
+		return e.getBodiesByRange(ctx, req.Start, req.Count)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*execution.GetBodiesBatchResponse), nil
+}
+
+func (e *EthereumExecutionModule) getBodiesByRange(ctx context.Context, start, count uint64) (*execution.GetBodiesBatchResponse, error) {
+	bodies, err := e.frozenBodies(start, count)
+	if err != nil {
+		return nil, fmt.Errorf("ethereumExecutionModule.GetBodiesByRange: frozenBodies error %w", err)
+	}
+	if uint64(len(bodies)) == count {
+		return &execution.GetBodiesBatchResponse{Bodies: bodies}, nil
+	}
+
 	tx, err := e.db.BeginRo(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("ethereumExecutionModule.GetBodiesByRange: could not begin database tx %w", err)
 	}
 	defer tx.Rollback()
 
-	bodies := make([]*execution.BlockBody, 0, req.Count)
-
-	for i := uint64(0); i < req.Count; i++ {
-		hash, err := e.canonicalHash(ctx, tx, req.Start+i)
+	for i := uint64(len(bodies)); i < count; i++ {
+		hash, err := e.canonicalHash(ctx, tx, start+i)
 		if err != nil {
 			return nil, fmt.Errorf("ethereumExecutionModule.GetBodiesByRange: ReadCanonicalHash error %w", err)
 		}
@@ -181,7 +239,7 @@ func (e *EthereumExecutionModule) GetBodiesByRange(ctx context.Context, req *exe
 			break
 		}
 
-		body, err := e.getBody(ctx, tx, hash, req.Start+i)
+		body, err := e.getBody(ctx, tx, hash, start+i)
 		if err != nil {
 			return nil, fmt.Errorf("ethereumExecutionModule.GetBodiesByRange: getBody error %w", err)
 		}
@@ -203,10 +261,8 @@ func (e *EthereumExecutionModule) GetBodiesByRange(ctx context.Context, req *exe
 	}
 	// Remove trailing nil values as per spec
 	// See point 4 in https://github.com/ethereum/execution-apis/blob/main/src/engine/shanghai.md#specification-4
-	for i := len(bodies) - 1; i >= 0; i-- {
-		if bodies[i] == nil {
-			bodies = bodies[:i]
-		}
+	for len(bodies) > 0 && bodies[len(bodies)-1] == nil {
+		bodies = bodies[:len(bodies)-1]
 	}
 
 	return &execution.GetBodiesBatchResponse{
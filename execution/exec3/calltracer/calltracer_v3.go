@@ -26,9 +26,10 @@ import (
 )
 
 type CallTracer struct {
-	hooks *tracing.Hooks
-	froms map[common.Address]struct{}
-	tos   map[common.Address]struct{}
+	hooks    *tracing.Hooks
+	froms    map[common.Address]struct{}
+	tos      map[common.Address]struct{}
+	maxDepth int
 }
 
 func NewCallTracer(hooks *tracing.Hooks) *CallTracer {
@@ -65,15 +66,19 @@ func (ct *CallTracer) Tracer() *tracers.Tracer {
 }
 
 func (ct *CallTracer) Reset() {
-	ct.froms, ct.tos = nil, nil
+	ct.froms, ct.tos, ct.maxDepth = nil, nil, 0
 }
 func (ct *CallTracer) Froms() map[common.Address]struct{} { return ct.froms }
 func (ct *CallTracer) Tos() map[common.Address]struct{}   { return ct.tos }
 
+// MaxDepth - max depth of calls since Reset, 1 - only the top-level call
+func (ct *CallTracer) MaxDepth() int { return ct.maxDepth }
+
 func (ct *CallTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
 	if ct.froms == nil {
 		ct.froms = map[common.Address]struct{}{}
 		ct.tos = map[common.Address]struct{}{}
 	}
 	ct.froms[from], ct.tos[to] = struct{}{}, struct{}{}
+	ct.maxDepth = max(ct.maxDepth, depth+1)
 }
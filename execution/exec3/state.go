@@ -21,9 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
@@ -269,6 +271,10 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 			}
 		}
 	default:
+		if txTask.Metrics != nil {
+			*txTask.Metrics = rawdb.TxMetrics{} // re-execution
+			defer func(start time.Time) { txTask.Metrics.ExecutionTime = time.Since(start) }(time.Now())
+		}
 		rw.callTracer.Reset()
 		rw.vmCfg.SkipAnalysis = txTask.SkipAnalysis
 		ibs.SetTxContext(txTask.BlockNum, txTask.TxIndex)
@@ -327,6 +333,17 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 		//for addr, bal := range txTask.BalanceIncreaseSet {
 		//	fmt.Printf("BalanceIncreaseSet [%x]=>[%d]\n", addr, &bal)
 		//}
+		if txTask.StateGrowth != nil {
+			txTask.StateGrowth.Reset() // re-execution
+		}
+		rw.stateWriter.SetStateGrowth(txTask.StateGrowth)
+		if txTask.Metrics != nil {
+			txTask.Metrics.StateReads = uint64(ibs.StorageReadCount())
+			txTask.Metrics.CallDepth = uint64(rw.callTracer.MaxDepth())
+			rw.stateWriter.SetWriteCounter(&txTask.Metrics.StateWrites)
+		} else {
+			rw.stateWriter.SetWriteCounter(nil)
+		}
 		if err = ibs.MakeWriteSet(rules, rw.stateWriter); err != nil {
 			panic(err)
 		}
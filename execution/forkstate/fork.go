@@ -0,0 +1,163 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package forkstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+)
+
+// GasCap - of the calls without gas limit, the default of --rpc.gascap
+const GasCap = 50_000_000
+
+var ErrBlockNotFound = errors.New("block not found on the remote node")
+
+// Fork is a local fork of the chain of a remote node at a block: calls run in the context of the block, on its
+// post-state, like eth_call. Committed calls change the state of the fork, seen by the following calls.
+type Fork struct {
+	client      *rpc.Client
+	chainConfig *chain.Config
+	engine      consensus.EngineReader
+	header      *types.Header
+	reader      *Reader
+
+	hashesLock sync.Mutex
+	hashes     map[uint64]common.Hash // of the ancestors, for BLOCKHASH
+}
+
+// New forks the chain of the remote node at the block. With verifyProofs the accounts are checked against the
+// state root of the block, for remote nodes which are not fully trusted.
+func New(ctx context.Context, client *rpc.Client, chainConfig *chain.Config, engine consensus.EngineReader,
+	block rpc.BlockNumberOrHash, verifyProofs bool) (*Fork, error) {
+	header, err := fetchHeader(ctx, client, block)
+	if err != nil {
+		return nil, err
+	}
+	// pin the block by hash: "latest" moves while the fork is used
+	pinned := rpc.BlockNumberOrHashWithHash(header.Hash(), false)
+	var stateRoot common.Hash
+	if verifyProofs {
+		stateRoot = header.Root
+	}
+	return &Fork{
+		client:      client,
+		chainConfig: chainConfig,
+		engine:      engine,
+		header:      header,
+		reader:      NewReader(ctx, client, pinned, stateRoot),
+		hashes:      map[uint64]common.Hash{header.Number.Uint64(): header.Hash()},
+	}, nil
+}
+
+func fetchHeader(ctx context.Context, client *rpc.Client, block rpc.BlockNumberOrHash) (*types.Header, error) {
+	var header *types.Header
+	var err error
+	if hash, ok := block.Hash(); ok {
+		err = client.CallContext(ctx, &header, "eth_getBlockByHash", hash, false)
+	} else {
+		number, _ := block.Number()
+		err = client.CallContext(ctx, &header, "eth_getBlockByNumber", number, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching header of block %s: %w", block.String(), err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBlockNotFound, block.String())
+	}
+	return header, nil
+}
+
+// Header returns the header of the forked block
+func (f *Fork) Header() *types.Header { return f.header }
+
+// State returns the overlay of the state of the fork
+func (f *Fork) State() *Reader { return f.reader }
+
+// blockHash returns the hash of an ancestor of the forked block, fetched from the remote node
+func (f *Fork) blockHash(n uint64) (common.Hash, error) {
+	f.hashesLock.Lock()
+	defer f.hashesLock.Unlock()
+	if hash, ok := f.hashes[n]; ok {
+		return hash, nil
+	}
+	header, err := fetchHeader(f.reader.ctx, f.client, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(n)))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	f.hashes[n] = header.Hash()
+	return header.Hash(), nil
+}
+
+// Call executes the call on the state of the fork, tracing it with the optional tracer. With commit, the
+// changes of the call are kept in the state of the fork.
+func (f *Fork) Call(ctx context.Context, args ethapi.CallArgs, tracer *tracing.Hooks, commit bool) (*evmtypes.ExecutionResult, error) {
+	ibs := state.New(f.reader)
+	if tracer != nil {
+		ibs.SetHooks(tracer)
+	}
+	var baseFee *uint256.Int
+	if f.header.BaseFee != nil {
+		var overflow bool
+		if baseFee, overflow = uint256.FromBig(f.header.BaseFee); overflow {
+			return nil, errors.New("header.BaseFee uint256 overflow")
+		}
+	}
+	msg, err := args.ToMessage(GasCap, baseFee)
+	if err != nil {
+		return nil, err
+	}
+	blockCtx := core.NewEVMBlockContext(f.header, f.blockHash, f.engine, nil /* author */, f.chainConfig)
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), ibs, f.chainConfig, vm.Config{Tracer: tracer, NoBaseFee: true})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		evm.Cancel()
+	}()
+
+	gp := new(core.GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas())
+	result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, f.engine)
+	if err != nil {
+		return nil, err
+	}
+	if evm.Cancelled() {
+		return nil, fmt.Errorf("execution aborted: %w", ctx.Err())
+	}
+	if commit {
+		if err := ibs.FinalizeTx(evm.ChainRules(), f.reader); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
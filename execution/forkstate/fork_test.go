@@ -0,0 +1,221 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package forkstate
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/execution/consensus/ethash"
+	"github.com/erigontech/erigon/execution/consensus/merge"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+)
+
+// counter contract: increments slot 0 on every call
+var counterCode = common.FromHex("0x600054600101600055" + "00")
+
+var (
+	counterAddr = common.HexToAddress("0xc0")
+	senderAddr  = common.HexToAddress("0x5e")
+)
+
+// remoteNode serves the state of a block like an archive node
+type remoteNode struct {
+	header  *types.Header
+	calls   atomic.Int32
+	storage map[common.Hash]common.Hash // of the counter
+}
+
+func (n *remoteNode) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	n.calls.Add(1)
+	return n.header, nil
+}
+
+func (n *remoteNode) GetBlockByHash(hash common.Hash, fullTx bool) (*types.Header, error) {
+	n.calls.Add(1)
+	if hash != n.header.Hash() {
+		return nil, nil
+	}
+	return n.header, nil
+}
+
+func (n *remoteNode) GetProof(address common.Address, keys []common.Hash, block rpc.BlockNumberOrHash) (*accounts.AccProofResult, error) {
+	n.calls.Add(1)
+	res := &accounts.AccProofResult{Address: address, Balance: (*hexutil.Big)(new(big.Int)), CodeHash: empty.CodeHash, StorageHash: empty.RootHash}
+	switch address {
+	case counterAddr:
+		res.Nonce = 1
+		res.CodeHash = crypto.Keccak256Hash(counterCode)
+		res.StorageHash = common.HexToHash("0x01")
+	case senderAddr:
+		res.Balance = (*hexutil.Big)(big.NewInt(1e18))
+	default:
+		res.CodeHash = common.Hash{}
+		res.StorageHash = common.Hash{}
+	}
+	return res, nil
+}
+
+func (n *remoteNode) GetCode(address common.Address, block rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	n.calls.Add(1)
+	if address == counterAddr {
+		return counterCode, nil
+	}
+	return nil, nil
+}
+
+func (n *remoteNode) GetStorageAt(address common.Address, key common.Hash, block rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	n.calls.Add(1)
+	if address != counterAddr {
+		return common.Hash{}.Bytes(), nil
+	}
+	return n.storage[key].Bytes(), nil
+}
+
+func newRemoteNode(t *testing.T) (*remoteNode, *rpc.Client) {
+	t.Helper()
+	node := &remoteNode{
+		header: &types.Header{
+			Number:     big.NewInt(100),
+			Difficulty: big.NewInt(1),
+			GasLimit:   30_000_000,
+			Time:       1_700_000_000,
+		},
+		storage: map[common.Hash]common.Hash{{}: common.HexToHash("0x29")},
+	}
+	logger := log.New()
+	server := rpc.NewServer(50, false /* traceRequests */, false /* debugSingleRequests */, true, logger, 100)
+	if err := server.RegisterName("eth", node); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server, logger)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return node, client
+}
+
+func TestReaderFetchesOnce(t *testing.T) {
+	node, client := newRemoteNode(t)
+	r := NewReader(context.Background(), client, rpc.BlockNumberOrHashWithNumber(100), common.Hash{})
+
+	for i := 0; i < 2; i++ {
+		acc, err := r.ReadAccountData(counterAddr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acc == nil || acc.Nonce != 1 || acc.Incarnation != 1 {
+			t.Fatalf("counter account %+v", acc)
+		}
+		code, err := r.ReadAccountCode(counterAddr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(code) != string(counterCode) {
+			t.Fatalf("code %x", code)
+		}
+		v, ok, err := r.ReadAccountStorage(counterAddr, common.Hash{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || v.Uint64() != 0x29 {
+			t.Fatalf("slot 0: %v, ok %v", v.Uint64(), ok)
+		}
+	}
+	if calls := node.calls.Load(); calls != 3 {
+		t.Fatalf("%d remote calls, want 3", calls)
+	}
+
+	acc, err := r.ReadAccountData(common.HexToAddress("0xdead"))
+	if err != nil || acc != nil {
+		t.Fatalf("nonexistent account %+v, err %v", acc, err)
+	}
+}
+
+func TestReaderOverlay(t *testing.T) {
+	_, client := newRemoteNode(t)
+	r := NewReader(context.Background(), client, rpc.BlockNumberOrHashWithNumber(100), common.Hash{})
+
+	if err := r.WriteAccountStorage(counterAddr, 1, common.HexToHash("0x01"), uint256.Int{}, *uint256.NewInt(7)); err != nil {
+		t.Fatal(err)
+	}
+	v, _, err := r.ReadAccountStorage(counterAddr, common.HexToHash("0x01"))
+	if err != nil || v.Uint64() != 7 {
+		t.Fatalf("written slot %v, err %v", v.Uint64(), err)
+	}
+
+	// the remote storage is dropped with the account
+	if err := r.DeleteAccount(counterAddr, nil); err != nil {
+		t.Fatal(err)
+	}
+	v, ok, err := r.ReadAccountStorage(counterAddr, common.Hash{})
+	if err != nil || ok || !v.IsZero() {
+		t.Fatalf("slot 0 of deleted account %v, ok %v, err %v", v.Uint64(), ok, err)
+	}
+	if has, err := r.HasStorage(counterAddr); err != nil || has {
+		t.Fatalf("deleted account has storage %v, err %v", has, err)
+	}
+}
+
+func TestForkCall(t *testing.T) {
+	_, client := newRemoteNode(t)
+	ctx := context.Background()
+	fork, err := New(ctx, client, chain.TestChainConfig, merge.New(&ethash.FakeEthash{}), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, from := counterAddr, senderAddr
+	args := ethapi.CallArgs{From: &from, To: &to}
+
+	for i, commit := range []bool{false, true, true} {
+		res, err := fork.Call(ctx, args, nil, commit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Err != nil {
+			t.Fatalf("call %d: %v", i, res.Err)
+		}
+	}
+	v, _, err := fork.State().ReadAccountStorage(counterAddr, common.Hash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Uint64() != 0x29+2 {
+		t.Fatalf("counter %d after two committed calls, want %d", v.Uint64(), 0x29+2)
+	}
+	acc, err := fork.State().ReadAccountData(senderAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc.Nonce != 2 {
+		t.Fatalf("sender nonce %d after two committed calls, want 2", acc.Nonce)
+	}
+}
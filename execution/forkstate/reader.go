@@ -0,0 +1,248 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package forkstate runs calls on the state of a block of a remote archive node - without local datadir.
+// Accounts, code and storage are fetched lazily over JSON-RPC (eth_getProof, eth_getCode, eth_getStorageAt)
+// into a local overlay, which also keeps the changes of the committed calls (anvil-style forking).
+package forkstate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/empty"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/rpc"
+)
+
+var (
+	_ state.StateReader = (*Reader)(nil)
+	_ state.StateWriter = (*Reader)(nil)
+)
+
+// Reader reads the state of a block of a remote node, fetching every account, code and storage slot once into
+// the local overlay. As a StateWriter it applies the changes of the executed transactions to the overlay.
+type Reader struct {
+	ctx       context.Context
+	client    *rpc.Client
+	block     rpc.BlockNumberOrHash
+	stateRoot common.Hash // of the block, the account proofs are verified against it unless zero
+
+	mu       sync.Mutex
+	accounts map[common.Address]*accounts.Account // nil for the accounts which don't exist
+	roots    map[common.Address]common.Hash       // remote storage roots of the accounts
+	code     map[common.Hash][]byte               // by code hash
+	storage  map[common.Address]map[common.Hash]uint256.Int
+	cleared  map[common.Address]struct{} // accounts deleted or re-created in the overlay, without remote storage
+}
+
+// NewReader creates the overlay of the state of the block. Account proofs returned by the remote node are
+// verified against stateRoot, unless it is zero.
+func NewReader(ctx context.Context, client *rpc.Client, block rpc.BlockNumberOrHash, stateRoot common.Hash) *Reader {
+	return &Reader{
+		ctx:       ctx,
+		client:    client,
+		block:     block,
+		stateRoot: stateRoot,
+		accounts:  make(map[common.Address]*accounts.Account),
+		roots:     make(map[common.Address]common.Hash),
+		code:      make(map[common.Hash][]byte),
+		storage:   make(map[common.Address]map[common.Hash]uint256.Int),
+		cleared:   make(map[common.Address]struct{}),
+	}
+}
+
+// account returns the account from the overlay, fetching it if needed. r.mu must be held.
+func (r *Reader) account(address common.Address) (*accounts.Account, error) {
+	if acc, ok := r.accounts[address]; ok {
+		return acc, nil
+	}
+	var proof accounts.AccProofResult
+	if err := r.client.CallContext(r.ctx, &proof, "eth_getProof", address, []common.Hash{}, r.block); err != nil {
+		return nil, fmt.Errorf("eth_getProof %x: %w", address, err)
+	}
+	if r.stateRoot != (common.Hash{}) {
+		if err := trie.VerifyAccountProof(r.stateRoot, &proof); err != nil {
+			return nil, fmt.Errorf("account proof of %x: %w", address, err)
+		}
+	}
+	var balance uint256.Int
+	if proof.Balance != nil && balance.SetFromBig(proof.Balance.ToInt()) {
+		return nil, fmt.Errorf("balance of %x overflows", address)
+	}
+	// Nodes return zero code hash and storage root (or the empty ones) for the accounts which don't exist
+	if proof.CodeHash == (common.Hash{}) || (proof.Nonce == 0 && balance.IsZero() && proof.CodeHash == empty.CodeHash) {
+		r.accounts[address] = nil
+		return nil, nil
+	}
+	acc := accounts.NewAccount()
+	acc.Initialised = true
+	acc.Nonce = uint64(proof.Nonce)
+	acc.Balance = balance
+	acc.CodeHash = proof.CodeHash
+	acc.Root = proof.StorageHash
+	if acc.CodeHash != empty.CodeHash {
+		acc.Incarnation = state.FirstContractIncarnation
+	}
+	r.accounts[address] = &acc
+	r.roots[address] = proof.StorageHash
+	return &acc, nil
+}
+
+func (r *Reader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	acc, err := r.account(address)
+	if acc == nil || err != nil {
+		return nil, err
+	}
+	cpy := *acc
+	return &cpy, nil
+}
+
+func (r *Reader) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	return r.ReadAccountData(address)
+}
+
+func (r *Reader) ReadAccountStorage(address common.Address, key common.Hash) (uint256.Int, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if v, ok := r.storage[address][key]; ok {
+		return v, !v.IsZero(), nil
+	}
+	if _, ok := r.cleared[address]; ok {
+		return uint256.Int{}, false, nil
+	}
+	var res hexutil.Bytes
+	if err := r.client.CallContext(r.ctx, &res, "eth_getStorageAt", address, key, r.block); err != nil {
+		return uint256.Int{}, false, fmt.Errorf("eth_getStorageAt %x %x: %w", address, key, err)
+	}
+	var v uint256.Int
+	v.SetBytes(res)
+	if r.storage[address] == nil {
+		r.storage[address] = make(map[common.Hash]uint256.Int)
+	}
+	r.storage[address][key] = v
+	return v, !v.IsZero(), nil
+}
+
+func (r *Reader) HasStorage(address common.Address) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range r.storage[address] {
+		if !v.IsZero() {
+			return true, nil
+		}
+	}
+	if _, ok := r.cleared[address]; ok {
+		return false, nil
+	}
+	if _, err := r.account(address); err != nil {
+		return false, err
+	}
+	root, ok := r.roots[address]
+	return ok && root != empty.RootHash && root != (common.Hash{}), nil
+}
+
+func (r *Reader) ReadAccountCode(address common.Address) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	acc, err := r.account(address)
+	if acc == nil || err != nil || acc.CodeHash == empty.CodeHash {
+		return nil, err
+	}
+	if code, ok := r.code[acc.CodeHash]; ok {
+		return code, nil
+	}
+	var code hexutil.Bytes
+	if err := r.client.CallContext(r.ctx, &code, "eth_getCode", address, r.block); err != nil {
+		return nil, fmt.Errorf("eth_getCode %x: %w", address, err)
+	}
+	if hash := crypto.Keccak256Hash(code); hash != acc.CodeHash {
+		return nil, fmt.Errorf("code of %x: hash %x, expected %x", address, hash, acc.CodeHash)
+	}
+	r.code[acc.CodeHash] = code
+	return code, nil
+}
+
+func (r *Reader) ReadAccountCodeSize(address common.Address) (int, error) {
+	code, err := r.ReadAccountCode(address)
+	return len(code), err
+}
+
+func (r *Reader) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	acc, err := r.account(address)
+	if acc == nil || err != nil {
+		return 0, err
+	}
+	return acc.Incarnation, nil
+}
+
+func (r *Reader) UpdateAccountData(address common.Address, original, account *accounts.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cpy := *account
+	r.accounts[address] = &cpy
+	return nil
+}
+
+func (r *Reader) UpdateAccountCode(address common.Address, incarnation uint64, codeHash common.Hash, code []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.code[codeHash] = common.CopyBytes(code)
+	return nil
+}
+
+func (r *Reader) DeleteAccount(address common.Address, original *accounts.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.accounts[address] = nil
+	r.clearStorage(address)
+	return nil
+}
+
+func (r *Reader) WriteAccountStorage(address common.Address, incarnation uint64, key common.Hash, original, value uint256.Int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.storage[address] == nil {
+		r.storage[address] = make(map[common.Hash]uint256.Int)
+	}
+	r.storage[address][key] = value
+	return nil
+}
+
+func (r *Reader) CreateContract(address common.Address) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clearStorage(address)
+	return nil
+}
+
+// clearStorage drops the local and the remote storage of the account. r.mu must be held.
+func (r *Reader) clearStorage(address common.Address) {
+	delete(r.storage, address)
+	r.cleared[address] = struct{}{}
+}
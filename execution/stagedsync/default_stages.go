@@ -36,6 +36,7 @@ func DefaultStages(ctx context.Context,
 	txLookup TxLookupCfg,
 	finish FinishCfg,
 	test bool) []*Stage {
+	crossCheck := StageCrossCheckCfg(exec.syncCfg.CrossCheckSamples, exec.syncCfg.CrossCheckEpoch, exec.chainConfig, exec.engine, exec.blockReader)
 	return []*Stage{
 		{
 			ID:          stages.Snapshots,
@@ -122,6 +123,20 @@ func DefaultStages(ctx context.Context,
 				return PruneExecutionStage(p, tx, exec, ctx, logger)
 			},
 		},
+		{
+			ID:          stages.CrossCheck,
+			Description: "Re-execute sampled blocks on history state and their witness",
+			Disabled:    exec.syncCfg.CrossCheckSamples == 0 || dbg.StagesOnlyBlocks,
+			Forward: func(badBlockUnwind bool, s *StageState, u Unwinder, txc wrap.TxContainer, logger log.Logger) error {
+				return SpawnCrossCheckStage(s, txc, crossCheck, ctx, logger)
+			},
+			Unwind: func(u *UnwindState, s *StageState, txc wrap.TxContainer, logger log.Logger) error {
+				return UnwindCrossCheckStage(u, s, txc)
+			},
+			Prune: func(p *PruneState, tx kv.RwTx, logger log.Logger) error {
+				return nil
+			},
+		},
 		//{
 		//	ID:          stages.CustomTrace,
 		//	Description: "Re-Execute blocks on history state - with custom tracer",
@@ -170,6 +185,7 @@ func DefaultStages(ctx context.Context,
 }
 
 func PipelineStages(ctx context.Context, snapshots SnapshotsCfg, blockHashCfg BlockHashesCfg, senders SendersCfg, exec ExecuteBlockCfg, txLookup TxLookupCfg, finish FinishCfg, test bool) []*Stage {
+	crossCheck := StageCrossCheckCfg(exec.syncCfg.CrossCheckSamples, exec.syncCfg.CrossCheckEpoch, exec.chainConfig, exec.engine, exec.blockReader)
 	return []*Stage{
 		{
 			ID:          stages.Snapshots,
@@ -226,6 +242,20 @@ func PipelineStages(ctx context.Context, snapshots SnapshotsCfg, blockHashCfg Bl
 				return PruneExecutionStage(p, tx, exec, ctx, logger)
 			},
 		},
+		{
+			ID:          stages.CrossCheck,
+			Description: "Re-execute sampled blocks on history state and their witness",
+			Disabled:    exec.syncCfg.CrossCheckSamples == 0,
+			Forward: func(badBlockUnwind bool, s *StageState, u Unwinder, txc wrap.TxContainer, logger log.Logger) error {
+				return SpawnCrossCheckStage(s, txc, crossCheck, ctx, logger)
+			},
+			Unwind: func(u *UnwindState, s *StageState, txc wrap.TxContainer, logger log.Logger) error {
+				return UnwindCrossCheckStage(u, s, txc)
+			},
+			Prune: func(p *PruneState, tx kv.RwTx, logger log.Logger) error {
+				return nil
+			},
+		},
 
 		{
 			ID:          stages.TxLookup,
@@ -461,6 +491,7 @@ var DefaultForwardOrder = UnwindOrder{
 	// Stages below don't use Internet
 	stages.Senders,
 	stages.Execution,
+	stages.CrossCheck,
 	//stages.CustomTrace,
 	stages.TxLookup,
 	stages.Finish,
@@ -478,6 +509,7 @@ var DefaultUnwindOrder = UnwindOrder{
 	stages.TxLookup,
 
 	//stages.CustomTrace,
+	stages.CrossCheck,
 	stages.Execution,
 	stages.Senders,
 
@@ -490,6 +522,7 @@ var PipelineUnwindOrder = UnwindOrder{
 	stages.Finish,
 	stages.TxLookup,
 
+	stages.CrossCheck,
 	stages.Execution,
 	stages.Senders,
 
@@ -448,6 +448,12 @@ func ExecV3(ctx context.Context,
 
 	var b *types.Block
 
+	checkpointInterval := cfg.syncCfg.ExecCheckpointInterval
+	lastCheckpointBlock := blockNum
+	if parallel && checkpointInterval > 0 {
+		logger.Warn("[exec] --sync.exec.checkpoint.interval is not supported by parallel execution, ignoring")
+	}
+
 	// Only needed by bor chains
 	shouldGenerateChangesetsForLastBlocks := cfg.chainConfig.Bor != nil
 
@@ -503,6 +509,7 @@ Loop:
 		}
 
 		txs := b.Transactions()
+		types.RecoverBlockAuthorities(txs) // in parallel, before the transactions are executed
 		header := b.HeaderNoCopy()
 		skipAnalysis := core.SkipAnalysis(chainConfig, blockNum)
 		signer := *types.MakeSigner(chainConfig, blockNum, header.Time)
@@ -591,6 +598,12 @@ Loop:
 				skipPostEvaluation = true
 				continue
 			}
+			if cfg.notifications != nil && cfg.notifications.StateGrowth != nil && !txTask.HistoryExecution && !isMining {
+				txTask.StateGrowth = &shards.TxStateGrowth{}
+			}
+			if cfg.syncCfg.TxMetrics && txIndex >= 0 && txIndex < len(txs) && !txTask.HistoryExecution && !isMining {
+				txTask.Metrics = &rawdb.TxMetrics{}
+			}
 			executor.domains().SetTxNum(txTask.TxNum)
 			executor.domains().SetBlockNum(txTask.BlockNum)
 
@@ -710,14 +723,22 @@ Loop:
 
 		// MA commitTx
 		if !parallel {
+			// checkpoint: on long initial runs force a consistent flush+commit every N blocks, so a crash
+			// (OOM, power loss) resumes from the last checkpoint instead of re-executing the whole batch
+			checkpointDue := initialCycle && execCheckpointDue(checkpointInterval, lastCheckpointBlock, blockNum)
+
+			var logTick bool
 			select {
 			case <-logEvery.C:
-				if inMemExec || isMining {
-					break
-				}
+				logTick = true
+			default:
+			}
 
-				stepsInDB := rawdbhelpers.IdxStepsCountV3(executor.tx())
-				progress.Log("", executor.readState(), nil, nil, count, logGas, inputBlockNum.Load(), outputBlockNum.GetValueUint64(), outputTxNum.Load(), mxExecRepeats.GetValueUint64(), stepsInDB, shouldGenerateChangesets, inMemExec)
+			if (logTick || checkpointDue) && !inMemExec && !isMining {
+				if logTick {
+					stepsInDB := rawdbhelpers.IdxStepsCountV3(executor.tx())
+					progress.Log("", executor.readState(), nil, nil, count, logGas, inputBlockNum.Load(), outputBlockNum.GetValueUint64(), outputTxNum.Load(), mxExecRepeats.GetValueUint64(), stepsInDB, shouldGenerateChangesets, inMemExec)
+				}
 
 				//TODO: https://github.com/erigontech/erigon/issues/10724
 				//if executor.tx().(state2.HasAggTx).AggTx().(*state2.AggregatorRoTx).CanPrune(executor.tx(), outputTxNum.Load()) {
@@ -730,58 +751,57 @@ Loop:
 				aggregatorRo := state2.AggTx(executor.tx())
 
 				needCalcRoot := executor.readState().SizeEstimate() >= commitThreshold ||
+					checkpointDue ||
 					skipPostEvaluation || // If we skip post evaluation, then we should compute root hash ASAP for fail-fast
 					aggregatorRo.CanPrune(executor.tx(), outputTxNum.Load()) // if have something to prune - better prune ASAP to keep chaindata smaller
-				if !needCalcRoot {
-					break
-				}
-
-				var (
-					commitStart = time.Now()
+				if needCalcRoot {
+					var (
+						commitStart = time.Now()
+
+						pruneDuration time.Duration
+					)
+					ok, times, err := flushAndCheckCommitmentV3(ctx, b.HeaderNoCopy(), executor.tx(), executor.domains(), cfg, execStage, stageProgress, parallel, logger, u, inMemExec)
+					if err != nil {
+						return err
+					} else if !ok {
+						break Loop
+					}
 
-					pruneDuration time.Duration
-				)
-				ok, times, err := flushAndCheckCommitmentV3(ctx, b.HeaderNoCopy(), executor.tx(), executor.domains(), cfg, execStage, stageProgress, parallel, logger, u, inMemExec)
-				if err != nil {
-					return err
-				} else if !ok {
-					break Loop
-				}
+					computeCommitmentDuration += times.ComputeCommitment
+					flushDuration := times.Flush
 
-				computeCommitmentDuration += times.ComputeCommitment
-				flushDuration := times.Flush
+					timeStart := time.Now()
 
-				timeStart := time.Now()
+					// allow greedy prune on non-chain-tip
+					pruneTimeout := 250 * time.Millisecond
+					if initialCycle {
+						pruneTimeout = 10 * time.Hour
 
-				// allow greedy prune on non-chain-tip
-				pruneTimeout := 250 * time.Millisecond
-				if initialCycle {
-					pruneTimeout = 10 * time.Hour
+						if err = executor.tx().(kv.TemporalRwTx).GreedyPruneHistory(ctx, kv.CommitmentDomain); err != nil {
+							return err
+						}
+					}
 
-					if err = executor.tx().(kv.TemporalRwTx).GreedyPruneHistory(ctx, kv.CommitmentDomain); err != nil {
+					if _, err := aggregatorRo.PruneSmallBatches(ctx, pruneTimeout, executor.tx()); err != nil {
 						return err
 					}
-				}
+					pruneDuration = time.Since(timeStart)
 
-				if _, err := aggregatorRo.PruneSmallBatches(ctx, pruneTimeout, executor.tx()); err != nil {
-					return err
-				}
-				pruneDuration = time.Since(timeStart)
-
-				commitDuration, err := executor.(*serialExecutor).commit(ctx, inputTxNum, outputBlockNum.GetValueUint64(), useExternalTx)
-				if err != nil {
-					return err
-				}
+					commitDuration, err := executor.(*serialExecutor).commit(ctx, inputTxNum, outputBlockNum.GetValueUint64(), useExternalTx)
+					if err != nil {
+						return err
+					}
+					lastCheckpointBlock = blockNum
 
-				// on chain-tip: if batch is full then stop execution - to allow stages commit
-				if !initialCycle {
-					break Loop
+					// on chain-tip: if batch is full then stop execution - to allow stages commit
+					if !initialCycle {
+						break Loop
+					}
+					logger.Info("Committed", "time", time.Since(commitStart),
+						"block", outputBlockNum.GetValueUint64(), "txNum", inputTxNum,
+						"step", fmt.Sprintf("%.1f", float64(inputTxNum)/float64(agg.StepSize())),
+						"flush", flushDuration, "compute commitment", computeCommitmentDuration, "tx.commit", commitDuration, "prune", pruneDuration, "checkpoint", checkpointDue)
 				}
-				logger.Info("Committed", "time", time.Since(commitStart),
-					"block", outputBlockNum.GetValueUint64(), "txNum", inputTxNum,
-					"step", fmt.Sprintf("%.1f", float64(inputTxNum)/float64(agg.StepSize())),
-					"flush", flushDuration, "compute commitment", computeCommitmentDuration, "tx.commit", commitDuration, "prune", pruneDuration)
-			default:
 			}
 		}
 
@@ -911,6 +931,11 @@ func handleIncorrectRootHashError(header *types.Header, applyTx kv.TemporalRwTx,
 	return false, nil
 }
 
+// execCheckpointDue - forced commit of execution progress is due: at least `interval` blocks executed since last commit
+func execCheckpointDue(interval, lastCheckpointBlock, blockNum uint64) bool {
+	return interval > 0 && blockNum >= lastCheckpointBlock+interval
+}
+
 type FlushAndComputeCommitmentTimes struct {
 	Flush             time.Duration
 	ComputeCommitment time.Duration
@@ -146,6 +146,31 @@ type parallelExecutor struct {
 	logEvery                 *time.Ticker
 	slowDownLimit            *time.Ticker
 	progress                 *Progress
+
+	// metrics of applied transactions, written by rwLoop with the state: apply loop doesn't own the tx
+	txMetricsLock sync.Mutex
+	txMetrics     map[uint64]*rawdb.TxMetrics
+}
+
+func (pe *parallelExecutor) addTxMetrics(txNum uint64, m *rawdb.TxMetrics) {
+	pe.txMetricsLock.Lock()
+	defer pe.txMetricsLock.Unlock()
+	if pe.txMetrics == nil {
+		pe.txMetrics = map[uint64]*rawdb.TxMetrics{}
+	}
+	pe.txMetrics[txNum] = m
+}
+
+func (pe *parallelExecutor) flushTxMetrics(tx kv.RwTx) error {
+	pe.txMetricsLock.Lock()
+	defer pe.txMetricsLock.Unlock()
+	for txNum, m := range pe.txMetrics {
+		if err := rawdb.WriteTxMetrics(tx, txNum, m); err != nil {
+			return err
+		}
+	}
+	pe.txMetrics = nil
+	return nil
 }
 
 func (pe *parallelExecutor) applyLoop(ctx context.Context, maxTxNum uint64, blockComplete *atomic.Bool, errCh chan error) {
@@ -253,6 +278,9 @@ func (pe *parallelExecutor) rwLoop(ctx context.Context, maxTxNum uint64, logger
 					if err = pe.doms.Flush(ctx, tx); err != nil {
 						return err
 					}
+					if err = pe.flushTxMetrics(tx); err != nil {
+						return err
+					}
 				}
 				break
 			}
@@ -319,6 +347,9 @@ func (pe *parallelExecutor) rwLoop(ctx context.Context, maxTxNum uint64, logger
 				if err := pe.doms.Flush(ctx, tx); err != nil {
 					return err
 				}
+				if err := pe.flushTxMetrics(tx); err != nil {
+					return err
+				}
 				pe.doms.ClearRam(true)
 				t3 = time.Since(tt)
 
@@ -360,6 +391,9 @@ func (pe *parallelExecutor) rwLoop(ctx context.Context, maxTxNum uint64, logger
 	if err := pe.doms.Flush(ctx, tx); err != nil {
 		return err
 	}
+	if err := pe.flushTxMetrics(tx); err != nil {
+		return err
+	}
 	if err := pe.execStage.Update(tx, pe.outputBlockNum.GetValueUint64()); err != nil {
 		return err
 	}
@@ -425,6 +459,12 @@ func (pe *parallelExecutor) processResultQueue(ctx context.Context, inputTxNum u
 			//	return outputTxNum, conflicts, triggers, processedBlockNum, false, fmt.Errorf("block hashk mismatch: %x != %x bn =%d, txn= %d", rh, txTask.BlockRoot[:], txTask.BlockNum, txTask.TxNum)
 			//}
 		}
+		if txTask.StateGrowth != nil {
+			pe.cfg.notifications.StateGrowth.AddTx(txTask.BlockNum, txTask.StateGrowth)
+		}
+		if txTask.Metrics != nil {
+			pe.addTxMetrics(txTask.TxNum, txTask.Metrics)
+		}
 		triggers += pe.rs.CommitTxNum(txTask.Sender(), txTask.TxNum, pe.in)
 		outputTxNum++
 		if backPressure != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-db/rawdb/rawtemporaldb"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -171,6 +172,14 @@ func (se *serialExecutor) execute(ctx context.Context, tasks []*state.TxTask, gp
 		if err := se.rs.ApplyState(ctx, txTask); err != nil {
 			return false, err
 		}
+		if txTask.StateGrowth != nil {
+			se.cfg.notifications.StateGrowth.AddTx(txTask.BlockNum, txTask.StateGrowth)
+		}
+		if txTask.Metrics != nil {
+			if err := rawdb.WriteTxMetrics(se.applyTx, txTask.TxNum, txTask.Metrics); err != nil {
+				return false, err
+			}
+		}
 
 		se.outputTxNum.Add(1)
 	}
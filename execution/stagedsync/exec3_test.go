@@ -0,0 +1,41 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecCheckpointDue(t *testing.T) {
+	t.Parallel()
+	commits := func(interval, from, to uint64) (res []uint64) {
+		lastCheckpointBlock := from
+		for blockNum := from + 1; blockNum <= to; blockNum++ {
+			if execCheckpointDue(interval, lastCheckpointBlock, blockNum) {
+				res = append(res, blockNum)
+				lastCheckpointBlock = blockNum
+			}
+		}
+		return res
+	}
+	require.Equal(t, []uint64{13, 16, 19}, commits(3, 10, 20))
+	require.Equal(t, []uint64{1, 2, 3}, commits(1, 0, 3))
+	require.Empty(t, commits(0, 0, 1_000))
+	require.Empty(t, commits(100, 0, 99))
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/execution/exec3"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+)
+
+const (
+	ReceiptsBackfillIdle    = "idle"
+	ReceiptsBackfillRunning = "running"
+	ReceiptsBackfillPaused  = "paused"
+	ReceiptsBackfillStopped = "stopped"
+	ReceiptsBackfillDone    = "done"
+	ReceiptsBackfillFailed  = "failed"
+)
+
+var ErrReceiptsBackfillRunning = errors.New("receipts backfill is already running")
+var ErrReceiptsBackfillNotRunning = errors.New("receipts backfill is not running")
+
+var defaultReceiptsBackfillProduce = []string{kv.ReceiptDomain.String(), kv.LogAddrIdx.String(), kv.LogTopicIdx.String()}
+
+// ReceiptsBackfillCfg - parameters of one backfill run
+type ReceiptsBackfillCfg struct {
+	Produce         []string `json:"produce"`         // domains and indices to re-produce, default: receipt, logaddrs, logtopics
+	FromBlock       uint64   `json:"fromBlock"`       //
+	ToBlock         uint64   `json:"toBlock"`         // inclusive, 0 - up to the last executed block
+	BatchSize       uint64   `json:"batchSize"`       // blocks re-executed per write transaction, default 1_000
+	Workers         int      `json:"workers"`         // re-execution parallelism, 0 - --exec.workers
+	BlocksPerSecond int      `json:"blocksPerSecond"` // IO throttling, 0 - unlimited
+}
+
+type ReceiptsBackfillStatus struct {
+	State     string    `json:"state"`
+	Produce   []string  `json:"produce,omitempty"`
+	FromBlock uint64    `json:"fromBlock"`
+	ToBlock   uint64    `json:"toBlock"`
+	NextBlock uint64    `json:"nextBlock"` // first block not re-executed yet
+	Started   time.Time `json:"started,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ReceiptsBackfill - background job re-executing a historical block range to re-produce receipts domain and
+// logs indices, for example after prune policy change. Uses the same re-execution as `integration stage_custom_trace`,
+// but in small batches: every batch holds the write transaction, so the node's sync loop is blocked only briefly.
+// Re-execution needs state history of the range.
+type ReceiptsBackfill struct {
+	parentCtx context.Context
+	db        kv.TemporalRwDB
+	execArgs  *exec3.ExecArgs
+	logger    log.Logger
+
+	mu     sync.Mutex
+	status ReceiptsBackfillStatus
+	cancel context.CancelFunc
+	resume chan struct{} // not nil while paused, closed on resume
+}
+
+func NewReceiptsBackfill(ctx context.Context, cfg CustomTraceCfg, logger log.Logger) *ReceiptsBackfill {
+	return &ReceiptsBackfill{parentCtx: ctx, db: cfg.db, execArgs: cfg.ExecArgs, logger: logger, status: ReceiptsBackfillStatus{State: ReceiptsBackfillIdle}}
+}
+
+func parseBackfillProduce(list []string) (Produce, error) {
+	for _, p := range list {
+		switch strings.TrimSpace(p) {
+		case kv.ReceiptDomain.String(), kv.RCacheDomain.String(), kv.LogAddrIdx.String(), kv.LogTopicIdx.String(),
+			kv.TracesFromIdx.String(), kv.TracesToIdx.String():
+		default:
+			return Produce{}, fmt.Errorf("unknown domain or index to produce: %q", p)
+		}
+	}
+	return NewProduce(list), nil
+}
+
+func (b *ReceiptsBackfill) Start(cfg ReceiptsBackfillCfg) (ReceiptsBackfillStatus, error) {
+	if len(cfg.Produce) == 0 {
+		cfg.Produce = defaultReceiptsBackfillProduce
+	}
+	produce, err := parseBackfillProduce(cfg.Produce)
+	if err != nil {
+		return b.Status(), err
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 1_000
+	}
+	if cfg.ToBlock, err = b.checkRange(cfg.FromBlock, cfg.ToBlock); err != nil {
+		return b.Status(), err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.status.State == ReceiptsBackfillRunning || b.status.State == ReceiptsBackfillPaused {
+		return b.status, ErrReceiptsBackfillRunning
+	}
+	ctx, cancel := context.WithCancel(b.parentCtx)
+	b.cancel = cancel
+	b.resume = nil
+	b.status = ReceiptsBackfillStatus{
+		State:     ReceiptsBackfillRunning,
+		Produce:   cfg.Produce,
+		FromBlock: cfg.FromBlock,
+		ToBlock:   cfg.ToBlock,
+		NextBlock: cfg.FromBlock,
+		Started:   time.Now(),
+	}
+	go b.run(ctx, cfg, produce)
+	return b.status, nil
+}
+
+// checkRange - range must be executed and its state history must be available
+func (b *ReceiptsBackfill) checkRange(fromBlock, toBlock uint64) (uint64, error) {
+	err := b.db.ViewTemporal(b.parentCtx, func(tx kv.TemporalTx) error {
+		execProgress, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		if toBlock == 0 || toBlock > execProgress {
+			toBlock = execProgress
+		}
+		if fromBlock > toBlock {
+			return fmt.Errorf("fromBlock=%d is after toBlock=%d (executed: %d)", fromBlock, toBlock, execProgress)
+		}
+		fromTxNum, err := b.execArgs.BlockReader.TxnumReader(b.parentCtx).Min(tx, fromBlock)
+		if err != nil {
+			return err
+		}
+		if historyFrom := tx.Debug().HistoryStartFrom(kv.AccountsDomain); fromTxNum < historyFrom {
+			return fmt.Errorf("state history of block %d is pruned (available from txNum %d): can't re-execute", fromBlock, historyFrom)
+		}
+		return nil
+	})
+	return toBlock, err
+}
+
+func (b *ReceiptsBackfill) run(ctx context.Context, cfg ReceiptsBackfillCfg, produce Produce) {
+	limit := rate.Inf
+	if cfg.BlocksPerSecond > 0 {
+		limit = rate.Limit(cfg.BlocksPerSecond)
+	}
+	limiter := rate.NewLimiter(limit, int(cfg.BatchSize))
+	execArgs := *b.execArgs
+	if cfg.Workers > 0 {
+		execArgs.Workers = cfg.Workers
+	}
+
+	b.logger.Info("[receipts_backfill] start", "produce", cfg.Produce, "from", cfg.FromBlock, "to", cfg.ToBlock)
+	err := func() error {
+		for from := cfg.FromBlock; from <= cfg.ToBlock; {
+			if err := b.waitResume(ctx); err != nil {
+				return err
+			}
+			to := min(cfg.ToBlock+1, from+cfg.BatchSize) // exclusive
+			if err := limiter.WaitN(ctx, int(to-from)); err != nil {
+				return err
+			}
+			if err := customTraceBatchProduce(ctx, produce, &execArgs, b.db, from, to, "receipts_backfill", b.logger); err != nil {
+				return err
+			}
+			from = to
+			b.mu.Lock()
+			b.status.NextBlock = from
+			b.mu.Unlock()
+		}
+		return nil
+	}()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cancel()
+	switch {
+	case err == nil:
+		b.status.State = ReceiptsBackfillDone
+		b.logger.Info("[receipts_backfill] done", "from", cfg.FromBlock, "to", cfg.ToBlock, "took", time.Since(b.status.Started))
+	case errors.Is(err, context.Canceled):
+		b.status.State = ReceiptsBackfillStopped
+		b.logger.Info("[receipts_backfill] stopped", "nextBlock", b.status.NextBlock)
+	default:
+		b.status.State = ReceiptsBackfillFailed
+		b.status.Error = err.Error()
+		b.logger.Warn("[receipts_backfill] failed", "nextBlock", b.status.NextBlock, "err", err)
+	}
+}
+
+func (b *ReceiptsBackfill) waitResume(ctx context.Context) error {
+	b.mu.Lock()
+	resume := b.resume
+	b.mu.Unlock()
+	if resume == nil {
+		return ctx.Err()
+	}
+	select {
+	case <-resume:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Pause - takes effect after the current batch
+func (b *ReceiptsBackfill) Pause() (ReceiptsBackfillStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.status.State != ReceiptsBackfillRunning {
+		return b.status, ErrReceiptsBackfillNotRunning
+	}
+	b.resume = make(chan struct{})
+	b.status.State = ReceiptsBackfillPaused
+	return b.status, nil
+}
+
+func (b *ReceiptsBackfill) Resume() (ReceiptsBackfillStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.status.State != ReceiptsBackfillPaused {
+		return b.status, ErrReceiptsBackfillNotRunning
+	}
+	close(b.resume)
+	b.resume = nil
+	b.status.State = ReceiptsBackfillRunning
+	return b.status, nil
+}
+
+// Stop - interrupts the current batch, already committed batches are kept
+func (b *ReceiptsBackfill) Stop() (ReceiptsBackfillStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.status.State != ReceiptsBackfillRunning && b.status.State != ReceiptsBackfillPaused {
+		return b.status, ErrReceiptsBackfillNotRunning
+	}
+	b.cancel()
+	return b.status, nil
+}
+
+func (b *ReceiptsBackfill) Status() ReceiptsBackfillStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.status
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon-lib/wrap"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+// crossCheckMaxEpochs - during initial sync only the blocks of the most recent epochs are cross-checked
+const crossCheckMaxEpochs = 16
+
+var (
+	errCrossCheckDivergence = errors.New("cross-check divergence")
+
+	crossCheckBlocks     = metrics.GetOrCreateCounter("cross_check_blocks")
+	crossCheckDivergence = metrics.GetOrCreateCounter("cross_check_divergence")
+)
+
+type CrossCheckCfg struct {
+	samples     uint64 // blocks re-executed per epoch, 0 - disabled
+	epoch       uint64
+	seed        uint64 // of the samples: they differ between the nodes and the restarts
+	chainConfig *chain.Config
+	engine      consensus.Engine
+	blockReader services.FullBlockReader
+}
+
+func StageCrossCheckCfg(samples, epoch uint64, chainConfig *chain.Config, engine consensus.Engine, blockReader services.FullBlockReader) CrossCheckCfg {
+	return CrossCheckCfg{
+		samples:     samples,
+		epoch:       epoch,
+		seed:        rand.Uint64(),
+		chainConfig: chainConfig,
+		engine:      engine,
+		blockReader: blockReader,
+	}
+}
+
+// SpawnCrossCheckStage - correctness canary of the Execution stage: re-executes a random sample of the executed
+// blocks of every epoch on an independent code path, see crossCheckBlock. A divergence is logged and counted by
+// the cross_check_divergence metric, it doesn't stop the sync.
+func SpawnCrossCheckStage(s *StageState, txc wrap.TxContainer, cfg CrossCheckCfg, ctx context.Context, logger log.Logger) error {
+	if cfg.samples == 0 || cfg.epoch == 0 {
+		return nil
+	}
+	tx, ok := txc.Tx.(kv.TemporalRwTx)
+	if !ok || txc.Doms != nil { // in-memory execution: its state isn't in the db
+		return nil
+	}
+	to, err := s.ExecutionAt(tx)
+	if err != nil {
+		return err
+	}
+	if s.BlockNumber >= to {
+		return nil
+	}
+	from := s.BlockNumber + 1
+	if window := crossCheckMaxEpochs * cfg.epoch; to-from >= window {
+		from = to - window + 1
+	}
+
+	for epoch := from / cfg.epoch; epoch <= to/cfg.epoch; epoch++ {
+		for _, blockNum := range cfg.sample(epoch) {
+			if blockNum < from || blockNum > to || blockNum == 0 {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			err := crossCheckBlock(ctx, tx, blockNum, cfg, logger)
+			if errors.Is(err, errCrossCheckDivergence) {
+				crossCheckDivergence.Inc()
+				logger.Error(fmt.Sprintf("[%s] block diverges from execution", s.LogPrefix()), "block", blockNum, "err", err)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			crossCheckBlocks.Inc()
+			logger.Debug(fmt.Sprintf("[%s] block checked", s.LogPrefix()), "block", blockNum)
+		}
+	}
+	return s.Update(tx, to)
+}
+
+func UnwindCrossCheckStage(u *UnwindState, s *StageState, txc wrap.TxContainer) error {
+	if s.BlockNumber <= u.UnwindPoint {
+		return nil
+	}
+	return u.Done(txc.Tx)
+}
+
+// sample - the blocks of the epoch to check: the same whichever part of the epoch a stage run covers
+func (cfg CrossCheckCfg) sample(epoch uint64) []uint64 {
+	r := rand.New(rand.NewPCG(cfg.seed, epoch))
+	picked := make(map[uint64]struct{}, cfg.samples)
+	for uint64(len(picked)) < min(cfg.samples, cfg.epoch) {
+		picked[epoch*cfg.epoch+r.Uint64N(cfg.epoch)] = struct{}{}
+	}
+	return slices.Sorted(maps.Keys(picked))
+}
+
+// crossCheckBlock re-executes the block on the history state, instead of the latest state of the Execution stage,
+// collecting the execution witness, then on the witness only. It returns errCrossCheckDivergence if an execution
+// doesn't match the header, if the two executions don't match, or if their writes don't match the state after the
+// block written by the Execution stage.
+func crossCheckBlock(ctx context.Context, tx kv.TemporalTx, blockNum uint64, cfg CrossCheckCfg, logger log.Logger) error {
+	block, err := cfg.blockReader.BlockByNumber(ctx, tx, blockNum)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		return nil
+	}
+	txNumsReader := cfg.blockReader.TxnumReader(ctx)
+	minTxNum, err := txNumsReader.Min(tx, blockNum)
+	if err != nil {
+		return err
+	}
+	maxTxNum, err := txNumsReader.Max(tx, blockNum)
+	if err != nil {
+		return err
+	}
+	pre, post := state.NewHistoryReaderV3(), state.NewHistoryReaderV3()
+	pre.SetTx(tx)
+	pre.SetTxNum(minTxNum) // before the system txn of the block beginning
+	post.SetTx(tx)
+	post.SetTxNum(maxTxNum + 1)
+	if minTxNum < pre.StateHistoryStartFrom() { // pruned
+		return nil
+	}
+
+	chainReader := NewChainReaderImpl(cfg.chainConfig, tx, cfg.blockReader, logger)
+	getHashFn := core.GetHashFn(block.Header(), func(hash common.Hash, number uint64) (*types.Header, error) {
+		return cfg.blockReader.Header(ctx, tx, hash, number)
+	})
+	writes := crossCheckWrites{}
+	res, err := core.ExecuteBlockEphemerally(cfg.chainConfig, &vm.Config{CollectWitness: true}, getHashFn, cfg.engine, block, pre, writes, chainReader, nil, logger)
+	if err != nil {
+		return fmt.Errorf("%w: history state execution: %w", errCrossCheckDivergence, err)
+	}
+	statelessWrites := crossCheckWrites{}
+	stateless, err := core.ExecuteBlockEphemerally(cfg.chainConfig, &vm.Config{}, res.Witness.BlockHash, cfg.engine, block, res.Witness, statelessWrites, chainReader, nil, logger)
+	if err != nil {
+		return fmt.Errorf("%w: witness execution: %w", errCrossCheckDivergence, err)
+	}
+	if stateless.ReceiptRoot != res.ReceiptRoot {
+		return fmt.Errorf("%w: receipts root of witness execution %x, of history state execution %x", errCrossCheckDivergence, stateless.ReceiptRoot, res.ReceiptRoot)
+	}
+	if key, ok := writes.diff(statelessWrites); !ok {
+		return fmt.Errorf("%w: %s written by witness execution %q, by history state execution %q", errCrossCheckDivergence, key, statelessWrites[key], writes[key])
+	}
+	return writes.check(post)
+}
+
+type crossCheckKind uint8
+
+const (
+	crossCheckAccount crossCheckKind = iota
+	crossCheckCode
+	crossCheckStorage
+)
+
+type crossCheckKey struct {
+	kind crossCheckKind
+	addr common.Address
+	slot common.Hash
+}
+
+func (k crossCheckKey) String() string {
+	switch k.kind {
+	case crossCheckCode:
+		return fmt.Sprintf("code of %x", k.addr)
+	case crossCheckStorage:
+		return fmt.Sprintf("storage %x of %x", k.slot, k.addr)
+	}
+	return fmt.Sprintf("account %x", k.addr)
+}
+
+// crossCheckWrites - state.StateWriter keeping the final values written by a block execution, rendered to compare
+// them
+type crossCheckWrites map[crossCheckKey]string
+
+func renderCrossCheckAccount(account *accounts.Account) string {
+	if account == nil {
+		return "deleted"
+	}
+	return fmt.Sprintf("nonce=%d balance=%d codeHash=%x", account.Nonce, &account.Balance, account.CodeHash)
+}
+
+func (w crossCheckWrites) UpdateAccountData(address common.Address, original, account *accounts.Account) error {
+	w[crossCheckKey{kind: crossCheckAccount, addr: address}] = renderCrossCheckAccount(account)
+	return nil
+}
+
+func (w crossCheckWrites) UpdateAccountCode(address common.Address, incarnation uint64, codeHash common.Hash, code []byte) error {
+	w[crossCheckKey{kind: crossCheckCode, addr: address}] = crypto.Keccak256Hash(code).Hex()
+	return nil
+}
+
+func (w crossCheckWrites) DeleteAccount(address common.Address, original *accounts.Account) error {
+	w[crossCheckKey{kind: crossCheckAccount, addr: address}] = renderCrossCheckAccount(nil)
+	return nil
+}
+
+func (w crossCheckWrites) WriteAccountStorage(address common.Address, incarnation uint64, key common.Hash, original, value uint256.Int) error {
+	w[crossCheckKey{kind: crossCheckStorage, addr: address, slot: key}] = value.Hex()
+	return nil
+}
+
+func (w crossCheckWrites) CreateContract(address common.Address) error {
+	return nil
+}
+
+// diff returns the first key, in no particular order, which values of w and other differ
+func (w crossCheckWrites) diff(other crossCheckWrites) (crossCheckKey, bool) {
+	for key, value := range w {
+		if otherValue, ok := other[key]; !ok || otherValue != value {
+			return key, false
+		}
+	}
+	for key := range other {
+		if _, ok := w[key]; !ok {
+			return key, false
+		}
+	}
+	return crossCheckKey{}, true
+}
+
+// check compares the writes with the state after the block: the storage of the deleted accounts is skipped
+func (w crossCheckWrites) check(post state.StateReader) error {
+	for key, value := range w {
+		var stored string
+		switch key.kind {
+		case crossCheckAccount:
+			account, err := post.ReadAccountData(key.addr)
+			if err != nil {
+				return err
+			}
+			stored = renderCrossCheckAccount(account)
+		case crossCheckCode:
+			code, err := post.ReadAccountCode(key.addr)
+			if err != nil {
+				return err
+			}
+			stored = crypto.Keccak256Hash(code).Hex()
+		case crossCheckStorage:
+			if w[crossCheckKey{kind: crossCheckAccount, addr: key.addr}] == renderCrossCheckAccount(nil) {
+				continue
+			}
+			v, _, err := post.ReadAccountStorage(key.addr, key.slot)
+			if err != nil {
+				return err
+			}
+			stored = v.Hex()
+		}
+		if stored != value {
+			return fmt.Errorf("%w: %s written by execution %q, by history state execution %q", errCrossCheckDivergence, key, stored, value)
+		}
+	}
+	return nil
+}
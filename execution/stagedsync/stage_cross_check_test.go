@@ -0,0 +1,66 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+func TestCrossCheckSample(t *testing.T) {
+	cfg := StageCrossCheckCfg(3, 100, nil, nil, nil)
+	sample := cfg.sample(7)
+	require.Len(t, sample, 3)
+	for _, blockNum := range sample {
+		require.GreaterOrEqual(t, blockNum, uint64(700))
+		require.Less(t, blockNum, uint64(800))
+	}
+	require.Equal(t, sample, cfg.sample(7))
+
+	// more samples than blocks in the epoch
+	cfg = StageCrossCheckCfg(10, 4, nil, nil, nil)
+	require.Equal(t, []uint64{8, 9, 10, 11}, cfg.sample(2))
+}
+
+func TestCrossCheckWrites(t *testing.T) {
+	addr, slot := common.Address{1}, common.Hash{2}
+	account := &accounts.Account{Nonce: 1, Balance: *uint256.NewInt(5)}
+
+	a, b := crossCheckWrites{}, crossCheckWrites{}
+	for _, w := range []crossCheckWrites{a, b} {
+		require.NoError(t, w.UpdateAccountData(addr, nil, account))
+		require.NoError(t, w.WriteAccountStorage(addr, 1, slot, uint256.Int{}, *uint256.NewInt(3)))
+	}
+	_, ok := a.diff(b)
+	require.True(t, ok)
+
+	require.NoError(t, b.WriteAccountStorage(addr, 1, slot, uint256.Int{}, *uint256.NewInt(4)))
+	key, ok := a.diff(b)
+	require.False(t, ok)
+	require.Equal(t, crossCheckKey{kind: crossCheckStorage, addr: addr, slot: slot}, key)
+
+	require.NoError(t, b.DeleteAccount(addr, account))
+	require.NoError(t, b.WriteAccountStorage(addr, 1, slot, uint256.Int{}, *uint256.NewInt(3)))
+	key, ok = a.diff(b)
+	require.False(t, ok)
+	require.Equal(t, crossCheckKey{kind: crossCheckAccount, addr: addr}, key)
+}
@@ -19,6 +19,7 @@ package stagedsync_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -123,3 +124,35 @@ func TestCustomTraceDomainProgressConsistency(t *testing.T) {
 	})
 	require.NoError(err)
 }
+
+func TestReceiptsBackfill(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+
+	stageCfg := stagedsync.StageCustomTraceCfg(nil, m.DB, m.Dirs, m.BlockReader, m.ChainConfig, m.Engine, m.Cfg().Genesis, m.Cfg().Sync)
+	require.NoError(stagedsync.StageCustomTraceReset(ctx, m.DB, stagedsync.NewProduce([]string{kv.ReceiptDomain.String()})))
+
+	backfill := stagedsync.NewReceiptsBackfill(ctx, stageCfg, m.Log)
+	_, err := backfill.Start(stagedsync.ReceiptsBackfillCfg{Produce: []string{"unknown"}})
+	require.ErrorContains(err, "unknown domain")
+	_, err = backfill.Pause()
+	require.ErrorIs(err, stagedsync.ErrReceiptsBackfillNotRunning)
+
+	status, err := backfill.Start(stagedsync.ReceiptsBackfillCfg{Produce: []string{kv.ReceiptDomain.String()}, BatchSize: 2})
+	require.NoError(err)
+	require.Positive(status.ToBlock)
+	require.Eventually(func() bool {
+		return backfill.Status().State == stagedsync.ReceiptsBackfillDone
+	}, time.Minute, 10*time.Millisecond, backfill.Status().Error)
+	require.Equal(status.ToBlock+1, backfill.Status().NextBlock)
+
+	err = m.DB.ViewTemporal(ctx, func(rtx kv.TemporalTx) error {
+		cumGasUsed, _, _, err := rawtemporaldb.ReceiptAsOf(rtx, 4)
+		require.NoError(err)
+		require.Equal(21_000, int(cumGasUsed))
+		return nil
+	})
+	require.NoError(err)
+}
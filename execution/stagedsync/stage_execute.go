@@ -224,6 +224,9 @@ func unwindExec3(u *UnwindState, s *StageState, txc wrap.TxContainer, ctx contex
 	if err := rawdb.DeleteNewerEpochs(tx, u.UnwindPoint+1); err != nil {
 		return fmt.Errorf("delete newer epochs: %w", err)
 	}
+	if err := rawdb.TruncateTxMetrics(tx, txNum); err != nil {
+		return fmt.Errorf("truncate tx metrics: %w", err)
+	}
 	return nil
 }
 
@@ -376,6 +379,9 @@ func UnwindExecutionStage(u *UnwindState, s *StageState, txc wrap.TxContainer, c
 	if err = u.Done(txc.Tx); err != nil {
 		return err
 	}
+	if cfg.notifications != nil {
+		cfg.notifications.StateGrowth.Unwind(u.UnwindPoint)
+	}
 	//dumpPlainStateDebug(tx, nil)
 
 	if !useExternalTx {
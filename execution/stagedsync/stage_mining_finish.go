@@ -78,7 +78,9 @@ func SpawnMiningFinishStage(s *StageState, tx kv.RwTx, cfg MiningFinishCfg, quit
 	//	return nil
 	//}
 	//prev = sealHash
-	cfg.latestBlockBuiltStore.AddBlockBuilt(block)
+	if cfg.latestBlockBuiltStore != nil { // nil for dry-run building
+		cfg.latestBlockBuiltStore.AddBlockBuilt(block)
+	}
 
 	// Tests may set pre-calculated nonce
 	if block.NonceU64() != 0 {
@@ -33,6 +33,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/debug"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/etl"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/dbutils"
@@ -127,7 +128,7 @@ func SpawnRecoverSendersStage(cfg SendersCfg, s *StageState, u Unwinder, tx kv.R
 			defer debug.LogPanic()
 			defer wg.Done()
 			// each goroutine gets it's own crypto context to make sure they are really parallel
-			recoverSenders(ctx, logPrefix, secp256k1.ContextForThread(threadNo), cfg.chainConfig, jobs, out, quitCh)
+			recoverSenders(ctx, logPrefix, secp256k1.ContextForThread(threadNo), cfg.syncCfg.SendersEcrecover, cfg.chainConfig, jobs, out, quitCh)
 		}(i)
 	}
 
@@ -324,7 +325,7 @@ type senderRecoveryJob struct {
 	err         error
 }
 
-func recoverSenders(ctx context.Context, logPrefix string, cryptoContext *secp256k1.Context, config *chain.Config, in, out chan *senderRecoveryJob, quit <-chan struct{}) {
+func recoverSenders(ctx context.Context, logPrefix string, cryptoContext *secp256k1.Context, ecrecover string, config *chain.Config, in, out chan *senderRecoveryJob, quit <-chan struct{}) {
 	var job *senderRecoveryJob
 	var ok bool
 	for {
@@ -346,14 +347,25 @@ func recoverSenders(ctx context.Context, logPrefix string, cryptoContext *secp25
 		job.body = nil // reduce ram usage and help GC
 		signer := types.MakeSigner(config, job.blockNumber, job.blockTime)
 		job.senders = make([]byte, len(body.Transactions)*length.Addr)
-		for i, txn := range body.Transactions {
-			from, err := signer.SenderWithContext(cryptoContext, txn)
+		if ecrecover == crypto.EcrecoverBatched {
+			senders, failed, err := signer.SendersBatch(body.Transactions)
 			if err != nil {
 				job.err = fmt.Errorf("%w: error recovering sender for tx=%x, %v",
-					consensus.ErrInvalidBlock, txn.Hash(), err)
-				break
+					consensus.ErrInvalidBlock, body.Transactions[failed].Hash(), err)
+			}
+			for i := range senders {
+				copy(job.senders[i*length.Addr:], senders[i][:])
+			}
+		} else {
+			for i, txn := range body.Transactions {
+				from, err := signer.SenderWithContext(cryptoContext, txn)
+				if err != nil {
+					job.err = fmt.Errorf("%w: error recovering sender for tx=%x, %v",
+						consensus.ErrInvalidBlock, txn.Hash(), err)
+					break
+				}
+				copy(job.senders[i*length.Addr:], from[:])
 			}
-			copy(job.senders[i*length.Addr:], from[:])
 		}
 
 		// prevent sending to close channel
@@ -38,6 +38,12 @@ import (
 )
 
 func TestSenders(t *testing.T) {
+	for _, ecrecover := range crypto.EcrecoverBackends {
+		t.Run(ecrecover, func(t *testing.T) { testSenders(t, ecrecover) })
+	}
+}
+
+func testSenders(t *testing.T, ecrecover string) {
 	require := require.New(t)
 
 	m := mock.Mock(t)
@@ -145,7 +151,9 @@ func TestSenders(t *testing.T) {
 
 	require.NoError(stages.SaveStageProgress(tx, stages.Bodies, 3))
 
-	cfg := stagedsync.StageSendersCfg(db, chain.TestChainConfig, ethconfig.Defaults.Sync, false, "", prune.Mode{}, br, nil)
+	syncCfg := ethconfig.Defaults.Sync
+	syncCfg.SendersEcrecover = ecrecover
+	cfg := stagedsync.StageSendersCfg(db, chain.TestChainConfig, syncCfg, false, "", prune.Mode{}, br, nil)
 	err = stagedsync.SpawnRecoverSendersStage(cfg, &stagedsync.StageState{ID: stages.Senders}, nil, tx, 3, m.Ctx, log.New())
 	require.NoError(err)
 
@@ -156,6 +164,7 @@ func TestSenders(t *testing.T) {
 		assert.NotNil(t, found)
 		assert.Len(t, found.Body().Transactions, 2)
 		assert.Len(t, senders, 2)
+		assert.Equal(t, []common.Address{testAddr, testAddr}, senders)
 		header.Number = common.Big2
 		hash = header.Hash()
 		found, senders, _ = br.BlockWithSenders(m.Ctx, tx, hash, 2)
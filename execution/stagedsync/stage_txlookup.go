@@ -224,7 +224,8 @@ func UnwindTxLookup(u *UnwindState, s *StageState, tx kv.RwTx, cfg TxLookupCfg,
 	blockFrom, blockTo = max(blockFrom, smallestInDB), max(blockTo, smallestInDB)
 
 	// etl.Transform uses ExtractEndKey as exclusive bound, therefore blockTo + 1
-	if err := deleteTxLookupRange(tx, s.LogPrefix(), blockFrom, blockTo+1, ctx, cfg, logger); err != nil {
+	// unwound transactions are tombstoned: canonical lookup doesn't see them, but RPC can report them as non-canonical
+	if err := deleteTxLookupRange(tx, s.LogPrefix(), blockFrom, blockTo+1, true, ctx, cfg, logger); err != nil {
 		return fmt.Errorf("unwind TxLookUp: %w", err)
 	}
 	if err := u.Done(tx); err != nil {
@@ -293,7 +294,7 @@ func PruneTxLookup(s *PruneState, tx kv.RwTx, cfg TxLookupCfg, ctx context.Conte
 			default:
 			}
 
-			err = deleteTxLookupRange(tx, logPrefix, pruneBlockNum, pruneBlockNum+1, ctx, cfg, logger)
+			err = deleteTxLookupRange(tx, logPrefix, pruneBlockNum, pruneBlockNum+1, false, ctx, cfg, logger)
 			if err != nil {
 				return fmt.Errorf("prune TxLookUp: %w", err)
 			}
@@ -302,6 +303,10 @@ func PruneTxLookup(s *PruneState, tx kv.RwTx, cfg TxLookupCfg, ctx context.Conte
 				break
 			}
 		}
+		// tombstones are written by unwind for non-canonical blocks, which the loop above doesn't visit
+		if err = rawdb.PruneTxLookupTombstones(tx, pruneBlockNum); err != nil {
+			return fmt.Errorf("prune TxLookUp tombstones: %w", err)
+		}
 		if err = s.DoneAt(tx, pruneBlockNum); err != nil {
 			return err
 		}
@@ -315,8 +320,8 @@ func PruneTxLookup(s *PruneState, tx kv.RwTx, cfg TxLookupCfg, ctx context.Conte
 	return nil
 }
 
-// deleteTxLookupRange - [blockFrom, blockTo)
-func deleteTxLookupRange(tx kv.RwTx, logPrefix string, blockFrom, blockTo uint64, ctx context.Context, cfg TxLookupCfg, logger log.Logger) (err error) {
+// deleteTxLookupRange - [blockFrom, blockTo). tombstone=true replaces entries by rawdb.TxLookupTombstone instead of deleting them
+func deleteTxLookupRange(tx kv.RwTx, logPrefix string, blockFrom, blockTo uint64, tombstone bool, ctx context.Context, cfg TxLookupCfg, logger log.Logger) (err error) {
 	err = etl.Transform(logPrefix, tx, kv.HeaderCanonical, kv.TxLookup, cfg.tmpdir, func(k, v []byte, next etl.ExtractNextFunc) error {
 		blocknum, blockHash := binary.BigEndian.Uint64(k), common.CastToHash(v)
 		body, err := cfg.blockReader.BodyWithTransactions(ctx, tx, blockHash, blocknum)
@@ -328,8 +333,17 @@ func deleteTxLookupRange(tx kv.RwTx, logPrefix string, blockFrom, blockTo uint64
 			return nil
 		}
 
+		var val []byte // nil - delete
+		if tombstone {
+			val = rawdb.TxLookupTombstone(blocknum, blockHash)
+		}
 		for _, txn := range body.Transactions {
-			if err := next(k, txn.Hash().Bytes(), nil); err != nil {
+			if tombstone {
+				if err := rawdb.WriteTxLookupTombstoneIndex(tx, blocknum, txn.Hash()); err != nil {
+					return err
+				}
+			}
+			if err := next(k, txn.Hash().Bytes(), val); err != nil {
 				return err
 			}
 		}
@@ -3,26 +3,20 @@ package stagedsync
 import (
 	"bytes"
 	"context"
-
-	"github.com/c2h5oh/datasize"
+	"fmt"
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/kv"
-	"github.com/erigontech/erigon-lib/kv/membatchwithdb"
-	"github.com/erigontech/erigon-lib/kv/prune"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/log/v3"
-	"github.com/erigontech/erigon-lib/trie"
+	libstate "github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon-lib/types"
-	"github.com/erigontech/erigon-lib/wrap"
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/state"
-	"github.com/erigontech/erigon/core/vm"
-	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/execution/consensus"
-	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/stateless"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 	"github.com/erigontech/erigon/turbo/services"
 )
@@ -91,53 +85,41 @@ func PrepareForWitness(tx kv.TemporalTx, block *types.Block, prevRoot common.Has
 	}, nil
 }
 
-// RewindStagesForWitness rewinds the Execution stage to previous block.
-func RewindStagesForWitness(batch *membatchwithdb.MemoryMutation, blockNr, latestBlockNr uint64, cfg *WitnessCfg, regenerateHash bool, ctx context.Context, logger log.Logger) error {
-	// Rewind the Execution stage to previous block
-	unwindState := &UnwindState{ID: stages.Execution, UnwindPoint: blockNr - 1, CurrentBlockNumber: latestBlockNr}
-	stageState := &StageState{ID: stages.Execution, BlockNumber: blockNr}
-
-	txc := wrap.NewTxContainer(batch, nil)
-	batchSizeStr := "512M"
-	var batchSize datasize.ByteSize
-	err := batchSize.UnmarshalText([]byte(batchSizeStr))
-	if err != nil {
-		return err
+// RewindStagesForWitness rewinds the state of domains to the previous block: the diffsets of the blocks
+// blockNr..latestBlockNr are reverted in memory, on top of the read-only temporal tx.
+func RewindStagesForWitness(tx kv.TemporalTx, domains *libstate.SharedDomains, blockNr, latestBlockNr uint64, cfg *WitnessCfg, ctx context.Context) error {
+	var changeset *[kv.DomainLen][]kv.DomainEntryDiff
+	for currentBlock := latestBlockNr; currentBlock >= blockNr; currentBlock-- {
+		currentHash, ok, err := cfg.blockReader.CanonicalHash(ctx, tx, currentBlock)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("canonical hash not found %d", currentBlock)
+		}
+		currentKeys, ok, err := libstate.ReadDiffSet(tx, currentBlock, currentHash)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("diffset of block %d (%s) not found", currentBlock, currentHash)
+		}
+		if changeset == nil {
+			changeset = &currentKeys
+		} else {
+			for i := range currentKeys {
+				changeset[i] = libstate.MergeDiffSets(changeset[i], currentKeys[i])
+			}
+		}
 	}
-
-	pruneMode := prune.Mode{
-		Initialised: false,
-	}
-	vmConfig := &vm.Config{}
-	dirs := cfg.dirs
-	blockReader := cfg.blockReader
-	syncCfg := ethconfig.Defaults.Sync
-	execCfg := StageExecuteBlocksCfg(batch.MemDB(), pruneMode, batchSize, cfg.chainConfig, cfg.engine, vmConfig, nil,
-		/*stateStream=*/ false,
-		/*badBlockHalt=*/ true, dirs, blockReader, nil, nil, syncCfg, nil)
-
-	if err := UnwindExecutionStage(unwindState, stageState, txc, ctx, execCfg, logger); err != nil {
+	txNum, err := cfg.blockReader.TxnumReader(ctx).Min(tx, blockNr)
+	if err != nil {
 		return err
 	}
-
-	return nil
+	return domains.UnwindInMemory(tx, changeset, blockNr-1, txNum)
 }
 
 func ExecuteBlockStatelessly(block *types.Block, prevHeader *types.Header, chainReader consensus.ChainReader, tds *state.TrieDbState, cfg *WitnessCfg, buf *bytes.Buffer, getHashFn func(n uint64) (common.Hash, error), logger log.Logger) (common.Hash, error) {
-	blockNr := block.NumberU64()
-	nw, err := trie.NewWitnessFromReader(bytes.NewReader(buf.Bytes()), false)
-	if err != nil {
-		return common.Hash{}, err
-	}
-
-	statelessIbs, err := state.NewStateless(prevHeader.Root, nw, blockNr-1, true /* trace */, false /* is binary */)
-	if err != nil {
-		return common.Hash{}, err
-	}
-	execResult, err := core.ExecuteBlockEphemerally(cfg.chainConfig, &vm.Config{}, getHashFn, cfg.engine, block, statelessIbs, statelessIbs, chainReader, nil, logger)
-	if err != nil {
-		return common.Hash{}, err
-	}
-	_ = execResult
-	return statelessIbs.Finalize(), nil
+	_, stateRoot, err := stateless.ExecuteBlock(cfg.chainConfig, cfg.engine, block, prevHeader, buf.Bytes(), chainReader, getHashFn, true /* trace */, logger)
+	return stateRoot, err
 }
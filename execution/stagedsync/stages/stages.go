@@ -41,6 +41,7 @@ var (
 	Senders         SyncStage = "Senders"         // "From" recovered from signatures, bodies re-written
 	Execution       SyncStage = "Execution"       // Executing each block w/o building a trie
 	CustomTrace     SyncStage = "CustomTrace"     // Executing each block w/o building a trie
+	CrossCheck      SyncStage = "CrossCheck"      // Re-executing a random sample of the executed blocks on an independent code path
 	Translation     SyncStage = "Translation"     // Translation each marked for translation contract (from EVM to TEVM)
 	TxLookup        SyncStage = "TxLookup"        // Generating transactions lookup index
 	Finish          SyncStage = "Finish"          // Nominal stage after all other stages
@@ -65,6 +66,7 @@ var AllStages = []SyncStage{
 	Bodies,
 	Senders,
 	Execution,
+	CrossCheck,
 	CustomTrace,
 	Translation,
 	TxLookup,
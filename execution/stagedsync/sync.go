@@ -49,6 +49,8 @@ type Sync struct {
 	stagesIdsList []string
 	mode          stages.Mode
 	metricsCache  metricsCache
+
+	onBadBlock func(tx kv.Tx, badBlock common.Hash, err error)
 }
 
 type Timing struct {
@@ -146,8 +148,15 @@ func (s *Sync) IsAfter(stage1, stage2 stages.SyncStage) bool {
 	return idx1 > idx2
 }
 
+// OnBadBlock sets the callback of the unwinds from a bad block, called with the tx of the stage while the block
+// is still in the db
+func (s *Sync) OnBadBlock(f func(tx kv.Tx, badBlock common.Hash, err error)) { s.onBadBlock = f }
+
 func (s *Sync) HasUnwindPoint() bool { return s.unwindPoint != nil }
 func (s *Sync) UnwindTo(unwindPoint uint64, reason UnwindReason, tx kv.Tx) error {
+	if s.onBadBlock != nil && reason.IsBadBlock() && reason.Block != nil && tx != nil {
+		s.onBadBlock(tx, *reason.Block, reason.Err)
+	}
 	if tx != nil {
 		if aggTx := state.AggTx(tx); aggTx != nil {
 			// protect from too far unwind
@@ -518,6 +518,7 @@ func TestChainTxReorgs(t *testing.T) {
 	if err1 := m.InsertChain(chain); err1 != nil {
 		t.Fatalf("failed to insert original chain: %v", err1)
 	}
+	droppedFrom := []*types.Block{chain.Blocks[0], chain.Blocks[2]}
 
 	// overwrite the old chain
 	chain, err = core.GenerateChain(m2.ChainConfig, m2.Genesis, m2.Engine, m2.DB, 5, func(i int, gen *core.BlockGen) {
@@ -558,6 +559,12 @@ func TestChainTxReorgs(t *testing.T) {
 		if rcpt, _, _, _, _ := readReceipt(tx, txn.Hash(), m); rcpt != nil {
 			t.Errorf("drop %d: receipt %v found while shouldn't have been", i, rcpt)
 		}
+		// known, but not canonical
+		bn, bh, ok, err := rawdb.ReadTxLookupTombstone(tx, txn.Hash())
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, droppedFrom[i].NumberU64(), bn)
+		require.Equal(t, droppedFrom[i].Hash(), bh)
 	}
 
 	// added tx
@@ -574,6 +581,9 @@ func TestChainTxReorgs(t *testing.T) {
 	// shared tx
 	txs = types.Transactions{postponed, swapped}
 	for i, txn := range txs {
+		_, _, tombstoned, err := rawdb.ReadTxLookupTombstone(tx, txn.Hash())
+		require.NoError(t, err)
+		require.False(t, tombstoned)
 		if bn, _, _ := rawdb.ReadTxLookupEntry(tx, txn.Hash()); bn == nil {
 			t.Errorf("drop %d: tx %v found while shouldn't have been", i, txn)
 		}
@@ -582,6 +592,28 @@ func TestChainTxReorgs(t *testing.T) {
 			t.Errorf("share %d: expected receipt to be found", i)
 		}
 	}
+	tx.Rollback()
+
+	// prune removes tombstones, but keeps entries of transactions which became canonical again
+	require.NoError(t, m.DB.Update(m.Ctx, func(tx kv.RwTx) error {
+		return rawdb.PruneTxLookupTombstones(tx, chain.TopBlock.NumberU64()+1)
+	}))
+	require.NoError(t, m.DB.View(m.Ctx, func(tx kv.Tx) error {
+		for _, txn := range []types.Transaction{pastDrop, freshDrop} {
+			_, _, ok, err := rawdb.ReadTxLookupTombstone(tx, txn.Hash())
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+		for _, txn := range []types.Transaction{postponed, swapped} {
+			bn, _, err := rawdb.ReadTxLookupEntry(tx, txn.Hash())
+			require.NoError(t, err)
+			require.NotNil(t, bn)
+		}
+		cnt, err := tx.Count(kv.TxLookupTombstones)
+		require.NoError(t, err)
+		require.Zero(t, cnt)
+		return nil
+	}))
 }
 
 func readReceipt(db kv.TemporalTx, txHash common.Hash, m *mock.MockSentry) (*types.Receipt, common.Hash, uint64, uint64, error) {
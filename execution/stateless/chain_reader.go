@@ -0,0 +1,90 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/execution/consensus"
+)
+
+var _ consensus.ChainReader = (*headerChain)(nil)
+
+// headerChain - consensus.ChainReader backed only by headers supplied together with the witness.
+// There is no local database: everything not supplied is reported as missing.
+type headerChain struct {
+	config   *chain.Config
+	byHash   map[common.Hash]*types.Header
+	byNumber map[uint64]*types.Header
+	current  *types.Header
+}
+
+func newHeaderChain(config *chain.Config, headers []*types.Header) *headerChain {
+	hc := &headerChain{
+		config:   config,
+		byHash:   make(map[common.Hash]*types.Header, len(headers)),
+		byNumber: make(map[uint64]*types.Header, len(headers)),
+	}
+	for _, h := range headers {
+		hc.byHash[h.Hash()] = h
+		hc.byNumber[h.Number.Uint64()] = h
+		if hc.current == nil || h.Number.Cmp(hc.current.Number) > 0 {
+			hc.current = h
+		}
+	}
+	return hc
+}
+
+func (hc *headerChain) Config() *chain.Config                 { return hc.config }
+func (hc *headerChain) CurrentHeader() *types.Header          { return hc.current }
+func (hc *headerChain) CurrentFinalizedHeader() *types.Header { return nil }
+func (hc *headerChain) CurrentSafeHeader() *types.Header      { return nil }
+func (hc *headerChain) FrozenBlocks() uint64                  { return 0 }
+func (hc *headerChain) FrozenBorBlocks(align bool) uint64     { return 0 }
+
+func (hc *headerChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	h, ok := hc.byHash[hash]
+	if !ok || h.Number.Uint64() != number {
+		return nil
+	}
+	return h
+}
+func (hc *headerChain) GetHeaderByNumber(number uint64) *types.Header  { return hc.byNumber[number] }
+func (hc *headerChain) GetHeaderByHash(hash common.Hash) *types.Header { return hc.byHash[hash] }
+func (hc *headerChain) GetTd(hash common.Hash, number uint64) *big.Int {
+	return nil
+}
+func (hc *headerChain) GetBlock(hash common.Hash, number uint64) *types.Block { return nil }
+func (hc *headerChain) HasBlock(hash common.Hash, number uint64) bool         { return false }
+func (hc *headerChain) BorEventsByBlock(hash common.Hash, number uint64) []rlp.RawValue {
+	return nil
+}
+func (hc *headerChain) BorStartEventId(hash common.Hash, number uint64) uint64 { return 0 }
+
+// getHashFn - BLOCKHASH opcode support: only headers supplied with the witness can be resolved
+func (hc *headerChain) getHashFn(n uint64) (common.Hash, error) {
+	h, ok := hc.byNumber[n]
+	if !ok {
+		return common.Hash{}, fmt.Errorf("%w: header %d", ErrMissingAncestor, n)
+	}
+	return h.Hash(), nil
+}
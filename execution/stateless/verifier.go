@@ -0,0 +1,111 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package stateless verifies blocks against an execution witness only - without local state.
+// Witness format is the one produced by eth_getWitness (see rpc/jsonrpc/eth_call.go).
+package stateless
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/consensus"
+)
+
+var (
+	ErrMissingAncestor   = errors.New("ancestor header is not part of witness")
+	ErrParentMismatch    = errors.New("parent header doesn't match block")
+	ErrStateRootMismatch = errors.New("post-state root mismatch")
+	ErrTxRootMismatch    = errors.New("transactions root mismatch")
+)
+
+// Input - everything needed to verify a block statelessly.
+type Input struct {
+	Block   *types.Block
+	Parent  *types.Header
+	Witness []byte // serialized trie.Witness of parent's state, touched by Block
+	// Ancestors - optional headers, required if block uses BLOCKHASH opcode (or system contracts reading history)
+	Ancestors []*types.Header
+}
+
+type Result struct {
+	StateRoot   common.Hash `json:"stateRoot"`
+	ReceiptRoot common.Hash `json:"receiptsRoot"`
+	GasUsed     uint64      `json:"gasUsed"`
+	Valid       bool        `json:"valid"`
+}
+
+// Verify - executes block on top of witness and checks header's transactions root, gas used, receipts root, bloom and post-state root.
+// Returns non-nil Result with Valid=false (and an error) if block is invalid.
+func Verify(chainConfig *chain.Config, engine consensus.Engine, in Input, logger log.Logger) (*Result, error) {
+	block, parent := in.Block, in.Parent
+	if block == nil || parent == nil {
+		return nil, errors.New("block and parent header are required")
+	}
+	if block.ParentHash() != parent.Hash() || block.NumberU64() != parent.Number.Uint64()+1 {
+		return nil, fmt.Errorf("%w: block %d parent=%x, got %d %x", ErrParentMismatch, block.NumberU64(), block.ParentHash(), parent.Number.Uint64(), parent.Hash())
+	}
+
+	chainReader := newHeaderChain(chainConfig, append([]*types.Header{parent}, in.Ancestors...))
+	execRes, stateRoot, err := ExecuteBlock(chainConfig, engine, block, parent, in.Witness, chainReader, chainReader.getHashFn, false /* trace */, logger)
+	if err != nil {
+		return &Result{}, err
+	}
+
+	res := &Result{
+		StateRoot:   stateRoot,
+		ReceiptRoot: execRes.ReceiptRoot,
+		GasUsed:     uint64(execRes.GasUsed),
+	}
+	// gas used, receipts root and bloom are checked by execution itself
+	if execRes.TxRoot != block.TxHash() {
+		return res, fmt.Errorf("%w: block %d, got %x, expected %x", ErrTxRootMismatch, block.NumberU64(), execRes.TxRoot, block.TxHash())
+	}
+	if res.StateRoot != block.Root() {
+		return res, fmt.Errorf("%w: block %d, got %x, expected %x", ErrStateRootMismatch, block.NumberU64(), res.StateRoot, block.Root())
+	}
+	res.Valid = true
+	return res, nil
+}
+
+// ExecuteBlock - executes block on top of the witness of parent's state.
+// Returns execution result and post-state root computed from the witness trie.
+func ExecuteBlock(chainConfig *chain.Config, engine consensus.Engine, block *types.Block, parent *types.Header, witness []byte,
+	chainReader consensus.ChainReader, getHashFn func(n uint64) (common.Hash, error), trace bool, logger log.Logger) (*core.EphemeralExecResult, common.Hash, error) {
+	nw, err := trie.NewWitnessFromReader(bytes.NewReader(witness), trace)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("decode witness: %w", err)
+	}
+	// checks that witness matches parent's state root
+	statelessIbs, err := state.NewStateless(parent.Root, nw, parent.Number.Uint64(), trace, false /* isBinary */)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	execRes, err := core.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, getHashFn, engine, block, statelessIbs, statelessIbs, chainReader, nil, logger)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	return execRes, statelessIbs.Finalize(), nil
+}
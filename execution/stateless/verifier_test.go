@@ -0,0 +1,51 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func TestVerifyParentMismatch(t *testing.T) {
+	parent := &types.Header{Number: big.NewInt(10)}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(11)})
+	_, err := Verify(chain.TestChainConfig, nil, Input{Block: block, Parent: parent}, log.New())
+	require.ErrorIs(t, err, ErrParentMismatch)
+}
+
+func TestHeaderChainGetHash(t *testing.T) {
+	h1 := &types.Header{Number: big.NewInt(1)}
+	h2 := &types.Header{Number: big.NewInt(2), ParentHash: h1.Hash()}
+	hc := newHeaderChain(chain.TestChainConfig, []*types.Header{h2, h1})
+	require.Equal(t, h2, hc.CurrentHeader())
+
+	hash, err := hc.getHashFn(1)
+	require.NoError(t, err)
+	require.Equal(t, h1.Hash(), hash)
+	require.Equal(t, h1, hc.GetHeader(hash, 1))
+	require.Nil(t, hc.GetHeader(hash, 2))
+
+	_, err = hc.getHashFn(3)
+	require.ErrorIs(t, err, ErrMissingAncestor)
+}
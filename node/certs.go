@@ -0,0 +1,93 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// CertReloader - TLS certificate of an endpoint, re-read from its files on SIGHUP: renewed certificates are served
+// to the new connections without a restart
+type CertReloader struct {
+	certFile, keyFile string
+	cert              atomic.Pointer[tls.Certificate]
+}
+
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload - re-reads the certificate, the previous one is kept on error
+func (r *CertReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tls certificate %s: %w", r.certFile, err)
+	}
+	r.cert.Store(&cert)
+	return nil
+}
+
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load(), nil
+}
+
+var (
+	reloadersLock sync.Mutex
+	reloaders     = map[*CertReloader]struct{}{}
+	sighupOnce    sync.Once
+)
+
+// watchCertReloader - reloads the certificate on every SIGHUP until the returned func is called
+func watchCertReloader(r *CertReloader) (unwatch func()) {
+	sighupOnce.Do(func() {
+		sighup := make(chan os.Signal, 1)
+		signal.Notify(sighup, syscall.SIGHUP)
+		go func() {
+			for range sighup {
+				reloadersLock.Lock()
+				for r := range reloaders {
+					if err := r.Reload(); err != nil {
+						log.Warn("[rpc] tls certificate not reloaded, keeping the previous one", "err", err)
+						continue
+					}
+					log.Info("[rpc] tls certificate reloaded", "file", r.certFile)
+				}
+				reloadersLock.Unlock()
+			}
+		}()
+	})
+	reloadersLock.Lock()
+	defer reloadersLock.Unlock()
+	reloaders[r] = struct{}{}
+	return func() {
+		reloadersLock.Lock()
+		defer reloadersLock.Unlock()
+		delete(reloaders, r)
+	}
+}
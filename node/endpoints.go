@@ -21,6 +21,7 @@ package node
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -40,7 +41,7 @@ import (
 type HttpEndpointConfig struct {
 	Timeouts rpccfg.HTTPTimeouts
 	HTTPS    bool
-	CertFile string
+	CertFile string // re-read on SIGHUP, see CertReloader
 	KeyFile  string
 }
 
@@ -55,6 +56,12 @@ func StartHTTPEndpoint(urlEndpoint string, cfg *HttpEndpointConfig, handler http
 	if err != nil {
 		return nil, nil, fmt.Errorf("malformatted http listen url %s: %w", urlEndpoint, err)
 	}
+	var certs *CertReloader
+	if cfg.HTTPS {
+		if certs, err = NewCertReloader(cfg.CertFile, cfg.KeyFile); err != nil {
+			return nil, nil, err
+		}
+	}
 	if listener, err = net.Listen(socketUrl.Scheme, socketUrl.Host+socketUrl.EscapedPath()); err != nil {
 		return nil, nil, err
 	}
@@ -72,11 +79,15 @@ func StartHTTPEndpoint(urlEndpoint string, cfg *HttpEndpointConfig, handler http
 		IdleTimeout:       cfg.Timeouts.IdleTimeout,
 		ReadHeaderTimeout: cfg.Timeouts.ReadTimeout,
 	}
+	if certs != nil {
+		httpSrv.TLSConfig = &tls.Config{GetCertificate: certs.GetCertificate, MinVersion: tls.VersionTLS12}
+		httpSrv.RegisterOnShutdown(watchCertReloader(certs))
+	}
 	// start the HTTP server
 	go func() {
 		var serveErr error
 		if cfg.HTTPS {
-			serveErr = httpSrv.ServeTLS(listener, cfg.CertFile, cfg.KeyFile)
+			serveErr = httpSrv.ServeTLS(listener, "", "")
 			if serveErr != nil && !isIgnoredHttpServerError(serveErr) {
 				log.Warn("Failed to serve https endpoint", "err", serveErr)
 			}
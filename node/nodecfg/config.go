@@ -164,6 +164,9 @@ type Config struct {
 	TLSKeyFile string
 	TLSCACert  string
 
+	// GRPCAuthTokenFile - tokens authenticating calls of private gRPC interfaces, see grpcutil.TokenAuth
+	GRPCAuthTokenFile string
+
 	MdbxPageSize    datasize.ByteSize
 	MdbxDBSizeLimit datasize.ByteSize
 	MdbxGrowthStep  datasize.ByteSize
@@ -0,0 +1,209 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+	"github.com/erigontech/erigon/p2p/forkid"
+)
+
+const unknownClient = "unknown"
+
+// peer-provided names end up in metric labels: anything unusual is reported as "other" to keep cardinality bounded
+var clientLabelRe = regexp.MustCompile(`^[a-z0-9_.]{1,32}$`)
+var versionLabelRe = regexp.MustCompile(`^v[0-9]+(\.[0-9]+){0,3}$`)
+
+var peersByClientGauge = metrics.GetOrCreateGaugeVec("p2p_peers_by_client", []string{"client", "version", "protocol", "fork_id"})
+
+// ClientDiversity - breakdown of connected peers by client implementation, version, the highest eth protocol
+// version and the fork ID
+type ClientDiversity struct {
+	Peers     int                       `json:"peers"`
+	Clients   map[string]int            `json:"clients"`
+	Versions  map[string]map[string]int `json:"versions"`  // client -> version -> peers
+	Protocols map[string]int            `json:"protocols"` // e.g. "eth/68" -> peers
+	ForkIDs   map[string]int            `json:"forkIds"`   // e.g. "fc64ec04/1150000" -> peers
+}
+
+// ParseClientName splits a devp2p client name like "Geth/v1.14.0-stable-abcdef/linux-amd64/go1.22.1"
+// into a lowercase client identifier and a release version ("geth", "v1.14.0"). The optional node identity
+// segment ("Geth/my-node/v1.14.0/...") is skipped. Unrecognized parts are reported as "unknown".
+func ParseClientName(name string) (client, version string) {
+	parts := strings.Split(name, "/")
+	client = strings.ToLower(strings.TrimSpace(parts[0]))
+	if client == "" {
+		client = unknownClient
+	}
+	version = unknownClient
+	for _, part := range parts[1:] {
+		if len(part) < 2 || part[0] != 'v' || part[1] < '0' || part[1] > '9' {
+			continue
+		}
+		if i := strings.IndexAny(part, "-+"); i > 0 {
+			part = part[:i]
+		}
+		version = part
+		break
+	}
+	return client, version
+}
+
+// highestEthCap - highest advertised version of the eth protocol, e.g. "eth/68"
+func highestEthCap(caps []string) string {
+	best := -1
+	for _, c := range caps {
+		name, v, ok := strings.Cut(c, "/")
+		if !ok || name != "eth" {
+			continue
+		}
+		if n, err := strconv.Atoi(v); err == nil && n > best {
+			best = n
+		}
+	}
+	if best < 0 {
+		return unknownClient
+	}
+	return "eth/" + strconv.Itoa(best)
+}
+
+// ethENREntry - the fork ID of the `eth` ENR entry, see enrEntry of p2p/protocols/eth
+type ethENREntry struct {
+	ForkID forkid.ID
+	Rest   []rlp.RawValue `rlp:"tail"`
+}
+
+func (ethENREntry) ENRKey() string { return "eth" }
+
+// forkIDLabel - fork ID of the `eth` entry of the node record as "hash/next" ("hash" without a next fork),
+// "unknown" if the record doesn't have the entry (e.g. inbound peers not found by discovery)
+func forkIDLabel(r *enr.Record) string {
+	var entry ethENREntry
+	if r == nil || r.Load(&entry) != nil {
+		return unknownClient
+	}
+	label := hex.EncodeToString(entry.ForkID.Hash[:])
+	if entry.ForkID.Next > 0 {
+		label += "/" + strconv.FormatUint(entry.ForkID.Next, 10)
+	}
+	return label
+}
+
+// peerInfoForkID - forkIDLabel of the ENR of the peer info
+func peerInfoForkID(p *PeerInfo) string {
+	if p.ENR == "" {
+		return unknownClient
+	}
+	n, err := enode.Parse(enode.ValidSchemes, p.ENR)
+	if err != nil {
+		return unknownClient
+	}
+	return forkIDLabel(n.Record())
+}
+
+// NewClientDiversity aggregates connected peers' handshake data. Node ids and addresses are not retained.
+func NewClientDiversity(peers []*PeerInfo) *ClientDiversity {
+	d := &ClientDiversity{
+		Clients:   map[string]int{},
+		Versions:  map[string]map[string]int{},
+		Protocols: map[string]int{},
+		ForkIDs:   map[string]int{},
+	}
+	for _, p := range peers {
+		client, version := ParseClientName(p.Name)
+		d.Peers++
+		d.Clients[client]++
+		if d.Versions[client] == nil {
+			d.Versions[client] = map[string]int{}
+		}
+		d.Versions[client][version]++
+		d.Protocols[highestEthCap(p.Caps)]++
+		d.ForkIDs[peerInfoForkID(p)]++
+	}
+	return d
+}
+
+// LogValues - anonymized summary for periodic logging: peer counts per client, most common first.
+// Client names become log keys, so they are bucketed into "other" the same way as metric labels.
+func (d *ClientDiversity) LogValues() []interface{} {
+	counts := make(map[string]int, len(d.Clients))
+	for c, n := range d.Clients {
+		if !clientLabelRe.MatchString(c) || c == "peers" {
+			c = "other"
+		}
+		counts[c] += n
+	}
+	clients := make([]string, 0, len(counts))
+	for c := range counts {
+		clients = append(clients, c)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		if counts[clients[i]] != counts[clients[j]] {
+			return counts[clients[i]] > counts[clients[j]]
+		}
+		return clients[i] < clients[j]
+	})
+	vals := []interface{}{"peers", d.Peers}
+	for _, c := range clients {
+		vals = append(vals, c, counts[c])
+	}
+	return vals
+}
+
+func peerMetricLabels(p *Peer) []string {
+	client, version := ParseClientName(p.Fullname())
+	if !clientLabelRe.MatchString(client) {
+		client, version = "other", unknownClient
+	}
+	if !versionLabelRe.MatchString(version) {
+		version = unknownClient
+	}
+	return []string{client, version, highestEthCap(capStrings(p.Caps())), forkIDLabel(p.Node().Record())}
+}
+
+func capStrings(caps []Cap) []string {
+	res := make([]string, 0, len(caps))
+	for _, c := range caps {
+		res = append(res, c.String())
+	}
+	return res
+}
+
+// handshakeInfos - only handshake data of the peers, without querying sub-protocols
+func handshakeInfos(peers map[enode.ID]*Peer) []*PeerInfo {
+	infos := make([]*PeerInfo, 0, len(peers))
+	for _, p := range peers {
+		infos = append(infos, &PeerInfo{Name: p.Fullname(), Caps: capStrings(p.Caps())})
+	}
+	return infos
+}
+
+func peerDiversityMetricAdd(p *Peer) {
+	peersByClientGauge.WithLabelValues(peerMetricLabels(p)...).Inc()
+}
+
+func peerDiversityMetricRemove(p *Peer) {
+	peersByClientGauge.WithLabelValues(peerMetricLabels(p)...).Dec()
+}
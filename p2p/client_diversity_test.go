@@ -0,0 +1,93 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+	"github.com/erigontech/erigon/p2p/forkid"
+)
+
+func TestParseClientName(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name, client, version string
+	}{
+		{"Geth/v1.14.0-stable-abcdef12/linux-amd64/go1.22.1", "geth", "v1.14.0"},
+		{"Geth/my-node/v1.13.5-stable/linux-amd64/go1.21.4", "geth", "v1.13.5"},
+		{"Nethermind/v1.25.4+20b10b35/linux-x64/dotnet8.0.2", "nethermind", "v1.25.4"},
+		{"erigon/v3.1.0-dev-0123abcd/linux-amd64/go1.24.0", "erigon", "v3.1.0"},
+		{"besu", "besu", "unknown"},
+		{"", "unknown", "unknown"},
+	} {
+		client, version := ParseClientName(tc.name)
+		require.Equal(t, tc.client, client, tc.name)
+		require.Equal(t, tc.version, version, tc.name)
+	}
+}
+
+func TestNewClientDiversity(t *testing.T) {
+	t.Parallel()
+	d := NewClientDiversity([]*PeerInfo{
+		{Name: "Geth/v1.14.0-stable/linux-amd64/go1.22.1", Caps: []string{"eth/67", "eth/68", "snap/1"}},
+		{Name: "Geth/v1.13.5-stable/linux-amd64/go1.21.4", Caps: []string{"eth/68"}},
+		{Name: "Nethermind/v1.25.4+20b10b35/linux-x64/dotnet8.0.2", Caps: []string{"eth/67"}},
+		{Name: "mystery", Caps: []string{"snap/1"}},
+	})
+	require.Equal(t, 4, d.Peers)
+	require.Equal(t, map[string]int{"geth": 2, "nethermind": 1, "mystery": 1}, d.Clients)
+	require.Equal(t, map[string]int{"v1.14.0": 1, "v1.13.5": 1}, d.Versions["geth"])
+	require.Equal(t, map[string]int{"eth/68": 2, "eth/67": 1, "unknown": 1}, d.Protocols)
+	require.Equal(t, []interface{}{"peers", 4, "geth", 2, "mystery", 1, "nethermind", 1}, d.LogValues())
+}
+
+func TestClientDiversity_LogValuesSanitized(t *testing.T) {
+	t.Parallel()
+	d := NewClientDiversity([]*PeerInfo{
+		{Name: "Geth/v1.14.0-stable/linux-amd64/go1.22.1"},
+		{Name: "evil client=\n/v1.0.0"},
+		{Name: "peers/v1.0.0"},
+		{Name: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/v1.0.0"},
+	})
+	require.Equal(t, []interface{}{"peers", 4, "other", 3, "geth", 1}, d.LogValues())
+}
+
+func TestClientDiversity_ForkIDs(t *testing.T) {
+	t.Parallel()
+	enrWithForkID := func(id *forkid.ID) string {
+		var r enr.Record
+		if id != nil {
+			r.Set(ethENREntry{ForkID: *id})
+		}
+		require.NoError(t, enode.SignV4(&r, newkey()))
+		n, err := enode.New(enode.ValidSchemes, &r)
+		require.NoError(t, err)
+		return n.String()
+	}
+	d := NewClientDiversity([]*PeerInfo{
+		{Name: "Geth/v1.14.0", ENR: enrWithForkID(&forkid.ID{Hash: [4]byte{0xfc, 0x64, 0xec, 0x04}, Next: 1150000})},
+		{Name: "Geth/v1.14.0", ENR: enrWithForkID(&forkid.ID{Hash: [4]byte{0xfc, 0x64, 0xec, 0x04}, Next: 1150000})},
+		{Name: "Geth/v1.14.0", ENR: enrWithForkID(&forkid.ID{Hash: [4]byte{0x9f, 0x3d, 0x22, 0x54}})},
+		{Name: "Geth/v1.14.0", ENR: enrWithForkID(nil)},
+		{Name: "Geth/v1.14.0"},
+	})
+	require.Equal(t, map[string]int{"fc64ec04/1150000": 2, "9f3d2254": 1, "unknown": 2}, d.ForkIDs)
+}
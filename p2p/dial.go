@@ -36,6 +36,7 @@ import (
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/netutil"
+	"github.com/erigontech/erigon/p2p/reputation"
 )
 
 const (
@@ -50,6 +51,9 @@ const (
 	// Endpoint resolution is throttled with bounded backoff.
 	initialResolveDelay = 60 * time.Second
 	maxResolveDelay     = time.Hour
+
+	// Score of the nodes without reputation events, see reputation.Record.Score.
+	neutralScore = 0.5
 )
 
 // NodeDialer is used to connect to nodes in the network, typically by using
@@ -83,6 +87,9 @@ var (
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNotWhitelisted   = errors.New("not contained in netrestrict whitelist")
 	errNoPort           = errors.New("node does not provide TCP port")
+	errBanned           = errors.New("banned for protocol violations")
+	errLowReputation    = errors.New("low reputation")
+	errNoNeededBlocks   = errors.New("doesn't serve the needed blocks")
 )
 
 // dialer creates outbound connections and submits them into Server.
@@ -136,10 +143,12 @@ type dialScheduler struct {
 type dialSetupFunc func(net.Conn, connFlag, *enode.Node) error
 
 type dialConfig struct {
-	self           enode.ID         // our own ID
-	maxDialPeers   int              // maximum number of dialed peers
-	maxActiveDials int              // maximum number of active dials
-	netRestrict    *netutil.Netlist // IP whitelist, disabled if nil
+	self           enode.ID          // our own ID
+	maxDialPeers   int               // maximum number of dialed peers
+	maxActiveDials int               // maximum number of active dials
+	netRestrict    *netutil.Netlist  // IP whitelist, disabled if nil
+	reputation     *reputation.Store // deprioritizes and bans dynamic dial candidates, disabled if nil
+	neededBlock    func() uint64     // next block to sync, preferred from the candidates serving it, 0 if none
 	resolver       nodeResolver
 	dialer         NodeDialer
 	log            log.Logger
@@ -154,6 +163,9 @@ func (cfg dialConfig) withDefaults() dialConfig {
 	if cfg.clock == nil {
 		cfg.clock = mclock.System{}
 	}
+	if cfg.neededBlock == nil {
+		cfg.neededBlock = func() uint64 { return 0 }
+	}
 	if cfg.rand == nil {
 		seedb := make([]byte, 8)
 		if _, err := crand.Read(seedb); err != nil {
@@ -260,7 +272,7 @@ loop:
 			d.logStats()
 
 		case node := <-nodesCh:
-			if err := d.checkDial(node); err != nil {
+			if err := d.checkDynDial(node); err != nil {
 				d.log.Trace("Discarding dial candidate", "id", node.ID(), "ip", node.IP(), "reason", err)
 			} else {
 				d.startDial(newDialTask(node, dynDialedConn))
@@ -426,6 +438,32 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 	return nil
 }
 
+// checkDynDial runs checkDial and the reputation checks of a dynamic dial candidate. Candidates
+// banned for protocol violations are rejected, and so is a part of the candidates with a
+// score below neutral, growing as the score falls, so that the dial slots go to better nodes first.
+// While syncing, half of the candidates not advertising the needed block in their snapshot
+// ranges are rejected too.
+func (d *dialScheduler) checkDynDial(n *enode.Node) error {
+	if err := d.checkDial(n); err != nil {
+		return err
+	}
+	if needed := d.neededBlock(); needed != 0 && d.rand.Intn(2) == 0 {
+		if ranges, _ := enode.LoadSnapshotRanges(n); !ranges.Contains(needed) {
+			return errNoNeededBlocks
+		}
+	}
+	if d.reputation == nil {
+		return nil
+	}
+	if d.reputation.Banned(n.ID()) {
+		return errBanned
+	}
+	if score := d.reputation.Score(n.ID()); score < neutralScore && d.rand.Float64()*neutralScore >= score {
+		return errLowReputation
+	}
+	return nil
+}
+
 // startStaticDials starts n static dial tasks.
 func (d *dialScheduler) startStaticDials() {
 	for len(d.staticPool) > 0 {
@@ -566,6 +604,7 @@ func (t *dialTask) dial(d *dialScheduler, dest *enode.Node) error {
 		d.mutex.Lock()
 		d.errors[cleanErr.Error()] = d.errors[cleanErr.Error()] + 1
 		d.mutex.Unlock()
+		d.reputation.Report(dest.ID(), reputation.Timeout)
 		return &dialError{err}
 	}
 	mfd := newMeteredConn(fd, false, &net.TCPAddr{IP: dest.IP(), Port: dest.TCP()})
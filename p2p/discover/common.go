@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/enr"
 	"github.com/erigontech/erigon/p2p/netutil"
+	"github.com/erigontech/erigon/p2p/reputation"
 )
 
 // UDPConn is a network connection on which discovery can operate.
@@ -60,6 +61,19 @@ type Config struct {
 	PrivateKeyGenerator func() (*ecdsa.PrivateKey, error)
 
 	TableRevalidateInterval time.Duration
+
+	// NodeFilter, if set, rejects nodes from the table, e.g. nodes of other networks. It must accept
+	// nodes whose records don't carry the information it filters on: neighbors usually come without records.
+	NodeFilter func(*enode.Node) error
+
+	// PacketRate limits the packets handled per second from a single source IP, with bursts
+	// of up to PacketBurst packets. Packets over the limit are dropped. Zero disables the limit.
+	PacketRate  float64
+	PacketBurst int
+
+	// Reputation, if set, receives the timeouts of the nodes, and its banned nodes are rejected
+	// from the table.
+	Reputation *reputation.Store
 }
 
 func (cfg Config) withDefaults(defaultReplyTimeout time.Duration) Config {
@@ -87,6 +101,22 @@ func (cfg Config) withDefaults(defaultReplyTimeout time.Duration) Config {
 	return cfg
 }
 
+// nodeFilter returns NodeFilter, extended by the rejection of the nodes banned by Reputation.
+func (cfg Config) nodeFilter() func(*enode.Node) error {
+	if cfg.Reputation == nil {
+		return cfg.NodeFilter
+	}
+	return func(n *enode.Node) error {
+		if cfg.Reputation.Banned(n.ID()) {
+			return errBanned
+		}
+		if cfg.NodeFilter != nil {
+			return cfg.NodeFilter(n)
+		}
+		return nil
+	}
+}
+
 // ListenUDP starts listening for discovery packets on the given UDP socket.
 func ListenUDP(ctx context.Context, protocol string, c UDPConn, ln *enode.LocalNode, cfg Config) (*UDPv4, error) {
 	return ListenV4(ctx, protocol, c, ln, cfg)
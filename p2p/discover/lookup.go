@@ -21,9 +21,11 @@ package discover
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/reputation"
 )
 
 // lookup performs a network search for nodes close to the given target. It approaches the
@@ -172,6 +174,9 @@ func (it *lookup) query(n *node, reply chan<- []*node) {
 	} else if len(r) == 0 {
 		fails++
 		it.tab.db.UpdateFindFails(n.ID(), n.IP(), fails)
+		if errors.Is(err, errTimeout) {
+			it.tab.reputation.Report(n.ID(), reputation.Timeout)
+		}
 		// Remove the node from the local table if it fails to return anything useful too
 		// many times, but only if there are enough other nodes in the bucket.
 		dropped := false
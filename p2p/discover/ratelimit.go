@@ -0,0 +1,90 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// maxLimitedIPs bounds the number of source IPs with a token bucket. The least recently
+// seen IPs lose their bucket, and start again with a full one.
+const maxLimitedIPs = 10_000
+
+var errRateLimited = errors.New("source IP rate limited")
+
+var (
+	v4RateLimitedMeter = metrics.GetOrCreateCounter(`p2p_discovery_dropped{protocol="v4",reason="rate_limit"}`)
+	v5RateLimitedMeter = metrics.GetOrCreateCounter(`p2p_discovery_dropped{protocol="v5",reason="rate_limit"}`)
+)
+
+// ipLimiter is a token bucket rate limiter of incoming packets keyed by source IP.
+// A nil ipLimiter allows all packets.
+type ipLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu      sync.Mutex
+	buckets *lru.Cache[netip.Addr, *rate.Limiter]
+}
+
+// newIPLimiter creates a limiter allowing packetsPerSec packets per second from every
+// source IP, with bursts of up to burst packets. It returns nil if packetsPerSec isn't positive.
+func newIPLimiter(packetsPerSec float64, burst int) *ipLimiter {
+	if packetsPerSec <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	buckets, _ := lru.New[netip.Addr, *rate.Limiter](maxLimitedIPs)
+	return &ipLimiter{
+		limit:   rate.Limit(packetsPerSec),
+		burst:   burst,
+		buckets: buckets,
+	}
+}
+
+// allow reports whether a packet from the given IP may be handled, and takes a token
+// from the IP's bucket if so.
+func (l *ipLimiter) allow(ip net.IP, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+	addr = addr.Unmap()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bucket, ok := l.buckets.Get(addr)
+	if !ok {
+		bucket = rate.NewLimiter(l.limit, l.burst)
+		l.buckets.Add(addr, bucket)
+	}
+	return bucket.AllowN(now, 1)
+}
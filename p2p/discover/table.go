@@ -28,6 +28,7 @@ package discover
 import (
 	crand "crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	mrand "math/rand"
 	"net"
@@ -39,8 +40,10 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/debug"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/netutil"
+	"github.com/erigontech/erigon/p2p/reputation"
 )
 
 const (
@@ -67,6 +70,8 @@ const (
 	seedMaxAge          = 5 * 24 * time.Hour
 )
 
+var tableRejectedMeter = metrics.GetOrCreateCounter(`p2p_discovery_rejected{source="table"}`)
+
 // Table is the 'node table', a Kademlia-like index of neighbor nodes. The table keeps
 // itself up-to-date by verifying the liveness of neighbors and requesting their node
 // records when announcements of a new record version are received.
@@ -87,7 +92,9 @@ type Table struct {
 	closeReq   chan struct{}
 	closed     chan struct{}
 
-	nodeAddedHook func(*node) // for testing
+	nodeFilter    func(*enode.Node) error // rejects nodes at admission, e.g. of other networks
+	reputation    *reputation.Store       // receives the timeouts of revalidation and lookups
+	nodeAddedHook func(*node)             // for testing
 
 	// diagnostics
 	errors      map[string]uint
@@ -122,6 +129,8 @@ func newTable(
 	db *enode.DB,
 	bootnodes []*enode.Node,
 	revalidateInterval time.Duration,
+	nodeFilter func(*enode.Node) error,
+	rep *reputation.Store,
 	logger log.Logger,
 ) (*Table, error) {
 	tab := &Table{
@@ -136,6 +145,8 @@ func newTable(
 		errors:             map[string]uint{},
 		revalidateInterval: revalidateInterval,
 		protocol:           protocol,
+		nodeFilter:         nodeFilter,
+		reputation:         rep,
 		log:                logger,
 	}
 	if err := tab.setFallbackNodes(bootnodes); err != nil {
@@ -419,17 +430,23 @@ func (tab *Table) doRevalidate(done chan<- struct{}) {
 		}
 	}
 
+	// The fetched record, or our own fork, may have changed since the node was admitted.
+	admitted := rErr == nil && tab.admits(last)
+
 	tab.mutex.Lock()
 	defer tab.mutex.Unlock()
 	b := tab.buckets[bi]
-	if rErr == nil {
+	if admitted {
 		// The node responded, move it to the front.
 		last.livenessChecks++
 		tab.log.Trace("Revalidated node", "b", bi, "id", last.ID(), "checks", last.livenessChecks)
 		tab.bumpInBucket(b, last)
 		return
-	} else {
+	} else if rErr != nil {
 		tab.addError(rErr)
+		if errors.Is(rErr, errTimeout) {
+			tab.reputation.Report(last.ID(), reputation.Timeout)
+		}
 	}
 
 	// No reply received, pick a replacement or delete the node if there aren't
@@ -565,6 +582,9 @@ func (tab *Table) addSeenNode(n *node) {
 	if n.ID() == tab.self().ID() {
 		return
 	}
+	if !tab.admits(n) {
+		return
+	}
 
 	tab.mutex.Lock()
 	defer tab.mutex.Unlock()
@@ -608,6 +628,9 @@ func (tab *Table) addVerifiedNode(n *node) {
 	if n.ID() == tab.self().ID() {
 		return
 	}
+	if !tab.admits(n) {
+		return
+	}
 
 	tab.mutex.Lock()
 	defer tab.mutex.Unlock()
@@ -635,6 +658,19 @@ func (tab *Table) addVerifiedNode(n *node) {
 	}
 }
 
+// admits reports whether the node passes the node filter of the table.
+func (tab *Table) admits(n *node) bool {
+	if tab.nodeFilter == nil {
+		return true
+	}
+	if err := tab.nodeFilter(unwrapNode(n)); err != nil {
+		tableRejectedMeter.Inc()
+		tab.log.Trace("Rejected node", "id", n.ID(), "addr", n.addr(), "err", err)
+		return false
+	}
+	return true
+}
+
 // delete removes an entry from the node table. It is used to evacuate dead nodes.
 func (tab *Table) delete(node *node) {
 	tab.mutex.Lock()
@@ -20,7 +20,9 @@
 package discover
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net"
@@ -492,6 +494,65 @@ func TestTable_revalidateSyncRecord(t *testing.T) {
 	}
 }
 
+// This test checks that nodes rejected by the node filter don't get into the table, and that
+// nodes whose updated record is rejected are removed on revalidation.
+func TestTable_nodeFilter(t *testing.T) {
+	transport := newPingRecorder()
+	db, err := enode.OpenDB(context.Background(), "", t.TempDir(), log.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	errOtherNetwork := errors.New("other network")
+	filter := func(n *enode.Node) error {
+		var network string
+		if err := n.Load(enr.WithEntry("net", &network)); err == nil && network != "test" {
+			return errOtherNetwork
+		}
+		return nil
+	}
+	tab, err := newTable(transport, "test", db, nil, time.Hour, filter, nil, log.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go tab.loop()
+	defer tab.close()
+	<-tab.initDone
+
+	newNode := func(id enode.ID, network string) *enode.Node {
+		var r enr.Record
+		r.Set(enr.IP(net.IP{127, 0, 0, id[0]}))
+		if network != "" {
+			r.Set(enr.WithEntry("net", network))
+		}
+		return enode.SignNull(&r, id)
+	}
+	unknown, same, other := newNode(enode.ID{1}, ""), newNode(enode.ID{2}, "test"), newNode(enode.ID{3}, "other")
+	tab.addSeenNode(wrapNode(unknown))
+	tab.addSeenNode(wrapNode(same))
+	tab.addSeenNode(wrapNode(other))
+	tab.addVerifiedNode(wrapNode(other))
+	if tab.getNode(unknown.ID()) == nil || tab.getNode(same.ID()) == nil {
+		t.Fatal("accepted nodes are not in the table")
+	}
+	if tab.getNode(other.ID()) != nil {
+		t.Fatal("rejected node is in the table")
+	}
+
+	// the node switches to the other network
+	var r enr.Record
+	r.Set(enr.IP(net.IP{127, 0, 0, 1}))
+	r.Set(enr.WithEntry("net", "other"))
+	r.SetSeq(unknown.Seq() + 1)
+	transport.updateRecord(enode.SignNull(&r, unknown.ID()))
+	for tab.getNode(unknown.ID()) != nil {
+		tab.doRevalidate(make(chan struct{}, 1))
+	}
+	if tab.getNode(same.ID()) == nil {
+		t.Fatal("accepted node removed by revalidation")
+	}
+}
+
 func genIP(rand *rand.Rand) net.IP {
 	ip := make(net.IP, 4)
 	rand.Read(ip)
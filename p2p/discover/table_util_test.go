@@ -51,7 +51,7 @@ func newTestTable(t transport, tmpDir string, logger log.Logger) (*Table, *enode
 	if err != nil {
 		panic(err)
 	}
-	tab, _ := newTable(t, "test", db, nil, time.Hour, log.Root())
+	tab, _ := newTable(t, "test", db, nil, time.Hour, nil, nil, log.Root())
 	go tab.loop()
 	return tab, db
 }
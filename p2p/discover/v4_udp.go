@@ -50,6 +50,7 @@ var (
 	errClockWarp        = errors.New("reply deadline too far in the future")
 	errClosed           = errors.New("socket closed")
 	errLowPort          = errors.New("low port")
+	errBanned           = errors.New("banned node")
 )
 
 var (
@@ -100,6 +101,7 @@ type UDPv4 struct {
 	errors              map[string]uint
 	unsolicitedNodes    *lru.Cache[enode.ID, *enode.Node]
 	privateKeyGenerator func() (*ecdsa.PrivateKey, error)
+	limiter             *ipLimiter
 
 	trace bool
 }
@@ -179,9 +181,10 @@ func ListenV4(ctx context.Context, protocol string, c UDPConn, ln *enode.LocalNo
 		errors:              map[string]uint{},
 		unsolicitedNodes:    unsolicitedNodes,
 		privateKeyGenerator: cfg.PrivateKeyGenerator,
+		limiter:             newIPLimiter(cfg.PacketRate, cfg.PacketBurst),
 	}
 
-	tab, err := newTable(t, protocol, ln.Database(), cfg.Bootnodes, cfg.TableRevalidateInterval, cfg.Log)
+	tab, err := newTable(t, protocol, ln.Database(), cfg.Bootnodes, cfg.TableRevalidateInterval, cfg.nodeFilter(), cfg.Reputation, cfg.Log)
 	if err != nil {
 		return nil, err
 	}
@@ -721,6 +724,8 @@ func (t *UDPv4) readLoop(unhandled chan<- ReadPacket) {
 		if err := t.handlePacket(from, buf[:nbytes]); err != nil {
 			func() {
 				switch {
+				case errors.Is(err, errRateLimited):
+					// Dropped, not even passed on to the unhandled channel.
 				case errors.Is(err, errUnsolicitedReply):
 					if packet, fromKey, _, err := v4wire.Decode(buf[:nbytes]); err == nil {
 						switch packet.Kind() {
@@ -752,6 +757,13 @@ func (t *UDPv4) readLoop(unhandled chan<- ReadPacket) {
 }
 
 func (t *UDPv4) handlePacket(from *net.UDPAddr, buf []byte) error {
+	if !t.limiter.allow(from.IP, time.Now()) {
+		v4RateLimitedMeter.Inc()
+		if t.trace {
+			t.log.Trace("Rate limited discv4 packet", "addr", from)
+		}
+		return errRateLimited
+	}
 	rawpacket, fromKey, hash, err := v4wire.Decode(buf)
 	if err != nil {
 		t.log.Trace("Bad discv4 packet", "addr", from, "err", err)
@@ -177,6 +177,23 @@ func TestUDPv4_packetErrors(t *testing.T) {
 	test.packetIn(errUnsolicitedReply, &v4wire.Neighbors{Expiration: futureExp})
 }
 
+func TestUDPv4_rateLimit(t *testing.T) {
+	logger := log.New()
+	test := newUDPTest(t, logger)
+	defer test.close()
+	// A practically empty refill rate: every source IP gets its burst only.
+	test.udp.limiter = newIPLimiter(1e-6, 2)
+
+	findnode := &v4wire.Findnode{Expiration: futureExp}
+	test.packetIn(errUnknownNode, findnode)
+	test.packetIn(errUnknownNode, findnode)
+	test.packetIn(errRateLimited, findnode)
+
+	// Other source IPs have their own bucket.
+	otherAddr := &net.UDPAddr{IP: net.IP{10, 0, 1, 100}, Port: 30303}
+	test.packetInFrom(errUnknownNode, test.remotekey, otherAddr, findnode)
+}
+
 func TestUDPv4_pingTimeout(t *testing.T) {
 	t.Parallel()
 	logger := log.New()
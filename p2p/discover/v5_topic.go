@@ -0,0 +1,489 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/debug"
+	"github.com/erigontech/erigon-lib/common/mclock"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon/p2p/discover/v5wire"
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+const (
+	topicAdLifetime       = 15 * time.Minute // registrars keep an ad so long, advertisers re-register before it expires
+	topicQueueLimit       = 100              // ads per topic kept by a registrar
+	topicTableLimit       = 5000             // ads of all topics kept by a registrar
+	topicTicketValidity   = 10 * time.Second // a ticket is accepted so long after its waiting time
+	topicMaxWait          = time.Minute      // advertisers give up on registrars asking to wait longer
+	topicRegistrars       = 8                // registrars per advertised topic: the nodes closest to the topic
+	topicQueryResultLimit = 16               // applies in TOPICQUERY handler
+	topicLookupInterval   = 30 * time.Second // minimum time between lookups of a topic iterator finding nothing new
+)
+
+var (
+	errTopicTicket = errors.New("invalid topic ticket")
+	errTopicEarly  = errors.New("topic ticket used before its waiting time")
+	errTopicLate   = errors.New("topic ticket expired")
+)
+
+// Topic - identifier of a service advertised in discv5 topic tables (e.g. a network, a shard or a kind of data
+// served), hash of its name. Registrars of a topic are the nodes closest to it.
+type Topic [32]byte
+
+// NewTopic returns the topic of the given name
+func NewTopic(name string) Topic {
+	return Topic(crypto.Keccak256Hash([]byte(name)))
+}
+
+func (t Topic) String() string { return fmt.Sprintf("%x", t[:8]) }
+
+func topicFromBytes(b []byte) (Topic, error) {
+	var topic Topic
+	if len(b) != len(topic) {
+		return topic, fmt.Errorf("invalid topic length %d", len(b))
+	}
+	copy(topic[:], b)
+	return topic, nil
+}
+
+// topicTicket is issued by registrars on REQUESTTICKET: the advertiser may register after the waiting time, which
+// is non-zero if the topic queue is full. The ticket is authenticated by the registrar, it isn't secret.
+type topicTicket struct {
+	Topic  Topic
+	NodeID enode.ID
+	IP     net.IP
+	Issued uint64 // mclock.AbsTime of the registrar
+	Wait   uint64 // nanoseconds
+	MAC    []byte
+}
+
+func (tk *topicTicket) waitTime() time.Duration { return time.Duration(tk.Wait) }
+
+func (tk *topicTicket) mac(key []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(tk.Topic[:])
+	h.Write(tk.NodeID[:])
+	h.Write(tk.IP.To16())
+	h.Write(binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, tk.Issued), tk.Wait))
+	return h.Sum(nil)
+}
+
+type topicAd struct {
+	node    *enode.Node
+	expires mclock.AbsTime
+}
+
+// topicTable - ads registered at this node, served by TOPICQUERY
+type topicTable struct {
+	lock   sync.Mutex
+	queues map[Topic][]topicAd // oldest first
+	total  int
+}
+
+func newTopicTable() *topicTable {
+	return &topicTable{queues: make(map[Topic][]topicAd)}
+}
+
+// expire drops the expired ads of the topic
+func (tt *topicTable) expire(topic Topic, now mclock.AbsTime) {
+	queue := tt.queues[topic]
+	i := 0
+	for i < len(queue) && queue[i].expires <= now {
+		i++
+	}
+	if i == 0 {
+		return
+	}
+	tt.total -= i
+	if i == len(queue) {
+		delete(tt.queues, topic)
+		return
+	}
+	tt.queues[topic] = queue[i:]
+}
+
+func (tt *topicTable) full(topic Topic) bool {
+	return len(tt.queues[topic]) >= topicQueueLimit || tt.total >= topicTableLimit
+}
+
+// waitTime returns how long an advertiser must wait before registering: until the oldest ad of the topic expires
+// if its queue is full, until the oldest expiring ad of the table if the table is full
+func (tt *topicTable) waitTime(topic Topic, now mclock.AbsTime) time.Duration {
+	tt.lock.Lock()
+	defer tt.lock.Unlock()
+	tt.expire(topic, now)
+	if !tt.full(topic) {
+		return 0
+	}
+	queue := tt.queues[topic]
+	if len(queue) < topicQueueLimit {
+		// the table is full: the ads of other topics expire first
+		var oldest mclock.AbsTime
+		for t := range tt.queues {
+			if q := tt.queues[t]; len(q) > 0 && (oldest == 0 || q[0].expires < oldest) {
+				oldest = q[0].expires
+			}
+		}
+		return time.Duration(oldest - now)
+	}
+	return time.Duration(queue[0].expires - now)
+}
+
+// register adds the ad of the node, replacing its previous one. It returns false if there is no room.
+func (tt *topicTable) register(topic Topic, n *enode.Node, now mclock.AbsTime) bool {
+	tt.lock.Lock()
+	defer tt.lock.Unlock()
+	for t := range tt.queues {
+		tt.expire(t, now)
+	}
+	queue := tt.queues[topic]
+	for i, ad := range queue {
+		if ad.node.ID() == n.ID() {
+			queue = append(queue[:i], queue[i+1:]...)
+			tt.total--
+			break
+		}
+	}
+	tt.queues[topic] = queue
+	if tt.full(topic) {
+		if len(queue) == 0 {
+			delete(tt.queues, topic)
+		}
+		return false
+	}
+	tt.queues[topic] = append(queue, topicAd{node: n, expires: now.Add(topicAdLifetime)})
+	tt.total++
+	return true
+}
+
+// nodes returns the most recently registered nodes of the topic
+func (tt *topicTable) nodes(topic Topic, now mclock.AbsTime, limit int) []*enode.Node {
+	tt.lock.Lock()
+	defer tt.lock.Unlock()
+	tt.expire(topic, now)
+	queue := tt.queues[topic]
+	nodes := make([]*enode.Node, 0, min(limit, len(queue)))
+	for i := len(queue) - 1; i >= 0 && len(nodes) < limit; i-- {
+		nodes = append(nodes, queue[i].node)
+	}
+	return nodes
+}
+
+// RegisterTopic starts advertising the local node under the topic: the node registers at the nodes closest to
+// the topic and re-registers before its ads expire, until StopRegisterTopic or Close.
+func (t *UDPv5) RegisterTopic(topic Topic) {
+	t.topicLock.Lock()
+	defer t.topicLock.Unlock()
+	if _, ok := t.topicRegs[topic]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	t.topicRegs[topic] = cancel
+	t.wg.Add(1)
+	go t.advertiseTopic(ctx, topic)
+}
+
+// StopRegisterTopic stops advertising the topic. The ads already registered expire.
+func (t *UDPv5) StopRegisterTopic(topic Topic) {
+	t.topicLock.Lock()
+	defer t.topicLock.Unlock()
+	if cancel, ok := t.topicRegs[topic]; ok {
+		cancel()
+		delete(t.topicRegs, topic)
+	}
+}
+
+// advertiseTopic registers the local node at the registrars of the topic once per ad lifetime
+func (t *UDPv5) advertiseTopic(ctx context.Context, topic Topic) {
+	defer debug.LogPanic()
+	defer t.wg.Done()
+	for {
+		registrars := t.newLookup(ctx, enode.ID(topic)).run()
+		if len(registrars) > topicRegistrars {
+			registrars = registrars[:topicRegistrars]
+		}
+		var (
+			wg         sync.WaitGroup
+			registered = make(chan struct{}, len(registrars))
+		)
+		for _, n := range registrars {
+			wg.Add(1)
+			go func(n *enode.Node) {
+				defer debug.LogPanic()
+				defer wg.Done()
+				if err := t.registerTopicAt(ctx, n, topic); err != nil {
+					t.log.Trace("REGTOPIC failed", "topic", topic, "id", n.ID(), "err", err)
+					return
+				}
+				registered <- struct{}{}
+			}(n)
+		}
+		wg.Wait()
+		t.log.Debug("[discv5] advertised topic", "topic", topic, "registrars", len(registrars), "registered", len(registered))
+
+		next := topicAdLifetime * 3 / 4
+		if len(registered) == 0 {
+			next = topicLookupInterval
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.clock.After(next):
+		}
+	}
+}
+
+// registerTopicAt gets a ticket of the registrar, waits for its waiting time and registers the local node
+func (t *UDPv5) registerTopicAt(ctx context.Context, n *enode.Node, topic Topic) error {
+	ticket, raw, err := t.requestTicket(n, topic)
+	if err != nil {
+		return err
+	}
+	if wait := ticket.waitTime(); wait > topicMaxWait {
+		return fmt.Errorf("waiting time %v too long", wait)
+	} else if wait > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.clock.After(wait):
+		}
+	}
+	registered, err := t.regtopic(n, raw)
+	if err != nil {
+		return err
+	}
+	if !registered {
+		return errors.New("not registered")
+	}
+	return nil
+}
+
+// requestTicket calls REQUESTTICKET on a node and waits for its TICKET
+func (t *UDPv5) requestTicket(n *enode.Node, topic Topic) (*topicTicket, []byte, error) {
+	resp := t.call(n, v5wire.TicketMsg, &v5wire.RequestTicket{Topic: topic[:]})
+	defer t.callDone(resp)
+	select {
+	case respMsg := <-resp.ch:
+		raw := respMsg.(*v5wire.Ticket).Ticket
+		ticket := new(topicTicket)
+		if err := rlp.DecodeBytes(raw, ticket); err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", errTopicTicket, err)
+		}
+		if ticket.Topic != topic {
+			return nil, nil, fmt.Errorf("%w: topic %v, want %v", errTopicTicket, ticket.Topic, topic)
+		}
+		return ticket, raw, nil
+	case err := <-resp.err:
+		return nil, nil, err
+	}
+}
+
+// regtopic calls REGTOPIC on a node and waits for its REGCONFIRMATION
+func (t *UDPv5) regtopic(n *enode.Node, ticket []byte) (bool, error) {
+	resp := t.call(n, v5wire.RegconfirmationMsg, &v5wire.Regtopic{Ticket: ticket, ENR: t.Self().Record()})
+	defer t.callDone(resp)
+	select {
+	case respMsg := <-resp.ch:
+		return respMsg.(*v5wire.Regconfirmation).Registered, nil
+	case err := <-resp.err:
+		return false, err
+	}
+}
+
+// topicQuery calls TOPICQUERY on a node and waits for the nodes registered under the topic
+func (t *UDPv5) topicQuery(n *enode.Node, topic Topic) ([]*enode.Node, error) {
+	resp := t.call(n, v5wire.NodesMsg, &v5wire.TopicQuery{Topic: topic[:]})
+	return t.waitForNodes(resp, nil)
+}
+
+// handleRequestTicket issues a ticket of the topic to the requester
+func (t *UDPv5) handleRequestTicket(p *v5wire.RequestTicket, fromID enode.ID, fromAddr *net.UDPAddr) {
+	topic, err := topicFromBytes(p.Topic)
+	if err != nil {
+		t.log.Trace("Invalid "+p.Name(), "id", fromID, "addr", fromAddr, "err", err)
+		return
+	}
+	now := t.clock.Now()
+	ticket := &topicTicket{
+		Topic:  topic,
+		NodeID: fromID,
+		IP:     fromAddr.IP,
+		Issued: uint64(now),
+		Wait:   uint64(t.topics.waitTime(topic, now)),
+	}
+	ticket.MAC = ticket.mac(t.ticketKey)
+	raw, err := rlp.EncodeToBytes(ticket)
+	if err != nil {
+		return
+	}
+	t.sendResponse(fromID, fromAddr, &v5wire.Ticket{ReqID: p.ReqID, Ticket: raw}) //nolint:errcheck
+}
+
+// handleRegtopic registers the sender under the topic of its ticket
+func (t *UDPv5) handleRegtopic(p *v5wire.Regtopic, fromID enode.ID, fromAddr *net.UDPAddr) {
+	registered := false
+	topic, n, err := t.verifyRegtopic(p, fromID, fromAddr)
+	if err != nil {
+		t.log.Trace("Invalid "+p.Name(), "id", fromID, "addr", fromAddr, "err", err)
+	} else {
+		registered = t.topics.register(topic, n, t.clock.Now())
+	}
+	t.sendResponse(fromID, fromAddr, &v5wire.Regconfirmation{ReqID: p.ReqID, Registered: registered}) //nolint:errcheck
+}
+
+// verifyRegtopic checks the ticket and the record of a REGTOPIC
+func (t *UDPv5) verifyRegtopic(p *v5wire.Regtopic, fromID enode.ID, fromAddr *net.UDPAddr) (Topic, *enode.Node, error) {
+	ticket := new(topicTicket)
+	if err := rlp.DecodeBytes(p.Ticket, ticket); err != nil {
+		return Topic{}, nil, fmt.Errorf("%w: %w", errTopicTicket, err)
+	}
+	if !hmac.Equal(ticket.MAC, ticket.mac(t.ticketKey)) || ticket.NodeID != fromID || !ticket.IP.Equal(fromAddr.IP) {
+		return Topic{}, nil, errTopicTicket
+	}
+	now := t.clock.Now()
+	usable := mclock.AbsTime(ticket.Issued).Add(ticket.waitTime())
+	if now < usable {
+		return Topic{}, nil, errTopicEarly
+	}
+	if now > usable.Add(topicTicketValidity) {
+		return Topic{}, nil, errTopicLate
+	}
+	if p.ENR == nil {
+		return Topic{}, nil, errors.New("no record")
+	}
+	n, err := enode.New(t.validSchemes, p.ENR)
+	if err != nil {
+		return Topic{}, nil, err
+	}
+	if n.ID() != fromID {
+		return Topic{}, nil, errors.New("record of another node")
+	}
+	return ticket.Topic, n, nil
+}
+
+// handleTopicQuery returns the nodes registered under the topic to the requester
+func (t *UDPv5) handleTopicQuery(p *v5wire.TopicQuery, fromID enode.ID, fromAddr *net.UDPAddr) {
+	topic, err := topicFromBytes(p.Topic)
+	if err != nil {
+		t.log.Trace("Invalid "+p.Name(), "id", fromID, "addr", fromAddr, "err", err)
+		return
+	}
+	nodes := t.topics.nodes(topic, t.clock.Now(), topicQueryResultLimit)
+	for _, resp := range packNodes(p.ReqID, nodes) {
+		t.sendResponse(fromID, fromAddr, resp) //nolint:errcheck
+	}
+}
+
+// TopicNodes returns an iterator of the nodes advertising the topic: it looks up the registrars of the topic
+// (the nodes closest to it) and asks them for the nodes registered under it.
+func (t *UDPv5) TopicNodes(topic Topic) enode.Iterator {
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	return &topicIterator{t: t, topic: topic, ctx: ctx, cancel: cancel}
+}
+
+// topicIterator runs lookups towards the topic and TOPICQUERY on the nodes found. Every lookup yields the
+// advertisers not yet yielded by it, lookups finding no advertiser are throttled.
+type topicIterator struct {
+	t      *UDPv5
+	topic  Topic
+	ctx    context.Context
+	cancel func()
+
+	lookup  *lookup
+	asked   map[enode.ID]struct{} // registrars of the current lookup
+	seen    map[enode.ID]struct{} // advertisers yielded by the current lookup
+	found   bool                  // the current lookup yielded advertisers
+	started mclock.AbsTime        // of the current lookup
+	buffer  []*enode.Node
+}
+
+func (it *topicIterator) Node() *enode.Node {
+	if len(it.buffer) == 0 {
+		return nil
+	}
+	return it.buffer[0]
+}
+
+func (it *topicIterator) Next() bool {
+	if len(it.buffer) > 0 {
+		it.buffer = it.buffer[1:]
+	}
+	for len(it.buffer) == 0 {
+		if it.ctx.Err() != nil {
+			it.lookup, it.buffer = nil, nil
+			return false
+		}
+		if it.lookup == nil {
+			if !it.found && it.started != 0 {
+				if wait := time.Duration(it.started.Add(topicLookupInterval) - it.t.clock.Now()); wait > 0 {
+					select {
+					case <-it.ctx.Done():
+						continue
+					case <-it.t.clock.After(wait):
+					}
+				}
+			}
+			it.lookup = it.t.newLookup(it.ctx, enode.ID(it.topic))
+			it.asked = make(map[enode.ID]struct{})
+			it.seen = make(map[enode.ID]struct{})
+			it.found = false
+			it.started = it.t.clock.Now()
+			continue
+		}
+		if !it.lookup.advance() {
+			it.lookup = nil
+			continue
+		}
+		for _, registrar := range it.lookup.replyBuffer {
+			if _, ok := it.asked[registrar.ID()]; ok {
+				continue
+			}
+			it.asked[registrar.ID()] = struct{}{}
+			nodes, err := it.t.topicQuery(unwrapNode(registrar), it.topic)
+			if errors.Is(err, errClosed) {
+				break
+			}
+			for _, n := range nodes {
+				if _, ok := it.seen[n.ID()]; ok || n.ID() == it.t.Self().ID() {
+					continue
+				}
+				it.seen[n.ID()] = struct{}{}
+				it.buffer = append(it.buffer, n)
+			}
+		}
+		if len(it.buffer) > 0 {
+			it.found = true
+		}
+	}
+	return true
+}
+
+func (it *topicIterator) Close() {
+	it.cancel()
+}
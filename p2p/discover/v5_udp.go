@@ -76,11 +76,18 @@ type UDPv5 struct {
 	log          log.Logger
 	clock        mclock.Clock
 	validSchemes enr.IdentityScheme
+	limiter      *ipLimiter
 
 	// talkreq handler registry
 	trlock     sync.Mutex
 	trhandlers map[string]TalkRequestHandler
 
+	// topic tables: ads registered here, topics advertised by the local node
+	topics    *topicTable
+	ticketKey []byte // authenticates the topic tickets issued here
+	topicLock sync.Mutex
+	topicRegs map[Topic]context.CancelFunc
+
 	// channels into dispatch
 	packetInCh    chan ReadPacket
 	readNextCh    chan struct{}
@@ -157,7 +164,11 @@ func newUDPv5(ctx context.Context, protocol string, conn UDPConn, ln *enode.Loca
 		log:          cfg.Log,
 		validSchemes: cfg.ValidSchemes,
 		clock:        cfg.Clock,
+		limiter:      newIPLimiter(cfg.PacketRate, cfg.PacketBurst),
 		trhandlers:   make(map[string]TalkRequestHandler),
+		topics:       newTopicTable(),
+		ticketKey:    make([]byte, 32),
+		topicRegs:    make(map[Topic]context.CancelFunc),
 		// channels into dispatch
 		packetInCh:    make(chan ReadPacket, 1),
 		readNextCh:    make(chan struct{}, 1),
@@ -175,7 +186,8 @@ func newUDPv5(ctx context.Context, protocol string, conn UDPConn, ln *enode.Loca
 		cancelCloseCtx: cancelCloseCtx,
 		errors:         map[string]uint{},
 	}
-	tab, err := newTable(t, protocol, t.db, cfg.Bootnodes, cfg.TableRevalidateInterval, cfg.Log)
+	crand.Read(t.ticketKey)
+	tab, err := newTable(t, protocol, t.db, cfg.Bootnodes, cfg.TableRevalidateInterval, cfg.nodeFilter(), cfg.Reputation, cfg.Log)
 	if err != nil {
 		return nil, err
 	}
@@ -665,6 +677,13 @@ func (t *UDPv5) dispatchReadPacket(from *net.UDPAddr, content []byte) bool {
 // handlePacket decodes and processes an incoming packet from the network.
 func (t *UDPv5) handlePacket(rawpacket []byte, fromAddr *net.UDPAddr) error {
 	addr := fromAddr.String()
+	if !t.limiter.allow(fromAddr.IP, time.Now()) {
+		v5RateLimitedMeter.Inc()
+		if t.trace {
+			t.log.Trace("Rate limited discv5 packet", "addr", addr)
+		}
+		return errRateLimited
+	}
 	fromID, fromNode, packet, err := t.codec.Decode(rawpacket, addr)
 	if err != nil {
 		t.log.Trace("Bad discv5 packet", "id", fromID, "addr", addr, "err", err)
@@ -736,6 +755,16 @@ func (t *UDPv5) handle(p v5wire.Packet, fromID enode.ID, fromAddr *net.UDPAddr)
 		t.handleTalkRequest(p, fromID, fromAddr)
 	case *v5wire.TalkResponse:
 		t.handleCallResponse(fromID, fromAddr, p)
+	case *v5wire.RequestTicket:
+		t.handleRequestTicket(p, fromID, fromAddr)
+	case *v5wire.Ticket:
+		t.handleCallResponse(fromID, fromAddr, p)
+	case *v5wire.Regtopic:
+		t.handleRegtopic(p, fromID, fromAddr)
+	case *v5wire.Regconfirmation:
+		t.handleCallResponse(fromID, fromAddr, p)
+	case *v5wire.TopicQuery:
+		t.handleTopicQuery(p, fromID, fromAddr)
 	}
 }
 
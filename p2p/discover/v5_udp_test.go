@@ -32,6 +32,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/erigontech/erigon-lib/common/mclock"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/rlp"
 	"github.com/erigontech/erigon-lib/testlog"
@@ -713,3 +714,84 @@ func (test *udpV5Test) close() {
 		}
 	}
 }
+
+// This test checks that topic tickets, registrations and queries are handled.
+func TestUDPv5_topicHandling(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fix me on win please")
+	}
+	t.Parallel()
+	logger := log.New()
+	test := newUDPV5Test(t, logger)
+	t.Cleanup(test.close)
+
+	topic := NewTopic("eth/1")
+	remote := test.getNode(test.remotekey, test.remoteaddr, logger).Node()
+
+	// Nothing is registered yet.
+	test.packetIn(&v5wire.TopicQuery{ReqID: []byte{0}, Topic: topic[:]}, logger)
+	test.expectNodes([]byte{0}, 1, nil)
+
+	var ticket []byte
+	test.packetIn(&v5wire.RequestTicket{ReqID: []byte{1}, Topic: topic[:]}, logger)
+	test.waitPacketOut(func(p *v5wire.Ticket, addr *net.UDPAddr, _ v5wire.Nonce) {
+		var tk topicTicket
+		if err := rlp.DecodeBytes(p.Ticket, &tk); err != nil {
+			t.Fatal(err)
+		}
+		if tk.Topic != topic || tk.NodeID != remote.ID() || tk.Wait != 0 {
+			t.Fatalf("wrong ticket %+v", tk)
+		}
+		ticket = p.Ticket
+	})
+
+	// A forged ticket is rejected.
+	forged := bytes.Clone(ticket)
+	forged[len(forged)-1] ^= 1
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte{2}, Ticket: forged, ENR: remote.Record()}, logger)
+	test.waitPacketOut(func(p *v5wire.Regconfirmation, addr *net.UDPAddr, _ v5wire.Nonce) {
+		if p.Registered {
+			t.Fatal("registered with a forged ticket")
+		}
+	})
+
+	test.packetIn(&v5wire.Regtopic{ReqID: []byte{3}, Ticket: ticket, ENR: remote.Record()}, logger)
+	test.waitPacketOut(func(p *v5wire.Regconfirmation, addr *net.UDPAddr, _ v5wire.Nonce) {
+		if !p.Registered {
+			t.Fatal("not registered")
+		}
+	})
+
+	test.packetIn(&v5wire.TopicQuery{ReqID: []byte{4}, Topic: topic[:]}, logger)
+	test.expectNodes([]byte{4}, 1, []*enode.Node{remote})
+	other := NewTopic("eth/2")
+	test.packetIn(&v5wire.TopicQuery{ReqID: []byte{5}, Topic: other[:]}, logger)
+	test.expectNodes([]byte{5}, 1, nil)
+}
+
+func TestTopicTableWaitTime(t *testing.T) {
+	t.Parallel()
+	tt := newTopicTable()
+	topic := NewTopic("eth/1")
+	var now mclock.AbsTime
+	for _, n := range nodesAtDistance(enode.ID{}, 255, topicQueueLimit) {
+		if !tt.register(topic, n, now) {
+			t.Fatal("not registered")
+		}
+		now += mclock.AbsTime(time.Second)
+	}
+	if tt.register(topic, nodesAtDistance(enode.ID{}, 254, 1)[0], now) {
+		t.Fatal("registered beyond the queue limit")
+	}
+	// the oldest ad expires first
+	if wait := tt.waitTime(topic, now); wait != topicAdLifetime-time.Duration(topicQueueLimit)*time.Second {
+		t.Fatalf("wrong waiting time %v", wait)
+	}
+	if wait := tt.waitTime(NewTopic("eth/2"), now); wait != 0 {
+		t.Fatalf("wrong waiting time of another topic %v", wait)
+	}
+	now = now.Add(topicAdLifetime)
+	if got := tt.nodes(topic, now, topicQueryResultLimit); len(got) != 0 || tt.total != 0 {
+		t.Fatalf("ads not expired: %d, total %d", len(got), tt.total)
+	}
+}
@@ -46,6 +46,7 @@ const (
 	dbVersionKey   = "version" // Version of the database to flush if changes
 	dbNodePrefix   = "n:"      // Identifier to prefix node entries with
 	dbLocalPrefix  = "local:"
+	dbRepPrefix    = "rep:" // Reputation records, kept when the node entries expire
 	dbDiscoverRoot = "v4"
 	dbDiscv5Root   = "v5"
 
@@ -558,6 +559,37 @@ func (db *DB) storeLocalSeq(id ID, n uint64) {
 	db.storeUint64(localItemKey(id, dbLocalSeq), n)
 }
 
+// NodeReputation retrieves the encoded reputation record of a node, nil if there is none.
+func (db *DB) NodeReputation(id ID) []byte {
+	var blob []byte
+	if err := db.kv.View(db.ctx, func(tx kv.Tx) error {
+		v, errGet := tx.GetOne(kv.Inodes, append([]byte(dbRepPrefix), id[:]...))
+		if errGet != nil {
+			return errGet
+		}
+		if v != nil {
+			blob = make([]byte, len(v))
+			copy(blob, v)
+		}
+		return nil
+	}); err != nil {
+		return nil
+	}
+	return blob
+}
+
+// UpdateNodeReputations stores encoded reputation records of nodes in one transaction.
+func (db *DB) UpdateNodeReputations(blobs map[ID][]byte) error {
+	return db.kv.Update(db.ctx, func(tx kv.RwTx) error {
+		for id, blob := range blobs {
+			if err := tx.Put(kv.Inodes, append([]byte(dbRepPrefix), id[:]...), blob); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // QuerySeeds retrieves random nodes to be used as potential seed nodes
 // for bootstrapping.
 func (db *DB) QuerySeeds(n int, maxAge time.Duration) []*Node {
@@ -0,0 +1,98 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package enode
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/erigontech/erigon/p2p/enr"
+)
+
+// MaxSnapshotRanges is the number of ranges kept in a SnapshotRanges entry. Node records are
+// limited to 300 bytes, the smallest ranges are dropped from the entry over the limit.
+const MaxSnapshotRanges = 4
+
+// BlockRange is the range of blocks [From, To).
+type BlockRange struct {
+	From, To uint64
+}
+
+// SnapshotRanges is the "snapshots" key, which holds the ranges of blocks a node serves from
+// its snapshot files, sorted and not overlapping. Syncing nodes prefer to dial the nodes
+// serving the blocks they need.
+type SnapshotRanges []BlockRange
+
+func (SnapshotRanges) ENRKey() string { return "snapshots" }
+
+// NewSnapshotRanges sorts and merges the ranges, keeping the MaxSnapshotRanges largest ones.
+func NewSnapshotRanges(ranges []BlockRange) SnapshotRanges {
+	sorted := make(SnapshotRanges, 0, len(ranges))
+	for _, r := range ranges {
+		if r.From < r.To {
+			sorted = append(sorted, r)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b BlockRange) int { return cmp.Compare(a.From, b.From) })
+	merged := sorted[:0]
+	for _, r := range sorted {
+		if last := len(merged) - 1; last >= 0 && r.From <= merged[last].To {
+			merged[last].To = max(merged[last].To, r.To)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	for len(merged) > MaxSnapshotRanges {
+		smallest := 0
+		for i, r := range merged {
+			if r.To-r.From < merged[smallest].To-merged[smallest].From {
+				smallest = i
+			}
+		}
+		merged = slices.Delete(merged, smallest, smallest+1)
+	}
+	return merged
+}
+
+// Contains reports whether the block is in one of the ranges.
+func (s SnapshotRanges) Contains(block uint64) bool {
+	_, found := slices.BinarySearchFunc(s, block, func(r BlockRange, block uint64) int {
+		switch {
+		case r.To <= block:
+			return -1
+		case r.From > block:
+			return 1
+		default:
+			return 0
+		}
+	})
+	return found
+}
+
+// LoadSnapshotRanges returns the ranges of blocks the node serves from its snapshot files,
+// nil if its record doesn't have the "snapshots" key.
+func LoadSnapshotRanges(n *Node) (SnapshotRanges, error) {
+	var ranges SnapshotRanges
+	if err := n.Load(&ranges); err != nil {
+		if enr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load snapshot ranges from ENR: %w", err)
+	}
+	return ranges, nil
+}
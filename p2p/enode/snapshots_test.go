@@ -0,0 +1,78 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package enode
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/erigontech/erigon/p2p/enr"
+)
+
+func TestNewSnapshotRanges(t *testing.T) {
+	tests := []struct {
+		in   []BlockRange
+		want SnapshotRanges
+	}{
+		{in: nil, want: SnapshotRanges{}},
+		{in: []BlockRange{{10, 10}, {20, 5}}, want: SnapshotRanges{}},
+		{
+			in:   []BlockRange{{500, 1000}, {0, 500}, {200, 300}},
+			want: SnapshotRanges{{0, 1000}},
+		},
+		{
+			in:   []BlockRange{{0, 100}, {200, 210}, {300, 400}, {500, 700}, {800, 801}, {900, 1000}},
+			want: SnapshotRanges{{0, 100}, {300, 400}, {500, 700}, {900, 1000}},
+		},
+	}
+	for i, test := range tests {
+		if got := NewSnapshotRanges(test.in); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestSnapshotRangesContains(t *testing.T) {
+	ranges := NewSnapshotRanges([]BlockRange{{100, 200}, {300, 400}})
+	for block, want := range map[uint64]bool{0: false, 100: true, 199: true, 200: false, 350: true, 400: false} {
+		if got := ranges.Contains(block); got != want {
+			t.Errorf("Contains(%d) = %v, want %v", block, got, want)
+		}
+	}
+	if SnapshotRanges(nil).Contains(0) {
+		t.Error("empty ranges contain block 0")
+	}
+}
+
+func TestLoadSnapshotRanges(t *testing.T) {
+	var r enr.Record
+	n := SignNull(&r, ID{1})
+	if ranges, err := LoadSnapshotRanges(n); err != nil || ranges != nil {
+		t.Fatalf("record without the entry: ranges %v, err %v", ranges, err)
+	}
+
+	want := NewSnapshotRanges([]BlockRange{{0, 1000}, {2000, 3000}})
+	r.Set(want)
+	n = SignNull(&r, ID{1})
+	ranges, err := LoadSnapshotRanges(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Fatalf("got %v, want %v", ranges, want)
+	}
+}
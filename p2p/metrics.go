@@ -38,6 +38,14 @@ var (
 	egressConnectMeter  = metrics.GetOrCreateCounter("p2p_dials")
 	egressTrafficMeter  = metrics.GetOrCreateCounter(egressMeterName)
 	activePeerGauge     = metrics.GetOrCreateGauge("p2p_peers")
+
+	dialCandidatesRejectedMeter = metrics.GetOrCreateCounter(`p2p_discovery_rejected{source="dial_candidates"}`)
+
+	inboundHandshakesGauge          = metrics.GetOrCreateGauge("p2p_inbound_handshakes")
+	inboundRejectedHandshakesMeter  = metrics.GetOrCreateCounter(`p2p_inbound_rejected{reason="handshakes"}`)
+	inboundRejectedNetRestrictMeter = metrics.GetOrCreateCounter(`p2p_inbound_rejected{reason="netrestrict"}`)
+	inboundRejectedIPRateMeter      = metrics.GetOrCreateCounter(`p2p_inbound_rejected{reason="ip_rate"}`)
+	inboundRejectedBudgetMeter      = metrics.GetOrCreateCounter(`p2p_inbound_rejected{reason="handshake_budget"}`)
 )
 
 // meteredConn is a wrapper around a net.Conn that meters both the
@@ -0,0 +1,232 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/p2p/netutil"
+)
+
+const (
+	// endpointRefresh is the interval of the refresh of the port mappings and the external IP,
+	// well within the lifetime of the mappings.
+	endpointRefresh = mapTimeout / 2
+	// endpointRetry is the interval of the refresh after a failed one.
+	endpointRetry = 30 * time.Second
+)
+
+// Sources of the external IP.
+const (
+	SourceRouter = "router" // reported by the gateway (UPnP or NAT-PMP)
+	SourceSTUN   = "stun"   // observed by the STUN server
+)
+
+var errNoPublicIP = errors.New("no public external IP")
+
+// cgnat is the shared address space of carrier-grade NATs (RFC 6598)
+var _, cgnat, _ = net.ParseCIDR("100.64.0.0/10")
+
+// MappingStatus is the state of the mapping of a local port.
+type MappingStatus struct {
+	Protocol     string `json:"protocol"`
+	InternalPort int    `json:"internalPort"`
+	ExternalPort int    `json:"externalPort,omitempty"` // zero while not mapped
+	Error        string `json:"error,omitempty"`
+}
+
+// Status is the state of the detection of the external endpoint of the node.
+type Status struct {
+	Mechanism  string          `json:"mechanism"`
+	Source     string          `json:"source,omitempty"` // of ExternalIP, SourceRouter or SourceSTUN
+	ExternalIP net.IP          `json:"externalIP,omitempty"`
+	Mappings   []MappingStatus `json:"mappings"`
+	Changes    int             `json:"changes"` // of the external endpoint since the start
+	Checked    time.Time       `json:"checked"`
+	Error      string          `json:"error,omitempty"` // of the last IP detection
+}
+
+type mapping struct {
+	protocol, name string
+	intport        int
+	extport        int // last mapped, zero while not mapped
+	err            error
+}
+
+// Endpoint keeps the external endpoint of a node behind a NAT fresh. It maps the ports on the
+// gateway and polls the external IP, so the changes of the mappings and of the IP assigned to
+// the gateway are noticed. When the gateway doesn't report a public IP (e.g. behind a second NAT
+// or no UPnP/NAT-PMP gateway at all), the IP observed by the STUN fallback is used instead.
+type Endpoint struct {
+	m        Interface
+	fallback Interface // nil disables the fallback
+	logger   log.Logger
+
+	mu       sync.Mutex
+	mappings []*mapping
+	status   Status
+}
+
+// NewEndpoint creates the endpoint detection on m, with the optional fallback detection of the IP.
+func NewEndpoint(m Interface, fallback Interface, logger log.Logger) *Endpoint {
+	return &Endpoint{m: m, fallback: fallback, logger: logger, status: Status{Mechanism: m.String()}}
+}
+
+// AddPort adds the local port to map on the gateway. It must be called before Run.
+func (e *Endpoint) AddPort(protocol string, port int, name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.mappings = append(e.mappings, &mapping{protocol: protocol, name: name, intport: port})
+}
+
+// Status returns the current state of the detection.
+func (e *Endpoint) Status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	s := e.status
+	s.Mechanism = e.m.String() // resolved once auto-discovery is done
+	s.ExternalIP = append(net.IP(nil), s.ExternalIP...)
+	s.Mappings = make([]MappingStatus, len(e.mappings))
+	for i, m := range e.mappings {
+		s.Mappings[i] = MappingStatus{Protocol: m.protocol, InternalPort: m.intport, ExternalPort: m.extport}
+		if m.err != nil {
+			s.Mappings[i].Error = m.err.Error()
+		}
+	}
+	return s
+}
+
+// Run refreshes the endpoint until quit is closed, then deletes the mappings. onChange is
+// called with the external IP and the mapped ports by protocol whenever either changes.
+func (e *Endpoint) Run(quit <-chan struct{}, onChange func(ip net.IP, ports map[string]int)) {
+	timer := time.NewTimer(0)
+	defer func() {
+		timer.Stop()
+		e.deleteMappings()
+	}()
+	for {
+		select {
+		case <-quit:
+			return
+		case <-timer.C:
+			changed, ok := e.refresh()
+			if changed {
+				ip, ports := e.endpoint()
+				e.logger.Info("NAT external endpoint updated", "interface", e.m, "ip", ip, "ports", ports)
+				onChange(ip, ports)
+			}
+			if ok {
+				timer.Reset(endpointRefresh)
+			} else {
+				timer.Reset(endpointRetry)
+			}
+		}
+	}
+}
+
+// refresh renews the mappings and detects the external IP, reporting whether the
+// endpoint changed and whether everything succeeded.
+func (e *Endpoint) refresh() (changed, ok bool) {
+	ok = true
+	if e.m.SupportsMapping() {
+		for _, m := range e.mappings {
+			requested := m.extport
+			if requested == 0 {
+				requested = m.intport
+			}
+			extport, err := e.m.AddMapping(m.protocol, requested, m.intport, m.name, mapTimeout)
+			e.mu.Lock()
+			m.err = err
+			if err != nil {
+				ok = false
+				e.logger.Debug("Couldn't add port mapping", "proto", m.protocol, "intport", m.intport, "interface", e.m, "err", err)
+			} else if int(extport) != m.extport {
+				m.extport = int(extport)
+				changed = true
+			}
+			e.mu.Unlock()
+		}
+	}
+
+	source := SourceRouter
+	ip, err := e.m.ExternalIP()
+	if err == nil && !isPublic(ip) {
+		err = errNoPublicIP
+	}
+	if err != nil && e.fallback != nil {
+		e.logger.Debug("NAT gateway doesn't report the external IP, asking STUN", "interface", e.m, "err", err)
+		source = SourceSTUN
+		ip, err = e.fallback.ExternalIP()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status.Checked = time.Now()
+	e.status.Error = ""
+	if err != nil {
+		e.status.Error = err.Error()
+		ok = false
+	} else {
+		if !ip.Equal(e.status.ExternalIP) {
+			e.status.ExternalIP = ip
+			changed = true
+		}
+		e.status.Source = source
+	}
+	if changed {
+		e.status.Changes++
+	}
+	return changed, ok
+}
+
+// endpoint returns the detected IP and the mapped ports.
+func (e *Endpoint) endpoint() (net.IP, map[string]int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ports := make(map[string]int, len(e.mappings))
+	for _, m := range e.mappings {
+		if m.extport != 0 {
+			ports[m.protocol] = m.extport
+		}
+	}
+	return e.status.ExternalIP, ports
+}
+
+func (e *Endpoint) deleteMappings() {
+	if !e.m.SupportsMapping() {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, m := range e.mappings {
+		if m.extport != 0 {
+			e.m.DeleteMapping(m.protocol, m.extport, m.intport)
+		}
+	}
+}
+
+// isPublic reports whether the IP can be reached from the Internet.
+func isPublic(ip net.IP) bool {
+	if ip == nil || ip.IsUnspecified() || netutil.IsLAN(ip) || netutil.IsSpecialNetwork(ip) {
+		return false
+	}
+	return !cgnat.Contains(ip)
+}
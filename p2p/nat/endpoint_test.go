@@ -0,0 +1,132 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// fakeGateway maps the ports to themselves plus offset and reports ip.
+type fakeGateway struct {
+	ip      net.IP
+	ipErr   error
+	offset  int
+	deleted []int
+}
+
+func (g *fakeGateway) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error) {
+	return uint16(intport + g.offset), nil
+}
+
+func (g *fakeGateway) DeleteMapping(protocol string, extport, intport int) error {
+	g.deleted = append(g.deleted, extport)
+	return nil
+}
+
+func (g *fakeGateway) SupportsMapping() bool       { return true }
+func (g *fakeGateway) ExternalIP() (net.IP, error) { return g.ip, g.ipErr }
+func (g *fakeGateway) String() string              { return "fake" }
+
+func TestEndpointRefresh(t *testing.T) {
+	gw := &fakeGateway{ip: net.IP{33, 44, 55, 66}}
+	e := NewEndpoint(gw, ExtIP{1, 2, 3, 4}, log.Root())
+	e.AddPort("tcp", 30303, "p2p")
+	e.AddPort("udp", 30304, "discovery")
+
+	if changed, ok := e.refresh(); !changed || !ok {
+		t.Fatalf("first refresh: changed %v, ok %v", changed, ok)
+	}
+	if changed, ok := e.refresh(); changed || !ok {
+		t.Fatalf("refresh without changes: changed %v, ok %v", changed, ok)
+	}
+
+	// the gateway lost the mappings and mapped other ports
+	gw.offset = 100
+	if changed, _ := e.refresh(); !changed {
+		t.Fatal("port change not detected")
+	}
+	ip, ports := e.endpoint()
+	if !ip.Equal(gw.ip) || ports["tcp"] != 30403 || ports["udp"] != 30404 {
+		t.Fatalf("endpoint %v %v", ip, ports)
+	}
+
+	// the gateway got a new IP
+	gw.ip = net.IP{33, 44, 55, 67}
+	if changed, _ := e.refresh(); !changed {
+		t.Fatal("IP change not detected")
+	}
+	status := e.Status()
+	if status.Source != SourceRouter || !status.ExternalIP.Equal(gw.ip) || status.Changes != 3 {
+		t.Fatalf("status %+v", status)
+	}
+}
+
+func TestEndpointSTUNFallback(t *testing.T) {
+	stun := ExtIP{1, 2, 3, 4}
+	for _, gw := range []*fakeGateway{
+		{ip: net.IP{192, 168, 1, 1}}, // behind a second NAT
+		{ip: net.IP{100, 64, 0, 1}},  // behind a carrier-grade NAT
+		{ipErr: errors.New("no UPnP or NAT-PMP router discovered")},
+	} {
+		e := NewEndpoint(gw, stun, log.Root())
+		e.refresh()
+		if status := e.Status(); status.Source != SourceSTUN || !status.ExternalIP.Equal(net.IP(stun)) {
+			t.Errorf("gateway IP %v, err %v: status %+v", gw.ip, gw.ipErr, status)
+		}
+	}
+
+	// without the fallback the failure is reported
+	e := NewEndpoint(&fakeGateway{ip: net.IP{10, 0, 0, 1}}, nil, log.Root())
+	if _, ok := e.refresh(); ok {
+		t.Fatal("refresh without public IP succeeded")
+	}
+	if status := e.Status(); status.ExternalIP != nil || status.Error == "" {
+		t.Fatalf("status %+v", status)
+	}
+}
+
+func TestEndpointRunDeletesMappings(t *testing.T) {
+	gw := &fakeGateway{ip: net.IP{33, 44, 55, 66}}
+	e := NewEndpoint(gw, nil, log.Root())
+	e.AddPort("tcp", 30303, "p2p")
+
+	quit := make(chan struct{})
+	updated := make(chan net.IP, 1)
+	done := make(chan struct{})
+	go func() {
+		e.Run(quit, func(ip net.IP, ports map[string]int) { updated <- ip })
+		close(done)
+	}()
+	select {
+	case ip := <-updated:
+		if !ip.Equal(gw.ip) {
+			t.Fatalf("updated IP %v", ip)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("endpoint not updated")
+	}
+	close(quit)
+	<-done
+	if len(gw.deleted) != 1 || gw.deleted[0] != 30303 {
+		t.Fatalf("deleted mappings %v", gw.deleted)
+	}
+}
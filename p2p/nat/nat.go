@@ -41,8 +41,9 @@ type Interface interface {
 	//
 	// protocol is "UDP" or "TCP". Some implementations allow setting
 	// a display name for the mapping. The mapping may be removed by
-	// the gateway when its lifetime ends.
-	AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) error
+	// the gateway when its lifetime ends. The gateway may map another
+	// external port than requested, AddMapping returns the mapped one.
+	AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error)
 	DeleteMapping(protocol string, extport, intport int) error
 	SupportsMapping() bool
 
@@ -125,10 +126,10 @@ func Map(m Interface, c <-chan struct{}, protocol string, extport, intport int,
 		logger1.Trace("Deleting port mapping")
 		m.DeleteMapping(protocol, extport, intport)
 	}()
-	if err := m.AddMapping(protocol, extport, intport, name, mapTimeout); err != nil {
+	if mapped, err := m.AddMapping(protocol, extport, intport, name, mapTimeout); err != nil {
 		logger1.Debug("Couldn't add port mapping", "err", err)
 	} else {
-		logger1.Info("Mapped network port")
+		logger1.Info("Mapped network port", "mapped", mapped)
 	}
 	for {
 		select {
@@ -138,7 +139,7 @@ func Map(m Interface, c <-chan struct{}, protocol string, extport, intport int,
 			}
 		case <-refresh.C:
 			logger1.Trace("Refreshing port mapping")
-			if err := m.AddMapping(protocol, extport, intport, name, mapTimeout); err != nil {
+			if _, err := m.AddMapping(protocol, extport, intport, name, mapTimeout); err != nil {
 				logger1.Debug("Couldn't add port mapping", "err", err)
 			}
 			refresh.Reset(mapTimeout)
@@ -156,9 +157,9 @@ func (n ExtIP) String() string              { return fmt.Sprintf("ExtIP(%v)", ne
 
 // These do nothing.
 
-func (ExtIP) AddMapping(string, int, int, string, time.Duration) error { return nil }
-func (ExtIP) DeleteMapping(string, int, int) error                     { return nil }
-func (ExtIP) SupportsMapping() bool                                    { return false }
+func (ExtIP) AddMapping(string, int, int, string, time.Duration) (uint16, error) { return 0, nil }
+func (ExtIP) DeleteMapping(string, int, int) error                               { return nil }
+func (ExtIP) SupportsMapping() bool                                              { return false }
 
 // Any returns a port mapper that tries to discover any supported
 // mechanism on the local network.
@@ -215,9 +216,9 @@ func startautodisc(what string, doit func() Interface) Interface {
 	return &autodisc{what: what, doit: doit}
 }
 
-func (n *autodisc) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) error {
+func (n *autodisc) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error) {
 	if err := n.wait(); err != nil {
-		return err
+		return 0, err
 	}
 	return n.found.AddMapping(protocol, extport, intport, name, lifetime)
 }
@@ -45,8 +45,8 @@ func (STUN) SupportsMapping() bool {
 	return false
 }
 
-func (STUN) AddMapping(string, int, int, string, time.Duration) error {
-	return nil
+func (STUN) AddMapping(string, int, int, string, time.Duration) (uint16, error) {
+	return 0, nil
 }
 
 func (STUN) DeleteMapping(string, int, int) error {
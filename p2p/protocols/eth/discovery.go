@@ -24,6 +24,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/enr"
 	"github.com/erigontech/erigon/p2p/forkid"
 )
@@ -32,6 +33,9 @@ import (
 type enrEntry struct {
 	ForkID forkid.ID // Fork identifier per EIP-2124
 
+	// EarliestBlock is the earliest block the node serves, omitted for the nodes serving all history
+	EarliestBlock uint64 `rlp:"optional"`
+
 	// Ignore additional fields (for forward compatibility).
 	Rest []rlp.RawValue `rlp:"tail"`
 }
@@ -48,6 +52,24 @@ func CurrentENREntryFromForks(heightForks, timeForks []uint64, genesisHash commo
 	}
 }
 
+// WithEarliestBlock sets the earliest block the node serves, after the expiry of the older history
+func (e *enrEntry) WithEarliestBlock(earliest uint64) *enrEntry {
+	e.EarliestBlock = earliest
+	return e
+}
+
+// LoadENREarliestBlock returns the earliest block the node serves, 0 if it doesn't advertise one
+func LoadENREarliestBlock(r *enr.Record) (uint64, error) {
+	var entry enrEntry
+	if err := r.Load(&entry); err != nil {
+		if enr.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to load earliest block from ENR: %w", err)
+	}
+	return entry.EarliestBlock, nil
+}
+
 func LoadENRForkID(r *enr.Record) (*forkid.ID, error) {
 	var entry enrEntry
 	if err := r.Load(&entry); err != nil {
@@ -58,3 +80,19 @@ func LoadENRForkID(r *enr.Record) (*forkid.ID, error) {
 	}
 	return &entry.ForkID, nil
 }
+
+// NewNodeFilter returns the filter of discovered nodes by the fork ID of their `eth` ENR entry: nodes on
+// forks incompatible with the current forkFilter are rejected. Nodes without the entry are accepted, as well
+// as all nodes while forkFilter returns nil (the chain status is unknown yet).
+func NewNodeFilter(forkFilter func() forkid.Filter) func(*enode.Node) error {
+	return func(n *enode.Node) error {
+		forkID, err := LoadENRForkID(n.Record())
+		if err != nil || forkID == nil {
+			return err
+		}
+		if filter := forkFilter(); filter != nil {
+			return filter(*forkID)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+	"github.com/erigontech/erigon/p2p/forkid"
+)
+
+func TestNodeFilter(t *testing.T) {
+	heightForks := []uint64{10, 20}
+	genesis, otherGenesis := common.Hash{1}, common.Hash{2}
+	newNode := func(entry *enrEntry) *enode.Node {
+		var r enr.Record
+		if entry != nil {
+			r.Set(entry)
+		}
+		return enode.SignNull(&r, enode.ID{1})
+	}
+
+	var forkFilter forkid.Filter
+	filter := NewNodeFilter(func() forkid.Filter { return forkFilter })
+	other := newNode(CurrentENREntryFromForks(heightForks, nil, otherGenesis, 15, 0))
+	require.NoError(t, filter(other), "status is unknown yet")
+
+	forkFilter = forkid.NewFilterFromForks(heightForks, nil, genesis, 15, 0)
+	require.NoError(t, filter(newNode(nil)), "no eth entry")
+	require.NoError(t, filter(newNode(CurrentENREntryFromForks(heightForks, nil, genesis, 15, 0))))
+	require.NoError(t, filter(newNode(CurrentENREntryFromForks(heightForks, nil, genesis, 25, 0))), "remote is ahead")
+	require.ErrorIs(t, filter(other), forkid.ErrLocalIncompatibleOrStale)
+}
+
+func TestENREarliestBlock(t *testing.T) {
+	heightForks := []uint64{10, 20}
+	genesis := common.Hash{1}
+	load := func(entry *enrEntry) (uint64, *forkid.ID) {
+		var r enr.Record
+		r.Set(entry)
+		earliest, err := LoadENREarliestBlock(&r)
+		require.NoError(t, err)
+		forkID, err := LoadENRForkID(&r)
+		require.NoError(t, err)
+		return earliest, forkID
+	}
+
+	earliest, forkID := load(CurrentENREntryFromForks(heightForks, nil, genesis, 15, 0))
+	require.Zero(t, earliest, "all history served")
+	require.Equal(t, forkid.NewIDFromForks(heightForks, nil, genesis, 15, 0), *forkID)
+
+	earliest, forkID = load(CurrentENREntryFromForks(heightForks, nil, genesis, 15, 0).WithEarliestBlock(12))
+	require.Equal(t, uint64(12), earliest)
+	require.Equal(t, forkid.NewIDFromForks(heightForks, nil, genesis, 15, 0), *forkID, "fork ID readable with the earliest block")
+}
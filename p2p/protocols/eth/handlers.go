@@ -34,7 +34,9 @@ import (
 	"github.com/erigontech/erigon/turbo/services"
 )
 
-func AnswerGetBlockHeadersQuery(db kv.Tx, query *GetBlockHeadersPacket, blockReader services.HeaderReader) ([]*types.Header, error) {
+// AnswerGetBlockHeadersQuery collects the headers of the query. Headers below the earliest served block
+// are not served: the response ends before the first of them.
+func AnswerGetBlockHeadersQuery(db kv.Tx, query *GetBlockHeadersPacket, earliest uint64, blockReader services.HeaderReader) ([]*types.Header, error) {
 	hashMode := query.Origin.Hash != (common.Hash{})
 	first := true
 	maxNonCanonical := uint64(100)
@@ -75,7 +77,7 @@ func AnswerGetBlockHeadersQuery(db kv.Tx, query *GetBlockHeadersPacket, blockRea
 				return nil, err
 			}
 		}
-		if origin == nil {
+		if origin == nil || origin.Number.Uint64() < earliest {
 			break
 		}
 		headers = append(headers, origin)
@@ -143,7 +145,9 @@ func AnswerGetBlockHeadersQuery(db kv.Tx, query *GetBlockHeadersPacket, blockRea
 	return headers, nil
 }
 
-func AnswerGetBlockBodiesQuery(db kv.Tx, query GetBlockBodiesPacket, blockReader services.HeaderAndBodyReader) []rlp.RawValue { //nolint:unparam
+// AnswerGetBlockBodiesQuery collects the bodies of the query, skipping the unknown blocks. It stops at
+// the first block below the earliest served one: bodies are matched to the query by position.
+func AnswerGetBlockBodiesQuery(db kv.Tx, query GetBlockBodiesPacket, earliest uint64, blockReader services.HeaderAndBodyReader) []rlp.RawValue { //nolint:unparam
 	// Gather blocks until the fetch or network limits is reached
 	var bytes int
 	bodies := make([]rlp.RawValue, 0, len(query))
@@ -157,6 +161,9 @@ func AnswerGetBlockBodiesQuery(db kv.Tx, query GetBlockBodiesPacket, blockReader
 		if number == nil {
 			continue
 		}
+		if *number < earliest {
+			break
+		}
 		bodyRLP, _ := blockReader.BodyRlp(context.Background(), db, hash, *number)
 		if len(bodyRLP) == 0 {
 			continue
@@ -178,7 +185,9 @@ type cachedReceipts struct {
 	PendingIndex    int // index of the first not-found receipt in the query
 }
 
-func AnswerGetReceiptsQueryCacheOnly(ctx context.Context, receiptsGetter ReceiptsGetter, query GetReceiptsPacket) (*cachedReceipts, bool, error) {
+// AnswerGetReceiptsQueryCacheOnly collects the cached receipts of the query prefix, the rest is left to
+// AnswerGetReceiptsQuery. Like there, it stops at the first block below the earliest served one.
+func AnswerGetReceiptsQueryCacheOnly(ctx context.Context, receiptsGetter ReceiptsGetter, query GetReceiptsPacket, earliest uint64) (*cachedReceipts, bool, error) {
 	var (
 		bytes        int
 		receiptsList []rlp.RawValue
@@ -193,6 +202,16 @@ func AnswerGetReceiptsQueryCacheOnly(ctx context.Context, receiptsGetter Receipt
 			break
 		}
 		if receipts, ok := receiptsGetter.GetCachedReceipts(ctx, hash); ok {
+			if earliest > 0 {
+				// the number of a block without receipts isn't known here, AnswerGetReceiptsQuery reads it
+				if len(receipts) == 0 || receipts[0].BlockNumber == nil {
+					break
+				}
+				if receipts[0].BlockNumber.Uint64() < earliest {
+					needMore = false
+					break
+				}
+			}
 			if encoded, err := rlp.EncodeToBytes(receipts); err != nil {
 				return nil, needMore, fmt.Errorf("failed to encode receipt: %w", err)
 			} else {
@@ -214,7 +233,9 @@ func AnswerGetReceiptsQueryCacheOnly(ctx context.Context, receiptsGetter Receipt
 	}, needMore, nil
 }
 
-func AnswerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGetter ReceiptsGetter, br services.HeaderAndBodyReader, db kv.TemporalTx, query GetReceiptsPacket, cachedReceipts *cachedReceipts) ([]rlp.RawValue, error) { //nolint:unparam
+// AnswerGetReceiptsQuery collects the receipts of the query, following the cached ones. It stops at the
+// first block below the earliest served one: receipts are matched to the query by position.
+func AnswerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGetter ReceiptsGetter, br services.HeaderAndBodyReader, db kv.TemporalTx, query GetReceiptsPacket, earliest uint64, cachedReceipts *cachedReceipts) ([]rlp.RawValue, error) { //nolint:unparam
 	// Gather state data until the fetch or network limits is reached
 	var (
 		bytes        int
@@ -238,6 +259,9 @@ func AnswerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGett
 		if number == nil {
 			return nil, nil
 		}
+		if *number < earliest {
+			break
+		}
 		// Retrieve the requested block's receipts
 		b, _, err := br.BlockWithSenders(context.Background(), db, hash, *number)
 		if err != nil {
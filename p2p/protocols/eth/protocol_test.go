@@ -230,3 +230,56 @@ func TestEth66Messages(t *testing.T) {
 		}
 	}
 }
+
+func TestReceiptsPacket69(t *testing.T) {
+	log := &types.Log{Address: common.Address{1}, Topics: []common.Hash{{2}}, Data: []byte{3}}
+	receipts := []types.Receipts{
+		{
+			{Type: types.LegacyTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000},
+			{Type: types.DynamicFeeTxType, Status: types.ReceiptStatusFailed, CumulativeGasUsed: 50000, Logs: []*types.Log{log}},
+		},
+		{},
+	}
+	packet := ReceiptsRLPPacket66{RequestId: 7}
+	for _, blockReceipts := range receipts {
+		for _, r := range blockReceipts {
+			r.Bloom = types.CreateBloom(types.Receipts{r})
+		}
+		b, err := rlp.EncodeToBytes(blockReceipts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packet.ReceiptsRLPPacket = append(packet.ReceiptsRLPPacket, b)
+	}
+	data68, err := rlp.EncodeToBytes(&packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data69, err := ReceiptsPacket69From68(data68)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var packet69 struct {
+		RequestId uint64
+		Receipts  [][]receiptRLP69
+	}
+	if err := rlp.DecodeBytes(data69, &packet69); err != nil {
+		t.Fatalf("decode eth/69 receipts: %v", err)
+	}
+	if packet69.RequestId != 7 || len(packet69.Receipts) != 2 || len(packet69.Receipts[0]) != 2 || len(packet69.Receipts[1]) != 0 {
+		t.Fatalf("unexpected eth/69 receipts: %+v", packet69)
+	}
+	if r := packet69.Receipts[0][1]; r.TxType != types.DynamicFeeTxType || r.CumulativeGasUsed != 50000 {
+		t.Fatalf("unexpected eth/69 receipt: %+v", r)
+	}
+
+	// the blooms are re-computed from the logs
+	back, err := ReceiptsPacket68From69(data69)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(back, data68) {
+		t.Fatalf("have\n\t%x\nwant\n\t%x", back, data68)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// receiptRLP68 is the eth/68 (consensus) encoding of a receipt, the typed ones are wrapped in their envelope
+type receiptRLP68 struct {
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Bloom             types.Bloom
+	Logs              rlp.RawValue
+}
+
+// receiptRLP69 is the eth/69 encoding of a receipt: without the bloom, with the transaction type
+type receiptRLP69 struct {
+	TxType            uint8
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              rlp.RawValue
+}
+
+// ReceiptsPacket69From68 re-encodes a Receipts message of eth/68 (as the core replies) for eth/69 peers
+func ReceiptsPacket69From68(data []byte) ([]byte, error) {
+	return convertReceiptsPacket(data, receipt69From68)
+}
+
+// ReceiptsPacket68From69 re-encodes a Receipts message of an eth/69 peer in the eth/68 encoding the core decodes,
+// the blooms are re-computed from the logs
+func ReceiptsPacket68From69(data []byte) ([]byte, error) {
+	return convertReceiptsPacket(data, receipt68From69)
+}
+
+func convertReceiptsPacket(data []byte, convert func(rlp.RawValue) (rlp.RawValue, error)) ([]byte, error) {
+	var packet ReceiptsRLPPacket66
+	if err := rlp.DecodeBytes(data, &packet); err != nil {
+		return nil, fmt.Errorf("decode receipts packet: %w", err)
+	}
+	for i, blockReceipts := range packet.ReceiptsRLPPacket {
+		var receipts []rlp.RawValue
+		if err := rlp.DecodeBytes(blockReceipts, &receipts); err != nil {
+			return nil, fmt.Errorf("decode receipts of block %d: %w", i, err)
+		}
+		for j, receipt := range receipts {
+			converted, err := convert(receipt)
+			if err != nil {
+				return nil, fmt.Errorf("receipt %d of block %d: %w", j, i, err)
+			}
+			receipts[j] = converted
+		}
+		encoded, err := rlp.EncodeToBytes(receipts)
+		if err != nil {
+			return nil, err
+		}
+		packet.ReceiptsRLPPacket[i] = encoded
+	}
+	return rlp.EncodeToBytes(&packet)
+}
+
+func receipt69From68(receipt rlp.RawValue) (rlp.RawValue, error) {
+	kind, content, _, err := rlp.Split(receipt)
+	if err != nil {
+		return nil, err
+	}
+	var txType uint8
+	payload := []byte(receipt)
+	if kind != rlp.List { // typed receipt: type || rlp(receiptRLP68)
+		if len(content) == 0 {
+			return nil, errors.New("empty typed receipt")
+		}
+		txType, payload = content[0], content[1:]
+	}
+	var r receiptRLP68
+	if err := rlp.DecodeBytes(payload, &r); err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(&receiptRLP69{TxType: txType, PostStateOrStatus: r.PostStateOrStatus, CumulativeGasUsed: r.CumulativeGasUsed, Logs: r.Logs})
+}
+
+func receipt68From69(receipt rlp.RawValue) (rlp.RawValue, error) {
+	var r receiptRLP69
+	if err := rlp.DecodeBytes(receipt, &r); err != nil {
+		return nil, err
+	}
+	var logs []*types.Log
+	if err := rlp.DecodeBytes(r.Logs, &logs); err != nil {
+		return nil, fmt.Errorf("decode logs: %w", err)
+	}
+	payload, err := rlp.EncodeToBytes(&receiptRLP68{PostStateOrStatus: r.PostStateOrStatus, CumulativeGasUsed: r.CumulativeGasUsed, Bloom: types.LogsBloom(logs), Logs: r.Logs})
+	if err != nil {
+		return nil, err
+	}
+	if r.TxType == types.LegacyTxType {
+		return payload, nil
+	}
+	return rlp.EncodeToBytes(append([]byte{r.TxType}, payload...))
+}
@@ -0,0 +1,313 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package reputation keeps the reputation of the nodes of the network, shared by the
+// discovery, the p2p server dialing and accepting peers, and the sentry serving the
+// block downloader. The records are kept in the node database, so repeat offenders stay
+// banned across restarts.
+package reputation
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+const (
+	// DefaultBanViolations is the number of protocol violations banning a node.
+	DefaultBanViolations = 3
+	// DefaultBanDuration is the duration of the first ban of a node. Every further ban
+	// doubles it, up to maxBanDoublings times.
+	DefaultBanDuration = time.Hour
+	// DefaultHalfLife is the time after which the events of a node count half.
+	DefaultHalfLife = 6 * time.Hour
+
+	maxBanDoublings = 5
+	cachedRecords   = 10_000
+	recordSize      = 5*4 + 2*8
+)
+
+var (
+	eventsMeter = map[Event]metrics.Counter{
+		Useful:    metrics.GetOrCreateCounter(`p2p_reputation_events{event="useful"}`),
+		Useless:   metrics.GetOrCreateCounter(`p2p_reputation_events{event="useless"}`),
+		Timeout:   metrics.GetOrCreateCounter(`p2p_reputation_events{event="timeout"}`),
+		Violation: metrics.GetOrCreateCounter(`p2p_reputation_events{event="violation"}`),
+	}
+	bansMeter = metrics.GetOrCreateCounter("p2p_reputation_bans")
+)
+
+// Event is something a node did, reported to its reputation.
+type Event uint8
+
+const (
+	Useful    Event = iota // a response with the requested data
+	Useless                // an empty response, or a peer of no use (e.g. of another network)
+	Timeout                // a request, ping or dial which wasn't answered in time
+	Violation              // a protocol violation: invalid message, failed handshake, penalty of the downloader
+)
+
+func (e Event) String() string {
+	switch e {
+	case Useful:
+		return "useful"
+	case Useless:
+		return "useless"
+	case Timeout:
+		return "timeout"
+	case Violation:
+		return "violation"
+	default:
+		return fmt.Sprintf("Event(%d)", uint8(e))
+	}
+}
+
+// Config of the reputation store. Zero fields take their defaults.
+type Config struct {
+	BanViolations uint32        // violations banning a node
+	BanDuration   time.Duration // of the first ban of a node
+	HalfLife      time.Duration // of the event counts
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.BanViolations == 0 {
+		cfg.BanViolations = DefaultBanViolations
+	}
+	if cfg.BanDuration == 0 {
+		cfg.BanDuration = DefaultBanDuration
+	}
+	if cfg.HalfLife == 0 {
+		cfg.HalfLife = DefaultHalfLife
+	}
+	return cfg
+}
+
+// Record is the reputation of a node: counts of its events, decaying with Config.HalfLife,
+// and its bans.
+type Record struct {
+	Useful, Useless, Timeouts, Violations uint32
+
+	Bans        uint32 // number of bans, lengthening the next one
+	BannedUntil uint64 // unix time
+	Updated     uint64 // unix time of the last decay of the counts
+}
+
+// Score is the share of the useful responses among the responses and timeouts of the node,
+// 0.5 for a node without any.
+func (r Record) Score() float64 {
+	return (float64(r.Useful) + 1) / (float64(r.Useful) + float64(r.Useless) + float64(r.Timeouts) + 2)
+}
+
+// Banned reports whether the node is banned at the given time.
+func (r Record) Banned(now time.Time) bool {
+	return r.BannedUntil > uint64(now.Unix())
+}
+
+// decay halves the counts for every half-life passed since the last decay.
+func (r *Record) decay(now time.Time, halfLife time.Duration) {
+	unixNow := uint64(now.Unix())
+	if r.Updated == 0 || unixNow < r.Updated {
+		r.Updated = unixNow
+		return
+	}
+	period := uint64(halfLife / time.Second)
+	if period == 0 {
+		period = 1
+	}
+	halvings := (unixNow - r.Updated) / period
+	if halvings == 0 {
+		return
+	}
+	shift := min(halvings, 32)
+	r.Useful >>= shift
+	r.Useless >>= shift
+	r.Timeouts >>= shift
+	r.Violations >>= shift
+	r.Updated += halvings * period
+}
+
+func (r Record) encode() []byte {
+	b := make([]byte, recordSize)
+	binary.BigEndian.PutUint32(b[0:], r.Useful)
+	binary.BigEndian.PutUint32(b[4:], r.Useless)
+	binary.BigEndian.PutUint32(b[8:], r.Timeouts)
+	binary.BigEndian.PutUint32(b[12:], r.Violations)
+	binary.BigEndian.PutUint32(b[16:], r.Bans)
+	binary.BigEndian.PutUint64(b[20:], r.BannedUntil)
+	binary.BigEndian.PutUint64(b[28:], r.Updated)
+	return b
+}
+
+func decodeRecord(b []byte) (Record, error) {
+	if len(b) != recordSize {
+		return Record{}, fmt.Errorf("reputation record of %d bytes, expected %d", len(b), recordSize)
+	}
+	return Record{
+		Useful:      binary.BigEndian.Uint32(b[0:]),
+		Useless:     binary.BigEndian.Uint32(b[4:]),
+		Timeouts:    binary.BigEndian.Uint32(b[8:]),
+		Violations:  binary.BigEndian.Uint32(b[12:]),
+		Bans:        binary.BigEndian.Uint32(b[16:]),
+		BannedUntil: binary.BigEndian.Uint64(b[20:]),
+		Updated:     binary.BigEndian.Uint64(b[28:]),
+	}, nil
+}
+
+// Store keeps the reputation records of the nodes, cached in memory and written to the node
+// database by Flush. A nil *Store accepts all reports and bans nobody.
+type Store struct {
+	cfg    Config
+	db     *enode.DB // nil keeps the records in memory only
+	logger log.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	records *simplelru.LRU[enode.ID, *Record]
+	dirty   map[enode.ID][]byte // encoded records to write by the next Flush
+}
+
+// New creates a store of the reputation records kept in db, which may be nil.
+func New(db *enode.DB, cfg Config, logger log.Logger) *Store {
+	s := &Store{
+		cfg:    cfg.withDefaults(),
+		db:     db,
+		logger: logger,
+		now:    time.Now,
+		dirty:  make(map[enode.ID][]byte),
+	}
+	// Evicted records are written by the next Flush, they are loaded again from the database.
+	s.records, _ = simplelru.NewLRU[enode.ID, *Record](cachedRecords, func(id enode.ID, r *Record) {
+		if s.db != nil {
+			if _, ok := s.dirty[id]; ok {
+				s.dirty[id] = r.encode()
+			}
+		}
+	})
+	return s
+}
+
+// record returns the cached record of the node, loading it from the database if needed.
+// s.mu must be held.
+func (s *Store) record(id enode.ID) *Record {
+	if r, ok := s.records.Get(id); ok {
+		return r
+	}
+	r := new(Record)
+	if blob, ok := s.dirty[id]; ok {
+		*r, _ = decodeRecord(blob)
+	} else if s.db != nil {
+		if blob := s.db.NodeReputation(id); blob != nil {
+			rec, err := decodeRecord(blob)
+			if err != nil {
+				s.logger.Debug("[p2p] Dropping invalid reputation record", "id", id, "err", err)
+			} else {
+				*r = rec
+			}
+		}
+	}
+	s.records.Add(id, r)
+	return r
+}
+
+// Report records an event of the node. Enough violations ban the node.
+func (s *Store) Report(id enode.ID, ev Event) {
+	if s == nil {
+		return
+	}
+	eventsMeter[ev].Inc()
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.record(id)
+	r.decay(now, s.cfg.HalfLife)
+	switch ev {
+	case Useful:
+		r.Useful++
+	case Useless:
+		r.Useless++
+	case Timeout:
+		r.Timeouts++
+	case Violation:
+		r.Violations++
+		if r.Violations >= s.cfg.BanViolations && !r.Banned(now) {
+			ban := s.cfg.BanDuration << min(r.Bans, maxBanDoublings)
+			r.Bans++
+			r.BannedUntil = uint64(now.Add(ban).Unix())
+			r.Violations = 0
+			bansMeter.Inc()
+			s.logger.Debug("[p2p] Banned node for protocol violations", "id", id, "bans", r.Bans, "duration", ban)
+		}
+	}
+	if s.db != nil {
+		s.dirty[id] = nil
+	}
+}
+
+// Get returns the reputation record of the node.
+func (s *Store) Get(id enode.ID) Record {
+	if s == nil {
+		return Record{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := s.record(id)
+	r.decay(s.now(), s.cfg.HalfLife)
+	return *r
+}
+
+// Score returns the score of the node, see Record.Score.
+func (s *Store) Score(id enode.ID) float64 {
+	return s.Get(id).Score()
+}
+
+// Banned reports whether the node is banned.
+func (s *Store) Banned(id enode.ID) bool {
+	return s.Get(id).Banned(s.now())
+}
+
+// Flush writes the records changed since the last Flush to the node database.
+func (s *Store) Flush() {
+	if s == nil || s.db == nil {
+		return
+	}
+	s.mu.Lock()
+	if len(s.dirty) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	blobs := make(map[enode.ID][]byte, len(s.dirty))
+	for id, blob := range s.dirty {
+		if blob == nil {
+			r, _ := s.records.Peek(id)
+			blob = r.encode()
+		}
+		blobs[id] = blob
+	}
+	s.dirty = make(map[enode.ID][]byte)
+	s.mu.Unlock()
+
+	if err := s.db.UpdateNodeReputations(blobs); err != nil {
+		s.logger.Warn("[p2p] Failed to write reputation records", "err", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package reputation
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+func TestBanAfterViolations(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := New(nil, Config{}, log.Root())
+	s.now = func() time.Time { return now }
+	id := enode.ID{1}
+
+	for i := 0; i < DefaultBanViolations-1; i++ {
+		s.Report(id, Violation)
+	}
+	if s.Banned(id) {
+		t.Fatalf("banned after %d violations", DefaultBanViolations-1)
+	}
+	s.Report(id, Violation)
+	if !s.Banned(id) {
+		t.Fatalf("not banned after %d violations", DefaultBanViolations)
+	}
+
+	// The ban expires, the next one is twice as long.
+	now = now.Add(DefaultBanDuration)
+	if s.Banned(id) {
+		t.Fatal("still banned after the ban duration")
+	}
+	for i := 0; i < DefaultBanViolations; i++ {
+		s.Report(id, Violation)
+	}
+	now = now.Add(DefaultBanDuration)
+	if !s.Banned(id) {
+		t.Fatal("second ban not doubled")
+	}
+	now = now.Add(DefaultBanDuration)
+	if s.Banned(id) {
+		t.Fatal("still banned after the doubled ban duration")
+	}
+}
+
+func TestScoreDecay(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := New(nil, Config{}, log.Root())
+	s.now = func() time.Time { return now }
+	id := enode.ID{2}
+
+	if score := s.Score(id); score != 0.5 {
+		t.Fatalf("score of unknown node %v, want 0.5", score)
+	}
+	for i := 0; i < 8; i++ {
+		s.Report(id, Timeout)
+	}
+	if score := s.Score(id); score != 0.1 {
+		t.Fatalf("score after 8 timeouts %v, want 0.1", score)
+	}
+	now = now.Add(2 * DefaultHalfLife)
+	if rec := s.Get(id); rec.Timeouts != 2 {
+		t.Fatalf("timeouts after two half-lives %d, want 2", rec.Timeouts)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "database")
+	id := enode.ID{3}
+
+	db, err := enode.OpenDB(context.Background(), path, root, log.Root())
+	if err != nil {
+		t.Fatalf("failed to create persistent database: %v", err)
+	}
+	s := New(db, Config{BanViolations: 1}, log.Root())
+	s.Report(id, Useful)
+	s.Report(id, Violation)
+	s.Flush()
+	db.Close()
+
+	db, err = enode.OpenDB(context.Background(), path, root, log.Root())
+	if err != nil {
+		t.Fatalf("failed to open persistent database: %v", err)
+	}
+	defer db.Close()
+	s = New(db, Config{BanViolations: 1}, log.Root())
+	rec := s.Get(id)
+	if !s.Banned(id) || rec.Useful != 1 || rec.Bans != 1 {
+		t.Fatalf("record not restored: %+v", rec)
+	}
+}
@@ -22,12 +22,15 @@ import (
 
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/direct"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/rlp"
 	"github.com/erigontech/erigon/execution/chainspec"
+	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/forkid"
 	"github.com/erigontech/erigon/p2p/protocols/eth"
 )
@@ -96,3 +99,30 @@ func TestCheckPeerStatusCompatibility(t *testing.T) {
 		assert.ErrorIs(t, err, forkid.ErrLocalIncompatibleOrStale)
 	})
 }
+
+func TestTryDecodeStatusMessage69(t *testing.T) {
+	status := eth.StatusPacket69{
+		ProtocolVersion: direct.ETH69,
+		NetworkID:       1,
+		Genesis:         chainspec.MainnetGenesisHash,
+		EarliestBlock:   10,
+		LatestBlock:     20,
+		LatestBlockHash: common.Hash{1},
+	}
+	decode := func(status eth.StatusPacket69) (*eth.StatusPacket, *eth.BlockRangeUpdatePacket, error) {
+		size, r, err := rlp.EncodeToReader(&status)
+		require.NoError(t, err)
+		return tryDecodeStatusMessage(&p2p.Msg{Code: eth.StatusMsg, Size: uint32(size), Payload: r}, direct.ETH69)
+	}
+
+	reply, blockRange, err := decode(status)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{1}, reply.Head)
+	assert.Equal(t, uint32(direct.ETH69), reply.ProtocolVersion)
+	assert.Equal(t, &eth.BlockRangeUpdatePacket{EarliestBlock: 10, LatestBlock: 20, LatestBlockHash: common.Hash{1}}, blockRange)
+
+	invalid := status
+	invalid.EarliestBlock = 21
+	_, _, err = decode(invalid)
+	assert.ErrorContains(t, err, "invalid block range")
+}
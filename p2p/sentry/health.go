@@ -0,0 +1,125 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/protocols/eth"
+)
+
+func peersGauge(protocol uint) metrics.Gauge {
+	return metrics.GetOrCreateGauge(fmt.Sprintf(`sentry_peers{protocol="%s/%d"}`, eth.ProtocolName, protocol))
+}
+
+func handshakeFailures(code p2p.PeerErrorCode) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_handshake_failures{reason="%s"}`, code))
+}
+
+func messagesCounter(inbound bool, msgType string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_messages{direction="%s",type="%s"}`, direction(inbound), msgType))
+}
+
+func messageBytesCounter(inbound bool, msgType string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_message_bytes{direction="%s",type="%s"}`, direction(inbound), msgType))
+}
+
+func direction(inbound bool) string {
+	if inbound {
+		return "in"
+	}
+	return "out"
+}
+
+// Readiness - reply of the readiness probe
+type Readiness struct {
+	Ready            bool           `json:"ready"`
+	Peers            int            `json:"peers"`
+	PeersPerProtocol map[string]int `json:"peersPerProtocol"`
+	MinPeers         int            `json:"minPeers"`
+	P2PStarted       bool           `json:"p2pStarted"` // after the first SetStatus call
+	StatusSet        bool           `json:"statusSet"`
+}
+
+// HealthHandler - HTTP probes of the sentry:
+//   - /health: liveness, 200 while the sentry is running
+//   - /ready: readiness, 200 once it has at least minPeers peers, 503 otherwise. It doesn't wait for the status
+//     of the node: the node sets it over the gRPC API, which must be reachable first.
+func (ss *GrpcServer) HealthHandler(minPeers int) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		if ss.ctx.Err() != nil {
+			http.Error(w, "stopping", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		readiness := ss.readiness(minPeers)
+		w.Header().Set("Content-Type", "application/json")
+		if !readiness.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(readiness)
+	})
+	return mux
+}
+
+func (ss *GrpcServer) readiness(minPeers int) Readiness {
+	readiness := Readiness{
+		PeersPerProtocol: map[string]int{},
+		MinPeers:         minPeers,
+		P2PStarted:       ss.getP2PServer() != nil,
+		StatusSet:        ss.GetStatus() != nil,
+	}
+	for protocol, count := range ss.SimplePeerCount() {
+		readiness.PeersPerProtocol[fmt.Sprintf("%s/%d", eth.ProtocolName, protocol)] = count
+		readiness.Peers += count
+	}
+	readiness.Ready = ss.ctx.Err() == nil && readiness.Peers >= minPeers
+	return readiness
+}
+
+// serveHealth - serves HealthHandler on addr until ctx is done
+func serveHealth(ctx context.Context, addr string, ss *GrpcServer, minPeers int, logger log.Logger) {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           ss.HealthHandler(minPeers),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		logger.Info("Starting Sentry health server", "on", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Sentry health server fail", "err", err)
+		}
+	}()
+}
@@ -58,6 +58,7 @@ import (
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/forkid"
 	"github.com/erigontech/erigon/p2p/protocols/eth"
+	"github.com/erigontech/erigon/p2p/reputation"
 
 	_ "github.com/erigontech/erigon/polygon/chain" // Register Polygon chains
 )
@@ -77,8 +78,10 @@ type PeerInfo struct {
 	deadlines     []time.Time // Request deadlines
 	latestDealine time.Time
 	height        uint64
+	earliest      uint64 // earliest block the peer serves, announced by eth/69 peers
 	rw            p2p.MsgReadWriter
 	protocol      uint
+	reputation    *reputation.Store // gets the answered and expired requests, nil if not kept
 
 	ctx       context.Context
 	ctxCancel context.CancelFunc
@@ -197,10 +200,22 @@ func (pi *PeerInfo) SetIncreasedHeight(newHeight uint64) {
 	}
 }
 
+// SetBlockRange records the range of the blocks an eth/69 peer serves, of its status or BlockRangeUpdate
+func (pi *PeerInfo) SetBlockRange(earliest, latest uint64) {
+	atomic.StoreUint64(&pi.earliest, earliest)
+	pi.SetIncreasedHeight(latest)
+}
+
+// EarliestBlock returns the earliest block the peer serves, 0 for the peers before eth/69
+func (pi *PeerInfo) EarliestBlock() uint64 {
+	return atomic.LoadUint64(&pi.earliest)
+}
+
 // ClearDeadlines goes through the deadlines of
 // given peers and removes the ones that have passed
 // Optionally, it also clears one extra deadline - this is used when response is received
 // It returns the number of deadlines left
+// The passed deadlines are reported as timeouts of the peer, the cleared extra one as a useful response
 func (pi *PeerInfo) ClearDeadlines(now time.Time, givePermit bool) int {
 	pi.lock.Lock()
 	defer pi.lock.Unlock()
@@ -212,6 +227,14 @@ func (pi *PeerInfo) ClearDeadlines(now time.Time, givePermit bool) int {
 	if cutOff < len(pi.deadlines) && givePermit {
 		cutOff++
 	}
+	if pi.reputation != nil {
+		for i := 0; i < firstNotPassed; i++ {
+			pi.reputation.Report(pi.peer.ID(), reputation.Timeout)
+		}
+		if cutOff > firstNotPassed {
+			pi.reputation.Report(pi.peer.ID(), reputation.Useful)
+		}
+	}
 	pi.deadlines = pi.deadlines[cutOff:]
 	return len(pi.deadlines)
 }
@@ -298,10 +321,11 @@ func handShake(
 	rw p2p.MsgReadWriter,
 	version uint,
 	minVersion uint,
-) (*common.Hash, *p2p.PeerError) {
+) (*common.Hash, *eth.BlockRangeUpdatePacket, *p2p.PeerError) {
 	// Send out own handshake in a new thread
 	errChan := make(chan *p2p.PeerError, 2)
 	resultChan := make(chan *eth.StatusPacket, 1)
+	blockRangeChan := make(chan *eth.BlockRangeUpdatePacket, 1)
 
 	ourTD := gointerfaces.ConvertH256ToUint256Int(status.TotalDifficulty)
 	// Convert proto status data into the one required by devp2p
@@ -309,15 +333,28 @@ func handShake(
 
 	go func() {
 		defer debug.LogPanic()
-		status := &eth.StatusPacket{
-			ProtocolVersion: uint32(version),
-			NetworkID:       status.NetworkId,
-			TD:              ourTD.ToBig(),
-			Head:            gointerfaces.ConvertH256ToHash(status.BestHash),
-			Genesis:         genesisHash,
-			ForkID:          forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime),
+		forkID := forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime)
+		var err error
+		if version >= direct.ETH69 {
+			err = p2p.Send(rw, eth.StatusMsg, &eth.StatusPacket69{
+				ProtocolVersion: uint32(version),
+				NetworkID:       status.NetworkId,
+				Genesis:         genesisHash,
+				ForkID:          forkID,
+				EarliestBlock:   status.MinimumBlockHeight,
+				LatestBlock:     status.MaxBlockHeight,
+				LatestBlockHash: gointerfaces.ConvertH256ToHash(status.BestHash),
+			})
+		} else {
+			err = p2p.Send(rw, eth.StatusMsg, &eth.StatusPacket{
+				ProtocolVersion: uint32(version),
+				NetworkID:       status.NetworkId,
+				TD:              ourTD.ToBig(),
+				Head:            gointerfaces.ConvertH256ToHash(status.BestHash),
+				Genesis:         genesisHash,
+				ForkID:          forkID,
+			})
 		}
-		err := p2p.Send(rw, eth.StatusMsg, status)
 
 		if err == nil {
 			errChan <- nil
@@ -328,10 +365,11 @@ func handShake(
 
 	go func() {
 		defer debug.LogPanic()
-		status, err := readAndValidatePeerStatusMessage(rw, status, version, minVersion)
+		status, blockRange, err := readAndValidatePeerStatusMessage(rw, status, version, minVersion)
 
 		if err == nil {
 			resultChan <- status
+			blockRangeChan <- blockRange
 			errChan <- nil
 		} else {
 			errChan <- err
@@ -344,17 +382,17 @@ func handShake(
 		select {
 		case err := <-errChan:
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		case <-timeout.C:
-			return nil, p2p.NewPeerError(p2p.PeerErrorStatusHandshakeTimeout, p2p.DiscReadTimeout, nil, "sentry.handShake timeout")
+			return nil, nil, p2p.NewPeerError(p2p.PeerErrorStatusHandshakeTimeout, p2p.DiscReadTimeout, nil, "sentry.handShake timeout")
 		case <-ctx.Done():
-			return nil, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscQuitting, ctx.Err(), "sentry.handShake ctx.Done")
+			return nil, nil, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscQuitting, ctx.Err(), "sentry.handShake ctx.Done")
 		}
 	}
 
 	peerStatus := <-resultChan
-	return &peerStatus.Head, nil
+	return &peerStatus.Head, <-blockRangeChan, nil
 }
 
 func runPeer(
@@ -468,9 +506,20 @@ func runPeer(
 			if _, err := io.ReadFull(msg.Payload, b); err != nil {
 				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", hex.EncodeToString(peerID[:]), err))
 			}
+			if protocol >= direct.ETH69 {
+				// the core decodes the receipts of all peers in the eth/68 encoding
+				if b, err = eth.ReceiptsPacket68From69(b); err != nil {
+					msg.Discard()
+					return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "sentry.runPeer: invalid eth/69 receipts")
+				}
+			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
 			//log.Info(fmt.Sprintf("[%s] ReceiptsMsg", peerID))
 		case eth.NewBlockHashesMsg:
+			if protocol >= direct.ETH69 {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscSubprotocolError, nil, "sentry.runPeer: NewBlockHashes is removed in eth/69")
+			}
 			if !hasSubscribers(eth.ToProto[protocol][msg.Code]) {
 				continue
 			}
@@ -481,6 +530,10 @@ func runPeer(
 			//log.Debug("NewBlockHashesMsg from", "peerId", fmt.Sprintf("%x", peerID)[:20], "name", peerInfo.peer.Name())
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
 		case eth.NewBlockMsg:
+			if protocol >= direct.ETH69 {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscSubprotocolError, nil, "sentry.runPeer: NewBlock is removed in eth/69")
+			}
 			if !hasSubscribers(eth.ToProto[protocol][msg.Code]) {
 				continue
 			}
@@ -530,6 +583,22 @@ func runPeer(
 				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", hex.EncodeToString(peerID[:]), err))
 			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
+		case eth.BlockRangeUpdateMsg:
+			var blockRange eth.BlockRangeUpdatePacket
+			if err := msg.Decode(&blockRange); err != nil {
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "sentry.runPeer: decode BlockRangeUpdate")
+			}
+			if err := blockRange.Validate(); err != nil {
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "sentry.runPeer: invalid BlockRangeUpdate")
+			}
+			peerInfo.SetBlockRange(blockRange.EarliestBlock, blockRange.LatestBlock)
+			if hasSubscribers(eth.ToProto[protocol][msg.Code]) {
+				b, err := rlp.EncodeToBytes(&blockRange)
+				if err != nil {
+					return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "sentry.runPeer: encode BlockRangeUpdate")
+				}
+				send(eth.ToProto[protocol][msg.Code], peerID, b)
+			}
 		case 11:
 			// Ignore
 			// TODO: Investigate why BSC peers for eth/67 send these messages
@@ -548,6 +617,9 @@ func runPeer(
 }
 
 func trackPeerStatistics(peerName string, peerID string, inbound bool, msgType string, msgCap string, bytes int) {
+	messagesCounter(inbound, msgType).Inc()
+	messageBytesCounter(inbound, msgType).Add(float64(bytes))
+
 	isDiagEnabled := diagnostics.TypeOf(diagnostics.PeerStatisticMsgUpdate{}).Enabled()
 	if isDiagEnabled {
 		stats := diagnostics.PeerStatisticMsgUpdate{
@@ -564,7 +636,7 @@ func trackPeerStatistics(peerName string, peerID string, inbound bool, msgType s
 	}
 }
 
-func grpcSentryServer(ctx context.Context, sentryAddr string, ss *GrpcServer, healthCheck bool) (*grpc.Server, error) {
+func grpcSentryServer(ctx context.Context, sentryAddr string, ss *GrpcServer, sec *grpcutil.Security, healthCheck bool) (*grpc.Server, error) {
 	// STARTING GRPC SERVER
 	ss.logger.Info("Starting Sentry gRPC server", "on", sentryAddr)
 	listenConfig := net.ListenConfig{
@@ -577,7 +649,7 @@ func grpcSentryServer(ctx context.Context, sentryAddr string, ss *GrpcServer, he
 	if err != nil {
 		return nil, fmt.Errorf("could not create Sentry P2P listener: %w, addr=%s", err, sentryAddr)
 	}
-	grpcServer := grpcutil.NewServer(100, nil)
+	grpcServer := grpcutil.NewServer(100, sec)
 	proto_sentry.RegisterSentryServer(grpcServer, ss)
 	var healthServer *health.Server
 	if healthCheck {
@@ -603,6 +675,9 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 		peersStreams: NewPeersStreams(),
 		logger:       logger,
 	}
+	if cfg.DiscoveryFilter == nil {
+		cfg.DiscoveryFilter = eth.NewNodeFilter(ss.currentForkFilter)
+	}
 
 	var disc enode.Iterator
 	if dialCandidates != nil {
@@ -611,11 +686,30 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 		disc, _ = setupDiscovery(ss.p2p.DiscoveryDNS)
 	}
 
-	ss.Protocols = append(ss.Protocols, p2p.Protocol{
-		Name:           eth.ProtocolName,
-		Version:        protocol,
-		Length:         17,
-		DialCandidates: disc,
+	// an eth/69 sentry keeps eth/68 for the older peers, devp2p runs the highest version both sides have
+	versions := []uint{protocol}
+	if protocol >= direct.ETH69 {
+		versions = append(versions, direct.ETH68)
+	}
+	for i, protocol := range versions {
+		ss.Protocols = append(ss.Protocols, ss.ethProtocol(ctx, protocol, readNodeInfo, logger))
+		if i == 0 {
+			ss.Protocols[i].DialCandidates = disc
+		}
+	}
+
+	return ss
+}
+
+func (ss *GrpcServer) ethProtocol(ctx context.Context, protocol uint, readNodeInfo func() *eth.NodeInfo, logger log.Logger) p2p.Protocol {
+	length := uint64(17)
+	if protocol >= direct.ETH69 {
+		length = eth.BlockRangeUpdateMsg + 1
+	}
+	return p2p.Protocol{
+		Name:    eth.ProtocolName,
+		Version: protocol,
+		Length:  length,
 		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
 			peerID := peer.Pubkey()
 			printablePeerID := hex.EncodeToString(peerID[:])
@@ -626,6 +720,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 
 			peerInfo := NewPeerInfo(peer, rw)
 			peerInfo.protocol = protocol
+			peerInfo.reputation = ss.reputation()
 			defer peerInfo.Close()
 
 			defer ss.GoodPeers.Delete(peerID)
@@ -633,18 +728,25 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 			status := ss.GetStatus()
 
 			if status == nil {
+				handshakeFailures(p2p.PeerErrorLocalStatusNeeded).Inc()
 				return p2p.NewPeerError(p2p.PeerErrorLocalStatusNeeded, p2p.DiscProtocolError, nil, "could not get status message from core")
 			}
 
-			peerBestHash, err := handShake(ctx, status, rw, protocol, protocol)
+			peerBestHash, peerBlockRange, err := handShake(ctx, status, rw, protocol, protocol)
 			if err != nil {
+				handshakeFailures(err.Code).Inc()
 				return err
 			}
+			if peerBlockRange != nil {
+				peerInfo.SetBlockRange(peerBlockRange.EarliestBlock, peerBlockRange.LatestBlock)
+			}
 
 			// handshake is successful
 			logger.Trace("[p2p] Received status message OK", "peerId", printablePeerID, "name", peer.Name())
 
 			ss.GoodPeers.Store(peerID, peerInfo)
+			peersGauge(protocol).Inc()
+			defer peersGauge(protocol).Dec()
 			ss.sendNewPeerToClients(gointerfaces.ConvertHashToH512(peerID))
 			defer ss.sendGonePeerToClients(gointerfaces.ConvertHashToH512(peerID))
 			getBlockHeadersErr := ss.getBlockHeaders(ctx, *peerBestHash, peerID)
@@ -673,13 +775,12 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 			return nil
 		},
 		//Attributes: []enr.Entry{eth.CurrentENREntry(chainConfig, genesisHash, headHeight)},
-	})
-
-	return ss
+	}
 }
 
-// Sentry creates and runs standalone sentry
-func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discoveryDNS []string, cfg *p2p.Config, protocolVersion uint, healthCheck bool, logger log.Logger) error {
+// Sentry creates and runs standalone sentry. sec - TLS and token auth of its gRPC server, nil - plaintext.
+// healthAddr - address of the HTTP probes (see GrpcServer.HealthHandler), empty - disabled.
+func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discoveryDNS []string, cfg *p2p.Config, protocolVersion uint, sec *grpcutil.Security, healthCheck bool, healthAddr string, healthMinPeers int, logger log.Logger) error {
 	dir.MustExist(dirs.DataDir)
 
 	discovery := func() enode.Iterator {
@@ -692,10 +793,13 @@ func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discovery
 	cfg.DiscoveryDNS = discoveryDNS
 	sentryServer := NewGrpcServer(ctx, discovery, func() *eth.NodeInfo { return nil }, cfg, protocolVersion, logger)
 
-	grpcServer, err := grpcSentryServer(ctx, sentryAddr, sentryServer, healthCheck)
+	grpcServer, err := grpcSentryServer(ctx, sentryAddr, sentryServer, sec, healthCheck)
 	if err != nil {
 		return err
 	}
+	if healthAddr != "" {
+		serveHealth(ctx, healthAddr, sentryServer, healthMinPeers, logger)
+	}
 
 	<-ctx.Done()
 	grpcServer.GracefulStop()
@@ -713,6 +817,9 @@ type GrpcServer struct {
 	p2pServerLock        sync.RWMutex
 	statusData           *proto_sentry.StatusData
 	statusDataLock       sync.RWMutex
+	forkFilter           atomic.Pointer[forkid.Filter] // of the current status, for discovery
+	blockRangeAnnounced  uint64                        // head of the last BlockRangeUpdate, under statusDataLock
+	snapshotRanges       enode.SnapshotRanges          // blocks served from the snapshot files, under p2pServerLock
 	messageStreams       map[proto_sentry.MessageId]map[uint64]chan *proto_sentry.InboundMessage
 	messagesSubscriberID uint64
 	messageStreamsLock   sync.RWMutex
@@ -753,6 +860,13 @@ func (ss *GrpcServer) removePeer(peerID [64]byte, reason *p2p.PeerError) {
 
 func (ss *GrpcServer) writePeer(logPrefix string, peerInfo *PeerInfo, msgcode uint64, data []byte, ttl time.Duration) {
 	peerInfo.Async(func() {
+		if msgcode == eth.ReceiptsMsg && peerInfo.protocol >= direct.ETH69 {
+			var err error
+			if data, err = eth.ReceiptsPacket69From68(data); err != nil {
+				ss.logger.Debug(logPrefix+" encode eth/69 receipts", "peer", peerInfo.peer.ID(), "err", err)
+				return
+			}
+		}
 		msgType := eth.ToProto[peerInfo.protocol][msgcode]
 		trackPeerStatistics(peerInfo.peer.Fullname(), peerInfo.peer.ID().String(), false, msgType.String(), fmt.Sprintf("%s/%d", eth.ProtocolName, peerInfo.protocol), len(data))
 
@@ -797,6 +911,9 @@ func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.Penalize
 	//log.Warn("Received penalty", "kind", req.GetPenalty().Descriptor().FullName, "from", fmt.Sprintf("%s", req.GetPeerId()))
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	peerInfo := ss.getPeer(peerID)
+	if peerInfo != nil {
+		ss.reputation().Report(peerInfo.peer.ID(), reputation.Violation)
+	}
 	if ss.statusData != nil && peerInfo != nil && !peerInfo.peer.Info().Network.Static && !peerInfo.peer.Info().Network.Trusted {
 		ss.removePeer(peerID, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscRequested, nil, "penalized peer"))
 	}
@@ -996,6 +1113,8 @@ func (ss *GrpcServer) HandShake(context.Context, *emptypb.Empty) (*proto_sentry.
 		reply.Protocol = proto_sentry.Protocol_ETH67
 	case direct.ETH68:
 		reply.Protocol = proto_sentry.Protocol_ETH68
+	case direct.ETH69:
+		reply.Protocol = proto_sentry.Protocol_ETH69
 	}
 	return reply, nil
 }
@@ -1036,6 +1155,14 @@ func (ss *GrpcServer) getP2PServer() *p2p.Server {
 	return ss.p2pServer
 }
 
+// reputation returns the reputation store of the p2p server, nil if it was not started
+func (ss *GrpcServer) reputation() *reputation.Store {
+	if p2pServer := ss.getP2PServer(); p2pServer != nil {
+		return p2pServer.ReputationStore()
+	}
+	return nil
+}
+
 func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.StatusData) (*proto_sentry.SetStatusReply, error) {
 	genesisHash := gointerfaces.ConvertH256ToHash(statusData.ForkData.Genesis)
 
@@ -1049,19 +1176,94 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 			return reply, err
 		}
 		ss.p2pServer = srv
+		if ss.snapshotRanges != nil {
+			srv.LocalNode().Set(ss.snapshotRanges)
+		}
 	}
 
 	ss.statusDataLock.Lock()
 	defer ss.statusDataLock.Unlock()
 
-	ss.p2pServer.LocalNode().Set(eth.CurrentENREntryFromForks(statusData.ForkData.HeightForks, statusData.ForkData.TimeForks, genesisHash, statusData.MaxBlockHeight, statusData.MaxBlockTime))
+	ss.p2pServer.LocalNode().Set(eth.CurrentENREntryFromForks(statusData.ForkData.HeightForks, statusData.ForkData.TimeForks, genesisHash, statusData.MaxBlockHeight, statusData.MaxBlockTime).
+		WithEarliestBlock(statusData.MinimumBlockHeight))
 	if ss.statusData == nil || statusData.MaxBlockHeight != 0 {
 		// Not overwrite statusData if the message contains zero MaxBlock (comes from standalone transaction pool)
 		ss.statusData = statusData
+		forkFilter := forkid.NewFilterFromForks(statusData.ForkData.HeightForks, statusData.ForkData.TimeForks, genesisHash, statusData.MaxBlockHeight, statusData.MaxBlockTime)
+		ss.forkFilter.Store(&forkFilter)
+		ss.announceBlockRange(statusData)
+		ss.p2pServer.SetNeededBlock(ss.neededBlock(statusData.MaxBlockHeight))
 	}
 	return reply, nil
 }
 
+// SetSnapshotRanges advertises the ranges of blocks served from the snapshot files in the node record
+func (ss *GrpcServer) SetSnapshotRanges(ranges enode.SnapshotRanges) {
+	ss.p2pServerLock.Lock()
+	defer ss.p2pServerLock.Unlock()
+	ss.snapshotRanges = ranges
+	if ss.p2pServer != nil {
+		ss.p2pServer.LocalNode().Set(ranges)
+	}
+}
+
+// syncDistance - blocks of the best peer ahead of the head from which the node is considered syncing,
+// dialing preferably the nodes serving its next block from their snapshots
+const syncDistance = 1024
+
+// neededBlock returns the next block of a syncing node, 0 if the head is close to the best peer
+func (ss *GrpcServer) neededBlock(head uint64) uint64 {
+	var best uint64
+	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		best = max(best, peerInfo.Height())
+		return true
+	})
+	if best > head+syncDistance {
+		return head + 1
+	}
+	return 0
+}
+
+// blockRangeUpdateInterval - blocks of the head between the BlockRangeUpdate announcements to the eth/69 peers
+const blockRangeUpdateInterval = 32
+
+// announceBlockRange sends BlockRangeUpdate to the eth/69 peers when the head moved by blockRangeUpdateInterval
+// blocks, or back, since the last announcement. The peers connected in between have the range of the status.
+// Called under statusDataLock.
+func (ss *GrpcServer) announceBlockRange(status *proto_sentry.StatusData) {
+	announced := ss.blockRangeAnnounced
+	if announced != 0 && status.MaxBlockHeight >= announced && status.MaxBlockHeight < announced+blockRangeUpdateInterval {
+		return
+	}
+	ss.blockRangeAnnounced = status.MaxBlockHeight
+	if announced == 0 || status.BestHash == nil {
+		return
+	}
+	b, err := rlp.EncodeToBytes(&eth.BlockRangeUpdatePacket{
+		EarliestBlock:   status.MinimumBlockHeight,
+		LatestBlock:     status.MaxBlockHeight,
+		LatestBlockHash: gointerfaces.ConvertH256ToHash(status.BestHash),
+	})
+	if err != nil {
+		ss.logger.Warn("[sentry] encode BlockRangeUpdate", "err", err)
+		return
+	}
+	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		if peerInfo.protocol >= direct.ETH69 {
+			ss.writePeer("[sentry] announceBlockRange", peerInfo, eth.BlockRangeUpdateMsg, b, 0)
+		}
+		return true
+	})
+}
+
+// currentForkFilter returns the fork filter of the current status, nil if the status is not set yet
+func (ss *GrpcServer) currentForkFilter() forkid.Filter {
+	if f := ss.forkFilter.Load(); f != nil {
+		return *f
+	}
+	return nil
+}
+
 func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.PeersReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
@@ -1303,6 +1505,12 @@ func (ss *GrpcServer) NodeInfo(_ context.Context, _ *emptypb.Empty) (*proto_type
 	}
 
 	ret.Protocols = protos
+
+	if info.NAT != nil {
+		if ret.Nat, err = json.Marshal(info.NAT); err != nil {
+			return nil, fmt.Errorf("cannot encode nat status: %w", err)
+		}
+	}
 	return ret, nil
 }
 
@@ -18,7 +18,10 @@ package sentry
 
 import (
 	"context"
+	"encoding/json"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -79,7 +82,7 @@ func startHandshake(
 	errChan chan *p2p.PeerError,
 ) {
 	go func() {
-		_, err := handShake(ctx, status, pipe, protocolVersion, protocolVersion)
+		_, _, err := handShake(ctx, status, pipe, protocolVersion, protocolVersion)
 		errChan <- err
 	}()
 }
@@ -88,6 +91,8 @@ func startHandshake(
 // fork IDs in the protocol handshake.
 func TestForkIDSplit67(t *testing.T) { testForkIDSplit(t, direct.ETH67) }
 
+func TestForkIDSplit69(t *testing.T) { testForkIDSplit(t, direct.ETH69) }
+
 func testForkIDSplit(t *testing.T, protocol uint) {
 	var (
 		ctx           = context.Background()
@@ -212,3 +217,28 @@ func TestSentryServerImpl_SetStatusInitPanic(t *testing.T) {
 		t.Fatalf("error expected")
 	}
 }
+
+func TestHealthHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ss := &GrpcServer{ctx: ctx}
+	ss.GoodPeers.Store([64]byte{1}, &PeerInfo{protocol: direct.ETH68})
+
+	get := func(handler http.Handler, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get(ss.HealthHandler(1), "/ready")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var readiness Readiness
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &readiness))
+	require.Equal(t, Readiness{Ready: true, Peers: 1, PeersPerProtocol: map[string]int{"eth/68": 1}, MinPeers: 1}, readiness)
+
+	require.Equal(t, http.StatusServiceUnavailable, get(ss.HealthHandler(2), "/ready").Code)
+	require.Equal(t, http.StatusOK, get(ss.HealthHandler(2), "/health").Code)
+
+	cancel()
+	require.Equal(t, http.StatusServiceUnavailable, get(ss.HealthHandler(0), "/health").Code)
+}
@@ -31,12 +31,12 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	proto_types "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
@@ -158,6 +158,7 @@ type MultiClient struct {
 	logger                           log.Logger
 	getReceiptsActiveGoroutineNumber *semaphore.Weighted
 	ethApiWrapper                    eth.ReceiptsGetter
+	announcements                    *libsentry.AnnouncementFilter // nil with single sentry
 }
 
 var _ eth.ReceiptsGetter = new(receipts.Generator) // compile-time interface-check
@@ -225,6 +226,7 @@ func NewMultiClient(
 		logger:                            logger,
 		getReceiptsActiveGoroutineNumber:  semaphore.NewWeighted(1),
 		ethApiWrapper:                     receipts.NewGenerator(blockReader, engine),
+		announcements:                     libsentry.NewAnnouncementFilterFor(sentries),
 	}
 
 	return cs, nil
@@ -246,6 +248,9 @@ func (cs *MultiClient) newBlockHashes66(ctx context.Context, req *proto_sentry.I
 		return fmt.Errorf("decode NewBlockHashes66: %w", err)
 	}
 	for _, announce := range request {
+		if cs.announcements.SeenBlock(announce.Hash, sentry) {
+			continue
+		}
 		cs.Hd.SaveExternalAnnounce(announce.Hash)
 		if cs.Hd.HasLink(announce.Hash) {
 			continue
@@ -399,6 +404,9 @@ func (cs *MultiClient) newBlock66(ctx context.Context, inreq *proto_sentry.Inbou
 	if headerRaw, err = rlpStream.Raw(); err != nil {
 		return fmt.Errorf("decode 3 NewBlockMsg: %w", err)
 	}
+	if cs.announcements.SeenBlock(types.RawRlpHash(headerRaw), sentryClient) {
+		return nil
+	}
 	// Parse the entire request from scratch
 	request := &eth.NewBlockPacket{}
 	if err := rlp.DecodeBytes(inreq.Data, &request); err != nil {
@@ -489,7 +497,7 @@ func (cs *MultiClient) getBlockHeaders66(ctx context.Context, inreq *proto_sentr
 
 	var headers []*types.Header
 	if err := cs.db.View(ctx, func(tx kv.Tx) (err error) {
-		headers, err = eth.AnswerGetBlockHeadersQuery(tx, query.GetBlockHeadersPacket, cs.blockReader)
+		headers, err = eth.AnswerGetBlockHeadersQuery(tx, query.GetBlockHeadersPacket, cs.statusDataProvider.EarliestBlock(), cs.blockReader)
 		if err != nil {
 			return err
 		}
@@ -539,7 +547,7 @@ func (cs *MultiClient) getBlockBodies66(ctx context.Context, inreq *proto_sentry
 		return err
 	}
 	defer tx.Rollback()
-	response := eth.AnswerGetBlockBodiesQuery(tx, query.GetBlockBodiesPacket, cs.blockReader)
+	response := eth.AnswerGetBlockBodiesQuery(tx, query.GetBlockBodiesPacket, cs.statusDataProvider.EarliestBlock(), cs.blockReader)
 	tx.Rollback()
 	b, err := rlp.EncodeToBytes(&eth.BlockBodiesRLPPacket66{
 		RequestId:            query.RequestId,
@@ -571,7 +579,7 @@ func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.In
 	if err := rlp.DecodeBytes(inreq.Data, &query); err != nil {
 		return fmt.Errorf("decoding getReceipts66: %w, data: %x", err, inreq.Data)
 	}
-	cachedReceipts, needMore, err := eth.AnswerGetReceiptsQueryCacheOnly(ctx, cs.ethApiWrapper, query.GetReceiptsPacket)
+	cachedReceipts, needMore, err := eth.AnswerGetReceiptsQueryCacheOnly(ctx, cs.ethApiWrapper, query.GetReceiptsPacket, cs.statusDataProvider.EarliestBlock())
 	if err != nil {
 		return err
 	}
@@ -591,7 +599,7 @@ func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.In
 			return err
 		}
 		defer tx.Rollback()
-		receiptsList, err = eth.AnswerGetReceiptsQuery(ctx, cs.ChainConfig, cs.ethApiWrapper, cs.blockReader, tx, query.GetReceiptsPacket, cachedReceipts)
+		receiptsList, err = eth.AnswerGetReceiptsQuery(ctx, cs.ChainConfig, cs.ethApiWrapper, cs.blockReader, tx, query.GetReceiptsPacket, cs.statusDataProvider.EarliestBlock(), cachedReceipts)
 		if err != nil {
 			return err
 		}
@@ -707,7 +715,7 @@ func (cs *MultiClient) makeStatusData(ctx context.Context) (*proto_sentry.Status
 	return cs.statusDataProvider.GetStatusData(ctx)
 }
 
-func GrpcClient(ctx context.Context, sentryAddr string) (*direct.SentryClientRemote, error) {
+func GrpcClient(ctx context.Context, sentryAddr string, sec *grpcutil.Security) (*direct.SentryClientRemote, error) {
 	// creating grpc client connection
 	var dialOpts []grpc.DialOption
 
@@ -720,7 +728,7 @@ func GrpcClient(ctx context.Context, sentryAddr string) (*direct.SentryClientRem
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{}),
 	}
 
-	dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	dialOpts = append(dialOpts, sec.DialOptions()...)
 	conn, err := grpc.DialContext(ctx, sentryAddr, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating client connection to sentry P2P: %w", err)
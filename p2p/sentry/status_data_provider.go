@@ -53,6 +53,8 @@ type StatusDataProvider struct {
 	heightForks []uint64
 	timeForks   []uint64
 
+	earliestBlock uint64 // earliest block served to the peers, advertised to the eth/69 ones
+
 	logger log.Logger
 }
 
@@ -61,14 +63,16 @@ func NewStatusDataProvider(
 	chainConfig *chain.Config,
 	genesis *types.Block,
 	networkId uint64,
+	earliestBlock uint64,
 	logger log.Logger,
 ) *StatusDataProvider {
 	s := &StatusDataProvider{
-		db:          db,
-		networkId:   networkId,
-		genesisHash: genesis.Hash(),
-		genesisHead: makeGenesisChainHead(genesis),
-		logger:      logger,
+		db:            db,
+		networkId:     networkId,
+		genesisHash:   genesis.Hash(),
+		genesisHead:   makeGenesisChainHead(genesis),
+		earliestBlock: earliestBlock,
+		logger:        logger,
 	}
 
 	s.heightForks, s.timeForks = forkid.GatherForks(chainConfig, genesis.Time())
@@ -109,6 +113,8 @@ func (s *StatusDataProvider) makeStatusData(head ChainHead) *proto_sentry.Status
 		BestHash:        gointerfaces.ConvertHashToH256(head.HeadHash),
 		MaxBlockHeight:  head.HeadHeight,
 		MaxBlockTime:    head.HeadTime,
+		// eth/69 peers reject ranges with the earliest block above the head, e.g. while syncing the pre-merge blocks
+		MinimumBlockHeight: min(s.earliestBlock, head.HeadHeight),
 		ForkData: &proto_sentry.Forks{
 			Genesis:     gointerfaces.ConvertHashToH256(s.genesisHash),
 			HeightForks: s.heightForks,
@@ -117,6 +123,11 @@ func (s *StatusDataProvider) makeStatusData(head ChainHead) *proto_sentry.Status
 	}
 }
 
+// EarliestBlock returns the earliest block served to the peers, earlier ones are not served
+func (s *StatusDataProvider) EarliestBlock() uint64 {
+	return s.earliestBlock
+}
+
 func (s *StatusDataProvider) GetStatusData(ctx context.Context) (*proto_sentry.StatusData, error) {
 	chainHead, err := ReadChainHead(ctx, s.db)
 	if err != nil {
@@ -47,6 +47,7 @@ import (
 	"github.com/erigontech/erigon/p2p/event"
 	"github.com/erigontech/erigon/p2p/nat"
 	"github.com/erigontech/erigon/p2p/netutil"
+	"github.com/erigontech/erigon/p2p/reputation"
 )
 
 const (
@@ -63,6 +64,14 @@ const (
 	// This time limits inbound connection attempts per source IP.
 	inboundThrottleTime = 30 * time.Second
 
+	// Inbound connection limits defaults, see Config.
+	defaultInboundConnsPerIP    = 1
+	defaultInboundHandshakeRate = 100
+
+	// Discovery packet rate limit defaults, see Config.
+	defaultDiscoveryPacketRate  = 100
+	defaultDiscoveryPacketBurst = 200
+
 	// Maximum time allowed for reading a complete message.
 	// This is effectively the amount of time a connection can be idle.
 	frameReadTimeout = 30 * time.Second
@@ -71,6 +80,9 @@ const (
 	frameWriteTimeout = 20 * time.Second
 
 	serverStatsLogInterval = 60 * time.Second
+
+	// Interval of the writes of the changed reputation records to the node database.
+	reputationFlushInterval = 30 * time.Second
 )
 
 var errServerStopped = errors.New("server stopped")
@@ -89,6 +101,20 @@ type Config struct {
 	// It must be greater than zero.
 	MaxPendingPeers int `toml:",omitempty"`
 
+	// MaxInboundHandshakes is the maximum number of inbound connections in the handshake
+	// phase. Connections above it are dropped when accepted instead of waiting for a slot.
+	// Setting it to zero defaults it to MaxPendingPeers.
+	MaxInboundHandshakes int `toml:",omitempty"`
+
+	// InboundConnsPerIP is the number of inbound connection attempts allowed from a single
+	// Internet (non-LAN) IP within 30 seconds. Setting it to zero defaults it to 1.
+	InboundConnsPerIP int `toml:",omitempty"`
+
+	// InboundHandshakeRate is the budget of inbound encryption handshakes per second. Every
+	// handshake costs a few elliptic curve operations, the budget bounds the CPU a handshake
+	// flood can take. Setting it to zero defaults it to 100, a negative value disables it.
+	InboundHandshakeRate float64 `toml:",omitempty"`
+
 	// DialRatio controls the ratio of inbound to dialed connections.
 	// Example: a DialRatio of 2 allows 1/2 of connections to be dialed.
 	// Setting DialRatio to zero defaults it to 3.
@@ -102,6 +128,21 @@ type Config struct {
 	// protocol should be started or not.
 	DiscoveryV5 bool `toml:",omitempty"`
 
+	// DiscoveryV5Topics are the discv5 topics the node is advertised under. Nodes advertising them
+	// are dial candidates.
+	DiscoveryV5Topics []string `toml:",omitempty"`
+
+	// DiscoveryPacketRate is the number of discovery packets per second handled from a single
+	// source IP, with bursts of up to DiscoveryPacketBurst packets. Packets over the limit are
+	// dropped. Setting them to zero defaults them to 100 and 200, a negative rate disables the limit.
+	DiscoveryPacketRate  float64 `toml:",omitempty"`
+	DiscoveryPacketBurst int     `toml:",omitempty"`
+
+	// Reputation configures the peer reputation kept in the node database. Nodes with enough
+	// protocol violations are banned: not dialed, found by discovery nor accepted, unless trusted
+	// or static. Zero fields take their defaults, see package reputation.
+	Reputation reputation.Config `toml:",omitempty"`
+
 	// Name sets the node name of this server.
 	// Use common.MakeName to create a name that follows existing conventions.
 	Name string `toml:"-"`
@@ -162,6 +203,10 @@ type Config struct {
 	// NAT interface description (see NAT.Parse()).
 	NATSpec string
 
+	// STUN server asked for the external IP when the NAT gateway doesn't report a public
+	// one, nat.STUNDefaultServerAddr if empty, "none" disables the fallback.
+	NATFallback string
+
 	// If Dialer is set to a non-nil value, the given Dialer
 	// is used to dial outbound peer connections.
 	Dialer NodeDialer `toml:"-"`
@@ -180,7 +225,14 @@ type Config struct {
 
 	MetricsEnabled bool
 
+	// LogClientDiversity enables periodic logging of connected peers' client breakdown (no node ids or addresses)
+	LogClientDiversity bool
+
 	DiscoveryDNS []string
+
+	// DiscoveryFilter, if set, rejects discovered nodes before they get into the discovery table or
+	// are dialed, e.g. nodes on incompatible forks. Nodes without the relevant ENR entries must be accepted.
+	DiscoveryFilter func(*enode.Node) error `toml:"-"`
 }
 
 func (config *Config) ListenPort() int {
@@ -222,6 +274,9 @@ type Server struct {
 	DiscV5             *discover.UDPv5
 	discmix            *enode.FairMix
 	dialsched          *dialScheduler
+	reputation         *reputation.Store
+	neededBlock        atomic.Uint64
+	natEndpoint        *nat.Endpoint
 
 	// Channels into the run loop.
 	quitCtx                 context.Context
@@ -237,6 +292,7 @@ type Server struct {
 
 	// State of run loop and listenLoop.
 	inboundHistory expHeap
+	inboundBudget  *tokenBucket
 
 	errorsMu sync.Mutex
 	errors   map[string]uint
@@ -532,6 +588,7 @@ func (srv *Server) Start(ctx context.Context, logger log.Logger) error {
 		return err
 	}
 	srv.setupDialScheduler()
+	srv.startNATEndpoint()
 
 	srv.running.Store(true)
 	srv.loopWG.Add(1)
@@ -558,6 +615,7 @@ func (srv *Server) setupLocalNode() error {
 		return err
 	}
 	srv.nodedb = db
+	srv.reputation = reputation.New(db, srv.Config.Reputation, srv.logger)
 
 	srv.localnode = enode.NewLocalNode(db, srv.PrivateKey, srv.logger)
 	srv.localnode.SetFallbackIP(net.IP{127, 0, 0, 1})
@@ -580,20 +638,9 @@ func (srv *Server) setupLocalNode() error {
 		srv.localnode.SetStaticIP(ip)
 		srv.updateLocalNodeStaticAddrCache()
 	default:
-		// Ask the router about the IP. This takes a while and blocks startup,
-		// do it in the background.
-		srv.loopWG.Add(1)
-		go func() {
-			defer debug.LogPanic()
-			defer srv.loopWG.Done()
-			if ip, err := srv.NAT.ExternalIP(); err == nil {
-				srv.logger.Info("NAT ExternalIP resolved", "ip", ip)
-				srv.localnode.SetStaticIP(ip)
-				srv.updateLocalNodeStaticAddrCache()
-			} else {
-				srv.logger.Warn("NAT ExternalIP resolution has failed, try to pass a different --nat option", "err", err)
-			}
-		}()
+		// Asking the router about the IP takes a while and would block startup,
+		// the endpoint is detected and kept fresh in the background.
+		srv.natEndpoint = nat.NewEndpoint(srv.NAT, srv.natFallback(), srv.logger)
 	}
 	return nil
 }
@@ -605,7 +652,7 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 	added := make(map[string]bool)
 	for _, proto := range srv.Protocols {
 		if proto.DialCandidates != nil && !added[proto.Name] {
-			srv.discmix.AddSource(proto.DialCandidates)
+			srv.discmix.AddSource(srv.filterDialCandidates(proto.DialCandidates))
 			added[proto.Name] = true
 		}
 	}
@@ -625,15 +672,8 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 	}
 	realaddr := conn.LocalAddr().(*net.UDPAddr)
 	srv.logger.Trace("UDP listener up", "addr", realaddr)
-	if srv.NAT != nil {
-		if !realaddr.IP.IsLoopback() && srv.NAT.SupportsMapping() {
-			srv.loopWG.Add(1)
-			go func() {
-				defer debug.LogPanic()
-				defer srv.loopWG.Done()
-				nat.Map(srv.NAT, srv.quit, "udp", realaddr.Port, realaddr.Port, "ethereum discovery", srv.logger)
-			}()
-		}
+	if srv.natEndpoint != nil && !realaddr.IP.IsLoopback() {
+		srv.natEndpoint.AddPort("udp", realaddr.Port, "ethereum discovery")
 	}
 	srv.localnode.SetFallbackUDP(realaddr.Port)
 	srv.updateLocalNodeStaticAddrCache()
@@ -652,6 +692,10 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 			Bootnodes:   srv.BootstrapNodes,
 			Unhandled:   unhandled,
 			Log:         srv.logger,
+			NodeFilter:  srv.DiscoveryFilter,
+			PacketRate:  srv.discoveryPacketRate(),
+			PacketBurst: srv.discoveryPacketBurst(),
+			Reputation:  srv.reputation,
 		}
 		ntab, err := discover.ListenV4(ctx, strconv.FormatUint(uint64(srv.Config.Protocols[0].Version), 10), conn, srv.localnode, cfg)
 		if err != nil {
@@ -668,6 +712,10 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 			NetRestrict: srv.NetRestrict,
 			Bootnodes:   srv.BootstrapNodesV5,
 			Log:         srv.logger,
+			NodeFilter:  srv.DiscoveryFilter,
+			PacketRate:  srv.discoveryPacketRate(),
+			PacketBurst: srv.discoveryPacketBurst(),
+			Reputation:  srv.reputation,
 		}
 		version := uint64(srv.Config.Protocols[0].Version)
 		var err error
@@ -679,10 +727,29 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		for _, name := range srv.DiscoveryV5Topics {
+			topic := discover.NewTopic(name)
+			srv.DiscV5.RegisterTopic(topic)
+			srv.discmix.AddSource(srv.filterDialCandidates(srv.DiscV5.TopicNodes(topic)))
+		}
 	}
 	return nil
 }
 
+// filterDialCandidates applies DiscoveryFilter to the nodes of the iterator
+func (srv *Server) filterDialCandidates(it enode.Iterator) enode.Iterator {
+	if srv.DiscoveryFilter == nil {
+		return it
+	}
+	return enode.Filter(it, func(n *enode.Node) bool {
+		if err := srv.DiscoveryFilter(n); err != nil {
+			dialCandidatesRejectedMeter.Inc()
+			return false
+		}
+		return true
+	})
+}
+
 func (srv *Server) setupDialScheduler() {
 	config := dialConfig{
 		self:           srv.localnode.ID(),
@@ -690,6 +757,8 @@ func (srv *Server) setupDialScheduler() {
 		maxActiveDials: srv.MaxPendingPeers,
 		log:            srv.logger,
 		netRestrict:    srv.NetRestrict,
+		reputation:     srv.reputation,
+		neededBlock:    srv.neededBlock.Load,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
 	}
@@ -742,13 +811,8 @@ func (srv *Server) setupListening(ctx context.Context) error {
 		srv.localnode.Set(enr.TCP(tcp.Port))
 		srv.updateLocalNodeStaticAddrCache()
 
-		if !tcp.IP.IsLoopback() && (srv.NAT != nil) && srv.NAT.SupportsMapping() {
-			srv.loopWG.Add(1)
-			go func() {
-				defer debug.LogPanic()
-				defer srv.loopWG.Done()
-				nat.Map(srv.NAT, srv.quit, "tcp", tcp.Port, tcp.Port, "ethereum p2p", srv.logger)
-			}()
+		if srv.natEndpoint != nil && !tcp.IP.IsLoopback() {
+			srv.natEndpoint.AddPort("tcp", tcp.Port, "ethereum p2p")
 		}
 	}
 
@@ -778,6 +842,7 @@ func (srv *Server) run() {
 	}
 	defer srv.loopWG.Done()
 	defer srv.nodedb.Close()
+	defer srv.reputation.Flush()
 	defer srv.discmix.Close()
 	defer srv.dialsched.stop()
 
@@ -794,6 +859,8 @@ func (srv *Server) run() {
 
 	logTimer := time.NewTicker(serverStatsLogInterval)
 	defer logTimer.Stop()
+	reputationTimer := time.NewTicker(reputationFlushInterval)
+	defer reputationTimer.Stop()
 
 running:
 	for {
@@ -831,7 +898,11 @@ running:
 				// Ensure that the trusted flag is set before checking against MaxPeers.
 				c.flags |= trustedConn
 			}
-			c.cont <- nil
+			var err error
+			if !c.is(trustedConn|staticDialedConn) && srv.reputation.Banned(c.node.ID()) {
+				err = DiscUselessPeer
+			}
+			c.cont <- err
 
 		case c := <-srv.checkpointAddPeer:
 			// At this point the connection is past the protocol handshake.
@@ -841,6 +912,7 @@ running:
 				// The handshakes are done and it passed all checks.
 				p := srv.launchPeer(c, c.pubkey)
 				peers[c.node.ID()] = p
+				peerDiversityMetricAdd(p)
 				srv.logger.Trace("Adding p2p peer", "peercount", len(peers), "url", p.Node(), "conn", c.flags, "name", p.Fullname())
 				srv.dialsched.peerAdded(c)
 				if p.Inbound() {
@@ -853,16 +925,25 @@ running:
 			// A peer disconnected.
 			d := common.PrettyDuration(mclock.Now() - pd.created)
 			delete(peers, pd.ID())
+			peerDiversityMetricRemove(pd.Peer)
 			srv.logger.Trace("Removing p2p peer", "peercount", len(peers), "url", pd.Node(), "duration", d, "err", pd.err)
 			srv.dialsched.peerRemoved(pd.rw)
 			if pd.Inbound() {
 				inboundCount--
 			}
+			if ev, ok := peerDropEvent(pd.err); ok {
+				srv.reputation.Report(pd.ID(), ev)
+			}
+		case <-reputationTimer.C:
+			srv.reputation.Flush()
 		case <-logTimer.C:
 			vals := []interface{}{"protocol", srv.Config.Protocols[0].Version, "peers", len(peers), "trusted", len(trusted), "inbound", inboundCount}
 			vals = append(vals, srv.listErrors()...)
 
 			srv.logger.Debug("[p2p] Server", vals...)
+			if srv.LogClientDiversity {
+				srv.logger.Info("[p2p] Client diversity", NewClientDiversity(handshakeInfos(peers)).LogValues()...)
+			}
 		}
 	}
 
@@ -906,6 +987,40 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 	}
 }
 
+// peerDropEvent classifies the error dropping a peer as a reputation event of the peer.
+// Disconnects requested by the peer itself don't count.
+func peerDropEvent(err *PeerError) (reputation.Event, bool) {
+	if err == nil {
+		return 0, false
+	}
+	switch err.Code {
+	case PeerErrorInvalidMessageCode, PeerErrorInvalidMessage, PeerErrorMessageSizeLimit,
+		PeerErrorStatusDecode, PeerErrorStatusUnexpected:
+		return reputation.Violation, true
+	case PeerErrorStatusIncompatible:
+		return reputation.Useless, true
+	case PeerErrorPingFailure, PeerErrorStatusHandshakeTimeout:
+		return reputation.Timeout, true
+	case PeerErrorDiscReason:
+		if err.Reason == DiscReadTimeout {
+			return reputation.Timeout, true
+		}
+	}
+	return 0, false
+}
+
+// SetNeededBlock sets the next block to sync while the node is behind its peers, 0 when it is
+// not. Dynamic dials prefer the nodes advertising the block in their snapshot ranges.
+func (srv *Server) SetNeededBlock(block uint64) {
+	srv.neededBlock.Store(block)
+}
+
+// ReputationStore returns the reputation of the nodes, shared with the protocols to report
+// the behaviour of their peers. It is nil until the server is started.
+func (srv *Server) ReputationStore() *reputation.Store {
+	return srv.reputation
+}
+
 // listenLoop runs in its own goroutine and accepts
 // inbound connections.
 func (srv *Server) listenLoop(ctx context.Context) {
@@ -922,6 +1037,13 @@ func (srv *Server) listenLoop(ctx context.Context) {
 		_ = slots.Acquire(ctx, int64(srv.MaxPendingPeers))
 	}()
 
+	// The handshakes limit inbound connections in the handshake phase, unlike the slots
+	// they don't hold up accepting: connections above the limit are dropped.
+	handshakes := semaphore.NewWeighted(int64(srv.maxInboundHandshakes()))
+	if rate := srv.inboundHandshakeRate(); rate > 0 {
+		srv.inboundBudget = newTokenBucket(rate, max(rate, 1), srv.clock.Now())
+	}
+
 	for {
 		// Wait for a free slot before accepting.
 		if slotErr := slots.Acquire(ctx, 1); slotErr != nil {
@@ -959,12 +1081,21 @@ func (srv *Server) listenLoop(ctx context.Context) {
 		}
 
 		remoteIP := netutil.AddrIP(fd.RemoteAddr())
+		if !handshakes.TryAcquire(1) {
+			inboundRejectedHandshakesMeter.Inc()
+			srv.logger.Trace("Rejected inbound connection", "addr", fd.RemoteAddr(), "err", "too many handshakes")
+			_ = fd.Close()
+			slots.Release(1)
+			continue
+		}
 		if err := srv.checkInboundConn(fd, remoteIP); err != nil {
 			srv.logger.Trace("Rejected inbound connection", "addr", fd.RemoteAddr(), "err", err)
 			_ = fd.Close()
+			handshakes.Release(1)
 			slots.Release(1)
 			continue
 		}
+		inboundHandshakesGauge.Inc()
 		if remoteIP != nil {
 			var addr *net.TCPAddr
 			if tcp, ok := fd.RemoteAddr().(*net.TCPAddr); ok {
@@ -978,6 +1109,8 @@ func (srv *Server) listenLoop(ctx context.Context) {
 			defer slots.Release(1)
 			// The error is logged in Server.setupConn().
 			_ = srv.SetupConn(fd, inboundConn, nil)
+			inboundHandshakesGauge.Dec()
+			handshakes.Release(1)
 		}()
 	}
 }
@@ -988,18 +1121,60 @@ func (srv *Server) checkInboundConn(fd net.Conn, remoteIP net.IP) error {
 	}
 	// Reject connections that do not match NetRestrict.
 	if srv.NetRestrict != nil && !srv.NetRestrict.Contains(remoteIP) {
+		inboundRejectedNetRestrictMeter.Inc()
 		return errors.New("not whitelisted in NetRestrict")
 	}
 	// Reject Internet peers that try too often.
 	now := srv.clock.Now()
 	srv.inboundHistory.expire(now, nil)
-	if !netutil.IsLAN(remoteIP) && srv.inboundHistory.contains(remoteIP.String()) {
+	if !netutil.IsLAN(remoteIP) && srv.inboundHistory.count(remoteIP.String()) >= srv.inboundConnsPerIP() {
+		inboundRejectedIPRateMeter.Inc()
 		return errors.New("too many attempts")
 	}
 	srv.inboundHistory.add(remoteIP.String(), now.Add(inboundThrottleTime))
+	// Reject everyone while the handshake budget is spent.
+	if srv.inboundBudget != nil && !srv.inboundBudget.take(now) {
+		inboundRejectedBudgetMeter.Inc()
+		return errors.New("handshake budget exceeded")
+	}
 	return nil
 }
 
+func (srv *Server) maxInboundHandshakes() int {
+	if srv.MaxInboundHandshakes <= 0 {
+		return srv.MaxPendingPeers
+	}
+	return min(srv.MaxInboundHandshakes, srv.MaxPendingPeers)
+}
+
+func (srv *Server) inboundConnsPerIP() int {
+	if srv.InboundConnsPerIP <= 0 {
+		return defaultInboundConnsPerIP
+	}
+	return srv.InboundConnsPerIP
+}
+
+func (srv *Server) inboundHandshakeRate() float64 {
+	if srv.InboundHandshakeRate == 0 {
+		return defaultInboundHandshakeRate
+	}
+	return srv.InboundHandshakeRate
+}
+
+func (srv *Server) discoveryPacketRate() float64 {
+	if srv.DiscoveryPacketRate == 0 {
+		return defaultDiscoveryPacketRate
+	}
+	return srv.DiscoveryPacketRate
+}
+
+func (srv *Server) discoveryPacketBurst() int {
+	if srv.DiscoveryPacketBurst <= 0 {
+		return defaultDiscoveryPacketBurst
+	}
+	return srv.DiscoveryPacketBurst
+}
+
 // SetupConn runs the handshakes and attempts to add the connection
 // as a peer. It returns when the connection has been added as a peer
 // or the handshakes have failed.
@@ -1064,6 +1239,7 @@ func (srv *Server) setupConn(c *conn, flags connFlag, dialDest *enode.Node) erro
 	}
 	if id := c.node.ID(); !bytes.Equal(crypto.Keccak256(phs.Pubkey), id[:]) {
 		clog.Trace("Wrong devp2p handshake identity", "phsid", hex.EncodeToString(phs.Pubkey))
+		srv.reputation.Report(c.node.ID(), reputation.Violation)
 		return DiscUnexpectedIdentity
 	}
 	c.caps, c.name = phs.Caps, phs.Name
@@ -1155,6 +1331,7 @@ type NodeInfo struct {
 	} `json:"ports"`
 	ListenAddr string                 `json:"listenAddr"`
 	Protocols  map[string]interface{} `json:"protocols"`
+	NAT        *nat.Status            `json:"nat,omitempty"` // Detection of the external endpoint
 }
 
 // NodeInfo gathers and returns a collection of metadata known about the host.
@@ -1168,6 +1345,7 @@ func (srv *Server) NodeInfo() *NodeInfo {
 		IP:         node.IP().String(),
 		ListenAddr: srv.ListenAddr,
 		Protocols:  make(map[string]interface{}),
+		NAT:        srv.NATStatus(),
 	}
 	info.Ports.Discovery = node.UDP()
 	info.Ports.Listener = node.TCP()
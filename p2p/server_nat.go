@@ -0,0 +1,71 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"net"
+
+	"github.com/erigontech/erigon-lib/common/debug"
+	"github.com/erigontech/erigon/p2p/enr"
+	"github.com/erigontech/erigon/p2p/nat"
+)
+
+// natFallback returns the STUN detection of the external IP used when the NAT gateway doesn't
+// report a public one, nil if disabled or if the NAT mechanism is STUN itself.
+func (srv *Server) natFallback() nat.Interface {
+	if _, ok := srv.NAT.(nat.STUN); ok || srv.NATFallback == "none" {
+		return nil
+	}
+	return nat.NewSTUN(srv.NATFallback)
+}
+
+// startNATEndpoint starts keeping the endpoint of the local node record fresh: the port
+// mappings are renewed and the external IP polled, the record follows their changes.
+func (srv *Server) startNATEndpoint() {
+	if srv.natEndpoint == nil {
+		return
+	}
+	srv.loopWG.Add(1)
+	go func() {
+		defer debug.LogPanic()
+		defer srv.loopWG.Done()
+		srv.natEndpoint.Run(srv.quit, srv.updateNATEndpoint)
+	}()
+}
+
+func (srv *Server) updateNATEndpoint(ip net.IP, ports map[string]int) {
+	if ip != nil {
+		srv.localnode.SetStaticIP(ip)
+	}
+	if port, ok := ports["tcp"]; ok {
+		srv.localnode.Set(enr.TCP(port))
+	}
+	if port, ok := ports["udp"]; ok {
+		srv.localnode.SetFallbackUDP(port)
+	}
+	srv.updateLocalNodeStaticAddrCache()
+}
+
+// NATStatus returns the state of the detection of the external endpoint, nil if the
+// NAT mechanism is none or a static IP.
+func (srv *Server) NATStatus() *nat.Status {
+	if srv.natEndpoint == nil {
+		return nil
+	}
+	status := srv.natEndpoint.Status()
+	return &status
+}
@@ -606,6 +606,116 @@ func TestServerInboundThrottle(t *testing.T) {
 	}
 }
 
+func TestServerInboundHandshakeBudget(t *testing.T) {
+	logger := log.New()
+	const timeout = 5 * time.Second
+	newTransportCalled := make(chan struct{})
+	srv := &Server{
+		Config: Config{
+			PrivateKey:           newkey(),
+			ListenAddr:           "127.0.0.1:0",
+			MaxPeers:             10,
+			MaxPendingPeers:      10,
+			InboundHandshakeRate: 0.001, // one handshake, then nothing for a long time
+			NoDial:               true,
+			NoDiscovery:          true,
+			Protocols:            []Protocol{discard},
+		},
+		newTransport: func(fd net.Conn, dialDest *ecdsa.PublicKey) transport {
+			newTransportCalled <- struct{}{}
+			return newRLPX(fd, dialDest)
+		},
+	}
+	if err := srv.TestStart(logger); err != nil {
+		t.Fatal("can't start: ", err)
+	}
+	defer srv.Stop()
+
+	// The first connection spends the budget, LAN connections aren't throttled by IP.
+	conn, err := net.DialTimeout("tcp", srv.ListenAddr, timeout)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	select {
+	case <-newTransportCalled:
+	case <-time.After(timeout):
+		t.Error("newTransport not called")
+	}
+	defer conn.Close()
+
+	// The second one is closed without a handshake.
+	conn2, err := net.DialTimeout("tcp", srv.ListenAddr, timeout)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	defer conn2.Close()
+	connClosed := make(chan struct{}, 1)
+	go func() {
+		conn2.SetDeadline(time.Now().Add(timeout))
+		buf := make([]byte, 10)
+		if n, err := conn2.Read(buf); err != io.EOF || n != 0 {
+			t.Errorf("expected io.EOF and n == 0, got error %q and n == %d", err, n)
+		}
+		connClosed <- struct{}{}
+	}()
+	select {
+	case <-connClosed:
+	case <-newTransportCalled:
+		t.Error("newTransport called over the handshake budget")
+	case <-time.After(timeout):
+		t.Error("connection not closed within timeout")
+	}
+}
+
+func TestServerMaxInboundHandshakes(t *testing.T) {
+	logger := log.New()
+	const timeout = 5 * time.Second
+	newTransportCalled := make(chan struct{})
+	srv := &Server{
+		Config: Config{
+			PrivateKey:           newkey(),
+			ListenAddr:           "127.0.0.1:0",
+			MaxPeers:             10,
+			MaxPendingPeers:      10,
+			MaxInboundHandshakes: 1,
+			NoDial:               true,
+			NoDiscovery:          true,
+			Protocols:            []Protocol{discard},
+		},
+		newTransport: func(fd net.Conn, dialDest *ecdsa.PublicKey) transport {
+			newTransportCalled <- struct{}{}
+			return newRLPX(fd, dialDest)
+		},
+	}
+	if err := srv.TestStart(logger); err != nil {
+		t.Fatal("can't start: ", err)
+	}
+	defer srv.Stop()
+
+	// The first connection stays in the handshake: nothing is sent.
+	conn, err := net.DialTimeout("tcp", srv.ListenAddr, timeout)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	defer conn.Close()
+	select {
+	case <-newTransportCalled:
+	case <-time.After(timeout):
+		t.Fatal("newTransport not called")
+	}
+
+	// The second one is dropped while the first one is handshaking.
+	conn2, err := net.DialTimeout("tcp", srv.ListenAddr, timeout)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	defer conn2.Close()
+	conn2.SetDeadline(time.Now().Add(timeout))
+	if n, err := conn2.Read(make([]byte, 10)); err != io.EOF || n != 0 {
+		t.Errorf("expected io.EOF and n == 0, got error %q and n == %d", err, n)
+	}
+}
+
 func listenFakeAddr(network, laddr string, remoteAddr net.Addr) (net.Listener, error) {
 	l, err := net.Listen(network, laddr)
 	if err == nil {
@@ -21,6 +21,7 @@ package p2p
 
 import (
 	"container/heap"
+	"time"
 
 	"github.com/erigontech/erigon-lib/common/mclock"
 )
@@ -54,6 +55,16 @@ func (h expHeap) contains(item string) bool {
 	return false
 }
 
+// count returns the number of entries of the item.
+func (h expHeap) count(item string) (n int) {
+	for _, v := range h {
+		if v.item == item {
+			n++
+		}
+	}
+	return n
+}
+
 // expire removes items with expiry time before 'now'.
 func (h *expHeap) expire(now mclock.AbsTime, onExp func(string)) {
 	for h.Len() > 0 && h.nextExpiry() < now {
@@ -77,3 +88,28 @@ func (h *expHeap) Pop() interface{} {
 	*h = old[0 : n-1]
 	return x
 }
+
+// tokenBucket limits the rate of events, allowing bursts of up to burst events.
+type tokenBucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   mclock.AbsTime
+}
+
+func newTokenBucket(rate, burst float64, now mclock.AbsTime) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: now}
+}
+
+// take consumes a token, it returns false if there are none left.
+func (b *tokenBucket) take(now mclock.AbsTime) bool {
+	if now > b.last {
+		b.tokens = min(b.burst, b.tokens+b.rate*time.Duration(now-b.last).Seconds())
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
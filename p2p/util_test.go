@@ -57,3 +57,49 @@ func TestExpHeap(t *testing.T) {
 		t.Fatal("heap doesn't contain all live items")
 	}
 }
+
+func TestExpHeapCount(t *testing.T) {
+	var h expHeap
+	h.add("a", mclock.AbsTime(1))
+	h.add("b", mclock.AbsTime(2))
+	h.add("a", mclock.AbsTime(3))
+	if n := h.count("a"); n != 2 {
+		t.Fatalf("wrong count of a: %d", n)
+	}
+	h.expire(mclock.AbsTime(2), nil)
+	if n := h.count("a"); n != 1 {
+		t.Fatalf("wrong count of a after expiry: %d", n)
+	}
+	if n := h.count("c"); n != 0 {
+		t.Fatalf("wrong count of c: %d", n)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	var clock mclock.Simulated
+	b := newTokenBucket(2, 3, clock.Now())
+	for i := 0; i < 3; i++ {
+		if !b.take(clock.Now()) {
+			t.Fatalf("burst token %d not available", i)
+		}
+	}
+	if b.take(clock.Now()) {
+		t.Fatal("token available after the burst")
+	}
+	clock.Run(500 * time.Millisecond)
+	if !b.take(clock.Now()) {
+		t.Fatal("token not refilled")
+	}
+	if b.take(clock.Now()) {
+		t.Fatal("token available before the refill")
+	}
+	clock.Run(time.Hour)
+	for i := 0; i < 3; i++ {
+		if !b.take(clock.Now()) {
+			t.Fatalf("token %d not refilled up to the burst", i)
+		}
+	}
+	if b.take(clock.Now()) {
+		t.Fatal("refilled above the burst")
+	}
+}
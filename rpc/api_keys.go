@@ -0,0 +1,155 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/time/rate"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+const (
+	APIKeyHeader     = "X-API-Key"
+	apiKeyQueryParam = "apikey"
+)
+
+// APIKeyConfig - one tenant of a shared node. Zero values mean "no restriction".
+type APIKeyConfig struct {
+	Name                string   `json:"name"`                // used in logs and metrics, the key itself is never exposed
+	Key                 string   `json:"key"`                 //
+	Namespaces          []string `json:"namespaces"`          // allowed namespaces, e.g. ["eth", "net"]
+	RateLimit           float64  `json:"rateLimit"`           // requests per second, batch elements are counted one by one
+	Burst               int      `json:"burst"`               // default: RateLimit
+	MaxConcurrentTraces int      `json:"maxConcurrentTraces"` // in-flight trace_* and debug_trace* calls
+	CORSOrigins         []string `json:"corsOrigins"`         // allowed values of Origin header, "*" - any
+}
+
+// APIKey - runtime state of a key: its limiter, trace slots and usage metrics
+type APIKey struct {
+	cfg     APIKeyConfig
+	limiter *rate.Limiter
+	traces  chan struct{}
+
+	requests, rateLimited, forbidden metrics.Counter
+}
+
+func newAPIKey(cfg APIKeyConfig) *APIKey {
+	k := &APIKey{
+		cfg:         cfg,
+		requests:    metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_apikey_requests{key="%s"}`, cfg.Name)),
+		rateLimited: metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_apikey_rejected{key="%s",reason="rate"}`, cfg.Name)),
+		forbidden:   metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_apikey_rejected{key="%s",reason="forbidden"}`, cfg.Name)),
+	}
+	if cfg.RateLimit > 0 {
+		burst := cfg.Burst
+		if burst <= 0 {
+			burst = max(int(cfg.RateLimit), 1)
+		}
+		k.limiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), burst)
+	}
+	if cfg.MaxConcurrentTraces > 0 {
+		k.traces = make(chan struct{}, cfg.MaxConcurrentTraces)
+	}
+	return k
+}
+
+func (k *APIKey) Name() string { return k.cfg.Name }
+
+func (k *APIKey) allowOrigin(origin string) bool {
+	if origin == "" || len(k.cfg.CORSOrigins) == 0 {
+		return true
+	}
+	return slices.Contains(k.cfg.CORSOrigins, "*") || slices.Contains(k.cfg.CORSOrigins, origin)
+}
+
+func isTraceMethod(method string) bool {
+	return strings.HasPrefix(method, "trace_") || strings.HasPrefix(method, "debug_trace")
+}
+
+// acquire - checks namespace and quotas of one call. `release` must be called when the call is done.
+func (k *APIKey) acquire(method string) (release func(), err error) {
+	k.requests.Inc()
+	namespace, _, _ := strings.Cut(method, serviceMethodSeparator)
+	if len(k.cfg.Namespaces) > 0 && !slices.Contains(k.cfg.Namespaces, namespace) {
+		k.forbidden.Inc()
+		return nil, &methodNotFoundError{method: method}
+	}
+	if k.limiter != nil && !k.limiter.Allow() {
+		k.rateLimited.Inc()
+		return nil, &limitExceededError{fmt.Sprintf("rate limit of api key %q exceeded", k.cfg.Name)}
+	}
+	if k.traces == nil || !isTraceMethod(method) {
+		return func() {}, nil
+	}
+	select {
+	case k.traces <- struct{}{}:
+		return func() { <-k.traces }, nil
+	default:
+		k.rateLimited.Inc()
+		return nil, &limitExceededError{fmt.Sprintf("api key %q has %d traces in progress", k.cfg.Name, k.cfg.MaxConcurrentTraces)}
+	}
+}
+
+// APIKeys - keys known to the server. When set, every HTTP request and websocket connection must present a key,
+// in X-API-Key header or in `apikey` query parameter (for clients which can't set headers).
+type APIKeys struct {
+	byKey map[string]*APIKey
+}
+
+func NewAPIKeys(cfgs []APIKeyConfig) (*APIKeys, error) {
+	keys := &APIKeys{byKey: make(map[string]*APIKey, len(cfgs))}
+	names := map[string]struct{}{}
+	for _, cfg := range cfgs {
+		if cfg.Key == "" || cfg.Name == "" {
+			return nil, errors.New("api key must have non-empty name and key")
+		}
+		if _, ok := keys.byKey[cfg.Key]; ok {
+			return nil, fmt.Errorf("duplicate api key: %s", cfg.Name)
+		}
+		if _, ok := names[cfg.Name]; ok {
+			return nil, fmt.Errorf("duplicate api key name: %s", cfg.Name)
+		}
+		names[cfg.Name] = struct{}{}
+		keys.byKey[cfg.Key] = newAPIKey(cfg)
+	}
+	return keys, nil
+}
+
+// authenticate - returns the key of request or an http status to reject it with
+func (a *APIKeys) authenticate(r *http.Request) (*APIKey, int, error) {
+	secret := r.Header.Get(APIKeyHeader)
+	if secret == "" {
+		secret = r.URL.Query().Get(apiKeyQueryParam)
+	}
+	if secret == "" {
+		return nil, http.StatusUnauthorized, errors.New("missing api key")
+	}
+	key, ok := a.byKey[secret]
+	if !ok {
+		return nil, http.StatusUnauthorized, errors.New("invalid api key")
+	}
+	if !key.allowOrigin(r.Header.Get("Origin")) {
+		key.forbidden.Inc()
+		return nil, http.StatusForbidden, errors.New("origin is not allowed for api key")
+	}
+	return key, 0, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestAPIKeys(t *testing.T) {
+	logger := log.New()
+	server := newTestServer(logger)
+	defer server.Stop()
+	keys, err := NewAPIKeys([]APIKeyConfig{
+		{Name: "a", Key: "secret-a", Namespaces: []string{"test"}, RateLimit: 1, Burst: 1},
+		{Name: "b", Key: "secret-b", CORSOrigins: []string{"https://b.example"}},
+	})
+	require.NoError(t, err)
+	server.SetAPIKeys(keys)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	call := func(key, origin, method string) (int, *jsonrpcMessage) {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + method + `","params":[]}`
+		req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("content-type", contentType)
+		if key != "" {
+			req.Header.Set(APIKeyHeader, key)
+		}
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		var msg jsonrpcMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&msg))
+		return resp.StatusCode, &msg
+	}
+
+	code, _ := call("", "", "test_rets")
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = call("wrong", "", "test_rets")
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	// namespaces
+	_, msg := call("secret-a", "", "test_rets")
+	require.Nil(t, msg.Error)
+	_, msg = call("secret-a", "", "rpc_modules")
+	require.NotNil(t, msg.Error)
+	require.Equal(t, -32601, msg.Error.Code)
+
+	// rate limit: burst is used up by the first call, forbidden calls do not consume it
+	_, msg = call("secret-a", "", "test_rets")
+	require.NotNil(t, msg.Error)
+	require.Equal(t, -32005, msg.Error.Code)
+
+	// CORS
+	code, _ = call("secret-b", "https://evil.example", "test_rets")
+	require.Equal(t, http.StatusForbidden, code)
+	_, msg = call("secret-b", "https://b.example", "rpc_modules")
+	require.Nil(t, msg.Error)
+
+	_, err = NewAPIKeys([]APIKeyConfig{{Name: "a", Key: "k"}, {Name: "b", Key: "k"}})
+	require.Error(t, err)
+}
+
+func TestAPIKeyConcurrentTraces(t *testing.T) {
+	key := newAPIKey(APIKeyConfig{Name: "traces", Key: "k", MaxConcurrentTraces: 1})
+	release, err := key.acquire("debug_traceTransaction")
+	require.NoError(t, err)
+	_, err = key.acquire("trace_block")
+	require.ErrorContains(t, err, "traces in progress")
+	// other methods are not limited
+	releaseOther, err := key.acquire("eth_blockNumber")
+	require.NoError(t, err)
+	releaseOther()
+	release()
+	release, err = key.acquire("trace_block")
+	require.NoError(t, err)
+	release()
+}
@@ -81,7 +81,7 @@ func (b DirectBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 
 func (b DirectBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
 	callArgs := CallArgsFromCallMsg(call)
-	gas, err := b.api.EstimateGas(ctx, &callArgs, nil, nil)
+	gas, err := b.api.EstimateGas(ctx, &callArgs, nil, nil, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -117,7 +117,7 @@ func (b DirectBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuer
 func (b DirectBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
 	resc, closec := make(chan any), make(chan any)
 	ctx = rpc.ContextWithNotifier(ctx, rpc.NewLocalNotifier("eth", resc, closec))
-	_, err := b.api.Logs(ctx, filters.FilterCriteria(query))
+	_, err := b.api.Logs(ctx, filters.FilterCriteria(query), nil)
 	if err != nil {
 		return nil, err
 	}
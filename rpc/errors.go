@@ -70,6 +70,13 @@ func (e *invalidMessageError) ErrorCode() int { return -32700 }
 
 func (e *invalidMessageError) Error() string { return e.message }
 
+// request exceeds a quota, e.g. rate limit of api key
+type limitExceededError struct{ message string }
+
+func (e *limitExceededError) ErrorCode() int { return -32005 }
+
+func (e *limitExceededError) Error() string { return e.message }
+
 // unable to decode supplied params, or invalid parameters
 type InvalidParamsError struct{ Message string }
 
@@ -19,6 +19,8 @@ package ethapi
 import (
 	"errors"
 
+	"github.com/holiman/uint256"
+
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/types"
@@ -36,7 +38,7 @@ type BlockOverrides struct {
 	Withdrawals   []*types.Withdrawal `json:"withdrawals"`
 }
 
-func (overrides *BlockOverrides) Override(context evmtypes.BlockContext) error {
+func (overrides *BlockOverrides) Override(context *evmtypes.BlockContext) error {
 
 	if overrides.Number != nil {
 		context.BlockNumber = overrides.Number.Uint64()
@@ -51,7 +53,7 @@ func (overrides *BlockOverrides) Override(context evmtypes.BlockContext) error {
 	}
 
 	if overrides.GasLimit != nil {
-		context.Time = overrides.GasLimit.Uint64()
+		context.GasLimit = overrides.GasLimit.Uint64()
 	}
 
 	if overrides.FeeRecipient != nil {
@@ -59,17 +61,19 @@ func (overrides *BlockOverrides) Override(context evmtypes.BlockContext) error {
 	}
 
 	if overrides.BaseFeePerGas != nil {
-		overflow := context.BaseFee.SetFromBig(overrides.BaseFeePerGas.ToInt())
+		baseFee, overflow := uint256.FromBig(overrides.BaseFeePerGas.ToInt())
 		if overflow {
 			return errors.New("BlockOverrides.BaseFee uint256 overflow")
 		}
+		context.BaseFee = baseFee
 	}
 
 	if overrides.BlobBaseFee != nil {
-		overflow := context.BlobBaseFee.SetFromBig(overrides.BlobBaseFee.ToInt())
+		blobBaseFee, overflow := uint256.FromBig(overrides.BlobBaseFee.ToInt())
 		if overflow {
 			return errors.New("BlockOverrides.BlobBaseFee uint256 overflow")
 		}
+		context.BlobBaseFee = blobBaseFee
 	}
 
 	if overrides.Withdrawals != nil {
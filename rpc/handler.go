@@ -29,6 +29,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/erigontech/erigon-lib/jsonstream"
@@ -75,6 +76,8 @@ type handler struct {
 
 	subLock             sync.Mutex
 	serverSubs          map[ID]*Subscription
+	subLimits           SubscriptionLimits
+	notifyBytes         atomic.Int64 // buffered by the notifiers of all subscriptions
 	maxBatchConcurrency uint
 	traceRequests       bool
 
@@ -460,6 +463,13 @@ func (h *handler) isMethodAllowedByGranularControl(method string) bool {
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream jsonstream.Stream) *jsonrpcMessage {
+	if key := PeerInfoFromContext(cp.ctx).APIKey; key != nil && !msg.isUnsubscribe() {
+		release, err := key.acquire(msg.Method)
+		if err != nil {
+			return msg.errorResponse(err)
+		}
+		defer release()
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg, stream)
 	}
@@ -254,6 +254,14 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
 	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
+	if s.apiKeys != nil {
+		key, code, err := s.apiKeys.authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), code)
+			return
+		}
+		connInfo.APIKey = key
+	}
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
 
@@ -23,6 +23,7 @@ import (
 
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/nat"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 )
 
@@ -37,6 +38,24 @@ type AdminAPI interface {
 
 	// AddPeer requests connecting to a remote node.
 	AddPeer(ctx context.Context, url string) (bool, error)
+
+	// ClientDiversity returns the breakdown of the connected remote nodes by client, version, eth protocol and fork ID.
+	ClientDiversity(ctx context.Context) (*p2p.ClientDiversity, error)
+
+	// NATStatus returns the state of the detection of the external endpoint (port mappings and IP) behind a NAT.
+	NATStatus(ctx context.Context) (*nat.Status, error)
+
+	// LogLevels returns per-subsystem log level overrides of this process.
+	LogLevels(ctx context.Context) (map[string]string, error)
+
+	// SetLogLevels changes per-subsystem log level overrides, empty level removes the override.
+	SetLogLevels(ctx context.Context, levels map[string]string) (map[string]string, error)
+
+	// ParseEnr decodes and validates an ENR or an enode URL.
+	ParseEnr(ctx context.Context, url string) (*NodeRecord, error)
+
+	// MakeEnode renders the enode URL of a node from its public key, ip and ports.
+	MakeEnode(ctx context.Context, pubkey string, ip string, tcp int, udp *int) (string, error)
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
@@ -78,3 +97,22 @@ func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error)
 	}
 	return result.Success, nil
 }
+
+func (api *AdminAPIImpl) ClientDiversity(ctx context.Context) (*p2p.ClientDiversity, error) {
+	peers, err := api.ethBackend.Peers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p2p.NewClientDiversity(peers), nil
+}
+
+func (api *AdminAPIImpl) NATStatus(ctx context.Context) (*nat.Status, error) {
+	node, err := api.NodeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if node.NAT == nil {
+		return nil, errors.New("no NAT port mapping: --nat is none or extip")
+	}
+	return node.NAT, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/execution/eth1"
+)
+
+// BuildBlockArgs - all fields are optional, see eth1.EthereumExecutionModule.BuildBlockDryRun for defaults
+type BuildBlockArgs struct {
+	ParentHash            common.Hash         `json:"parentHash"`
+	FeeRecipient          common.Address      `json:"feeRecipient"`
+	Timestamp             hexutil.Uint64      `json:"timestamp"`
+	PrevRandao            common.Hash         `json:"prevRandao"`
+	Withdrawals           []*types.Withdrawal `json:"withdrawals"`
+	ParentBeaconBlockRoot *common.Hash        `json:"parentBeaconBlockRoot"`
+	BuildTimeMs           hexutil.Uint64      `json:"buildTimeMs"` // default 1s
+}
+
+// BlockBuildingAPI - admin_* command building a payload without forkchoice interaction, for validators
+// comparing local building with relay bids. Available only with the embedded rpcdaemon.
+// Payload is built on top of the current head only: other parents are rejected.
+type BlockBuildingAPI interface {
+	BuildBlockDryRun(ctx context.Context, args BuildBlockArgs) (*eth1.DryRunBlock, error)
+}
+
+type BlockBuildingAPIImpl struct {
+	execModule *eth1.EthereumExecutionModule
+}
+
+func NewBlockBuildingAPI(execModule *eth1.EthereumExecutionModule) *BlockBuildingAPIImpl {
+	return &BlockBuildingAPIImpl{execModule: execModule}
+}
+
+func (api *BlockBuildingAPIImpl) BuildBlockDryRun(ctx context.Context, args BuildBlockArgs) (*eth1.DryRunBlock, error) {
+	buildTime := time.Second
+	if args.BuildTimeMs > 0 {
+		buildTime = time.Duration(args.BuildTimeMs) * time.Millisecond
+	}
+	return api.execModule.BuildBlockDryRun(ctx, core.BlockBuilderParameters{
+		ParentHash:            args.ParentHash,
+		Timestamp:             uint64(args.Timestamp),
+		PrevRandao:            args.PrevRandao,
+		SuggestedFeeRecipient: args.FeeRecipient,
+		Withdrawals:           args.Withdrawals,
+		ParentBeaconBlockRoot: args.ParentBeaconBlockRoot,
+	}, buildTime)
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/protocols/eth"
+)
+
+// NodeRecord - a node URL (enr: or enode://) decoded by admin_parseEnr
+type NodeRecord struct {
+	ID             enode.ID                 `json:"id"`
+	Enode          string                   `json:"enode"`
+	Enr            string                   `json:"enr,omitempty"` // only of the signed records
+	Seq            uint64                   `json:"seq"`
+	IdentityScheme string                   `json:"identityScheme,omitempty"`
+	PublicKey      hexutil.Bytes            `json:"publicKey,omitempty"` // 64 bytes, as in the enode URL
+	Signature      hexutil.Bytes            `json:"signature,omitempty"` // verified
+	IP             string                   `json:"ip,omitempty"`
+	TCP            int                      `json:"tcp,omitempty"`
+	UDP            int                      `json:"udp,omitempty"`
+	ForkID         *NodeRecordForkID        `json:"forkId,omitempty"` // of the `eth` entry
+	Entries        map[string]hexutil.Bytes `json:"entries,omitempty"`
+}
+
+type NodeRecordForkID struct {
+	Hash hexutil.Bytes  `json:"hash"`
+	Next hexutil.Uint64 `json:"next"`
+}
+
+// ParseEnr decodes an ENR or an enode URL. The signature of an ENR is verified, a record with an invalid
+// signature or of an unknown identity scheme is rejected. Entries are the raw rlp values of the record by key.
+func (api *AdminAPIImpl) ParseEnr(ctx context.Context, url string) (*NodeRecord, error) {
+	n, err := enode.Parse(enode.ValidSchemes, strings.TrimSpace(url))
+	if err != nil {
+		return nil, fmt.Errorf("invalid node URL: %w", err)
+	}
+	r := n.Record()
+	res := &NodeRecord{
+		ID:             n.ID(),
+		Enode:          n.URLv4(),
+		Seq:            n.Seq(),
+		IdentityScheme: r.IdentityScheme(),
+		Signature:      r.Signature(),
+		TCP:            n.TCP(),
+		UDP:            n.UDP(),
+	}
+	if len(res.Signature) > 0 {
+		res.Enr = n.String()
+	}
+	if pubkey := n.Pubkey(); pubkey != nil {
+		res.PublicKey = crypto.MarshalPubkey(pubkey)
+	}
+	if ip := n.IP(); ip != nil {
+		res.IP = ip.String()
+	}
+	forkID, err := eth.LoadENRForkID(r)
+	if err != nil {
+		return nil, err
+	}
+	if forkID != nil {
+		res.ForkID = &NodeRecordForkID{Hash: forkID.Hash[:], Next: hexutil.Uint64(forkID.Next)}
+	}
+	elements := r.AppendElements(nil)[1:] // without seq
+	res.Entries = make(map[string]hexutil.Bytes, len(elements)/2)
+	for i := 0; i+1 < len(elements); i += 2 {
+		key, _ := elements[i].(string)
+		value, _ := elements[i+1].(rlp.RawValue)
+		res.Entries[key] = hexutil.Bytes(value)
+	}
+	return res, nil
+}
+
+// MakeEnode renders the enode URL of a node: its public key (64 bytes as in enode URLs, 65 bytes uncompressed or
+// 33 bytes compressed, hex), ip and ports. udp defaults to tcp.
+func (api *AdminAPIImpl) MakeEnode(ctx context.Context, pubkey string, ip string, tcp int, udp *int) (string, error) {
+	key, err := parseNodePubkey(pubkey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return "", fmt.Errorf("invalid ip: %q", ip)
+	}
+	if tcp <= 0 || tcp > 65535 {
+		return "", fmt.Errorf("invalid tcp port: %d", tcp)
+	}
+	udpPort := tcp
+	if udp != nil {
+		if *udp <= 0 || *udp > 65535 {
+			return "", fmt.Errorf("invalid udp port: %d", *udp)
+		}
+		udpPort = *udp
+	}
+	return enode.NewV4(key, parsedIP, tcp, udpPort).URLv4(), nil
+}
+
+func parseNodePubkey(s string) (*ecdsa.PublicKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "enode://"), "0x"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(b) {
+	case 64:
+		return crypto.UnmarshalPubkey(b)
+	case 65:
+		return crypto.UnmarshalPubkeyStd(b)
+	case 33:
+		return crypto.DecompressPubkey(b)
+	default:
+		return nil, fmt.Errorf("wrong length %d, want 64, 65 or 33 bytes", len(b))
+	}
+}
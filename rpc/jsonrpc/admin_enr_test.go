@@ -0,0 +1,85 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon/execution/chainspec"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+	"github.com/erigontech/erigon/p2p/forkid"
+	"github.com/erigontech/erigon/p2p/protocols/eth"
+)
+
+func TestAdminParseEnr(t *testing.T) {
+	ctx := context.Background()
+	api := NewAdminAPI(nil)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	heightForks, timeForks := forkid.GatherForks(chainspec.MainnetChainConfig, 0)
+	var r enr.Record
+	r.Set(enr.IPv4(net.IPv4(10, 0, 0, 1)))
+	r.Set(enr.TCP(30303))
+	r.Set(enr.UDP(30304))
+	r.Set(eth.CurrentENREntryFromForks(heightForks, timeForks, chainspec.MainnetGenesisHash, 0, 0))
+	require.NoError(t, enode.SignV4(&r, key))
+	n, err := enode.New(enode.ValidSchemes, &r)
+	require.NoError(t, err)
+
+	res, err := api.ParseEnr(ctx, n.String())
+	require.NoError(t, err)
+	require.Equal(t, n.ID(), res.ID)
+	require.Equal(t, n.String(), res.Enr)
+	require.Equal(t, "v4", res.IdentityScheme)
+	require.Equal(t, "10.0.0.1", res.IP)
+	require.Equal(t, 30303, res.TCP)
+	require.Equal(t, 30304, res.UDP)
+	require.NotNil(t, res.ForkID)
+	forkID := forkid.NewIDFromForks(heightForks, timeForks, chainspec.MainnetGenesisHash, 0, 0)
+	require.Equal(t, forkID.Hash[:], []byte(res.ForkID.Hash))
+	require.Contains(t, res.Entries, "eth")
+	require.Contains(t, res.Entries, "secp256k1")
+
+	// the enode URL of the record renders back to the same node
+	enodeURL, err := api.MakeEnode(ctx, hex.EncodeToString(res.PublicKey), res.IP, res.TCP, &res.UDP)
+	require.NoError(t, err)
+	require.Equal(t, res.Enode, enodeURL)
+	compressed, err := api.MakeEnode(ctx, hex.EncodeToString(crypto.CompressPubkey(&key.PublicKey)), res.IP, res.TCP, &res.UDP)
+	require.NoError(t, err)
+	require.Equal(t, enodeURL, compressed)
+
+	res, err = api.ParseEnr(ctx, enodeURL)
+	require.NoError(t, err)
+	require.Equal(t, n.ID(), res.ID)
+	require.Empty(t, res.Enr)
+	require.Nil(t, res.ForkID)
+
+	_, err = api.ParseEnr(ctx, "enr:invalid")
+	require.Error(t, err)
+	_, err = api.MakeEnode(ctx, "0x1234", "10.0.0.1", 30303, nil)
+	require.ErrorContains(t, err, "wrong length")
+	_, err = api.MakeEnode(ctx, hex.EncodeToString(res.PublicKey), "10.0.0.1", 0, nil)
+	require.ErrorContains(t, err, "invalid tcp port")
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/turbo/logging"
+)
+
+// LogLevels - overrides apply to the process serving RPC: erigon itself for the embedded rpcdaemon
+func (api *AdminAPIImpl) LogLevels(ctx context.Context) (map[string]string, error) {
+	return logging.Subsystems.Get(), nil
+}
+
+func (api *AdminAPIImpl) SetLogLevels(ctx context.Context, levels map[string]string) (map[string]string, error) {
+	if err := logging.Subsystems.Set(levels); err != nil {
+		return nil, err
+	}
+	res := logging.Subsystems.Get()
+	log.Info("[rpc] per-subsystem log levels changed", "levels", res)
+	return res, nil
+}
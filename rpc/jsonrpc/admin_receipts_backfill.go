@@ -0,0 +1,61 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon/execution/stagedsync"
+)
+
+// ReceiptsBackfillAPI - admin_* commands controlling re-production of pruned receipts and logs indices.
+// Available only with the embedded rpcdaemon: the job re-executes blocks and writes to the node's db.
+type ReceiptsBackfillAPI interface {
+	StartReceiptsBackfill(ctx context.Context, cfg stagedsync.ReceiptsBackfillCfg) (stagedsync.ReceiptsBackfillStatus, error)
+	PauseReceiptsBackfill(ctx context.Context) (stagedsync.ReceiptsBackfillStatus, error)
+	ResumeReceiptsBackfill(ctx context.Context) (stagedsync.ReceiptsBackfillStatus, error)
+	StopReceiptsBackfill(ctx context.Context) (stagedsync.ReceiptsBackfillStatus, error)
+	ReceiptsBackfillStatus(ctx context.Context) (stagedsync.ReceiptsBackfillStatus, error)
+}
+
+type ReceiptsBackfillAPIImpl struct {
+	job *stagedsync.ReceiptsBackfill
+}
+
+func NewReceiptsBackfillAPI(job *stagedsync.ReceiptsBackfill) *ReceiptsBackfillAPIImpl {
+	return &ReceiptsBackfillAPIImpl{job: job}
+}
+
+func (api *ReceiptsBackfillAPIImpl) StartReceiptsBackfill(ctx context.Context, cfg stagedsync.ReceiptsBackfillCfg) (stagedsync.ReceiptsBackfillStatus, error) {
+	return api.job.Start(cfg)
+}
+
+func (api *ReceiptsBackfillAPIImpl) PauseReceiptsBackfill(ctx context.Context) (stagedsync.ReceiptsBackfillStatus, error) {
+	return api.job.Pause()
+}
+
+func (api *ReceiptsBackfillAPIImpl) ResumeReceiptsBackfill(ctx context.Context) (stagedsync.ReceiptsBackfillStatus, error) {
+	return api.job.Resume()
+}
+
+func (api *ReceiptsBackfillAPIImpl) StopReceiptsBackfill(ctx context.Context) (stagedsync.ReceiptsBackfillStatus, error) {
+	return api.job.Stop()
+}
+
+func (api *ReceiptsBackfillAPIImpl) ReceiptsBackfillStatus(ctx context.Context) (stagedsync.ReceiptsBackfillStatus, error) {
+	return api.job.Status(), nil
+}
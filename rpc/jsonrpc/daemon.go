@@ -28,6 +28,8 @@ import (
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/shards"
+	txpoolimpl "github.com/erigontech/erigon/txnprovider/txpool"
 )
 
 // APIList describes the list of available RPC apis
@@ -35,10 +37,15 @@ func APIList(db kv.TemporalRoDB, eth rpchelper.ApiBackend, txPool txpool.TxpoolC
 	filters *rpchelper.Filters, stateCache kvcache.Cache,
 	blockReader services.FullBlockReader, cfg *httpcfg.HttpCfg, engine consensus.EngineReader,
 	logger log.Logger, bridgeReader bridgeReader, spanProducersReader spanProducersReader,
+	feeMarket txpoolimpl.FeeMarketHistoryReader, stateGrowth *shards.StateGrowthTracker,
 ) (list []rpc.API) {
 	base := NewBaseApi(filters, stateCache, blockReader, cfg.WithDatadir, cfg.EvmCallTimeout, engine, cfg.Dirs, bridgeReader)
+	base.evmMaxMemory = cfg.EvmMaxMemoryMB * 1024 * 1024
+	base.nonCanonicalTxs = cfg.TxLookupNonCanonical
+	base.opcodeOverrides = cfg.OpcodeOverrides
 	ethImpl := NewEthAPI(base, db, eth, txPool, mining, cfg.Gascap, cfg.Feecap, cfg.ReturnDataLimit, cfg.AllowUnprotectedTxs, cfg.MaxGetProofRewindBlockCount, cfg.WebsocketSubscribeLogsChannelSize, logger)
-	erigonImpl := NewErigonAPI(base, db, eth)
+	erigonImpl := NewErigonAPI(base, db, eth, feeMarket)
+	erigonImpl.stateGrowth = stateGrowth
 	txpoolImpl := NewTxPoolAPI(base, db, txPool)
 	netImpl := NewNetAPIImpl(eth)
 	debugImpl := NewPrivateDebugAPI(base, db, cfg.Gascap)
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 
@@ -32,9 +33,11 @@ import (
 	"github.com/erigontech/erigon-lib/kv/order"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon-lib/types/accounts"
 	"github.com/erigontech/erigon/core/state"
 	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
+	"github.com/erigontech/erigon/execution/eth1"
 	"github.com/erigontech/erigon/execution/stagedsync/stages"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/ethapi"
@@ -55,6 +58,7 @@ type PrivateDebugAPI interface {
 	TraceTransaction(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error
 	TraceBlockByHash(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error
 	TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error
+	TraceChain(ctx context.Context, start, end rpc.BlockNumber, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error
 	AccountRange(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start []byte, maxResults int, nocode, nostorage bool) (state.IteratorDump, error)
 	GetModifiedAccountsByNumber(ctx context.Context, startNum rpc.BlockNumber, endNum *rpc.BlockNumber) ([]common.Address, error)
 	GetModifiedAccountsByHash(ctx context.Context, startHash common.Hash, endHash *common.Hash) ([]common.Address, error)
@@ -191,16 +195,21 @@ func (api *DebugAPIImpl) GetModifiedAccountsByNumber(ctx context.Context, startN
 		return nil, err
 	}
 
-	// forces negative numbers to fail (too large) but allows zero
-	startNum := uint64(startNumber.Int64())
+	startNum, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(startNumber), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
 	if startNum > latestBlock {
 		return nil, fmt.Errorf("start block (%d) is later than the latest block (%d)", startNum, latestBlock)
 	}
 
 	endNum := startNum + 1 // allows for single param calls
 	if endNumber != nil {
-		// forces negative numbers to fail (too large) but allows zero
-		endNum = uint64(endNumber.Int64()) + 1
+		endBlock, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(*endNumber), tx, api._blockReader, api.filters)
+		if err != nil {
+			return nil, err
+		}
+		endNum = endBlock + 1
 	}
 
 	// is endNum too big?
@@ -445,8 +454,34 @@ func (api *DebugAPIImpl) GetRawReceipts(ctx context.Context, blockNrOrHash rpc.B
 	return result, nil
 }
 
-// GetBadBlocks implements debug_getBadBlocks - Returns an array of recent bad blocks that the client has seen on the network
+// GetBadBlocks implements debug_getBadBlocks - Returns an array of recent bad blocks that the client has seen on the network:
+// the ones kept by the bad block pool of the datadir (eth1.BadBlocks, most recent first) with their validation error,
+// then the ones of the chains marked as bad in the db
 func (api *DebugAPIImpl) GetBadBlocks(ctx context.Context) ([]map[string]interface{}, error) {
+	var results []map[string]interface{}
+	kept := map[common.Hash]struct{}{}
+	if api.dirs.DataDir != "" {
+		badBlocks, err := eth1.ReadBadBlocks(filepath.Join(api.dirs.DataDir, eth1.BadBlocksDir))
+		if err != nil {
+			return nil, err
+		}
+		for i := len(badBlocks) - 1; i >= 0; i-- {
+			b := badBlocks[i]
+			block, err := b.Block()
+			if err != nil {
+				return nil, err
+			}
+			result := badBlockResult(block)
+			result["number"] = hexutil.Uint64(b.Number)
+			result["error"] = b.Error
+			result["field"] = b.Field
+			result["source"] = b.Source
+			result["time"] = b.Time
+			results = append(results, result)
+			kept[b.Hash] = struct{}{}
+		}
+	}
+
 	tx, err := api.db.BeginTemporalRo(ctx)
 	if err != nil {
 		return nil, err
@@ -454,34 +489,44 @@ func (api *DebugAPIImpl) GetBadBlocks(ctx context.Context) ([]map[string]interfa
 	defer tx.Rollback()
 
 	blocks, err := rawdb.GetLatestBadBlocks(tx)
-	if err != nil || len(blocks) == 0 {
+	if err != nil {
 		return nil, err
 	}
-
-	results := make([]map[string]interface{}, 0, len(blocks))
 	for _, block := range blocks {
-		var blockRlp string
-		if rlpBytes, err := rlp.EncodeToBytes(block); err != nil {
-			blockRlp = err.Error() // hack
-		} else {
-			blockRlp = fmt.Sprintf("%#x", rlpBytes)
+		if block == nil {
+			continue
 		}
-
-		blockJson, err := ethapi.RPCMarshalBlock(block, true, true, nil)
-		if err != nil {
-			log.Error("Failed to marshal block", "err", err)
-			blockJson = map[string]interface{}{}
+		if _, ok := kept[block.Hash()]; ok {
+			continue
 		}
-		results = append(results, map[string]interface{}{
-			"hash":  block.Hash(),
-			"block": blockRlp,
-			"rlp":   blockJson,
-		})
+		results = append(results, badBlockResult(block))
+	}
+	if len(results) == 0 {
+		return nil, nil
 	}
-
 	return results, nil
 }
 
+func badBlockResult(block *types.Block) map[string]interface{} {
+	var blockRlp string
+	if rlpBytes, err := rlp.EncodeToBytes(block); err != nil {
+		blockRlp = err.Error() // hack
+	} else {
+		blockRlp = fmt.Sprintf("%#x", rlpBytes)
+	}
+
+	blockJson, err := ethapi.RPCMarshalBlock(block, true, true, nil)
+	if err != nil {
+		log.Error("Failed to marshal block", "err", err)
+		blockJson = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"hash":  block.Hash(),
+		"block": blockJson,
+		"rlp":   blockRlp,
+	}
+}
+
 // GetRawTransaction implements debug_getRawTransaction - Returns an array of EIP-2718 binary-encoded transactions
 func (api *DebugAPIImpl) GetRawTransaction(ctx context.Context, txnHash common.Hash) (hexutil.Bytes, error) {
 	tx, err := api.db.BeginTemporalRo(ctx)
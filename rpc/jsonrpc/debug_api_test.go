@@ -126,6 +126,37 @@ func TestTraceBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestTraceChain(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	baseApi := NewBaseApi(nil, stateCache, m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil)
+	api := NewPrivateDebugAPI(baseApi, m.DB, 0)
+
+	var buf bytes.Buffer
+	s := jsonstream.New(jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096))
+	err := api.TraceChain(m.Ctx, rpc.BlockNumber(1), rpc.BlockNumber(3), &tracersConfig.TraceConfig{}, s)
+	require.NoError(t, err)
+	require.NoError(t, s.Flush())
+
+	var res []struct {
+		Block  *uint64                  `json:"block"`
+		Next   *uint64                  `json:"next"`
+		Result []ethapi.ExecutionResult `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	require.Len(t, res, 3)
+	for i, r := range res {
+		require.NotNil(t, r.Block)
+		require.Equal(t, uint64(i+1), *r.Block)
+		require.Nil(t, r.Next)
+	}
+
+	buf.Reset()
+	s = jsonstream.New(jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096))
+	err = api.TraceChain(m.Ctx, rpc.BlockNumber(3), rpc.BlockNumber(1), &tracersConfig.TraceConfig{}, s)
+	require.Error(t, err)
+}
+
 func TestTraceBlockByHash(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
@@ -0,0 +1,85 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// maxGetAccountsAddresses - limit of addresses per erigon_getAccounts call
+const maxGetAccountsAddresses = 10_000
+
+// AccountSummary - balance, nonce and code hash of an account. CodeHash is zero for non-existent accounts (as EXTCODEHASH).
+type AccountSummary struct {
+	Address  common.Address `json:"address"`
+	Balance  *hexutil.Big   `json:"balance"`
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	CodeHash common.Hash    `json:"codeHash"`
+}
+
+// GetAccounts implements erigon_getAccounts. Returns accounts in order of `addresses`, all read from the same state.
+// Addresses are read in sorted order: neighbouring keys share domain pages and files.
+func (api *ErigonImpl) GetAccounts(ctx context.Context, addresses []common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]AccountSummary, error) {
+	if len(addresses) > maxGetAccountsAddresses {
+		return nil, fmt.Errorf("too many addresses: %d, max %d", len(addresses), maxGetAccountsAddresses)
+	}
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	reader, err := rpchelper.CreateStateReader(ctx, tx, api._blockReader, blockNrOrHash, 0, api.filters, api.stateCache, api._txNumReader)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := slices.Clone(addresses)
+	slices.SortFunc(sorted, func(a, b common.Address) int { return bytes.Compare(a[:], b[:]) })
+	sorted = slices.Compact(sorted)
+	byAddress := make(map[common.Address]AccountSummary, len(sorted))
+	for _, address := range sorted {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		acc, err := reader.ReadAccountData(address)
+		if err != nil {
+			return nil, fmt.Errorf("cant get account %x: %w", address, err)
+		}
+		summary := AccountSummary{Address: address, Balance: (*hexutil.Big)(new(big.Int))}
+		if acc != nil {
+			summary.Balance = (*hexutil.Big)(acc.Balance.ToBig())
+			summary.Nonce = hexutil.Uint64(acc.Nonce)
+			summary.CodeHash = acc.CodeHash
+		}
+		byAddress[address] = summary
+	}
+
+	res := make([]AccountSummary, len(addresses))
+	for i, address := range addresses {
+		res[i] = byAddress[address]
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestGetAccounts(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	key1, _ := crypto.HexToECDSA("49a7b37aa6f6645917e7b807e9d1c00d4fa71f18343b0d4122a4d2df64dd6fee")
+	sender, receiver, missing := crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(key1.PublicKey), common.Address{0xde, 0xad}
+	addresses := []common.Address{receiver, missing, sender, receiver} // unsorted, with duplicate
+
+	for _, blockNum := range []rpc.BlockNumber{0, 2, rpc.LatestBlockNumber} {
+		blockNrOrHash := rpc.BlockNumberOrHashWithNumber(blockNum)
+		accounts, err := api.GetAccounts(ctx, addresses, blockNrOrHash)
+		require.NoError(t, err)
+		require.Len(t, accounts, len(addresses))
+		for i, acc := range accounts {
+			require.Equal(t, addresses[i], acc.Address)
+			balance, err := ethApi.GetBalance(ctx, acc.Address, blockNrOrHash)
+			require.NoError(t, err)
+			require.Equal(t, balance.ToInt(), acc.Balance.ToInt(), "block %d, address %x", blockNum, acc.Address)
+			nonce, err := ethApi.GetTransactionCount(ctx, acc.Address, blockNrOrHash)
+			require.NoError(t, err)
+			require.Equal(t, *nonce, acc.Nonce)
+		}
+		require.Equal(t, common.Hash{}, accounts[1].CodeHash)
+		require.NotEqual(t, common.Hash{}, accounts[2].CodeHash)
+	}
+
+	_, err := api.GetAccounts(ctx, make([]common.Address, maxGetAccountsAddresses+1), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.ErrorContains(t, err, "too many addresses")
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/kv/stream"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+const (
+	defaultAddressHistoryPageSize = 100
+	maxAddressHistoryPageSize     = 1_000
+	// maxAddressHistorySlots - limit of storage slots searched per call, every slot is a separate index lookup
+	maxAddressHistorySlots = 10_000
+)
+
+// AddressHistoryQuery - parameters of erigon_getAddressHistory, all optional
+type AddressHistoryQuery struct {
+	FromBlock *hexutil.Uint64 `json:"fromBlock"` // default: first block with available history
+	ToBlock   *hexutil.Uint64 `json:"toBlock"`   // inclusive, default: latest executed block
+	Cursor    *hexutil.Uint64 `json:"cursor"`    // `next` of the previous page
+	PageSize  *hexutil.Uint64 `json:"pageSize"`  // default 100, max 1000
+	// Slots - storage slots to search, by default all slots currently non-empty. Slots which are empty now
+	// (e.g. cleared by the contract) are not found by default, they must be passed explicitly.
+	Slots []common.Hash `json:"slots"`
+}
+
+// AddressHistoryEntry - a change of the account or its storage. Changes made outside of transactions
+// (block rewards, withdrawals, system calls) have no transaction index and hash.
+type AddressHistoryEntry struct {
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	TxIndex     *hexutil.Uint64 `json:"transactionIndex"`
+	TxHash      *common.Hash    `json:"transactionHash"`
+	Balance     bool            `json:"balance,omitempty"`
+	Nonce       bool            `json:"nonce,omitempty"`
+	Code        bool            `json:"code,omitempty"`
+	Storage     []common.Hash   `json:"storage,omitempty"` // changed slots
+}
+
+// AddressHistoryPage - result of erigon_getAddressHistory, entries are in ascending order
+type AddressHistoryPage struct {
+	Entries []AddressHistoryEntry `json:"entries"`
+	Next    *hexutil.Uint64       `json:"next"` // cursor of the next page, nil if it is the last page
+}
+
+// GetAddressHistory implements erigon_getAddressHistory. Returns transactions which changed balance, nonce, code
+// or storage of the address, found by the history inverted indices.
+func (api *ErigonImpl) GetAddressHistory(ctx context.Context, address common.Address, query AddressHistoryQuery) (*AddressHistoryPage, error) {
+	pageSize := uint64(defaultAddressHistoryPageSize)
+	if query.PageSize != nil {
+		pageSize = uint64(*query.PageSize)
+		if pageSize == 0 || pageSize > maxAddressHistoryPageSize {
+			return nil, fmt.Errorf("pageSize must be in 1-%d, got %d", maxAddressHistoryPageSize, pageSize)
+		}
+	}
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	latestBlock, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	toBlock := latestBlock
+	if query.ToBlock != nil {
+		toBlock = min(uint64(*query.ToBlock), latestBlock)
+	}
+	toTxNum, err := api._txNumReader.Max(tx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	historyStart := tx.Debug().HistoryStartFrom(kv.AccountsDomain)
+	fromTxNum := historyStart
+	if query.FromBlock != nil {
+		if fromTxNum, err = api._txNumReader.Min(tx, uint64(*query.FromBlock)); err != nil {
+			return nil, err
+		}
+		if fromTxNum < historyStart {
+			return nil, state.PrunedError
+		}
+	}
+	if query.Cursor != nil {
+		fromTxNum = max(fromTxNum, uint64(*query.Cursor))
+	}
+	page := &AddressHistoryPage{Entries: []AddressHistoryEntry{}}
+	if fromTxNum > toTxNum {
+		return page, nil
+	}
+
+	slots := query.Slots
+	if len(slots) == 0 {
+		if slots, err = currentStorageSlots(tx, address); err != nil {
+			return nil, err
+		}
+	}
+	if len(slots) > maxAddressHistorySlots {
+		return nil, fmt.Errorf("too many storage slots: %d, max %d, pass `slots` explicitly", len(slots), maxAddressHistorySlots)
+	}
+
+	// Every index contributes at most pageSize+1 txNums to the page: more txNums than pageSize in total means
+	// the next page exists and starts at the first txNum not in this page.
+	changes := map[uint64]*AddressHistoryEntry{}
+	collect := func(idx kv.InvertedIdx, key []byte, mark func(e *AddressHistoryEntry)) error {
+		it, err := tx.IndexRange(idx, key, int(fromTxNum), int(toTxNum)+1, order.Asc, int(pageSize)+1)
+		if err != nil {
+			return err
+		}
+		defer it.Close()
+		for it.HasNext() {
+			txNum, err := it.Next()
+			if err != nil {
+				return err
+			}
+			e, ok := changes[txNum]
+			if !ok {
+				e = &AddressHistoryEntry{}
+				changes[txNum] = e
+			}
+			mark(e)
+		}
+		return nil
+	}
+	var accountChanged []uint64
+	if err := collect(kv.AccountsHistoryIdx, address[:], func(e *AddressHistoryEntry) {}); err != nil {
+		return nil, err
+	}
+	for txNum := range changes {
+		accountChanged = append(accountChanged, txNum)
+	}
+	if err := collect(kv.CodeHistoryIdx, address[:], func(e *AddressHistoryEntry) { e.Code = true }); err != nil {
+		return nil, err
+	}
+	for _, slot := range slots {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := collect(kv.StorageHistoryIdx, append(address[:], slot[:]...), func(e *AddressHistoryEntry) { e.Storage = append(e.Storage, slot) }); err != nil {
+			return nil, err
+		}
+	}
+
+	txNums := make([]uint64, 0, len(changes))
+	for txNum := range changes {
+		txNums = append(txNums, txNum)
+	}
+	slices.Sort(txNums)
+	if uint64(len(txNums)) > pageSize {
+		next := hexutil.Uint64(txNums[pageSize])
+		page.Next = &next
+		txNums = txNums[:pageSize]
+	}
+
+	// which of the account fields changed: the account before the change vs after it
+	for _, txNum := range accountChanged {
+		e := changes[txNum]
+		if e == nil || (page.Next != nil && txNum >= uint64(*page.Next)) {
+			continue
+		}
+		before, err := accountAsOf(tx, address, txNum)
+		if err != nil {
+			return nil, err
+		}
+		after, err := accountAsOf(tx, address, txNum+1)
+		if err != nil {
+			return nil, err
+		}
+		e.Balance = !before.Balance.Eq(&after.Balance)
+		e.Nonce = before.Nonce != after.Nonce
+		e.Code = e.Code || before.CodeHash != after.CodeHash
+	}
+
+	it := rawdbv3.TxNums2BlockNums(tx, api._txNumReader, stream.Array(txNums), order.Asc)
+	defer it.Close()
+	for it.HasNext() {
+		txNum, blockNum, txIndex, isFinalTxn, _, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		e := changes[txNum]
+		e.BlockNumber = hexutil.Uint64(blockNum)
+		if txIndex >= 0 && !isFinalTxn {
+			txn, err := api._txnReader.TxnByIdxInBlock(ctx, tx, blockNum, txIndex)
+			if err != nil {
+				return nil, err
+			}
+			if txn != nil {
+				idx, hash := hexutil.Uint64(txIndex), txn.Hash()
+				e.TxIndex, e.TxHash = &idx, &hash
+			}
+		}
+		page.Entries = append(page.Entries, *e)
+	}
+	return page, nil
+}
+
+// currentStorageSlots returns the non-empty storage slots of the address in the latest state
+func currentStorageSlots(tx kv.TemporalTx, address common.Address) ([]common.Hash, error) {
+	to, _ := kv.NextSubtree(address[:])
+	it, err := tx.Debug().RangeLatest(kv.StorageDomain, address[:], to, maxAddressHistorySlots+1)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var slots []common.Hash
+	for it.HasNext() {
+		k, v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if len(v) > 0 {
+			slots = append(slots, common.BytesToHash(k[length.Addr:]))
+		}
+	}
+	return slots, nil
+}
+
+// accountAsOf returns the account before the transaction txNum, empty if it doesn't exist
+func accountAsOf(tx kv.TemporalTx, address common.Address, txNum uint64) (*accounts.Account, error) {
+	acc := accounts.NewAccount()
+	enc, _, err := tx.GetAsOf(kv.AccountsDomain, address[:], txNum)
+	if err != nil || len(enc) == 0 {
+		return &acc, err
+	}
+	if err := accounts.DeserialiseV3(&acc, enc); err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
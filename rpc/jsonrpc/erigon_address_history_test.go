@@ -0,0 +1,114 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestGetAddressHistory(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	all, err := api.GetAddressHistory(ctx, sender, AddressHistoryQuery{PageSize: pageSize(maxAddressHistoryPageSize)})
+	require.NoError(t, err)
+	require.Nil(t, all.Next)
+	require.NotEmpty(t, all.Entries)
+
+	// every block changing the balance or nonce of the sender has an entry saying so
+	balanceChanged, nonceChanged := map[uint64]bool{}, map[uint64]bool{}
+	for i, e := range all.Entries {
+		if i > 0 {
+			require.LessOrEqual(t, all.Entries[i-1].BlockNumber, e.BlockNumber)
+		}
+		balanceChanged[uint64(e.BlockNumber)] = balanceChanged[uint64(e.BlockNumber)] || e.Balance
+		nonceChanged[uint64(e.BlockNumber)] = nonceChanged[uint64(e.BlockNumber)] || e.Nonce
+		if e.TxHash != nil {
+			txn, err := ethApi.GetTransactionByHash(ctx, *e.TxHash)
+			require.NoError(t, err)
+			require.Equal(t, uint64(e.BlockNumber), txn.BlockNumber.ToInt().Uint64())
+			require.Equal(t, *e.TxIndex, *txn.TransactionIndex)
+		}
+	}
+	latest, err := ethApi.BlockNumber(ctx)
+	require.NoError(t, err)
+	for blockNum := uint64(1); blockNum <= uint64(latest); blockNum++ {
+		prev, cur := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNum-1)), rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNum))
+		balanceBefore, err := ethApi.GetBalance(ctx, sender, prev)
+		require.NoError(t, err)
+		balanceAfter, err := ethApi.GetBalance(ctx, sender, cur)
+		require.NoError(t, err)
+		if balanceBefore.ToInt().Cmp(balanceAfter.ToInt()) != 0 {
+			require.True(t, balanceChanged[blockNum], "block %d", blockNum)
+		}
+		nonceBefore, err := ethApi.GetTransactionCount(ctx, sender, prev)
+		require.NoError(t, err)
+		nonceAfter, err := ethApi.GetTransactionCount(ctx, sender, cur)
+		require.NoError(t, err)
+		require.Equal(t, *nonceBefore != *nonceAfter, nonceChanged[blockNum], "block %d", blockNum)
+	}
+
+	// pages of one entry add up to the whole history
+	var paged []AddressHistoryEntry
+	query := AddressHistoryQuery{PageSize: pageSize(1)}
+	for {
+		page, err := api.GetAddressHistory(ctx, sender, query)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page.Entries), 1)
+		paged = append(paged, page.Entries...)
+		if page.Next == nil {
+			break
+		}
+		query.Cursor = page.Next
+	}
+	require.Equal(t, all.Entries, paged)
+
+	// block range
+	from, to := hexutil.Uint64(2), hexutil.Uint64(3)
+	ranged, err := api.GetAddressHistory(ctx, sender, AddressHistoryQuery{FromBlock: &from, ToBlock: &to, PageSize: pageSize(maxAddressHistoryPageSize)})
+	require.NoError(t, err)
+	var expected []AddressHistoryEntry
+	for _, e := range all.Entries {
+		if e.BlockNumber >= from && e.BlockNumber <= to {
+			expected = append(expected, e)
+		}
+	}
+	require.Equal(t, expected, ranged.Entries)
+
+	_, err = api.GetAddressHistory(ctx, sender, AddressHistoryQuery{PageSize: pageSize(maxAddressHistoryPageSize + 1)})
+	require.ErrorContains(t, err, "pageSize")
+}
+
+func pageSize(n uint64) *hexutil.Uint64 {
+	size := hexutil.Uint64(n)
+	return &size
+}
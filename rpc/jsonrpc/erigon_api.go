@@ -27,6 +27,8 @@ import (
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/shards"
+	"github.com/erigontech/erigon/txnprovider/txpool"
 )
 
 // ErigonAPI Erigon specific routines
@@ -34,6 +36,7 @@ type ErigonAPI interface {
 	// System related (see ./erigon_system.go)
 	Forks(ctx context.Context) (Forks, error)
 	BlockNumber(ctx context.Context, rpcBlockNumPtr *rpc.BlockNumber) (hexutil.Uint64, error)
+	ForkSchedule(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash, timestamp *hexutil.Uint64) (*ForkSchedule, error) // see ./erigon_fork_schedule.go
 
 	// Blocks related (see ./erigon_blocks.go)
 	GetHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error)
@@ -41,6 +44,13 @@ type ErigonAPI interface {
 	GetBlockByTimestamp(ctx context.Context, timeStamp rpc.Timestamp, fullTx bool) (map[string]interface{}, error)
 	GetBalanceChangesInBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (map[common.Address]*hexutil.Big, error)
 
+	// Accounts related (see ./erigon_accounts.go)
+	GetAccounts(ctx context.Context, addresses []common.Address, blockNrOrHash rpc.BlockNumberOrHash) ([]AccountSummary, error)
+	GetAddressHistory(ctx context.Context, address common.Address, query AddressHistoryQuery) (*AddressHistoryPage, error) // see ./erigon_address_history.go
+
+	// Withdrawals related (see ./erigon_withdrawals.go)
+	GetSystemWithdrawals(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*SystemWithdrawalsAccounting, error)
+
 	// Receipt related (see ./erigon_receipts.go)
 	GetLogsByHash(ctx context.Context, hash common.Hash) ([][]*types.Log, error)
 	//GetLogsByNumber(ctx context.Context, number rpc.BlockNumber) ([][]*types.Log, error)
@@ -51,20 +61,36 @@ type ErigonAPI interface {
 
 	// NodeInfo returns a collection of metadata known about the host.
 	NodeInfo(ctx context.Context) ([]p2p.NodeInfo, error)
+
+	// Blob fee market (see ./erigon_blob_fee.go)
+	BlobFeeHistory(ctx context.Context, blockCount rpc.DecimalOrHex, newestBlock rpc.BlockNumber) ([]BlobFeeHistoryEntry, error)
+
+	// Txpool related (see ./erigon_fee_market.go)
+	FeeMarketHistory(ctx context.Context, fromTime hexutil.Uint64, limit *hexutil.Uint64) ([]txpool.FeeMarketSnapshot, error)
+
+	// State growth analytics (see ./erigon_state_growth.go)
+	StateGrowth(ctx context.Context, topN *hexutil.Uint64) (*shards.StateGrowthReport, error)
+	BlockStateGrowth(ctx context.Context, blockNr rpc.BlockNumber) (*shards.BlockStateGrowth, error)
+
+	// Per-transaction resource usage (see ./erigon_tx_metrics.go)
+	GetTransactionMetrics(ctx context.Context, txnHash common.Hash) (*TransactionMetrics, error)
 }
 
 // ErigonImpl is implementation of the ErigonAPI interface
 type ErigonImpl struct {
 	*BaseAPI
-	db         kv.TemporalRoDB
-	ethBackend rpchelper.ApiBackend
+	db          kv.TemporalRoDB
+	ethBackend  rpchelper.ApiBackend
+	feeMarket   txpool.FeeMarketHistoryReader // nil if txpool is not in-process
+	stateGrowth *shards.StateGrowthTracker    // nil if disabled or rpcdaemon is not embedded into erigon
 }
 
 // NewErigonAPI returns ErigonImpl instance
-func NewErigonAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend) *ErigonImpl {
+func NewErigonAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend, feeMarket txpool.FeeMarketHistoryReader) *ErigonImpl {
 	return &ErigonImpl{
 		BaseAPI:    base,
 		db:         db,
 		ethBackend: eth,
+		feeMarket:  feeMarket,
 	}
 }
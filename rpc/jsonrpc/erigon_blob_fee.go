@@ -0,0 +1,117 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/execution/consensus/misc"
+	"github.com/erigontech/erigon/rpc"
+)
+
+const blobFeeHistoryMaxBlocks = 1024
+
+// BlobFeeHistoryEntry - blob fee market of one block, computed from its header
+type BlobFeeHistoryEntry struct {
+	BlockNumber       hexutil.Uint64 `json:"blockNumber"`
+	Timestamp         hexutil.Uint64 `json:"timestamp"`
+	BlobGasUsed       hexutil.Uint64 `json:"blobGasUsed"`
+	ExcessBlobGas     hexutil.Uint64 `json:"excessBlobGas"`
+	BlobBaseFee       *hexutil.Big   `json:"blobBaseFee"`
+	BlobCount         hexutil.Uint64 `json:"blobCount"`
+	TargetBlobCount   hexutil.Uint64 `json:"targetBlobCount"`
+	MaxBlobCount      hexutil.Uint64 `json:"maxBlobCount"`
+	TargetUtilization float64        `json:"targetUtilization"` // blobCount / targetBlobCount
+}
+
+// BlobFeeHistory returns the blob fee market of `blockCount` blocks up to `newestBlock`. Blocks before Cancun are skipped.
+func (api *ErigonImpl) BlobFeeHistory(ctx context.Context, blockCount rpc.DecimalOrHex, newestBlock rpc.BlockNumber) ([]BlobFeeHistoryEntry, error) {
+	if blockCount == 0 || blockCount > blobFeeHistoryMaxBlocks {
+		return nil, fmt.Errorf("blockCount must be in range [1, %d]", blobFeeHistoryMaxBlocks)
+	}
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	newest, err := api.headerByRPCNumber(ctx, newestBlock, tx)
+	if err != nil {
+		return nil, err
+	}
+	if newest == nil {
+		return nil, fmt.Errorf("block %d not found", newestBlock)
+	}
+	to := newest.Number.Uint64()
+	from := to + 1 - min(uint64(blockCount), to+1)
+
+	res := make([]BlobFeeHistoryEntry, 0, to+1-from)
+	for n := from; n <= to; n++ {
+		header, err := api._blockReader.HeaderByNumber(ctx, tx, n)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block %d not found", n)
+		}
+		if header.ExcessBlobGas == nil {
+			continue
+		}
+		entry, err := blobFeeHistoryEntry(chainConfig, header)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, entry)
+	}
+	return res, nil
+}
+
+func blobFeeHistoryEntry(chainConfig *chain.Config, header *types.Header) (BlobFeeHistoryEntry, error) {
+	var blobGasUsed uint64
+	if header.BlobGasUsed != nil {
+		blobGasUsed = *header.BlobGasUsed
+	}
+	blobBaseFee, err := misc.GetBlobGasPrice(chainConfig, *header.ExcessBlobGas, header.Time)
+	if err != nil {
+		return BlobFeeHistoryEntry{}, err
+	}
+	blobCount := blobGasUsed / params.GasPerBlob
+	target := chainConfig.GetTargetBlobsPerBlock(header.Time)
+	entry := BlobFeeHistoryEntry{
+		BlockNumber:     hexutil.Uint64(header.Number.Uint64()),
+		Timestamp:       hexutil.Uint64(header.Time),
+		BlobGasUsed:     hexutil.Uint64(blobGasUsed),
+		ExcessBlobGas:   hexutil.Uint64(*header.ExcessBlobGas),
+		BlobBaseFee:     (*hexutil.Big)(blobBaseFee.ToBig()),
+		BlobCount:       hexutil.Uint64(blobCount),
+		TargetBlobCount: hexutil.Uint64(target),
+		MaxBlobCount:    hexutil.Uint64(chainConfig.GetMaxBlobsPerBlock(header.Time)),
+	}
+	if target > 0 {
+		entry.TargetUtilization = float64(blobCount) / float64(target)
+	}
+	return entry, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestBlobFeeHistoryEntry(t *testing.T) {
+	t.Parallel()
+	blobGasUsed, excessBlobGas := 3*params.GasPerBlob, uint64(0)
+	header := &types.Header{Number: big.NewInt(10), Time: 100, BlobGasUsed: &blobGasUsed, ExcessBlobGas: &excessBlobGas}
+	entry, err := blobFeeHistoryEntry(chain.AllProtocolChanges, header)
+	require.NoError(t, err)
+	target := chain.AllProtocolChanges.GetTargetBlobsPerBlock(100)
+	require.Equal(t, uint64(3), uint64(entry.BlobCount))
+	require.Equal(t, target, uint64(entry.TargetBlobCount))
+	require.Equal(t, float64(3)/float64(target), entry.TargetUtilization)
+	require.Equal(t, big.NewInt(int64(chain.AllProtocolChanges.GetMinBlobGasPrice())), entry.BlobBaseFee.ToInt())
+
+	excessBlobGas = 100 * params.GasPerBlob
+	entry, err = blobFeeHistoryEntry(chain.AllProtocolChanges, header)
+	require.NoError(t, err)
+	require.Equal(t, 1, entry.BlobBaseFee.ToInt().Cmp(big.NewInt(int64(chain.AllProtocolChanges.GetMinBlobGasPrice()))))
+}
+
+func TestBlobFeeHistory_PreCancun(t *testing.T) {
+	t.Parallel()
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
+	res, err := api.BlobFeeHistory(context.Background(), 100, rpc.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	_, err = api.BlobFeeHistory(context.Background(), blobFeeHistoryMaxBlocks+1, rpc.LatestBlockNumber)
+	require.ErrorContains(t, err, "blockCount")
+}
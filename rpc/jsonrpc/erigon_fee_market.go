@@ -0,0 +1,50 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/txnprovider/txpool"
+)
+
+const feeMarketHistoryMaxLimit = 10_000
+
+var errFeeMarketHistoryUnavailable = errors.New("fee market history is available only in rpcdaemon embedded into erigon with internal txpool")
+
+// FeeMarketHistory returns persisted summaries of the pending txpool (fee percentiles, counts by type, blobs)
+// starting from the given unix timestamp.
+func (api *ErigonImpl) FeeMarketHistory(ctx context.Context, fromTime hexutil.Uint64, limit *hexutil.Uint64) ([]txpool.FeeMarketSnapshot, error) {
+	if api.feeMarket == nil {
+		return nil, errFeeMarketHistoryUnavailable
+	}
+
+	n := feeMarketHistoryMaxLimit
+	if limit != nil && *limit > 0 && uint64(*limit) < feeMarketHistoryMaxLimit {
+		n = int(*limit)
+	}
+	res, err := api.feeMarket.FeeMarketHistory(ctx, uint64(fromTime), n)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		res = []txpool.FeeMarketSnapshot{}
+	}
+	return res, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// ScheduledFork is a fork of the chain config, activated at Block or Timestamp
+type ScheduledFork struct {
+	Name      string          `json:"name"`
+	Block     *hexutil.Uint64 `json:"block,omitempty"`
+	Timestamp *hexutil.Uint64 `json:"timestamp,omitempty"`
+	EIPs      []int           `json:"eips"`
+	// BlobSchedule - blob parameters from the fork on, nil before Cancun
+	BlobSchedule *params.BlobConfig `json:"blobSchedule,omitempty"`
+	Active       bool               `json:"active"`
+}
+
+// ForkSchedule is the response of erigon_forkSchedule
+type ForkSchedule struct {
+	ChainId   hexutil.Uint64  `json:"chainId"`
+	Block     hexutil.Uint64  `json:"block"`
+	Timestamp hexutil.Uint64  `json:"timestamp"`
+	Forks     []ScheduledFork `json:"forks"`
+	Current   string          `json:"current"`        // last active fork, empty before the first one
+	Next      string          `json:"next,omitempty"` // first inactive fork
+	// BlobSchedule - blob parameters active at Timestamp, nil before Cancun
+	BlobSchedule *params.BlobConfig `json:"blobSchedule"`
+	ActiveEIPs   []int              `json:"activeEips"`
+}
+
+// ForkSchedule implements erigon_forkSchedule. Returns all forks of the chain config with the ones active
+// at the block (latest by default) marked, the blob schedule and the EIPs of the execution layer active at it.
+// The optional timestamp replaces the time of the block, e.g. to look at upcoming forks.
+func (api *ErigonImpl) ForkSchedule(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash, timestamp *hexutil.Uint64) (*ForkSchedule, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	config, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	blockNum, hash, _, err := rpchelper.GetBlockNumber(ctx, *blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	header, err := api._blockReader.Header(ctx, tx, hash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %d not found", blockNum)
+	}
+	blockTime := header.Time
+	if timestamp != nil {
+		blockTime = uint64(*timestamp)
+	}
+	return forkSchedule(config, blockNum, blockTime), nil
+}
+
+func forkSchedule(config *chain.Config, blockNum, blockTime uint64) *ForkSchedule {
+	res := &ForkSchedule{
+		Block:      hexutil.Uint64(blockNum),
+		Timestamp:  hexutil.Uint64(blockTime),
+		Forks:      []ScheduledFork{},
+		ActiveEIPs: config.ActiveEIPs(blockNum, blockTime),
+	}
+	if config.ChainID != nil {
+		res.ChainId = hexutil.Uint64(config.ChainID.Uint64())
+	}
+	for _, f := range config.Forks() {
+		fork := ScheduledFork{Name: f.Name, EIPs: f.EIPs, Active: f.Active(blockNum, blockTime)}
+		if fork.EIPs == nil {
+			fork.EIPs = []int{}
+		}
+		if f.Block != nil {
+			n := hexutil.Uint64(f.Block.Uint64())
+			fork.Block = &n
+		}
+		if f.Time != nil {
+			t := hexutil.Uint64(f.Time.Uint64())
+			fork.Timestamp = &t
+			if config.IsCancun(f.Time.Uint64()) {
+				fork.BlobSchedule = config.GetBlobConfig(f.Time.Uint64())
+			}
+		}
+		if fork.Active {
+			res.Current = fork.Name
+		} else if res.Next == "" {
+			res.Next = fork.Name
+		}
+		res.Forks = append(res.Forks, fork)
+	}
+	if config.IsCancun(blockTime) {
+		res.BlobSchedule = config.GetBlobConfig(blockTime)
+	}
+	return res
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/execution/chainspec"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestForkScheduleMainnet(t *testing.T) {
+	config := chainspec.MainnetChainConfig
+	cancun := config.CancunTime.Uint64()
+
+	s := forkSchedule(config, 20_000_000, cancun)
+	require.Equal(t, hexutil.Uint64(1), s.ChainId)
+	require.Equal(t, "cancun", s.Current)
+	require.Equal(t, "prague", s.Next)
+	require.Equal(t, params.DefaultCancunBlobConfig, *s.BlobSchedule)
+	require.Contains(t, s.ActiveEIPs, 4844)
+	require.NotContains(t, s.ActiveEIPs, 7702)
+	require.NotContains(t, s.ActiveEIPs, 1283) // reverted by Petersburg
+
+	byName := map[string]ScheduledFork{}
+	for _, f := range s.Forks {
+		byName[f.Name] = f
+	}
+	require.True(t, byName["london"].Active)
+	require.Equal(t, hexutil.Uint64(12_965_000), *byName["london"].Block)
+	require.True(t, byName["paris"].Active)
+	require.False(t, byName["prague"].Active)
+	require.Equal(t, hexutil.Uint64(config.PragueTime.Uint64()), *byName["prague"].Timestamp)
+	require.Equal(t, params.DefaultPragueBlobConfig, *byName["prague"].BlobSchedule)
+	require.Nil(t, byName["shanghai"].BlobSchedule)
+
+	s = forkSchedule(config, 1_200_000, 0)
+	require.Equal(t, "homestead", s.Current)
+	require.Equal(t, "dao", s.Next)
+	require.Nil(t, s.BlobSchedule)
+}
+
+func TestForkSchedule(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
+	ctx := context.Background()
+
+	s, err := api.ForkSchedule(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(m.ChainConfig.ChainID.Uint64()), s.ChainId)
+	require.NotEmpty(t, s.Forks)
+
+	genesis := rpc.BlockNumberOrHashWithNumber(0)
+	timestamp := hexutil.Uint64(1)
+	s, err = api.ForkSchedule(ctx, &genesis, &timestamp)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(0), s.Block)
+	require.Equal(t, timestamp, s.Timestamp)
+}
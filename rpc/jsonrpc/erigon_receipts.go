@@ -101,18 +101,16 @@ func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria)
 
 		begin = 0
 		if crit.FromBlock != nil {
-			if crit.FromBlock.Sign() >= 0 {
-				begin = crit.FromBlock.Uint64()
-			} else if !crit.FromBlock.IsInt64() || crit.FromBlock.Int64() != int64(rpc.LatestBlockNumber) {
-				return nil, fmt.Errorf("negative value for FromBlock: %v", crit.FromBlock)
+			if crit.FromBlock.IsInt64() && crit.FromBlock.Int64() == int64(rpc.LatestBlockNumber) {
+				begin = 0 // `latest` lower bound has always meant "from genesis" here
+			} else if begin, err = rpchelper.GetRangeBlockNumber(ctx, crit.FromBlock, tx, api._blockReader, api.filters); err != nil {
+				return nil, err
 			}
 		}
 		end = latest
 		if crit.ToBlock != nil {
-			if crit.ToBlock.Sign() >= 0 {
-				end = crit.ToBlock.Uint64()
-			} else if !crit.ToBlock.IsInt64() || crit.ToBlock.Int64() != int64(rpc.LatestBlockNumber) {
-				return nil, fmt.Errorf("negative value for ToBlock: %v", crit.ToBlock)
+			if end, err = rpchelper.GetRangeBlockNumber(ctx, crit.ToBlock, tx, api._blockReader, api.filters); err != nil {
+				return nil, err
 			}
 		}
 	}
@@ -187,18 +185,16 @@ func (api *ErigonImpl) GetLatestLogs(ctx context.Context, crit filters.FilterCri
 
 		begin = 0
 		if crit.FromBlock != nil {
-			if crit.FromBlock.Sign() >= 0 {
-				begin = crit.FromBlock.Uint64()
-			} else if !crit.FromBlock.IsInt64() || crit.FromBlock.Int64() != int64(rpc.LatestBlockNumber) {
-				return nil, fmt.Errorf("negative value for FromBlock: %v", crit.FromBlock)
+			if crit.FromBlock.IsInt64() && crit.FromBlock.Int64() == int64(rpc.LatestBlockNumber) {
+				begin = 0 // `latest` lower bound has always meant "from genesis" here
+			} else if begin, err = rpchelper.GetRangeBlockNumber(ctx, crit.FromBlock, tx, api._blockReader, api.filters); err != nil {
+				return nil, err
 			}
 		}
 		end = latest
 		if crit.ToBlock != nil {
-			if crit.ToBlock.Sign() >= 0 {
-				end = crit.ToBlock.Uint64()
-			} else if !crit.ToBlock.IsInt64() || crit.ToBlock.Int64() != int64(rpc.LatestBlockNumber) {
-				return nil, fmt.Errorf("negative value for ToBlock: %v", crit.ToBlock)
+			if end, err = rpchelper.GetRangeBlockNumber(ctx, crit.ToBlock, tx, api._blockReader, api.filters); err != nil {
+				return nil, err
 			}
 		}
 	}
@@ -72,11 +72,58 @@ func TestGetLogs(t *testing.T) {
 	}
 }
 
+func TestGetLogs_SafeAndFinalizedTags(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	ctx := context.Background()
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	erigonApi := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
+
+	// no fork-choice state yet
+	_, err := ethApi.GetLogs(ctx, filters.FilterCriteria{FromBlock: big.NewInt(rpc.SafeBlockNumber.Int64())})
+	require.Error(t, err)
+
+	tx, err := m.DB.BeginRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	finalizedHash, ok, err := m.BlockReader.CanonicalHash(ctx, tx, 5)
+	require.NoError(t, err)
+	require.True(t, ok)
+	rawdb.WriteForkchoiceFinalized(tx, finalizedHash)
+	rawdb.WriteForkchoiceSafe(tx, finalizedHash)
+	require.NoError(t, tx.Commit())
+
+	all, err := ethApi.GetLogs(ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())})
+	require.NoError(t, err)
+	var expectedTillFinalized, expectedFromSafe int
+	for _, l := range all {
+		if l.BlockNumber <= 5 {
+			expectedTillFinalized++
+		}
+		if l.BlockNumber >= 5 {
+			expectedFromSafe++
+		}
+	}
+
+	logs, err := ethApi.GetLogs(ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.FinalizedBlockNumber.Int64())})
+	require.NoError(t, err)
+	require.Len(t, logs, expectedTillFinalized)
+
+	logs, err = ethApi.GetLogs(ctx, filters.FilterCriteria{FromBlock: big.NewInt(rpc.SafeBlockNumber.Int64())})
+	require.NoError(t, err)
+	require.Len(t, logs, expectedFromSafe)
+
+	erigonLogs, err := erigonApi.GetLogs(ctx, filters.FilterCriteria{FromBlock: big.NewInt(rpc.SafeBlockNumber.Int64()), ToBlock: big.NewInt(rpc.FinalizedBlockNumber.Int64())})
+	require.NoError(t, err)
+	for _, l := range erigonLogs {
+		require.Equal(t, uint64(5), l.BlockNumber)
+	}
+}
+
 func TestErigonGetLatestLogs(t *testing.T) {
 	assert := assert.New(t)
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	db := m.DB
-	api := NewErigonAPI(newBaseApiForTest(m), db, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), db, nil, nil)
 	expectedLogs, _ := api.GetLogs(m.Ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())})
 
 	expectedErigonLogs := make(types.ErigonLogs, 0)
@@ -122,7 +169,7 @@ func TestErigonGetLatestLogsIgnoreTopics(t *testing.T) {
 	assert := assert.New(t)
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	db := m.DB
-	api := NewErigonAPI(newBaseApiForTest(m), db, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), db, nil, nil)
 	expectedLogs, _ := api.GetLogs(m.Ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())})
 
 	expectedErigonLogs := make([]*types.ErigonLog, 0)
@@ -209,7 +256,7 @@ func TestGetBlockReceiptsByBlockHash(t *testing.T) {
 	}
 	// Assemble the test environment
 	m := mockWithGenerator(t, 4, generator)
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	expect := map[uint64]string{
 		0: `[]`,
@@ -0,0 +1,73 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/shards"
+)
+
+const (
+	stateGrowthDefaultTopN = 20
+	stateGrowthMaxTopN     = 1_000
+)
+
+var errStateGrowthUnavailable = errors.New("state growth analytics are available only in rpcdaemon embedded into erigon with --sync.state-growth.window")
+
+// StateGrowth returns state growth (new/deleted accounts and storage slots, deployed code bytes) of blocks executed
+// since the node start and of the recent blocks, with the `topN` contracts which got most storage slots recently.
+func (api *ErigonImpl) StateGrowth(ctx context.Context, topN *hexutil.Uint64) (*shards.StateGrowthReport, error) {
+	if api.stateGrowth == nil {
+		return nil, errStateGrowthUnavailable
+	}
+	n := stateGrowthDefaultTopN
+	if topN != nil {
+		n = int(min(uint64(*topN), stateGrowthMaxTopN))
+	}
+	report := api.stateGrowth.Report(n)
+	return &report, nil
+}
+
+// BlockStateGrowth returns state growth made by one of the recent blocks
+func (api *ErigonImpl) BlockStateGrowth(ctx context.Context, blockNr rpc.BlockNumber) (*shards.BlockStateGrowth, error) {
+	if api.stateGrowth == nil {
+		return nil, errStateGrowthUnavailable
+	}
+	if blockNr < 0 {
+		tx, err := api.db.BeginTemporalRo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+		blockNum, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(blockNr), tx, api._blockReader, api.filters)
+		if err != nil {
+			return nil, err
+		}
+		blockNr = rpc.BlockNumber(blockNum)
+	}
+	growth, ok := api.stateGrowth.Block(uint64(blockNr))
+	if !ok {
+		return nil, fmt.Errorf("state growth of block %d is not tracked: only recent blocks executed since the node start are", blockNr)
+	}
+	return &growth, nil
+}
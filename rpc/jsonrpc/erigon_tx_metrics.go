@@ -0,0 +1,76 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+)
+
+// TransactionMetrics - resources used by execution of a transaction, collected with --sync.tx-metrics
+type TransactionMetrics struct {
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	ExecutionTimeNs  hexutil.Uint64 `json:"executionTimeNs"`
+	StateReads       hexutil.Uint64 `json:"stateReads"`
+	StateWrites      hexutil.Uint64 `json:"stateWrites"`
+	CallDepth        hexutil.Uint64 `json:"callDepth"`
+}
+
+// GetTransactionMetrics returns execution time, number of state reads and writes, and max EVM call depth of
+// a canonical transaction. nil if the transaction is unknown, or its block was executed without --sync.tx-metrics.
+// The time is measured on this node, so it's comparable only between transactions executed by the same node.
+func (api *ErigonImpl) GetTransactionMetrics(ctx context.Context, txnHash common.Hash) (*TransactionMetrics, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, txNum, ok, err := api.txnLookup(ctx, tx, txnHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	txNumMin, err := api._txNumReader.Min(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if txNumMin+1 > txNum {
+		return nil, fmt.Errorf("uint underflow txnums error txNum: %d, txNumMin: %d, blockNum: %d", txNum, txNumMin, blockNum)
+	}
+	m, err := rawdb.ReadTxMetrics(tx, txNum)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return &TransactionMetrics{
+		BlockNumber:      hexutil.Uint64(blockNum),
+		TransactionIndex: hexutil.Uint64(txNum - txNumMin - 1),
+		TransactionHash:  txnHash,
+		ExecutionTimeNs:  hexutil.Uint64(m.ExecutionTime.Nanoseconds()),
+		StateReads:       hexutil.Uint64(m.StateReads),
+		StateWrites:      hexutil.Uint64(m.StateWrites),
+		CallDepth:        hexutil.Uint64(m.CallDepth),
+	}, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv/prune"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/program"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/execution/consensus/ethash"
+	"github.com/erigontech/erigon/execution/stages/mock"
+)
+
+func TestGetTransactionMetrics(t *testing.T) {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	caller, callee := common.Address{0xca}, common.Address{0xce}
+	gspec := &types.Genesis{
+		Config: chain.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			sender: {Balance: big.NewInt(common.Ether)},
+			// writes slot 0, then calls callee
+			caller: {Balance: common.Big0, Code: program.New().Sstore(0, 1).Call(nil, callee, 0, 0, 0, 0, 0).Op(vm.STOP).Bytes()},
+			// reads its slot 0
+			callee: {Balance: common.Big0, Code: program.New().Push(0).Op(vm.SLOAD, vm.POP, vm.STOP).Bytes()},
+		},
+	}
+	txMetrics := func(cfg *ethconfig.Config) { cfg.Sync.TxMetrics = true }
+	m := mock.MockWithEverything(t, gspec, key, prune.MockMode, ethash.NewFaker(), 128, false, false, true, txMetrics)
+
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	var callTx, transferTx types.Transaction
+	chainPack, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+		callTx = types.MustSignNewTx(key, *signer, &types.LegacyTx{
+			CommonTx: types.CommonTx{Nonce: 0, GasLimit: 100_000, To: &caller, Value: uint256.NewInt(0)},
+			GasPrice: uint256.NewInt(1),
+		})
+		transferTx = types.MustSignNewTx(key, *signer, &types.LegacyTx{
+			CommonTx: types.CommonTx{Nonce: 1, GasLimit: 21_000, To: &common.Address{0xee}, Value: uint256.NewInt(1)},
+			GasPrice: uint256.NewInt(1),
+		})
+		b.AddTx(callTx)
+		b.AddTx(transferTx)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chainPack))
+
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
+	ctx := context.Background()
+
+	res, err := api.GetTransactionMetrics(ctx, callTx.Hash())
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, hexutil.Uint64(1), res.BlockNumber)
+	require.Equal(t, hexutil.Uint64(0), res.TransactionIndex)
+	require.Equal(t, callTx.Hash(), res.TransactionHash)
+	require.Equal(t, hexutil.Uint64(2), res.CallDepth)
+	require.Equal(t, hexutil.Uint64(4), res.StateWrites) // sender, coinbase, caller and its slot 0
+	require.Equal(t, hexutil.Uint64(8), res.StateReads)  // sender, coinbase, caller and callee: their accounts, code of both contracts and their slot 0
+	require.NotZero(t, res.ExecutionTimeNs)
+
+	res, err = api.GetTransactionMetrics(ctx, transferTx.Hash())
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, hexutil.Uint64(1), res.TransactionIndex)
+	require.Equal(t, hexutil.Uint64(1), res.CallDepth)
+	require.Equal(t, hexutil.Uint64(3), res.StateWrites) // sender, coinbase, recipient
+	require.Equal(t, hexutil.Uint64(3), res.StateReads)  // accounts of sender, coinbase and recipient
+
+	res, err = api.GetTransactionMetrics(ctx, common.Hash{1})
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/execution/consensus/aura"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/transactions"
+)
+
+// Events of the Gnosis deposit contract emitted by `executeSystemWithdrawals`
+var (
+	withdrawalExecutedTopic        = crypto.Keccak256Hash([]byte("WithdrawalExecuted(uint256,address)"))
+	withdrawalFailedTopic          = crypto.Keccak256Hash([]byte("WithdrawalFailed(uint256,uint256,address)"))
+	failedWithdrawalProcessedTopic = crypto.Keccak256Hash([]byte("FailedWithdrawalProcessed(uint256,uint256,address)"))
+)
+
+// withdrawal amounts are denominated in gwei of "32 per token" units (mGNO): 1 gwei of amount == 1e9/32 wei of GNO
+var withdrawalTokenDivisor = big.NewInt(32)
+
+type SystemWithdrawal struct {
+	Index       hexutil.Uint64 `json:"index"`
+	Validator   hexutil.Uint64 `json:"validatorIndex"`
+	Address     common.Address `json:"address"`
+	Amount      hexutil.Uint64 `json:"amount"`      // in gwei, as in the block
+	TokenAmount *hexutil.Big   `json:"tokenAmount"` // in wei of withdrawal token
+}
+
+type SystemWithdrawalEvent struct {
+	FailedWithdrawalID *hexutil.Big   `json:"failedWithdrawalId,omitempty"`
+	Address            common.Address `json:"address"`
+	Amount             *hexutil.Big   `json:"amount"`
+}
+
+type SystemWithdrawalsAccounting struct {
+	BlockNumber     hexutil.Uint64          `json:"blockNumber"`
+	BlockHash       common.Hash             `json:"blockHash"`
+	Contract        common.Address          `json:"contract"`
+	Withdrawals     []SystemWithdrawal      `json:"withdrawals"`
+	Executed        []SystemWithdrawalEvent `json:"executed"`
+	Failed          []SystemWithdrawalEvent `json:"failed"`
+	FailedProcessed []SystemWithdrawalEvent `json:"failedProcessed"`
+	Logs            types.Logs              `json:"logs"`
+	Error           string                  `json:"error,omitempty"`
+}
+
+// GetSystemWithdrawals re-executes the `executeSystemWithdrawals` system call of AuRa-based chains (Gnosis, Chiado)
+// on top of the block's post-transactions state with the block rewards applied, as the block finalization does,
+// and reports the withdrawal token conversion results.
+func (api *ErigonImpl) GetSystemWithdrawals(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*SystemWithdrawalsAccounting, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if chainConfig.Aura == nil || chainConfig.Aura.WithdrawalContractAddress == nil {
+		return nil, errors.New("chain has no system withdrawal contract")
+	}
+	contract := *chainConfig.Aura.WithdrawalContractAddress
+
+	blockNumber, hash, _, err := rpchelper.GetCanonicalBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(ctx, tx, hash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", blockNumber)
+	}
+	if err = api.BaseAPI.checkPruneHistory(ctx, tx, blockNumber); err != nil {
+		return nil, err
+	}
+
+	res := &SystemWithdrawalsAccounting{
+		BlockNumber:     hexutil.Uint64(blockNumber),
+		BlockHash:       block.Hash(),
+		Contract:        contract,
+		Withdrawals:     []SystemWithdrawal{},
+		Executed:        []SystemWithdrawalEvent{},
+		Failed:          []SystemWithdrawalEvent{},
+		FailedProcessed: []SystemWithdrawalEvent{},
+		Logs:            types.Logs{},
+	}
+	withdrawals := block.Withdrawals()
+	if withdrawals == nil {
+		return res, nil
+	}
+	res.Withdrawals = systemWithdrawals(withdrawals)
+
+	// state after all block's transactions - right before the system call
+	txIndex := len(block.Transactions())
+	ibs, _, _, _, _, err := transactions.ComputeBlockContext(ctx, api.engine(), block.HeaderNoCopy(), chainConfig, api._blockReader, api._txNumReader, tx, txIndex)
+	if err != nil {
+		return nil, err
+	}
+	ibs.SetTxContext(blockNumber, txIndex)
+	if err := applyBlockRewards(chainConfig, ibs, block.HeaderNoCopy(), block.Uncles(), api.engine()); err != nil {
+		return nil, err
+	}
+	if err := execSystemWithdrawals(res, withdrawals, chainConfig, ibs, block.HeaderNoCopy(), api.engine(), txIndex); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func systemWithdrawals(withdrawals []*types.Withdrawal) []SystemWithdrawal {
+	res := make([]SystemWithdrawal, 0, len(withdrawals))
+	for _, w := range withdrawals {
+		tokenAmount := new(big.Int).Mul(new(big.Int).SetUint64(w.Amount), big.NewInt(common.GWei))
+		tokenAmount.Div(tokenAmount, withdrawalTokenDivisor)
+		res = append(res, SystemWithdrawal{
+			Index:       hexutil.Uint64(w.Index),
+			Validator:   hexutil.Uint64(w.Validator),
+			Address:     w.Address,
+			Amount:      hexutil.Uint64(w.Amount),
+			TokenAmount: (*hexutil.Big)(tokenAmount),
+		})
+	}
+	return res
+}
+
+// applyBlockRewards - adds the block rewards to the balances, as Merge.Finalize does before the system withdrawals.
+// On AuRa the rewards come from the block reward contract, called on top of `ibs`.
+func applyBlockRewards(chainConfig *chain.Config, ibs *state.IntraBlockState, header *types.Header, uncles []*types.Header, engine consensus.EngineReader) error {
+	syscall := func(contract common.Address, data []byte) ([]byte, error) {
+		return core.SysCallContract(contract, data, chainConfig, ibs, header, engine, false /* constCall */, nil, vm.Config{})
+	}
+	rewards, err := engine.CalculateRewards(chainConfig, header, uncles, syscall)
+	if err != nil {
+		return err
+	}
+	for _, r := range rewards {
+		switch r.Kind {
+		case consensus.RewardAuthor:
+			ibs.AddBalance(r.Beneficiary, r.Amount, tracing.BalanceIncreaseRewardMineBlock)
+		case consensus.RewardUncle:
+			ibs.AddBalance(r.Beneficiary, r.Amount, tracing.BalanceIncreaseRewardMineUncle)
+		default:
+			ibs.AddBalance(r.Beneficiary, r.Amount, tracing.BalanceChangeUnspecified)
+		}
+	}
+	return nil
+}
+
+// execSystemWithdrawals - runs `executeSystemWithdrawals` system call on top of `ibs` and collects the contract's events.
+// Failure of the call itself is reported in `res.Error`; its events are reverted with it, so none are returned then.
+func execSystemWithdrawals(res *SystemWithdrawalsAccounting, withdrawals []*types.Withdrawal, chainConfig *chain.Config, ibs *state.IntraBlockState, header *types.Header, engine consensus.EngineReader, txIndex int) error {
+	packed, err := aura.PackSystemWithdrawals(withdrawals)
+	if err != nil {
+		return err
+	}
+	logsBefore := len(ibs.GetLogs(txIndex, common.Hash{}, uint64(res.BlockNumber), res.BlockHash)) // of the block rewards
+	if _, err = core.SysCallContract(res.Contract, packed, chainConfig, ibs, header, engine, false /* constCall */, nil, vm.Config{}); err != nil {
+		res.Error = err.Error()
+	}
+
+	res.Logs = ibs.GetLogs(txIndex, common.Hash{}, uint64(res.BlockNumber), res.BlockHash)[logsBefore:]
+	for _, l := range res.Logs {
+		if l.Address != res.Contract || len(l.Topics) == 0 {
+			continue
+		}
+		switch l.Topics[0] {
+		case withdrawalExecutedTopic:
+			if len(l.Topics) < 2 || len(l.Data) < 32 {
+				continue
+			}
+			res.Executed = append(res.Executed, SystemWithdrawalEvent{
+				Address: common.BytesToAddress(l.Topics[1][:]),
+				Amount:  (*hexutil.Big)(new(big.Int).SetBytes(l.Data[:32])),
+			})
+		case withdrawalFailedTopic, failedWithdrawalProcessedTopic:
+			if len(l.Topics) < 3 || len(l.Data) < 32 {
+				continue
+			}
+			ev := SystemWithdrawalEvent{
+				FailedWithdrawalID: (*hexutil.Big)(new(big.Int).SetBytes(l.Topics[1][:])),
+				Address:            common.BytesToAddress(l.Topics[2][:]),
+				Amount:             (*hexutil.Big)(new(big.Int).SetBytes(l.Data[:32])),
+			}
+			if l.Topics[0] == withdrawalFailedTopic {
+				res.Failed = append(res.Failed, ev)
+			} else {
+				res.FailedProcessed = append(res.FailedProcessed, ev)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/program"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/execution/consensus/ethash"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// withdrawalContractStub - emits one event of each kind, optionally reverts afterwards
+func withdrawalContractStub(revert bool) []byte {
+	p := program.New().Push(64).Push(0).Op(vm.MSTORE) // amount: 64 wei
+	p.Push(common.Address{0xa1}).Push(withdrawalExecutedTopic).Push(32).Push(0).Op(vm.LOG2)
+	p.Push(common.Address{0xa2}).Push(7).Push(withdrawalFailedTopic).Push(32).Push(0).Op(vm.LOG3)
+	p.Push(common.Address{0xa3}).Push(5).Push(failedWithdrawalProcessedTopic).Push(32).Push(0).Op(vm.LOG3)
+	if revert {
+		p.Push(0).Push(0).Op(vm.REVERT)
+	} else {
+		p.Op(vm.STOP)
+	}
+	return p.Bytes()
+}
+
+func TestSystemWithdrawals_TokenAmount(t *testing.T) {
+	t.Parallel()
+	res := systemWithdrawals([]*types.Withdrawal{
+		{Index: 1, Validator: 2, Address: common.Address{0xa1}, Amount: 32},
+		{Index: 2, Validator: 3, Address: common.Address{0xa2}, Amount: 1},
+	})
+	require.Len(t, res, 2)
+	require.Equal(t, big.NewInt(1_000_000_000), res[0].TokenAmount.ToInt()) // 32 gwei of amount == 1 token gwei
+	require.Equal(t, big.NewInt(31_250_000), res[1].TokenAmount.ToInt())
+	require.Equal(t, hexutil.Uint64(3), res[1].Validator)
+}
+
+func TestExecSystemWithdrawals(t *testing.T) {
+	t.Parallel()
+	contract := common.Address{0xc0}
+	withdrawals := []*types.Withdrawal{{Index: 1, Validator: 2, Address: common.Address{0xa1}, Amount: 32}}
+	header := &types.Header{Number: big.NewInt(10), GasLimit: 30_000_000, Difficulty: big.NewInt(0), BaseFee: big.NewInt(0)}
+
+	run := func(revert bool) *SystemWithdrawalsAccounting {
+		ibs := state.New(state.NewNoopReader())
+		require.NoError(t, ibs.SetCode(contract, withdrawalContractStub(revert)))
+		ibs.SetTxContext(10, 0)
+		res := &SystemWithdrawalsAccounting{BlockNumber: 10, Contract: contract}
+		require.NoError(t, execSystemWithdrawals(res, withdrawals, chain.AllProtocolChanges, ibs, header, nil, 0))
+		return res
+	}
+
+	res := run(false)
+	require.Empty(t, res.Error)
+	require.Len(t, res.Logs, 3)
+	require.Equal(t, []SystemWithdrawalEvent{{Address: common.Address{0xa1}, Amount: (*hexutil.Big)(big.NewInt(64))}}, res.Executed)
+	require.Equal(t, []SystemWithdrawalEvent{{FailedWithdrawalID: (*hexutil.Big)(big.NewInt(7)), Address: common.Address{0xa2}, Amount: (*hexutil.Big)(big.NewInt(64))}}, res.Failed)
+	require.Equal(t, []SystemWithdrawalEvent{{FailedWithdrawalID: (*hexutil.Big)(big.NewInt(5)), Address: common.Address{0xa3}, Amount: (*hexutil.Big)(big.NewInt(64))}}, res.FailedProcessed)
+
+	// reverted call: error is reported, events are rolled back
+	res = run(true)
+	require.NotEmpty(t, res.Error)
+	require.Empty(t, res.Logs)
+	require.Empty(t, res.Executed)
+}
+
+// rewardsEngine - block rewards computed by a reward contract, as on AuRa. EVM hooks are the ones of ethash.
+type rewardsEngine struct {
+	consensus.EngineReader
+	contract common.Address
+}
+
+func (e rewardsEngine) CalculateRewards(_ *chain.Config, header *types.Header, _ []*types.Header, syscall consensus.SystemCall) ([]consensus.Reward, error) {
+	if _, err := syscall(e.contract, nil); err != nil {
+		return nil, err
+	}
+	return []consensus.Reward{{Beneficiary: header.Coinbase, Kind: consensus.RewardExternal, Amount: *uint256.NewInt(7)}}, nil
+}
+
+func TestApplyBlockRewards(t *testing.T) {
+	t.Parallel()
+	rewardContract, withdrawalContract := common.Address{0xbb}, common.Address{0xc0}
+	header := &types.Header{Number: big.NewInt(10), Coinbase: common.Address{0xcb}, GasLimit: 30_000_000, Difficulty: big.NewInt(0), BaseFee: big.NewInt(0)}
+
+	ibs := state.New(state.NewNoopReader())
+	// the reward contract emits a log and updates its slot 0
+	require.NoError(t, ibs.SetCode(rewardContract, program.New().Push(0).Push(0).Op(vm.LOG0).Sstore(0, 1).Op(vm.STOP).Bytes()))
+	require.NoError(t, ibs.SetCode(withdrawalContract, withdrawalContractStub(false)))
+	ibs.SetTxContext(10, 0)
+
+	require.NoError(t, applyBlockRewards(chain.AllProtocolChanges, ibs, header, nil, rewardsEngine{EngineReader: ethash.NewFaker(), contract: rewardContract}))
+	balance, err := ibs.GetBalance(header.Coinbase)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), balance.Uint64())
+	var slot uint256.Int
+	require.NoError(t, ibs.GetState(rewardContract, common.Hash{}, &slot))
+	require.Equal(t, uint64(1), slot.Uint64())
+
+	// the logs of the reward contract aren't reported with the ones of the withdrawals
+	res := &SystemWithdrawalsAccounting{BlockNumber: 10, Contract: withdrawalContract}
+	withdrawals := []*types.Withdrawal{{Index: 1, Validator: 2, Address: common.Address{0xa1}, Amount: 32}}
+	require.NoError(t, execSystemWithdrawals(res, withdrawals, chain.AllProtocolChanges, ibs, header, nil, 0))
+	require.Empty(t, res.Error)
+	require.Len(t, res.Logs, 3)
+	for _, l := range res.Logs {
+		require.Equal(t, withdrawalContract, l.Address)
+	}
+}
+
+func TestGetSystemWithdrawals_NotAuRa(t *testing.T) {
+	t.Parallel()
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
+	_, err := api.GetSystemWithdrawals(context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.ErrorContains(t, err, "no system withdrawal contract")
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"path/filepath"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -88,7 +89,7 @@ type EthAPI interface {
 	UninstallFilter(_ context.Context, index string) (bool, error)
 	GetFilterChanges(_ context.Context, index string) ([]any, error)
 	GetFilterLogs(_ context.Context, index string) ([]*types.Log, error)
-	Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error)
+	Logs(ctx context.Context, crit filters.FilterCriteria, opts *filters.LogSubscriptionOptions) (*rpc.Subscription, error)
 
 	// Account related (see ./eth_accounts.go)
 	Accounts(ctx context.Context) ([]common.Address, error)
@@ -103,11 +104,12 @@ type EthAPI interface {
 	ChainId(ctx context.Context) (hexutil.Uint64, error) /* called eth_protocolVersion elsewhere */
 	ProtocolVersion(_ context.Context) (hexutil.Uint, error)
 	GasPrice(_ context.Context) (*hexutil.Big, error)
-	Config(_ context.Context) (*EthConfigResp, error)
+	Config(_ context.Context, timestamp *hexutil.Uint64) (*EthConfigResp, error)
 
 	// Sending related (see ./eth_call.go)
 	Call(ctx context.Context, args ethapi.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi.StateOverrides) (hexutil.Bytes, error)
-	EstimateGas(ctx context.Context, argsOrNil *ethapi.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi.StateOverrides) (hexutil.Uint64, error)
+	EstimateGas(ctx context.Context, argsOrNil *ethapi.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi.StateOverrides, blockOverrides *ethapi.BlockOverrides) (hexutil.Uint64, error)
+	EstimateGasWithBounds(ctx context.Context, argsOrNil *ethapi.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi.StateOverrides, blockOverrides *ethapi.BlockOverrides) (*GasEstimation, error)
 	SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error)
 	SendTransaction(_ context.Context, txObject interface{}) (common.Hash, error)
 	Sign(ctx context.Context, _ common.Address, _ hexutil.Bytes) (hexutil.Bytes, error)
@@ -143,7 +145,11 @@ type BaseAPI struct {
 	bridgeReader    bridgeReader
 
 	evmCallTimeout      time.Duration
+	evmMaxMemory        uint64                 // bytes of memory of a call frame of simulation endpoints, 0 - unlimited
+	nonCanonicalTxs     bool                   // eth_getTransactionByHash reports transactions of unwound blocks as an error instead of null
+	opcodeOverrides     []chain.OpcodeOverride // of --evm.opcode-overrides, applied to the chain config read from the db
 	dirs                datadir.Dirs
+	logsCursors         *rpchelper.LogsCursorStore
 	receiptsGenerator   *receipts.Generator
 	borReceiptGenerator *receipts.BorGenerator
 }
@@ -161,6 +167,11 @@ func NewBaseApi(f *rpchelper.Filters, stateCache kvcache.Cache, blockReader serv
 		panic(err)
 	}
 
+	var logsCursors *rpchelper.LogsCursorStore
+	if dirs.DataDir != "" {
+		logsCursors = rpchelper.NewLogsCursorStore(filepath.Join(dirs.DataDir, "rpc", "logs_cursors"))
+	}
+
 	return &BaseAPI{
 		filters:             f,
 		stateCache:          stateCache,
@@ -173,6 +184,7 @@ func NewBaseApi(f *rpchelper.Filters, stateCache kvcache.Cache, blockReader serv
 		receiptsGenerator:   receipts.NewGenerator(blockReader, engine),
 		borReceiptGenerator: receipts.NewBorGenerator(blockReader, engine),
 		dirs:                dirs,
+		logsCursors:         logsCursors,
 		useBridgeReader:     bridgeReader != nil && !reflect.ValueOf(bridgeReader).IsNil(), // needed for interface nil caveat
 		bridgeReader:        bridgeReader,
 	}
@@ -290,6 +302,7 @@ func (api *BaseAPI) chainConfigWithGenesis(ctx context.Context, tx kv.Tx) (*chai
 		return nil, nil, err
 	}
 	if cc != nil {
+		cc.OpcodeOverrides = api.opcodeOverrides
 		api._genesis.Store(genesisBlock)
 		api._chainConfig.Store(cc)
 	}
@@ -442,7 +455,7 @@ func NewEthAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend, txPo
 		logger.Info("starting rpc with polygon bridge")
 	}
 
-	return &APIImpl{
+	api := &APIImpl{
 		BaseAPI:                     base,
 		db:                          db,
 		ethBackend:                  eth,
@@ -457,6 +470,10 @@ func NewEthAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend, txPo
 		SubscribeLogsChannelSize:    subscribeLogsChannelSize,
 		logger:                      logger,
 	}
+	if base.filters != nil {
+		base.filters.SetForkchoiceReader(api.readForkchoiceHeads)
+	}
+	return api
 }
 
 // newRPCPendingTransaction returns a pending transaction that will serialize to the RPC representation
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/hexutil"
@@ -34,6 +35,7 @@ import (
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
 	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/execution/stages/mock"
 	"github.com/erigontech/erigon/rpc"
@@ -52,7 +54,7 @@ func TestGetBalanceChangesInBlock(t *testing.T) {
 	myBlockNum := rpc.BlockNumberOrHashWithNumber(0)
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	db := m.DB
-	api := NewErigonAPI(newBaseApiForTest(m), db, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), db, nil, nil)
 	balances, err := api.GetBalanceChangesInBlock(context.Background(), myBlockNum)
 	if err != nil {
 		t.Errorf("calling GetBalanceChangesInBlock resulted in an error: %v", err)
@@ -89,6 +91,19 @@ func TestGetTransactionReceiptUnprotected(t *testing.T) {
 	}
 }
 
+func TestChainConfigOpcodeOverrides(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	base := newBaseApiForTest(m)
+	base.opcodeOverrides = []chain.OpcodeOverride{{Opcode: byte(vm.SELFDESTRUCT), Disabled: true}}
+
+	tx, err := m.DB.BeginTemporalRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	cc, err := base.chainConfig(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, base.opcodeOverrides, cc.OpcodeOverrides, "overrides aren't stored with the chain config in the db")
+}
+
 // EIP-1898 test cases
 
 func TestGetStorageAt_ByBlockNumber_WithRequireCanonicalDefault(t *testing.T) {
@@ -27,6 +27,7 @@ import (
 	"github.com/holiman/uint256"
 	"google.golang.org/grpc"
 
+	"github.com/erigontech/erigon-lib/abi"
 	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/empty"
@@ -36,7 +37,6 @@ import (
 	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/dbutils"
-	"github.com/erigontech/erigon-lib/kv/membatchwithdb"
 	"github.com/erigontech/erigon-lib/log/v3"
 	libstate "github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon-lib/trie"
@@ -92,7 +92,7 @@ func (api *APIImpl) Call(ctx context.Context, args ethapi2.CallArgs, requestedBl
 	if err != nil {
 		return nil, err
 	}
-	result, err := transactions.DoCall(ctx, engine, args, tx, blockNrOrHash, header, overrides, api.GasCap, chainConfig, stateReader, api._blockReader, api.evmCallTimeout)
+	result, err := transactions.DoCall(ctx, engine, args, tx, blockNrOrHash, header, overrides, api.GasCap, chainConfig, stateReader, api._blockReader, api.evmCallTimeout, api.evmMaxMemory)
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +133,70 @@ func headerByNumberOrHash(ctx context.Context, tx kv.Tx, blockNrOrHash rpc.Block
 }
 
 // EstimateGas implements eth_estimateGas. Returns an estimate of how much gas is necessary to allow the transaction to complete. The transaction will not be added to the blockchain.
-func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides) (hexutil.Uint64, error) {
+func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides, blockOverrides *ethapi2.BlockOverrides) (hexutil.Uint64, error) {
+	estimate, hi, err := api.estimateGas(ctx, argsOrNil, blockNrOrHash, overrides, blockOverrides)
+	if err != nil {
+		return 0, err
+	}
+	if result := estimate.Result; result.Failed() {
+		if !errors.Is(result.Err, vm.ErrOutOfGas) {
+			if len(result.Revert()) > 0 {
+				return 0, ethapi2.NewRevertError(result)
+			}
+			return 0, result.Err
+		}
+		// Otherwise, the specified gas cap is too low
+		return 0, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+	return hexutil.Uint64(estimate.Gas), nil
+}
+
+// GasEstimation - result of eth_estimateGasWithBounds
+type GasEstimation struct {
+	Gas          hexutil.Uint64 `json:"gas"`                    // estimate of the binary search, 0 if the execution fails
+	LowerBound   hexutil.Uint64 `json:"lowerBound"`             // optimistic lower bound: no lower gas limit succeeds
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`                // by the execution with the highest gas limit
+	GasCap       hexutil.Uint64 `json:"gasCap"`                 // the highest gas limit of the search
+	Failed       bool           `json:"failed"`                 // the execution with the highest gas limit failed
+	Error        string         `json:"error,omitempty"`        // reason of the failure
+	RevertData   hexutil.Bytes  `json:"revertData,omitempty"`   // data returned by REVERT
+	RevertReason string         `json:"revertReason,omitempty"` // unpacked Error(string) or Panic(uint256) of the revert data
+}
+
+// EstimateGasWithBounds implements eth_estimateGasWithBounds: eth_estimateGas returning the optimistic lower bound of
+// the gas limit too. Failure of the execution is not an error: its reason and revert data are in the result.
+func (api *APIImpl) EstimateGasWithBounds(ctx context.Context, argsOrNil *ethapi2.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides, blockOverrides *ethapi2.BlockOverrides) (*GasEstimation, error) {
+	estimate, hi, err := api.estimateGas(ctx, argsOrNil, blockNrOrHash, overrides, blockOverrides)
+	if err != nil {
+		return nil, err
+	}
+	result := estimate.Result
+	res := &GasEstimation{
+		Gas:        hexutil.Uint64(estimate.Gas),
+		LowerBound: hexutil.Uint64(estimate.LowerBound),
+		GasUsed:    hexutil.Uint64(result.GasUsed),
+		GasCap:     hexutil.Uint64(hi),
+		Failed:     result.Failed(),
+	}
+	if !result.Failed() {
+		return res, nil
+	}
+	if errors.Is(result.Err, vm.ErrOutOfGas) {
+		res.Error = fmt.Sprintf("gas required exceeds allowance (%d)", hi)
+		return res, nil
+	}
+	res.Error = result.Err.Error()
+	if revert := result.Revert(); len(revert) > 0 {
+		res.RevertData = revert
+		if reason, err := abi.UnpackRevert(revert); err == nil {
+			res.RevertReason = reason
+		}
+	}
+	return res, nil
+}
+
+// estimateGas returns the estimate and the highest gas limit of the search
+func (api *APIImpl) estimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides, blockOverrides *ethapi2.BlockOverrides) (*core.GasEstimate, uint64, error) {
 	var args ethapi2.CallArgs
 	// if we actually get CallArgs here, we use them
 	if argsOrNil != nil {
@@ -142,7 +205,7 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 
 	dbtx, err := api.db.BeginTemporalRo(ctx)
 	if err != nil {
-		return 0, err
+		return nil, 0, err
 	}
 	defer dbtx.Rollback()
 
@@ -153,13 +216,13 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 
 	chainConfig, err := api.chainConfig(ctx, dbtx)
 	if err != nil {
-		return 0, err
+		return nil, 0, err
 	}
 	engine := api.engine()
 
 	header, isLatest, err := headerByNumberOrHash(ctx, dbtx, *blockNrOrHash, api)
 	if err != nil {
-		return 0, err
+		return nil, 0, err
 	}
 
 	// try to check if it is a pending block
@@ -167,7 +230,7 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 		b := api.filters.LastPendingBlock()
 		blockNum, _, _, err := rpchelper.GetBlockNumber(ctx, *blockNrOrHash, dbtx, api._blockReader, api.filters)
 		if err != nil {
-			return 0, err
+			return nil, 0, err
 		}
 		if b != nil && blockNum == b.NumberU64() {
 			header = b.HeaderNoCopy()
@@ -175,21 +238,18 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 	}
 
 	if header == nil {
-		return 0, errors.New(fmt.Sprintf("could not find the header %s in cache or db", blockNrOrHash.String()))
+		return nil, 0, errors.New(fmt.Sprintf("could not find the header %s in cache or db", blockNrOrHash.String()))
 	}
 
 	blockNum := *(header.Number)
 
 	stateReader, err := rpchelper.CreateStateReaderFromBlockNumber(ctx, dbtx, blockNum.Uint64(), isLatest, 0, api.stateCache, api._txNumReader)
 	if err != nil {
-		return 0, err
+		return nil, 0, err
 	}
 
-	// Binary search the gas requirement, as it may be higher than the amount used
-	var (
-		lo uint64
-		hi uint64
-	)
+	// The highest gas limit of the binary search, the gas requirement may be higher than the amount used
+	var hi uint64
 	// Use zero address if sender unspecified.
 	if args.From == nil {
 		args.From = new(common.Address)
@@ -198,6 +258,8 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 	// Determine the highest gas limit can be used during the estimation.
 	if args.Gas != nil && uint64(*args.Gas) >= params.TxGas {
 		hi = uint64(*args.Gas)
+	} else if blockOverrides != nil && blockOverrides.GasLimit != nil {
+		hi = uint64(*blockOverrides.GasLimit)
 	} else {
 		// Retrieve the block to act as the gas ceiling
 		hi = header.GasLimit
@@ -210,7 +272,7 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 
 	var feeCap *big.Int
 	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
-		return 0, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+		return nil, 0, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	} else if args.GasPrice != nil {
 		feeCap = args.GasPrice.ToInt()
 	} else if args.MaxFeePerGas != nil {
@@ -222,17 +284,17 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 	if feeCap.Sign() != 0 {
 		state := state.New(stateReader)
 		if state == nil {
-			return 0, errors.New("can't get the current state")
+			return nil, 0, errors.New("can't get the current state")
 		}
 
 		balance, err := state.GetBalance(*args.From) // from can't be nil
 		if err != nil {
-			return 0, err
+			return nil, 0, err
 		}
 		available := balance.ToBig()
 		if args.Value != nil {
 			if args.Value.ToInt().Cmp(available) >= 0 {
-				return 0, errors.New("insufficient funds for transfer")
+				return nil, 0, errors.New("insufficient funds for transfer")
 			}
 			available.Sub(available, args.Value.ToInt())
 		}
@@ -250,55 +312,24 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 		}
 	}
 
-	caller, err := transactions.NewReusableCaller(engine, stateReader, overrides, header, args, api.GasCap, *blockNrOrHash, dbtx, api._blockReader, chainConfig, api.evmCallTimeout)
+	evm, msg, err := transactions.NewCallEVM(engine, stateReader, overrides, blockOverrides, header, args, api.GasCap, *blockNrOrHash, dbtx, api._blockReader, chainConfig, api.evmMaxMemory)
 	if err != nil {
-		return 0, err
+		return nil, 0, err
 	}
-
-	// First try with highest gas possible
-	result, err := caller.DoCallWithNewGas(ctx, hi, engine, overrides)
-	if err != nil || result == nil {
-		return 0, err
+	if api.evmCallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, api.evmCallTimeout)
+		defer cancel()
 	}
-	if result.Failed() {
-		if !errors.Is(result.Err, vm.ErrOutOfGas) {
-			if len(result.Revert()) > 0 {
-				return 0, ethapi2.NewRevertError(result)
-			}
-			return 0, result.Err
-		}
-		// Otherwise, the specified gas cap is too low
-		return 0, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+
+	estimate, err := core.EstimateGasBounds(ctx, evm, msg, api.GasCap, hi, engine)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, 0, fmt.Errorf("execution aborted (timeout = %v)", api.evmCallTimeout)
 	}
-	// Assuming a contract can freely run all the instructions, we have
-	// the true amount of gas it wants to consume to execute fully.
-	// We want to ensure that the gas used doesn't fall below this
-	trueGas := result.GasUsed // Must not fall below this
-	lo = max(trueGas+result.EvmRefund-1, params.TxGas-1)
-
-	i := 0
-	// Execute the binary search and hone in on an executable gas limit
-	for lo+1 < hi {
-		mid := (hi + lo) / 2
-		if mid < trueGas {
-			lo = mid
-			continue
-		}
-		result, err := caller.DoCallWithNewGas(ctx, mid, engine, overrides)
-		// If the error is not nil(consensus error), it means the provided message
-		// call or transaction will never be accepted no matter how much gas it is
-		// assigened. Return the error directly, don't struggle any more.
-		if err != nil && !errors.Is(err, core.ErrIntrinsicGas) {
-			return 0, err
-		}
-		if errors.Is(err, core.ErrIntrinsicGas) || result.Failed() || result.GasUsed < trueGas {
-			lo = mid
-		} else {
-			hi = mid
-		}
-		i++
+	if err != nil {
+		return nil, 0, err
 	}
-	return hexutil.Uint64(hi), nil
+	return estimate, hi, nil
 }
 
 // GetProof implements eth_getProof partially; Proofs are available only with the `latest` block tag.
@@ -518,8 +549,8 @@ func verifyExecResult(execResult *core.EphemeralExecResult, block *types.Block)
 	return nil
 }
 
-func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rpc.BlockNumberOrHash, txIndex hexutil.Uint, fullBlock bool, maxGetProofRewindBlockCount int, logger log.Logger) (hexutil.Bytes, error) {
-	roTx, err := db.BeginRo(ctx)
+func (api *BaseAPI) getWitness(ctx context.Context, db kv.TemporalRoDB, blockNrOrHash rpc.BlockNumberOrHash, txIndex hexutil.Uint, fullBlock bool, maxGetProofRewindBlockCount int, logger log.Logger) (hexutil.Bytes, error) {
+	roTx, err := db.BeginTemporalRo(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -571,9 +602,9 @@ func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rp
 		return nil, err
 	}
 
-	regenerateHash := false
+	// the state is unwound in memory, by the changesets of the blocks after blockNr-1
 	if latestBlock-blockNr > uint64(maxGetProofRewindBlockCount) {
-		regenerateHash = true
+		return nil, fmt.Errorf("requested block is too old, block must be within %d blocks of the head block number (currently %d), see --rpc.maxgetproofrewindblockcount.limit", maxGetProofRewindBlockCount, latestBlock)
 	}
 
 	engine, ok := api.engine().(consensus.Engine)
@@ -581,37 +612,32 @@ func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rp
 		return nil, errors.New("engine is not consensus.Engine")
 	}
 
-	roTx2, err := db.BeginRo(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer roTx2.Rollback()
-	txBatch2 := membatchwithdb.NewMemoryBatch(roTx2, "", logger)
-	defer txBatch2.Rollback()
-
 	// Prepare witness config
-	chainConfig, err := api.chainConfig(ctx, roTx2)
+	chainConfig, err := api.chainConfig(ctx, roTx)
 	if err != nil {
 		return nil, fmt.Errorf("error loading chain config: %v", err)
 	}
 
-	// Unwind to blockNr
 	cfg := stagedsync.StageWitnessCfg(true, 0, chainConfig, engine, api._blockReader, api.dirs)
-	err = stagedsync.RewindStagesForWitness(txBatch2, blockNr, latestBlock, &cfg, regenerateHash, ctx, logger)
+	store, err := stagedsync.PrepareForWitness(roTx, block, prevHeader.Root, &cfg, ctx, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	store, err := stagedsync.PrepareForWitness(txBatch2, block, prevHeader.Root, &cfg, ctx, logger)
+	domains, err := libstate.NewSharedDomains(roTx, log.New())
 	if err != nil {
 		return nil, err
 	}
+	defer domains.Close()
+	sdCtx := domains.GetCommitmentContext()
 
-	domains, err := libstate.NewSharedDomains(txBatch2, log.New())
-	if err != nil {
+	// Unwind to blockNr-1, in memory of the domains
+	if err := stagedsync.RewindStagesForWitness(roTx, domains, blockNr, latestBlock, &cfg, ctx); err != nil {
+		return nil, err
+	}
+	if err := domains.SeekCommitment(ctx, roTx); err != nil {
 		return nil, err
 	}
-	sdCtx := domains.GetCommitmentContext()
 
 	// execute block #blockNr ephemerally. This will use TrieStateWriter to record touches of accounts and storage keys.
 	_, err = core.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, store.GetHashFn, engine, block, store.Tds, store.TrieStateWriter, store.ChainReader, nil, logger)
@@ -29,6 +29,7 @@ import (
 
 	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/crypto"
@@ -62,11 +63,53 @@ func TestEstimateGas(t *testing.T) {
 	if _, err := api.EstimateGas(context.Background(), &ethapi.CallArgs{
 		From: &from,
 		To:   &to,
-	}, nil, nil); err != nil {
+	}, nil, nil, nil); err != nil {
 		t.Errorf("calling EstimateGas: %v", err)
 	}
 }
 
+func TestEstimateGasWithBounds(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mock.Mock(t))
+	mining := txpool.NewMiningClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, nil, mining, func() {}, m.Log)
+	api := NewEthAPI(NewBaseApi(ff, stateCache, m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	var from = common.HexToAddress("0x71562b71999873db5b286df957af199ec94617f7")
+	var to = common.HexToAddress("0x0d3ab14bbad3d99f4203bd7a11acb94882050e7e")
+	args := &ethapi.CallArgs{From: &from, To: &to}
+
+	res, err := api.EstimateGasWithBounds(context.Background(), args, nil, nil, nil)
+	require.NoError(t, err)
+	require.False(t, res.Failed)
+	require.Equal(t, hexutil.Uint64(params.TxGas), res.Gas)
+	require.Equal(t, hexutil.Uint64(params.TxGas), res.LowerBound)
+
+	// the gas limit of the overridden block is the ceiling of the search
+	gasLimit := hexutil.Uint64(30_000)
+	res, err = api.EstimateGasWithBounds(context.Background(), args, nil, nil, &ethapi.BlockOverrides{GasLimit: &gasLimit})
+	require.NoError(t, err)
+	require.Equal(t, gasLimit, res.GasCap)
+
+	// code reverting with Error("x"): CODECOPY of the revert data appended to the code, REVERT
+	code := hexutil.Bytes(common.FromHex("0x6064600c60003960646000fd" +
+		"08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"7800000000000000000000000000000000000000000000000000000000000000"))
+	overrides := ethapi.StateOverrides{to: ethapi.Account{Code: &code}}
+	res, err = api.EstimateGasWithBounds(context.Background(), args, nil, &overrides, nil)
+	require.NoError(t, err)
+	require.True(t, res.Failed)
+	require.Zero(t, res.Gas)
+	require.Equal(t, "x", res.RevertReason)
+	require.Equal(t, code[12:], res.RevertData)
+
+	_, err = api.EstimateGas(context.Background(), args, nil, &overrides, nil)
+	var revertErr *ethapi.RevertError
+	require.ErrorAs(t, err, &revertErr)
+}
+
 func TestEthCallNonCanonical(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
@@ -242,7 +285,7 @@ func TestGetBlockByTimestampLatestTime(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	latestBlock, err := m.BlockReader.CurrentBlock(tx)
 	require.NoError(t, err)
@@ -277,7 +320,7 @@ func TestGetBlockByTimestampOldestTime(t *testing.T) {
 		t.Errorf("failed at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	oldestBlock, err := m.BlockReader.BlockByNumber(m.Ctx, tx, 0)
 	if err != nil {
@@ -315,7 +358,7 @@ func TestGetBlockByTimeHigherThanLatestBlock(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	latestBlock, err := m.BlockReader.CurrentBlock(tx)
 	require.NoError(t, err)
@@ -351,7 +394,7 @@ func TestGetBlockByTimeMiddle(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	currentHeader := rawdb.ReadCurrentHeader(tx)
 	oldestHeader, err := api._blockReader.HeaderByNumber(ctx, tx, 0)
@@ -398,7 +441,7 @@ func TestGetBlockByTimestamp(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, nil)
 
 	highestBlockNumber := rawdb.ReadCurrentHeader(tx).Number
 	pickedBlock, err := m.BlockReader.BlockByNumber(m.Ctx, tx, highestBlockNumber.Uint64()/3)
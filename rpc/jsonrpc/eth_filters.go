@@ -19,8 +19,13 @@ package jsonrpc
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/big"
 	"strings"
+	"time"
 
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/debug"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
@@ -176,6 +181,73 @@ func (api *APIImpl) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 	return rpcSub, nil
 }
 
+// SafeHeads send a notification each time the safe head of the engine forkchoice state changes.
+func (api *APIImpl) SafeHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return api.forkchoiceHeads(ctx, api.filters.SubscribeSafeHeads, api.filters.UnsubscribeSafeHeads)
+}
+
+// FinalizedHeads send a notification each time the finalized head of the engine forkchoice state changes.
+func (api *APIImpl) FinalizedHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return api.forkchoiceHeads(ctx, api.filters.SubscribeFinalizedHeads, api.filters.UnsubscribeFinalizedHeads)
+}
+
+func (api *APIImpl) forkchoiceHeads(ctx context.Context, subscribe func(int) (<-chan *types.Header, rpchelper.HeadsSubID), unsubscribe func(rpchelper.HeadsSubID) bool) (*rpc.Subscription, error) {
+	if api.filters == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer debug.LogPanic()
+		headers, id := subscribe(8)
+		defer unsubscribe(id)
+		for {
+			select {
+			case h, ok := <-headers:
+				if h != nil {
+					err := notifier.Notify(rpcSub.ID, h)
+					if err != nil {
+						log.Warn("[rpc] error while notifying subscription", "err", err)
+					}
+				}
+				if !ok {
+					log.Warn("[rpc] forkchoice heads channel was closed")
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// readForkchoiceHeads reads the safe and finalized heads of the forkchoice state for the rpc filters.
+func (api *APIImpl) readForkchoiceHeads(ctx context.Context) (safe, finalized *types.Header, err error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+	if hash := rawdb.ReadForkchoiceSafe(tx); hash != (common.Hash{}) {
+		if safe, err = api._blockReader.HeaderByHash(ctx, tx, hash); err != nil {
+			return nil, nil, err
+		}
+	}
+	if hash := rawdb.ReadForkchoiceFinalized(tx); hash != (common.Hash{}) {
+		if finalized, err = api._blockReader.HeaderByHash(ctx, tx, hash); err != nil {
+			return nil, nil, err
+		}
+	}
+	return safe, finalized, nil
+}
+
 // NewPendingTransactions send a notification each time when a transaction had added into mempool.
 func (api *APIImpl) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
 	if api.filters == nil {
@@ -264,8 +336,9 @@ func (api *APIImpl) NewPendingTransactionsWithBody(ctx context.Context) (*rpc.Su
 	return rpcSub, nil
 }
 
-// Logs send a notification each time a new log appears.
-func (api *APIImpl) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
+// Logs send a notification each time a new log appears. Subscriptions with a named cursor are resumable:
+// the cursor is persisted in datadir and on resubscription `removed` logs of reorged blocks and the missed logs are sent first.
+func (api *APIImpl) Logs(ctx context.Context, crit filters.FilterCriteria, opts *filters.LogSubscriptionOptions) (*rpc.Subscription, error) {
 	if api.filters == nil {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
@@ -274,26 +347,138 @@ func (api *APIImpl) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
 
+	if opts == nil || opts.Cursor == "" {
+		logs, id := api.filters.SubscribeLogs(api.SubscribeLogsChannelSize, crit)
+		rpcSub := notifier.CreateSubscription()
+		go func() {
+			defer debug.LogPanic()
+			defer api.filters.UnsubscribeLogs(id)
+			for {
+				select {
+				case h, ok := <-logs:
+					if h != nil {
+						err := notifier.Notify(rpcSub.ID, h)
+						if err != nil {
+							log.Warn("[rpc] error while notifying subscription", "err", err)
+						}
+					}
+					if !ok {
+						log.Warn("[rpc] log channel was closed")
+						return
+					}
+				case <-rpcSub.Err():
+					return
+				}
+			}
+		}()
+		return rpcSub, nil
+	}
+	return api.logsWithCursor(ctx, notifier, crit, opts.Cursor)
+}
+
+// logsWithCursor - `logs` subscription resumable by named cursor. Missed logs are sent in pages of
+// rpchelper.LogsCursorBacklogPage blocks before the live subscription is created: otherwise its buffer
+// would overflow on a long backlog.
+func (api *APIImpl) logsWithCursor(ctx context.Context, notifier rpc.Notifier, crit filters.FilterCriteria, cursorID string) (*rpc.Subscription, error) {
+	if api.logsCursors == nil {
+		return &rpc.Subscription{}, errors.New("logs subscription cursors are not supported: rpcdaemon runs without datadir")
+	}
+	release, err := api.logsCursors.Acquire(cursorID)
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+	cursor, err := api.logsCursors.Load(cursorID)
+	if err != nil {
+		release()
+		return &rpc.Subscription{}, err
+	}
+	removed, resumeFrom, err := api.rewindLogsCursor(ctx, cursor)
+	if err != nil {
+		release()
+		return &rpc.Subscription{}, err
+	}
+
 	rpcSub := notifier.CreateSubscription()
+	var dirty bool
+	// notify - the cursor advances only on delivered logs: on error the subscription ends, and the rest is re-sent on resume
+	notify := func(lg *types.Log) error {
+		if err := notifier.Notify(rpcSub.ID, lg); err != nil {
+			return fmt.Errorf("notify subscription: %w", err)
+		}
+		cursor.Delivered(lg)
+		dirty = true
+		return nil
+	}
+	// ctx of the subscription request is done when this method returns
+	backlogCtx := context.Background()
 
 	go func() {
 		defer debug.LogPanic()
+		defer release()
+		defer func() { api.saveLogsCursor(cursorID, cursor) }()
+
+		for _, lg := range removed {
+			if err := notify(lg); err != nil {
+				log.Warn("[rpc] logs cursor", "cursor", cursorID, "err", err)
+				return
+			}
+		}
+		from := resumeFrom
+		synced := cursor.Synced > 0 || len(cursor.Blocks) > 0
+		for synced {
+			head, err := api.BlockNumber(backlogCtx)
+			if err != nil {
+				log.Warn("[rpc] logs cursor backlog", "cursor", cursorID, "err", err)
+				return
+			}
+			if uint64(head) < from+rpchelper.LogsCursorBacklogPage {
+				break
+			}
+			to := from + rpchelper.LogsCursorBacklogPage - 1
+			if err := api.sendLogsBacklog(backlogCtx, crit, cursor, from, &to, notify); err != nil {
+				log.Warn("[rpc] logs cursor backlog", "cursor", cursorID, "err", err)
+				return
+			}
+			api.saveLogsCursor(cursorID, cursor)
+			dirty = false
+			from = to + 1
+			select {
+			case <-rpcSub.Err():
+				return
+			default:
+			}
+		}
+
+		// subscribe before reading the rest of backlog: logs arriving meanwhile are buffered and de-duplicated against the cursor
 		logs, id := api.filters.SubscribeLogs(api.SubscribeLogsChannelSize, crit)
 		defer api.filters.UnsubscribeLogs(id)
+		if synced {
+			if err := api.sendLogsBacklog(backlogCtx, crit, cursor, from, nil, notify); err != nil {
+				log.Warn("[rpc] logs cursor backlog", "cursor", cursorID, "err", err)
+				return
+			}
+		}
 
+		saveEvery := time.NewTicker(rpchelper.LogsCursorSaveInterval)
+		defer saveEvery.Stop()
 		for {
 			select {
 			case h, ok := <-logs:
-				if h != nil {
-					err := notifier.Notify(rpcSub.ID, h)
-					if err != nil {
-						log.Warn("[rpc] error while notifying subscription", "err", err)
+				if h != nil && (h.Removed || !cursor.Contains(h)) {
+					if err := notify(h); err != nil {
+						log.Warn("[rpc] logs cursor", "cursor", cursorID, "err", err)
+						return
 					}
 				}
 				if !ok {
 					log.Warn("[rpc] log channel was closed")
 					return
 				}
+			case <-saveEvery.C:
+				if dirty {
+					api.saveLogsCursor(cursorID, cursor)
+					dirty = false
+				}
 			case <-rpcSub.Err():
 				return
 			}
@@ -302,3 +487,51 @@ func (api *APIImpl) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc
 
 	return rpcSub, nil
 }
+
+// rewindLogsCursor - drops journaled blocks which are not canonical anymore. Returns their logs marked as `removed`
+// and the block number from which canonical logs have to be re-delivered.
+func (api *APIImpl) rewindLogsCursor(ctx context.Context, cursor *rpchelper.LogsCursor) (removed []*types.Log, resumeFrom uint64, err error) {
+	if cursor.Synced == 0 && len(cursor.Blocks) == 0 {
+		return nil, 0, nil
+	}
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+	return cursor.Rewind(func(num uint64, hash common.Hash) (bool, error) {
+		canonical, ok, err := api._blockReader.CanonicalHash(ctx, tx, num)
+		if err != nil {
+			return false, err
+		}
+		return ok && canonical == hash, nil
+	})
+}
+
+// sendLogsBacklog - canonical logs of blocks [from, to] (to == nil - up to head) not delivered to the cursor yet
+func (api *APIImpl) sendLogsBacklog(ctx context.Context, crit filters.FilterCriteria, cursor *rpchelper.LogsCursor, from uint64, to *uint64, notify func(lg *types.Log) error) error {
+	crit.BlockHash = nil
+	crit.FromBlock = new(big.Int).SetUint64(from)
+	crit.ToBlock = nil
+	if to != nil {
+		crit.ToBlock = new(big.Int).SetUint64(*to)
+	}
+	logs, err := api.GetLogs(ctx, crit)
+	if err != nil {
+		return err
+	}
+	for _, lg := range logs {
+		if !cursor.Contains(lg) {
+			if err := notify(lg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (api *APIImpl) saveLogsCursor(id string, cursor *rpchelper.LogsCursor) {
+	if err := api.logsCursors.Save(id, cursor); err != nil {
+		log.Warn("[rpc] failed to save logs subscription cursor", "cursor", id, "err", err)
+	}
+}
@@ -97,32 +97,17 @@ func (api *APIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (t
 
 		begin = latest
 		if crit.FromBlock != nil {
-			fromBlock := crit.FromBlock.Int64()
-			if fromBlock > 0 {
-				begin = uint64(fromBlock)
-			} else {
-				blockNum := rpc.BlockNumber(fromBlock)
-				begin, _, _, err = rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(blockNum), tx, api._blockReader, api.filters)
-				if err != nil {
-					return nil, err
-				}
+			if begin, err = rpchelper.GetRangeBlockNumber(ctx, crit.FromBlock, tx, api._blockReader, api.filters); err != nil {
+				return nil, err
 			}
-
-			if uint64(fromBlock) > latest {
+			if begin > latest {
 				return types.Logs{}, nil
 			}
 		}
 		end = latest
 		if crit.ToBlock != nil {
-			toBlock := crit.ToBlock.Int64()
-			if toBlock > 0 {
-				end = uint64(toBlock)
-			} else {
-				blockNum := rpc.BlockNumber(toBlock)
-				end, _, _, err = rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(blockNum), tx, api._blockReader, api.filters)
-				if err != nil {
-					return nil, err
-				}
+			if end, err = rpchelper.GetRangeBlockNumber(ctx, crit.ToBlock, tx, api._blockReader, api.filters); err != nil {
+				return nil, err
 			}
 		}
 	}
@@ -304,8 +304,9 @@ var PrecompileNamesMap = map[string]string{
 }
 
 // Config returns the HardFork config for current and upcoming forks:
-// assuming linear fork progression and ethereum-like schedule
-func (api *APIImpl) Config(ctx context.Context) (*EthConfigResp, error) {
+// assuming linear fork progression and ethereum-like schedule.
+// The forks are those of the optional timestamp, the current time by default.
+func (api *APIImpl) Config(ctx context.Context, timestamp *hexutil.Uint64) (*EthConfigResp, error) {
 	tx, err := api.db.BeginTemporalRo(ctx)
 	if err != nil {
 		return nil, err
@@ -317,6 +318,9 @@ func (api *APIImpl) Config(ctx context.Context) (*EthConfigResp, error) {
 		return nil, err
 	}
 	tt := uint64(time.Now().Unix())
+	if timestamp != nil {
+		tt = uint64(*timestamp)
+	}
 
 	ret := &EthConfigResp{
 		Current: &EthHardForkConfig{},
@@ -27,6 +27,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
@@ -84,11 +85,25 @@ func TestEthConfig(t *testing.T) {
 		eth := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000, ethconfig.Defaults.RPCTxFeeCap, 10_000, false, 10_000, 128, log.New())
 
 		ctx := context.Background()
-		result, err := eth.Config(ctx)
+		result, err := eth.Config(ctx, nil)
 		require.NoError(t, err)
 		require.Equal(t, result.CurrentHash, "8ea4635f")
 	})
 
+	t.Run("eth_config mainnet at cancun", func(t *testing.T) {
+		key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		m := mock.MockWithGenesis(t, chainspec.MainnetGenesisBlock(), key, false)
+		defer m.DB.Close()
+		eth := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000, ethconfig.Defaults.RPCTxFeeCap, 10_000, false, 10_000, 128, log.New())
+
+		cancun := hexutil.Uint64(chainspec.MainnetChainConfig.CancunTime.Uint64())
+		result, err := eth.Config(context.Background(), &cancun)
+		require.NoError(t, err)
+		require.Equal(t, hexutil.Uint(cancun), result.Current.ActivationTime)
+		require.NotNil(t, result.Next)
+		require.Equal(t, hexutil.Uint(chainspec.MainnetChainConfig.PragueTime.Uint64()), result.Next.ActivationTime)
+	})
+
 }
 
 func createGasPriceTestKV(t *testing.T, chainSize int) *mock.MockSentry {
@@ -137,10 +137,35 @@ func (api *APIImpl) GetTransactionByHash(ctx context.Context, txnHash common.Has
 		return newRPCPendingTransaction(txn, curHeader, chainConfig), nil
 	}
 
+	if api.nonCanonicalTxs {
+		blockNum, blockHash, ok, err := rawdb.ReadTxLookupTombstone(tx, txnHash)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return nil, &nonCanonicalTxError{BlockNumber: hexutil.Uint64(blockNum), BlockHash: blockHash}
+		}
+	}
+
 	// Transaction unknown, return as such
 	return nil, nil
 }
 
+// nonCanonicalTxError - transaction was included into a block which was unwound from the canonical chain,
+// and it's not in the txpool
+type nonCanonicalTxError struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+}
+
+func (e *nonCanonicalTxError) ErrorCode() int { return -32000 }
+
+func (e *nonCanonicalTxError) ErrorData() interface{} { return e }
+
+func (e *nonCanonicalTxError) Error() string {
+	return fmt.Sprintf("transaction is known but not canonical (in block %d %x)", e.BlockNumber, e.BlockHash)
+}
+
 // GetRawTransactionByHash returns the bytes of the transaction for the given hash.
 func (api *APIImpl) GetRawTransactionByHash(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
 	tx, err := api.db.BeginTemporalRo(ctx)
@@ -0,0 +1,76 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/execution/stateless"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// witness produced by eth_getWitness must be enough to verify the block by stateless verifier
+func TestGetWitness_StatelessVerify(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	require.NoError(t, err)
+	// the last block with transactions
+	var block *types.Block
+	blockNum := latest
+	for ; blockNum > 0; blockNum-- {
+		block, err = m.BlockReader.BlockByNumber(ctx, tx, blockNum)
+		require.NoError(t, err)
+		if len(block.Transactions()) > 0 {
+			break
+		}
+	}
+	require.NotEmpty(t, block.Transactions())
+	parent, err := m.BlockReader.HeaderByNumber(ctx, tx, blockNum-1)
+	require.NoError(t, err)
+
+	witness, err := api.GetWitness(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNum)))
+	require.NoError(t, err)
+
+	res, err := stateless.Verify(m.ChainConfig, m.Engine, stateless.Input{Block: block, Parent: parent, Witness: witness}, log.New())
+	require.NoError(t, err)
+	require.True(t, res.Valid)
+	require.Equal(t, block.Root(), res.StateRoot)
+	require.Equal(t, block.GasUsed(), res.GasUsed)
+
+	// header claims different post-state
+	header := block.Header()
+	header.Root = common.Hash{0x01}
+	res, err = stateless.Verify(m.ChainConfig, m.Engine, stateless.Input{Block: block.WithSeal(header), Parent: parent, Witness: witness}, log.New())
+	require.ErrorIs(t, err, stateless.ErrStateRootMismatch)
+	require.False(t, res.Valid)
+	require.Equal(t, block.Root(), res.StateRoot)
+}
@@ -582,29 +582,14 @@ func getBeginEnd(ctx context.Context, tx kv.Tx, api *OverlayAPIImpl, crit filter
 
 		begin = latest
 		if crit.FromBlock != nil {
-			fromBlock := crit.FromBlock.Int64()
-			if fromBlock > 0 {
-				begin = uint64(fromBlock)
-			} else {
-				blockNum := rpc.BlockNumber(fromBlock)
-				begin, _, _, err = rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(blockNum), tx, api._blockReader, api.filters)
-				if err != nil {
-					return 0, 0, err
-				}
+			if begin, err = rpchelper.GetRangeBlockNumber(ctx, crit.FromBlock, tx, api._blockReader, api.filters); err != nil {
+				return 0, 0, err
 			}
-
 		}
 		end = latest
 		if crit.ToBlock != nil {
-			toBlock := crit.ToBlock.Int64()
-			if toBlock > 0 {
-				end = uint64(toBlock)
-			} else {
-				blockNum := rpc.BlockNumber(toBlock)
-				end, _, _, err = rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(blockNum), tx, api._blockReader, api.filters)
-				if err != nil {
-					return 0, 0, err
-				}
+			if end, err = rpchelper.GetRangeBlockNumber(ctx, crit.ToBlock, tx, api._blockReader, api.filters); err != nil {
+				return 0, 0, err
 			}
 		}
 	}
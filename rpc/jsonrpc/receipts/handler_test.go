@@ -341,6 +341,81 @@ func TestGetBlockReceipts(t *testing.T) {
 	require.Equal(t, expect, sent.Data)
 }
 
+func TestAnswerQueriesBelowEarliest(t *testing.T) {
+	signer := types.LatestSignerForChainID(nil)
+	m := mockWithGenerator(t, 6, func(i int, block *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testAddr), common.Address{0xee}, uint256.NewInt(1), params.TxGas, nil, nil), *signer, testKey)
+		block.AddTx(tx)
+	})
+	const earliest = 3
+	tx, err := m.DB.BeginTemporalRo(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	var (
+		hashes  []common.Hash
+		bodies  []rlp.RawValue
+		encoded []rlp.RawValue
+	)
+	generator := receipts.NewGenerator(m.BlockReader, m.Engine)
+	for i := uint64(0); i <= 6; i++ {
+		block, err := m.BlockReader.BlockByNumber(m.Ctx, tx, i)
+		require.NoError(t, err)
+		hashes = append(hashes, block.Hash())
+		body, err := m.BlockReader.BodyRlp(m.Ctx, tx, block.Hash(), i)
+		require.NoError(t, err)
+		bodies = append(bodies, body)
+		r, err := generator.GetReceipts(m.Ctx, m.ChainConfig, tx, block)
+		require.NoError(t, err)
+		enc, err := rlp.EncodeToBytes(r)
+		require.NoError(t, err)
+		encoded = append(encoded, enc)
+	}
+
+	// headers: the response ends before the first block below earliest
+	headerNumbers := func(query *eth.GetBlockHeadersPacket) (numbers []uint64) {
+		headers, err := eth.AnswerGetBlockHeadersQuery(tx, query, earliest, m.BlockReader)
+		require.NoError(t, err)
+		for _, h := range headers {
+			numbers = append(numbers, h.Number.Uint64())
+		}
+		return numbers
+	}
+	require.Empty(t, headerNumbers(&eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Number: 1}, Amount: 4}))
+	require.Equal(t, []uint64{3, 4}, headerNumbers(&eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Number: 3}, Amount: 2}))
+	require.Equal(t, []uint64{5, 4, 3}, headerNumbers(&eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Number: 5}, Amount: 4, Reverse: true}))
+	require.Equal(t, []uint64{5, 3}, headerNumbers(&eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Hash: hashes[5]}, Amount: 3, Skip: 1, Reverse: true}))
+
+	// bodies and receipts: the served prefix of the query is kept
+	query := []common.Hash{hashes[4], hashes[3], hashes[1], hashes[5]}
+	require.Equal(t, []rlp.RawValue{bodies[4], bodies[3]}, eth.AnswerGetBlockBodiesQuery(tx, query, earliest, m.BlockReader))
+	require.Empty(t, eth.AnswerGetBlockBodiesQuery(tx, []common.Hash{hashes[2], hashes[5]}, earliest, m.BlockReader))
+
+	res, err := eth.AnswerGetReceiptsQuery(m.Ctx, m.ChainConfig, receipts.NewGenerator(m.BlockReader, m.Engine), m.BlockReader, tx, query, earliest, nil)
+	require.NoError(t, err)
+	require.Equal(t, []rlp.RawValue{encoded[4], encoded[3]}, res)
+
+	// all receipts of the query are cached: the cache-only path stops at earliest too
+	cached, needMore, err := eth.AnswerGetReceiptsQueryCacheOnly(m.Ctx, generator, query, earliest)
+	require.NoError(t, err)
+	require.False(t, needMore)
+	require.Equal(t, []rlp.RawValue{encoded[4], encoded[3]}, cached.EncodedReceipts)
+
+	// only block 4 is cached: the cached prefix is followed by the receipts read from the db
+	partial := receipts.NewGenerator(m.BlockReader, m.Engine)
+	block4, err := m.BlockReader.BlockByNumber(m.Ctx, tx, 4)
+	require.NoError(t, err)
+	_, err = partial.GetReceipts(m.Ctx, m.ChainConfig, tx, block4)
+	require.NoError(t, err)
+	cached, needMore, err = eth.AnswerGetReceiptsQueryCacheOnly(m.Ctx, partial, query, earliest)
+	require.NoError(t, err)
+	require.True(t, needMore)
+	require.Equal(t, []rlp.RawValue{encoded[4]}, cached.EncodedReceipts)
+	res, err = eth.AnswerGetReceiptsQuery(m.Ctx, m.ChainConfig, partial, m.BlockReader, tx, query, earliest, cached)
+	require.NoError(t, err)
+	require.Equal(t, []rlp.RawValue{encoded[4], encoded[3]}, res)
+}
+
 // newTestBackend creates a chain with a number of explicitly defined blocks and
 // wraps it into a mock backend.
 func mockWithGenerator(t *testing.T, blocks int, generator func(int, *core.BlockGen)) *mock.MockSentry {
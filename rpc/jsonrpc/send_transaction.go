@@ -25,10 +25,6 @@ func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutil.By
 		return common.Hash{}, err
 	}
 
-	if !txn.Protected() && !api.AllowUnprotectedTxs {
-		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
-	}
-
 	// this has been moved to prior to adding of transactions to capture the
 	// pre state of the db - which is used for logging in the messages below
 	tx, err := api.db.BeginTemporalRo(ctx)
@@ -43,8 +39,16 @@ func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutil.By
 		return common.Hash{}, err
 	}
 
-	if txn.Protected() {
-		txnChainId := txn.GetChainID()
+	txnChainId := txn.GetChainID()
+	if t, ok := txn.(*types.LegacyTx); ok {
+		// V of the legacy ones is decoded by the signer scheme of the chain, if any
+		txnChainId = types.LegacyChainID(cc.ChainID, t)
+	}
+	if txnChainId == nil && !api.AllowUnprotectedTxs {
+		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+
+	if txnChainId != nil {
 		chainId := cc.ChainID
 		if chainId.Cmp(txnChainId.ToBig()) != 0 {
 			return common.Hash{}, fmt.Errorf("invalid chain id, expected: %d got: %d", chainId, *txnChainId)
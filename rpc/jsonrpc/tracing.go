@@ -17,6 +17,7 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -221,6 +222,85 @@ func (api *DebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rpc.Block
 	return nil
 }
 
+// traceChainMaxBlocks - max amount of blocks traced by one debug_traceChain call. Clients continue from returned cursor.
+const traceChainMaxBlocks = 1_000
+
+// TraceChain implements debug_traceChain. Traces blocks in range [start, end] and streams results block-by-block:
+// [{"block": n, "result": [...]}, ..., {"next": n+1}]
+// If range is longer than traceChainMaxBlocks or a block failed to trace - the last element contains "next" - cursor
+// to use as `start` of the following call (and "error" if any).
+func (api *DebugAPIImpl) TraceChain(ctx context.Context, start, end rpc.BlockNumber, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	from, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(start), tx, api._blockReader, api.filters)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	to, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(end), tx, api._blockReader, api.filters)
+	tx.Rollback() // every block is traced in own tx - to not keep long-living read tx
+	if err != nil {
+		return err
+	}
+	if from > to {
+		return fmt.Errorf("invalid range: start %d is greater than end %d", from, to)
+	}
+	last := min(to, from+traceChainMaxBlocks-1)
+
+	var buf bytes.Buffer
+	stream.WriteArrayStart()
+	for blockNum := from; blockNum <= last; blockNum++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		// trace into buffer: on error don't leave half-written block in the output
+		buf.Reset()
+		blockStream := jsonstream.New(&buf)
+		traceErr := api.traceBlock(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNum)), config, blockStream)
+		if err := blockStream.Flush(); err != nil && traceErr == nil {
+			traceErr = err
+		}
+		if blockNum != from {
+			stream.WriteMore()
+		}
+		stream.WriteObjectStart()
+		if traceErr != nil {
+			stream.WriteObjectField("next")
+			stream.WriteUint64(blockNum)
+			stream.WriteMore()
+			rpc.HandleError(traceErr, stream)
+			stream.WriteObjectEnd()
+			stream.WriteArrayEnd()
+			return stream.Flush()
+		}
+		stream.WriteObjectField("block")
+		stream.WriteUint64(blockNum)
+		stream.WriteMore()
+		stream.WriteObjectField("result")
+		if _, err := stream.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		stream.WriteObjectEnd()
+		if err := stream.Flush(); err != nil {
+			return err
+		}
+	}
+	if last < to {
+		stream.WriteMore()
+		stream.WriteObjectStart()
+		stream.WriteObjectField("next")
+		stream.WriteUint64(last + 1)
+		stream.WriteObjectEnd()
+	}
+	stream.WriteArrayEnd()
+	return stream.Flush()
+}
+
 // TraceTransaction implements debug_traceTransaction. Returns Geth style transaction traces.
 func (api *DebugAPIImpl) TraceTransaction(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream jsonstream.Stream) error {
 	tx, err := api.db.BeginTemporalRo(ctx)
@@ -420,7 +500,7 @@ func (api *DebugAPIImpl) TraceCall(ctx context.Context, args ethapi.CallArgs, bl
 
 	blockCtx := transactions.NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, dbtx, api._blockReader, chainConfig)
 	if config != nil && config.BlockOverrides != nil {
-		err := config.BlockOverrides.Override(blockCtx)
+		err := config.BlockOverrides.Override(&blockCtx)
 		if err != nil {
 			return err
 		}
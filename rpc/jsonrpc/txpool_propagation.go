@@ -0,0 +1,48 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/txnprovider/txpool"
+)
+
+// TxPoolPropagationAPI - txpool_propagation: when the transaction was first seen, from which peer, and when it
+// was received from and sent to peers. Available only with the embedded rpcdaemon and internal txpool,
+// with --txpool.propagation.window set.
+type TxPoolPropagationAPI interface {
+	Propagation(ctx context.Context, hash common.Hash) (*txpool.TxnPropagation, error)
+}
+
+type TxPoolPropagationAPIImpl struct {
+	reader txpool.TxnPropagationReader
+}
+
+func NewTxPoolPropagationAPI(reader txpool.TxnPropagationReader) *TxPoolPropagationAPIImpl {
+	return &TxPoolPropagationAPIImpl{reader: reader}
+}
+
+// Propagation - returns nil if the transaction was not seen within the window
+func (api *TxPoolPropagationAPIImpl) Propagation(ctx context.Context, hash common.Hash) (*txpool.TxnPropagation, error) {
+	res, ok := api.reader.TxnPropagation(hash)
+	if !ok {
+		return nil, nil
+	}
+	return &res, nil
+}
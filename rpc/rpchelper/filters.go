@@ -52,22 +52,31 @@ type Filters struct {
 
 	pendingBlock *types.Block
 
-	headsSubs        *concurrent.SyncMap[HeadsSubID, Sub[*types.Header]]
-	pendingLogsSubs  *concurrent.SyncMap[PendingLogsSubID, Sub[types.Logs]]
-	pendingBlockSubs *concurrent.SyncMap[PendingBlockSubID, Sub[*types.Block]]
-	pendingTxsSubs   *concurrent.SyncMap[PendingTxsSubID, Sub[[]types.Transaction]]
-	logsSubs         *LogsFilterAggregator
-	logsRequestor    atomic.Value
-	onNewSnapshot    func()
+	headsSubs          *concurrent.SyncMap[HeadsSubID, Sub[*types.Header]]
+	safeHeadsSubs      *concurrent.SyncMap[HeadsSubID, Sub[*types.Header]]
+	finalizedHeadsSubs *concurrent.SyncMap[HeadsSubID, Sub[*types.Header]]
+	pendingLogsSubs    *concurrent.SyncMap[PendingLogsSubID, Sub[types.Logs]]
+	pendingBlockSubs   *concurrent.SyncMap[PendingBlockSubID, Sub[*types.Block]]
+	pendingTxsSubs     *concurrent.SyncMap[PendingTxsSubID, Sub[[]types.Transaction]]
+	logsSubs           *LogsFilterAggregator
+	logsRequestor      atomic.Value
+	onNewSnapshot      func()
 
 	logsStores         *concurrent.SyncMap[LogsSubID, []*types.Log]
 	pendingHeadsStores *concurrent.SyncMap[HeadsSubID, []*types.Header]
 	pendingTxsStores   *concurrent.SyncMap[PendingTxsSubID, [][]types.Transaction]
 	logger             log.Logger
 
+	forkchoiceReader            ForkchoiceReader
+	lastSafeHead, lastFinalHead common.Hash // protected by mu
+
 	config FiltersConfig
 }
 
+// ForkchoiceReader returns the safe and finalized heads of the latest engine forkchoice state,
+// nil if they are unknown.
+type ForkchoiceReader func(ctx context.Context) (safe, finalized *types.Header, err error)
+
 // New creates a new Filters instance, initializes it, and starts subscription goroutines for Ethereum events.
 // It requires a context, Ethereum backend, transaction pool client, mining client, snapshot callback function,
 // and a logger for logging events.
@@ -76,6 +85,8 @@ func New(ctx context.Context, config FiltersConfig, ethBackend ApiBackend, txPoo
 
 	ff := &Filters{
 		headsSubs:          concurrent.NewSyncMap[HeadsSubID, Sub[*types.Header]](),
+		safeHeadsSubs:      concurrent.NewSyncMap[HeadsSubID, Sub[*types.Header]](),
+		finalizedHeadsSubs: concurrent.NewSyncMap[HeadsSubID, Sub[*types.Header]](),
 		pendingTxsSubs:     concurrent.NewSyncMap[PendingTxsSubID, Sub[[]types.Transaction]](),
 		pendingLogsSubs:    concurrent.NewSyncMap[PendingLogsSubID, Sub[types.Logs]](),
 		pendingBlockSubs:   concurrent.NewSyncMap[PendingBlockSubID, Sub[*types.Block]](),
@@ -371,6 +382,96 @@ func (ff *Filters) UnsubscribeHeads(id HeadsSubID) bool {
 	return true
 }
 
+// SetForkchoiceReader sets the reader of the forkchoice state which drives safe and finalized heads subscriptions.
+func (ff *Filters) SetForkchoiceReader(reader ForkchoiceReader) {
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	ff.forkchoiceReader = reader
+}
+
+// SubscribeSafeHeads subscribes to changes of the safe head of the forkchoice state and returns a channel
+// to receive the headers and a subscription ID to manage the subscription.
+func (ff *Filters) SubscribeSafeHeads(size int) (<-chan *types.Header, HeadsSubID) {
+	id := HeadsSubID(generateSubscriptionID())
+	sub := newChanSub[*types.Header](size)
+	ff.safeHeadsSubs.Put(id, sub)
+	return sub.ch, id
+}
+
+// UnsubscribeSafeHeads unsubscribes from safe heads using the given subscription ID.
+func (ff *Filters) UnsubscribeSafeHeads(id HeadsSubID) bool {
+	sub, ok := ff.safeHeadsSubs.Delete(id)
+	if !ok {
+		return false
+	}
+	sub.Close()
+	return true
+}
+
+// SubscribeFinalizedHeads subscribes to changes of the finalized head of the forkchoice state and returns a channel
+// to receive the headers and a subscription ID to manage the subscription.
+func (ff *Filters) SubscribeFinalizedHeads(size int) (<-chan *types.Header, HeadsSubID) {
+	id := HeadsSubID(generateSubscriptionID())
+	sub := newChanSub[*types.Header](size)
+	ff.finalizedHeadsSubs.Put(id, sub)
+	return sub.ch, id
+}
+
+// UnsubscribeFinalizedHeads unsubscribes from finalized heads using the given subscription ID.
+func (ff *Filters) UnsubscribeFinalizedHeads(id HeadsSubID) bool {
+	sub, ok := ff.finalizedHeadsSubs.Delete(id)
+	if !ok {
+		return false
+	}
+	sub.Close()
+	return true
+}
+
+// OnNewForkchoice notifies subscribers of the safe and finalized heads which changed since the last call.
+// Nil heads are ignored.
+func (ff *Filters) OnNewForkchoice(safe, finalized *types.Header) {
+	ff.mu.Lock()
+	safeChanged := safe != nil && safe.Hash() != ff.lastSafeHead
+	finalizedChanged := finalized != nil && finalized.Hash() != ff.lastFinalHead
+	if safeChanged {
+		ff.lastSafeHead = safe.Hash()
+	}
+	if finalizedChanged {
+		ff.lastFinalHead = finalized.Hash()
+	}
+	ff.mu.Unlock()
+
+	if safeChanged {
+		ff.safeHeadsSubs.Range(func(k HeadsSubID, v Sub[*types.Header]) error {
+			v.Send(safe)
+			return nil
+		})
+	}
+	if finalizedChanged {
+		ff.finalizedHeadsSubs.Range(func(k HeadsSubID, v Sub[*types.Header]) error {
+			v.Send(finalized)
+			return nil
+		})
+	}
+}
+
+// onNewForkchoice reads the forkchoice state, which is committed before new headers are announced,
+// if anybody is subscribed to it.
+func (ff *Filters) onNewForkchoice() error {
+	ff.mu.RLock()
+	reader := ff.forkchoiceReader
+	ff.mu.RUnlock()
+	if reader == nil || (ff.safeHeadsSubs.Len() == 0 && ff.finalizedHeadsSubs.Len() == 0) {
+		return nil
+	}
+	safe, finalized, err := reader(context.Background())
+	if err != nil {
+		return fmt.Errorf("reading forkchoice: %w", err)
+	}
+	ff.OnNewForkchoice(safe, finalized)
+	return nil
+}
+
 // SubscribePendingLogs subscribes to pending logs and returns a channel to receive the logs
 // and a subscription ID to manage the subscription. It uses the specified filter criteria.
 func (ff *Filters) SubscribePendingLogs(size int) (<-chan types.Logs, PendingLogsSubID) {
@@ -621,10 +722,13 @@ func (ff *Filters) onNewHeader(event *remote.SubscribeReply) error {
 	if err != nil {
 		return fmt.Errorf("unprocessable payload: %w", err)
 	}
-	return ff.headsSubs.Range(func(k HeadsSubID, v Sub[*types.Header]) error {
+	if err := ff.headsSubs.Range(func(k HeadsSubID, v Sub[*types.Header]) error {
 		v.Send(&header)
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	return ff.onNewForkchoice()
 }
 
 // OnNewTx handles a new transaction event from the transaction pool and processes it.
@@ -18,6 +18,7 @@ package rpchelper
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/holiman/uint256"
@@ -27,6 +28,7 @@ import (
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
 	types2 "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/eth/filters"
 )
@@ -181,6 +183,39 @@ func TestFilters_TwoSubscriptionsWithDifferentCriteria(t *testing.T) {
 	}
 }
 
+func TestFilters_RemovedLogDoesNotOverwriteUndeliveredLog(t *testing.T) {
+	t.Parallel()
+	config := FiltersConfig{}
+	f := New(context.TODO(), config, nil, nil, nil, func() {}, log.New())
+
+	chan1, _ := f.SubscribeLogs(256, filters.FilterCriteria{})
+	chan2, _ := f.SubscribeLogs(256, filters.FilterCriteria{Topics: [][]common.Hash{{topic1}}})
+
+	lg := createLog()
+	lg.Topics = []*types2.H256{topic1H256}
+	lg.BlockNumber = 10
+	f.OnNewLogs(lg)
+
+	removed := createLog()
+	removed.Topics = []*types2.H256{topic1H256}
+	removed.BlockNumber = 10
+	removed.Removed = true
+	f.OnNewLogs(removed)
+
+	for i, ch := range []<-chan *types.Log{chan1, chan2} {
+		if len(ch) != 2 {
+			t.Fatalf("channel %d: expected 2 logs, got %d", i, len(ch))
+		}
+		first, second := <-ch, <-ch
+		if first == second {
+			t.Errorf("channel %d: log instance is reused", i)
+		}
+		if first.Removed || !second.Removed {
+			t.Errorf("channel %d: expected added log followed by removed one", i)
+		}
+	}
+}
+
 func TestFilters_ThreeSubscriptionsWithDifferentCriteria(t *testing.T) {
 	t.Parallel()
 	config := FiltersConfig{}
@@ -504,3 +539,60 @@ func TestFilters_AddPendingTxs(t *testing.T) {
 		})
 	}
 }
+
+func TestFilters_ForkchoiceHeads(t *testing.T) {
+	f := New(context.TODO(), DefaultFiltersConfig, nil, nil, nil, func() {}, log.New())
+	safe, finalized := &types.Header{Number: big.NewInt(2)}, &types.Header{Number: big.NewInt(1)}
+	reads := 0
+	f.SetForkchoiceReader(func(ctx context.Context) (*types.Header, *types.Header, error) {
+		reads++
+		return safe, finalized, nil
+	})
+	newHeader := func(number int64) {
+		t.Helper()
+		payload, err := rlp.EncodeToBytes(&types.Header{Number: big.NewInt(number)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.onNewEvent(&remote.SubscribeReply{Type: remote.Event_HEADER, Data: payload}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the forkchoice state isn't read without subscribers
+	newHeader(3)
+	if reads != 0 {
+		t.Fatalf("Expected no forkchoice reads, got %d", reads)
+	}
+
+	safeHeads, safeID := f.SubscribeSafeHeads(8)
+	finalizedHeads, finalizedID := f.SubscribeFinalizedHeads(8)
+	newHeader(4)
+	if h := <-safeHeads; h.Hash() != safe.Hash() {
+		t.Fatalf("Expected safe head %d, got %d", safe.Number, h.Number)
+	}
+	if h := <-finalizedHeads; h.Hash() != finalized.Hash() {
+		t.Fatalf("Expected finalized head %d, got %d", finalized.Number, h.Number)
+	}
+
+	// only changed heads are sent
+	safe = &types.Header{Number: big.NewInt(3)}
+	newHeader(5)
+	if h := <-safeHeads; h.Hash() != safe.Hash() {
+		t.Fatalf("Expected safe head %d, got %d", safe.Number, h.Number)
+	}
+	if len(finalizedHeads) != 0 {
+		t.Fatal("Expected no finalized head")
+	}
+
+	if !f.UnsubscribeSafeHeads(safeID) || !f.UnsubscribeFinalizedHeads(finalizedID) {
+		t.Fatal("Expected to unsubscribe")
+	}
+	if _, ok := <-safeHeads; ok {
+		t.Fatal("Expected closed channel")
+	}
+	newHeader(6)
+	if reads != 2 {
+		t.Fatalf("Expected 2 forkchoice reads, got %d", reads)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/common"
@@ -141,6 +142,28 @@ func _GetBlockNumber(ctx context.Context, requireCanonical bool, blockNrOrHash r
 	return blockNumber, hash, blockNumber == plainStateBlockNumber, true, nil
 }
 
+// GetRangeBlockNumber resolves a block range bound of a log filter (`fromBlock`/`toBlock`): either a block number
+// or a block tag - "latest", "safe", "finalized", etc. - which filters.FilterCriteria decodes into a negative number.
+func GetRangeBlockNumber(ctx context.Context, n *big.Int, tx kv.Tx, br services.FullBlockReader, filters *Filters) (uint64, error) {
+	if n.Sign() >= 0 {
+		if !n.IsUint64() {
+			return 0, fmt.Errorf("block number out of range: %v", n)
+		}
+		return n.Uint64(), nil
+	}
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("invalid block number: %v", n)
+	}
+	// unknown negative value would fall through to `default` of _GetBlockNumber and wrap around uint64
+	switch tag := rpc.BlockNumber(n.Int64()); tag {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber, rpc.LatestExecutedBlockNumber:
+		blockNumber, _, _, err := GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(tag), tx, br, filters)
+		return blockNumber, err
+	default:
+		return 0, fmt.Errorf("negative value for block number: %v", n)
+	}
+}
+
 func CreateStateReader(ctx context.Context, tx kv.TemporalTx, br services.FullBlockReader, blockNrOrHash rpc.BlockNumberOrHash, txnIndex int, filters *Filters, stateCache kvcache.Cache, txNumReader rawdbv3.TxNumsReader) (state.StateReader, error) {
 	blockNumber, _, latest, _, err := _GetBlockNumber(ctx, true, blockNrOrHash, tx, br, filters)
 	if err != nil {
@@ -0,0 +1,43 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpchelper
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRangeBlockNumber_Numbers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	n, err := GetRangeBlockNumber(ctx, big.NewInt(42), nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), n)
+
+	_, err = GetRangeBlockNumber(ctx, new(big.Int).Lsh(big.NewInt(1), 64), nil, nil, nil)
+	require.Error(t, err)
+
+	// not a known block tag: must not wrap around uint64
+	for _, v := range []int64{-6, -100} {
+		_, err = GetRangeBlockNumber(ctx, big.NewInt(v), nil, nil, nil)
+		require.ErrorContains(t, err, "negative value for block number")
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpchelper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// LogsCursorMaxBlocks - how many most recent blocks with delivered logs are journaled per cursor.
+// Reorgs deeper than this can't be reported with `removed` events after a resubscription.
+const LogsCursorMaxBlocks = 128
+
+// LogsCursorBacklogPage - missed logs are re-delivered by ranges of this many blocks
+const LogsCursorBacklogPage = 1_000
+
+// LogsCursorSaveInterval - how often the journal of a live subscription is persisted
+const LogsCursorSaveInterval = 5 * time.Second
+
+var logsCursorIDRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+var ErrInvalidLogsCursorID = errors.New("invalid logs cursor id: expected 1-64 characters of [A-Za-z0-9_-]")
+var ErrLogsCursorInUse = errors.New("logs cursor is already used by another subscription")
+
+// LogsCursorBlock - logs of one block delivered to the subscriber
+type LogsCursorBlock struct {
+	Number uint64       `json:"number"`
+	Hash   common.Hash  `json:"hash"`
+	Logs   []*types.Log `json:"logs"`
+}
+
+// LogsCursor - journal of logs recently delivered to a named `logs` subscription. It lets a client which
+// resubscribes with the same cursor (also after rpcdaemon restart) receive `removed` events for
+// the blocks reorged out while it was away, followed by the canonical logs it missed.
+type LogsCursor struct {
+	Synced uint64            `json:"synced"` // highest block number with delivered logs
+	Blocks []LogsCursorBlock `json:"blocks"` // ordered by block number
+}
+
+// Delivered - log was sent to the subscriber, a `removed` log drops it from the journal
+func (c *LogsCursor) Delivered(lg *types.Log) {
+	if lg.Removed {
+		for i := range c.Blocks {
+			b := &c.Blocks[i]
+			if b.Hash != lg.BlockHash {
+				continue
+			}
+			for j, l := range b.Logs {
+				if l.Index == lg.Index {
+					b.Logs = append(b.Logs[:j], b.Logs[j+1:]...)
+					break
+				}
+			}
+			if len(b.Logs) == 0 {
+				c.Blocks = append(c.Blocks[:i], c.Blocks[i+1:]...)
+			}
+			break
+		}
+		return
+	}
+
+	if n := len(c.Blocks); n > 0 && c.Blocks[n-1].Hash == lg.BlockHash {
+		c.Blocks[n-1].Logs = append(c.Blocks[n-1].Logs, lg)
+	} else {
+		// a block on a new fork replaces the journaled blocks of the same height and above
+		for n > 0 && c.Blocks[n-1].Number >= lg.BlockNumber {
+			n--
+		}
+		c.Blocks = append(c.Blocks[:n], LogsCursorBlock{Number: lg.BlockNumber, Hash: lg.BlockHash, Logs: []*types.Log{lg}})
+	}
+	c.Synced = lg.BlockNumber
+	if len(c.Blocks) > LogsCursorMaxBlocks {
+		c.Blocks = c.Blocks[len(c.Blocks)-LogsCursorMaxBlocks:]
+	}
+}
+
+// Contains - log of non-removed block was already delivered
+func (c *LogsCursor) Contains(lg *types.Log) bool {
+	for i := len(c.Blocks) - 1; i >= 0; i-- {
+		b := &c.Blocks[i]
+		if b.Hash != lg.BlockHash {
+			continue
+		}
+		for _, l := range b.Logs {
+			if l.Index == lg.Index {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// Rewind - removes journaled blocks for which `isCanonical` reports false and returns their logs
+// with `Removed` set, newest first - in the order they have to be sent to the subscriber.
+// Returns also the block number from which canonical logs have to be re-delivered.
+func (c *LogsCursor) Rewind(isCanonical func(num uint64, hash common.Hash) (bool, error)) (removed []*types.Log, resumeFrom uint64, err error) {
+	resumeFrom = c.Synced
+	n := len(c.Blocks)
+	for n > 0 {
+		b := c.Blocks[n-1]
+		ok, err := isCanonical(b.Number, b.Hash)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			break
+		}
+		for i := len(b.Logs) - 1; i >= 0; i-- {
+			lg := *b.Logs[i]
+			lg.Removed = true
+			removed = append(removed, &lg)
+		}
+		resumeFrom = b.Number
+		n--
+	}
+	c.Blocks = c.Blocks[:n]
+	if len(removed) > 0 {
+		if n > 0 {
+			c.Synced = c.Blocks[n-1].Number
+		} else {
+			c.Synced = resumeFrom
+		}
+	}
+	return removed, resumeFrom, nil
+}
+
+// LogsCursorStore - keeps LogsCursor of every named subscription as a json file in `dir`
+type LogsCursorStore struct {
+	dir    string
+	mu     sync.Mutex
+	active map[string]struct{}
+}
+
+func NewLogsCursorStore(dir string) *LogsCursorStore {
+	return &LogsCursorStore{dir: dir, active: map[string]struct{}{}}
+}
+
+// Acquire - cursor can be used by only 1 subscription at a time: otherwise they would overwrite each other's journal
+func (s *LogsCursorStore) Acquire(id string) (release func(), err error) {
+	if _, err := s.path(id); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.active[id]; ok {
+		return nil, ErrLogsCursorInUse
+	}
+	s.active[id] = struct{}{}
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.active, id)
+	}, nil
+}
+
+func (s *LogsCursorStore) path(id string) (string, error) {
+	if !logsCursorIDRe.MatchString(id) {
+		return "", ErrInvalidLogsCursorID
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// Load - returns empty cursor if it doesn't exist yet
+func (s *LogsCursorStore) Load(id string) (*LogsCursor, error) {
+	p, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return &LogsCursor{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c := &LogsCursor{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("logs cursor %s: %w", id, err)
+	}
+	return c, nil
+}
+
+func (s *LogsCursorStore) Save(id string, c *LogsCursor) error {
+	p, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	return dir.WriteFileWithFsync(p, data, 0o644)
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpchelper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func cursorLog(num uint64, hash byte, index uint) *types.Log {
+	return &types.Log{
+		Address:     address1,
+		Topics:      []common.Hash{topic1},
+		Data:        []byte{},
+		BlockNumber: num,
+		BlockHash:   common.Hash{hash},
+		TxHash:      common.Hash{hash, byte(index)},
+		Index:       index,
+	}
+}
+
+func TestLogsCursor_RewindAfterReorg(t *testing.T) {
+	t.Parallel()
+	c := &LogsCursor{}
+	c.Delivered(cursorLog(10, 0xa, 0))
+	c.Delivered(cursorLog(11, 0xb, 1))
+	c.Delivered(cursorLog(11, 0xb, 2))
+	c.Delivered(cursorLog(12, 0xc, 3))
+	require.True(t, c.Contains(cursorLog(11, 0xb, 2)))
+	require.Equal(t, uint64(12), c.Synced)
+
+	// blocks 11 and 12 were reorged out
+	canonical := map[uint64]common.Hash{10: {0xa}, 11: {0xbb}, 12: {0xcc}}
+	removed, resumeFrom, err := c.Rewind(func(num uint64, hash common.Hash) (bool, error) {
+		return canonical[num] == hash, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), resumeFrom)
+	require.Equal(t, uint64(10), c.Synced)
+	require.Len(t, removed, 3)
+	for i, idx := range []uint{3, 2, 1} {
+		require.True(t, removed[i].Removed)
+		require.Equal(t, idx, removed[i].Index)
+	}
+	require.False(t, c.Contains(cursorLog(11, 0xb, 1)))
+	require.True(t, c.Contains(cursorLog(10, 0xa, 0)))
+}
+
+func TestLogsCursor_LiveReorg(t *testing.T) {
+	t.Parallel()
+	c := &LogsCursor{}
+	c.Delivered(cursorLog(10, 0xa, 0))
+	c.Delivered(cursorLog(11, 0xb, 1))
+
+	removed := cursorLog(11, 0xb, 1)
+	removed.Removed = true
+	c.Delivered(removed)
+	require.Len(t, c.Blocks, 1)
+
+	// a sibling block replaces journaled blocks of the same height even without `removed` events
+	c.Delivered(cursorLog(12, 0xc, 0))
+	c.Delivered(cursorLog(12, 0xd, 0))
+	require.Len(t, c.Blocks, 2)
+	require.Equal(t, common.Hash{0xd}, c.Blocks[1].Hash)
+}
+
+func TestLogsCursorStore(t *testing.T) {
+	t.Parallel()
+	s := NewLogsCursorStore(t.TempDir())
+
+	c, err := s.Load("indexer-1")
+	require.NoError(t, err)
+	require.Empty(t, c.Blocks)
+
+	c.Delivered(cursorLog(10, 0xa, 0))
+	require.NoError(t, s.Save("indexer-1", c))
+
+	loaded, err := s.Load("indexer-1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), loaded.Synced)
+	require.True(t, loaded.Contains(cursorLog(10, 0xa, 0)))
+
+	_, err = s.Load("../escape")
+	require.ErrorIs(t, err, ErrInvalidLogsCursorID)
+}
+
+func TestLogsCursorStore_Acquire(t *testing.T) {
+	t.Parallel()
+	s := NewLogsCursorStore(t.TempDir())
+
+	release, err := s.Acquire("indexer-1")
+	require.NoError(t, err)
+	_, err = s.Acquire("indexer-1")
+	require.ErrorIs(t, err, ErrLogsCursorInUse)
+
+	other, err := s.Acquire("indexer-2")
+	require.NoError(t, err)
+	other()
+
+	release()
+	release, err = s.Acquire("indexer-1")
+	require.NoError(t, err)
+	release()
+
+	_, err = s.Acquire("../escape")
+	require.ErrorIs(t, err, ErrInvalidLogsCursorID)
+}
@@ -219,9 +219,6 @@ func (a *LogsFilterAggregator) distributeLog(eventLog *remote.SubscribeLogsReply
 	a.logsFilterLock.RLock()
 	defer a.logsFilterLock.RUnlock()
 
-	var lg types.Log
-	var topics []common.Hash
-
 	a.logsFilters.Range(func(k LogsSubID, filter *LogsFilter) error {
 		if filter.allAddrs == 0 {
 			_, addrOk := filter.addrs.Get(gointerfaces.ConvertH160toAddress(eventLog.Address))
@@ -230,11 +227,10 @@ func (a *LogsFilterAggregator) distributeLog(eventLog *remote.SubscribeLogsReply
 			}
 		}
 
-		// Pre-allocate topics slice to the required size to avoid multiple allocations
-		topics = topics[:0]
-		if cap(topics) < len(eventLog.Topics) {
-			topics = make([]common.Hash, 0, len(eventLog.Topics))
-		}
+		// Every subscriber gets its own log and topics: senders are buffered channels and consumers
+		// may read the log long after distribution, so sharing one instance would let the next event
+		// (e.g. the `removed` counterpart of a reorged log) overwrite the one not yet delivered.
+		topics := make([]common.Hash, 0, len(eventLog.Topics))
 		for _, topic := range eventLog.Topics {
 			topics = append(topics, gointerfaces.ConvertH256ToHash(topic))
 		}
@@ -245,18 +241,17 @@ func (a *LogsFilterAggregator) distributeLog(eventLog *remote.SubscribeLogsReply
 			}
 		}
 
-		// Reuse lg object to avoid creating new instances
-		lg.Address = gointerfaces.ConvertH160toAddress(eventLog.Address)
-		lg.Topics = topics
-		lg.Data = eventLog.Data
-		lg.BlockNumber = eventLog.BlockNumber
-		lg.TxHash = gointerfaces.ConvertH256ToHash(eventLog.TransactionHash)
-		lg.TxIndex = uint(eventLog.TransactionIndex)
-		lg.BlockHash = gointerfaces.ConvertH256ToHash(eventLog.BlockHash)
-		lg.Index = uint(eventLog.LogIndex)
-		lg.Removed = eventLog.Removed
-
-		filter.sender.Send(&lg)
+		filter.sender.Send(&types.Log{
+			Address:     gointerfaces.ConvertH160toAddress(eventLog.Address),
+			Topics:      topics,
+			Data:        eventLog.Data,
+			BlockNumber: eventLog.BlockNumber,
+			TxHash:      gointerfaces.ConvertH256ToHash(eventLog.TransactionHash),
+			TxIndex:     uint(eventLog.TransactionIndex),
+			BlockHash:   gointerfaces.ConvertH256ToHash(eventLog.BlockHash),
+			Index:       uint(eventLog.LogIndex),
+			Removed:     eventLog.Removed,
+		})
 		return nil
 	})
 	return nil
@@ -51,6 +51,7 @@ const (
 type Server struct {
 	services        serviceRegistry
 	methodAllowList AllowList
+	apiKeys         *APIKeys
 	idgen           func() ID
 	run             int32
 	codecs          mapset.Set // mapset.Set[ServerCodec] requires go 1.20
@@ -60,6 +61,7 @@ type Server struct {
 	traceRequests       bool // Whether to print requests at INFO level
 	debugSingleRequest  bool // Whether to print requests at INFO level
 	batchLimit          int  // Maximum number of requests in a batch
+	subLimits           SubscriptionLimits
 	logger              log.Logger
 	rpcSlowLogThreshold time.Duration
 }
@@ -80,11 +82,21 @@ func (s *Server) SetAllowList(allowList AllowList) {
 	s.methodAllowList = allowList
 }
 
+// SetAPIKeys - requires every HTTP request and websocket connection to present one of the keys
+func (s *Server) SetAPIKeys(keys *APIKeys) {
+	s.apiKeys = keys
+}
+
 // SetBatchLimit sets limit of number of requests in a batch
 func (s *Server) SetBatchLimit(limit int) {
 	s.batchLimit = limit
 }
 
+// SetSubscriptionLimits bounds the notifications buffered for the subscriptions of every connection
+func (s *Server) SetSubscriptionLimits(limits SubscriptionLimits) {
+	s.subLimits = limits
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either a RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -110,7 +122,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services, s.logger)
+	c := initClient(codec, s.idgen, &s.services, s.subLimits, s.logger)
 	<-codec.closed()
 	c.Close()
 }
@@ -200,6 +212,9 @@ type PeerInfo struct {
 		Origin    string
 		Host      string
 	}
+
+	// APIKey the connection is authenticated with, nil if server has no api keys.
+	APIKey *APIKey
 }
 
 type peerInfoContextKey struct{}
@@ -152,15 +152,22 @@ func (n *LocalNotifier) Closed() <-chan interface{} {
 }
 
 // RemoteNotifier is tied to a RPC connection that supports subscriptions.
+//
+// Notifications are queued and written to the connection by a goroutine of the notifier, so a
+// client reading them slowly doesn't block their producer. The queued bytes are bounded by the
+// SubscriptionLimits of the connection.
 type RemoteNotifier struct {
 	h         *handler
 	namespace string
 
-	mu           sync.Mutex
-	sub          *Subscription
-	buffer       []json.RawMessage
-	callReturned bool
-	activated    bool
+	mu            sync.Mutex
+	sub           *Subscription
+	buffer        []json.RawMessage
+	bufferedBytes int64
+	callReturned  bool
+	activated     bool
+	sending       bool  // a goroutine writes the buffer to the connection
+	err           error // the connection failed or was closed for a slow consumer
 }
 
 // CreateSubscription returns a new subscription that is coupled to the
@@ -180,8 +187,9 @@ func (n *RemoteNotifier) CreateSubscription() *Subscription {
 	return n.sub
 }
 
-// Notify sends a notification to the client with the given data as payload.
-// If an error occurs the RPC connection is closed and the error is returned.
+// Notify queues a notification to the client with the given data as payload.
+// If writing to the connection failed, or the connection was closed because
+// the client is a slow consumer, the error is returned.
 func (n *RemoteNotifier) Notify(id ID, data interface{}) error {
 	enc, err := json.Marshal(data)
 	if err != nil {
@@ -196,10 +204,28 @@ func (n *RemoteNotifier) Notify(id ID, data interface{}) error {
 	} else if n.sub.ID != id {
 		panic("Notify with wrong ID")
 	}
-	if n.activated {
-		return n.send(n.sub, enc)
+	if n.err != nil {
+		return n.err
+	}
+	size := int64(len(enc))
+	limits := n.h.subLimits
+	if limits.exceeded(n.bufferedBytes, n.h.notifyBytes.Load(), size) {
+		if limits.SlowConsumer == DropSlowConsumerNotifications {
+			subscriptionDroppedMeter.Inc()
+			n.h.logger.Trace("Dropped notification of slow consumer", "id", id, "buffered", n.bufferedBytes)
+			return nil
+		}
+		subscriptionDisconnected.Inc()
+		n.h.logger.Debug("Disconnecting slow subscription consumer", "id", id, "buffered", n.bufferedBytes, "client", n.h.notifyBytes.Load())
+		n.fail(ErrSlowConsumer)
+		if c, ok := n.h.conn.(interface{ Close() }); ok {
+			go c.Close()
+		}
+		return n.err
 	}
 	n.buffer = append(n.buffer, enc)
+	n.account(size)
+	n.startSending()
 	return nil
 }
 
@@ -221,17 +247,66 @@ func (n *RemoteNotifier) takeSubscription() *Subscription {
 // activate is called after the subscription ID was sent to client. Notifications are
 // buffered before activation. This prevents notifications being sent to the client before
 // the subscription ID is sent to the client.
-func (n *RemoteNotifier) activate() error {
+func (n *RemoteNotifier) activate() {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	for _, data := range n.buffer {
-		if err := n.send(n.sub, data); err != nil {
-			return err
+	n.activated = true
+	n.startSending()
+}
+
+// startSending starts the goroutine writing the buffered notifications, unless it runs.
+// n.mu must be held.
+func (n *RemoteNotifier) startSending() {
+	if !n.activated || n.sending || len(n.buffer) == 0 {
+		return
+	}
+	n.sending = true
+	go n.sendLoop()
+}
+
+// sendLoop writes the buffered notifications in order until the buffer is empty.
+func (n *RemoteNotifier) sendLoop() {
+	for {
+		n.mu.Lock()
+		if len(n.buffer) == 0 || n.err != nil {
+			n.sending = false
+			n.mu.Unlock()
+			return
 		}
+		data := n.buffer[0]
+		n.buffer[0] = nil
+		n.buffer = n.buffer[1:]
+		n.mu.Unlock()
+
+		err := n.send(n.sub, data)
+
+		n.mu.Lock()
+		n.account(-int64(len(data)))
+		if err != nil && n.err == nil {
+			n.fail(err)
+		}
+		n.mu.Unlock()
 	}
-	n.activated = true
-	return nil
+}
+
+// account adds delta to the bytes buffered for the subscription and its client.
+// n.mu must be held.
+func (n *RemoteNotifier) account(delta int64) {
+	n.bufferedBytes += delta
+	n.h.notifyBytes.Add(delta)
+	subscriptionBufferedBytes.Add(float64(delta))
+}
+
+// fail drops the buffered notifications, later ones fail with err. n.mu must be held.
+func (n *RemoteNotifier) fail(err error) {
+	n.err = err
+	var size int64
+	for _, data := range n.buffer {
+		size += int64(len(data))
+	}
+	n.account(-size)
+	n.buffer = nil
 }
 
 func (n *RemoteNotifier) send(sub *Subscription, data json.RawMessage) error {
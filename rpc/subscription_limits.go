@@ -0,0 +1,87 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// ErrSlowConsumer is returned by Notify once the connection of a client, which doesn't read
+// its notifications fast enough, was closed by the DisconnectSlowConsumer policy.
+var ErrSlowConsumer = errors.New("subscription client too slow, disconnected")
+
+var (
+	subscriptionBufferedBytes = metrics.GetOrCreateGauge("rpc_subscription_buffered_bytes")
+	subscriptionDroppedMeter  = metrics.GetOrCreateCounter(`rpc_subscription_slow_consumer{action="drop"}`)
+	subscriptionDisconnected  = metrics.GetOrCreateCounter(`rpc_subscription_slow_consumer{action="disconnect"}`)
+)
+
+// SlowConsumerPolicy is applied to a notification which would take the bytes buffered for a
+// subscription or a client over their SubscriptionLimits.
+type SlowConsumerPolicy uint8
+
+const (
+	// DisconnectSlowConsumer closes the connection of the client, ending all its subscriptions.
+	DisconnectSlowConsumer SlowConsumerPolicy = iota
+	// DropSlowConsumerNotifications drops the notification and keeps the client connected.
+	DropSlowConsumerNotifications
+)
+
+func (p SlowConsumerPolicy) String() string {
+	switch p {
+	case DisconnectSlowConsumer:
+		return "disconnect"
+	case DropSlowConsumerNotifications:
+		return "drop"
+	default:
+		return fmt.Sprintf("SlowConsumerPolicy(%d)", uint8(p))
+	}
+}
+
+// ParseSlowConsumerPolicy parses the name of a policy: "disconnect" (the default, also for an
+// empty name) or "drop".
+func ParseSlowConsumerPolicy(name string) (SlowConsumerPolicy, error) {
+	switch name {
+	case "", "disconnect":
+		return DisconnectSlowConsumer, nil
+	case "drop":
+		return DropSlowConsumerNotifications, nil
+	default:
+		return 0, fmt.Errorf("unknown slow consumer policy %q, expected disconnect or drop", name)
+	}
+}
+
+// SubscriptionLimits bound the notification bytes buffered for clients of subscriptions (websocket,
+// IPC) which don't read them as fast as they are produced. Zero disables a limit.
+type SubscriptionLimits struct {
+	MaxSubscriptionBytes int64 // buffered for a single subscription
+	MaxClientBytes       int64 // buffered for all subscriptions of a connection
+	SlowConsumer         SlowConsumerPolicy
+}
+
+// exceeded reports whether buffering size more bytes takes a subscription with subBytes or a
+// client with clientBytes buffered over the limits. A notification is always buffered for a
+// subscription without pending ones, so that one larger than MaxSubscriptionBytes isn't lost.
+func (l SubscriptionLimits) exceeded(subBytes, clientBytes, size int64) bool {
+	if l.MaxSubscriptionBytes > 0 && subBytes > 0 && subBytes+size > l.MaxSubscriptionBytes {
+		return true
+	}
+	return l.MaxClientBytes > 0 && clientBytes+size > l.MaxClientBytes
+}
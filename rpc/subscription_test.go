@@ -20,10 +20,14 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -225,3 +229,75 @@ func readAndValidateMessage(in *json.Decoder) (*subConfirmation, *subscriptionRe
 		return nil, nil, fmt.Errorf("unrecognized message: %v", msg)
 	}
 }
+
+// blockingConn is a jsonWriter whose writes block until it's closed, like the connection of
+// a client not reading its notifications.
+type blockingConn struct {
+	closeOnce sync.Once
+	closeCh   chan interface{}
+}
+
+func (c *blockingConn) WriteJSON(ctx context.Context, v interface{}) error {
+	<-c.closeCh
+	return io.EOF
+}
+
+func (c *blockingConn) Close()                     { c.closeOnce.Do(func() { close(c.closeCh) }) }
+func (c *blockingConn) closed() <-chan interface{} { return c.closeCh }
+func (c *blockingConn) remoteAddr() string         { return "" }
+
+func TestSlowConsumer(t *testing.T) {
+	notification := strings.Repeat("x", 100) // 102 bytes encoded
+	newNotifier := func(limits SubscriptionLimits) (*RemoteNotifier, *blockingConn) {
+		conn := &blockingConn{closeCh: make(chan interface{})}
+		h := newHandler(context.Background(), conn, randomIDGenerator(), &serviceRegistry{}, nil, 1, false, log.New(), 0)
+		h.subLimits = limits
+		n := &RemoteNotifier{h: h, namespace: "eth"}
+		n.CreateSubscription()
+		n.takeSubscription()
+		n.activate()
+		return n, conn
+	}
+
+	t.Run("drop", func(t *testing.T) {
+		n, conn := newNotifier(SubscriptionLimits{MaxSubscriptionBytes: 250, SlowConsumer: DropSlowConsumerNotifications})
+		defer conn.Close()
+		for i := 0; i < 10; i++ {
+			if err := n.Notify(n.sub.ID, notification); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// At most 2 notifications queued and 1 in flight.
+		if buffered := n.h.notifyBytes.Load(); buffered > 3*102 {
+			t.Fatalf("buffered %d bytes over the limit", buffered)
+		}
+		select {
+		case <-conn.closed():
+			t.Fatal("connection closed by the drop policy")
+		default:
+		}
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		n, conn := newNotifier(SubscriptionLimits{MaxClientBytes: 250, SlowConsumer: DisconnectSlowConsumer})
+		var err error
+		for i := 0; i < 10 && err == nil; i++ {
+			err = n.Notify(n.sub.ID, notification)
+		}
+		if !errors.Is(err, ErrSlowConsumer) {
+			t.Fatalf("got %v, want %v", err, ErrSlowConsumer)
+		}
+		select {
+		case <-conn.closed():
+		case <-time.After(5 * time.Second):
+			t.Fatal("slow consumer not disconnected")
+		}
+		deadline := time.Now().Add(5 * time.Second)
+		for n.h.notifyBytes.Load() != 0 {
+			if time.Now().After(deadline) {
+				t.Fatalf("%d bytes still buffered after disconnect", n.h.notifyBytes.Load())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
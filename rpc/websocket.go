@@ -62,12 +62,22 @@ func (s *Server) WebsocketHandler(allowedOrigins []string, jwtSecret []byte, com
 		if jwtSecret != nil && !CheckJwtSecret(w, r, jwtSecret) {
 			return
 		}
+		var key *APIKey
+		if s.apiKeys != nil {
+			var code int
+			var err error
+			if key, code, err = s.apiKeys.authenticate(r); err != nil {
+				http.Error(w, err.Error(), code)
+				return
+			}
+		}
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			logger.Warn("WebSocket upgrade failed", "err", err)
 			return
 		}
 		codec := NewWebsocketCodec(conn, r.Host, r.Header)
+		codec.(*websocketCodec).info.APIKey = key
 		s.ServeCodec(codec, 0)
 	})
 }
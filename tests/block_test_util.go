@@ -117,14 +117,19 @@ type btHeaderMarshaling struct {
 	ExcessBlobGas *math.HexOrDecimal64
 }
 
-func (bt *BlockTest) Run(t *testing.T, checkStateRoot bool) error {
+// Network - fork the test is run with
+func (bt *BlockTest) Network() string {
+	return bt.json.Network
+}
+
+func (bt *BlockTest) Run(tb testing.TB, checkStateRoot bool) error {
 	config, ok := testutil.Forks[bt.json.Network]
 	if !ok {
 		return testutil.UnsupportedForkError{Name: bt.json.Network}
 	}
 
 	engine := ethconsensusconfig.CreateConsensusEngineBareBones(context.Background(), config, log.New())
-	m := mock.MockWithGenesisEngine(t, bt.genesis(config), engine, false, checkStateRoot)
+	m := mock.MockWithGenesisEngine(tb, bt.genesis(config), engine, false, checkStateRoot)
 	defer m.Close()
 
 	bt.br = m.BlockReader
@@ -0,0 +1,108 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/execution/eth1"
+)
+
+var badBlockHashFlag = cli.StringFlag{
+	Name:  "hash",
+	Usage: "Dump the bad block of this hash: its header and transactions (json) and rlp",
+}
+
+var badBlockOutFlag = cli.StringFlag{
+	Name:  "out",
+	Usage: "With --hash: write the rlp of the block to this file (as read by the import command)",
+}
+
+var badBlocksCommand = cli.Command{
+	Action: MigrateFlags(badBlocks),
+	Name:   "bad-blocks",
+	Usage:  "List or dump the last invalid blocks kept by the node",
+	Flags: []cli.Flag{
+		&utils.DataDirFlag,
+		&badBlockHashFlag,
+		&badBlockOutFlag,
+	},
+	Description: `
+The node keeps the last --sync.bad-blocks.limit invalid blocks received via engine_newPayload or p2p, with
+their validation error and the offending header field, in <datadir>/badblocks. Erigon may be running.`,
+}
+
+func badBlocks(cliCtx *cli.Context) error {
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	kept, err := eth1.ReadBadBlocks(filepath.Join(dirs.DataDir, eth1.BadBlocksDir))
+	if err != nil {
+		return err
+	}
+
+	if hash := cliCtx.String(badBlockHashFlag.Name); hash != "" {
+		for _, b := range kept {
+			if b.Hash == common.HexToHash(hash) {
+				return dumpBadBlock(b, cliCtx.String(badBlockOutFlag.Name))
+			}
+		}
+		return fmt.Errorf("bad block %s not found among %d kept", hash, len(kept))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NUMBER\tHASH\tSOURCE\tFIELD\tTIME\tERROR")
+	for i := len(kept) - 1; i >= 0; i-- {
+		b := kept[i]
+		fmt.Fprintf(w, "%d\t%x\t%s\t%s\t%s\t%s\n", b.Number, b.Hash, b.Source, b.Field, b.Time.Format("2006-01-02 15:04:05"), b.Error)
+	}
+	return w.Flush()
+}
+
+func dumpBadBlock(b *eth1.BadBlock, out string) error {
+	block, err := b.Block()
+	if err != nil {
+		return err
+	}
+	if out != "" {
+		return os.WriteFile(out, b.RLP, 0o644)
+	}
+	dump, err := json.MarshalIndent(map[string]interface{}{
+		"hash":         b.Hash,
+		"error":        b.Error,
+		"field":        b.Field,
+		"source":       b.Source,
+		"time":         b.Time,
+		"header":       block.Header(),
+		"transactions": block.Transactions(),
+		"uncles":       block.Uncles(),
+		"withdrawals":  block.Withdrawals(),
+		"rlp":          b.RLP,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(string(dump))
+	return err
+}
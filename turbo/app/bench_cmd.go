@@ -0,0 +1,363 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/execution/consensus/ethash"
+	"github.com/erigontech/erigon/execution/consensus/merge"
+	"github.com/erigontech/erigon/polygon/bor"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/transactions"
+)
+
+var (
+	benchFromFlag = cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "First block to execute",
+		Required: true,
+	}
+	benchToFlag = cli.Uint64Flag{
+		Name:     "to",
+		Usage:    "Last block to execute (inclusive)",
+		Required: true,
+	}
+	benchJsonFlag = cli.BoolFlag{
+		Name:  "json",
+		Usage: "Print the result as JSON to stdout (for CI/regression tracking)",
+	}
+	benchBlockFusionFlag = cli.BoolFlag{
+		Name:  "vm.block-fusion",
+		Usage: "Execute legacy code a basic block at a time",
+	}
+)
+
+var benchCommand = cli.Command{
+	Name:  "bench",
+	Usage: "Benchmarks of the local hardware",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "exec",
+			Action: MigrateFlags(benchExec),
+			Usage:  "Replay historical blocks from local snapshots measuring Mgas/s, cache hit rates and domain IO",
+			Flags: []cli.Flag{
+				&utils.DataDirFlag,
+				&benchFromFlag,
+				&benchToFlag,
+				&benchJsonFlag,
+				&benchBlockFusionFlag,
+			},
+			Description: `
+Executes transactions of blocks [from, to] on the historical state: every transaction reads the state as of its
+beginning, so blocks are replayed without unwinding the node and the datadir is not modified. Erigon may be running.
+State history of the range must not be pruned. Reads of the history are slower than of the latest state: compare
+results of the same range and datadir layout across hardware and versions.`,
+		},
+	},
+}
+
+// BenchExecResult - result of BenchExec, its JSON is the output of 'erigon bench exec --json'
+type BenchExecResult struct {
+	From       uint64  `json:"from"`
+	To         uint64  `json:"to"`
+	Blocks     uint64  `json:"blocks"`
+	Txs        uint64  `json:"txs"`
+	Failed     uint64  `json:"failed"` // transactions which couldn't be applied: the history doesn't match the blocks
+	Skipped    uint64  `json:"skipped"`
+	Gas        uint64  `json:"gas"`
+	Seconds    float64 `json:"seconds"` // of execution, reading of blocks is not included
+	MgasPerSec float64 `json:"mgasPerSec"`
+	TxsPerSec  float64 `json:"txsPerSec"`
+
+	// hit rates of caches, 0..1
+	JumpDestCacheHitRate float64 `json:"jumpDestCacheHitRate"`
+	MemoryPoolHitRate    float64 `json:"memoryPoolHitRate"`
+	StackPoolHitRate     float64 `json:"stackPoolHitRate"`
+
+	StateReads map[string]*BenchReads `json:"stateReads"` // by domain
+}
+
+// BenchReads - reads of a domain by the executed transactions
+type BenchReads struct {
+	Count   uint64  `json:"count"`
+	Seconds float64 `json:"seconds"`
+	AvgUs   float64 `json:"avgMicros"`
+}
+
+func benchExec(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	from, to := cliCtx.Uint64(benchFromFlag.Name), cliCtx.Uint64(benchToFlag.Name)
+	if from == 0 || to < from {
+		return fmt.Errorf("invalid range of blocks [%d, %d]: genesis is not executable, --to must be not less than --from", from, to)
+	}
+	ctx := cliCtx.Context
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+	chainConfig := fromdb.ChainConfig(chainDB)
+	cfg := ethconfig.NewSnapCfg(false, true, true, chainConfig.ChainName)
+
+	_, _, _, blockRetire, agg, clean, err := openSnaps(ctx, cfg, dirs, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer clean()
+	db, err := temporal.New(chainDB, agg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	blockReader, _ := blockRetire.IO()
+
+	vmConfig := vm.Config{BlockFusion: cliCtx.Bool(benchBlockFusionFlag.Name)}
+	res, err := BenchExec(ctx, db, chainConfig, benchEngine(chainConfig, chainDB, blockReader, logger), blockReader, vmConfig, from, to, logger)
+	if err != nil {
+		return err
+	}
+	if cliCtx.Bool(benchJsonFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+	logger.Info("Bench exec done", "blocks", fmt.Sprintf("%d-%d", res.From, res.To), "txs", res.Txs, "failed", res.Failed,
+		"Mgas/s", fmt.Sprintf("%.1f", res.MgasPerSec), "tx/s", fmt.Sprintf("%.0f", res.TxsPerSec), "took", time.Duration(res.Seconds*float64(time.Second)),
+		"jumpDestCache", fmt.Sprintf("%.2f", res.JumpDestCacheHitRate), "memoryPool", fmt.Sprintf("%.2f", res.MemoryPoolHitRate),
+		"stackPool", fmt.Sprintf("%.2f", res.StackPoolHitRate))
+	for domain, reads := range res.StateReads {
+		logger.Info("Bench exec reads", "domain", domain, "count", reads.Count, "avg", fmt.Sprintf("%.1fµs", reads.AvgUs))
+	}
+	return nil
+}
+
+// benchEngine - author of blocks is all the engine is needed for to execute user transactions
+func benchEngine(cc *chain.Config, chainDB kv.RoDB, blockReader services.FullBlockReader, logger log.Logger) consensus.EngineReader {
+	if cc.Bor != nil {
+		return bor.NewRo(cc, chainDB, blockReader, logger)
+	}
+	var engine consensus.Engine = ethash.NewFaker()
+	if cc.TerminalTotalDifficulty != nil {
+		engine = merge.New(engine)
+	}
+	return engine
+}
+
+// BenchExec executes user transactions of blocks [from, to] on the historical state
+func BenchExec(ctx context.Context, db kv.TemporalRoDB, cc *chain.Config, engine consensus.EngineReader, blockReader services.FullBlockReader,
+	vmConfig vm.Config, from, to uint64, logger log.Logger) (*BenchExecResult, error) {
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	txNumsReader := blockReader.TxnumReader(ctx)
+	firstTxNum, err := txNumsReader.Min(tx, from)
+	if err != nil {
+		return nil, err
+	}
+	reader := newBenchStateReader(tx)
+	if startFrom := reader.StateHistoryStartFrom(); startFrom > firstTxNum {
+		return nil, fmt.Errorf("state history of block %d is pruned: it starts from txNum %d", from, startFrom)
+	}
+	if vmConfig.JumpDestCache == nil {
+		vmConfig.JumpDestCache = vm.NewJumpDestCache(vm.JumpDestCacheLimit)
+	}
+	ibs := state.New(reader)
+	evm := vm.NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, ibs, cc, vmConfig)
+
+	poolCounters := []metrics.Counter{
+		metrics.GetOrCreateCounter(`evm_pool_gets{pool="memory"}`), metrics.GetOrCreateCounter(`evm_pool_misses{pool="memory"}`),
+		metrics.GetOrCreateCounter(`evm_pool_gets{pool="stack"}`), metrics.GetOrCreateCounter(`evm_pool_misses{pool="stack"}`),
+	}
+	poolsBefore := make([]uint64, len(poolCounters))
+	for i, c := range poolCounters {
+		poolsBefore[i] = c.GetValueUint64()
+	}
+
+	res := &BenchExecResult{From: from, To: to}
+	var took time.Duration
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	for blockNum := from; blockNum <= to; blockNum++ {
+		block, err := blockReader.BlockByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block %d is not found", blockNum)
+		}
+		firstTxNum, err := txNumsReader.Min(tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		header := block.HeaderNoCopy()
+		blockCtx := transactions.NewEVMBlockContext(engine, header, true /* requireCanonical */, tx, blockReader, cc)
+		rules := cc.Rules(blockNum, header.Time)
+		signer := types.MakeSigner(cc, blockNum, header.Time)
+		vmConfig.SkipAnalysis = core.SkipAnalysis(cc, blockNum)
+
+		start := time.Now()
+		for txIndex, txn := range block.Transactions() {
+			if txn.Type() == types.AccountAbstractionTxType {
+				res.Skipped++
+				continue
+			}
+			reader.SetTxNum(firstTxNum + 1 + uint64(txIndex)) // firstTxNum - system txn of the block beginning
+			ibs.Reset()
+			ibs.SetTxContext(blockNum, txIndex)
+			msg, err := txn.AsMessage(*signer, header.BaseFee, rules)
+			if err != nil {
+				return nil, fmt.Errorf("block %d txn %d: %w", blockNum, txIndex, err)
+			}
+			evm.ResetBetweenBlocks(blockCtx, core.NewEVMTxContext(msg), ibs, vmConfig, rules)
+			gp := new(core.GasPool).AddGas(txn.GetGasLimit()).AddBlobGas(txn.GetBlobGas())
+			result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, engine)
+			if err != nil {
+				res.Failed++
+				logger.Debug("[bench] txn failed", "block", blockNum, "txIndex", txIndex, "err", err)
+				continue
+			}
+			res.Txs++
+			res.Gas += result.GasUsed
+		}
+		took += time.Since(start)
+		res.Blocks++
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-logEvery.C:
+			logger.Info("[bench] executing", "block", blockNum, "Mgas/s", fmt.Sprintf("%.1f", float64(res.Gas)/1e6/took.Seconds()),
+				"progress", fmt.Sprintf("%.2f%%", 100*float64(blockNum-from+1)/float64(to-from+1)))
+		default:
+		}
+	}
+
+	res.Seconds = took.Seconds()
+	if res.Seconds > 0 {
+		res.MgasPerSec = float64(res.Gas) / 1e6 / res.Seconds
+		res.TxsPerSec = float64(res.Txs) / res.Seconds
+	}
+	hit, total := vmConfig.JumpDestCache.Stats()
+	res.JumpDestCacheHitRate = hitRate(uint64(hit), uint64(total-hit))
+	res.MemoryPoolHitRate = hitRate(poolCounters[0].GetValueUint64()-poolsBefore[0], poolCounters[1].GetValueUint64()-poolsBefore[1])
+	res.StackPoolHitRate = hitRate(poolCounters[2].GetValueUint64()-poolsBefore[2], poolCounters[3].GetValueUint64()-poolsBefore[3])
+	res.StateReads = reader.stats()
+	return res, nil
+}
+
+// hitRate of `gets` of which `misses` were missed
+func hitRate(gets, misses uint64) float64 {
+	if gets == 0 {
+		return 0
+	}
+	return float64(gets-min(gets, misses)) / float64(gets)
+}
+
+// benchStateReader counts reads of the historical state and time spent on them
+type benchStateReader struct {
+	*state.HistoryReaderV3
+	reads [kv.DomainLen]struct {
+		count uint64
+		took  time.Duration
+	}
+}
+
+func newBenchStateReader(tx kv.TemporalTx) *benchStateReader {
+	r := &benchStateReader{HistoryReaderV3: state.NewHistoryReaderV3()}
+	r.SetTx(tx)
+	return r
+}
+
+func (r *benchStateReader) done(domain kv.Domain, start time.Time) {
+	r.reads[domain].count++
+	r.reads[domain].took += time.Since(start)
+}
+
+func (r *benchStateReader) stats() map[string]*BenchReads {
+	res := map[string]*BenchReads{}
+	for domain, reads := range r.reads {
+		if reads.count == 0 {
+			continue
+		}
+		res[kv.Domain(domain).String()] = &BenchReads{
+			Count:   reads.count,
+			Seconds: reads.took.Seconds(),
+			AvgUs:   float64(reads.took.Microseconds()) / float64(reads.count),
+		}
+	}
+	return res
+}
+
+func (r *benchStateReader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	defer r.done(kv.AccountsDomain, time.Now())
+	return r.HistoryReaderV3.ReadAccountData(address)
+}
+
+func (r *benchStateReader) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	defer r.done(kv.AccountsDomain, time.Now())
+	return r.HistoryReaderV3.ReadAccountDataForDebug(address)
+}
+
+func (r *benchStateReader) ReadAccountStorage(address common.Address, key common.Hash) (uint256.Int, bool, error) {
+	defer r.done(kv.StorageDomain, time.Now())
+	return r.HistoryReaderV3.ReadAccountStorage(address, key)
+}
+
+func (r *benchStateReader) HasStorage(address common.Address) (bool, error) {
+	defer r.done(kv.StorageDomain, time.Now())
+	return r.HistoryReaderV3.HasStorage(address)
+}
+
+func (r *benchStateReader) ReadAccountCode(address common.Address) ([]byte, error) {
+	defer r.done(kv.CodeDomain, time.Now())
+	return r.HistoryReaderV3.ReadAccountCode(address)
+}
+
+func (r *benchStateReader) ReadAccountCodeSize(address common.Address) (int, error) {
+	defer r.done(kv.CodeDomain, time.Now())
+	return r.HistoryReaderV3.ReadAccountCodeSize(address)
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/execution/chainspec"
+	"github.com/erigontech/erigon/turbo/buildinfo"
+)
+
+var buildInfoCommand = cli.Command{
+	Action: MigrateFlags(buildInfo),
+	Name:   "buildinfo",
+	Usage:  "Print the build, the enabled experimental features, the chain config hash and the snapshot manifest as JSON",
+	Flags: []cli.Flag{
+		&utils.ChainFlag,
+	},
+	Description: `
+Prints the report a running node serves at /debug/diag/buildinfo of the diagnostics endpoint, for the flags given
+before the command, e.g. 'erigon --chain sepolia --experimental.concurrent-commitment buildinfo'. The digest is
+the same for nodes of the same build and config, so a fleet can be checked for drift by comparing digests.`,
+}
+
+func buildInfo(cliCtx *cli.Context) error {
+	chainName := cliCtx.String(utils.ChainFlag.Name)
+	cc := chainspec.ChainConfigByChainName(chainName)
+	if cc == nil {
+		return fmt.Errorf("unknown chain %s", chainName)
+	}
+	report, err := buildinfo.New(cc, buildinfo.ExperimentalFeatures(cliCtx))
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+
+	coresnaptype "github.com/erigontech/erigon-db/snaptype"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/compress"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/seg"
+	"github.com/erigontech/erigon-lib/snaptype"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/snapshotsync"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+var (
+	codecDictFlag = cli.StringFlag{
+		Name:     "dict",
+		Usage:    "file name of the dictionary in snapshots/dicts, see `seg codec-train`",
+		Required: true,
+	}
+	codecDictSizeFlag = cli.IntFlag{
+		Name:  "dict.size",
+		Usage: "size of the dictionary, bytes",
+		Value: 112_640,
+	}
+	codecSamplesFlag = cli.IntFlag{
+		Name:  "samples",
+		Usage: "amount of the latest words to train the dictionary on",
+		Value: 200_000,
+	}
+	codecFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "re-encode the files of the blocks starting from",
+	}
+	codecToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "re-encode the files of the blocks up to (0 - to the last file)",
+	}
+)
+
+// doCodecTrain - trains the zstd dictionary of the bodies and puts it into the dictionaries directory. Nothing is
+// encoded with it until `seg codec-reencode`.
+func doCodecTrain(cliCtx *cli.Context) error {
+	dirs, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+
+	samples, err := bodiesSamples(dirs, cliCtx.Int(codecSamplesFlag.Name))
+	if err != nil {
+		return err
+	}
+	if len(samples) == 0 {
+		return fmt.Errorf("no %s to train the dictionary on", coresnaptype.BodiesDict)
+	}
+
+	raw, err := compress.TrainDict(samples, cliCtx.Int(codecDictSizeFlag.Name))
+	if err != nil {
+		return err
+	}
+	d, err := compress.RegisterDict(coresnaptype.BodiesDict, raw)
+	if err != nil {
+		return err
+	}
+	var plain, encoded int
+	var buf []byte
+	for _, s := range samples {
+		var word []byte
+		buf, word = compress.EncodeWord(buf[:0], s, d)
+		plain, encoded = plain+len(s), encoded+len(word)
+	}
+
+	dictsDir := coresnaptype.DictsDir(dirs.Snap)
+	if err := os.MkdirAll(dictsDir, 0o755); err != nil {
+		return err
+	}
+	if err := dir.WriteFileWithFsync(filepath.Join(dictsDir, d.FileName()), raw, 0o644); err != nil {
+		return err
+	}
+	logger.Info("[codec] dictionary trained", "file", d.FileName(), "samples", len(samples),
+		"size", common.ByteCount(uint64(len(raw))), "ratio", fmt.Sprintf("%.2f", float64(encoded)/float64(plain)))
+	return nil
+}
+
+// bodiesSamples - the latest bodies of the block files, oldest first
+func bodiesSamples(dirs datadir.Dirs, limit int) ([][]byte, error) {
+	files, _, err := snapshotsync.TypedSegments(dirs.Snap, 0, []snaptype.Type{coresnaptype.Bodies}, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := compress.LoadDicts(coresnaptype.DictsDir(dirs.Snap)); err != nil {
+		return nil, err
+	}
+	var samples [][]byte
+	for i := len(files) - 1; i >= 0 && len(samples) < limit; i-- {
+		words, err := func() ([][]byte, error) {
+			d, err := seg.NewDecompressor(files[i].Path)
+			if err != nil {
+				return nil, err
+			}
+			defer d.Close()
+			skip := max(d.Count()-(limit-len(samples)), 0)
+			words := make([][]byte, 0, d.Count()-skip)
+			g := d.MakeGetter()
+			for j := 0; g.HasNext(); j++ {
+				if j < skip {
+					g.Skip()
+					continue
+				}
+				word, _ := g.Next(nil)
+				if _, word, err = compress.DecodeWord(nil, word); err != nil {
+					return nil, fmt.Errorf("%s: %w", d.FileName(), err)
+				}
+				words = append(words, word)
+			}
+			return words, nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+		samples = append(words, samples...)
+	}
+	return samples, nil
+}
+
+// doCodecReencode - re-encodes the bodies files with the dictionary and rebuilds their indices. Erigon must be stopped.
+// Re-encoded files differ from the published ones: their .torrent files are removed.
+func doCodecReencode(cliCtx *cli.Context) error {
+	dirs, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	from, to := cliCtx.Uint64(codecFromFlag.Name), cliCtx.Uint64(codecToFlag.Name)
+
+	if err := compress.LoadDicts(coresnaptype.DictsDir(dirs.Snap)); err != nil {
+		return err
+	}
+	dictFile := cliCtx.String(codecDictFlag.Name)
+	raw, err := os.ReadFile(filepath.Join(coresnaptype.DictsDir(dirs.Snap), dictFile))
+	if err != nil {
+		return err
+	}
+	d, err := compress.RegisterDict(coresnaptype.BodiesDict, raw)
+	if err != nil {
+		return err
+	}
+	if d.FileName() != dictFile {
+		return fmt.Errorf("%s: expected a dictionary of %s, named %s", dictFile, coresnaptype.BodiesDict, d.FileName())
+	}
+	files, _, err := snapshotsync.TypedSegments(dirs.Snap, 0, []snaptype.Type{coresnaptype.Bodies}, true)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.From < from || (to > 0 && f.To > to) {
+			continue
+		}
+		before, after, err := reencodeFile(ctx, f, d, dirs.Tmp, logger)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		logger.Info("[codec] re-encoded", "file", f.Name(), "dict", d.FileName(),
+			"before", common.ByteCount(uint64(before)), "after", common.ByteCount(uint64(after)))
+	}
+	return nil
+}
+
+func reencodeFile(ctx context.Context, f snaptype.FileInfo, dict *compress.Dict, tmpDir string, logger log.Logger) (before, after int64, err error) {
+	src, err := seg.NewDecompressor(f.Path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer src.Close()
+	before = src.Size()
+
+	c, err := seg.NewCompressor(ctx, "[codec] "+f.Name(), f.Path+".reencoded", tmpDir, freezeblocks.BlockCompressCfg, log.LvlDebug, logger)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer c.Close()
+	var word, decodedBuf, encodedBuf []byte
+	g := src.MakeGetter()
+	for g.HasNext() {
+		word, _ = g.Next(word[:0])
+		decodedBuf, word, err = compress.DecodeWord(decodedBuf, word)
+		if err != nil {
+			return 0, 0, err
+		}
+		encodedBuf, word = compress.EncodeWord(encodedBuf[:0], word, dict)
+		if err := c.AddWord(word); err != nil {
+			return 0, 0, err
+		}
+	}
+	if c.Count() != src.Count() {
+		return 0, 0, fmt.Errorf("words count mismatch: %d != %d", c.Count(), src.Count())
+	}
+	if err := c.Compress(); err != nil {
+		return 0, 0, err
+	}
+	src.Close()
+
+	// offsets of the words changed: the indices must be rebuilt
+	for _, idx := range f.Type.IdxFileNames(f.Version, f.From, f.To) {
+		if err := os.Remove(filepath.Join(f.Dir(), idx)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return 0, 0, err
+		}
+	}
+	if err := os.Remove(f.Path + ".torrent"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, 0, err
+	}
+	if err := os.Rename(f.Path+".reencoded", f.Path); err != nil {
+		return 0, 0, err
+	}
+	if err := f.Type.BuildIndexes(ctx, f, nil, nil, tmpDir, nil, log.LvlDebug, logger); err != nil {
+		return 0, 0, err
+	}
+	st, err := os.Stat(f.Path)
+	if err != nil {
+		return 0, 0, err
+	}
+	return before, st.Size(), nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/prune"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/diskforecast"
+)
+
+var diskForecastDaysFlag = cli.Float64Flag{
+	Name:  "days",
+	Usage: "Fail if the disk is expected to be full in less than this number of days",
+	Value: diskforecast.WarnDays,
+}
+
+var diskForecastCommand = cli.Command{
+	Action: MigrateFlags(diskForecast),
+	Name:   "disk-forecast",
+	Usage:  "Forecast growth of the datadir per stage and domain and days until the disk is full",
+	Flags: []cli.Flag{
+		&utils.DataDirFlag,
+		&diskForecastDaysFlag,
+	},
+	Description: `
+Estimates daily growth of snapshots of every block type and state domain from their most recent files,
+taking into account the prune mode of the datadir: pruned history and blocks don't grow. If the node is
+syncing, the space needed to execute up to the downloaded head is forecasted too. Erigon may be running.`,
+}
+
+func diskForecast(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	cfg, err := diskForecastConfig(cliCtx.Context, dirs)
+	if err != nil {
+		return err
+	}
+	f, err := diskforecast.Plan(cfg)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "STAGE\tNAME\tKIND\tSIZE\tPER DAY")
+	for _, item := range f.Items {
+		perDay := common.ByteCount(item.PerDay)
+		if item.Pruned {
+			perDay = "pruned"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", item.Stage, item.Name, item.Kind, common.ByteCount(item.Size), perDay)
+	}
+	fmt.Fprintf(w, "total\t\t\t%s\t%s\n", common.ByteCount(f.Size), common.ByteCount(f.PerDay))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	logger.Info("Disk forecast", "free", common.ByteCount(f.Free), "total", common.ByteCount(f.Total),
+		"toHead", common.ByteCount(f.ToHead), "daysLeft", fmt.Sprintf("%.1f", f.DaysLeft), "prune", cfg.Prune.String())
+	return f.Warning(cliCtx.Float64(diskForecastDaysFlag.Name))
+}
+
+// diskForecastConfig reads the prune mode, the chain and the sync progress of the datadir
+func diskForecastConfig(ctx context.Context, dirs datadir.Dirs) (diskforecast.Config, error) {
+	cfg := diskforecast.Config{Dirs: dirs}
+	if _, err := os.Stat(dirs.Chaindata); err != nil { // prune mode is inferred from the files
+		return cfg, nil
+	}
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+	err := chainDB.View(ctx, func(tx kv.Tx) (err error) {
+		genesisHash, err := rawdb.ReadCanonicalHash(tx, 0)
+		if err != nil {
+			return err
+		}
+		chainConfig, err := core.ReadChainConfig(tx, genesisHash)
+		if err != nil {
+			return err
+		}
+		if chainConfig != nil {
+			cfg.SecondsPerBlock = chainConfig.SecondsPerSlot()
+		}
+		if cfg.Prune, err = prune.Get(tx); err != nil {
+			return err
+		}
+		if cfg.ExecutedBlock, err = stages.GetStageProgress(tx, stages.Execution); err != nil {
+			return err
+		}
+		cfg.HeadBlock, err = stages.GetStageProgress(tx, stages.Headers)
+		return err
+	})
+	return cfg, err
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+var exportCommand = cli.Command{
+	Action:    MigrateFlags(exportChain),
+	Name:      "export",
+	Usage:     "Export blockchain into file",
+	ArgsUsage: "<filename> [<blockNumFirst> <blockNumLast>]",
+	Flags: []cli.Flag{
+		&utils.DataDirFlag,
+	},
+	Description: `
+Exports canonical blocks (genesis included) into an RLP-encoded file, the format of 'geth export'
+readable by 'erigon import' and 'geth import'. Without a range the whole chain is exported.
+If the file ends with .gz, the output will be gzipped. Erigon may be running.`,
+}
+
+func exportChain(cliCtx *cli.Context) error {
+	if cliCtx.NArg() != 1 && cliCtx.NArg() != 3 {
+		utils.Fatalf("This command requires a filename and optionally a range of blocks.")
+	}
+	var blockRange ChainRange
+	if cliCtx.NArg() == 3 {
+		var err error
+		if blockRange.From, err = strconv.ParseUint(cliCtx.Args().Get(1), 10, 64); err != nil {
+			return fmt.Errorf("blockNumFirst: %w", err)
+		}
+		if blockRange.To, err = strconv.ParseUint(cliCtx.Args().Get(2), 10, 64); err != nil {
+			return fmt.Errorf("blockNumLast: %w", err)
+		}
+		if blockRange.To < blockRange.From {
+			utils.Fatalf("blockNumLast must be not less than blockNumFirst")
+		}
+	}
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+	cfg := ethconfig.NewSnapCfg(false, true, true, fromdb.ChainConfig(chainDB).ChainName)
+
+	blockSnaps, borSnaps, _, _, _, clean, err := openSnaps(ctx, cfg, dirs, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer clean()
+	blockReader := freezeblocks.NewBlockReader(blockSnaps, borSnaps, nil, nil)
+
+	return ExportChain(ctx, chainDB, blockReader, cliCtx.Args().First(), blockRange, logger)
+}
+
+// ExportChain writes RLP of canonical blocks of `blockRange` into file `fn` (gzipped if it ends with .gz).
+// blockRange.To == 0 - up to the head block.
+func ExportChain(ctx context.Context, db kv.RoDB, blockReader services.FullBlockReader, fn string, blockRange ChainRange, logger log.Logger) error {
+	tx, err := db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if blockRange.To == 0 {
+		head := rawdb.ReadCurrentHeaderHavingBody(tx)
+		if head == nil {
+			return errors.New("head block is not found")
+		}
+		blockRange.To = head.Number.Uint64()
+	}
+
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	buf := bufio.NewWriter(fh)
+	var w io.Writer = buf
+	var gz *gzip.Writer
+	if strings.HasSuffix(fn, ".gz") {
+		gz = gzip.NewWriter(w)
+		w = gz
+	}
+
+	logger.Info("Exporting blockchain", "file", fn, "from", blockRange.From, "to", blockRange.To)
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	start := time.Now()
+	for blockNum := blockRange.From; blockNum <= blockRange.To; blockNum++ {
+		block, err := blockReader.BlockByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return fmt.Errorf("block %d is not found", blockNum)
+		}
+		if err := block.EncodeRLP(w); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			exported := blockNum - blockRange.From + 1
+			logger.Info("Exporting", "block", blockNum, "blk/s", fmt.Sprintf("%.1f", float64(exported)/time.Since(start).Seconds()),
+				"progress", fmt.Sprintf("%.2f%%", 100*float64(exported)/float64(blockRange.To-blockRange.From+1)))
+		default:
+		}
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	logger.Info("Export done", "file", fn, "blocks", blockRange.To-blockRange.From+1, "took", time.Since(start))
+	return fh.Close()
+}
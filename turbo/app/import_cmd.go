@@ -24,11 +24,14 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"runtime"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/direct"
@@ -53,6 +56,17 @@ const (
 	importBatchSize = 2500
 )
 
+var (
+	ImportFromFlag = cli.Uint64Flag{
+		Name:  "import.from",
+		Usage: "Import blocks starting from this number: earlier blocks of the file are skipped",
+	}
+	ImportToFlag = cli.Uint64Flag{
+		Name:  "import.to",
+		Usage: "Import blocks up to this number (inclusive), 0 - up to the end of the file",
+	}
+)
+
 var importCommand = cli.Command{
 	Action:    MigrateFlags(importChain),
 	Name:      "import",
@@ -61,20 +75,36 @@ var importCommand = cli.Command{
 	Flags: []cli.Flag{
 		&utils.DataDirFlag,
 		&utils.ChainFlag,
+		&ImportFromFlag,
+		&ImportToFlag,
 	},
 	//Category: "BLOCKCHAIN COMMANDS",
 	Description: `
-The import command imports blocks from an RLP-encoded form. The form can be one file
-with several RLP-encoded blocks, or several files can be used.
+The import command imports blocks from an RLP-encoded form (e.g. made by 'erigon export'
+or 'geth export'). The form can be one file with several RLP-encoded blocks, or several
+files can be used. Files with .gz suffix are gunzipped.
 
 If only one file is used, import error will result in failure. If several files are used,
 processing will proceed even if an individual RLP-file import failure occurs.`,
 }
 
+// ChainRange - inclusive range of block numbers, To == 0 - no upper bound
+type ChainRange struct {
+	From, To uint64
+}
+
+func (r ChainRange) Contains(blockNum uint64) bool {
+	return blockNum >= r.From && (r.To == 0 || blockNum <= r.To)
+}
+
 func importChain(cliCtx *cli.Context) error {
 	if cliCtx.NArg() < 1 {
 		utils.Fatalf("This command requires an argument.")
 	}
+	blockRange := ChainRange{From: cliCtx.Uint64(ImportFromFlag.Name), To: cliCtx.Uint64(ImportToFlag.Name)}
+	if blockRange.To != 0 && blockRange.To < blockRange.From {
+		utils.Fatalf("--%s must be not less than --%s", ImportToFlag.Name, ImportFromFlag.Name)
+	}
 	logger, tracer, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
 	if err != nil {
 		return err
@@ -98,14 +128,73 @@ func importChain(cliCtx *cli.Context) error {
 		return err
 	}
 
-	if err := ImportChain(ethereum, ethereum.ChainDB(), cliCtx.Args().First(), logger); err != nil {
-		return err
+	if cliCtx.NArg() == 1 {
+		return ImportChain(ethereum, ethereum.ChainDB(), cliCtx.Args().First(), blockRange, logger)
+	}
+	for _, fn := range cliCtx.Args().Slice() {
+		if err := ImportChain(ethereum, ethereum.ChainDB(), fn, blockRange, logger); err != nil {
+			logger.Error("Import error", "file", fn, "err", err)
+		}
 	}
-
 	return nil
 }
 
-func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, logger log.Logger) error {
+// countingReader - bytes read from the file, for progress
+type countingReader struct {
+	io.Reader
+	n atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
+
+// decodeBlocks decodes RLP of blocks in parallel: decoding with transactions parsing is the most CPU-heavy part of reading
+func decodeBlocks(ctx context.Context, raws [][]byte, blocks []*types.Block) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(runtime.NumCPU())
+	for i := range raws {
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var b types.Block
+			if err := rlp.DecodeBytes(raws[i], &b); err != nil {
+				return err
+			}
+			blocks[i] = &b
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// blocksInRange - blocks of the batch to import, the batch is filtered in place: genesis and the blocks out of
+// the range are skipped. reachedEnd - the batch has a block past the end of the range, the rest of the file is not read.
+func blocksInRange(blocks []*types.Block, blockRange ChainRange) (inRange []*types.Block, reachedEnd bool) {
+	inRange = blocks[:0]
+	for _, b := range blocks {
+		if blockRange.To != 0 && b.NumberU64() > blockRange.To {
+			return inRange, true
+		}
+		if b.NumberU64() != 0 && blockRange.Contains(b.NumberU64()) {
+			inRange = append(inRange, b)
+		}
+	}
+	return inRange, false
+}
+
+func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, blockRange ChainRange, logger log.Logger) error {
+	br, _ := ethereum.BlockIO()
+	return importBlocks(chainDB, br, fn, blockRange, func(chain *core.ChainPack) error {
+		return InsertChain(ethereum, chain, logger)
+	}, logger)
+}
+
+// importBlocks - reads the blocks of file `fn` and inserts the missing ones of `blockRange` by batches
+func importBlocks(chainDB kv.RwDB, blockReader services.FullBlockReader, fn string, blockRange ChainRange, insert func(chain *core.ChainPack) error, logger log.Logger) error {
 	// Watch for Ctrl-C while the import is running.
 	// If a signal is received, the import will stop at the next batch.
 	interrupt := make(chan os.Signal, 1)
@@ -128,7 +217,7 @@ func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, logger log.
 		}
 	}
 
-	logger.Info("Importing blockchain", "file", fn)
+	logger.Info("Importing blockchain", "file", fn, "from", blockRange.From, "to", blockRange.To)
 
 	// Open the file handle and potentially unwrap the gzip stream
 	fh, err := os.Open(fn)
@@ -136,8 +225,13 @@ func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, logger log.
 		return err
 	}
 	defer fh.Close()
+	fi, err := fh.Stat()
+	if err != nil {
+		return err
+	}
 
-	var reader io.Reader = fh
+	counter := &countingReader{Reader: fh}
+	var reader io.Reader = counter
 	if strings.HasSuffix(fn, ".gz") {
 		if reader, err = gzip.NewReader(reader); err != nil {
 			return err
@@ -146,8 +240,10 @@ func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, logger log.
 	stream := rlp.NewStream(reader, 0)
 
 	// Run actual the import.
+	raws := make([][]byte, importBatchSize)
 	blocks := make(types.Blocks, importBatchSize)
-	n := 0
+	n, imported := 0, 0
+	start := time.Now()
 	for batch := 0; ; batch++ {
 		// Load a batch of RLP blocks.
 		if checkInterrupt() {
@@ -155,45 +251,58 @@ func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, logger log.
 		}
 		i := 0
 		for ; i < importBatchSize; i++ {
-			var b types.Block
-			if err := stream.Decode(&b); errors.Is(err, io.EOF) {
+			raw, err := stream.Raw()
+			if errors.Is(err, io.EOF) {
 				break
 			} else if err != nil {
-				return fmt.Errorf("at block %d: %v", n, err)
-			}
-			// don't import first block
-			if b.NumberU64() == 0 {
-				i--
-				continue
+				return fmt.Errorf("at block %d: %v", n+i, err)
 			}
-			blocks[i] = &b
-			n++
+			raws[i] = raw
 		}
 		if i == 0 {
 			break
 		}
-		// Import the batch.
-		if checkInterrupt() {
-			return errors.New("interrupted")
-		}
-
-		br, _ := ethereum.BlockIO()
-		missing := missingBlocks(chainDB, blocks[:i], br)
-		if len(missing) == 0 {
-			logger.Info("Skipping batch as all blocks present", "batch", batch, "first", blocks[0].Hash(), "last", blocks[i-1].Hash())
-			continue
+		if err := decodeBlocks(context.Background(), raws[:i], blocks[:i]); err != nil {
+			return fmt.Errorf("in blocks %d-%d: %v", n, n+i-1, err)
 		}
+		n += i
+
+		// don't import first block and blocks out of range
+		inRange, reachedEnd := blocksInRange(blocks[:i], blockRange)
+		if len(inRange) > 0 {
+			// Import the batch.
+			if checkInterrupt() {
+				return errors.New("interrupted")
+			}
 
-		// RLP decoding worked, try to insert into chain:
-		missingChain := &core.ChainPack{
-			Blocks:   missing,
-			TopBlock: missing[len(missing)-1],
+			missing := missingBlocks(chainDB, inRange, blockReader)
+			if len(missing) == 0 {
+				logger.Info("Skipping batch as all blocks present", "batch", batch, "first", inRange[0].Hash(), "last", inRange[len(inRange)-1].Hash())
+			} else {
+				// RLP decoding worked, try to insert into chain:
+				missingChain := &core.ChainPack{
+					Headers:  make([]*types.Header, len(missing)),
+					Blocks:   missing,
+					TopBlock: missing[len(missing)-1],
+				}
+				for j, b := range missing {
+					missingChain.Headers[j] = b.Header()
+				}
+
+				if err := insert(missingChain); err != nil {
+					return err
+				}
+				imported += len(missing)
+			}
+			logger.Info("Imported", "file", fn, "block", inRange[len(inRange)-1].NumberU64(), "imported", imported,
+				"blk/s", fmt.Sprintf("%.1f", float64(imported)/time.Since(start).Seconds()),
+				"progress", fmt.Sprintf("%.2f%%", 100*float64(counter.n.Load())/float64(max(fi.Size(), 1))))
 		}
-
-		if err := InsertChain(ethereum, missingChain, logger); err != nil {
-			return err
+		if reachedEnd {
+			break
 		}
 	}
+	logger.Info("Import done", "file", fn, "read", n, "imported", imported, "took", time.Since(start))
 	return nil
 }
 
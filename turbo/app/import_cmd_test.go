@@ -0,0 +1,124 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/execution/stages/mock"
+)
+
+func TestChainRange(t *testing.T) {
+	t.Parallel()
+	r := ChainRange{From: 3, To: 5}
+	require.False(t, r.Contains(2))
+	require.True(t, r.Contains(3))
+	require.True(t, r.Contains(5))
+	require.False(t, r.Contains(6))
+
+	open := ChainRange{From: 3}
+	require.False(t, open.Contains(2))
+	require.True(t, open.Contains(1_000_000))
+	require.True(t, ChainRange{}.Contains(0))
+}
+
+func TestBlocksInRange(t *testing.T) {
+	t.Parallel()
+	batch := func(from, to uint64) []*types.Block {
+		var blocks []*types.Block
+		for n := from; n <= to; n++ {
+			blocks = append(blocks, types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(n)}))
+		}
+		return blocks
+	}
+	numbers := func(blocks []*types.Block) (res []uint64) {
+		for _, b := range blocks {
+			res = append(res, b.NumberU64())
+		}
+		return res
+	}
+
+	inRange, reachedEnd := blocksInRange(batch(0, 4), ChainRange{})
+	require.Equal(t, []uint64{1, 2, 3, 4}, numbers(inRange)) // genesis is never imported
+	require.False(t, reachedEnd)
+
+	inRange, reachedEnd = blocksInRange(batch(0, 9), ChainRange{From: 3, To: 6})
+	require.Equal(t, []uint64{3, 4, 5, 6}, numbers(inRange))
+	require.True(t, reachedEnd)
+
+	inRange, reachedEnd = blocksInRange(batch(10, 12), ChainRange{From: 3, To: 12})
+	require.Equal(t, []uint64{10, 11, 12}, numbers(inRange))
+	require.False(t, reachedEnd) // end of the range is the last block of the batch: next batch is read
+
+	inRange, reachedEnd = blocksInRange(batch(1, 2), ChainRange{From: 5})
+	require.Empty(t, inRange)
+	require.False(t, reachedEnd)
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+	src := mock.Mock(t)
+	chain, err := core.GenerateChain(src.ChainConfig, src.Genesis, src.Engine, src.DB, 8, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{byte(i + 1)})
+	})
+	require.NoError(t, err)
+	require.NoError(t, src.InsertChain(chain))
+
+	ctx := context.Background()
+	for _, fn := range []string{"chain.rlp", "chain.rlp.gz"} {
+		fn := filepath.Join(t.TempDir(), fn)
+		require.NoError(t, ExportChain(ctx, src.DB, src.BlockReader, fn, ChainRange{}, src.Log))
+
+		dst := mock.Mock(t)
+		insert := func(chain *core.ChainPack) error { return dst.InsertChain(chain) }
+		// part of the range first, then the whole file: present blocks are skipped
+		require.NoError(t, importBlocks(dst.DB, dst.BlockReader, fn, ChainRange{To: 5}, insert, dst.Log))
+		requireHead(t, dst, chain.Blocks[4])
+		require.NoError(t, importBlocks(dst.DB, dst.BlockReader, fn, ChainRange{}, insert, dst.Log))
+		requireHead(t, dst, chain.TopBlock)
+	}
+
+	// exported range: blocks 3..5 only
+	fn := filepath.Join(t.TempDir(), "range.rlp")
+	require.NoError(t, ExportChain(ctx, src.DB, src.BlockReader, fn, ChainRange{From: 3, To: 5}, src.Log))
+	dst := mock.Mock(t)
+	require.ErrorContains(t, importBlocks(dst.DB, dst.BlockReader, fn, ChainRange{}, func(chain *core.ChainPack) error {
+		require.Equal(t, uint64(3), chain.Blocks[0].NumberU64())
+		require.Equal(t, uint64(5), chain.TopBlock.NumberU64())
+		return dst.InsertChain(chain)
+	}, dst.Log), "did not import block 5") // parent of block 3 is missing
+}
+
+func requireHead(t *testing.T, m *mock.MockSentry, want *types.Block) {
+	t.Helper()
+	require.NoError(t, m.DB.View(context.Background(), func(tx kv.Tx) error {
+		head := rawdb.ReadCurrentHeaderHavingBody(tx)
+		require.NotNil(t, head)
+		require.Equal(t, want.Hash(), head.Hash())
+		return nil
+	}))
+}
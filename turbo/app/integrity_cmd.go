@@ -0,0 +1,92 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/integrity"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var integrityCommand = cli.Command{
+	Name:  "integrity",
+	Usage: "Consistency checks of the node's database",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "temporal",
+			Action: doIntegrityTemporal,
+			Usage:  "Cross-check domains vs history vs inverted indices vs commitment",
+			Description: `Samples keys of latest state (accounts, storage, code) and checks them against history and inverted indices: key presence, txNum monotonicity.
+Restores commitment as of sampled blocks and compares its stored state root with block headers (no recomputation from domains).
+Doesn't lock datadir: can run next to a running erigon, use --keysPerSecond to limit IO.`,
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&cli.BoolFlag{Name: "failFast", Value: true, Usage: "to stop after 1st problem or print WARN log and continue check"},
+				&cli.IntFlag{Name: "sampling", Value: 11, Usage: "check every N-th key of latest state"},
+				&cli.IntFlag{Name: "blocks", Value: 16, Usage: "amount of blocks (evenly distributed over commitment history) to check restored state root of"},
+				&cli.IntFlag{Name: "keysPerSecond", Value: 0, Usage: "IO throttling: max sampled keys and blocks per second, 0 - unlimited"},
+			}),
+		},
+	},
+}
+
+func doIntegrityTemporal(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* root logger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+
+	chainConfig := fromdb.ChainConfig(chainDB)
+	cfg := ethconfig.NewSnapCfg(false, true, true, chainConfig.ChainName)
+
+	_, _, _, blockRetire, agg, clean, err := openSnaps(ctx, cfg, dirs, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	db, err := temporal.New(chainDB, agg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	blockReader, _ := blockRetire.IO()
+	if err := integrity.TemporalConsistency(ctx, db, blockReader, integrity.TemporalCfg{
+		FailFast:      cliCtx.Bool("failFast"),
+		KeysSampling:  cliCtx.Int("sampling"),
+		Blocks:        cliCtx.Int("blocks"),
+		KeysPerSecond: cliCtx.Int("keysPerSecond"),
+	}); err != nil {
+		log.Error("[integrity] Temporal", "err", err)
+		return err
+	}
+	log.Info("[integrity] Temporal: no problems found")
+	return nil
+}
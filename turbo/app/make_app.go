@@ -80,8 +80,14 @@ func MakeApp(name string, action cli.ActionFunc, cliFlags []cli.Flag) *cli.App {
 	app.Commands = []*cli.Command{
 		&initCommand,
 		&importCommand,
+		&exportCommand,
+		&diskForecastCommand,
+		&badBlocksCommand,
+		&benchCommand,
 		&snapshotCommand,
+		&integrityCommand,
 		&supportCommand,
+		&buildInfoCommand,
 		//&backupCommand,
 	}
 	return app
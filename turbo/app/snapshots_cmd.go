@@ -330,6 +330,27 @@ var snapshotCommand = cli.Command{
 				&cli.StringFlag{Name: "type", Required: true, Aliases: []string{"domain"}},
 			}),
 		},
+		{
+			Name:   "codec-train",
+			Action: doCodecTrain,
+			Usage:  "Train the zstd dictionary of the bodies files, see `seg codec-reencode`",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&codecDictSizeFlag,
+				&codecSamplesFlag,
+			}),
+		},
+		{
+			Name:   "codec-reencode",
+			Action: doCodecReencode,
+			Usage:  "Re-encode the local bodies files with the zstd dictionary, they are not seeded afterwards",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&codecDictFlag,
+				&codecFromFlag,
+				&codecToFlag,
+			}),
+		},
 		{
 			Name: "integrity",
 			Action: func(cliCtx *cli.Context) error {
@@ -828,6 +849,10 @@ func doIntegrity(cliCtx *cli.Context) error {
 			if err := integrity.CheckRCacheNoDups(ctx, db, blockReader, failFast); err != nil {
 				return err
 			}
+		case integrity.Temporal:
+			if err := integrity.TemporalConsistency(ctx, db, blockReader, integrity.TemporalCfg{FailFast: failFast, KeysSampling: 11, Blocks: 16}); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unknown check: %s", chk)
 		}
@@ -0,0 +1,176 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package buildinfo reports what makes nodes of a fleet behave differently: the build, the enabled
+// experimental features, the chain config and the snapshot manifest. The report has no timestamps or
+// paths, so nodes running the same build with the same config report the same digest.
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/snapcfg"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/snaptype"
+	"github.com/erigontech/erigon/params"
+)
+
+// Report is the structured build and config document of a node
+type Report struct {
+	Version       string            `json:"version"`
+	GitCommit     string            `json:"gitCommit"`
+	GitBranch     string            `json:"gitBranch"`
+	GitTag        string            `json:"gitTag"`
+	GoVersion     string            `json:"goVersion"`
+	Platform      string            `json:"platform"`      // GOOS/GOARCH
+	BuildSettings map[string]string `json:"buildSettings"` // of the Go toolchain: -tags, CGO_ENABLED, GOAMD64, vcs.*, ...
+	// Experimental - the set flags of experimental features, `name=value`, sorted
+	Experimental []string  `json:"experimentalFeatures"`
+	Chain        Chain     `json:"chain"`
+	Snapshots    Snapshots `json:"snapshots"`
+	// Digest - keccak256 of the JSON of the report without the digest
+	Digest common.Hash `json:"digest"`
+}
+
+// Chain identifies the chain config
+type Chain struct {
+	Name       string      `json:"name"`
+	ChainID    *big.Int    `json:"chainId"`
+	ConfigHash common.Hash `json:"configHash"` // keccak256 of the JSON of the config
+}
+
+// Snapshots identifies the snapshot manifest (preverified files) of the chain
+type Snapshots struct {
+	Branch   string            `json:"branch"` // of the remote manifest
+	Local    bool              `json:"local"`  // the manifest was committed after the initial sync
+	Files    int               `json:"files"`
+	MaxBlock uint64            `json:"maxBlock"`
+	Versions map[string]string `json:"versions"` // newest version of the files by type and extension
+	Hash     common.Hash       `json:"hash"`     // keccak256 of the names and hashes of the files
+}
+
+// New builds the report of the running binary with the chain config and the experimental features
+func New(cc *chain.Config, experimental []string) (*Report, error) {
+	r := &Report{
+		Version:       params.VersionWithMeta,
+		GitCommit:     params.GitCommit,
+		GitBranch:     params.GitBranch,
+		GitTag:        params.GitTag,
+		GoVersion:     runtime.Version(),
+		Platform:      runtime.GOOS + "/" + runtime.GOARCH,
+		BuildSettings: map[string]string{},
+		Experimental:  experimental,
+	}
+	if r.Experimental == nil {
+		r.Experimental = []string{}
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			r.BuildSettings[s.Key] = s.Value
+		}
+	}
+
+	configJson, err := json.Marshal(cc)
+	if err != nil {
+		return nil, fmt.Errorf("chain config: %w", err)
+	}
+	r.Chain = Chain{Name: cc.ChainName, ChainID: cc.ChainID, ConfigHash: crypto.Keccak256Hash(configJson)}
+
+	snapCfg, _ := snapcfg.KnownCfg(cc.ChainName)
+	r.Snapshots = NewSnapshots(snapCfg.Preverified)
+	r.Snapshots.Branch = snapcfg.GitBranch()
+	r.Snapshots.MaxBlock = snapCfg.ExpectBlocks
+
+	if r.Digest, err = r.digest(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewSnapshots summarizes the manifest, MaxBlock and Branch are left to the caller
+func NewSnapshots(preverified snapcfg.Preverified) Snapshots {
+	s := Snapshots{Local: preverified.Local, Files: len(preverified.Items), Versions: map[string]string{}}
+	items := slices.Clone(preverified.Items)
+	slices.SortFunc(items, func(a, b snapcfg.PreverifiedItem) int { return strings.Compare(a.Name, b.Name) })
+	hasher := crypto.NewKeccakState()
+	newest := map[string]snaptype.FileInfo{}
+	for _, item := range items {
+		hasher.Write([]byte(item.Name))
+		hasher.Write([]byte{0})
+		hasher.Write([]byte(item.Hash))
+		hasher.Write([]byte{0})
+
+		info, _, ok := snaptype.ParseFileName("", filepath.Base(item.Name))
+		if !ok || info.TypeString == "" {
+			continue
+		}
+		kind := info.TypeString + info.Ext
+		if prev, ok := newest[kind]; !ok || prev.Version.Less(info.Version) {
+			newest[kind] = info
+		}
+	}
+	hasher.Read(s.Hash[:])
+	for kind, info := range newest {
+		s.Versions[kind] = info.Version.String()
+	}
+	return s
+}
+
+func (r *Report) digest() (common.Hash, error) {
+	cp := *r
+	cp.Digest = common.Hash{}
+	data, err := json.Marshal(cp) // keys of maps are sorted
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+// ExperimentalFeatures returns the set flags of the app which enable experimental features: the flags
+// named or described as experimental
+func ExperimentalFeatures(ctx *cli.Context) []string {
+	var res []string
+	for _, flag := range ctx.App.Flags {
+		name := flag.Names()[0]
+		if !isExperimental(flag) || !ctx.IsSet(name) {
+			continue
+		}
+		res = append(res, fmt.Sprintf("%s=%v", name, ctx.Value(name)))
+	}
+	slices.Sort(res)
+	return res
+}
+
+func isExperimental(flag cli.Flag) bool {
+	for _, name := range flag.Names() {
+		if strings.Contains(name, "experimental") {
+			return true
+		}
+	}
+	docFlag, ok := flag.(cli.DocGenerationFlag)
+	return ok && strings.Contains(strings.ToLower(docFlag.GetUsage()), "experimental")
+}
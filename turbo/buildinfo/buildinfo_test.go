@@ -0,0 +1,108 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package buildinfo
+
+import (
+	"encoding/json"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+
+	_ "github.com/erigontech/erigon-db/snaptype" // registers the block file types
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/snapcfg"
+	"github.com/erigontech/erigon/execution/chainspec"
+)
+
+func TestReportDeterministic(t *testing.T) {
+	a, err := New(chainspec.SepoliaChainConfig, []string{"experimental.concurrent-commitment=true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := New(chainspec.SepoliaChainConfig, []string{"experimental.concurrent-commitment=true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Digest != b.Digest || a.Chain.ConfigHash != b.Chain.ConfigHash {
+		t.Fatalf("digests %x %x", a.Digest, b.Digest)
+	}
+
+	c, err := New(chainspec.SepoliaChainConfig, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Digest == a.Digest {
+		t.Fatal("experimental features not in the digest")
+	}
+
+	// a devnet-like override of a fork
+	var cc chain.Config
+	data, _ := json.Marshal(chainspec.SepoliaChainConfig)
+	if err := json.Unmarshal(data, &cc); err != nil {
+		t.Fatal(err)
+	}
+	cc.PragueTime = big.NewInt(1)
+	d, err := New(&cc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Chain.ConfigHash == c.Chain.ConfigHash || d.Digest == c.Digest {
+		t.Fatal("chain config not in the digest")
+	}
+}
+
+func TestNewSnapshots(t *testing.T) {
+	items := snapcfg.PreverifiedItems{
+		{Name: "v1.0-000000-000500-headers.seg", Hash: "01"},
+		{Name: "v1.1-000500-001000-headers.seg", Hash: "02"},
+		{Name: "domain/v1.0-accounts.0-64.kv", Hash: "03"},
+	}
+	s := NewSnapshots(snapcfg.Preverified{Items: items})
+	if s.Files != 3 || s.Versions["headers.seg"] != "v1.1" || s.Versions["accounts.kv"] != "v1.0" {
+		t.Fatalf("snapshots %+v", s)
+	}
+	// the order of the manifest doesn't matter
+	reversed := slices.Clone(items)
+	slices.Reverse(reversed)
+	if r := NewSnapshots(snapcfg.Preverified{Items: reversed}); r.Hash != s.Hash {
+		t.Fatalf("hash depends on the order: %x %x", r.Hash, s.Hash)
+	}
+}
+
+func TestExperimentalFeatures(t *testing.T) {
+	var features []string
+	app := &cli.App{
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "experimental.a"},
+			&cli.BoolFlag{Name: "b", Usage: "EXPERIMENTAL: enables b"},
+			&cli.BoolFlag{Name: "experimental.c"},
+			&cli.BoolFlag{Name: "d"},
+		},
+		Action: func(ctx *cli.Context) error {
+			features = ExperimentalFeatures(ctx)
+			return nil
+		},
+	}
+	if err := app.Run([]string{"erigon", "--d", "--experimental.a", "--b"}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(features, []string{"b=true", "experimental.a=true"}) {
+		t.Fatalf("features %v", features)
+	}
+}
@@ -31,14 +31,32 @@ var DefaultFlags = []cli.Flag{
 	&utils.TxPoolPriceLimitFlag,
 	&utils.TxPoolPriceBumpFlag,
 	&utils.TxPoolBlobPriceBumpFlag,
+	&utils.TxPoolBlobFeeBumpFlag,
 	&utils.TxPoolAccountSlotsFlag,
 	&utils.TxPoolBlobSlotsFlag,
 	&utils.TxPoolTotalBlobPoolLimit,
+	&utils.TxPoolBlobMemoryLimitFlag,
+	&utils.TxPoolSenderMaxSlotsFlag,
+	&utils.TxPoolSenderMaxGasFlag,
+	&utils.TxPoolSenderMaxNonceGapFlag,
+	&utils.TxPoolSimulateLocalFlag,
+	&utils.TxPoolParkingLimitFlag,
+	&utils.TxPoolParkingMaxAgeFlag,
+	&utils.TxPoolQueuedLifetimeFlag,
+	&utils.TxPoolPeerRateFlag,
+	&utils.TxPoolPeerBurstFlag,
+	&utils.TxPoolPeerMaxPenaltyFlag,
+	&utils.TxPoolPrioritySendersFlag,
+	&utils.TxPoolOrderingFlag,
+	&utils.TxPoolOrderingAddrFlag,
 	&utils.TxPoolGlobalSlotsFlag,
 	&utils.TxPoolGlobalBaseFeeSlotsFlag,
 	&utils.TxPoolGlobalQueueFlag,
 	&utils.TxPoolTraceSendersFlag,
 	&utils.TxPoolCommitEveryFlag,
+	&utils.TxPoolFeeMarketHistoryEveryFlag,
+	&utils.TxPoolFeeMarketHistoryLimitFlag,
+	&utils.TxPoolPropagationWindowFlag,
 	&PruneDistanceFlag,
 	&PruneBlocksDistanceFlag,
 	&PruneModeFlag,
@@ -54,6 +72,7 @@ var DefaultFlags = []cli.Flag{
 	&TLSCertFlag,
 	&TLSKeyFlag,
 	&TLSCACertFlag,
+	&GRPCAuthTokenFileFlag,
 	&StateStreamDisableFlag,
 	&SyncLoopThrottleFlag,
 	&BadBlockFlag,
@@ -69,11 +88,23 @@ var DefaultFlags = []cli.Flag{
 	&utils.HttpCompressionFlag,
 	&utils.HTTPCORSDomainFlag,
 	&utils.HTTPVirtualHostsFlag,
+	&utils.HTTPTLSCertFlag,
+	&utils.HTTPTLSKeyFlag,
+	&utils.AuthRpcCORSDomainFlag,
 	&utils.AuthRpcVirtualHostsFlag,
+	&utils.AuthRpcTLSCertFlag,
+	&utils.AuthRpcTLSKeyFlag,
 	&utils.HTTPApiFlag,
 	&utils.WSPortFlag,
 	&utils.WSEnabledFlag,
+	&utils.WSAllowedOriginsFlag,
+	&utils.WSVirtualHostsFlag,
+	&utils.WSTLSCertFlag,
+	&utils.WSTLSKeyFlag,
 	&utils.WsCompressionFlag,
+	&utils.WSSubscriptionBufferFlag,
+	&utils.WSClientBufferFlag,
+	&utils.WSSlowConsumerFlag,
 	&utils.HTTPTraceFlag,
 	&utils.HTTPDebugSingleFlag,
 	&utils.StateCacheFlag,
@@ -81,10 +112,12 @@ var DefaultFlags = []cli.Flag{
 	&utils.RpcStreamingDisableFlag,
 	&utils.DBReadConcurrencyFlag,
 	&utils.RpcAccessListFlag,
+	&utils.RpcAPIKeysFlag,
 	&utils.RpcTraceCompatFlag,
 	&utils.RpcGasCapFlag,
 	&utils.RpcBatchLimit,
 	&utils.RpcReturnDataLimit,
+	&utils.RpcMaxGetProofRewindBlockCount,
 	&utils.AllowUnprotectedTxs,
 	&utils.RPCGlobalTxFeeCapFlag,
 	&utils.TxpoolApiAddrFlag,
@@ -97,6 +130,8 @@ var DefaultFlags = []cli.Flag{
 	&AuthRpcWriteTimeoutFlag,
 	&AuthRpcIdleTimeoutFlag,
 	&EvmCallTimeoutFlag,
+	&EvmMaxMemoryFlag,
+	&TxLookupNonCanonicalFlag,
 	&OverlayGetLogsFlag,
 	&OverlayReplayBlockFlag,
 
@@ -127,8 +162,14 @@ var DefaultFlags = []cli.Flag{
 	&utils.P2pProtocolVersionFlag,
 	&utils.P2pProtocolAllowedPorts,
 	&utils.NATFlag,
+	&utils.NATSTUNFallbackFlag,
 	&utils.NoDiscoverFlag,
 	&utils.DiscoveryV5Flag,
+	&utils.DiscoveryV5TopicsFlag,
+	&utils.DiscoveryPacketRateFlag,
+	&utils.DiscoveryPacketBurstFlag,
+	&utils.P2PBanViolationsFlag,
+	&utils.P2PBanDurationFlag,
 	&utils.NetrestrictFlag,
 	&utils.NodeKeyFileFlag,
 	&utils.NodeKeyHexFlag,
@@ -138,7 +179,9 @@ var DefaultFlags = []cli.Flag{
 	&utils.TrustedPeersFlag,
 	&utils.MaxPeersFlag,
 	&utils.ChainFlag,
+	&utils.ChainRegistryFlag,
 	&utils.DeveloperPeriodFlag,
+	&utils.DeveloperPoSFlag,
 	&utils.VMEnableDebugFlag,
 	&utils.NetworkIdFlag,
 	&utils.PersistReceiptsV2Flag,
@@ -163,6 +206,11 @@ var DefaultFlags = []cli.Flag{
 	&utils.MinerRecommitIntervalFlag,
 	&utils.SentryAddrFlag,
 	&utils.SentryLogPeerInfoFlag,
+	&utils.NoPreMergeHistoryFlag,
+	&utils.P2pLogClientDiversityFlag,
+	&utils.P2pMaxInboundHandshakesFlag,
+	&utils.P2pInboundConnsPerIPFlag,
+	&utils.P2pInboundHandshakeRateFlag,
 	&utils.DownloaderAddrFlag,
 	&utils.DisableIPV4,
 	&utils.DisableIPV6,
@@ -176,6 +224,16 @@ var DefaultFlags = []cli.Flag{
 	&utils.BorBlockSizeFlag,
 	&utils.AAFlag,
 	&utils.EthStatsURLFlag,
+	&utils.SinkURLFlag,
+	&utils.SinkFromBlockFlag,
+	&utils.MaintenanceConfigFlag,
+	&utils.SnapAutoUpdateFlag,
+	&utils.SnapAutoUpdateWindowFlag,
+	&utils.OpcodeOverridesFlag,
+	&utils.WatchdogRSSFlag,
+	&utils.WatchdogGoroutinesFlag,
+	&utils.WatchdogGCPauseFlag,
+	&utils.WatchdogFilesFlag,
 	&utils.OverrideOsakaFlag,
 
 	&utils.CaplinDiscoveryAddrFlag,
@@ -233,12 +291,21 @@ var DefaultFlags = []cli.Flag{
 	&utils.CaplinUseEngineApiFlag,
 
 	&utils.TrustedSetupFile,
+	&utils.KZGBackendFlag,
 	&utils.RPCSlowFlag,
 
 	&utils.TxPoolGossipDisableFlag,
+	&utils.TxPoolDumpDisableFlag,
 	&SyncLoopBlockLimitFlag,
 	&SyncLoopBreakAfterFlag,
 	&SyncParallelStateFlushing,
+	&SyncExecCheckpointIntervalFlag,
+	&SyncStateGrowthWindowFlag,
+	&SyncTxMetricsFlag,
+	&SyncSendersEcrecoverFlag,
+	&SyncCrossCheckSamplesFlag,
+	&SyncCrossCheckEpochFlag,
+	&SyncBadBlocksLimitFlag,
 
 	&utils.ChaosMonkeyFlag,
 
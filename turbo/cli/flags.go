@@ -19,6 +19,7 @@ package cli
 import (
 	"fmt"
 	"math"
+	"slices"
 	"time"
 
 	"github.com/c2h5oh/datasize"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/etl"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
@@ -112,6 +114,11 @@ var (
 		Usage: "Specify certificate authority",
 		Value: "",
 	}
+	GRPCAuthTokenFileFlag = cli.StringFlag{
+		Name:  "grpc.auth.tokenfile",
+		Usage: "File with tokens (one per line) authenticating calls of private gRPC interfaces (remote kv, txpool, sentry, downloader): the first one is sent, any is accepted. Re-read on change",
+		Value: "",
+	}
 	StateStreamDisableFlag = cli.BoolFlag{
 		Name:  "state.stream.disable",
 		Usage: "Disable streaming of state changes from core to RPC daemon",
@@ -136,6 +143,47 @@ var (
 		Value: 5_000,
 	}
 
+	SyncExecCheckpointIntervalFlag = cli.Uint64Flag{
+		Name:  "sync.exec.checkpoint.interval",
+		Usage: "Commit execution progress at least every N blocks during initial sync, to resume quickly after a crash (0 - disabled). Not supported by parallel execution",
+		Value: 0,
+	}
+
+	SyncStateGrowthWindowFlag = cli.Uint64Flag{
+		Name:  "sync.state-growth.window",
+		Usage: "Collect state growth analytics of executed blocks (new accounts, storage slots, code bytes; served by erigon_stateGrowth and metrics) and keep per-block and per-contract stats of N recent blocks (0 - disabled)",
+		Value: 0,
+	}
+
+	SyncTxMetricsFlag = cli.BoolFlag{
+		Name:  "sync.tx-metrics",
+		Usage: "Store resource usage of executed transactions (execution time, state reads and writes, EVM call depth), served by erigon_getTransactionMetrics",
+	}
+
+	SyncSendersEcrecoverFlag = cli.StringFlag{
+		Name:  "sync.senders.ecrecover",
+		Usage: "Signature recovery of senders stage: 'libsecp256k1' - C library, fastest with cgo; 'batch' - pure Go, recovers all transactions of a block at once (batched inversions, GLV, precomputed tables), fastest without cgo",
+		Value: ethconfig.Defaults.Sync.SendersEcrecover,
+	}
+
+	SyncCrossCheckSamplesFlag = cli.Uint64Flag{
+		Name:  "sync.cross-check.samples",
+		Usage: "Correctness canary: re-execute N random blocks of every --sync.cross-check.epoch executed blocks, on the history state and then on their execution witness only, and alert (log and metrics) if the results diverge from each other, from the header or from the state written by execution (0 - disabled)",
+		Value: 0,
+	}
+
+	SyncBadBlocksLimitFlag = cli.Uint64Flag{
+		Name:  "sync.bad-blocks.limit",
+		Usage: "Keep the last N invalid blocks (received via engine_newPayload or p2p) with their validation error in <datadir>/badblocks, served by debug_getBadBlocks and the bad-blocks command (0 - disabled)",
+		Value: ethconfig.Defaults.Sync.BadBlocksLimit,
+	}
+
+	SyncCrossCheckEpochFlag = cli.Uint64Flag{
+		Name:  "sync.cross-check.epoch",
+		Usage: "Number of executed blocks sampled by --sync.cross-check.samples",
+		Value: ethconfig.Defaults.Sync.CrossCheckEpoch,
+	}
+
 	SyncParallelStateFlushing = cli.BoolFlag{
 		Name:  "sync.parallel-state-flushing",
 		Usage: "Enables parallel state flushing",
@@ -209,6 +257,17 @@ var (
 		Value: rpccfg.DefaultEvmCallTimeout,
 	}
 
+	EvmMaxMemoryFlag = cli.Uint64Flag{
+		Name:  "rpc.evm.maxmemory",
+		Usage: "Maximum memory (MB) of a single call frame of eth_call, eth_estimateGas and eth_callMany, independent of gas (0 = unlimited)",
+		Value: 0,
+	}
+
+	TxLookupNonCanonicalFlag = cli.BoolFlag{
+		Name:  "rpc.txlookup.noncanonical",
+		Usage: "eth_getTransactionByHash: for a transaction of a block removed from the canonical chain by reorg, return an error with the block number and hash instead of null",
+	}
+
 	OverlayGetLogsFlag = cli.DurationFlag{
 		Name:  "rpc.overlay.getlogstimeout",
 		Usage: "Maximum amount of time to wait for the answer from the overlay_getLogs call.",
@@ -317,6 +376,19 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config, logger log.
 		cfg.Sync.LoopBlockLimit = limit
 	}
 	cfg.Sync.ParallelStateFlushing = ctx.Bool(SyncParallelStateFlushing.Name)
+	cfg.Sync.ExecCheckpointInterval = ctx.Uint64(SyncExecCheckpointIntervalFlag.Name)
+	cfg.Sync.StateGrowthWindow = ctx.Uint64(SyncStateGrowthWindowFlag.Name)
+	cfg.Sync.TxMetrics = ctx.Bool(SyncTxMetricsFlag.Name)
+	cfg.Sync.SendersEcrecover = ctx.String(SyncSendersEcrecoverFlag.Name)
+	cfg.Sync.CrossCheckSamples = ctx.Uint64(SyncCrossCheckSamplesFlag.Name)
+	cfg.Sync.CrossCheckEpoch = ctx.Uint64(SyncCrossCheckEpochFlag.Name)
+	cfg.Sync.BadBlocksLimit = ctx.Uint64(SyncBadBlocksLimitFlag.Name)
+	if cfg.Sync.CrossCheckSamples > 0 && cfg.Sync.CrossCheckEpoch == 0 {
+		utils.Fatalf("%s must be > 0", SyncCrossCheckEpochFlag.Name)
+	}
+	if !slices.Contains(crypto.EcrecoverBackends, cfg.Sync.SendersEcrecover) {
+		utils.Fatalf("Invalid %s: %q, expected one of %v", SyncSendersEcrecoverFlag.Name, cfg.Sync.SendersEcrecover, crypto.EcrecoverBackends)
+	}
 
 	if location := ctx.String(UploadLocationFlag.Name); len(location) > 0 {
 		cfg.Sync.UploadLocation = location
@@ -430,6 +502,8 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		TLSCACert:   cfg.TLSCACert,
 		TLSCertfile: cfg.TLSCertFile,
 
+		GRPCAuthTokenFile: cfg.GRPCAuthTokenFile,
+
 		GraphQLEnabled:           ctx.Bool(utils.GraphQLEnabledFlag.Name),
 		HttpListenAddress:        ctx.String(utils.HTTPListenAddrFlag.Name),
 		HttpPort:                 ctx.Int(utils.HTTPPortFlag.Name),
@@ -440,7 +514,12 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		DebugSingleRequest:       ctx.Bool(utils.HTTPDebugSingleFlag.Name),
 		HttpCORSDomain:           common.CliString2Array(ctx.String(utils.HTTPCORSDomainFlag.Name)),
 		HttpVirtualHost:          common.CliString2Array(ctx.String(utils.HTTPVirtualHostsFlag.Name)),
+		HttpCertfile:             ctx.String(utils.HTTPTLSCertFlag.Name),
+		HttpKeyFile:              ctx.String(utils.HTTPTLSKeyFlag.Name),
+		AuthRpcCORSDomain:        common.CliString2Array(ctx.String(utils.AuthRpcCORSDomainFlag.Name)),
 		AuthRpcVirtualHost:       common.CliString2Array(ctx.String(utils.AuthRpcVirtualHostsFlag.Name)),
+		AuthRpcCertfile:          ctx.String(utils.AuthRpcTLSCertFlag.Name),
+		AuthRpcKeyFile:           ctx.String(utils.AuthRpcTLSKeyFlag.Name),
 		API:                      common.CliString2Array(apis),
 		HTTPTimeouts: rpccfg.HTTPTimeouts{
 			ReadTimeout:  ctx.Duration(HTTPReadTimeoutFlag.Name),
@@ -452,15 +531,25 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 			WriteTimeout: ctx.Duration(AuthRpcWriteTimeoutFlag.Name),
 			IdleTimeout:  ctx.Duration(HTTPIdleTimeoutFlag.Name),
 		},
-		EvmCallTimeout:            ctx.Duration(EvmCallTimeoutFlag.Name),
-		OverlayGetLogsTimeout:     ctx.Duration(OverlayGetLogsFlag.Name),
-		OverlayReplayBlockTimeout: ctx.Duration(OverlayReplayBlockFlag.Name),
-		WebsocketPort:             ctx.Int(utils.WSPortFlag.Name),
-		WebsocketEnabled:          ctx.IsSet(utils.WSEnabledFlag.Name),
-		RpcBatchConcurrency:       ctx.Uint(utils.RpcBatchConcurrencyFlag.Name),
-		RpcStreamingDisable:       ctx.Bool(utils.RpcStreamingDisableFlag.Name),
-		DBReadConcurrency:         ctx.Int(utils.DBReadConcurrencyFlag.Name),
-		RpcAllowListFilePath:      ctx.String(utils.RpcAccessListFlag.Name),
+		EvmCallTimeout:                ctx.Duration(EvmCallTimeoutFlag.Name),
+		EvmMaxMemoryMB:                ctx.Uint64(EvmMaxMemoryFlag.Name),
+		TxLookupNonCanonical:          ctx.Bool(TxLookupNonCanonicalFlag.Name),
+		OverlayGetLogsTimeout:         ctx.Duration(OverlayGetLogsFlag.Name),
+		OverlayReplayBlockTimeout:     ctx.Duration(OverlayReplayBlockFlag.Name),
+		WebsocketPort:                 ctx.Int(utils.WSPortFlag.Name),
+		WebsocketEnabled:              ctx.IsSet(utils.WSEnabledFlag.Name),
+		WebsocketOrigins:              common.CliString2Array(ctx.String(utils.WSAllowedOriginsFlag.Name)),
+		WebsocketVirtualHost:          common.CliString2Array(ctx.String(utils.WSVirtualHostsFlag.Name)),
+		WebsocketCertfile:             ctx.String(utils.WSTLSCertFlag.Name),
+		WebsocketKeyFile:              ctx.String(utils.WSTLSKeyFlag.Name),
+		WebsocketSubscriptionBufferMB: ctx.Int(utils.WSSubscriptionBufferFlag.Name),
+		WebsocketClientBufferMB:       ctx.Int(utils.WSClientBufferFlag.Name),
+		WebsocketSlowConsumer:         ctx.String(utils.WSSlowConsumerFlag.Name),
+		RpcBatchConcurrency:           ctx.Uint(utils.RpcBatchConcurrencyFlag.Name),
+		RpcStreamingDisable:           ctx.Bool(utils.RpcStreamingDisableFlag.Name),
+		DBReadConcurrency:             ctx.Int(utils.DBReadConcurrencyFlag.Name),
+		RpcAllowListFilePath:          ctx.String(utils.RpcAccessListFlag.Name),
+		RpcAPIKeysFilePath:            ctx.String(utils.RpcAPIKeysFlag.Name),
 		RpcFiltersConfig: rpchelper.FiltersConfig{
 			RpcSubscriptionFiltersMaxLogs:      ctx.Int(RpcSubscriptionFiltersMaxLogsFlag.Name),
 			RpcSubscriptionFiltersMaxHeaders:   ctx.Int(RpcSubscriptionFiltersMaxHeadersFlag.Name),
@@ -476,6 +565,8 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		ReturnDataLimit:     ctx.Int(utils.RpcReturnDataLimit.Name),
 		AllowUnprotectedTxs: ctx.Bool(utils.AllowUnprotectedTxs.Name),
 
+		MaxGetProofRewindBlockCount: ctx.Int(utils.RpcMaxGetProofRewindBlockCount.Name),
+
 		OtsMaxPageSize: ctx.Uint64(utils.OtsSearchMaxCapFlag.Name),
 
 		TxPoolApiAddr: ctx.String(utils.TxpoolApiAddrFlag.Name),
@@ -536,6 +627,7 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 func setPrivateApi(ctx *cli.Context, cfg *nodecfg.Config) {
 	cfg.PrivateApiAddr = ctx.String(PrivateApiAddr.Name)
 	cfg.PrivateApiRateLimit = uint32(ctx.Uint64(PrivateApiRateLimit.Name))
+	cfg.GRPCAuthTokenFile = ctx.String(GRPCAuthTokenFileFlag.Name)
 	maxRateLimit := uint32(kv.ReadersLimit - 128) // leave some readers for P2P
 	if cfg.PrivateApiRateLimit > maxRateLimit {
 		log.Warn("private.api.ratelimit is too big", "force", maxRateLimit)
@@ -0,0 +1,399 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package diskforecast estimates growth of the datadir per stage and domain from the files of the recent
+// blocks/steps and the prune mode, and the number of days until the disk of the datadir is full.
+package diskforecast
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/disk"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv/prune"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/snaptype"
+)
+
+const (
+	// growth of a file group is estimated over its most recent files covering at least:
+	blocksWindow = 1_000_000
+	stepsWindow  = 128
+
+	// WarnDays - Watch warns if the disk is expected to be full earlier
+	WarnDays = 7
+	// MaxDays - forecast horizon, days left if the datadir doesn't grow
+	MaxDays = 100 * 365
+)
+
+// Kinds of files
+const (
+	KindSegment  = "segment"  // blocks snapshots
+	KindDomain   = "domain"   // latest state
+	KindHistory  = "history"  // historical state
+	KindIndex    = "index"    // inverted indices
+	KindAccessor = "accessor" // accessors of state files
+	KindCaplin   = "caplin"
+	KindDB       = "db"
+)
+
+type Config struct {
+	Dirs            datadir.Dirs
+	Prune           prune.Mode // not initialised - inferred from the files
+	SecondsPerBlock uint64     // chain.Config.SecondsPerSlot()
+	// Optional sync progress: growth of the state to the head block is forecasted if the head is ahead
+	ExecutedBlock, HeadBlock uint64
+}
+
+// Item - files of a domain (or blocks of a type) written by a stage
+type Item struct {
+	Stage  string `json:"stage"`
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Size   uint64 `json:"size"`
+	PerDay uint64 `json:"perDay"`           // forecasted growth
+	Pruned bool   `json:"pruned,omitempty"` // size is kept by pruning
+}
+
+type Forecast struct {
+	Items         []Item  `json:"items"`
+	Size          uint64  `json:"size"`
+	PerDay        uint64  `json:"perDay"`
+	ToHead        uint64  `json:"toHead"` // growth until the state reaches HeadBlock
+	Free          uint64  `json:"free"`   // on the disk of the datadir
+	Total         uint64  `json:"total"`
+	DaysLeft      float64 `json:"daysLeft"` // until the disk is full after reaching the head, at most MaxDays
+	HistoryPruned bool    `json:"historyPruned"`
+	BlocksPruned  bool    `json:"blocksPruned"`
+}
+
+// Warning returns a warning if the disk is expected to be full in less than `days` days or before reaching the head
+func (f *Forecast) Warning(days float64) error {
+	if f.ToHead > f.Free {
+		return fmt.Errorf("not enough disk space to reach the head: %s needed, %s free", common.ByteCount(f.ToHead), common.ByteCount(f.Free))
+	}
+	if f.DaysLeft < days {
+		return fmt.Errorf("disk is expected to be full in %.1f days: %s free, growth %s/day", f.DaysLeft, common.ByteCount(f.Free), common.ByteCount(f.PerDay))
+	}
+	return nil
+}
+
+type fileGroup struct {
+	stage, name, kind string
+	files             []file
+}
+
+type file struct {
+	from, to uint64
+	size     uint64
+}
+
+// Plan forecasts growth of the datadir
+func Plan(cfg Config) (*Forecast, error) {
+	if cfg.SecondsPerBlock == 0 {
+		cfg.SecondsPerBlock = 12
+	}
+	blocksPerDay := float64(24*60*60) / float64(cfg.SecondsPerBlock)
+	dirs := cfg.Dirs
+
+	blocks, err := scanFiles(dirs.Snap, KindSegment, false)
+	if err != nil {
+		return nil, err
+	}
+	var state []*fileGroup
+	for _, d := range []struct{ dir, kind string }{
+		{dirs.SnapDomain, KindDomain}, {dirs.SnapHistory, KindHistory}, {dirs.SnapIdx, KindIndex}, {dirs.SnapAccessors, KindAccessor},
+	} {
+		groups, err := scanFiles(d.dir, d.kind, false)
+		if err != nil {
+			return nil, err
+		}
+		state = append(state, groups...)
+	}
+	caplin, err := scanFiles(dirs.SnapCaplin, KindCaplin, true)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksTip, stepsTip := tip(blocks), tip(state)
+	historyPruned, blocksPruned := cfg.Prune.Initialised && cfg.Prune.History.Enabled(), blocksPrunedByDistance(cfg.Prune)
+	if !cfg.Prune.Initialised {
+		historyPruned, blocksPruned = inferPruning(blocks, state, blocksTip)
+	}
+	executed := cfg.ExecutedBlock
+	if executed == 0 {
+		executed = blocksTip
+	}
+	var blocksPerStep float64 // average over the chain: recent blocks have more transactions
+	if stepsTip > 0 {
+		blocksPerStep = float64(executed) / float64(stepsTip)
+	}
+
+	f := &Forecast{HistoryPruned: historyPruned, BlocksPruned: blocksPruned}
+	add := func(g *fileGroup, pruned bool, perUnit, unitsPerDay, unitsToHead float64) {
+		item := Item{Stage: g.stage, Name: g.name, Kind: g.kind, Size: g.size(), Pruned: pruned}
+		if !pruned {
+			item.PerDay = uint64(perUnit * unitsPerDay)
+			f.ToHead += uint64(perUnit * unitsToHead)
+		}
+		f.Items = append(f.Items, item)
+	}
+	var blocksToHead float64
+	if cfg.HeadBlock > blocksTip {
+		blocksToHead = float64(cfg.HeadBlock - blocksTip)
+	}
+	for _, g := range blocks {
+		add(g, blocksPruned && g.name != "headers", g.rate(blocksWindow), blocksPerDay, blocksToHead)
+	}
+	var stepsPerDay, stepsToHead float64
+	if blocksPerStep > 0 {
+		stepsPerDay = blocksPerDay / blocksPerStep
+		if cfg.HeadBlock > executed {
+			stepsToHead = float64(cfg.HeadBlock-executed) / blocksPerStep
+		}
+	}
+	for _, g := range state {
+		add(g, historyPruned && g.kind != KindDomain, g.rate(stepsWindow), stepsPerDay, stepsToHead)
+	}
+	for _, g := range caplin {
+		add(g, false, g.rate(blocksWindow), blocksPerDay, 0) // a slot per block
+	}
+	// bounded by pruning: recent blocks and state not in files yet, blobs of the recent epochs
+	for _, d := range []struct{ stage, name, dir string }{
+		{"all", "chaindata", dirs.Chaindata}, {"Caplin", "blobs", dirs.CaplinBlobs},
+		{"Caplin", "columns", dirs.CaplinColumnData}, {"Caplin", "indexing", dirs.CaplinIndexing},
+	} {
+		size, err := dirSize(d.dir)
+		if err != nil {
+			return nil, err
+		}
+		if size > 0 {
+			f.Items = append(f.Items, Item{Stage: d.stage, Name: d.name, Kind: KindDB, Size: size, Pruned: true})
+		}
+	}
+
+	for _, item := range f.Items {
+		f.Size += item.Size
+		f.PerDay += item.PerDay
+	}
+	if f.Free, f.Total, err = diskSpace(dirs.DataDir); err != nil {
+		return nil, err
+	}
+	f.DaysLeft = MaxDays
+	if f.PerDay > 0 {
+		f.DaysLeft = min(MaxDays, float64(f.Free-min(f.Free, f.ToHead))/float64(f.PerDay))
+	}
+	slices.SortStableFunc(f.Items, func(a, b Item) int { return cmp.Compare(b.PerDay, a.PerDay) })
+	return f, nil
+}
+
+// blocksPrunedByDistance - blocks older than a distance are deleted: the default of the full mode
+// (history expiry of the chain) keeps new blocks
+func blocksPrunedByDistance(m prune.Mode) bool {
+	return m.Initialised && m.Blocks != prune.DefaultBlocksPruneMode && m.Blocks != prune.KeepAllBlocksPruneMode && m.Blocks.Enabled()
+}
+
+// inferPruning - history is pruned if its files don't start from the first step, blocks are pruned if
+// bodies of less than half of the chain are kept
+func inferPruning(blocks, state []*fileGroup, blocksTip uint64) (historyPruned, blocksPruned bool) {
+	for _, g := range state {
+		if g.kind == KindHistory && len(g.files) > 0 && g.files[0].from > 0 {
+			historyPruned = true
+		}
+	}
+	for _, g := range blocks {
+		if g.name == "bodies" && len(g.files) > 0 && g.files[0].from > blocksTip/2 {
+			blocksPruned = true
+		}
+	}
+	return historyPruned, blocksPruned
+}
+
+var stages = map[string]string{
+	"headers":        "Headers",
+	"bodies":         "Bodies",
+	"transactions":   "Bodies",
+	"borevents":      "BorHeimdall",
+	"borspans":       "BorHeimdall",
+	"bormilestones":  "BorHeimdall",
+	"borcheckpoints": "BorHeimdall",
+}
+
+func stageOf(kind, name string) string {
+	switch kind {
+	case KindSegment:
+		if s, ok := stages[name]; ok {
+			return s
+		}
+		return "Snapshots"
+	case KindCaplin:
+		return "Caplin"
+	default:
+		return "Execution"
+	}
+}
+
+// scanFiles groups files of `dir` by type and kind, files covered by merged files are skipped
+func scanFiles(dir, kind string, caplin bool) ([]*fileGroup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	groups := map[string]*fileGroup{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if caplin {
+			name = "caplin/" + name // types of caplin files are known only in its dir
+		}
+		// not ok for types not registered by the node: bor segments of a non-bor chain
+		fi, _, _ := snaptype.ParseFileName(dir, name)
+		if fi.TypeString == "" || fi.To <= fi.From {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) { // removed by merge
+				continue
+			}
+			return nil, err
+		}
+		g, ok := groups[fi.TypeString]
+		if !ok {
+			g = &fileGroup{stage: stageOf(kind, fi.TypeString), name: fi.TypeString, kind: kind}
+			groups[fi.TypeString] = g
+		}
+		g.files = append(g.files, file{from: fi.From, to: fi.To, size: uint64(info.Size())})
+	}
+	res := make([]*fileGroup, 0, len(groups))
+	for _, g := range groups {
+		// several files of a range: .kv and .bt, .seg and .idx
+		slices.SortFunc(g.files, func(a, b file) int {
+			return cmp.Or(cmp.Compare(a.from, b.from), cmp.Compare(b.to, a.to))
+		})
+		merged := g.files[:0]
+		for _, f := range g.files {
+			if n := len(merged); n > 0 && f.to <= merged[n-1].to {
+				if f.from == merged[n-1].from && f.to == merged[n-1].to {
+					merged[n-1].size += f.size
+				}
+				continue
+			}
+			merged = append(merged, f)
+		}
+		g.files = merged
+		res = append(res, g)
+	}
+	slices.SortFunc(res, func(a, b *fileGroup) int { return strings.Compare(a.name, b.name) })
+	return res, nil
+}
+
+func (g *fileGroup) size() (size uint64) {
+	for _, f := range g.files {
+		size += f.size
+	}
+	return size
+}
+
+// rate returns bytes per block (step) over the most recent files covering at least `window` blocks (steps)
+func (g *fileGroup) rate(window uint64) float64 {
+	var size, covered uint64
+	for i := len(g.files) - 1; i >= 0 && covered < window; i-- {
+		size += g.files[i].size
+		covered += g.files[i].to - g.files[i].from
+	}
+	if covered == 0 {
+		return 0
+	}
+	return float64(size) / float64(covered)
+}
+
+func tip(groups []*fileGroup) (to uint64) {
+	for _, g := range groups {
+		if n := len(g.files); n > 0 {
+			to = max(to, g.files[n-1].to)
+		}
+	}
+	return to
+}
+
+func diskSpace(dir string) (free, total uint64, err error) {
+	usage, err := disk.Usage(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	return usage.Free, usage.Total, nil
+}
+
+func dirSize(dir string) (size uint64, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
+
+// Watch logs a warning every `every` if the disk is expected to be full in less than WarnDays or before reaching the head
+func Watch(ctx context.Context, cfg func() Config, every time.Duration, logger log.Logger) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		c := cfg()
+		f, err := Plan(c)
+		if err != nil {
+			logger.Debug("[disk] forecast failed", "err", err)
+		} else if err := f.Warning(WarnDays); err != nil {
+			logger.Warn("[disk] "+err.Error(), "datadir", c.Dirs.DataDir)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diskforecast
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv/prune"
+)
+
+// createFile creates a sparse file of `size` bytes
+func createFile(t *testing.T, dir, name string, size int64) {
+	t.Helper()
+	fn := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(fn, nil, 0644))
+	require.NoError(t, os.Truncate(fn, size))
+}
+
+func findItem(f *Forecast, kind, name string) *Item {
+	for i := range f.Items {
+		if f.Items[i].Kind == kind && f.Items[i].Name == name {
+			return &f.Items[i]
+		}
+	}
+	return nil
+}
+
+func testDirs(t *testing.T) datadir.Dirs {
+	dirs := datadir.New(t.TempDir())
+	// 2M blocks: 1M of 100 bytes/block, then merged 500K files of 200 bytes/block
+	createFile(t, dirs.Snap, "v1.0-000000-001000-headers.seg", 100*1_000_000)
+	createFile(t, dirs.Snap, "v1.0-000000-001000-headers.idx", 0)
+	createFile(t, dirs.Snap, "v1.0-001000-001500-headers.seg", 200*500_000)
+	createFile(t, dirs.Snap, "v1.0-001500-002000-headers.seg", 200*500_000)
+	createFile(t, dirs.Snap, "v1.0-001500-001600-headers.seg", 1) // merged into 1500-2000
+	createFile(t, dirs.Snap, "v1.0-000000-001000-bodies.seg", 10*1_000_000)
+	createFile(t, dirs.Snap, "v1.0-001000-002000-bodies.seg", 20*1_000_000)
+
+	// 256 steps: 2 files of 128 steps
+	createFile(t, dirs.SnapDomain, "v1.0-accounts.0-128.kv", 1000*128)
+	createFile(t, dirs.SnapDomain, "v1.0-accounts.128-256.kv", 3000*128)
+	createFile(t, dirs.SnapDomain, "v1.0-accounts.128-256.bt", 1000*128)
+	createFile(t, dirs.SnapHistory, "v1.0-accounts.128-256.v", 8000*128)
+	return dirs
+}
+
+func TestPlan(t *testing.T) {
+	dirs := testDirs(t)
+	f, err := Plan(Config{Dirs: dirs, Prune: prune.ArchiveMode, SecondsPerBlock: 12, HeadBlock: 2_000_000 + 7200})
+	require.NoError(t, err)
+	require.False(t, f.HistoryPruned)
+	require.False(t, f.BlocksPruned)
+
+	headers := findItem(f, KindSegment, "headers")
+	require.NotNil(t, headers)
+	require.Equal(t, "Headers", headers.Stage)
+	require.Equal(t, uint64(100*1_000_000+2*200*500_000), headers.Size)
+	require.Equal(t, uint64(200*7200), headers.PerDay) // rate of the last 1M blocks
+	bodies := findItem(f, KindSegment, "bodies")
+	require.Equal(t, "Bodies", bodies.Stage)
+	require.Equal(t, uint64(20*7200), bodies.PerDay)
+
+	// 7200 blocks per day, 2M/256 blocks per step
+	stepsPerDay := 7200.0 * 256 / 2_000_000
+	accounts := findItem(f, KindDomain, "accounts")
+	require.Equal(t, "Execution", accounts.Stage)
+	require.Equal(t, uint64(5000*128), accounts.Size)
+	require.Equal(t, uint64(4000*stepsPerDay), accounts.PerDay) // .kv and .bt of the last 128 steps
+	history := findItem(f, KindHistory, "accounts")
+	require.Equal(t, uint64(8000*stepsPerDay), history.PerDay)
+
+	// a day of blocks and state to execute to the head
+	require.InDelta(t, float64(f.PerDay), float64(f.ToHead), 2)
+	require.Equal(t, f.Size, headers.Size+bodies.Size+accounts.Size+history.Size)
+	require.Positive(t, f.Free)
+	require.Positive(t, f.DaysLeft)
+	require.NoError(t, f.Warning(0))
+}
+
+func TestPlanPruned(t *testing.T) {
+	dirs := testDirs(t)
+	f, err := Plan(Config{Dirs: dirs, Prune: prune.MinimalMode})
+	require.NoError(t, err)
+	require.True(t, f.HistoryPruned)
+	require.True(t, f.BlocksPruned)
+	require.True(t, findItem(f, KindSegment, "bodies").Pruned)
+	require.Zero(t, findItem(f, KindSegment, "bodies").PerDay)
+	require.False(t, findItem(f, KindSegment, "headers").Pruned)
+	require.True(t, findItem(f, KindHistory, "accounts").Pruned)
+	require.False(t, findItem(f, KindDomain, "accounts").Pruned)
+
+	// inferred from the files: history doesn't start from step 0
+	f, err = Plan(Config{Dirs: dirs})
+	require.NoError(t, err)
+	require.True(t, f.HistoryPruned)
+	require.False(t, f.BlocksPruned)
+}
+
+func TestWarning(t *testing.T) {
+	f := &Forecast{Free: 100, PerDay: 50, DaysLeft: 2}
+	require.NoError(t, f.Warning(1))
+	require.ErrorContains(t, f.Warning(3), "disk is expected to be full in 2.0 days")
+	f.ToHead = 200
+	require.ErrorContains(t, f.Warning(1), "not enough disk space to reach the head")
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package execsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// kafkaRestPublisher - produces to Kafka topic through REST proxy: POST /topics/<topic> of v2 API
+type kafkaRestPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func newKafkaRestPublisher(url string, timeout time.Duration) *kafkaRestPublisher {
+	return &kafkaRestPublisher{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition int     `json:"partition"`
+		Offset    int64   `json:"offset"`
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (p *kafkaRestPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	body, err := json.Marshal(struct {
+		Records []kafkaRecord `json:"records"`
+	}{Records: []kafkaRecord{{Key: key, Value: data}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kafka rest proxy: %s: %s", resp.Status, respBody)
+	}
+	var res kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		return fmt.Errorf("kafka rest proxy: %w", err)
+	}
+	if len(res.Offsets) != 1 {
+		return fmt.Errorf("kafka rest proxy: expected 1 offset, got %d", len(res.Offsets))
+	}
+	if o := res.Offsets[0]; o.ErrorCode != nil {
+		var msg string
+		if o.Error != nil {
+			msg = *o.Error
+		}
+		return fmt.Errorf("kafka rest proxy: error_code=%d: %s", *o.ErrorCode, msg)
+	}
+	return nil
+}
+
+func (p *kafkaRestPublisher) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package execsink
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// natsPublisher - minimal NATS client (text protocol) publishing to JetStream: every message waits for
+// the stream's ack and carries `Nats-Msg-Id` header, so re-published blocks are de-duplicated by the stream.
+// Supports TLS (`tls` option or server's `tls_required`) and user/password or token auth; nkey/JWT (.creds) auth is not supported.
+// Not safe for concurrent use: the sink publishes sequentially.
+type natsPublisher struct {
+	addr, subject, inbox string
+	timeout              time.Duration
+
+	tls               bool // upgrade to TLS even if server doesn't require it
+	user, pass, token string
+
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newNatsPublisher(addr, subject string, timeout time.Duration) *natsPublisher {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return &natsPublisher{addr: addr, subject: subject, inbox: "_INBOX.erigon." + hex.EncodeToString(id[:]), timeout: timeout}
+}
+
+// natsInfo - fields of server's INFO used by the handshake
+type natsInfo struct {
+	TLSRequired  bool `json:"tls_required"`
+	AuthRequired bool `json:"auth_required"`
+}
+
+type natsConnect struct {
+	Verbose      bool   `json:"verbose"`
+	Pedantic     bool   `json:"pedantic"`
+	TLSRequired  bool   `json:"tls_required"`
+	Headers      bool   `json:"headers"`
+	NoResponders bool   `json:"no_responders"`
+	Name         string `json:"name"`
+	Lang         string `json:"lang"`
+	Protocol     int    `json:"protocol"`
+	User         string `json:"user,omitempty"`
+	Pass         string `json:"pass,omitempty"`
+	AuthToken    string `json:"auth_token,omitempty"`
+}
+
+type jsPubAck struct {
+	Stream    string `json:"stream"`
+	Seq       uint64 `json:"seq"`
+	Duplicate bool   `json:"duplicate"`
+	Error     *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if err := p.publish(ctx, key, data); err != nil {
+		// connection state is unknown after an error: reconnect on next publish
+		p.Close()
+		return err
+	}
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn, p.r = nil, nil
+	return err
+}
+
+func (p *natsPublisher) setDeadline(ctx context.Context) error {
+	deadline := time.Now().Add(p.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	return p.conn.SetDeadline(deadline)
+}
+
+func (p *natsPublisher) connect(ctx context.Context) error {
+	d := net.Dialer{Timeout: p.timeout}
+	conn, err := d.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return err
+	}
+	p.conn, p.r = conn, bufio.NewReader(conn)
+	if err := p.handshake(ctx); err != nil {
+		p.Close()
+		return fmt.Errorf("nats %s: %w", p.addr, err)
+	}
+	return nil
+}
+
+func (p *natsPublisher) handshake(ctx context.Context) error {
+	if err := p.setDeadline(ctx); err != nil {
+		return err
+	}
+	line, err := p.readLine()
+	if err != nil {
+		return err
+	}
+	infoJSON, ok := strings.CutPrefix(line, "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected greeting: %q", line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		return fmt.Errorf("bad INFO: %w", err)
+	}
+	// NATS upgrades to TLS after the plaintext INFO
+	if p.tls || info.TLSRequired {
+		host, _, err := net.SplitHostPort(p.addr)
+		if err != nil {
+			return err
+		}
+		conn := tls.Client(p.conn, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err := conn.HandshakeContext(ctx); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+		p.conn, p.r = conn, bufio.NewReader(conn)
+	}
+	if info.AuthRequired && p.user == "" && p.token == "" {
+		return errors.New("server requires auth, set user:password@ or token@ in the sink url")
+	}
+	connect, err := json.Marshal(natsConnect{
+		TLSRequired:  p.tls || info.TLSRequired,
+		Headers:      true,
+		NoResponders: true,
+		Name:         "erigon",
+		Lang:         "go",
+		Protocol:     1,
+		User:         p.user,
+		Pass:         p.pass,
+		AuthToken:    p.token,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(p.conn, "CONNECT %s\r\nSUB %s 1\r\nPING\r\n", connect, p.inbox); err != nil {
+		return err
+	}
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New(line)
+		}
+	}
+}
+
+func (p *natsPublisher) publish(ctx context.Context, key string, data []byte) error {
+	if err := p.setDeadline(ctx); err != nil {
+		return err
+	}
+	hdr := "NATS/1.0\r\nNats-Msg-Id: " + key + "\r\n\r\n"
+	if _, err := fmt.Fprintf(p.conn, "HPUB %s %s %d %d\r\n%s%s\r\n", p.subject, p.inbox, len(hdr), len(hdr)+len(data), hdr, data); err != nil {
+		return err
+	}
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PING":
+			if _, err := io.WriteString(p.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: %s", line)
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(line)
+			payload, err := p.readPayload(fields[len(fields)-1])
+			if err != nil {
+				return err
+			}
+			return parsePubAck(payload)
+		case strings.HasPrefix(line, "HMSG "):
+			// HMSG <subject> <sid> [reply-to] <#header bytes> <#total bytes>: status-only reply, e.g. 503 no responders
+			fields := strings.Fields(line)
+			payload, err := p.readPayload(fields[len(fields)-1])
+			if err != nil {
+				return err
+			}
+			status, _, _ := strings.Cut(string(payload), "\r\n")
+			return fmt.Errorf("nats: no JetStream ack for subject %s: %s", p.subject, status)
+		}
+		// +OK, PONG, INFO - ignored
+	}
+}
+
+func (p *natsPublisher) readLine() (string, error) {
+	line, err := p.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *natsPublisher) readPayload(size string) ([]byte, error) {
+	n, err := strconv.Atoi(size)
+	if err != nil {
+		return nil, fmt.Errorf("nats: bad message size %q", size)
+	}
+	buf := make([]byte, n+2) // payload + \r\n
+	if _, err := io.ReadFull(p.r, buf); err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+func parsePubAck(payload []byte) error {
+	var ack jsPubAck
+	if err := json.Unmarshal(payload, &ack); err != nil {
+		return fmt.Errorf("nats: bad JetStream ack %q: %w", payload, err)
+	}
+	if ack.Error != nil {
+		return fmt.Errorf("nats: JetStream error %d: %s", ack.Error.Code, ack.Error.Description)
+	}
+	if ack.Stream == "" {
+		return fmt.Errorf("nats: bad JetStream ack %q", payload)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package execsink
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dir"
+)
+
+// recentBlocks - amount of published block hashes kept to detect reorgs
+const recentBlocks = 128
+
+type publishedBlock struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// offsets - sink position: next block to publish and hashes of recently published blocks
+type offsets struct {
+	file    string
+	Started bool             `json:"started"`
+	Next    uint64           `json:"next"`
+	Recent  []publishedBlock `json:"recent"`
+}
+
+func loadOffsets(file string) (*offsets, error) {
+	o := &offsets{file: file}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Start - position of a new sink
+func (o *offsets) Start(fromBlock, head uint64) {
+	o.Started = true
+	o.Next = fromBlock
+	if fromBlock == 0 {
+		o.Next = head + 1
+	}
+}
+
+func (o *offsets) Add(blockNum uint64, hash common.Hash) {
+	o.Next = blockNum + 1
+	o.Recent = append(o.Recent, publishedBlock{Number: blockNum, Hash: hash})
+	if len(o.Recent) > recentBlocks {
+		o.Recent = o.Recent[len(o.Recent)-recentBlocks:]
+	}
+}
+
+func (o *offsets) Last() (publishedBlock, bool) {
+	if len(o.Recent) == 0 {
+		return publishedBlock{}, false
+	}
+	return o.Recent[len(o.Recent)-1], true
+}
+
+// Rewind - forgets the last published block: it will be published again
+func (o *offsets) Rewind() {
+	last, ok := o.Last()
+	if !ok {
+		return
+	}
+	o.Recent = o.Recent[:len(o.Recent)-1]
+	o.Next = last.Number
+}
+
+func (o *offsets) Save() error {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(o.file), 0755); err != nil {
+		return err
+	}
+	tmp := o.file + ".tmp"
+	if err := dir.WriteFileWithFsync(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, o.file)
+}
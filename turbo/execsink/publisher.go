@@ -0,0 +1,67 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package execsink
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// publishTimeout - max time to wait for the bus to accept one message
+const publishTimeout = 10 * time.Second
+
+// NewPublisher - by URL scheme:
+//   - nats://[user:password@|token@]host:4222/<subject> - NATS JetStream, a stream must be configured for the subject.
+//     tls://... - same over TLS (also used when the server requires it). nkey/JWT (.creds) auth is not supported.
+//   - kafka+http(s)://<rest-proxy>/topics/<topic> - Kafka via REST proxy (v2 API)
+func NewPublisher(rawURL string) (Publisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "nats", "tls":
+		subject := strings.TrimPrefix(u.Path, "/")
+		if subject == "" {
+			return nil, fmt.Errorf("sink: no subject in %s", rawURL)
+		}
+		host := u.Host
+		if u.Port() == "" {
+			host += ":4222"
+		}
+		p := newNatsPublisher(host, subject, publishTimeout)
+		p.tls = u.Scheme == "tls"
+		if u.User != nil {
+			if pass, ok := u.User.Password(); ok {
+				p.user, p.pass = u.User.Username(), pass
+			} else {
+				p.token = u.User.Username()
+			}
+		}
+		return p, nil
+	case "kafka+http", "kafka+https":
+		if !strings.HasPrefix(u.Path, "/topics/") {
+			return nil, fmt.Errorf("sink: expected /topics/<topic> path in %s", rawURL)
+		}
+		u.Scheme = strings.TrimPrefix(u.Scheme, "kafka+")
+		return newKafkaRestPublisher(u.String(), publishTimeout), nil
+	default:
+		return nil, fmt.Errorf("sink: unsupported URL scheme %q, expected nats, tls, kafka+http or kafka+https", u.Scheme)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package execsink publishes block-executed events to a message bus (NATS JetStream or Kafka),
+// so pipelines don't need to poll RPC.
+package execsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+const (
+	pollInterval = 5 * time.Second
+	retryBackoff = 3 * time.Second
+	// saveEvery - offsets are persisted every N published blocks and when caught up with the head.
+	// Crash between publish and save re-publishes blocks: delivery is at-least-once, consumers must be idempotent by block hash
+	saveEvery = 64
+)
+
+// Event - published for every executed canonical block, in block order. On reorg blocks of the new
+// branch are published again: consumers detect it by ParentHash.
+type Event struct {
+	BlockNumber  uint64         `json:"blockNumber"`
+	BlockHash    common.Hash    `json:"blockHash"`
+	ParentHash   common.Hash    `json:"parentHash"`
+	Header       *types.Header  `json:"header"`
+	Receipts     types.Receipts `json:"receipts"`
+	StateChanges StateChanges   `json:"stateChanges"`
+}
+
+// StateChanges - summary of state changed by the block
+type StateChanges struct {
+	Accounts        int              `json:"accounts"`
+	Storage         int              `json:"storage"`
+	Code            int              `json:"code"`
+	ChangedAccounts []common.Address `json:"changedAccounts"`
+}
+
+// Publisher - message bus client. Publish returns after the message is durably accepted by the bus,
+// `key` is the block hash: used for de-duplication (NATS) and partitioning (Kafka).
+type Publisher interface {
+	Publish(ctx context.Context, key string, data []byte) error
+	Close() error
+}
+
+// source - executed blocks, implemented by dbSource
+type source interface {
+	Head(ctx context.Context) (uint64, error)
+	CanonicalHash(ctx context.Context, blockNum uint64) (common.Hash, bool, error)
+	Event(ctx context.Context, blockNum uint64) (*Event, error)
+}
+
+type Config struct {
+	URL         string // nats://host:4222/<subject> or kafka+http(s)://<rest-proxy>/topics/<topic>
+	OffsetsFile string
+	FromBlock   uint64 // first block to publish if there are no saved offsets, 0 - the next executed block
+}
+
+type Sink struct {
+	cfg       Config
+	source    source
+	publisher Publisher
+	offsets   *offsets
+	newHeads  <-chan [][]byte
+	logger    log.Logger
+}
+
+func newSink(cfg Config, src source, publisher Publisher, newHeads <-chan [][]byte, logger log.Logger) (*Sink, error) {
+	o, err := loadOffsets(cfg.OffsetsFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Sink{cfg: cfg, source: src, publisher: publisher, offsets: o, newHeads: newHeads, logger: logger}, nil
+}
+
+// Run - publishes executed blocks after every new head (or poll interval) until ctx is done.
+// Publish errors are retried: the sink never skips a block.
+func (s *Sink) Run(ctx context.Context) error {
+	defer s.publisher.Close()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	s.logger.Info("[sink] started", "url", s.cfg.URL, "next", s.offsets.Next)
+	for {
+		wait := (<-chan time.Time)(ticker.C)
+		if err := s.publishNew(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			s.logger.Warn("[sink] publish failed, will retry", "next", s.offsets.Next, "err", err)
+			wait = time.After(retryBackoff)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.newHeads:
+		case <-wait:
+		}
+	}
+}
+
+func (s *Sink) publishNew(ctx context.Context) error {
+	head, err := s.source.Head(ctx)
+	if err != nil {
+		return err
+	}
+	if !s.offsets.Started {
+		s.offsets.Start(s.cfg.FromBlock, head)
+	}
+	if err := s.rewindReorged(ctx, head); err != nil {
+		return err
+	}
+
+	published := 0
+	defer func() {
+		if published > 0 {
+			if err := s.offsets.Save(); err != nil {
+				s.logger.Warn("[sink] can't save offsets", "err", err)
+			}
+		}
+	}()
+	for blockNum := s.offsets.Next; blockNum <= head; blockNum++ {
+		ev, err := s.source.Event(ctx, blockNum)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, ev.BlockHash.Hex(), data); err != nil {
+			return fmt.Errorf("block %d: %w", blockNum, err)
+		}
+		s.offsets.Add(ev.BlockNumber, ev.BlockHash)
+		if published++; published%saveEvery == 0 {
+			if err := s.offsets.Save(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rewindReorged - drops published blocks which are not canonical anymore, they will be re-published from the new branch
+func (s *Sink) rewindReorged(ctx context.Context, head uint64) error {
+	for {
+		last, ok := s.offsets.Last()
+		if !ok {
+			return nil
+		}
+		hash, ok, err := s.source.CanonicalHash(ctx, last.Number)
+		if err != nil {
+			return err
+		}
+		if ok && hash == last.Hash && last.Number <= head {
+			return nil
+		}
+		s.logger.Info("[sink] reorg: re-publishing", "block", last.Number, "hash", last.Hash)
+		s.offsets.Rewind()
+	}
+}
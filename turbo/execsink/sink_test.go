@@ -0,0 +1,155 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package execsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// fakeSource - canonical chain of `head` blocks, hashes depend on `branch` to simulate reorgs
+type fakeSource struct {
+	head      uint64
+	branch    map[uint64]byte
+	failEvent uint64
+}
+
+func (s *fakeSource) hash(blockNum uint64) common.Hash {
+	return common.Hash{byte(blockNum), s.branch[blockNum]}
+}
+
+func (s *fakeSource) Head(ctx context.Context) (uint64, error) { return s.head, nil }
+
+func (s *fakeSource) CanonicalHash(ctx context.Context, blockNum uint64) (common.Hash, bool, error) {
+	if blockNum > s.head {
+		return common.Hash{}, false, nil
+	}
+	return s.hash(blockNum), true, nil
+}
+
+func (s *fakeSource) Event(ctx context.Context, blockNum uint64) (*Event, error) {
+	if s.failEvent != 0 && blockNum == s.failEvent {
+		return nil, errors.New("fail")
+	}
+	return &Event{BlockNumber: blockNum, BlockHash: s.hash(blockNum), ParentHash: s.hash(blockNum - 1),
+		Header: &types.Header{Number: new(big.Int).SetUint64(blockNum), Difficulty: new(big.Int)}}, nil
+}
+
+type fakePublisher struct {
+	keys   []string
+	blocks []uint64
+	fail   bool
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, key string, data []byte) error {
+	if p.fail {
+		return errors.New("bus is down")
+	}
+	var ev Event
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return err
+	}
+	p.keys = append(p.keys, key)
+	p.blocks = append(p.blocks, ev.BlockNumber)
+	return nil
+}
+
+func (p *fakePublisher) Close() error { return nil }
+
+func TestSink_PublishNew(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "sink", "offsets.json")
+	src := &fakeSource{head: 10, branch: map[uint64]byte{}}
+	pub := &fakePublisher{}
+	s, err := newSink(Config{OffsetsFile: file, FromBlock: 8}, src, pub, nil, log.New())
+	require.NoError(t, err)
+
+	require.NoError(t, s.publishNew(ctx))
+	require.Equal(t, []uint64{8, 9, 10}, pub.blocks)
+	require.Equal(t, src.hash(10).Hex(), pub.keys[2])
+
+	// bus is down: nothing is skipped
+	src.head = 12
+	pub.fail = true
+	require.Error(t, s.publishNew(ctx))
+	pub.fail = false
+	require.NoError(t, s.publishNew(ctx))
+	require.Equal(t, []uint64{8, 9, 10, 11, 12}, pub.blocks)
+
+	// restart: continues from saved offsets, FromBlock is ignored
+	src.head = 13
+	pub2 := &fakePublisher{}
+	s2, err := newSink(Config{OffsetsFile: file, FromBlock: 1}, src, pub2, nil, log.New())
+	require.NoError(t, err)
+	require.NoError(t, s2.publishNew(ctx))
+	require.Equal(t, []uint64{13}, pub2.blocks)
+
+	// reorg of 2 blocks: new branch is re-published
+	src.branch[12], src.branch[13] = 1, 1
+	src.head = 14
+	require.NoError(t, s2.publishNew(ctx))
+	require.Equal(t, []uint64{13, 12, 13, 14}, pub2.blocks)
+	require.Equal(t, src.hash(12).Hex(), pub2.keys[1])
+
+	// failed read of a block: retried from it
+	src.head, src.failEvent = 16, 16
+	require.Error(t, s2.publishNew(ctx))
+	require.Equal(t, uint64(16), s2.offsets.Next)
+}
+
+func TestSink_StartsAtHead(t *testing.T) {
+	t.Parallel()
+	src := &fakeSource{head: 10, branch: map[uint64]byte{}}
+	pub := &fakePublisher{}
+	s, err := newSink(Config{OffsetsFile: filepath.Join(t.TempDir(), "offsets.json")}, src, pub, nil, log.New())
+	require.NoError(t, err)
+	require.NoError(t, s.publishNew(context.Background()))
+	require.Empty(t, pub.blocks)
+	src.head = 11
+	require.NoError(t, s.publishNew(context.Background()))
+	require.Equal(t, []uint64{11}, pub.blocks)
+}
+
+func TestOffsets_RecentLimit(t *testing.T) {
+	t.Parallel()
+	o, err := loadOffsets(filepath.Join(t.TempDir(), "offsets.json"))
+	require.NoError(t, err)
+	o.Start(1, 0)
+	for i := uint64(1); i <= recentBlocks+10; i++ {
+		o.Add(i, common.Hash{byte(i)})
+	}
+	require.Len(t, o.Recent, recentBlocks)
+	require.NoError(t, o.Save())
+
+	loaded, err := loadOffsets(o.file)
+	require.NoError(t, err)
+	require.True(t, loaded.Started)
+	require.Equal(t, uint64(recentBlocks+11), loaded.Next)
+	loaded.Rewind()
+	require.Equal(t, uint64(recentBlocks+10), loaded.Next)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package execsink
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+// ReceiptsGetter - implemented by receipts.Generator
+type ReceiptsGetter interface {
+	GetReceipts(ctx context.Context, cfg *chain.Config, tx kv.TemporalTx, block *types.Block) (types.Receipts, error)
+}
+
+// dbSource - reads executed blocks, their receipts and state changes from the node's database
+type dbSource struct {
+	db          kv.TemporalRoDB
+	br          services.FullBlockReader
+	chainConfig *chain.Config
+	receipts    ReceiptsGetter
+}
+
+func New(cfg Config, db kv.TemporalRoDB, br services.FullBlockReader, chainConfig *chain.Config, receipts ReceiptsGetter, newHeads <-chan [][]byte, logger log.Logger) (*Sink, error) {
+	publisher, err := NewPublisher(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	return newSink(cfg, &dbSource{db: db, br: br, chainConfig: chainConfig, receipts: receipts}, publisher, newHeads, logger)
+}
+
+func (s *dbSource) Head(ctx context.Context) (head uint64, err error) {
+	err = s.db.View(ctx, func(tx kv.Tx) error {
+		head, err = stages.GetStageProgress(tx, stages.Execution)
+		return err
+	})
+	return head, err
+}
+
+func (s *dbSource) CanonicalHash(ctx context.Context, blockNum uint64) (hash common.Hash, ok bool, err error) {
+	err = s.db.View(ctx, func(tx kv.Tx) error {
+		hash, ok, err = s.br.CanonicalHash(ctx, tx, blockNum)
+		return err
+	})
+	return hash, ok, err
+}
+
+func (s *dbSource) Event(ctx context.Context, blockNum uint64) (*Event, error) {
+	tx, err := s.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	block, err := s.br.BlockByNumber(ctx, tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block not found: %d", blockNum)
+	}
+	receipts, err := s.receipts.GetReceipts(ctx, s.chainConfig, tx, block)
+	if err != nil {
+		return nil, err
+	}
+	ev := &Event{
+		BlockNumber: blockNum,
+		BlockHash:   block.Hash(),
+		ParentHash:  block.ParentHash(),
+		Header:      block.Header(),
+		Receipts:    receipts,
+	}
+	if ev.StateChanges, err = s.stateChanges(ctx, tx, blockNum); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// stateChanges - keys changed by txNums of the block, from state history
+func (s *dbSource) stateChanges(ctx context.Context, tx kv.TemporalTx, blockNum uint64) (res StateChanges, err error) {
+	txNumsReader := s.br.TxnumReader(ctx)
+	fromTxNum, err := txNumsReader.Min(tx, blockNum)
+	if err != nil {
+		return res, err
+	}
+	toTxNum, err := txNumsReader.Max(tx, blockNum)
+	if err != nil {
+		return res, err
+	}
+	res.ChangedAccounts = []common.Address{}
+	for _, domain := range []kv.Domain{kv.AccountsDomain, kv.StorageDomain, kv.CodeDomain} {
+		it, err := tx.HistoryRange(domain, int(fromTxNum), int(toTxNum+1), order.Asc, -1)
+		if err != nil {
+			return res, err
+		}
+		var cnt int
+		for it.HasNext() {
+			k, _, err := it.Next()
+			if err != nil {
+				it.Close()
+				return res, err
+			}
+			cnt++
+			if domain == kv.AccountsDomain {
+				res.ChangedAccounts = append(res.ChangedAccounts, common.BytesToAddress(k))
+			}
+		}
+		it.Close()
+		switch domain {
+		case kv.AccountsDomain:
+			res.Accounts = cnt
+		case kv.StorageDomain:
+			res.Storage = cnt
+		case kv.CodeDomain:
+			res.Code = cnt
+		}
+	}
+	return res, nil
+}
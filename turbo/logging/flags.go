@@ -69,6 +69,11 @@ var (
 		Value: log.LvlInfo.String(),
 	}
 
+	LogSubsystemsFlag = cli.StringFlag{
+		Name:  "log.subsystems",
+		Usage: "Per-subsystem log levels overriding console and file verbosity, e.g. txpool=debug,p2p=warn. Subsystem is the [name] prefix of log messages. Changeable at runtime with admin_setLogLevels",
+	}
+
 	LogBlockDelayFlag = cli.BoolFlag{
 		Name:  "log.delays",
 		Usage: "Enable block delay logging",
@@ -85,5 +90,6 @@ var Flags = []cli.Flag{
 	&LogDirPathFlag,
 	&LogDirPrefixFlag,
 	&LogDirVerbosityFlag,
+	&LogSubsystemsFlag,
 	&LogBlockDelayFlag,
 }
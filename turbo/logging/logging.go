@@ -106,6 +106,7 @@ func SetupLoggerCtx(
 	}
 
 	initSeparatedLogging(logger, filePrefix, dirPath, consoleLevel, dirLevel, consoleJson, dirJson)
+	setSubsystemLevels(logger, ctx.String(LogSubsystemsFlag.Name))
 	return logger
 }
 
@@ -168,6 +169,9 @@ func SetupLoggerCmd(filePrefix string, cmd *cobra.Command) log.Logger {
 	}
 
 	initSeparatedLogging(log.Root(), filePrefix, dirPath, consoleLevel, dirLevel, consoleJson, dirJson)
+	if f := cmd.Flags().Lookup(LogSubsystemsFlag.Name); f != nil {
+		setSubsystemLevels(log.Root(), f.Value.String())
+	}
 	return log.Root()
 }
 
@@ -224,9 +228,9 @@ func initSeparatedLogging(
 	var consoleHandler log.Handler
 
 	if consoleJson {
-		consoleHandler = log.LvlFilterHandler(consoleLevel, log.StreamHandler(os.Stderr, log.JsonFormat()))
+		consoleHandler = SubsystemHandler(consoleLevel, true, log.StreamHandler(os.Stderr, log.JsonFormat()))
 	} else {
-		consoleHandler = log.LvlFilterHandler(consoleLevel, log.StderrHandler)
+		consoleHandler = SubsystemHandler(consoleLevel, false, log.StderrHandler)
 	}
 	logger.SetHandler(consoleHandler)
 
@@ -254,11 +258,25 @@ func initSeparatedLogging(
 	}
 	userLog := log.StreamHandler(lumberjack, dirFormat)
 
-	mux := log.MultiHandler(consoleHandler, log.LvlFilterHandler(dirLevel, userLog))
+	mux := log.MultiHandler(consoleHandler, SubsystemHandler(dirLevel, dirJson, userLog))
 	logger.SetHandler(mux)
 	logger.Info("logging to file system", "log dir", dirPath, "file prefix", filePrefix, "log level", dirLevel, "json", dirJson)
 }
 
+func setSubsystemLevels(logger log.Logger, flagValue string) {
+	levels, err := ParseSubsystemLevels(flagValue)
+	if err == nil {
+		err = Subsystems.Set(levels)
+	}
+	if err != nil {
+		logger.Warn("invalid --"+LogSubsystemsFlag.Name+", ignored", "err", err)
+		return
+	}
+	if len(levels) > 0 {
+		logger.Info("per-subsystem log levels", "levels", sortedSubsystems(levels))
+	}
+}
+
 func tryGetLogLevel(s string) (log.Lvl, error) {
 	lvl, err := log.LvlFromString(s)
 	if err != nil {
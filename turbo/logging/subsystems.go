@@ -0,0 +1,203 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// SubsystemKey - field with record's subsystem in JSON logs
+const SubsystemKey = "subsystem"
+
+// Subsystems - per-subsystem level overrides shared by console and file handlers. Can be changed at runtime (admin_setLogLevels).
+var Subsystems = &SubsystemLevels{levels: map[string]log.Lvl{}}
+
+type SubsystemLevels struct {
+	mu     sync.RWMutex
+	levels map[string]log.Lvl
+	maxLvl log.Lvl
+}
+
+func (s *SubsystemLevels) Get() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := make(map[string]string, len(s.levels))
+	for name, lvl := range s.levels {
+		res[name] = lvl.String()
+	}
+	return res
+}
+
+// Set - replaces overrides of given subsystems, empty level removes the override
+func (s *SubsystemLevels) Set(levels map[string]string) error {
+	parsed := make(map[string]log.Lvl, len(levels))
+	for name, lvl := range levels {
+		if lvl == "" {
+			continue
+		}
+		l, err := tryGetLogLevel(lvl)
+		if err != nil {
+			return fmt.Errorf("subsystem %s: %w", name, err)
+		}
+		parsed[strings.ToLower(name)] = l
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, lvl := range levels {
+		if lvl == "" {
+			delete(s.levels, strings.ToLower(name))
+		}
+	}
+	maps.Copy(s.levels, parsed)
+	s.maxLvl = log.LvlCrit
+	for _, lvl := range s.levels {
+		s.maxLvl = max(s.maxLvl, lvl)
+	}
+	return nil
+}
+
+// ParseSubsystemLevels - parses `txpool=debug,p2p=warn`
+func ParseSubsystemLevels(s string) (map[string]string, error) {
+	res := map[string]string{}
+	for _, kv := range strings.Split(s, ",") {
+		if kv = strings.TrimSpace(kv); kv == "" {
+			continue
+		}
+		name, lvl, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("expected <subsystem>=<level>, got %q", kv)
+		}
+		if _, err := tryGetLogLevel(lvl); err != nil {
+			return nil, fmt.Errorf("subsystem %s: %w", name, err)
+		}
+		res[strings.TrimSpace(name)] = strings.TrimSpace(lvl)
+	}
+	return res, nil
+}
+
+// level - override of the subsystem or of its closest parent (`txpool` applies to `txpool.fetch`)
+func (s *SubsystemLevels) level(subsystem string) (log.Lvl, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.levels) == 0 {
+		return 0, false
+	}
+	for name := subsystem; name != ""; {
+		if lvl, ok := s.levels[name]; ok {
+			return lvl, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return 0, false
+}
+
+func (s *SubsystemLevels) max() log.Lvl {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.maxLvl
+}
+
+// Subsystem - explicit `subsystem` field of the record, or the `[name]` prefix of the message which is the
+// convention of this codebase: "[txpool] ...", "[p2p] ...". Stage progress is dropped: "[2/6 Headers]" is `headers`.
+func Subsystem(r *log.Record) string {
+	for i := 0; i+1 < len(r.Ctx); i += 2 {
+		if k, ok := r.Ctx[i].(string); ok && k == SubsystemKey {
+			if v, ok := r.Ctx[i+1].(string); ok {
+				return strings.ToLower(v)
+			}
+		}
+	}
+	if !strings.HasPrefix(r.Msg, "[") {
+		return ""
+	}
+	end := strings.IndexByte(r.Msg, ']')
+	if end < 0 {
+		return ""
+	}
+	name := r.Msg[1:end]
+	if i := strings.LastIndexByte(name, ' '); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.ToLower(name)
+}
+
+// SubsystemHandler - level filter honoring per-subsystem overrides. With `withField` records get the `subsystem`
+// field, for JSON output: log pipelines can route and filter by it.
+func SubsystemHandler(defaultLvl log.Lvl, withField bool, h log.Handler) log.Handler {
+	return subsystemHandler{defaultLvl: defaultLvl, withField: withField, levels: Subsystems, h: h}
+}
+
+type subsystemHandler struct {
+	defaultLvl log.Lvl
+	withField  bool
+	levels     *SubsystemLevels
+	h          log.Handler
+}
+
+func (h subsystemHandler) Log(r *log.Record) error {
+	if r.Lvl > max(h.defaultLvl, h.levels.max()) { // fast path: dropped by any level
+		return nil
+	}
+	subsystem := Subsystem(r)
+	lvl, ok := h.levels.level(subsystem)
+	if !ok {
+		lvl = h.defaultLvl
+	}
+	if r.Lvl > lvl {
+		return nil
+	}
+	if h.withField && subsystem != "" && !hasKey(r.Ctx, SubsystemKey) {
+		// record is shared by handlers of MultiHandler: don't modify it
+		rec := *r
+		rec.Ctx = append([]interface{}{SubsystemKey, subsystem}, r.Ctx...)
+		r = &rec
+	}
+	return h.h.Log(r)
+}
+
+func (h subsystemHandler) Enabled(ctx context.Context, lvl log.Lvl) bool {
+	return lvl <= max(h.defaultLvl, h.levels.max())
+}
+
+func hasKey(logCtx []interface{}, key string) bool {
+	for i := 0; i < len(logCtx); i += 2 {
+		if k, ok := logCtx[i].(string); ok && k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedSubsystems(levels map[string]string) []string {
+	names := make([]string, 0, len(levels))
+	for name, lvl := range levels {
+		names = append(names, name+"="+lvl)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestSubsystem(t *testing.T) {
+	require.Equal(t, "txpool", Subsystem(&log.Record{Msg: "[txpool] stat"}))
+	require.Equal(t, "headers", Subsystem(&log.Record{Msg: "[2/6 Headers] Waiting"}))
+	require.Equal(t, "", Subsystem(&log.Record{Msg: "no prefix"}))
+	require.Equal(t, "p2p", Subsystem(&log.Record{Msg: "[txpool] stat", Ctx: []interface{}{"subsystem", "P2P"}}))
+}
+
+func TestSubsystemHandler(t *testing.T) {
+	levels := &SubsystemLevels{levels: map[string]log.Lvl{}}
+	got := make(chan *log.Record, 10)
+	h := subsystemHandler{defaultLvl: log.LvlInfo, withField: true, levels: levels, h: log.ChannelHandler(got)}
+	write := func(lvl log.Lvl, msg string) {
+		require.NoError(t, h.Log(&log.Record{Lvl: lvl, Msg: msg}))
+	}
+
+	write(log.LvlDebug, "[txpool] dropped")
+	write(log.LvlInfo, "[txpool] kept")
+	require.Len(t, got, 1)
+	require.Equal(t, []interface{}{SubsystemKey, "txpool"}, (<-got).Ctx)
+	require.False(t, h.Enabled(context.Background(), log.LvlDebug))
+
+	levels0, err := ParseSubsystemLevels("txpool=debug, p2p=warn")
+	require.NoError(t, err)
+	require.NoError(t, levels.Set(levels0))
+	require.True(t, h.Enabled(context.Background(), log.LvlDebug))
+	write(log.LvlDebug, "[txpool] kept")
+	write(log.LvlDebug, "[txpool.fetch] kept by parent")
+	write(log.LvlInfo, "[p2p] dropped")
+	write(log.LvlDebug, "[exec] dropped")
+	require.Len(t, got, 2)
+
+	require.NoError(t, levels.Set(map[string]string{"txpool": ""}))
+	require.Equal(t, map[string]string{"p2p": "warn"}, levels.Get())
+	require.False(t, h.Enabled(context.Background(), log.LvlDebug))
+
+	_, err = ParseSubsystemLevels("txpool")
+	require.Error(t, err)
+	require.Error(t, levels.Set(map[string]string{"txpool": "loud"}))
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	proto_downloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/backup"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/eth/integrity"
+	"github.com/erigontech/erigon/execution/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/snapshotsync"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// Node provides the jobs of a running node:
+//   - retire: moves executed blocks and state history from the database to snapshot files
+//   - prune: catches up with pruning of blocks and state history, in write transactions of
+//     at most `batch` (default 1s) to not stall the sync
+//   - compact: merges small snapshot files of the state and removes the merged ones
+//   - integrity: checks of snapshot files, `checks` - comma separated list of
+//     Blocks, BlocksTxnID, HeaderNoGaps (default all of them), `failFast` - stop at the first error
+//   - backup: copies the chaindata database into an empty directory `dir`. The copy is compacted:
+//     free pages are not copied
+//   - snapshots-update: downloads the block snapshot files published in the manifest since the start
+//     of the node and opens them, no restart needed
+type Node struct {
+	DB          kv.TemporalRwDB
+	Agg         *state.Aggregator
+	BlockReader services.FullBlockReader
+	BlockRetire services.BlockRetire
+	Logger      log.Logger
+
+	Dirs        datadir.Dirs
+	ChainConfig *chain.Config
+	Downloader  proto_downloader.DownloaderClient // nil - snapshots-update does nothing
+	// OnNewSnapshots is called after snapshots-update opened new files
+	OnNewSnapshots func()
+}
+
+// Jobs returns the jobs by name
+func (n *Node) Jobs() map[string]Job {
+	return map[string]Job{
+		"retire":    n.retire,
+		"prune":     n.prune,
+		"compact":   n.compact,
+		"integrity": n.integrity,
+		"backup":    n.backup,
+
+		"snapshots-update": n.snapshotsUpdate,
+	}
+}
+
+func (n *Node) retire(ctx context.Context, _ map[string]string) error {
+	var executed, lastTxNum uint64
+	if err := n.DB.View(ctx, func(tx kv.Tx) (err error) {
+		if executed, err = stages.GetStageProgress(tx, stages.Execution); err != nil {
+			return err
+		}
+		lastTxNum, err = n.BlockReader.TxnumReader(ctx).Max(tx, executed)
+		return err
+	}); err != nil {
+		return err
+	}
+	// does nothing if the snapshots stage is retiring blocks already
+	n.BlockRetire.RetireBlocksInBackground(ctx, 0, executed, log.LvlInfo, nil, nil, nil)
+	select {
+	case <-n.Agg.BuildFilesInBackground(lastTxNum):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *Node) prune(ctx context.Context, args map[string]string) error {
+	batch := time.Second
+	if s, ok := args["batch"]; ok {
+		var err error
+		if batch, err = time.ParseDuration(s); err != nil {
+			return fmt.Errorf("batch: %w", err)
+		}
+	}
+	for deleted, total := 1, 0; deleted > 0; total += deleted {
+		if err := n.DB.Update(ctx, func(tx kv.RwTx) (err error) {
+			deleted, err = n.BlockRetire.PruneAncientBlocks(tx, 1_000, batch)
+			return err
+		}); err != nil {
+			return err
+		}
+		if deleted == 0 && total > 0 {
+			n.Logger.Info("[maintenance] pruned blocks", "blocks", total)
+		}
+	}
+	for haveMore := true; haveMore; {
+		if err := n.DB.Update(ctx, func(tx kv.RwTx) (err error) {
+			haveMore, err = tx.(kv.TemporalRwTx).PruneSmallBatches(ctx, batch)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Node) compact(ctx context.Context, _ map[string]string) error {
+	if err := n.Agg.MergeLoop(ctx); err != nil {
+		return err
+	}
+	return n.Agg.RemoveOverlapsAfterMerge(ctx)
+}
+
+func (n *Node) integrity(ctx context.Context, args map[string]string) error {
+	checks := []integrity.Check{integrity.Blocks, integrity.BlocksTxnID, integrity.HeaderNoGaps}
+	if s := args["checks"]; s != "" {
+		checks = checks[:0]
+		for _, check := range strings.Split(s, ",") {
+			checks = append(checks, integrity.Check(strings.TrimSpace(check)))
+		}
+	}
+	failFast := args["failFast"] == "true"
+	var errs []error
+	for _, check := range checks {
+		var err error
+		switch check {
+		case integrity.Blocks:
+			err = integrity.SnapBlocksRead(ctx, n.DB, n.BlockReader, 0, 0, failFast)
+		case integrity.BlocksTxnID:
+			if reader, ok := n.BlockReader.(*freezeblocks.BlockReader); ok {
+				err = reader.IntegrityTxnID(failFast)
+			}
+		case integrity.HeaderNoGaps:
+			err = integrity.NoGapsInCanonicalHeaders(ctx, n.DB, n.BlockReader, failFast)
+		default:
+			err = errors.New("unsupported check")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", check, err))
+			if failFast || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Node) backup(ctx context.Context, args map[string]string) error {
+	dir := args["dir"]
+	if dir == "" {
+		return errors.New("`dir` is required")
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s is not empty", dir)
+	}
+	dst, err := mdbx.New(kv.ChainDB, n.Logger).Path(dir).
+		WriteMap(true).
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.ChaindataTablesCfg }).
+		Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	return backup.Kv2kv(ctx, n.DB, dst, nil, 0, n.Logger)
+}
+
+func (n *Node) snapshotsUpdate(ctx context.Context, _ map[string]string) error {
+	files, err := snapshotsync.AutoUpdate(ctx, "maintenance", n.Dirs, n.ChainConfig, n.BlockReader, n.Downloader)
+	if err != nil {
+		return err
+	}
+	if len(files) > 0 && n.OnNewSnapshots != nil {
+		n.OnNewSnapshots()
+	}
+	return nil
+}
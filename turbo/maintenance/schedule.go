@@ -0,0 +1,211 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a cron expression of 5 fields: minute, hour, day of month, month, day of week (0 - Sunday).
+// Fields are `*`, numbers, ranges `a-b` and steps `*/n`, `a-b/n`, separated by commas.
+// Shortcuts @hourly, @daily, @weekly and @monthly are supported too.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64 // bit i is set if value i matches
+	domStar, dowStar              bool
+}
+
+var scheduleShortcuts = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// ParseSchedule parses a cron expression
+func ParseSchedule(spec string) (*Schedule, error) {
+	if s, ok := scheduleShortcuts[spec]; ok {
+		spec = s
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var bits [5]uint64
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseField(field, bounds[i][0], bounds[i][1]); err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", spec, err)
+		}
+	}
+	if bits[4]&(1<<7) != 0 { // 7 is Sunday too
+		bits[4] |= 1
+	}
+	return &Schedule{
+		minute: bits[0], hour: bits[1], dom: bits[2], month: bits[3], dow: bits[4],
+		domStar: fields[2] == "*", dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseField(field string, lo, hi int) (bits uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+		from, to := lo, hi
+		if rng != "*" {
+			fromStr, toStr, isRange := strings.Cut(rng, "-")
+			if from, err = strconv.Atoi(fromStr); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(toStr); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			} else if hasStep {
+				to = hi
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, lo, hi)
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the first time matching the schedule strictly after t, with minute precision
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// a matching day exists within 4 years (e.g. Feb 29), otherwise the expression never matches
+	for limit := t.AddDate(5, 0, 0); t.Before(limit); {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches follows cron: if both day of month and day of week are restricted, either of them matches
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom, dow := s.dom&(1<<uint(t.Day())) != 0, s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Window is a daily time window `HH:MM-HH:MM` in local time. The end may be before the start: `22:00-04:00`.
+// The zero Window is always open.
+type Window struct {
+	start, end time.Duration // since midnight
+	set        bool
+}
+
+// ParseWindow parses a window, empty string is the always open window
+func ParseWindow(spec string) (Window, error) {
+	if spec == "" {
+		return Window{}, nil
+	}
+	startStr, endStr, ok := strings.Cut(spec, "-")
+	if !ok {
+		return Window{}, fmt.Errorf("window %q: expected HH:MM-HH:MM", spec)
+	}
+	start, err := parseClock(startStr)
+	if err != nil {
+		return Window{}, fmt.Errorf("window %q: %w", spec, err)
+	}
+	end, err := parseClock(endStr)
+	if err != nil {
+		return Window{}, fmt.Errorf("window %q: %w", spec, err)
+	}
+	if start == end {
+		return Window{}, fmt.Errorf("window %q: empty", spec)
+	}
+	return Window{start: start, end: end, set: true}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (w Window) String() string {
+	if !w.set {
+		return "always"
+	}
+	clock := func(d time.Duration) string { return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60) }
+	return clock(w.start) + "-" + clock(w.end)
+}
+
+// Open returns whether the window is open at t and, if so, when it closes (zero time for the always open window)
+func (w Window) Open(t time.Time) (open bool, closes time.Time) {
+	if !w.set {
+		return true, time.Time{}
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	// the window which opened yesterday may still be open
+	for _, day := range []time.Time{midnight.AddDate(0, 0, -1), midnight} {
+		start, end := day.Add(w.start), day.Add(w.end)
+		if w.end < w.start {
+			end = day.AddDate(0, 0, 1).Add(w.end)
+		}
+		if !t.Before(start) && t.Before(end) {
+			return true, end
+		}
+	}
+	return false, time.Time{}
+}
+
+// NextOpen returns t if the window is open at t, otherwise the next time it opens
+func (w Window) NextOpen(t time.Time) time.Time {
+	if open, _ := w.Open(t); open {
+		return t
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if start := midnight.Add(w.start); start.After(t) {
+		return start
+	}
+	return midnight.AddDate(0, 0, 1).Add(w.start)
+}
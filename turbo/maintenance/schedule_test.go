@@ -0,0 +1,103 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, err := time.ParseInLocation(time.DateTime, s, time.UTC)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestScheduleNext(t *testing.T) {
+	// 2025-01-01 is Wednesday
+	now := date("2025-01-01 10:30:15")
+	cases := []struct {
+		spec, next string
+	}{
+		{"* * * * *", "2025-01-01 10:31:00"},
+		{"30 10 * * *", "2025-01-02 10:30:00"},
+		{"*/15 * * * *", "2025-01-01 10:45:00"},
+		{"0 2-4 * * *", "2025-01-02 02:00:00"},
+		{"0,45 11 * * *", "2025-01-01 11:00:00"},
+		{"@daily", "2025-01-02 00:00:00"},
+		{"@weekly", "2025-01-05 00:00:00"},
+		{"@monthly", "2025-02-01 00:00:00"},
+		{"0 3 * * 7", "2025-01-05 03:00:00"}, // 7 is Sunday
+		{"0 3 * * 1-5", "2025-01-02 03:00:00"},
+		{"0 0 29 2 *", "2028-02-29 00:00:00"},
+		{"0 0 13 * 5", "2025-01-03 00:00:00"}, // day of month or day of week
+		{"0 0 31 * *", "2025-01-31 00:00:00"},
+	}
+	for _, c := range cases {
+		s, err := ParseSchedule(c.spec)
+		require.NoError(t, err, c.spec)
+		require.Equal(t, date(c.next), s.Next(now), c.spec)
+	}
+
+	s, err := ParseSchedule("0 0 30 2 *") // never
+	require.NoError(t, err)
+	require.True(t, s.Next(now).IsZero())
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := ParseSchedule(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	w, err := ParseWindow("02:00-05:30")
+	require.NoError(t, err)
+	require.Equal(t, "02:00-05:30", w.String())
+	open, closes := w.Open(date("2025-01-01 03:00:00"))
+	require.True(t, open)
+	require.Equal(t, date("2025-01-01 05:30:00"), closes)
+	open, _ = w.Open(date("2025-01-01 05:30:00"))
+	require.False(t, open)
+	require.Equal(t, date("2025-01-02 02:00:00"), w.NextOpen(date("2025-01-01 06:00:00")))
+	require.Equal(t, date("2025-01-01 02:00:00"), w.NextOpen(date("2025-01-01 01:00:00")))
+
+	// over midnight
+	w, err = ParseWindow("22:00-04:00")
+	require.NoError(t, err)
+	open, closes = w.Open(date("2025-01-01 23:00:00"))
+	require.True(t, open)
+	require.Equal(t, date("2025-01-02 04:00:00"), closes)
+	open, closes = w.Open(date("2025-01-02 01:00:00"))
+	require.True(t, open)
+	require.Equal(t, date("2025-01-02 04:00:00"), closes)
+	require.Equal(t, date("2025-01-02 22:00:00"), w.NextOpen(date("2025-01-02 12:00:00")))
+
+	w, err = ParseWindow("")
+	require.NoError(t, err)
+	open, closes = w.Open(date("2025-01-01 12:00:00"))
+	require.True(t, open)
+	require.True(t, closes.IsZero())
+
+	for _, spec := range []string{"02:00", "02:00-02:00", "25:00-03:00"} {
+		_, err := ParseWindow(spec)
+		require.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package maintenance runs node maintenance jobs (snapshot retire, integrity checks, backup, ...)
+// on cron-like schedules within daily time windows.
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// Job is a maintenance job. It must return soon after ctx is cancelled: at the end of the task's window,
+// its timeout or the node shutdown.
+type Job func(ctx context.Context, args map[string]string) error
+
+// TaskConfig is a scheduled run of a job, e.g. in TOML:
+//
+//	[[task]]
+//	name = "nightly-backup"
+//	job = "backup"
+//	schedule = "30 2 * * *"
+//	window = "02:00-05:00"
+//	timeout = "2h"
+//	args = { dir = "/mnt/backup/chaindata" }
+type TaskConfig struct {
+	Name     string            `toml:"name"`
+	Job      string            `toml:"job"`
+	Schedule string            `toml:"schedule"` // cron expression, see Schedule
+	Window   string            `toml:"window"`   // runs due outside of the window are postponed to its opening, see Window
+	Timeout  string            `toml:"timeout"`  // Go duration, empty - until the window closes
+	Args     map[string]string `toml:"args"`     // job specific
+}
+
+// Config is the TOML file of the scheduler
+type Config struct {
+	Tasks []TaskConfig `toml:"task"`
+}
+
+// LoadConfig reads the TOML file of the scheduler
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err := toml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("maintenance config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// TaskStatus is the state of a task for logs and APIs
+type TaskStatus struct {
+	Name     string
+	Job      string
+	Window   string
+	Next     time.Time
+	LastRun  time.Time
+	Duration time.Duration
+	Err      string
+	Runs     int
+}
+
+type task struct {
+	TaskConfig
+	schedule *Schedule
+	window   Window
+	timeout  time.Duration
+	job      Job
+	next     time.Time // next run, postponed to the window opening
+
+	status TaskStatus // protected by Scheduler.mu
+}
+
+// Scheduler runs tasks one at a time: maintenance jobs compete for disk and the database with the node
+// and with each other. A task which is due while another one runs starts after it, if its window is still open.
+type Scheduler struct {
+	tasks  []*task
+	logger log.Logger
+	now    func() time.Time
+
+	mu sync.Mutex
+}
+
+// New validates the config against the available jobs
+func New(cfg *Config, jobs map[string]Job, logger log.Logger) (*Scheduler, error) {
+	s := &Scheduler{logger: logger, now: time.Now}
+	names := map[string]bool{}
+	for i, tc := range cfg.Tasks {
+		if tc.Name == "" {
+			tc.Name = fmt.Sprintf("%s-%d", tc.Job, i)
+		}
+		if names[tc.Name] {
+			return nil, fmt.Errorf("maintenance task %q: duplicate name", tc.Name)
+		}
+		names[tc.Name] = true
+		t := &task{TaskConfig: tc, job: jobs[tc.Job]}
+		if t.job == nil {
+			return nil, fmt.Errorf("maintenance task %q: unknown job %q, available: %v", tc.Name, tc.Job, jobNames(jobs))
+		}
+		var err error
+		if t.schedule, err = ParseSchedule(tc.Schedule); err != nil {
+			return nil, fmt.Errorf("maintenance task %q: %w", tc.Name, err)
+		}
+		if t.window, err = ParseWindow(tc.Window); err != nil {
+			return nil, fmt.Errorf("maintenance task %q: %w", tc.Name, err)
+		}
+		if tc.Timeout != "" {
+			if t.timeout, err = time.ParseDuration(tc.Timeout); err != nil {
+				return nil, fmt.Errorf("maintenance task %q: timeout: %w", tc.Name, err)
+			}
+		}
+		t.status = TaskStatus{Name: tc.Name, Job: tc.Job, Window: t.window.String()}
+		s.tasks = append(s.tasks, t)
+	}
+	return s, nil
+}
+
+func jobNames(jobs map[string]Job) []string {
+	names := make([]string, 0, len(jobs))
+	for name := range jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Status returns the state of all tasks
+func (s *Scheduler) Status() []TaskStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]TaskStatus, len(s.tasks))
+	for i, t := range s.tasks {
+		res[i] = t.status
+	}
+	return res
+}
+
+// schedule sets the next run of the task after t
+func (s *Scheduler) schedule(t *task, after time.Time) {
+	next := t.schedule.Next(after)
+	if !next.IsZero() {
+		next = t.window.NextOpen(next)
+	}
+	t.next = next
+	s.mu.Lock()
+	t.status.Next = next
+	s.mu.Unlock()
+}
+
+// due returns the task to run next, nil if no task will ever run
+func (s *Scheduler) due() *task {
+	var res *task
+	for _, t := range s.tasks {
+		if !t.next.IsZero() && (res == nil || t.next.Before(res.next)) {
+			res = t
+		}
+	}
+	return res
+}
+
+// Run runs the tasks until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) error {
+	if len(s.tasks) == 0 {
+		return nil
+	}
+	now := s.now()
+	for _, t := range s.tasks {
+		s.schedule(t, now)
+		s.logger.Info("[maintenance] scheduled", "task", t.Name, "job", t.Job, "next", t.next.Format(time.DateTime), "window", t.window)
+	}
+	for {
+		t := s.due()
+		if t == nil {
+			return nil
+		}
+		if wait := t.next.Sub(s.now()); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		s.run(ctx, t)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.schedule(t, s.now())
+	}
+}
+
+// run runs the task if its window is still open
+func (s *Scheduler) run(ctx context.Context, t *task) {
+	start := s.now()
+	open, closes := t.window.Open(start)
+	if !open { // an earlier task took the window
+		s.logger.Warn("[maintenance] window closed, skipping", "task", t.Name, "window", t.window)
+		return
+	}
+	if t.timeout > 0 && (closes.IsZero() || start.Add(t.timeout).Before(closes)) {
+		closes = start.Add(t.timeout)
+	}
+	runCtx, cancel := ctx, context.CancelFunc(func() {})
+	if !closes.IsZero() {
+		runCtx, cancel = context.WithDeadline(ctx, closes)
+	}
+	defer cancel()
+
+	s.logger.Info("[maintenance] starting", "task", t.Name, "job", t.Job)
+	err := t.job(runCtx, t.Args)
+	took := time.Since(start)
+	metrics.GetOrCreateCounter(fmt.Sprintf(`maintenance_runs{job=%q}`, t.Job)).Inc()
+	switch {
+	case err == nil:
+		s.logger.Info("[maintenance] done", "task", t.Name, "took", took)
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+		s.logger.Warn("[maintenance] interrupted at the end of the window", "task", t.Name, "took", took)
+	default:
+		metrics.GetOrCreateCounter(fmt.Sprintf(`maintenance_failures{job=%q}`, t.Job)).Inc()
+		s.logger.Warn("[maintenance] failed", "task", t.Name, "took", took, "err", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.status.LastRun, t.status.Duration, t.status.Runs = start, took, t.status.Runs+1
+	t.status.Err = ""
+	if err != nil {
+		t.status.Err = err.Error()
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const testConfig = `
+[[task]]
+name = "often"
+job = "count"
+schedule = "* * * * *"
+
+[[task]]
+job = "fail"
+schedule = "0 3 * * *"
+window = "03:00-04:00"
+timeout = "10m"
+args = { reason = "broken" }
+
+[[task]]
+name = "closed"
+job = "count"
+schedule = "0 12 * * *"
+window = "01:00-02:00"
+`
+
+func TestScheduler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "maintenance.toml")
+	require.NoError(t, os.WriteFile(path, []byte(testConfig), 0644))
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Tasks, 3)
+	require.Equal(t, map[string]string{"reason": "broken"}, cfg.Tasks[1].Args)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	counted, deadlines := 0, map[string]time.Time{}
+	jobs := map[string]Job{
+		"count": func(ctx context.Context, args map[string]string) error {
+			if counted++; counted == 2 {
+				cancel()
+			}
+			return nil
+		},
+		"fail": func(ctx context.Context, args map[string]string) error {
+			deadlines["fail"], _ = ctx.Deadline()
+			return errors.New(args["reason"])
+		},
+	}
+	_, err = New(cfg, map[string]Job{"count": jobs["count"]}, log.New())
+	require.ErrorContains(t, err, `unknown job "fail"`)
+	s, err := New(cfg, jobs, log.New())
+	require.NoError(t, err)
+
+	// tasks are scheduled at 02:59:30, then the clock jumps to 03:05 when the first two are due
+	start, now := date("2025-01-01 02:59:30"), date("2025-01-01 03:05:00")
+	s.now = func() time.Time { return start }
+	for _, task := range s.tasks {
+		s.schedule(task, s.now())
+	}
+	require.Equal(t, date("2025-01-01 03:00:00"), s.tasks[0].next)
+	require.Equal(t, date("2025-01-01 03:00:00"), s.tasks[1].next)
+	require.Equal(t, date("2025-01-02 01:00:00"), s.tasks[2].next) // postponed to the window
+	s.now = func() time.Time { return now }
+
+	s.run(ctx, s.tasks[1])
+	require.Equal(t, now.Add(10*time.Minute), deadlines["fail"]) // timeout before the end of the window
+	s.run(ctx, s.tasks[2])                                       // window is closed
+	require.Zero(t, counted)
+
+	// the clock ticks a minute per reading: every task is due when the loop checks it
+	first := true
+	s.now = func() time.Time {
+		if first {
+			first = false
+			return start
+		}
+		now = now.Add(time.Minute)
+		return now
+	}
+	require.ErrorIs(t, s.Run(ctx), context.Canceled)
+	require.Equal(t, 2, counted)
+
+	status := s.Status()
+	require.Equal(t, "fail-1", status[1].Name)
+	require.Equal(t, "broken", status[1].Err)
+	require.Equal(t, 2, status[1].Runs)
+	require.Equal(t, 2, status[0].Runs)
+	require.Zero(t, status[2].Runs)
+	require.Equal(t, "01:00-02:00", status[2].Window)
+}
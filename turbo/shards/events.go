@@ -162,7 +162,8 @@ type Notifications struct {
 	Accumulator          *Accumulator // StateAccumulator
 	StateChangesConsumer StateChangeConsumer
 	RecentLogs           *RecentLogs
-	LastNewBlockSeen     atomic.Uint64 // This is used by eth_syncing as an heuristic to determine if the node is syncing or not.
+	StateGrowth          *StateGrowthTracker // nil - state growth analytics disabled
+	LastNewBlockSeen     atomic.Uint64       // This is used by eth_syncing as an heuristic to determine if the node is syncing or not.
 }
 
 func (n *Notifications) NewLastBlockSeen(blockNum uint64) {
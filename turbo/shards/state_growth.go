@@ -0,0 +1,267 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package shards
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+var (
+	mxStateGrowthNewAccounts     = metrics.GetOrCreateGauge(`state_growth{kind="new_accounts"}`)
+	mxStateGrowthDeletedAccounts = metrics.GetOrCreateGauge(`state_growth{kind="deleted_accounts"}`)
+	mxStateGrowthNewSlots        = metrics.GetOrCreateGauge(`state_growth{kind="new_slots"}`)
+	mxStateGrowthClearedSlots    = metrics.GetOrCreateGauge(`state_growth{kind="cleared_slots"}`)
+	mxStateGrowthCodeBytes       = metrics.GetOrCreateGauge(`state_growth{kind="code_bytes"}`)
+)
+
+// StateGrowth - changes of state size: accounts and storage slots created and deleted, bytes of deployed code.
+// Storage of self-destructed contracts is not counted as cleared.
+type StateGrowth struct {
+	NewAccounts     uint64 `json:"newAccounts"`
+	DeletedAccounts uint64 `json:"deletedAccounts"`
+	NewSlots        uint64 `json:"newSlots"`
+	ClearedSlots    uint64 `json:"clearedSlots"`
+	CodeBytes       uint64 `json:"codeBytes"`
+}
+
+func (g *StateGrowth) add(o *StateGrowth) {
+	g.NewAccounts += o.NewAccounts
+	g.DeletedAccounts += o.DeletedAccounts
+	g.NewSlots += o.NewSlots
+	g.ClearedSlots += o.ClearedSlots
+	g.CodeBytes += o.CodeBytes
+}
+
+func (g *StateGrowth) sub(o *StateGrowth) {
+	g.NewAccounts -= o.NewAccounts
+	g.DeletedAccounts -= o.DeletedAccounts
+	g.NewSlots -= o.NewSlots
+	g.ClearedSlots -= o.ClearedSlots
+	g.CodeBytes -= o.CodeBytes
+}
+
+// ContractStateGrowth - state growth of a contract
+type ContractStateGrowth struct {
+	Address   common.Address `json:"address"`
+	Slots     int64          `json:"slots"` // net number of new storage slots
+	CodeBytes uint64         `json:"codeBytes"`
+}
+
+// TxStateGrowth - state growth made by a txn (or by system calls of a block), collected by state writer
+type TxStateGrowth struct {
+	StateGrowth
+	contracts map[common.Address]*ContractStateGrowth
+}
+
+func (g *TxStateGrowth) Reset() {
+	g.StateGrowth = StateGrowth{}
+	clear(g.contracts)
+}
+
+func (g *TxStateGrowth) contract(addr common.Address) *ContractStateGrowth {
+	if g.contracts == nil {
+		g.contracts = map[common.Address]*ContractStateGrowth{}
+	}
+	c, ok := g.contracts[addr]
+	if !ok {
+		c = &ContractStateGrowth{Address: addr}
+		g.contracts[addr] = c
+	}
+	return c
+}
+
+func (g *TxStateGrowth) AccountCreated() { g.NewAccounts++ }
+func (g *TxStateGrowth) AccountDeleted() { g.DeletedAccounts++ }
+
+func (g *TxStateGrowth) CodeDeployed(addr common.Address, size int) {
+	g.CodeBytes += uint64(size)
+	g.contract(addr).CodeBytes += uint64(size)
+}
+
+// SlotWritten - storage slot of addr changed, wasEmpty/isEmpty - zero value before/after the change
+func (g *TxStateGrowth) SlotWritten(addr common.Address, wasEmpty, isEmpty bool) {
+	switch {
+	case wasEmpty && !isEmpty:
+		g.NewSlots++
+		g.contract(addr).Slots++
+	case !wasEmpty && isEmpty:
+		g.ClearedSlots++
+		g.contract(addr).Slots--
+	}
+}
+
+// BlockStateGrowth - state growth made by a block
+type BlockStateGrowth struct {
+	BlockNum uint64 `json:"blockNumber"`
+	StateGrowth
+	contracts map[common.Address]*ContractStateGrowth
+}
+
+// StateGrowthReport - state growth since the node start and over the recent blocks with the contracts which grew most
+type StateGrowthReport struct {
+	Total        StateGrowth           `json:"total"` // since the node start
+	TotalBlocks  uint64                `json:"totalBlocks"`
+	FromBlock    uint64                `json:"fromBlock"` // recent blocks
+	ToBlock      uint64                `json:"toBlock"`
+	Recent       StateGrowth           `json:"recent"`
+	TopContracts []ContractStateGrowth `json:"topContracts"` // by Slots over the recent blocks
+}
+
+// StateGrowthTracker - accumulates state growth of executed blocks: totals since the node start and per-block and
+// per-contract stats of the `window` most recent blocks. Re-executed and unwound blocks are not counted twice. Thread-safe
+type StateGrowthTracker struct {
+	mu          sync.Mutex
+	window      uint64
+	blocks      map[uint64]*BlockStateGrowth
+	current     *BlockStateGrowth // block being executed
+	total       StateGrowth
+	totalBlocks uint64
+}
+
+func NewStateGrowthTracker(window uint64) *StateGrowthTracker {
+	return &StateGrowthTracker{window: window, blocks: make(map[uint64]*BlockStateGrowth, window)}
+}
+
+// AddTx adds growth made by a txn of block `blockNum`. nil tracker ignores it.
+func (t *StateGrowthTracker) AddTx(blockNum uint64, g *TxStateGrowth) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil || t.current.BlockNum != blockNum {
+		t.unwindLocked(blockNum) // re-execution of blockNum
+		t.current = &BlockStateGrowth{BlockNum: blockNum, contracts: map[common.Address]*ContractStateGrowth{}}
+		t.blocks[blockNum] = t.current
+		t.totalBlocks++
+		for bn := range t.blocks {
+			if bn+t.window <= blockNum {
+				delete(t.blocks, bn)
+			}
+		}
+	}
+	t.current.add(&g.StateGrowth)
+	t.total.add(&g.StateGrowth)
+	for addr, c := range g.contracts {
+		bc, ok := t.current.contracts[addr]
+		if !ok {
+			bc = &ContractStateGrowth{Address: addr}
+			t.current.contracts[addr] = bc
+		}
+		bc.Slots += c.Slots
+		bc.CodeBytes += c.CodeBytes
+	}
+	t.updateMetricsLocked()
+}
+
+// Unwind forgets blocks starting from `unwindPoint+1`. Totals can't be corrected for blocks older than the window.
+func (t *StateGrowthTracker) Unwind(unwindPoint uint64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.unwindLocked(unwindPoint + 1)
+	t.updateMetricsLocked()
+}
+
+func (t *StateGrowthTracker) unwindLocked(from uint64) {
+	for bn, b := range t.blocks {
+		if bn < from {
+			continue
+		}
+		t.total.sub(&b.StateGrowth)
+		t.totalBlocks--
+		delete(t.blocks, bn)
+	}
+	if t.current != nil && t.current.BlockNum >= from {
+		t.current = nil
+	}
+}
+
+func (t *StateGrowthTracker) updateMetricsLocked() {
+	mxStateGrowthNewAccounts.SetUint64(t.total.NewAccounts)
+	mxStateGrowthDeletedAccounts.SetUint64(t.total.DeletedAccounts)
+	mxStateGrowthNewSlots.SetUint64(t.total.NewSlots)
+	mxStateGrowthClearedSlots.SetUint64(t.total.ClearedSlots)
+	mxStateGrowthCodeBytes.SetUint64(t.total.CodeBytes)
+}
+
+// Block returns growth made by a recent block, false if the block is not tracked
+func (t *StateGrowthTracker) Block(blockNum uint64) (BlockStateGrowth, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, ok := t.blocks[blockNum]
+	if !ok {
+		return BlockStateGrowth{}, false
+	}
+	return BlockStateGrowth{BlockNum: b.BlockNum, StateGrowth: b.StateGrowth}, true
+}
+
+// Report returns totals and `topN` contracts with the most new storage slots over the recent blocks
+func (t *StateGrowthTracker) Report(topN int) StateGrowthReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r := StateGrowthReport{Total: t.total, TotalBlocks: t.totalBlocks, TopContracts: []ContractStateGrowth{}}
+	contracts := map[common.Address]*ContractStateGrowth{}
+	first := true
+	for bn, b := range t.blocks {
+		if first || bn < r.FromBlock {
+			r.FromBlock = bn
+		}
+		if first || bn > r.ToBlock {
+			r.ToBlock = bn
+		}
+		first = false
+		r.Recent.add(&b.StateGrowth)
+		for addr, c := range b.contracts {
+			acc, ok := contracts[addr]
+			if !ok {
+				acc = &ContractStateGrowth{Address: addr}
+				contracts[addr] = acc
+			}
+			acc.Slots += c.Slots
+			acc.CodeBytes += c.CodeBytes
+		}
+	}
+	for _, c := range contracts {
+		r.TopContracts = append(r.TopContracts, *c)
+	}
+	slices.SortFunc(r.TopContracts, func(a, b ContractStateGrowth) int {
+		if a.Slots != b.Slots {
+			if a.Slots > b.Slots {
+				return -1
+			}
+			return 1
+		}
+		if a.CodeBytes != b.CodeBytes {
+			if a.CodeBytes > b.CodeBytes {
+				return -1
+			}
+			return 1
+		}
+		return a.Address.Cmp(b.Address)
+	})
+	if len(r.TopContracts) > topN {
+		r.TopContracts = r.TopContracts[:topN]
+	}
+	return r
+}
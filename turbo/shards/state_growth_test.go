@@ -0,0 +1,98 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package shards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+)
+
+func TestStateGrowthTracker(t *testing.T) {
+	t.Parallel()
+	a, b := common.Address{1}, common.Address{2}
+	txn := func(newSlotsA, newSlotsB int) *TxStateGrowth {
+		g := &TxStateGrowth{}
+		g.AccountCreated()
+		for i := 0; i < newSlotsA; i++ {
+			g.SlotWritten(a, true, false)
+		}
+		for i := 0; i < newSlotsB; i++ {
+			g.SlotWritten(b, true, false)
+		}
+		return g
+	}
+
+	t.Run("Window", func(t *testing.T) {
+		tr := NewStateGrowthTracker(2)
+		tr.AddTx(1, txn(1, 0))
+		tr.AddTx(1, txn(0, 3))
+		tr.AddTx(2, txn(2, 0))
+		tr.AddTx(3, txn(2, 0))
+
+		_, ok := tr.Block(1)
+		require.False(t, ok)
+		b2, ok := tr.Block(2)
+		require.True(t, ok)
+		require.Equal(t, StateGrowth{NewAccounts: 1, NewSlots: 2}, b2.StateGrowth)
+
+		r := tr.Report(1)
+		require.Equal(t, StateGrowth{NewAccounts: 4, NewSlots: 8}, r.Total)
+		require.Equal(t, uint64(3), r.TotalBlocks)
+		require.Equal(t, uint64(2), r.FromBlock)
+		require.Equal(t, uint64(3), r.ToBlock)
+		require.Equal(t, StateGrowth{NewAccounts: 2, NewSlots: 4}, r.Recent)
+		require.Equal(t, []ContractStateGrowth{{Address: a, Slots: 4}}, r.TopContracts)
+	})
+	t.Run("ReExecution", func(t *testing.T) {
+		tr := NewStateGrowthTracker(10)
+		tr.AddTx(1, txn(1, 0))
+		tr.AddTx(2, txn(1, 0))
+		tr.AddTx(3, txn(1, 0))
+		tr.AddTx(2, txn(0, 1)) // blocks 2.. re-executed without reported unwind
+		r := tr.Report(10)
+		require.Equal(t, StateGrowth{NewAccounts: 2, NewSlots: 2}, r.Total)
+		require.Equal(t, uint64(2), r.TotalBlocks)
+		require.Len(t, r.TopContracts, 2)
+	})
+	t.Run("Unwind", func(t *testing.T) {
+		tr := NewStateGrowthTracker(10)
+		tr.AddTx(1, txn(1, 0))
+		tr.AddTx(2, txn(0, 2))
+		g := &TxStateGrowth{}
+		g.SlotWritten(a, false, true)
+		g.CodeDeployed(b, 10)
+		tr.AddTx(3, g)
+		tr.Unwind(1)
+		r := tr.Report(10)
+		require.Equal(t, StateGrowth{NewAccounts: 1, NewSlots: 1}, r.Total)
+		require.Equal(t, uint64(1), r.TotalBlocks)
+		require.Equal(t, []ContractStateGrowth{{Address: a, Slots: 1}}, r.TopContracts)
+
+		tr.AddTx(2, g)
+		r = tr.Report(10)
+		require.Equal(t, StateGrowth{NewAccounts: 1, NewSlots: 1, ClearedSlots: 1, CodeBytes: 10}, r.Total)
+		require.Equal(t, []ContractStateGrowth{{Address: b, CodeBytes: 10}, {Address: a}}, r.TopContracts)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var tr *StateGrowthTracker
+		tr.AddTx(1, txn(1, 1))
+		tr.Unwind(0)
+	})
+}
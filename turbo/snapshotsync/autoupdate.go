@@ -0,0 +1,120 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshotsync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/snapcfg"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	proto_downloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/snaptype"
+)
+
+type autoUpdateBlockReader interface {
+	Snapshots() BlockSnapshots
+	BorSnapshots() BlockSnapshots
+	AllTypes() []snaptype.Type
+	FrozenBlocks() uint64
+}
+
+// NewPublishedBlockFiles returns the block segments of the manifest which start after the
+// frozen blocks and are missing from snapDir. The state and caplin files are not included:
+// they are merged and replaced by the node itself.
+func NewPublishedBlockFiles(preverified snapcfg.Preverified, types []snaptype.Type, frozenBlocks uint64, snapDir string) []DownloadRequest {
+	known := make(map[snaptype.Enum]struct{}, len(types))
+	for _, t := range types {
+		known[t.Enum()] = struct{}{}
+	}
+	var res []DownloadRequest
+	for _, p := range preverified.Items {
+		if strings.Contains(p.Name, "/") {
+			continue
+		}
+		info, _, ok := snaptype.ParseFileName("", p.Name)
+		if !ok || info.Type == nil || info.Ext != ".seg" {
+			continue
+		}
+		if _, ok := known[info.Type.Enum()]; !ok {
+			continue
+		}
+		// FrozenBlocks is the last block in the files, 0 without files
+		if frozenBlocks > 0 && info.From <= frozenBlocks {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(snapDir, p.Name)); err == nil {
+			continue
+		}
+		res = append(res, NewDownloadRequest(p.Name, p.Hash))
+	}
+	return res
+}
+
+// AutoUpdate reloads the snapshot manifest, downloads the block segments published since the
+// start of the node and opens them in the block reader. It returns the names of the new files.
+func AutoUpdate(
+	ctx context.Context,
+	logPrefix string,
+	dirs datadir.Dirs,
+	cc *chain.Config,
+	blockReader autoUpdateBlockReader,
+	snapshotDownloader proto_downloader.DownloaderClient,
+) ([]string, error) {
+	if snapshotDownloader == nil {
+		return nil, nil
+	}
+	if err := snapcfg.LoadRemotePreverified(ctx); err != nil {
+		return nil, fmt.Errorf("loading snapshot manifest: %w", err)
+	}
+	snapCfg, _ := snapcfg.KnownCfg(cc.ChainName)
+	if snapCfg.Local {
+		return nil, nil
+	}
+	downloadRequest := NewPublishedBlockFiles(snapCfg.Preverified, blockReader.AllTypes(), blockReader.FrozenBlocks(), dirs.Snap)
+	if len(downloadRequest) == 0 {
+		return nil, nil
+	}
+
+	log.Info(fmt.Sprintf("[%s] Downloading new snapshots", logPrefix), "files", len(downloadRequest), "frozenBlocks", blockReader.FrozenBlocks())
+	if err := RequestSnapshotsDownload(ctx, downloadRequest, snapshotDownloader, logPrefix); err != nil {
+		return nil, err
+	}
+	if err := waitForDownloadCompleted(ctx, snapshotDownloader); err != nil {
+		return nil, err
+	}
+
+	if err := blockReader.Snapshots().OpenFolder(); err != nil {
+		return nil, err
+	}
+	if cc.Bor != nil {
+		if err := blockReader.BorSnapshots().OpenFolder(); err != nil {
+			return nil, err
+		}
+	}
+	files := make([]string, len(downloadRequest))
+	for i, r := range downloadRequest {
+		files[i] = r.Path
+	}
+	log.Info(fmt.Sprintf("[%s] Opened new snapshots", logPrefix), "files", len(files), "frozenBlocks", blockReader.FrozenBlocks())
+	return files, nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/erigontech/erigon-db/rawdb"
 	coresnaptype "github.com/erigontech/erigon-db/snaptype"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/compress"
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
@@ -1080,8 +1081,12 @@ func BodyForTxnFromSnapshot(blockHeight uint64, sn *snapshotsync.VisibleSegment,
 	if len(buf) == 0 {
 		return nil, buf, nil
 	}
+	_, word, err := compress.DecodeWord(nil, buf)
+	if err != nil {
+		return nil, buf, err
+	}
 	b := &types.BodyOnlyTxn{}
-	if err := rlp.DecodeBytesPartial(buf, b); err != nil {
+	if err := rlp.DecodeBytesPartial(word, b); err != nil {
 		return nil, buf, err
 	}
 
@@ -1107,7 +1112,7 @@ func BodyForStorageFromSnapshot(blockHeight uint64, sn *snapshotsync.VisibleSegm
 		return nil, buf, nil
 	}
 	b := &types.BodyForStorage{}
-	if err := rlp.DecodeBytes(buf, b); err != nil {
+	if _, err := coresnaptype.DecodeBody(nil, buf, b); err != nil {
 		return nil, buf, err
 	}
 
@@ -1313,13 +1318,14 @@ func (r *BlockReader) IterateFrozenBodies(f func(blockNum, baseTxNum, txCount ui
 		sn := sn
 		defer sn.Src().MadvSequential().DisableReadAhead()
 
-		var buf []byte
+		var buf, decodedBuf []byte
 		g := sn.Src().MakeGetter()
 		blockNum := sn.From()
 		var b types.BodyForStorage
 		for g.HasNext() {
+			var err error
 			buf, _ = g.Next(buf[:0])
-			if err := rlp.DecodeBytes(buf, &b); err != nil {
+			if decodedBuf, err = coresnaptype.DecodeBody(decodedBuf, buf, &b); err != nil {
 				return err
 			}
 			if err := f(blockNum, b.BaseTxnID.U64(), uint64(b.TxCount)); err != nil {
@@ -1404,6 +1410,95 @@ func (r *BlockReader) RawTransactions(ctx context.Context, tx kv.Getter, fromBlo
 	return rawdb.RawTransactionsRange(tx, fromBlock, toBlock)
 }
 
+// FrozenBody - body of a block in the snapshot files, with the transactions in the binary encoding (as MarshalBinary)
+type FrozenBody struct {
+	Transactions [][]byte
+	Withdrawals  types.Withdrawals
+}
+
+// FrozenBodiesBinary reads the bodies of blocks [from, from+count) from the snapshot files, stopping at the first
+// block not in the files. Transactions are sliced from the records of the transactions segment, not decoded:
+// serving of ranges of old blocks (engine_getPayloadBodiesByRange) doesn't re-encode them.
+func (r *BlockReader) FrozenBodiesBinary(from, count uint64) ([]*FrozenBody, error) {
+	to := min(from+count, r.sn.BlocksAvailable()+1)
+	bodies := make([]*FrozenBody, 0, to-min(from, to))
+	for blockNum := from; blockNum < to; {
+		var err error
+		var next uint64
+		bodies, next, err = r.frozenBodiesBinary(blockNum, to, bodies)
+		if err != nil {
+			return nil, err
+		}
+		if next == blockNum {
+			break
+		}
+		blockNum = next
+	}
+	return bodies, nil
+}
+
+// frozenBodiesBinary appends the bodies of blocks [from, to) in the segment of block `from`, returns the block to
+// continue from: `from` if it isn't in the files
+func (r *BlockReader) frozenBodiesBinary(from, to uint64, bodies []*FrozenBody) ([]*FrozenBody, uint64, error) {
+	bodySeg, ok, release := r.sn.ViewSingleFile(coresnaptype.Bodies, from)
+	if !ok {
+		return bodies, from, nil
+	}
+	defer release()
+	txnSeg, ok, releaseTxns := r.sn.ViewSingleFile(coresnaptype.Transactions, from)
+	if !ok {
+		return bodies, from, nil
+	}
+	defer releaseTxns()
+	idxBody, idxTxnHash := bodySeg.Src().Index(), txnSeg.Src().Index(coresnaptype.Indexes.TxnHash)
+	if idxBody == nil || idxTxnHash == nil {
+		return bodies, from, nil
+	}
+
+	bodyGetter, txnGetter := bodySeg.Src().MakeGetter(), txnSeg.Src().MakeGetter()
+	bodyGetter.Reset(idxBody.OrdinalLookup(from - idxBody.BaseDataID()))
+	var buf, decodedBuf []byte
+	blockNum := from
+	for ; blockNum < min(to, bodySeg.To()); blockNum++ {
+		if !bodyGetter.HasNext() {
+			break
+		}
+		buf, _ = bodyGetter.Next(buf[:0]) // bodies of consecutive blocks are consecutive words
+		if len(buf) == 0 {
+			break
+		}
+		var b types.BodyForStorage
+		var err error
+		if decodedBuf, err = coresnaptype.DecodeBody(decodedBuf, buf, &b); err != nil {
+			return nil, 0, fmt.Errorf("body %d in %s: %w", blockNum, bodySeg.Src().FileName(), err)
+		}
+		body := &FrozenBody{Transactions: [][]byte{}, Withdrawals: b.Withdrawals}
+		if b.TxCount > 2 { // empty txs in the beginning and end of block
+			body.Transactions = make([][]byte, b.TxCount-2)
+			txnGetter.Reset(idxTxnHash.OrdinalLookup(b.BaseTxnID.First() - idxTxnHash.BaseDataID()))
+			for i := range body.Transactions {
+				if !txnGetter.HasNext() {
+					return nil, 0, fmt.Errorf("segment %s has no txn %d of block %d", txnSeg.Src().FileName(), i, blockNum)
+				}
+				word, _ := txnGetter.Next(nil) // the body keeps slices of the word
+				if len(word) < 1+20 {
+					return nil, 0, fmt.Errorf("segment %s has too short record: len(buf)=%d < 21", txnSeg.Src().FileName(), len(word))
+				}
+				txn := word[1+20:] // first byte of the hash and the sender precede the txn
+				if types.TypedTransactionMarshalledAsRlpString(txn) {
+					var err error
+					if _, txn, _, err = rlp.Split(txn); err != nil {
+						return nil, 0, err
+					}
+				}
+				body.Transactions[i] = txn
+			}
+		}
+		bodies = append(bodies, body)
+	}
+	return bodies, blockNum, nil
+}
+
 func (r *BlockReader) ReadAncestor(db kv.Getter, hash common.Hash, number, ancestor uint64, maxNonCanonical *uint64) (common.Hash, uint64) {
 	if ancestor > number {
 		return common.Hash{}, 0
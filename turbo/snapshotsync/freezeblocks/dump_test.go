@@ -37,6 +37,7 @@ import (
 	"github.com/erigontech/erigon-lib/rlp"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/execution/stages/mock"
 	"github.com/erigontech/erigon/polygon/bor/borcfg"
 	polychain "github.com/erigontech/erigon/polygon/chain"
@@ -303,3 +304,49 @@ func createDumpTestKV(t *testing.T, chainConfig *chain.Config, chainSize int) *m
 
 	return m
 }
+
+func TestFrozenBodiesBinary(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fix me on win")
+	}
+
+	require := require.New(t)
+	logger := log.New()
+	const chainSize = 1000
+	m := createDumpTestKV(t, chain.TestChainConfig, chainSize)
+	tmpDir, snapDir := t.TempDir(), t.TempDir()
+	require.NoError(freezeblocks.DumpBlocks(m.Ctx, 0, chainSize, m.ChainConfig, tmpDir, snapDir, m.DB, 1, log.LvlInfo, logger, m.BlockReader))
+
+	snapshots := freezeblocks.NewRoSnapshots(ethconfig.BlocksFreezing{ChainName: networkname.Mainnet}, snapDir, 0, logger)
+	defer snapshots.Close()
+	require.NoError(snapshots.OpenFolder())
+	blockReader := freezeblocks.NewBlockReader(snapshots, nil, nil, nil)
+	require.Equal(uint64(chainSize-1), blockReader.FrozenBlocks())
+
+	// the range ends at the last frozen block
+	bodies, err := blockReader.FrozenBodiesBinary(chainSize-10, 20)
+	require.NoError(err)
+	require.Len(bodies, 10)
+
+	bodies, err = blockReader.FrozenBodiesBinary(0, 100)
+	require.NoError(err)
+	require.Len(bodies, 100)
+	tx, err := m.DB.BeginRo(m.Ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	for blockNum, body := range bodies {
+		block, err := m.BlockReader.BlockByNumber(m.Ctx, tx, uint64(blockNum))
+		require.NoError(err)
+		txs, err := types.MarshalTransactionsBinary(block.Transactions())
+		require.NoError(err)
+		require.Equal(txs, body.Transactions, "block %d", blockNum)
+	}
+
+	bodies, err = blockReader.FrozenBodiesBinary(chainSize, 10)
+	require.NoError(err)
+	require.Empty(bodies)
+}
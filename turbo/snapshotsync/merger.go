@@ -14,6 +14,7 @@ import (
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
 	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/compress"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/seg"
@@ -282,12 +283,22 @@ func (m *Merger) merge(ctx context.Context, v *View, toMerge []*DirtySegment, ta
 	}
 	m.logger.Debug("[snapshots] merge", "file", targetFile.Name())
 
+	// merged files may be seeded: bodies re-encoded with a local dictionary (`seg codec-reencode`) are merged as plain RLP
+	decodeBodies := targetFile.Type.Enum() == coresnaptype.Enums.Bodies
+	var decodedBuf []byte
 	for _, d := range cList {
 		if err := d.WithReadAhead(func() error {
 			g := d.MakeGetter()
 			for g.HasNext() {
 				word, _ = g.Next(word[:0])
-				if err := f.AddWord(word); err != nil {
+				toAdd := word
+				if decodeBodies {
+					var err error
+					if decodedBuf, toAdd, err = compress.DecodeWord(decodedBuf, word); err != nil {
+						return fmt.Errorf("%s: %w", d.FileName(), err)
+					}
+				}
+				if err := f.AddWord(toAdd); err != nil {
 					return err
 				}
 			}
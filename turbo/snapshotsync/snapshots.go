@@ -37,6 +37,7 @@ import (
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/compress"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/diagnostics"
@@ -1050,6 +1051,11 @@ func (s *RoSnapshots) openSegments(fileNames []string, open bool, optimistic boo
 
 	snConfig, _ := snapcfg.KnownCfg(s.cfg.ChainName)
 
+	// words of the files may be encoded with the dictionaries
+	if err := compress.LoadDicts(coresnaptype.DictsDir(s.dir)); err != nil {
+		return err
+	}
+
 	for _, fName := range fileNames {
 		f, isState, ok := snaptype.ParseFileName(s.dir, fName)
 		if !ok || isState {
@@ -140,6 +140,26 @@ func RequestSnapshotsDownload(
 	return nil
 }
 
+// waitForDownloadCompleted checks for completion immediately, then in growing intervals.
+func waitForDownloadCompleted(ctx context.Context, downloader proto_downloader.DownloaderClient) error {
+	interval := time.Second
+	for {
+		completedResp, err := downloader.Completed(ctx, &proto_downloader.CompletedRequest{})
+		if err != nil {
+			return fmt.Errorf("waiting for snapshot download: %w", err)
+		}
+		if completedResp.GetCompleted() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, 20*time.Second)
+	}
+}
+
 func adjustBlockPrune(blocks, minBlocksToDownload uint64) uint64 {
 	if minBlocksToDownload < snaptype.Erigon2MergeLimit {
 		minBlocksToDownload = snaptype.Erigon2MergeLimit
@@ -444,22 +464,8 @@ func SyncSnapshots(
 			break
 		}
 
-		// Check for completion immediately, then growing intervals.
-		interval := time.Second
-		for {
-			completedResp, err := snapshotDownloader.Completed(ctx, &proto_downloader.CompletedRequest{})
-			if err != nil {
-				return fmt.Errorf("waiting for snapshot download: %w", err)
-			}
-			if completedResp.GetCompleted() {
-				break
-			}
-			select {
-			case <-ctx.Done():
-				return context.Cause(ctx)
-			case <-time.After(interval):
-			}
-			interval = min(interval*2, 20*time.Second)
+		if err := waitForDownloadCompleted(ctx, snapshotDownloader); err != nil {
+			return err
 		}
 		log.Info(fmt.Sprintf("[%s] Downloader completed %s", logPrefix, task))
 	}
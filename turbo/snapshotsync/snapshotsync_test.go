@@ -17,9 +17,12 @@
 package snapshotsync
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	coresnaptype "github.com/erigontech/erigon-db/snaptype"
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
 	"github.com/erigontech/erigon-lib/snaptype"
 )
@@ -57,3 +60,30 @@ func TestBlackListForPruning(t *testing.T) {
 	}
 
 }
+
+func TestNewPublishedBlockFiles(t *testing.T) {
+	snapDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(snapDir, "v1-001000-001500-headers.seg"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	preverified := snapcfg.Preverified{Items: []snapcfg.PreverifiedItem{
+		{Name: "v1-000000-000500-headers.seg", Hash: "01"}, // frozen
+		{Name: "v1-000500-001000-bodies.seg", Hash: "02"},  // frozen
+		{Name: "v1-001000-001500-headers.seg", Hash: "03"}, // downloaded already
+		{Name: "v1-001000-001500-bodies.seg", Hash: "04"},
+		{Name: "v1-001000-001500-transactions.seg", Hash: "05"},
+		{Name: "v1-001000-001500-headers.idx", Hash: "06"},
+		{Name: "v1-001000-001500-beaconblocks.seg", Hash: "07"}, // not a block type of the reader
+		{Name: "domain/v1-accounts.0-64.kv", Hash: "08"},
+		{Name: "salt-blocks.txt", Hash: "09"},
+	}}
+
+	requests := NewPublishedBlockFiles(preverified, coresnaptype.BlockSnapshotTypes, 999_999, snapDir)
+	var names []string
+	for _, r := range requests {
+		names = append(names, r.Path)
+	}
+	if want := "v1-001000-001500-bodies.seg,v1-001000-001500-transactions.seg"; strings.Join(names, ",") != want {
+		t.Fatalf("new files %v, want %s", names, want)
+	}
+}
@@ -52,6 +52,7 @@ func DoCall(
 	stateReader state.StateReader,
 	headerReader services.HeaderReader,
 	callTimeout time.Duration,
+	maxMemory uint64,
 ) (*evmtypes.ExecutionResult, error) {
 	// todo: Pending state is only known by the miner
 	/*
@@ -99,7 +100,7 @@ func DoCall(
 	blockCtx := NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, tx, headerReader, chainConfig)
 	txCtx := core.NewEVMTxContext(msg)
 
-	evm := vm.NewEVM(blockCtx, txCtx, state, chainConfig, vm.Config{NoBaseFee: true})
+	evm := vm.NewEVM(blockCtx, txCtx, state, chainConfig, vm.Config{NoBaseFee: true, MaxMemory: maxMemory})
 
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
@@ -142,82 +143,27 @@ func MakeHeaderGetter(requireCanonical bool, tx kv.Getter, headerReader services
 	}
 }
 
-type ReusableCaller struct {
-	evm             *vm.EVM
-	intraBlockState *state.IntraBlockState
-	gasCap          uint64
-	baseFee         *uint256.Int
-	stateReader     state.StateReader
-	callTimeout     time.Duration
-	message         *types.Message
-}
-
-func (r *ReusableCaller) DoCallWithNewGas(
-	ctx context.Context,
-	newGas uint64,
-	engine consensus.EngineReader,
-	overrides *ethapi2.StateOverrides,
-) (*evmtypes.ExecutionResult, error) {
-	var cancel context.CancelFunc
-	if r.callTimeout > 0 {
-		ctx, cancel = context.WithTimeout(ctx, r.callTimeout)
-	} else {
-		ctx, cancel = context.WithCancel(ctx)
-	}
-
-	// Make sure the context is cancelled when the call has completed
-	// this makes sure resources are cleaned up.
-	defer cancel()
-
-	r.message.ChangeGas(r.gasCap, newGas)
-
-	// reset the EVM so that we can continue to use it with the new context
-	txCtx := core.NewEVMTxContext(r.message)
-	if overrides == nil {
-		r.intraBlockState = state.New(r.stateReader)
-	}
-
-	r.evm.Reset(txCtx, r.intraBlockState)
-
-	timedOut := false
-	go func() {
-		<-ctx.Done()
-		timedOut = true
-	}()
-
-	gp := new(core.GasPool).AddGas(r.message.Gas()).AddBlobGas(r.message.BlobGas())
-
-	result, err := core.ApplyMessage(r.evm, r.message, gp, true /* refunds */, false /* gasBailout */, engine)
-	if err != nil {
-		return nil, err
-	}
-
-	// If the timer caused an abort, return an appropriate error message
-	if timedOut {
-		return nil, fmt.Errorf("execution aborted (timeout = %v)", r.callTimeout)
-	}
-
-	return result, nil
-}
-
-func NewReusableCaller(
+// NewCallEVM prepares the EVM on a fresh IntraBlockState with the state and block overrides applied and the
+// message of the call, for repeated executions of it, e.g. by core.EstimateGas
+func NewCallEVM(
 	engine consensus.EngineReader,
 	stateReader state.StateReader,
 	overrides *ethapi2.StateOverrides,
+	blockOverrides *ethapi2.BlockOverrides,
 	header *types.Header,
-	initialArgs ethapi2.CallArgs,
+	args ethapi2.CallArgs,
 	gasCap uint64,
 	blockNrOrHash rpc.BlockNumberOrHash,
 	tx kv.Tx,
 	headerReader services.HeaderReader,
 	chainConfig *chain.Config,
-	callTimeout time.Duration,
-) (*ReusableCaller, error) {
+	maxMemory uint64,
+) (*vm.EVM, *types.Message, error) {
 	ibs := state.New(stateReader)
 
 	if overrides != nil {
 		if err := overrides.Override(ibs); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -226,27 +172,23 @@ func NewReusableCaller(
 		var overflow bool
 		baseFee, overflow = uint256.FromBig(header.BaseFee)
 		if overflow {
-			return nil, errors.New("header.BaseFee uint256 overflow")
+			return nil, nil, errors.New("header.BaseFee uint256 overflow")
 		}
 	}
 
-	msg, err := initialArgs.ToMessage(gasCap, baseFee)
+	msg, err := args.ToMessage(gasCap, baseFee)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	blockCtx := NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, tx, headerReader, chainConfig)
+	if blockOverrides != nil {
+		if err := blockOverrides.Override(&blockCtx); err != nil {
+			return nil, nil, err
+		}
+	}
 	txCtx := core.NewEVMTxContext(msg)
 
-	evm := vm.NewEVM(blockCtx, txCtx, ibs, chainConfig, vm.Config{NoBaseFee: true})
-
-	return &ReusableCaller{
-		evm:             evm,
-		intraBlockState: ibs,
-		baseFee:         baseFee,
-		gasCap:          gasCap,
-		callTimeout:     callTimeout,
-		stateReader:     stateReader,
-		message:         msg,
-	}, nil
+	evm := vm.NewEVM(blockCtx, txCtx, ibs, chainConfig, vm.Config{NoBaseFee: true, MaxMemory: maxMemory})
+	return evm, msg, nil
 }
@@ -0,0 +1,71 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package transactions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+// ServiceTxnChecker recognizes senders of service transactions by the state of the latest executed block:
+// txpool.ServiceTxnChecker of the priority lane of the pool
+type ServiceTxnChecker struct {
+	db          kv.TemporalRoDB
+	engine      consensus.EngineReader
+	blockReader services.FullBlockReader
+	chainConfig *chain.Config
+}
+
+func NewServiceTxnChecker(db kv.TemporalRoDB, engine consensus.EngineReader, blockReader services.FullBlockReader, chainConfig *chain.Config) *ServiceTxnChecker {
+	return &ServiceTxnChecker{db: db, engine: engine, blockReader: blockReader, chainConfig: chainConfig}
+}
+
+func (c *ServiceTxnChecker) IsServiceTransaction(ctx context.Context, sender common.Address) (bool, error) {
+	tx, err := c.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	blockNum, err := rpchelper.GetLatestExecutedBlockNumber(tx)
+	if err != nil {
+		return false, err
+	}
+	header, err := c.blockReader.HeaderByNumber(ctx, tx, blockNum)
+	if err != nil {
+		return false, err
+	}
+	if header == nil {
+		return false, fmt.Errorf("header of the latest executed block %d not found", blockNum)
+	}
+
+	ibs := state.New(state.NewReaderV3(tx))
+	syscall := func(contract common.Address, data []byte) ([]byte, error) {
+		return core.SysCallContract(contract, data, c.chainConfig, ibs, header, c.engine, true /* constCall */, nil, vm.Config{})
+	}
+	return c.engine.IsServiceTransaction(sender, syscall), nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+// txnSimulationTimeout - the simulation is a pre-check of transaction submission, it must not hold it for long
+const txnSimulationTimeout = time.Second
+
+// TxnSimulator executes transactions against the state of the latest executed block, like eth_call of the
+// transaction: txpool.TxnSimulator of the pre-simulation of local transactions
+type TxnSimulator struct {
+	db          kv.TemporalRoDB
+	engine      consensus.EngineReader
+	blockReader services.FullBlockReader
+	chainConfig *chain.Config
+}
+
+func NewTxnSimulator(db kv.TemporalRoDB, engine consensus.EngineReader, blockReader services.FullBlockReader, chainConfig *chain.Config) *TxnSimulator {
+	return &TxnSimulator{db: db, engine: engine, blockReader: blockReader, chainConfig: chainConfig}
+}
+
+// WouldRevert executes the transaction, skipping the nonce and base fee checks: the transaction may wait in the pool
+// for the previous ones of the sender, or for the lower base fee. Errors of the execution aren't reported: the
+// transaction doesn't revert, the pool validation decides on it.
+func (s *TxnSimulator) WouldRevert(ctx context.Context, txnRlp []byte, wrappedWithBlobs bool) (bool, error) {
+	decode := types.DecodeTransaction
+	if wrappedWithBlobs {
+		decode = types.DecodeWrappedTransaction
+	}
+	txn, err := decode(txnRlp)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := s.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	blockNum, err := rpchelper.GetLatestExecutedBlockNumber(tx)
+	if err != nil {
+		return false, err
+	}
+	header, err := s.blockReader.HeaderByNumber(ctx, tx, blockNum)
+	if err != nil {
+		return false, err
+	}
+	if header == nil {
+		return false, fmt.Errorf("header of the latest executed block %d not found", blockNum)
+	}
+
+	msg, err := txn.AsMessage(*types.MakeSigner(s.chainConfig, blockNum, header.Time), header.BaseFee, s.chainConfig.Rules(blockNum, header.Time))
+	if err != nil {
+		return false, err
+	}
+	msg.SetCheckNonce(false)
+
+	ctx, cancel := context.WithTimeout(ctx, txnSimulationTimeout)
+	defer cancel()
+
+	ibs := state.New(state.NewReaderV3(tx))
+	blockCtx := NewEVMBlockContext(s.engine, header, true /* requireCanonical */, tx, s.blockReader, s.chainConfig)
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), ibs, s.chainConfig, vm.Config{NoBaseFee: true})
+	go func() {
+		<-ctx.Done()
+		evm.Cancel()
+	}()
+
+	gp := new(core.GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas())
+	result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, s.engine)
+	if err != nil {
+		return false, nil //nolint:nilerr
+	}
+	if evm.Cancelled() {
+		return false, fmt.Errorf("simulation aborted (timeout = %v)", txnSimulationTimeout)
+	}
+	return result.Failed(), nil
+}
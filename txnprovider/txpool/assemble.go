@@ -59,6 +59,7 @@ func Assemble(
 
 	newTxns := make(chan Announcements, 1024)
 	newSlotsStreams := &NewSlotsStreams{}
+	droppedTxnsStreams := &DroppedTxnsStreams{}
 	pool, err := New(
 		ctx,
 		newTxns,
@@ -73,13 +74,13 @@ func Assemble(
 		newSlotsStreams,
 		ethBackend,
 		logger,
-		opts...,
+		append(opts, WithDroppedTxnsStreams(droppedTxnsStreams))...,
 	)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	grpcServer := NewGrpcServer(ctx, pool, poolDB, newSlotsStreams, *chainID, logger)
+	grpcServer := NewGrpcServer(ctx, pool, poolDB, newSlotsStreams, droppedTxnsStreams, *chainID, logger)
 	return pool, grpcServer, nil
 }
 
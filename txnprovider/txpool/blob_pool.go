@@ -0,0 +1,235 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/diagnostics"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// BlobPool - blob transactions (EIP-4844) of the pool. They're in the pending, base fee and queued sub-pools by their
+// readiness like other transactions, but aren't counted by the limits of those: the blob sub-pool has its own limit
+// of blobs, so a burst of blob transactions doesn't crowd out regular ones.
+//
+// When the pool is full, blob transactions are evicted by blob fee cap, the lowest first (remote before local):
+// they're the last to be included when the blob base fee spikes. Blobs make most of the memory of the pool:
+// above memoryLimit, blobs of the transactions evicted first are dropped from memory - they're in the pool db
+// with the RLP of the transaction, and are read from it when requested.
+type BlobPool struct {
+	worst       *blobWorstQueue
+	blobs       uint64 // of all blob transactions
+	inMemory    uint64 // blobs whose bundles are in memory
+	limit       uint64
+	memoryLimit uint64
+}
+
+func NewBlobPool(limit, memoryLimit uint64) *BlobPool {
+	return &BlobPool{worst: &blobWorstQueue{}, limit: limit, memoryLimit: memoryLimit}
+}
+
+func (p *BlobPool) Add(mt *metaTxn) {
+	heap.Push(p.worst, mt)
+	n := uint64(len(mt.TxnSlot.BlobHashes))
+	p.blobs += n
+	if !mt.blobsOnDisk {
+		p.inMemory += n
+	}
+}
+
+func (p *BlobPool) Remove(mt *metaTxn) {
+	if mt.blobIndex < 0 || mt.blobIndex >= len(p.worst.ms) || p.worst.ms[mt.blobIndex] != mt {
+		return
+	}
+	heap.Remove(p.worst, mt.blobIndex)
+	n := uint64(len(mt.TxnSlot.BlobHashes))
+	p.blobs -= n
+	if !mt.blobsOnDisk {
+		p.inMemory -= n
+	}
+}
+
+// Worst - the blob transaction to evict first
+func (p *BlobPool) Worst() *metaTxn {
+	if len(p.worst.ms) == 0 {
+		return nil
+	}
+	return p.worst.ms[0]
+}
+
+func (p *BlobPool) Len() int {
+	return len(p.worst.ms)
+}
+
+// Blobs - number of blobs of the blob transactions, in memory and on disk
+func (p *BlobPool) Blobs() uint64 {
+	return p.blobs
+}
+
+// InMemory - number of blobs held in memory
+func (p *BlobPool) InMemory() uint64 {
+	return p.inMemory
+}
+
+// Overflows - the blob sub-pool is above its limit
+func (p *BlobPool) Overflows() bool {
+	return p.blobs > p.limit
+}
+
+// Accepts returns false if the blob transaction doesn't fit into the full sub-pool: it would be evicted before the
+// worst pooled blob transaction
+func (p *BlobPool) Accepts(txn *TxnSlot, isLocal bool) bool {
+	if p.blobs+uint64(len(txn.BlobHashes)) <= p.limit {
+		return true
+	}
+	worst := p.Worst()
+	if worst == nil {
+		return false
+	}
+	if worstLocal := worst.subPool&IsLocal != 0; worstLocal != isLocal {
+		return isLocal
+	}
+	return worst.TxnSlot.BlobFeeCap.Lt(&txn.BlobFeeCap)
+}
+
+// evictedBefore - order of the eviction of blob transactions: remote ones first, then by blob fee cap, fee cap,
+// and the later nonces of a sender first, not to leave nonce gaps
+func evictedBefore(mt, than *metaTxn) bool {
+	if local, thanLocal := mt.subPool&IsLocal != 0, than.subPool&IsLocal != 0; local != thanLocal {
+		return thanLocal
+	}
+	if c := mt.TxnSlot.BlobFeeCap.Cmp(&than.TxnSlot.BlobFeeCap); c != 0 {
+		return c < 0
+	}
+	if c := mt.TxnSlot.FeeCap.Cmp(&than.TxnSlot.FeeCap); c != 0 {
+		return c < 0
+	}
+	if mt.TxnSlot.SenderID == than.TxnSlot.SenderID {
+		return mt.TxnSlot.Nonce > than.TxnSlot.Nonce
+	}
+	return mt.timestamp > than.timestamp
+}
+
+type blobWorstQueue struct {
+	ms []*metaTxn
+}
+
+func (q *blobWorstQueue) Len() int { return len(q.ms) }
+
+func (q *blobWorstQueue) Less(i, j int) bool { return evictedBefore(q.ms[i], q.ms[j]) }
+
+func (q *blobWorstQueue) Swap(i, j int) {
+	q.ms[i], q.ms[j] = q.ms[j], q.ms[i]
+	q.ms[i].blobIndex = i
+	q.ms[j].blobIndex = j
+}
+
+func (q *blobWorstQueue) Push(x interface{}) {
+	item := x.(*metaTxn)
+	item.blobIndex = len(q.ms)
+	q.ms = append(q.ms, item)
+}
+
+func (q *blobWorstQueue) Pop() interface{} {
+	old := q.ms
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil      // avoid memory leak
+	item.blobIndex = -1 // for safety
+	q.ms = old[0 : n-1]
+	return item
+}
+
+// evictBlobTxnsLocked discards the worst blob transactions until the blob sub-pool is within its limit
+func (p *TxPool) evictBlobTxnsLocked() {
+	for p.blobs.Overflows() {
+		mt := p.blobs.Worst()
+		if mt.currentSubPool != 0 {
+			sendChangeBatchEventToDiagnostics(mt.currentSubPool.String(), "remove", []diagnostics.TxnHashOrder{
+				{
+					OrderMarker: uint8(mt.subPool),
+					Hash:        mt.TxnSlot.IDHash,
+				},
+			})
+		}
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			p.pending.Remove(mt, "blob-overflow", p.logger)
+		case BaseFeeSubPool:
+			p.baseFee.Remove(mt, "blob-overflow", p.logger)
+		case QueuedSubPool:
+			p.queued.Remove(mt, "blob-overflow", p.logger)
+		}
+		p.discardLocked(mt, txpoolcfg.BlobPoolOverflow)
+	}
+}
+
+// offloadBlobsLocked drops blobs from memory until the in-memory blobs are within the limit, starting from the blob
+// transactions to evict first. Only blobs of transactions flushed to the pool db are dropped.
+func (p *TxPool) offloadBlobsLocked() {
+	if p.blobs.inMemory <= p.blobs.memoryLimit {
+		return
+	}
+	ms := slices.Clone(p.blobs.worst.ms)
+	slices.SortFunc(ms, func(a, b *metaTxn) int {
+		if evictedBefore(a, b) {
+			return -1
+		}
+		if evictedBefore(b, a) {
+			return 1
+		}
+		return 0
+	})
+	for _, mt := range ms {
+		if p.blobs.inMemory <= p.blobs.memoryLimit {
+			break
+		}
+		if mt.blobsOnDisk || mt.TxnSlot.Rlp != nil {
+			continue
+		}
+		mt.TxnSlot.BlobBundles = nil
+		mt.blobsOnDisk = true
+		p.blobs.inMemory -= uint64(len(mt.TxnSlot.BlobHashes))
+	}
+}
+
+// blobBundlesLocked returns the blob bundles of the pooled blob transaction, read from the pool db if they were
+// dropped from memory
+func (p *TxPool) blobBundlesLocked(tx kv.Tx, mt *metaTxn) ([]PoolBlobBundle, error) {
+	if !mt.blobsOnDisk {
+		return mt.TxnSlot.BlobBundles, nil
+	}
+	v, err := tx.GetOne(kv.PoolTransaction, mt.TxnSlot.IDHash[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, fmt.Errorf("blobs of txn %x not found in the pool db", mt.TxnSlot.IDHash)
+	}
+	parseCtx := NewTxnParseContext(p.chainID)
+	parseCtx.WithSender(false)
+	txnSlot := &TxnSlot{}
+	if _, err := parseCtx.ParseTransaction(common.Copy(v[20:]), 0, txnSlot, nil, false, true, nil); err != nil {
+		return nil, fmt.Errorf("blobs of txn %x: %w", mt.TxnSlot.IDHash, err)
+	}
+	return txnSlot.BlobBundles, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/kv"
+)
+
+// FeeMarketPercentiles - percentiles of effective tips reported in FeeMarketSnapshot
+var FeeMarketPercentiles = []int{10, 25, 50, 75, 90}
+
+// FeeMarketSnapshot - compact summary of the pending sub-pool at some moment in time.
+// Snapshots are persisted in kv.PoolFeeMarketHistory and served by erigon_feeMarketHistory.
+type FeeMarketSnapshot struct {
+	Time           uint64          `json:"time"`
+	BlockNum       uint64          `json:"blockNum"`
+	PendingBaseFee uint64          `json:"pendingBaseFee"`
+	PendingBlobFee uint64          `json:"pendingBlobFee"`
+	Pending        int             `json:"pending"`
+	BaseFee        int             `json:"baseFee"`
+	Queued         int             `json:"queued"`
+	CountByType    map[byte]int    `json:"countByType"`
+	Blobs          uint64          `json:"blobs"`
+	TipPercentiles map[int]uint64  `json:"tipPercentiles"`
+	FeeCapMin      uint64          `json:"feeCapMin"`
+	FeeCapMax      uint64          `json:"feeCapMax"`
+	BlobFeeCapMin  uint64          `json:"blobFeeCapMin,omitempty"`
+	BlobFeeCapMax  uint64          `json:"blobFeeCapMax,omitempty"`
+	GasByType      map[byte]uint64 `json:"gasByType"`
+}
+
+// feeMarketSnapshot - must be called without p.lock held
+func (p *TxPool) feeMarketSnapshot(now time.Time) FeeMarketSnapshot {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	pendingBaseFee := p.pendingBaseFee.Load()
+	s := FeeMarketSnapshot{
+		Time:           uint64(now.Unix()),
+		BlockNum:       p.lastSeenBlock.Load(),
+		PendingBaseFee: pendingBaseFee,
+		PendingBlobFee: p.pendingBlobFee.Load(),
+		Pending:        p.pending.Len(),
+		BaseFee:        p.baseFee.Len(),
+		Queued:         p.queued.Len(),
+		CountByType:    map[byte]int{},
+		GasByType:      map[byte]uint64{},
+		TipPercentiles: map[int]uint64{},
+		Blobs:          p.blobs.Blobs(),
+	}
+
+	baseFee := uint256.NewInt(pendingBaseFee)
+	tips := make([]uint64, 0, len(p.pending.best.ms))
+	var effectiveTip uint256.Int
+	for i, mt := range p.pending.best.ms {
+		txn := mt.TxnSlot
+		s.CountByType[txn.Type]++
+		s.GasByType[txn.Type] += txn.Gas
+
+		effectiveTip.Set(&txn.Tip)
+		if txn.FeeCap.Gt(baseFee) {
+			var feeCapMinusBaseFee uint256.Int
+			feeCapMinusBaseFee.Sub(&txn.FeeCap, baseFee)
+			if feeCapMinusBaseFee.Lt(&effectiveTip) {
+				effectiveTip.Set(&feeCapMinusBaseFee)
+			}
+		} else {
+			effectiveTip.Clear()
+		}
+		tips = append(tips, saturatingUint64(&effectiveTip))
+
+		feeCap := saturatingUint64(&txn.FeeCap)
+		if i == 0 || feeCap < s.FeeCapMin {
+			s.FeeCapMin = feeCap
+		}
+		if feeCap > s.FeeCapMax {
+			s.FeeCapMax = feeCap
+		}
+		if txn.Type == BlobTxnType {
+			blobFeeCap := saturatingUint64(&txn.BlobFeeCap)
+			if s.BlobFeeCapMin == 0 || blobFeeCap < s.BlobFeeCapMin {
+				s.BlobFeeCapMin = blobFeeCap
+			}
+			if blobFeeCap > s.BlobFeeCapMax {
+				s.BlobFeeCapMax = blobFeeCap
+			}
+		}
+	}
+
+	if len(tips) > 0 {
+		slices.Sort(tips)
+		for _, pct := range FeeMarketPercentiles {
+			s.TipPercentiles[pct] = tips[(len(tips)-1)*pct/100]
+		}
+	}
+	return s
+}
+
+func saturatingUint64(v *uint256.Int) uint64 {
+	if !v.IsUint64() {
+		return ^uint64(0)
+	}
+	return v.Uint64()
+}
+
+// recordFeeMarketSnapshot - takes snapshot of pending sub-pool and persists it. Keeps at most `keep` newest records.
+func (p *TxPool) recordFeeMarketSnapshot(ctx context.Context, keep int) error {
+	if !p.Started() {
+		return nil
+	}
+	snapshot := p.feeMarketSnapshot(time.Now())
+	return p.poolDB.Update(ctx, func(tx kv.RwTx) error {
+		if err := PutFeeMarketSnapshot(tx, &snapshot); err != nil {
+			return err
+		}
+		return PruneFeeMarketHistory(tx, keep)
+	})
+}
+
+func PutFeeMarketSnapshot(tx kv.RwTx, s *FeeMarketSnapshot) error {
+	v, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("fee market snapshot marshal: %w", err)
+	}
+	var k [8]byte
+	binary.BigEndian.PutUint64(k[:], s.Time)
+	return tx.Put(kv.PoolFeeMarketHistory, k[:], v)
+}
+
+// PruneFeeMarketHistory - deletes oldest records, leaving at most `keep` of them. keep <= 0 means "keep everything".
+func PruneFeeMarketHistory(tx kv.RwTx, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	cnt, err := tx.Count(kv.PoolFeeMarketHistory)
+	if err != nil {
+		return err
+	}
+	if cnt <= uint64(keep) {
+		return nil
+	}
+	toDelete := cnt - uint64(keep)
+	c, err := tx.RwCursor(kv.PoolFeeMarketHistory)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	for k, _, err := c.First(); toDelete > 0; k, _, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		if k == nil {
+			break
+		}
+		if err := c.DeleteCurrent(); err != nil {
+			return err
+		}
+		toDelete--
+	}
+	return nil
+}
+
+// FeeMarketHistoryReader - read access to persisted fee market snapshots. Implemented by GrpcServer, but it's not part
+// of the txpool gRPC interface: available only to in-process consumers (rpcdaemon embedded into erigon with internal txpool)
+type FeeMarketHistoryReader interface {
+	FeeMarketHistory(ctx context.Context, fromTime uint64, limit int) ([]FeeMarketSnapshot, error)
+}
+
+// FeeMarketHistory - returns up to `limit` snapshots with Time >= fromTime, ordered by time
+func FeeMarketHistory(tx kv.Tx, fromTime uint64, limit int) ([]FeeMarketSnapshot, error) {
+	var from [8]byte
+	binary.BigEndian.PutUint64(from[:], fromTime)
+	c, err := tx.Cursor(kv.PoolFeeMarketHistory)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	var res []FeeMarketSnapshot
+	for k, v, err := c.Seek(from[:]); ; k, v, err = c.Next() {
+		if err != nil {
+			return nil, err
+		}
+		if k == nil || (limit > 0 && len(res) >= limit) {
+			break
+		}
+		var s FeeMarketSnapshot
+		if err := json.Unmarshal(v, &s); err != nil {
+			return nil, fmt.Errorf("fee market snapshot unmarshal: %w", err)
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/kv/memdb"
+)
+
+func TestFeeMarketHistory(t *testing.T) {
+	db := memdb.NewTestPoolDB(t)
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	for i := uint64(1); i <= 10; i++ {
+		s := &FeeMarketSnapshot{Time: i * 60, BlockNum: i, Pending: int(i), TipPercentiles: map[int]uint64{50: i}}
+		require.NoError(t, PutFeeMarketSnapshot(tx, s))
+	}
+
+	res, err := FeeMarketHistory(tx, 5*60, 3)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	require.Equal(t, uint64(5), res[0].BlockNum)
+	require.Equal(t, uint64(7), res[2].TipPercentiles[50])
+
+	require.NoError(t, PruneFeeMarketHistory(tx, 4))
+	res, err = FeeMarketHistory(tx, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+	require.Equal(t, uint64(7), res[0].BlockNum)
+	require.Equal(t, uint64(10), res[3].BlockNum)
+}
@@ -33,6 +33,7 @@ import (
 	sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
+	libsentry "github.com/erigontech/erigon-lib/p2p/sentry"
 	"github.com/erigontech/erigon-lib/rlp"
 )
 
@@ -51,6 +52,9 @@ type Fetch struct {
 	sentryClients            []sentry.SentryClient // sentry clients that will be used for accessing the network
 	stateChangesParseCtxLock sync.Mutex
 	pooledTxnsParseCtxLock   sync.Mutex
+	propagation              *PropagationTracker           // nil if disabled
+	ingestion                *peerIngestion                // nil if disabled
+	announcements            *libsentry.AnnouncementFilter // nil with single sentry
 	logger                   log.Logger
 }
 
@@ -81,6 +85,7 @@ func NewFetch(
 		stateChangesParseCtx: NewTxnParseContext(chainID).ChainIDRequired(), //TODO: change ctx if rules changed
 		pooledTxnsParseCtx:   NewTxnParseContext(chainID).ChainIDRequired(),
 		wg:                   options.p2pFetcherWg,
+		announcements:        libsentry.NewAnnouncementFilterFor(sentryClients),
 		logger:               logger,
 	}
 	f.pooledTxnsParseCtx.ValidateRLP(f.pool.ValidateSerializedTxn)
@@ -222,14 +227,18 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 	case sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_66:
 		hashCount, pos, err := ParseHashesCount(req.Data, 0)
 		if err != nil {
+			f.ingestion.penalize(req.PeerId, penaltyMalformed, 1)
 			return fmt.Errorf("parsing NewPooledTransactionHashes: %w", err)
 		}
 		hashes := make([]byte, 32*hashCount)
+		now := time.Now()
 		for i := 0; i < len(hashes); i += 32 {
 			if _, pos, err = ParseHash(req.Data, pos, hashes[i:]); err != nil {
 				return err
 			}
+			f.propagation.Received(hashes[i:i+32], req.PeerId, PropagationAnnouncement, now)
 		}
+		hashes = f.announcements.FilterTxnHashes(hashes, sentryClient)
 		unknownHashes, err := f.pool.FilterKnownIdHashes(tx, hashes)
 		if err != nil {
 			return err
@@ -251,8 +260,14 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 	case sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_68:
 		_, _, hashes, _, err := rlp.ParseAnnouncements(req.Data, 0)
 		if err != nil {
+			f.ingestion.penalize(req.PeerId, penaltyMalformed, 1)
 			return fmt.Errorf("parsing NewPooledTransactionHashes88: %w", err)
 		}
+		now := time.Now()
+		for i := 0; i < len(hashes); i += 32 {
+			f.propagation.Received(hashes[i:i+32], req.PeerId, PropagationAnnouncement, now)
+		}
+		hashes = f.announcements.FilterTxnHashes(hashes, sentryClient)
 		unknownHashes, err := f.pool.FilterKnownIdHashes(tx, hashes)
 		if err != nil {
 			return err
@@ -322,6 +337,9 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 			return err
 		}
 	case sentry.MessageId_POOLED_TRANSACTIONS_66, sentry.MessageId_TRANSACTIONS_66:
+		if !f.ingestion.accept(req.PeerId) {
+			return nil
+		}
 		txns := TxnSlots{}
 		if err := f.threadSafeParsePooledTxn(func(parseContext *TxnParseContext) error {
 			return nil
@@ -333,6 +351,7 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 		case sentry.MessageId_TRANSACTIONS_66:
 			if err := f.threadSafeParsePooledTxn(func(parseContext *TxnParseContext) error {
 				if _, err := ParseTransactions(req.Data, 0, parseContext, &txns, func(hash []byte) error {
+					f.propagation.Received(hash, req.PeerId, PropagationTransaction, time.Now())
 					known, err := f.pool.IdHashKnown(tx, hash)
 					if err != nil {
 						return err
@@ -346,11 +365,15 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 				}
 				return nil
 			}); err != nil {
+				if errors.Is(err, rlp.ErrParse) {
+					f.ingestion.penalize(req.PeerId, penaltyMalformed, 1)
+				}
 				return err
 			}
 		case sentry.MessageId_POOLED_TRANSACTIONS_66:
 			if err := f.threadSafeParsePooledTxn(func(parseContext *TxnParseContext) error {
 				if _, _, err := ParsePooledTransactions66(req.Data, 0, parseContext, &txns, func(hash []byte) error {
+					f.propagation.Received(hash, req.PeerId, PropagationTransaction, time.Now())
 					known, err := f.pool.IdHashKnown(tx, hash)
 					if err != nil {
 						return err
@@ -364,6 +387,9 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 				}
 				return nil
 			}); err != nil {
+				if errors.Is(err, rlp.ErrParse) {
+					f.ingestion.penalize(req.PeerId, penaltyMalformed, 1)
+				}
 				return err
 			}
 		default:
@@ -372,6 +398,10 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 		if len(txns.Txns) == 0 {
 			return nil
 		}
+		for _, txn := range txns.Txns {
+			txn.Peer = req.PeerId
+		}
+		f.ingestion.charge(req.PeerId, len(txns.Txns))
 
 		f.pool.AddRemoteTxns(ctx, txns)
 	default:
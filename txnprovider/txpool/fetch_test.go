@@ -39,6 +39,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/memdb"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
 
 func TestFetch(t *testing.T) {
@@ -372,3 +373,42 @@ func toPeerIDs(h ...byte) (out []PeerID) {
 	}
 	return out
 }
+
+func TestPeerIngestion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	sentryClient := sentryproto.NewMockSentryClient(ctrl)
+	penalized := make(chan PeerID, 1)
+	sentryClient.EXPECT().PenalizePeer(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r *sentryproto.PenalizePeerRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+			penalized <- r.PeerId
+			return &emptypb.Empty{}, nil
+		}).Times(1)
+
+	var nilIngestion *peerIngestion
+	require.True(t, nilIngestion.accept(peerID))
+
+	pi := newPeerIngestion(ctx, txpoolcfg.PeerIngestion{TxnsPerSecond: 0.001, TxnsBurst: 10, MaxPenalty: 25}, []sentryproto.SentryClient{sentryClient}, log.New())
+	otherPeer := gointerfaces.ConvertHashToH512([64]byte{0x56})
+
+	// the message above the rate is processed, the next ones are dropped
+	require.True(t, pi.accept(peerID))
+	pi.charge(peerID, 15)
+	require.False(t, pi.accept(peerID))
+	require.True(t, pi.accept(otherPeer))
+
+	// 1 point for the dropped message, 10 per malformed one
+	pi.penalize(peerID, penaltyMalformed, 2)
+	pi.penalize(peerID, penaltyInvalidTxn, 3)
+	select {
+	case <-penalized:
+		t.Fatal("penalized below the max")
+	default:
+	}
+	pi.penalize(peerID, penaltyInvalidTxn, 2) // the points decay meanwhile
+	require.Equal(t, peerID, <-penalized)
+	require.False(t, pi.accept(peerID))
+	pi.penalize(peerID, penaltyMalformed, 1) // penalized once
+	require.True(t, pi.accept(otherPeer))
+}
@@ -16,10 +16,14 @@
 
 package txpool
 
-import "github.com/holiman/uint256"
+import (
+	"time"
+
+	"github.com/holiman/uint256"
+)
 
 func newMetaTxn(slot *TxnSlot, isLocal bool, timestamp uint64) *metaTxn {
-	mt := &metaTxn{TxnSlot: slot, worstIndex: -1, bestIndex: -1, timestamp: timestamp}
+	mt := &metaTxn{TxnSlot: slot, worstIndex: -1, bestIndex: -1, blobIndex: -1, timestamp: timestamp}
 	if isLocal {
 		mt.subPool = IsLocal
 	}
@@ -35,10 +39,14 @@ type metaTxn struct {
 	minTip                    uint64
 	bestIndex                 int
 	worstIndex                int
-	timestamp                 uint64 // when it was added to pool
+	blobIndex                 int       // in BlobPool
+	blobsOnDisk               bool      // blob bundles were dropped from memory, they're in the pool db with the RLP
+	timestamp                 uint64    // when it was added to pool
+	queuedAt                  time.Time // when it entered the queued sub-pool, see txpoolcfg.Config.QueuedLifetime
 	subPool                   SubPoolMarker
 	currentSubPool            SubPoolType
 	minedBlockNum             uint64
+	priority                  bool // sent by a priority sender: see TxPool.isPrioritySenderLocked
 }
 
 // Returns true if the txn "mt" is better than the parameter txn "than"
@@ -56,6 +64,10 @@ func (mt *metaTxn) better(than *metaTxn, pendingBaseFee uint256.Int) bool {
 	if than.minFeeCap.Cmp(&pendingBaseFee) >= 0 {
 		thanSubPool |= EnoughFeeCapBlock
 	}
+	// priority txns are offered first, the ones of the other sub pools can't skip their promotion order
+	if mt.currentSubPool == PendingSubPool && mt.priority != than.priority {
+		return mt.priority
+	}
 	if subPool != thanSubPool {
 		return subPool > thanSubPool
 	}
@@ -121,6 +133,10 @@ func (mt *metaTxn) worse(than *metaTxn, pendingBaseFee uint256.Int) bool {
 	if subPool != thanSubPool {
 		return subPool < thanSubPool
 	}
+	// priority txns are evicted after all the others qualifying for the sub pool
+	if mt.priority != than.priority {
+		return than.priority
+	}
 
 	switch mt.currentSubPool {
 	case PendingSubPool:
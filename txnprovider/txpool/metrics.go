@@ -16,7 +16,11 @@
 
 package txpool
 
-import "github.com/erigontech/erigon-lib/metrics"
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
 
 var (
 	processBatchTxnsTimer   = metrics.NewSummary(`pool_process_remote_txs`)
@@ -29,4 +33,37 @@ var (
 	pendingSubCounter       = metrics.GetOrCreateGauge(`txpool_pending`)
 	queuedSubCounter        = metrics.GetOrCreateGauge(`txpool_queued`)
 	basefeeSubCounter       = metrics.GetOrCreateGauge(`txpool_basefee`)
+	blobTxnsCounter         = metrics.GetOrCreateGauge(`txpool_blob_txns`)
+	blobsInMemoryCounter    = metrics.GetOrCreateGauge(`txpool_blobs{location="memory"}`)
+	blobsOnDiskCounter      = metrics.GetOrCreateGauge(`txpool_blobs{location="disk"}`)
+	parkedDuration          = metrics.GetOrCreateSummary(`txpool_parked_duration`)
+	orderingDuration        = metrics.GetOrCreateSummary(`txpool_ordering_duration`)
+	orderingFailures        = metrics.GetOrCreateCounter(`txpool_ordering_failures`)
+	peerPenalized           = metrics.GetOrCreateCounter(`txpool_peer_penalized`)
+	queuedReapTimer         = metrics.NewSummary(`txpool_queued_reap`)
+	queuedExpired           = metrics.GetOrCreateCounter(`txpool_queued_expired`)
 )
+
+func parkedGauge(cause parkingCause) metrics.Gauge {
+	return metrics.GetOrCreateGauge(fmt.Sprintf(`txpool_parked{cause="%s"}`, cause))
+}
+
+func parkingPromoted(cause parkingCause) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`txpool_parked_promoted{cause="%s"}`, cause))
+}
+
+func parkingExpired(cause parkingCause) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`txpool_parked_expired{cause="%s"}`, cause))
+}
+
+func parkingEvicted(cause parkingCause) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`txpool_parked_evicted{cause="%s"}`, cause))
+}
+
+func peerIngestionDropped(reason string) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`txpool_peer_dropped{reason="%s"}`, reason))
+}
+
+func peerPenalties(cause penaltyCause) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`txpool_peer_penalties{cause="%s"}`, cause))
+}
@@ -48,11 +48,44 @@ func WithP2PSenderWg(wg *sync.WaitGroup) Option {
 	}
 }
 
+// WithDroppedTxnsStreams - subscribers of the transactions dropped from the pool, see GrpcServer.OnDrop
+func WithDroppedTxnsStreams(streams *DroppedTxnsStreams) Option {
+	return func(o *options) {
+		o.droppedTxnsStreams = streams
+	}
+}
+
+// WithTxnSimulator - executes local transactions before they are added, see txpoolcfg.Config.SimulateLocalTxns
+func WithTxnSimulator(s TxnSimulator) Option {
+	return func(o *options) {
+		o.txnSimulator = s
+	}
+}
+
+// WithServiceTxnChecker - txns of the senders of service txns recognized by the consensus engine are given the
+// priority of txpoolcfg.Config.PrioritySenders
+func WithServiceTxnChecker(c ServiceTxnChecker) Option {
+	return func(o *options) {
+		o.serviceTxnChecker = c
+	}
+}
+
+// WithOrdering - order of the pending transactions offered for the blocks, overrides txpoolcfg.Config.Ordering
+func WithOrdering(o Ordering) Option {
+	return func(o_ *options) {
+		o_.ordering = o
+	}
+}
+
 type options struct {
-	feeCalculator     FeeCalculator
-	poolDBInitializer poolDBInitializer
-	p2pSenderWg       *sync.WaitGroup
-	p2pFetcherWg      *sync.WaitGroup
+	feeCalculator      FeeCalculator
+	poolDBInitializer  poolDBInitializer
+	p2pSenderWg        *sync.WaitGroup
+	p2pFetcherWg       *sync.WaitGroup
+	droppedTxnsStreams *DroppedTxnsStreams
+	txnSimulator       TxnSimulator
+	serviceTxnChecker  ServiceTxnChecker
+	ordering           Ordering
 }
 
 func applyOpts(opts ...Option) options {
@@ -0,0 +1,205 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
+	"github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// orderingTimeout - the pending sub-pool order is used if the scores aren't ready in time
+const orderingTimeout = 200 * time.Millisecond
+
+// OrderingTxn - pending transaction to score by Ordering
+type OrderingTxn struct {
+	IDHash    common.Hash
+	Sender    common.Address
+	Nonce     uint64
+	Gas       uint64
+	Tip       uint64 // minimal tip of the transaction and of the previous ones of the sender
+	FeeCap    uint256.Int
+	BlobCount int
+	Size      uint32
+	Timestamp uint64 // when it was added to the pool
+	Local     bool
+}
+
+// Ordering - order of the pending transactions offered for the blocks by YieldBest and PeekBest, see
+// txpoolcfg.Config.Ordering. Transactions of a sender are offered in the order of their nonces whatever their
+// scores are.
+type Ordering interface {
+	// Score - per transaction, the higher the earlier it's offered. Transactions of equal scores are offered in the
+	// order of the pending sub-pool.
+	Score(ctx context.Context, txns []OrderingTxn, baseFee uint64) ([]uint64, error)
+}
+
+// newOrdering - nil for txpoolcfg.OrderingEffectiveTip: the pending sub-pool is kept in that order
+func newOrdering(cfg txpoolcfg.Config) (Ordering, error) {
+	switch cfg.Ordering {
+	case "", txpoolcfg.OrderingEffectiveTip:
+		return nil, nil
+	case txpoolcfg.OrderingTipAfterBaseFee:
+		return TipAfterBaseFeeOrdering{}, nil
+	case txpoolcfg.OrderingGrpc:
+		if cfg.OrderingAddr == "" {
+			return nil, fmt.Errorf("txpool ordering %s needs the address of the scoring service", cfg.Ordering)
+		}
+		conn, err := grpcutil.Connect(nil, cfg.OrderingAddr)
+		if err != nil {
+			return nil, fmt.Errorf("txpool ordering %s: %w", cfg.Ordering, err)
+		}
+		return NewGrpcOrdering(txpoolproto.NewScorerClient(conn)), nil
+	}
+	return nil, fmt.Errorf("unknown txpool ordering %q, expected one of %v", cfg.Ordering, txpoolcfg.Orderings)
+}
+
+// TipAfterBaseFeeOrdering - by the EIP-1559 tip after the base fee: min(tip, feeCap-baseFee). Unlike the pending
+// sub-pool order it ignores the sub-pool markers and the priority of the senders.
+type TipAfterBaseFeeOrdering struct{}
+
+func (TipAfterBaseFeeOrdering) Score(_ context.Context, txns []OrderingTxn, baseFee uint64) ([]uint64, error) {
+	scores := make([]uint64, len(txns))
+	base := uint256.NewInt(baseFee)
+	for i := range txns {
+		if txns[i].FeeCap.Lt(base) {
+			continue
+		}
+		var tip uint256.Int
+		tip.Sub(&txns[i].FeeCap, base)
+		if tip.IsUint64() {
+			scores[i] = min(tip.Uint64(), txns[i].Tip)
+		} else {
+			scores[i] = txns[i].Tip
+		}
+	}
+	return scores, nil
+}
+
+// GrpcOrdering - by the scores of the external txpoolproto.Scorer service
+type GrpcOrdering struct {
+	client txpoolproto.ScorerClient
+}
+
+func NewGrpcOrdering(client txpoolproto.ScorerClient) *GrpcOrdering {
+	return &GrpcOrdering{client: client}
+}
+
+func (o *GrpcOrdering) Score(ctx context.Context, txns []OrderingTxn, baseFee uint64) ([]uint64, error) {
+	req := &txpoolproto.ScoreRequest{BaseFee: baseFee, Txns: make([]*txpoolproto.ScoreTxn, len(txns))}
+	for i := range txns {
+		feeCap := txns[i].FeeCap.Bytes32()
+		req.Txns[i] = &txpoolproto.ScoreTxn{
+			Hash:      txns[i].IDHash[:],
+			Sender:    txns[i].Sender[:],
+			Nonce:     txns[i].Nonce,
+			Gas:       txns[i].Gas,
+			Tip:       txns[i].Tip,
+			FeeCap:    feeCap[:],
+			BlobCount: uint32(txns[i].BlobCount),
+			Size:      txns[i].Size,
+			Local:     txns[i].Local,
+		}
+	}
+	reply, err := o.client.Score(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return reply.Scores, nil
+}
+
+// orderedLocked - the pending transactions in the order of p.ordering, or in the order of the pending sub-pool if
+// it's not set or fails to score them
+func (p *TxPool) orderedLocked(ctx context.Context, best []*metaTxn) []*metaTxn {
+	if p.ordering == nil || len(best) == 0 {
+		return best
+	}
+	txns := make([]OrderingTxn, len(best))
+	for i, mt := range best {
+		sender, _ := p.senders.getAddr(mt.TxnSlot.SenderID)
+		txns[i] = OrderingTxn{
+			IDHash:    mt.TxnSlot.IDHash,
+			Sender:    sender,
+			Nonce:     mt.TxnSlot.Nonce,
+			Gas:       mt.TxnSlot.Gas,
+			Tip:       mt.minTip,
+			FeeCap:    mt.minFeeCap,
+			BlobCount: len(mt.TxnSlot.BlobHashes),
+			Size:      mt.TxnSlot.Size,
+			Timestamp: mt.timestamp,
+			Local:     mt.subPool&IsLocal != 0,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, orderingTimeout)
+	defer cancel()
+	start := time.Now()
+	scores, err := p.ordering.Score(ctx, txns, p.pendingBaseFee.Load())
+	orderingDuration.ObserveDuration(start)
+	if err == nil && len(scores) != len(best) {
+		err = fmt.Errorf("%d scores of %d transactions", len(scores), len(best))
+	}
+	if err != nil {
+		orderingFailures.Inc()
+		p.logger.Warn("[txpool] ordering failed, using the pending sub-pool order", "ordering", p.cfg.Ordering, "err", err)
+		return best
+	}
+	return orderByScores(best, scores)
+}
+
+// orderByScores - the transactions by descending scores, then the transactions of every sender are put in the
+// order of their nonces into the places the scores gave to them
+func orderByScores(best []*metaTxn, scores []uint64) []*metaTxn {
+	idx := make([]int, len(best))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return scores[idx[i]] > scores[idx[j]] })
+	ordered := make([]*metaTxn, len(best))
+	for i, j := range idx {
+		ordered[i] = best[j]
+	}
+
+	places := map[uint64][]int{}
+	for i, mt := range ordered {
+		places[mt.TxnSlot.SenderID] = append(places[mt.TxnSlot.SenderID], i)
+	}
+	for _, at := range places {
+		if len(at) < 2 {
+			continue
+		}
+		txns := make([]*metaTxn, len(at))
+		for i, place := range at {
+			txns[i] = ordered[place]
+		}
+		slices.SortFunc(txns, func(a, b *metaTxn) int { return cmp.Compare(a.TxnSlot.Nonce, b.TxnSlot.Nonce) })
+		for i, place := range at {
+			ordered[place] = txns[i]
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// parkingCause - why a transaction of the queued sub-pool can't be promoted
+type parkingCause uint8
+
+const (
+	notParked      parkingCause = iota
+	parkedNonceGap              // some of the previous nonces of the sender aren't pooled
+	parkedBalance               // the sender can't pay for this and the previous transactions
+)
+
+var parkingCauses = []parkingCause{parkedNonceGap, parkedBalance}
+
+func (c parkingCause) String() string {
+	switch c {
+	case parkedNonceGap:
+		return "nonce_gap"
+	case parkedBalance:
+		return "balance"
+	}
+	return fmt.Sprintf("unknown:%d", uint8(c))
+}
+
+func parkingCauseOf(mt *metaTxn) parkingCause {
+	switch {
+	case mt.subPool&NoNonceGaps == 0:
+		return parkedNonceGap
+	case mt.subPool&EnoughBalance == 0:
+		return parkedBalance
+	}
+	return notParked
+}
+
+type parkedTxn struct {
+	cause parkingCause
+	since time.Time
+}
+
+// parkingLot - tracks the transactions of the queued sub-pool waiting for a nonce gap to be filled or for the
+// balance of the sender, see txpoolcfg.Parking. They stay in the queued sub-pool. nil lot is disabled.
+type parkingLot struct {
+	cfg    txpoolcfg.Parking
+	parked map[*metaTxn]parkedTxn
+}
+
+func newParkingLot(cfg txpoolcfg.Parking) *parkingLot {
+	if cfg == (txpoolcfg.Parking{}) {
+		return nil
+	}
+	return &parkingLot{cfg: cfg, parked: map[*metaTxn]parkedTxn{}}
+}
+
+// updateParkingLocked - parks new transactions of the queued sub-pool, releases the ones which were promoted or
+// discarded and discards the ones parked for too long or above the limit. Must be called after promotion.
+func (p *TxPool) updateParkingLocked(now time.Time, logger log.Logger) {
+	lot := p.parking
+	if lot == nil {
+		return
+	}
+
+	for _, mt := range p.queued.best.ms {
+		cause := parkingCauseOf(mt)
+		if cause == notParked {
+			continue
+		}
+		if parked, ok := lot.parked[mt]; ok && parked.cause == cause {
+			continue
+		} else if ok {
+			// the gap was filled but the balance isn't enough yet: age is counted from the first parking
+			lot.parked[mt] = parkedTxn{cause: cause, since: parked.since}
+			continue
+		}
+		lot.parked[mt] = parkedTxn{cause: cause, since: now}
+	}
+
+	for mt, parked := range lot.parked {
+		if p.byHash[string(mt.TxnSlot.IDHash[:])] != mt {
+			delete(lot.parked, mt) // discarded or mined
+			continue
+		}
+		if mt.currentSubPool == QueuedSubPool && parkingCauseOf(mt) != notParked {
+			continue
+		}
+		delete(lot.parked, mt)
+		parkingPromoted(parked.cause).Inc()
+		parkedDuration.ObserveDuration(parked.since)
+		logger.Debug("[txpool] unparked", "idHash", fmt.Sprintf("%x", mt.TxnSlot.IDHash), "sender", mt.TxnSlot.SenderID,
+			"nonce", mt.TxnSlot.Nonce, "cause", parked.cause, "parked", now.Sub(parked.since), "subPool", mt.currentSubPool)
+	}
+
+	var expired, overflow []*metaTxn
+	byAge := make([]*metaTxn, 0, len(lot.parked))
+	for mt, parked := range lot.parked {
+		if lot.cfg.MaxAge > 0 && now.Sub(parked.since) > lot.cfg.MaxAge {
+			expired = append(expired, mt)
+			continue
+		}
+		byAge = append(byAge, mt)
+	}
+	if lot.cfg.Limit > 0 && len(byAge) > lot.cfg.Limit {
+		sort.Slice(byAge, func(i, j int) bool {
+			a, b := lot.parked[byAge[i]], lot.parked[byAge[j]]
+			if !a.since.Equal(b.since) {
+				return a.since.Before(b.since)
+			}
+			// the farthest nonce of the oldest is the least likely to be executed
+			return byAge[i].nonceDistance > byAge[j].nonceDistance
+		})
+		overflow = byAge[:len(byAge)-lot.cfg.Limit]
+	}
+	for _, mt := range expired {
+		parkingExpired(lot.parked[mt].cause).Inc()
+		p.unparkDiscardLocked(mt, txpoolcfg.ParkingExpired, logger)
+	}
+	for _, mt := range overflow {
+		parkingEvicted(lot.parked[mt].cause).Inc()
+		p.unparkDiscardLocked(mt, txpoolcfg.ParkingOverflow, logger)
+	}
+
+	counts := map[parkingCause]int{}
+	for _, parked := range lot.parked {
+		counts[parked.cause]++
+	}
+	for _, cause := range parkingCauses {
+		parkedGauge(cause).SetInt(counts[cause])
+	}
+}
+
+func (p *TxPool) unparkDiscardLocked(mt *metaTxn, reason txpoolcfg.DiscardReason, logger log.Logger) {
+	cause := p.parking.parked[mt].cause
+	delete(p.parking.parked, mt)
+	p.queued.Remove(mt, "parking", logger)
+	p.discardLocked(mt, reason)
+	if mt.TxnSlot.Traced {
+		logger.Info(fmt.Sprintf("TX TRACING: discarded parked txn idHash=%x cause=%s reason=%s", mt.TxnSlot.IDHash, cause, reason))
+	}
+}
+
+// ParkedCount - amount of the tracked parked transactions by cause, nil if parking is disabled
+func (p *TxPool) ParkedCount() map[string]int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.parking == nil {
+		return nil
+	}
+	counts := map[string]int{}
+	for _, parked := range p.parking.parked {
+		counts[parked.cause.String()]++
+	}
+	return counts
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+const (
+	// penaltyHalfLife - penalty points of a peer halve within it
+	penaltyHalfLife = time.Minute
+	// peerIngestionIdle - accounting of a peer without messages for longer is forgotten
+	peerIngestionIdle = 10 * time.Minute
+)
+
+// penaltyCause - why penalty points are given to a peer
+type penaltyCause uint8
+
+const (
+	penaltyMalformed   penaltyCause = iota + 1 // transactions message which can't be parsed
+	penaltyInvalidTxn                          // transaction which can't be valid whatever the state, see invalidTxn
+	penaltyRateLimited                         // transactions message above the rate limit of the peer
+)
+
+func (c penaltyCause) String() string {
+	switch c {
+	case penaltyMalformed:
+		return "malformed"
+	case penaltyInvalidTxn:
+		return "invalid_txn"
+	case penaltyRateLimited:
+		return "rate_limited"
+	}
+	return fmt.Sprintf("unknown:%d", uint8(c))
+}
+
+func (c penaltyCause) points() float64 {
+	if c == penaltyMalformed {
+		return 10
+	}
+	return 1
+}
+
+// invalidTxn - the sender of the transaction couldn't think it's valid: unlike the fees, the nonce or the balance,
+// it doesn't depend on the state of the pool or of the chain
+func invalidTxn(reason txpoolcfg.DiscardReason) bool {
+	switch reason {
+	case txpoolcfg.InvalidSender, txpoolcfg.NegativeValue, txpoolcfg.GasUintOverflow, txpoolcfg.IntrinsicGas,
+		txpoolcfg.RLPTooLong, txpoolcfg.InitCodeTooLarge, txpoolcfg.InvalidCreateTxn, txpoolcfg.NoBlobs,
+		txpoolcfg.UnequalBlobTxExt, txpoolcfg.BlobHashCheckFail, txpoolcfg.UnmatchedBlobTxExt,
+		txpoolcfg.NoAuthorizations, txpoolcfg.InvalidAA:
+		return true
+	}
+	return false
+}
+
+type peerIngestionState struct {
+	limiter   *rate.Limiter // nil without the rate limit
+	penalty   float64       // decays by penaltyHalfLife since penalized
+	penalized time.Time
+	seen      time.Time
+	kicked    bool // reported to the sentries, since the penalty reached the max
+}
+
+// peerIngestion - accounting of the remote transactions per sentry peer, see txpoolcfg.PeerIngestion. Transactions
+// of a peer over its rate are dropped. Malformed messages, invalid transactions and messages over the rate give
+// penalty points to the peer: reaching txpoolcfg.PeerIngestion.MaxPenalty, it's penalized in the sentries and its
+// messages are dropped until the points decay. nil is disabled.
+type peerIngestion struct {
+	ctx           context.Context
+	cfg           txpoolcfg.PeerIngestion
+	sentryClients []sentryproto.SentryClient
+	logger        log.Logger
+
+	mu     sync.Mutex
+	peers  map[[64]byte]*peerIngestionState
+	pruned time.Time
+}
+
+func newPeerIngestion(ctx context.Context, cfg txpoolcfg.PeerIngestion, sentryClients []sentryproto.SentryClient, logger log.Logger) *peerIngestion {
+	if cfg == (txpoolcfg.PeerIngestion{}) {
+		return nil
+	}
+	return &peerIngestion{ctx: ctx, cfg: cfg, sentryClients: sentryClients, logger: logger, peers: map[[64]byte]*peerIngestionState{}}
+}
+
+// peerLocked - must be called with pi.mu held
+func (pi *peerIngestion) peerLocked(peerID PeerID, now time.Time) *peerIngestionState {
+	if now.Sub(pi.pruned) > peerIngestionIdle {
+		for id, peer := range pi.peers {
+			if now.Sub(peer.seen) > peerIngestionIdle {
+				delete(pi.peers, id)
+			}
+		}
+		pi.pruned = now
+	}
+	id := gointerfaces.ConvertH512ToHash(peerID)
+	peer, ok := pi.peers[id]
+	if !ok {
+		peer = &peerIngestionState{}
+		if pi.cfg.TxnsPerSecond > 0 {
+			peer.limiter = rate.NewLimiter(rate.Limit(pi.cfg.TxnsPerSecond), max(pi.cfg.TxnsBurst, 1))
+		}
+		pi.peers[id] = peer
+	}
+	peer.seen = now
+	if peer.penalty > 0 {
+		peer.penalty *= math.Exp2(-float64(now.Sub(peer.penalized)) / float64(penaltyHalfLife))
+		peer.penalized = now
+	}
+	if peer.kicked && peer.penalty < float64(pi.cfg.MaxPenalty) {
+		peer.kicked = false
+	}
+	return peer
+}
+
+// accept - whether a transactions message of the peer is processed: it's dropped if the peer reached the max
+// penalty or its rate limit. Transactions of the message are charged by charge after parsing.
+func (pi *peerIngestion) accept(peerID PeerID) bool {
+	if pi == nil || peerID == nil {
+		return true
+	}
+	now := time.Now()
+	pi.mu.Lock()
+	peer := pi.peerLocked(peerID, now)
+	switch {
+	case peer.kicked:
+		pi.mu.Unlock()
+		peerIngestionDropped("penalized").Inc()
+		return false
+	case peer.limiter != nil && peer.limiter.TokensAt(now) < 1:
+		pi.mu.Unlock()
+		peerIngestionDropped("rate_limited").Inc()
+		pi.penalize(peerID, penaltyRateLimited, 1)
+		return false
+	}
+	pi.mu.Unlock()
+	return true
+}
+
+// charge - takes the parsed transactions of an accepted message from the rate of the peer. The message is
+// processed, but the next ones are dropped until the rate restores.
+func (pi *peerIngestion) charge(peerID PeerID, txns int) {
+	if pi == nil || peerID == nil || txns == 0 {
+		return
+	}
+	now := time.Now()
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	if peer := pi.peerLocked(peerID, now); peer.limiter != nil {
+		peer.limiter.ReserveN(now, min(txns, peer.limiter.Burst()))
+	}
+}
+
+// penalize - gives the penalty points of the cause to the peer, `times` times
+func (pi *peerIngestion) penalize(peerID PeerID, cause penaltyCause, times int) {
+	if pi == nil || peerID == nil || times == 0 {
+		return
+	}
+	peerPenalties(cause).AddInt(times)
+	if pi.cfg.MaxPenalty == 0 {
+		return
+	}
+	now := time.Now()
+	pi.mu.Lock()
+	peer := pi.peerLocked(peerID, now)
+	peer.penalty += cause.points() * float64(times)
+	peer.penalized = now
+	kick := !peer.kicked && peer.penalty >= float64(pi.cfg.MaxPenalty)
+	if kick {
+		peer.kicked = true
+	}
+	pi.mu.Unlock()
+	if !kick {
+		return
+	}
+
+	peerPenalized.Inc()
+	pi.logger.Debug("[txpool] penalizing peer", "peer", peerString(peerID), "cause", cause)
+	// called with the pool lock held: don't wait for the sentries
+	go func() {
+		for _, sentryClient := range pi.sentryClients {
+			if _, err := sentryClient.PenalizePeer(pi.ctx, &sentryproto.PenalizePeerRequest{
+				PeerId:  peerID,
+				Penalty: sentryproto.PenaltyKind_Kick,
+			}, &grpc.EmptyCallOption{}); err != nil {
+				pi.logger.Debug("[txpool] penalizing peer", "peer", peerString(peerID), "err", err)
+			}
+		}
+	}()
+}
@@ -30,7 +30,8 @@ import (
 type PendingPool struct {
 	best  *bestSlice
 	worst *WorstQueue
-	limit int
+	limit int // of transactions which aren't blob transactions, see BlobPool
+	blobs int // blob transactions in the sub-pool
 	t     SubPoolType
 }
 
@@ -64,6 +65,22 @@ func (p *PendingPool) PopWorst() *metaTxn { //nolint
 	if i.bestIndex >= 0 {
 		p.best.UnsafeRemove(i)
 	}
+	if i.TxnSlot.Type == BlobTxnType {
+		p.blobs--
+	}
+	return i
+}
+
+// PopWorstNonBlob pops the worst transaction which isn't a blob transaction, there must be one
+func (p *PendingPool) PopWorstNonBlob(logger log.Logger) *metaTxn {
+	var blobTxns []*metaTxn
+	i := p.PopWorst()
+	for ; i.TxnSlot.Type == BlobTxnType; i = p.PopWorst() {
+		blobTxns = append(blobTxns, i)
+	}
+	for _, mt := range blobTxns {
+		p.Add(mt, logger)
+	}
 	return i
 }
 
@@ -75,6 +92,11 @@ func (p *PendingPool) Len() int {
 	return len(p.best.ms)
 }
 
+// NonBlobLen - number of transactions limited by the sub-pool limit
+func (p *PendingPool) NonBlobLen() int {
+	return len(p.best.ms) - p.blobs
+}
+
 func (p *PendingPool) Remove(i *metaTxn, reason string, logger log.Logger) {
 	if i.TxnSlot.Traced {
 		logger.Info(fmt.Sprintf("TX TRACING: removed from subpool %s", p.t), "idHash", fmt.Sprintf("%x", i.TxnSlot.IDHash), "sender", i.TxnSlot.SenderID, "nonce", i.TxnSlot.Nonce, "reason", reason)
@@ -85,6 +107,9 @@ func (p *PendingPool) Remove(i *metaTxn, reason string, logger log.Logger) {
 	if i.bestIndex >= 0 {
 		p.best.UnsafeRemove(i)
 	}
+	if i.TxnSlot.Type == BlobTxnType {
+		p.blobs--
+	}
 	i.currentSubPool = 0
 }
 
@@ -95,6 +120,9 @@ func (p *PendingPool) Add(i *metaTxn, logger log.Logger) {
 	i.currentSubPool = p.t
 	heap.Push(p.worst, i)
 	p.best.UnsafeAdd(i)
+	if i.TxnSlot.Type == BlobTxnType {
+		p.blobs++
+	}
 }
 
 func (p *PendingPool) DebugPrint(prefix string) {
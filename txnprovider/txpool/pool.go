@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -116,6 +117,7 @@ type TxPool struct {
 	pending                 *PendingPool
 	baseFee                 *SubPool
 	queued                  *SubPool
+	blobs                   *BlobPool
 	minedBlobTxnsByBlock    map[uint64][]*metaTxn            // (blockNum => slice): cache of recently mined blobs
 	minedBlobTxnsByHash     map[string]*metaTxn              // (hash => mt): map of recently mined blobs
 	isLocalLRU              *simplelru.LRU[string, struct{}] // txn_hash => is_local : to restore isLocal flag of unwinded transactions
@@ -133,7 +135,6 @@ type TxPool struct {
 	pendingBaseFee          atomic.Uint64
 	pendingBlobFee          atomic.Uint64 // For gas accounting for blobs, which has its own dimension
 	blockGasLimit           atomic.Uint64
-	totalBlobsInPool        atomic.Uint64
 	shanghaiTime            *uint64
 	isPostShanghai          atomic.Bool
 	agraBlock               *uint64
@@ -147,13 +148,25 @@ type TxPool struct {
 	osakaTime               *uint64
 	isPostOsaka             atomic.Bool
 	feeCalculator           FeeCalculator
+	txnSimulator            TxnSimulator // nil if local txns are not simulated
+	prioritySenders         map[common.Address]struct{}
+	serviceTxnChecker       ServiceTxnChecker       // nil if service txns aren't recognized
+	serviceSenders          map[common.Address]bool // checked by serviceTxnChecker since the last block
+	ordering                Ordering                // nil - in the order of the pending sub-pool
 	p2pFetcher              *Fetch
 	p2pSender               *Send
+	propagation             *PropagationTracker // nil if disabled
+	parking                 *parkingLot         // nil if disabled
+	ingestion               *peerIngestion      // nil if disabled
 	newSlotsStreams         *NewSlotsStreams
+	droppedTxnsStreams      *DroppedTxnsStreams       // nil if dropped txns are not streamed
+	droppedTxns             []*txpoolproto.DroppedTxn // dropped since the last broadcast to droppedTxnsStreams
+	droppedTxnsNotify       chan struct{}
 	ethBackend              remote.ETHBACKENDClient
 	builderNotifyNewTxns    func()
 	logger                  log.Logger
 	auths                   map[AuthAndNonce]*metaTxn // All authority accounts with a pooled authorization
+	authNonces              map[string][]uint64       // authority -> nonces of its pooled authorizations
 	blobHashToTxn           map[common.Hash]struct {
 		index   int
 		txnHash common.Hash
@@ -168,6 +181,40 @@ type FeeCalculator interface {
 	CurrentFees(chainConfig *chain.Config, db kv.Getter) (baseFee uint64, blobFee uint64, minBlobGasPrice, blockGasLimit uint64, err error)
 }
 
+// ServiceTxnChecker - recognizes senders of service txns by the state of the latest block, see
+// consensus.Engine.IsServiceTransaction
+type ServiceTxnChecker interface {
+	IsServiceTransaction(ctx context.Context, sender common.Address) (bool, error)
+}
+
+// isPrioritySenderLocked - txns of the sender are offered first and evicted last: it's one of the
+// cfg.PrioritySenders, or a sender of service txns. Service senders are checked once per block.
+func (p *TxPool) isPrioritySenderLocked(sender common.Address) bool {
+	if _, ok := p.prioritySenders[sender]; ok {
+		return true
+	}
+	if p.serviceTxnChecker == nil {
+		return false
+	}
+	if service, ok := p.serviceSenders[sender]; ok {
+		return service
+	}
+	service, err := p.serviceTxnChecker.IsServiceTransaction(context.Background(), sender)
+	if err != nil {
+		p.logger.Debug("[txpool] service txn check failed", "sender", sender, "err", err)
+		return false
+	}
+	p.serviceSenders[sender] = service
+	return service
+}
+
+// TxnSimulator - executes transactions against the latest state
+type TxnSimulator interface {
+	// WouldRevert executes the transaction (TxnSlot.Rlp). Transactions which can't be executed, e.g. because of
+	// insufficient balance, don't revert: validation of the pool decides on them.
+	WouldRevert(ctx context.Context, txnRlp []byte, wrappedWithBlobs bool) (bool, error)
+}
+
 func New(
 	ctx context.Context,
 	newTxns chan Announcements,
@@ -185,6 +232,16 @@ func New(
 	opts ...Option,
 ) (*TxPool, error) {
 	options := applyOpts(opts...)
+	if cfg.SimulateLocalTxns && options.txnSimulator == nil {
+		logger.Warn("[txpool] simulation of local transactions needs the chain state: it's supported only by txpool embedded into erigon")
+	}
+	ordering := options.ordering
+	if ordering == nil {
+		var err error
+		if ordering, err = newOrdering(cfg); err != nil {
+			return nil, err
+		}
+	}
 	localsHistory, err := simplelru.NewLRU[string, struct{}](10_000, nil)
 	if err != nil {
 		return nil, err
@@ -199,11 +256,16 @@ func New(
 		search:            &metaTxn{TxnSlot: &TxnSlot{}},
 		senderIDTxnCount:  map[uint64]int{},
 		senderIDBlobCount: map[uint64]uint64{},
+		senderIDGas:       map[uint64]uint64{},
 	}
 	tracedSenders := make(map[common.Address]struct{})
 	for _, sender := range cfg.TracedSenders {
 		tracedSenders[common.BytesToAddress([]byte(sender))] = struct{}{}
 	}
+	prioritySenders := make(map[common.Address]struct{})
+	for _, sender := range cfg.PrioritySenders {
+		prioritySenders[common.BytesToAddress([]byte(sender))] = struct{}{}
+	}
 
 	configChainID, overflow := uint256.FromBig(chainConfig.ChainID)
 	if overflow {
@@ -223,6 +285,7 @@ func New(
 		pending:                 NewPendingSubPool(PendingSubPool, cfg.PendingSubPoolLimit),
 		baseFee:                 NewSubPool(BaseFeeSubPool, cfg.BaseFeeSubPoolLimit),
 		queued:                  NewSubPool(QueuedSubPool, cfg.QueuedSubPoolLimit),
+		blobs:                   NewBlobPool(cfg.TotalBlobPoolLimit, cfg.BlobMemoryLimit),
 		newPendingTxns:          newTxns,
 		_stateCache:             cache,
 		senders:                 newSendersBatch(tracedSenders),
@@ -236,11 +299,19 @@ func New(
 		minedBlobTxnsByBlock:    map[uint64][]*metaTxn{},
 		minedBlobTxnsByHash:     map[string]*metaTxn{},
 		feeCalculator:           options.feeCalculator,
+		txnSimulator:            options.txnSimulator,
+		prioritySenders:         prioritySenders,
+		serviceTxnChecker:       options.serviceTxnChecker,
+		serviceSenders:          map[common.Address]bool{},
+		ordering:                ordering,
 		ethBackend:              ethBackend,
 		builderNotifyNewTxns:    builderNotifyNewTxns,
 		newSlotsStreams:         newSlotsStreams,
+		droppedTxnsStreams:      options.droppedTxnsStreams,
+		droppedTxnsNotify:       make(chan struct{}, 1),
 		logger:                  logger,
 		auths:                   make(map[AuthAndNonce]*metaTxn),
+		authNonces:              make(map[string][]uint64),
 		blobHashToTxn: make(map[common.Hash]struct {
 			index   int
 			txnHash common.Hash
@@ -294,7 +365,12 @@ func New(
 		res.osakaTime = &osakaTimeU64
 	}
 
+	res.propagation = NewPropagationTracker(cfg.PropagationTraceWindow)
+	res.parking = newParkingLot(cfg.Parking)
 	res.p2pFetcher = NewFetch(ctx, sentryClients, res, stateChangesClient, poolDB, res.chainID, logger, opts...)
+	res.p2pFetcher.propagation = res.propagation
+	res.ingestion = newPeerIngestion(ctx, cfg.PeerIngestion, sentryClients, logger)
+	res.p2pFetcher.ingestion = res.ingestion
 	res.p2pSender = NewSend(ctx, sentryClients, logger, opts...)
 
 	return res, nil
@@ -305,7 +381,7 @@ func (p *TxPool) start(ctx context.Context) error {
 		return nil
 	}
 
-	return p.poolDB.View(ctx, func(tx kv.Tx) error {
+	if err := p.poolDB.View(ctx, func(tx kv.Tx) error {
 		coreDb, _ := p.chainDB()
 		coreTx, err := coreDb.BeginTemporalRo(ctx)
 		if err != nil {
@@ -317,13 +393,16 @@ func (p *TxPool) start(ctx context.Context) error {
 		if err := p.fromDB(ctx, tx, coreTx); err != nil {
 			return fmt.Errorf("loading pool from DB: %w", err)
 		}
-
-		if p.started.CompareAndSwap(false, true) {
-			p.logger.Info("[txpool] Started")
-		}
-
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	p.loadFile(ctx)
+
+	if p.started.CompareAndSwap(false, true) {
+		p.logger.Info("[txpool] Started")
+	}
+	return nil
 }
 
 func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChangeBatch, unwindTxns, unwindBlobTxns, minedTxns TxnSlots) error {
@@ -362,6 +441,9 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 		p.lock.Unlock()
 	}()
 
+	// certification of service senders may change with the state
+	clear(p.serviceSenders)
+
 	pendingPre := p.pending.Len()
 	defer func() {
 
@@ -438,7 +520,7 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 		return err
 	}
 
-	_, unwindTxns, err = p.validateTxns(&unwindTxns, cacheView)
+	_, unwindTxns, err = p.validateTxns(&unwindTxns, cacheView, nil)
 	if err != nil {
 		return err
 	}
@@ -525,10 +607,15 @@ func (p *TxPool) processRemoteTxns(ctx context.Context) (err error) {
 		return err
 	}
 
-	_, newTxns, err := p.validateTxns(p.unprocessedRemoteTxns, cacheView)
+	validationReasons, newTxns, err := p.validateTxns(p.unprocessedRemoteTxns, cacheView, nil)
 	if err != nil {
 		return err
 	}
+	for i, reason := range validationReasons {
+		if invalidTxn(reason) {
+			p.ingestion.penalize(p.unprocessedRemoteTxns.Txns[i].Peer, penaltyInvalidTxn, 1)
+		}
+	}
 
 	diagTxns := make([]diagnostics.DiagTxn, 0, len(newTxns.Txns))
 
@@ -744,7 +831,7 @@ func (p *TxPool) getCachedBlobTxnLocked(tx kv.Tx, hash []byte) (*metaTxn, error)
 	if txn, ok := p.getUnprocessedTxn(hashS); ok {
 		return newMetaTxn(txn, false, 0), nil
 	}
-	if mt, ok := p.byHash[hashS]; ok {
+	if mt, ok := p.byHash[hashS]; ok && !mt.blobsOnDisk {
 		return mt, nil
 	}
 	v, err := tx.GetOne(kv.PoolTransaction, hash)
@@ -786,18 +873,18 @@ func (p *TxPool) best(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availa
 		p.lastSeenCond.Wait()
 	}
 
-	best := p.pending.best
+	best := p.orderedLocked(ctx, p.pending.best.ms)
 
 	isEIP3860 := p.isShanghai() || p.isAgra()
 	isEIP7623 := p.isPrague() || p.isBhilai()
 
-	txns.Resize(uint(min(n, len(best.ms))))
+	txns.Resize(uint(min(n, len(best))))
 	var toRemove []*metaTxn
 	count := 0
 	i := 0
 
 	defer func() {
-		p.logger.Debug("[txpool] Processing best request", "last", onTopOf, "txRequested", n, "txAvailable", len(best.ms), "txProcessed", i, "txReturned", count)
+		p.logger.Debug("[txpool] Processing best request", "last", onTopOf, "txRequested", n, "txAvailable", len(best), "txProcessed", i, "txReturned", count)
 	}()
 
 	tx, err := p.poolDB.BeginRo(ctx)
@@ -806,7 +893,7 @@ func (p *TxPool) best(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availa
 	}
 
 	defer tx.Rollback()
-	for ; count < n && i < len(best.ms); i++ {
+	for ; count < n && i < len(best); i++ {
 		// if we wouldn't have enough gas for a standard transaction then quit out early
 		if availableGas < params.TxGas {
 			break
@@ -815,7 +902,7 @@ func (p *TxPool) best(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availa
 			break
 		}
 
-		mt := best.ms[i]
+		mt := best[i]
 
 		if yielded.Contains(mt.TxnSlot.IDHash) {
 			continue
@@ -1131,8 +1218,7 @@ func (p *TxPool) validateBlobTxn(txn *TxnSlot, isLocal bool) txpoolcfg.DiscardRe
 		}
 	} else {
 		// https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof_batch
-		kzgCtx := libkzg.Ctx()
-		err := kzgCtx.VerifyBlobKZGProofBatch(toBlobs(blobs), commitments, proofs)
+		err := libkzg.ActiveBackend().VerifyBlobKZGProofBatch(toBlobs(blobs), commitments, proofs)
 		if err != nil {
 			return txpoolcfg.UnmatchedBlobTxExt
 		}
@@ -1144,9 +1230,9 @@ func (p *TxPool) validateBlobTxn(txn *TxnSlot, isLocal bool) txpoolcfg.DiscardRe
 		}
 		return txpoolcfg.Spammer
 	}
-	if p.totalBlobsInPool.Load() >= p.cfg.TotalBlobPoolLimit {
+	if !p.blobs.Accepts(txn, isLocal) {
 		if txn.Traced {
-			p.logger.Info(fmt.Sprintf("TX TRACING: validateTx total blobs limit reached in pool limit=%x current blobs=%d", p.cfg.TotalBlobPoolLimit, p.totalBlobsInPool.Load()))
+			p.logger.Info(fmt.Sprintf("TX TRACING: validateTx total blobs limit reached in pool limit=%d current blobs=%d", p.cfg.TotalBlobPoolLimit, p.blobs.Blobs()))
 		}
 		return txpoolcfg.BlobPoolOverflow
 	}
@@ -1307,7 +1393,9 @@ func (p *TxPool) ValidateSerializedTxn(serializedTxn []byte) error {
 	return nil
 }
 
-func (p *TxPool) validateTxns(txns *TxnSlots, stateCache kvcache.CacheView) (reasons []txpoolcfg.DiscardReason, goodTxns TxnSlots, err error) {
+// validateTxns returns the valid txns, and discard reasons of all txns. reverting - txns to discard with WouldRevert,
+// see simulateLocalTxns.
+func (p *TxPool) validateTxns(txns *TxnSlots, stateCache kvcache.CacheView, reverting map[*TxnSlot]struct{}) (reasons []txpoolcfg.DiscardReason, goodTxns TxnSlots, err error) {
 	// reasons is pre-sized for direct indexing, with the default zero
 	// value DiscardReason of NotSet
 	reasons = make([]txpoolcfg.DiscardReason, len(txns.Txns))
@@ -1316,9 +1404,20 @@ func (p *TxPool) validateTxns(txns *TxnSlots, stateCache kvcache.CacheView) (rea
 		return reasons, goodTxns, err
 	}
 
+	var batchUsage map[uint64]senderUsage
+	if p.cfg.SenderPolicy != (txpoolcfg.SenderPolicy{}) {
+		batchUsage = map[uint64]senderUsage{}
+	}
+
 	goodCount := 0
 	for i, txn := range txns.Txns {
 		reason := p.validateTx(txn, txns.IsLocal[i], stateCache)
+		if _, ok := reverting[txn]; ok && reason == txpoolcfg.Success {
+			reason = txpoolcfg.WouldRevert
+		}
+		if reason == txpoolcfg.Success && batchUsage != nil {
+			reason = p.checkSenderPolicy(txn, stateCache, batchUsage)
+		}
 		if reason == txpoolcfg.Success {
 			goodCount++
 			// Success here means no DiscardReason yet, so leave it NotSet
@@ -1344,6 +1443,50 @@ func (p *TxPool) validateTxns(txns *TxnSlots, stateCache kvcache.CacheView) (rea
 	return reasons, goodTxns, nil
 }
 
+// senderUsage - slots and gas taken by the txns of a sender
+type senderUsage struct {
+	slots uint64
+	gas   uint64
+}
+
+// checkSenderPolicy enforces cfg.SenderPolicy on the txn, counting the pooled txns of the sender and the
+// txns of the same batch accepted before it (batchUsage, updated if the txn is accepted)
+func (p *TxPool) checkSenderPolicy(txn *TxnSlot, stateCache kvcache.CacheView, batchUsage map[uint64]senderUsage) txpoolcfg.DiscardReason {
+	policy := p.cfg.SenderPolicy
+	if policy.MaxNonceGap > 0 {
+		senderNonce, _, _ := p.senders.info(stateCache, txn.SenderID)
+		if txn.Nonce > senderNonce && txn.Nonce-senderNonce > policy.MaxNonceGap {
+			if txn.Traced {
+				p.logger.Info(fmt.Sprintf("TX TRACING: checkSenderPolicy nonce gap too large idHash=%x nonce in state=%d, txn.nonce=%d, limit=%d", txn.IDHash, senderNonce, txn.Nonce, policy.MaxNonceGap))
+			}
+			return txpoolcfg.NonceGapTooLarge
+		}
+	}
+
+	// pooled and earlier in the batch, then this txn: it takes the slot of the pooled txn with the same nonce
+	batch := batchUsage[txn.SenderID]
+	slots, gas := uint64(p.all.count(txn.SenderID))+batch.slots, p.all.gas(txn.SenderID)+batch.gas
+	var addSlots, addGas uint64 = 1, txn.Gas
+	if replaced := p.all.get(txn.SenderID, txn.Nonce); replaced != nil {
+		addSlots = 0
+		addGas = txn.Gas - min(txn.Gas, replaced.TxnSlot.Gas)
+	}
+	if policy.MaxSlots > 0 && slots+addSlots > policy.MaxSlots {
+		if txn.Traced {
+			p.logger.Info(fmt.Sprintf("TX TRACING: checkSenderPolicy too many txns idHash=%x slots=%d, limit=%d", txn.IDHash, slots, policy.MaxSlots))
+		}
+		return txpoolcfg.SenderSlotsExceeded
+	}
+	if policy.MaxGas > 0 && gas+addGas > policy.MaxGas {
+		if txn.Traced {
+			p.logger.Info(fmt.Sprintf("TX TRACING: checkSenderPolicy too much gas idHash=%x gas=%d, txn.gas=%d, limit=%d", txn.IDHash, gas, txn.Gas, policy.MaxGas))
+		}
+		return txpoolcfg.SenderGasExceeded
+	}
+	batchUsage[txn.SenderID] = senderUsage{slots: batch.slots + addSlots, gas: batch.gas + addGas}
+	return txpoolcfg.Success
+}
+
 // punishSpammer by drop half of it's transactions with high nonce
 func (p *TxPool) punishSpammer(spammer uint64) {
 	count := p.all.count(spammer) / 2
@@ -1420,6 +1563,16 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 		return nil, err
 	}
 
+	now := time.Now()
+	for _, txn := range newTxns.Txns {
+		p.propagation.Local(txn.IDHash[:], now)
+	}
+
+	var reverting map[*TxnSlot]struct{}
+	if p.cfg.SimulateLocalTxns && p.txnSimulator != nil {
+		reverting = p.simulateLocalTxns(ctx, newTxns)
+	}
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -1427,22 +1580,27 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 		return nil, err
 	}
 
-	reasons, newTxns, err := p.validateTxns(&newTxns, cacheView)
+	reasons, goodTxns, err := p.validateTxns(&newTxns, cacheView, reverting)
 	if err != nil {
 		return nil, err
 	}
 
-	announcements, addReasons, err := p.addTxns(p.lastSeenBlock.Load(), cacheView, p.senders, newTxns,
+	announcements, addReasons, err := p.addTxns(p.lastSeenBlock.Load(), cacheView, p.senders, goodTxns,
 		p.pendingBaseFee.Load(), p.pendingBlobFee.Load(), p.blockGasLimit.Load(), true, p.logger)
-	if err == nil {
-		for i, reason := range addReasons {
-			if reason != txpoolcfg.NotSet {
-				reasons[i] = reason
-			}
-		}
-	} else {
+	if err != nil {
 		return nil, err
 	}
+	// addReasons are indexed by goodTxns: the txns of newTxns without a reason
+	j := 0
+	for i := range reasons {
+		if reasons[i] != txpoolcfg.NotSet {
+			continue
+		}
+		if addReasons[j] != txpoolcfg.NotSet {
+			reasons[i] = addReasons[j]
+		}
+		j++
+	}
 	p.promoted.Reset()
 	p.promoted.AppendOther(announcements)
 
@@ -1465,6 +1623,29 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 	return reasons, nil
 }
 
+// simulateLocalTxns executes the txns against the latest state, without the pool lock: each one on its own, so
+// a txn depending on a previous txn of the same sender may be reported wrongly. Returns the txns which revert.
+func (p *TxPool) simulateLocalTxns(ctx context.Context, txns TxnSlots) map[*TxnSlot]struct{} {
+	reverting := map[*TxnSlot]struct{}{}
+	for _, txn := range txns.Txns {
+		if txn.Type == AATxnType {
+			continue
+		}
+		revert, err := p.txnSimulator.WouldRevert(ctx, txn.Rlp, txn.Type == BlobTxnType)
+		if err != nil {
+			p.logger.Debug("[txpool] simulation of local txn failed", "idHash", hex.EncodeToString(txn.IDHash[:]), "err", err)
+			continue
+		}
+		if revert {
+			if txn.Traced {
+				p.logger.Info(fmt.Sprintf("TX TRACING: simulateLocalTxns reverts idHash=%x", txn.IDHash))
+			}
+			reverting[txn] = struct{}{}
+		}
+	}
+	return reverting
+}
+
 func (p *TxPool) chainDB() (kv.TemporalRoDB, kvcache.Cache) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -1503,6 +1684,7 @@ func (p *TxPool) addTxns(blockNum uint64, cacheView kvcache.CacheView, senders *
 			continue
 		}
 		mt := newMetaTxn(txn, newTxns.IsLocal[i], blockNum)
+		mt.priority = p.isPrioritySenderLocked(newTxns.Senders.AddressAt(i))
 
 		if reason := p.addLocked(mt, &announcements); reason != txpoolcfg.NotSet {
 			discardReasons[i] = reason
@@ -1555,6 +1737,7 @@ func (p *TxPool) addTxnsOnNewBlock(blockNum uint64, cacheView kvcache.CacheView,
 			continue
 		}
 		mt := newMetaTxn(txn, newTxns.IsLocal[i], blockNum)
+		mt.priority = p.isPrioritySenderLocked(newTxns.Senders.AddressAt(i))
 		if reason := p.addLocked(mt, &announcements); reason != txpoolcfg.NotSet {
 			p.discardLocked(mt, reason)
 			continue
@@ -1608,18 +1791,29 @@ func (p *TxPool) setBlobFee(blobFee uint64) {
 func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.DiscardReason {
 	// Insert to pending pool, if pool doesn't have txn with same Nonce and bigger Tip
 	found := p.all.get(mt.TxnSlot.SenderID, mt.TxnSlot.Nonce)
+
+	// Authorization checks go before the replacement: a rejected txn must not discard the one it would replace
+	senderAddr, ok := p.senders.senderID2Addr[mt.TxnSlot.SenderID]
+	if !ok {
+		p.logger.Info("senderID not registered, discarding transaction for safety")
+		return txpoolcfg.InvalidSender
+	}
+	if reason := p.checkAuthoritiesLocked(mt, found, senderAddr); reason != txpoolcfg.NotSet {
+		return reason
+	}
+
 	if found != nil {
 		if found.TxnSlot.Type == BlobTxnType && mt.TxnSlot.Type != BlobTxnType {
 			return txpoolcfg.BlobTxReplace
 		}
-		priceBump := p.cfg.PriceBump
+		priceBump := p.cfg.Replacement.PriceBump
 
 		if mt.TxnSlot.Type == BlobTxnType {
 			//Blob txn threshold checks for replace txn
-			priceBump = p.cfg.BlobPriceBump
+			priceBump = p.cfg.Replacement.BlobPriceBump
 			blobFeeThreshold, overflow := (&uint256.Int{}).MulDivOverflow(
 				&found.TxnSlot.BlobFeeCap,
-				uint256.NewInt(100+priceBump),
+				uint256.NewInt(100+p.cfg.Replacement.BlobFeeBump),
 				uint256.NewInt(100),
 			)
 			if mt.TxnSlot.BlobFeeCap.Lt(blobFeeThreshold) && !overflow {
@@ -1683,7 +1877,7 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 			//already removed
 		}
 
-		p.discardLocked(found, txpoolcfg.ReplacedByHigherTip)
+		p.discardReplacedLocked(found, txpoolcfg.ReplacedByHigherTip, mt)
 	}
 
 	// Don't add blob txn to queued if it's less than current pending blob base fee
@@ -1691,31 +1885,10 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 		return txpoolcfg.FeeTooLow
 	}
 
-	// Do not allow transaction from this same (sender + nonce) if sender has existing pooled authorization as authority
-	senderAddr, ok := p.senders.senderID2Addr[mt.TxnSlot.SenderID]
-	if !ok {
-		p.logger.Info("senderID not registered, discarding transaction for safety")
-		return txpoolcfg.InvalidSender
-	}
-	if _, ok := p.auths[AuthAndNonce{senderAddr.String(), mt.TxnSlot.Nonce}]; ok {
-		return txpoolcfg.ErrAuthorityReserved
-	}
-
-	// Check if we have txn with same authorization in the pool
 	if mt.TxnSlot.Type == SetCodeTxnType {
 		for _, a := range mt.TxnSlot.AuthAndNonces {
-			// Self authorization nonce should be senderNonce + 1
-			if a.authority == senderAddr.String() && a.nonce != mt.TxnSlot.Nonce+1 {
-				p.logger.Debug("Self authorization nonce should be senderNonce + 1", "authority", a.authority, "txn", fmt.Sprintf("%x", mt.TxnSlot.IDHash))
-				return txpoolcfg.NonceTooLow
-			}
-			if _, ok := p.auths[AuthAndNonce{a.authority, a.nonce}]; ok {
-				p.logger.Debug("setCodeTxn ", "DUPLICATE authority", a.authority, "at nonce", a.nonce, "txn", fmt.Sprintf("%x", mt.TxnSlot.IDHash))
-				return txpoolcfg.ErrAuthorityReserved
-			}
-		}
-		for _, a := range mt.TxnSlot.AuthAndNonces {
-			p.auths[AuthAndNonce{a.authority, a.nonce}] = mt
+			p.auths[a] = mt
+			p.authNonces[a.authority] = append(p.authNonces[a.authority], a.nonce)
 		}
 	}
 
@@ -1740,8 +1913,7 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 		},
 	})
 	if mt.TxnSlot.Type == BlobTxnType {
-		t := p.totalBlobsInPool.Load()
-		p.totalBlobsInPool.Store(t + (uint64(len(mt.TxnSlot.BlobHashes))))
+		p.blobs.Add(mt)
 		for i, b := range mt.TxnSlot.BlobHashes {
 			p.blobHashToTxn[b] = struct {
 				index   int
@@ -1758,25 +1930,103 @@ func (p *TxPool) addLocked(mt *metaTxn, announcements *Announcements) txpoolcfg.
 // dropping transaction from all sub-structures and from db
 // Important: don't call it while iterating by all
 func (p *TxPool) discardLocked(mt *metaTxn, reason txpoolcfg.DiscardReason) {
+	p.discardReplacedLocked(mt, reason, nil)
+}
+
+// discardReplacedLocked - discardLocked of the txn replaced by another one with the same sender and nonce,
+// replacedBy is nil if it isn't replaced
+func (p *TxPool) discardReplacedLocked(mt *metaTxn, reason txpoolcfg.DiscardReason, replacedBy *metaTxn) {
+	if p.droppedTxnsStreams != nil {
+		dropped := &txpoolproto.DroppedTxn{
+			Hash:       gointerfaces.ConvertHashToH256(mt.TxnSlot.IDHash),
+			Reason:     reason.String(),
+			ReasonCode: uint32(reason),
+		}
+		if replacedBy != nil {
+			dropped.ReplacedBy = gointerfaces.ConvertHashToH256(replacedBy.TxnSlot.IDHash)
+		}
+		p.droppedTxns = append(p.droppedTxns, dropped)
+		select {
+		case p.droppedTxnsNotify <- struct{}{}:
+		default:
+		}
+	}
 	hashStr := string(mt.TxnSlot.IDHash[:])
 	delete(p.byHash, hashStr)
 	p.deletedTxns = append(p.deletedTxns, mt)
 	p.all.delete(mt, reason, p.logger)
 	p.discardReasonsLRU.Add(hashStr, reason)
 	if mt.TxnSlot.Type == BlobTxnType {
-		t := p.totalBlobsInPool.Load()
-		p.totalBlobsInPool.Store(t - uint64(len(mt.TxnSlot.BlobHashes)))
+		p.blobs.Remove(mt)
 	}
 	if mt.TxnSlot.Type == SetCodeTxnType {
 		for _, a := range mt.TxnSlot.AuthAndNonces {
 			delete(p.auths, a)
+			nonces := p.authNonces[a.authority]
+			if i := slices.Index(nonces, a.nonce); i >= 0 {
+				nonces = slices.Delete(nonces, i, i+1)
+			}
+			if len(nonces) == 0 {
+				delete(p.authNonces, a.authority)
+			} else {
+				p.authNonces[a.authority] = nonces
+			}
 		}
 	}
 }
 
+// broadcastDroppedTxns sends the txns dropped since the last call to the OnDrop subscribers
+func (p *TxPool) broadcastDroppedTxns() {
+	p.lock.Lock()
+	dropped := p.droppedTxns
+	p.droppedTxns = nil
+	p.lock.Unlock()
+	if len(dropped) > 0 {
+		p.droppedTxnsStreams.Broadcast(&txpoolproto.OnDropReply{Txns: dropped}, p.logger)
+	}
+}
+
+// checkAuthoritiesLocked - EIP-7702: a nonce of an account is consumed either by its txn or by an authorization it
+// signed, so only one of them can be pooled. Txn `replaced` (same sender and nonce as mt) is not a conflict.
+func (p *TxPool) checkAuthoritiesLocked(mt, replaced *metaTxn, senderAddr common.Address) txpoolcfg.DiscardReason {
+	// Do not allow transaction from this same (sender + nonce) if sender has existing pooled authorization as authority
+	if owner, ok := p.auths[AuthAndNonce{senderAddr.String(), mt.TxnSlot.Nonce}]; ok && owner != replaced {
+		return txpoolcfg.ErrAuthorityReserved
+	}
+	if mt.TxnSlot.Type != SetCodeTxnType {
+		return txpoolcfg.NotSet
+	}
+	for _, a := range mt.TxnSlot.AuthAndNonces {
+		// Self authorization nonce should be senderNonce + 1
+		if a.authority == senderAddr.String() && a.nonce != mt.TxnSlot.Nonce+1 {
+			p.logger.Debug("Self authorization nonce should be senderNonce + 1", "authority", a.authority, "txn", fmt.Sprintf("%x", mt.TxnSlot.IDHash))
+			return txpoolcfg.NonceTooLow
+		}
+		// Check if we have txn with same authorization in the pool
+		if owner, ok := p.auths[a]; ok && owner != replaced {
+			p.logger.Debug("setCodeTxn ", "DUPLICATE authority", a.authority, "at nonce", a.nonce, "txn", fmt.Sprintf("%x", mt.TxnSlot.IDHash))
+			return txpoolcfg.ErrAuthorityReserved
+		}
+		// Or authority's own txn with the nonce of the authorization
+		if authorityID, ok := p.senders.getID(common.HexToAddress(a.authority)); ok {
+			if pooled := p.all.get(authorityID, a.nonce); pooled != nil && pooled != replaced {
+				p.logger.Debug("setCodeTxn ", "authority has pooled txn", a.authority, "at nonce", a.nonce, "txn", fmt.Sprintf("%x", mt.TxnSlot.IDHash))
+				return txpoolcfg.ErrAuthorityReserved
+			}
+		}
+	}
+	return txpoolcfg.NotSet
+}
+
 func (p *TxPool) getBlobsAndProofByBlobHashLocked(blobHashes []common.Hash) []PoolBlobBundle {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	var tx kv.Tx // to read the blobs dropped from memory
+	defer func() {
+		if tx != nil {
+			tx.Rollback()
+		}
+	}()
 	blobBundles := make([]PoolBlobBundle, len(blobHashes))
 	for i, h := range blobHashes {
 		th, ok := p.blobHashToTxn[h]
@@ -1787,7 +2037,19 @@ func (p *TxPool) getBlobsAndProofByBlobHashLocked(blobHashes []common.Hash) []Po
 		if !ok || mt == nil {
 			continue
 		}
-		blobBundles[i] = mt.TxnSlot.BlobBundles[th.index]
+		if mt.blobsOnDisk && tx == nil {
+			var err error
+			if tx, err = p.poolDB.BeginRo(context.Background()); err != nil {
+				p.logger.Warn("[txpool] GetBlobs: pool db", "err", err)
+				return blobBundles
+			}
+		}
+		bundles, err := p.blobBundlesLocked(tx, mt)
+		if err != nil || th.index >= len(bundles) {
+			p.logger.Warn("[txpool] GetBlobs", "hash", h, "err", err)
+			continue
+		}
+		blobBundles[i] = bundles[th.index]
 	}
 	return blobBundles
 }
@@ -1842,11 +2104,44 @@ func (p *TxPool) deleteMinedBlobTxn(hash string) {
 func (p *TxPool) NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
-	senderID, found := p.senders.getID(addr)
-	if !found {
-		return 0, false
+	if senderID, found := p.senders.getID(addr); found {
+		nonce, inPool = p.all.nonce(senderID)
+	}
+	// pooled EIP-7702 authorizations consume nonces of the authority too
+	for _, authNonce := range p.authNonces[common.Address(addr).String()] {
+		if !inPool || authNonce > nonce {
+			nonce, inPool = authNonce, true
+		}
 	}
-	return p.all.nonce(senderID)
+	return nonce, inPool
+}
+
+// SetSenderPolicy changes the per-sender limits, they apply to the txns added after the call:
+// the already pooled txns are kept.
+func (p *TxPool) SetSenderPolicy(policy txpoolcfg.SenderPolicy) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.cfg.SenderPolicy = policy
+}
+
+func (p *TxPool) SenderPolicy() txpoolcfg.SenderPolicy {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.cfg.SenderPolicy
+}
+
+// SetReplacementPolicy changes the price bumps needed to replace a pooled txn, they apply to the replacements
+// added after the call
+func (p *TxPool) SetReplacementPolicy(policy txpoolcfg.ReplacementPolicy) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.cfg.Replacement = policy
+}
+
+func (p *TxPool) ReplacementPolicy() txpoolcfg.ReplacementPolicy {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.cfg.Replacement
 }
 
 // removeMined - apply new highest block (or batch of blocks)
@@ -2133,10 +2428,13 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 	// Discard worst transactions from the queued sub pool if they do not qualify
 	// <FUNCTIONALITY REMOVED>
 
+	// Track the transactions parked in the queued sub pool, discard the expired ones and the ones above the limit
+	p.updateParkingLocked(time.Now(), logger)
+
 	// Discard worst transactions from pending pool until it is within capacity limit
-	for p.pending.Len() > p.pending.limit {
-		tx := p.pending.PopWorst()
-		p.discardLocked(p.pending.PopWorst(), txpoolcfg.PendingPoolOverflow)
+	for p.pending.NonBlobLen() > p.pending.limit {
+		tx := p.pending.PopWorstNonBlob(logger)
+		p.discardLocked(tx, txpoolcfg.PendingPoolOverflow)
 		sendChangeBatchEventToDiagnostics("Pending", "remove", []diagnostics.TxnHashOrder{
 			{
 				OrderMarker: uint8(tx.subPool),
@@ -2146,8 +2444,8 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 	}
 
 	// Discard worst transactions from pending sub pool until it is within capacity limits
-	for p.baseFee.Len() > p.baseFee.limit {
-		tx := p.baseFee.PopWorst()
+	for p.baseFee.NonBlobLen() > p.baseFee.limit {
+		tx := p.baseFee.PopWorstNonBlob(logger)
 		p.discardLocked(tx, txpoolcfg.BaseFeePoolOverflow)
 		sendChangeBatchEventToDiagnostics("BaseFee", "remove", []diagnostics.TxnHashOrder{
 			{
@@ -2158,8 +2456,8 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 	}
 
 	// Discard worst transactions from the queued sub pool until it is within its capacity limits
-	for _ = p.queued.Worst(); p.queued.Len() > p.queued.limit; _ = p.queued.Worst() {
-		tx := p.queued.PopWorst()
+	for p.queued.NonBlobLen() > p.queued.limit {
+		tx := p.queued.PopWorstNonBlob(logger)
 		p.discardLocked(tx, txpoolcfg.QueuedPoolOverflow)
 		sendChangeBatchEventToDiagnostics("Queued", "remove", []diagnostics.TxnHashOrder{
 			{
@@ -2168,6 +2466,9 @@ func (p *TxPool) promote(pendingBaseFee uint64, pendingBlobFee uint64, announcem
 			},
 		})
 	}
+
+	// Discard worst blob transactions until the blob sub-pool is within its limit
+	p.evictBlobTxnsLocked()
 }
 
 // Run - does:
@@ -2192,6 +2493,18 @@ func (p *TxPool) Run(ctx context.Context) error {
 	defer commitEvery.Stop()
 	logEvery := time.NewTicker(p.cfg.LogEvery)
 	defer logEvery.Stop()
+	var feeMarketEvery <-chan time.Time
+	if p.cfg.FeeMarketHistoryEvery > 0 {
+		feeMarketTicker := time.NewTicker(p.cfg.FeeMarketHistoryEvery)
+		defer feeMarketTicker.Stop()
+		feeMarketEvery = feeMarketTicker.C
+	}
+	var reapQueuedEvery <-chan time.Time
+	if p.cfg.QueuedLifetime > 0 {
+		reapQueuedTicker := time.NewTicker(queuedReapEvery(p.cfg.QueuedLifetime))
+		defer reapQueuedTicker.Stop()
+		reapQueuedEvery = reapQueuedTicker.C
+	}
 
 	if err := p.start(ctx); err != nil {
 		p.logger.Error("[txpool] Failed to start", "err", err)
@@ -2206,9 +2519,22 @@ func (p *TxPool) Run(ctx context.Context) error {
 			if flushErr != nil {
 				err = fmt.Errorf("%w: %w", flushErr, err)
 			}
+			if dumpErr := p.dumpFile(context.Background()); dumpErr != nil {
+				err = fmt.Errorf("%w: %w", dumpErr, err)
+			}
 			return err
 		case <-logEvery.C:
 			p.logStats()
+		case <-p.droppedTxnsNotify:
+			p.broadcastDroppedTxns()
+		case <-feeMarketEvery:
+			if err := p.recordFeeMarketSnapshot(ctx, p.cfg.FeeMarketHistoryLimit); err != nil {
+				p.logger.Warn("[txpool] record fee market snapshot", "err", err)
+			}
+		case <-reapQueuedEvery:
+			if p.Started() {
+				p.reapQueued(time.Now())
+			}
 		case <-processRemoteTxnsEvery.C:
 			if !p.Started() {
 				continue
@@ -2270,6 +2596,7 @@ func (p *TxPool) Run(ctx context.Context) error {
 				var remoteTxnHashes Hashes
 				var remoteTxnRlps [][]byte
 				var broadcastHashes Hashes
+				var remoteBroadcastHashes Hashes
 				slotsRlp := make([][]byte, 0, announcements.Len())
 
 				if err := p.poolDB.View(ctx, func(tx kv.Tx) error {
@@ -2308,6 +2635,7 @@ func (p *TxPool) Run(ctx context.Context) error {
 							// "Nodes MUST NOT automatically broadcast blob transactions to their peers" - EIP-4844
 							if t != BlobTxnType && len(slotRlp) < txMaxBroadcastSize {
 								remoteTxnRlps = append(remoteTxnRlps, slotRlp)
+								remoteBroadcastHashes = append(remoteBroadcastHashes, hash...)
 							}
 						}
 					}
@@ -2327,6 +2655,9 @@ func (p *TxPool) Run(ctx context.Context) error {
 					p.logger.Trace("Local txn broadcast", "txHash", hex.EncodeToString(broadcastHashes.At(i)), "to peer", peer)
 				}
 				hashSentTo := p.p2pSender.AnnouncePooledTxns(localTxnTypes, localTxnSizes, localTxnHashes, localTxnsBroadcastMaxPeers*2)
+				now := time.Now()
+				p.propagation.Sent(broadcastHashes, txnSentTo, PropagationTransaction, now)
+				p.propagation.Sent(localTxnHashes, hashSentTo, PropagationAnnouncement, now)
 				for i := 0; i < localTxnHashes.Len(); i++ {
 					hash := localTxnHashes.At(i)
 					p.logger.Trace("Local txn announced", "txHash", hex.EncodeToString(hash), "to peer", hashSentTo[i], "baseFee", p.pendingBaseFee.Load())
@@ -2334,8 +2665,10 @@ func (p *TxPool) Run(ctx context.Context) error {
 
 				// broadcast remote transactions
 				const remoteTxnsBroadcastMaxPeers uint64 = 3
-				p.p2pSender.BroadcastPooledTxns(remoteTxnRlps, remoteTxnsBroadcastMaxPeers)
-				p.p2pSender.AnnouncePooledTxns(remoteTxnTypes, remoteTxnSizes, remoteTxnHashes, remoteTxnsBroadcastMaxPeers*2)
+				txnSentTo = p.p2pSender.BroadcastPooledTxns(remoteTxnRlps, remoteTxnsBroadcastMaxPeers)
+				hashSentTo = p.p2pSender.AnnouncePooledTxns(remoteTxnTypes, remoteTxnSizes, remoteTxnHashes, remoteTxnsBroadcastMaxPeers*2)
+				p.propagation.Sent(remoteBroadcastHashes, txnSentTo, PropagationTransaction, now)
+				p.propagation.Sent(remoteTxnHashes, hashSentTo, PropagationAnnouncement, now)
 			}()
 		case <-syncToNewPeersEvery.C: // new peer
 			newPeers := p.recentlyConnectedPeers.GetAndClean()
@@ -2471,6 +2804,7 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 		}
 		metaTx.TxnSlot.Rlp = nil
 	}
+	p.offloadBlobsLocked()
 
 	binary.BigEndian.PutUint64(encID, p.pendingBaseFee.Load())
 	if err := tx.Put(kv.PoolInfo, PoolPendingBaseFeeKey, encID); err != nil {
@@ -2657,6 +2991,7 @@ func (p *TxPool) logStats() {
 		"pending", p.pending.Len(),
 		"baseFee", p.baseFee.Len(),
 		"queued", p.queued.Len(),
+		"blobTxns", p.blobs.Len(),
 	}
 	cacheKeys := p._stateCache.Len()
 	if cacheKeys > 0 {
@@ -2666,23 +3001,88 @@ func (p *TxPool) logStats() {
 	pendingSubCounter.SetInt(p.pending.Len())
 	basefeeSubCounter.SetInt(p.baseFee.Len())
 	queuedSubCounter.SetInt(p.queued.Len())
+	blobTxnsCounter.SetInt(p.blobs.Len())
+	blobsInMemoryCounter.SetUint64(p.blobs.InMemory())
+	blobsOnDiskCounter.SetUint64(p.blobs.Blobs() - p.blobs.InMemory())
 }
 
-// Deprecated need switch to streaming-like
-func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx) {
+// allQuery - page of the pooled transactions of the All call, see txpoolproto.AllRequest. nil filters match all
+// transactions
+type allQuery struct {
+	fromSenderID, fromNonce uint64 // position of the page: the next one of the previous page
+	limit                   int    // 0 - no limit
+	senders                 map[common.Address]struct{}
+	subPools                map[SubPoolType]struct{}
+	types                   map[byte]struct{}
+}
+
+func (q *allQuery) match(mt *metaTxn) bool {
+	if q.subPools != nil {
+		if _, ok := q.subPools[mt.currentSubPool]; !ok {
+			return false
+		}
+	}
+	if q.types != nil {
+		if _, ok := q.types[mt.TxnSlot.Type]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// forEachPage calls f for the pooled transactions of the page, ordered by sender id and nonce. Returns the position
+// of the next page, more=false if this page is the last one. Transactions added or removed between the pages may
+// be missed.
+func (p *TxPool) forEachPage(q allQuery, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx) (nextSenderID, nextNonce uint64, more bool) {
 	var txns []*metaTxn
 	var senders []common.Address
 
 	p.lock.Lock()
 
-	p.all.ascendAll(func(mt *metaTxn) bool {
-		if sender, found := p.senders.senderID2Addr[mt.TxnSlot.SenderID]; found {
-			txns = append(txns, mt)
-			senders = append(senders, sender)
+	visit := func(mt *metaTxn) bool {
+		if !q.match(mt) {
+			return true
 		}
-
+		sender, found := p.senders.senderID2Addr[mt.TxnSlot.SenderID]
+		if !found {
+			return true
+		}
+		if q.limit > 0 && len(txns) == q.limit {
+			nextSenderID, nextNonce, more = mt.TxnSlot.SenderID, mt.TxnSlot.Nonce, true
+			return false
+		}
+		txns = append(txns, mt)
+		senders = append(senders, sender)
 		return true
-	})
+	}
+	if q.senders == nil {
+		p.all.ascendFrom(q.fromSenderID, q.fromNonce, visit)
+	} else {
+		senderIDs := make([]uint64, 0, len(q.senders))
+		for addr := range q.senders {
+			if id, ok := p.senders.getID(addr); ok && id >= q.fromSenderID {
+				senderIDs = append(senderIDs, id)
+			}
+		}
+		slices.Sort(senderIDs)
+		for _, id := range senderIDs {
+			var fromNonce uint64
+			if id == q.fromSenderID {
+				fromNonce = q.fromNonce
+			}
+			stopped := false
+			p.all.ascendFrom(id, fromNonce, func(mt *metaTxn) bool {
+				if mt.TxnSlot.SenderID != id {
+					return false
+				}
+				stopped = !visit(mt)
+				return !stopped
+			})
+			if stopped {
+				break
+			}
+		}
+	}
 
 	p.lock.Unlock()
 
@@ -2703,6 +3103,7 @@ func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender
 
 		f(slotRlp, senders[i], txns[i].currentSubPool)
 	}
+	return nextSenderID, nextNonce, more
 }
 
 func sendChangeBatchEventToDiagnostics(pool string, event string, orderHashes []diagnostics.TxnHashOrder) {
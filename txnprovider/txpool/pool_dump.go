@@ -0,0 +1,326 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// DumpFile - file of txpoolcfg.Config.DBDir the pool is dumped to on shutdown and loaded from on startup, unless
+// txpoolcfg.Config.NoDump
+const DumpFile = "pool.dump"
+
+// dumpVersion - version of the dump format, a dump of another version isn't loaded:
+//
+//	magic [4]byte, version uint32, block uint64
+//	senders uint32, then per sender: address [20]byte, nonce uint64
+//	txns uint32, then per txn: sender index uint32, nonce uint64, flags byte, rlp length uint32, rlp
+//
+// Integers are big-endian. The nonce of a sender is its state nonce at the block, the rlp of a blob txn is the
+// one with the blobs wrapper.
+const dumpVersion uint32 = 1
+
+var dumpMagic = [4]byte{'t', 'x', 'p', 'd'}
+
+const dumpTxnLocal byte = 1
+
+var ErrDumpVersion = errors.New("unsupported txpool dump")
+
+// maxDumpTxnRlp - bound of the rlp length read from a dump, to not allocate garbage of a corrupted one
+const maxDumpTxnRlp = 16 * 1024 * 1024
+
+type dumpTxn struct {
+	sender uint32
+	nonce  uint64
+	flags  byte
+	rlp    []byte
+}
+
+// Dump writes all pooled transactions of all sub-pools, with their senders and the state nonces of the senders,
+// in the dump format (see dumpVersion). Transactions which rlp isn't known are skipped. Returns the number of
+// dumped transactions.
+func (p *TxPool) Dump(ctx context.Context, w io.Writer) (int, error) {
+	coreDb, cache := p.chainDB()
+	coreTx, err := coreDb.BeginTemporalRo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer coreTx.Rollback()
+	cacheView, err := cache.View(ctx, coreTx)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := p.poolDB.BeginRo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var senders []common.Address
+	var nonces []uint64
+	senderIdx := map[uint64]uint32{}
+	var txns []dumpTxn
+	var iterErr error
+	p.all.ascendAll(func(mt *metaTxn) bool {
+		rlpTxn, sender, _, err := p.getRlpLocked(tx, mt.TxnSlot.IDHash[:])
+		if err != nil {
+			iterErr = err
+			return false
+		}
+		if len(rlpTxn) == 0 {
+			return true
+		}
+		idx, ok := senderIdx[mt.TxnSlot.SenderID]
+		if !ok {
+			nonce, _, err := accountInfo(cacheView, sender)
+			if err != nil {
+				iterErr = err
+				return false
+			}
+			idx = uint32(len(senders))
+			senderIdx[mt.TxnSlot.SenderID] = idx
+			senders = append(senders, sender)
+			nonces = append(nonces, nonce)
+		}
+		var flags byte
+		if mt.subPool&IsLocal != 0 {
+			flags |= dumpTxnLocal
+		}
+		txns = append(txns, dumpTxn{sender: idx, nonce: mt.TxnSlot.Nonce, flags: flags, rlp: rlpTxn})
+		return true
+	})
+	if iterErr != nil {
+		return 0, iterErr
+	}
+
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, 0, 64)
+	buf = append(buf, dumpMagic[:]...)
+	buf = binary.BigEndian.AppendUint32(buf, dumpVersion)
+	buf = binary.BigEndian.AppendUint64(buf, p.lastSeenBlock.Load())
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(senders)))
+	if _, err := bw.Write(buf); err != nil {
+		return 0, err
+	}
+	for i, sender := range senders {
+		buf = append(buf[:0], sender[:]...)
+		buf = binary.BigEndian.AppendUint64(buf, nonces[i])
+		if _, err := bw.Write(buf); err != nil {
+			return 0, err
+		}
+	}
+	buf = binary.BigEndian.AppendUint32(buf[:0], uint32(len(txns)))
+	if _, err := bw.Write(buf); err != nil {
+		return 0, err
+	}
+	for _, txn := range txns {
+		buf = binary.BigEndian.AppendUint32(buf[:0], txn.sender)
+		buf = binary.BigEndian.AppendUint64(buf, txn.nonce)
+		buf = append(buf, txn.flags)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(txn.rlp)))
+		if _, err := bw.Write(buf); err != nil {
+			return 0, err
+		}
+		if _, err := bw.Write(txn.rlp); err != nil {
+			return 0, err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	return len(txns), nil
+}
+
+// Load adds the transactions of a dump written by Dump to the pool, validated against the latest state like the
+// transactions read from the pool db. Transactions with a nonce below the state nonce of their sender are skipped
+// without parsing. Returns the number of added transactions.
+func (p *TxPool) Load(ctx context.Context, r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	var header [20]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return 0, fmt.Errorf("txpool dump header: %w", err)
+	}
+	if [4]byte(header[:4]) != dumpMagic || binary.BigEndian.Uint32(header[4:8]) != dumpVersion {
+		return 0, fmt.Errorf("%w: magic %x, version %d", ErrDumpVersion, header[:4], binary.BigEndian.Uint32(header[4:8]))
+	}
+	block := binary.BigEndian.Uint64(header[8:16])
+	sendersCount := binary.BigEndian.Uint32(header[16:20])
+
+	coreDb, cache := p.chainDB()
+	coreTx, err := coreDb.BeginTemporalRo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer coreTx.Rollback()
+	cacheView, err := cache.View(ctx, coreTx)
+	if err != nil {
+		return 0, err
+	}
+
+	// the dumped state nonces are the current ones if no block was processed since the dump
+	stale := block != p.lastSeenBlock.Load()
+	senders := make([]common.Address, 0, min(sendersCount, 1<<16))
+	nonces := make([]uint64, 0, cap(senders))
+	var entry [28]byte
+	for i := uint32(0); i < sendersCount; i++ {
+		if _, err := io.ReadFull(br, entry[:]); err != nil {
+			return 0, fmt.Errorf("txpool dump senders: %w", err)
+		}
+		sender, nonce := common.Address(entry[:20]), binary.BigEndian.Uint64(entry[20:])
+		if stale {
+			if nonce, _, err = accountInfo(cacheView, sender); err != nil {
+				return 0, err
+			}
+		}
+		senders = append(senders, sender)
+		nonces = append(nonces, nonce)
+	}
+
+	var count [4]byte
+	if _, err := io.ReadFull(br, count[:]); err != nil {
+		return 0, fmt.Errorf("txpool dump txns: %w", err)
+	}
+	txnsCount := binary.BigEndian.Uint32(count[:])
+	parseCtx := NewTxnParseContext(p.chainID)
+	parseCtx.WithSender(false)
+	txns := TxnSlots{}
+	var txnHeader [17]byte
+	for i := uint32(0); i < txnsCount; i++ {
+		if _, err := io.ReadFull(br, txnHeader[:]); err != nil {
+			return 0, fmt.Errorf("txpool dump txns: %w", err)
+		}
+		idx, nonce, flags := binary.BigEndian.Uint32(txnHeader[:4]), binary.BigEndian.Uint64(txnHeader[4:12]), txnHeader[12]
+		rlpLen := binary.BigEndian.Uint32(txnHeader[13:])
+		if idx >= uint32(len(senders)) || rlpLen > maxDumpTxnRlp {
+			return 0, fmt.Errorf("txpool dump txns: corrupted txn %d", i)
+		}
+		if nonce < nonces[idx] { // mined since the dump
+			if _, err := br.Discard(int(rlpLen)); err != nil {
+				return 0, fmt.Errorf("txpool dump txns: %w", err)
+			}
+			continue
+		}
+		txnRlp := make([]byte, rlpLen)
+		if _, err := io.ReadFull(br, txnRlp); err != nil {
+			return 0, fmt.Errorf("txpool dump txns: %w", err)
+		}
+		txn := &TxnSlot{}
+		if _, err := parseCtx.ParseTransaction(txnRlp, 0, txn, nil, false /* hasEnvelope */, true /* wrappedWithBlobs */, nil); err != nil {
+			p.logger.Warn("[txpool] load dump: parseTransaction", "err", err)
+			continue
+		}
+		isLocal := flags&dumpTxnLocal != 0
+		if isLocal {
+			p.isLocalLRU.Add(string(txn.IDHash[:]), struct{}{})
+		}
+		txns.Append(txn, senders[idx][:], isLocal)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err := p.senders.registerNewSenders(&txns, p.logger); err != nil {
+		return 0, err
+	}
+	_, goodTxns, err := p.validateTxns(&txns, cacheView, nil)
+	if err != nil {
+		return 0, err
+	}
+	_, reasons, err := p.addTxns(p.lastSeenBlock.Load(), cacheView, p.senders, goodTxns,
+		p.pendingBaseFee.Load(), p.pendingBlobFee.Load(), p.blockGasLimit.Load(), false, p.logger)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, reason := range reasons {
+		if reason == txpoolcfg.NotSet {
+			added++
+		}
+	}
+	return added, nil
+}
+
+// dumpFile dumps the pool to DumpFile: to a temporary file first, so a failed dump doesn't replace a previous one
+func (p *TxPool) dumpFile(ctx context.Context) error {
+	if p.cfg.NoDump || p.cfg.DBDir == "" {
+		return nil
+	}
+	t := time.Now()
+	path := filepath.Join(p.cfg.DBDir, DumpFile)
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	n, err := p.Dump(ctx, f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("txpool dump: %w", err)
+	}
+	p.logger.Info("[txpool] dumped", "txns", n, "file", path, "in", time.Since(t))
+	return nil
+}
+
+// loadFile loads the pool from DumpFile and removes it: pool changes after the load are in the pool db. A dump
+// which can't be loaded is reported and removed, it doesn't fail the start.
+func (p *TxPool) loadFile(ctx context.Context) {
+	if p.cfg.NoDump || p.cfg.DBDir == "" {
+		return
+	}
+	t := time.Now()
+	path := filepath.Join(p.cfg.DBDir, DumpFile)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		p.logger.Warn("[txpool] load dump", "file", path, "err", err)
+		return
+	}
+	n, err := p.Load(ctx, f)
+	f.Close()
+	if err != nil {
+		p.logger.Warn("[txpool] load dump", "file", path, "err", err)
+	} else {
+		p.logger.Info("[txpool] loaded dump", "txns", n, "file", path, "in", time.Since(t))
+	}
+	if err := os.Remove(path); err != nil {
+		p.logger.Warn("[txpool] remove dump", "file", path, "err", err)
+	}
+}
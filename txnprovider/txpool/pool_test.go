@@ -21,23 +21,29 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"path/filepath"
 	"testing"
+	"time"
 
 	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
 	"github.com/holiman/uint256"
 	"github.com/jinzhu/copier"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/kv/memdb"
@@ -47,6 +53,7 @@ import (
 	"github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon-lib/types"
 	accounts3 "github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon-lib/types/testdata"
 	"github.com/erigontech/erigon/execution/testutil"
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 )
@@ -190,6 +197,7 @@ func TestMultipleAuthorizations(t *testing.T) {
 		tipcap         uint64
 		expectedReason txpoolcfg.DiscardReason
 		replacedAuth   *AuthAndNonce
+		keptAuth       *AuthAndNonce
 	}{
 		{
 			title:          "a setcode txn with sender=A and authority=B",
@@ -304,6 +312,37 @@ func TestMultipleAuthorizations(t *testing.T) {
 			tipcap:         100_000,
 			expectedReason: txpoolcfg.Success,
 		},
+		{
+			title:          "A sends setcode txn with B's authorization at nonce of B's pooled txn",
+			sender:         addrA,
+			senderNonce:    3,
+			authority:      &addrB,
+			authNonce:      1,
+			feecap:         100_000,
+			tipcap:         100_000,
+			expectedReason: txpoolcfg.ErrAuthorityReserved,
+		},
+		{
+			title:          "rejected replacement of B's setcode txn (A's authorization at nonce of A's pooled txn) keeps the replaced txn",
+			sender:         addrB,
+			senderNonce:    3,
+			authority:      &addrA,
+			authNonce:      1,
+			feecap:         200_000,
+			tipcap:         200_000,
+			expectedReason: txpoolcfg.ErrAuthorityReserved,
+			keptAuth:       &AuthAndNonce{addrB.String(), 4},
+		},
+		{
+			title:          "replace B's setcode txn with the same authorization and higher tipcap",
+			sender:         addrB,
+			senderNonce:    3,
+			authority:      &addrB,
+			authNonce:      4,
+			feecap:         200_000,
+			tipcap:         200_000,
+			expectedReason: txpoolcfg.Success,
+		},
 	}
 
 	ch := make(chan Announcements, 100)
@@ -384,8 +423,30 @@ func TestMultipleAuthorizations(t *testing.T) {
 				_, ok := pool.auths[*c.replacedAuth]
 				assert.False(t, ok)
 			}
+			if c.keptAuth != nil {
+				_, ok := pool.auths[*c.keptAuth]
+				assert.True(t, ok)
+			}
 		})
 	}
+
+	// B's highest pooled txn nonce is 3, its pooled authorization consumes nonce 4
+	nonce, inPool := pool.NonceFromAddress(addrB)
+	assert.True(t, inPool)
+	assert.Equal(t, uint64(4), nonce)
+	// authority without pooled txns
+	addrC := common.HexToAddress("0xc")
+	_, inPool = pool.NonceFromAddress(addrC)
+	assert.False(t, inPool)
+	var txnSlots TxnSlots
+	txnSlots.Append(&TxnSlot{Tip: *uint256.NewInt(100_000), FeeCap: *uint256.NewInt(100_000), Gas: 100000, Nonce: 4,
+		Type: SetCodeTxnType, AuthAndNonces: []AuthAndNonce{{addrC.String(), 7}}, IDHash: [32]byte{0xcc}}, addrA[:], true)
+	reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(t, err)
+	require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	nonce, inPool = pool.NonceFromAddress(addrC)
+	assert.True(t, inPool)
+	assert.Equal(t, uint64(7), nonce)
 }
 
 func TestRecoverSignerFromRLP_ValidData(t *testing.T) {
@@ -553,6 +614,542 @@ func TestReplaceWithHigherFee(t *testing.T) {
 	}
 }
 
+type droppedTxnsStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies []*txpoolproto.OnDropReply
+}
+
+func (s *droppedTxnsStream) Send(reply *txpoolproto.OnDropReply) error {
+	s.replies = append(s.replies, reply)
+	return nil
+}
+func (s *droppedTxnsStream) Context() context.Context { return s.ctx }
+
+func TestDroppedTxnsStream(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	streams := &DroppedTxnsStreams{}
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil), WithDroppedTxnsStreams(streams))
+	require.NoError(err)
+	stream := &droppedTxnsStream{ctx: ctx}
+	remove := streams.Add(stream)
+	defer remove()
+
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{
+		Nonce:       2,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	add := func(id byte, fee uint64) {
+		var txnSlots TxnSlots
+		txnSlot := &TxnSlot{
+			Tip:    *uint256.NewInt(fee),
+			FeeCap: *uint256.NewInt(fee),
+			Gas:    100000,
+			Nonce:  3,
+		}
+		txnSlot.IDHash[0] = id
+		txnSlots.Append(txnSlot, addr[:], true)
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	}
+	add(1, 300000)
+	pool.broadcastDroppedTxns()
+	assert.Empty(stream.replies)
+
+	add(2, 330000)
+	pool.broadcastDroppedTxns()
+	require.Len(stream.replies, 1)
+	require.Len(stream.replies[0].Txns, 1)
+	dropped := stream.replies[0].Txns[0]
+	assert.Equal(common.Hash{1}, common.Hash(gointerfaces.ConvertH256ToHash(dropped.Hash)))
+	assert.Equal(common.Hash{2}, common.Hash(gointerfaces.ConvertH256ToHash(dropped.ReplacedBy)))
+	assert.Equal(txpoolcfg.ReplacedByHigherTip.String(), dropped.Reason)
+	assert.Equal(uint32(txpoolcfg.ReplacedByHigherTip), dropped.ReasonCode)
+}
+
+func TestFindUnknown(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+	server := NewGrpcServer(ctx, pool, db, nil, nil, *uint256.NewInt(1), log.New())
+
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{
+		Nonce:       2,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	// txn 1 is replaced by txn 2: both are known
+	for _, txn := range []struct {
+		id  byte
+		fee uint64
+	}{{1, 300000}, {2, 330000}} {
+		var txnSlots TxnSlots
+		txnSlot := &TxnSlot{
+			Tip:    *uint256.NewInt(txn.fee),
+			FeeCap: *uint256.NewInt(txn.fee),
+			Gas:    100000,
+			Nonce:  3,
+		}
+		txnSlot.IDHash[0] = txn.id
+		txnSlots.Append(txnSlot, addr[:], true)
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	}
+
+	reply, err := server.FindUnknown(ctx, &txpoolproto.TxHashes{Hashes: []*typesproto.H256{
+		gointerfaces.ConvertHashToH256(common.Hash{4}),
+		gointerfaces.ConvertHashToH256(common.Hash{1}),
+		gointerfaces.ConvertHashToH256(common.Hash{2}),
+		gointerfaces.ConvertHashToH256(common.Hash{3}),
+	}})
+	require.NoError(err)
+	unknown := make([]common.Hash, len(reply.Hashes))
+	for i, h := range reply.Hashes {
+		unknown[i] = gointerfaces.ConvertH256ToHash(h)
+	}
+	assert.Equal([]common.Hash{{4}, {3}}, unknown)
+}
+
+func TestSenderPolicy(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	cfg.SenderPolicy = txpoolcfg.SenderPolicy{MaxSlots: 2, MaxGas: 250000, MaxNonceGap: 3}
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{
+		Nonce:       2,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	var id byte
+	add := func(fee uint64, nonces ...uint64) []txpoolcfg.DiscardReason {
+		var txnSlots TxnSlots
+		for _, nonce := range nonces {
+			id++
+			txnSlot := &TxnSlot{
+				Tip:    *uint256.NewInt(fee),
+				FeeCap: *uint256.NewInt(fee),
+				Gas:    100000,
+				Nonce:  nonce,
+			}
+			txnSlot.IDHash[0] = id
+			txnSlots.Append(txnSlot, addr[:], true)
+		}
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		return reasons
+	}
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.NonceGapTooLarge}, add(300000, 6))
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, add(300000, 2))
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, add(300000, 3))
+	// the replacement takes the slot of the replaced txn
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, add(330000, 3))
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.SenderSlotsExceeded}, add(300000, 4))
+
+	// the txns of the batch are counted together
+	pool.SetSenderPolicy(txpoolcfg.SenderPolicy{MaxSlots: 3})
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success, txpoolcfg.SenderSlotsExceeded}, add(300000, 4, 5))
+
+	pool.SetSenderPolicy(txpoolcfg.SenderPolicy{MaxGas: 350000})
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.SenderGasExceeded}, add(300000, 5))
+	senderID, ok := pool.senders.getID(addr)
+	require.True(ok)
+	assert.Equal(uint64(300000), pool.all.gas(senderID))
+
+	pool.SetSenderPolicy(txpoolcfg.SenderPolicy{})
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, add(300000, 5))
+	assert.Equal(txpoolcfg.SenderPolicy{}, pool.SenderPolicy())
+}
+
+// revertingSimulator - the txns with rlp in reverts revert
+type revertingSimulator struct {
+	reverts map[byte]bool
+}
+
+func (s revertingSimulator) WouldRevert(_ context.Context, txnRlp []byte, _ bool) (bool, error) {
+	return s.reverts[txnRlp[0]], nil
+}
+
+func TestSimulateLocalTxns(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	cfg.SimulateLocalTxns = true
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	simulator := revertingSimulator{reverts: map[byte]bool{2: true}}
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(),
+		WithFeeCalculator(nil), WithTxnSimulator(simulator))
+	require.NoError(err)
+
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{
+		Nonce:       2,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	var txnSlots TxnSlots
+	for id := byte(1); id <= 3; id++ {
+		txnSlot := &TxnSlot{
+			Tip:    *uint256.NewInt(300000),
+			FeeCap: *uint256.NewInt(300000),
+			Gas:    100000,
+			Nonce:  uint64(id) + 1,
+			Rlp:    []byte{id},
+		}
+		txnSlot.IDHash[0] = id
+		txnSlots.Append(txnSlot, addr[:], true)
+	}
+	reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(err)
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success, txpoolcfg.WouldRevert, txpoolcfg.Success}, reasons)
+	assert.Equal(txpoolproto.ImportResult_WOULD_REVERT, mapDiscardReasonToProto(reasons[1]))
+	senderID, ok := pool.senders.getID(addr)
+	require.True(ok)
+	assert.Equal(2, pool.all.count(senderID))
+}
+
+func TestParking(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	cfg.Parking = txpoolcfg.Parking{Limit: 2, MaxAge: time.Hour}
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{
+		Nonce:       2,
+		Balance:     *uint256.NewInt(1 * common.Ether),
+		CodeHash:    common.Hash{},
+		Incarnation: 1,
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	var id byte
+	add := func(nonces ...uint64) []txpoolcfg.DiscardReason {
+		var txnSlots TxnSlots
+		for _, nonce := range nonces {
+			id++
+			txnSlot := &TxnSlot{
+				Tip:    *uint256.NewInt(300000),
+				FeeCap: *uint256.NewInt(300000),
+				Gas:    100000,
+				Nonce:  nonce,
+			}
+			txnSlot.IDHash[0] = id
+			txnSlots.Append(txnSlot, addr[:], true)
+		}
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		return reasons
+	}
+
+	// parked at the same time: the farthest nonce is discarded above the limit
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success, txpoolcfg.Success, txpoolcfg.ParkingOverflow}, add(5, 6, 7))
+	assert.Equal(map[string]int{"nonce_gap": 2}, pool.ParkedCount())
+
+	pool.lock.Lock()
+	pool.updateParkingLocked(time.Now().Add(2*time.Hour), pool.logger)
+	pool.lock.Unlock()
+	assert.Equal(map[string]int{}, pool.ParkedCount())
+	senderID, ok := pool.senders.getID(addr)
+	require.True(ok)
+	assert.Equal(0, pool.all.count(senderID))
+	reason, ok := pool.discardReasonsLRU.Get(string([]byte{1}) + string(make([]byte, 31)))
+	require.True(ok)
+	assert.Equal(txpoolcfg.ParkingExpired, reason)
+
+	// filling the gap promotes the parked txn
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, add(3))
+	assert.Equal(map[string]int{"nonce_gap": 1}, pool.ParkedCount())
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, add(2))
+	assert.Equal(map[string]int{}, pool.ParkedCount())
+	assert.Equal(2, pool.pending.Len())
+}
+
+func TestReapQueued(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := txpoolcfg.DefaultConfig
+	cfg.QueuedLifetime = time.Hour
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	acc := accounts3.Account{Nonce: 2, Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    accounts3.SerialiseV3(&acc),
+	})
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	// nonce 2 is pending, 4 and 5 are queued on the gap, 6 is queued and local
+	var txnSlots TxnSlots
+	for i, nonce := range []uint64{2, 4, 5, 6} {
+		txnSlot := &TxnSlot{
+			Tip:    *uint256.NewInt(300000),
+			FeeCap: *uint256.NewInt(300000),
+			Gas:    100000,
+			Nonce:  nonce,
+		}
+		txnSlot.IDHash[0] = byte(i + 1)
+		txnSlots.Append(txnSlot, addr[:], nonce == 6)
+	}
+	reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(err)
+	for _, reason := range reasons {
+		require.Equal(txpoolcfg.Success, reason, reason.String())
+	}
+	require.Equal(3, pool.queued.Len())
+
+	assert.Equal(0, pool.reapQueued(time.Now()))
+	assert.Equal(2, pool.reapQueued(time.Now().Add(2*time.Hour)))
+	assert.Equal(1, pool.queued.Len())
+	assert.Equal(1, pool.pending.Len())
+	reason, ok := pool.discardReasonsLRU.Get(string([]byte{2}) + string(make([]byte, 31)))
+	require.True(ok)
+	assert.Equal(txpoolcfg.Expired, reason)
+
+	pool.cfg.QueuedLifetime = 0
+	assert.Equal(0, pool.reapQueued(time.Now().Add(2*time.Hour)))
+}
+
+func TestPrioritySenders(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan Announcements, 100)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	var addr, priorityAddr [20]byte
+	addr[0], priorityAddr[0] = 1, 2
+	cfg := txpoolcfg.DefaultConfig
+	cfg.PendingSubPoolLimit = 2
+	cfg.PrioritySenders = []string{string(priorityAddr[:])}
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	for _, a := range [][20]byte{addr, priorityAddr} {
+		acc := accounts3.Account{
+			Nonce:       2,
+			Balance:     *uint256.NewInt(1 * common.Ether),
+			CodeHash:    common.Hash{},
+			Incarnation: 1,
+		}
+		change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(a),
+			Data:    accounts3.SerialiseV3(&acc),
+		})
+	}
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	var id byte
+	add := func(sender [20]byte, fee uint64) {
+		id++
+		txnSlot := &TxnSlot{
+			Tip:    *uint256.NewInt(fee),
+			FeeCap: *uint256.NewInt(fee),
+			Gas:    100000,
+			Nonce:  2,
+			Rlp:    []byte{id},
+		}
+		txnSlot.IDHash[0] = id
+		var txnSlots TxnSlots
+		txnSlots.Append(txnSlot, sender[:], false)
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	}
+	add(priorityAddr, 300000)
+	add(addr, 400000)
+
+	// the priority txn is offered first despite the lower tip
+	var txns TxnsRlp
+	_, err = pool.PeekBest(ctx, 2, &txns, 0, 1000000, 0, math.MaxInt)
+	require.NoError(err)
+	assert.Equal([][]byte{{1}, {2}}, txns.Txns)
+
+	// and it isn't evicted for the fees
+	addr[0] = 3
+	change.ChangeBatch[0].Changes = change.ChangeBatch[0].Changes[:1]
+	change.ChangeBatch[0].Changes[0].Address = gointerfaces.ConvertAddressToH160(addr)
+	require.NoError(pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	add(addr, 500000)
+	assert.Equal(2, pool.pending.Len())
+	reason, ok := pool.discardReasonsLRU.Get(string([]byte{2}) + string(make([]byte, 31)))
+	require.True(ok)
+	assert.Equal(txpoolcfg.PendingPoolOverflow, reason)
+	_, ok = pool.byHash[string([]byte{1})+string(make([]byte, 31))]
+	assert.True(ok)
+}
+
+func TestOrdering(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	mt := func(senderID, nonce uint64) *metaTxn {
+		return &metaTxn{TxnSlot: &TxnSlot{SenderID: senderID, Nonce: nonce}}
+	}
+	a1, a2, b1, c1 := mt(1, 1), mt(1, 2), mt(2, 1), mt(3, 1)
+	best := []*metaTxn{a1, a2, b1, c1}
+
+	// the higher score the earlier, but the second txn of the sender can't be offered before the first one:
+	// they swap their places
+	assert.Equal([]*metaTxn{a1, c1, b1, a2}, orderByScores(best, []uint64{1, 10, 2, 5}))
+	// equal scores keep the pending sub-pool order
+	assert.Equal(best, orderByScores(best, []uint64{3, 3, 3, 3}))
+
+	txns := []OrderingTxn{
+		{Tip: 5, FeeCap: *uint256.NewInt(120)},  // 5, tip is below the fee cap - base fee
+		{Tip: 5, FeeCap: *uint256.NewInt(103)},  // 3
+		{Tip: 50, FeeCap: *uint256.NewInt(120)}, // 20
+		{Tip: 5, FeeCap: *uint256.NewInt(90)},   // below the base fee
+	}
+	scores, err := TipAfterBaseFeeOrdering{}.Score(context.Background(), txns, 100)
+	require.NoError(err)
+	assert.Equal([]uint64{5, 3, 20, 0}, scores)
+}
+
 func TestReverseNonces(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	ch := make(chan Announcements, 100)
@@ -1190,7 +1787,7 @@ func TestBlobTxnReplacement(t *testing.T) {
 		}
 	}
 
-	// Try to replace it with required price bump (configured in pool.cfg.BlobPriceBump for blob txns) to all transaction fields - should be successful only if all are bumped
+	// Try to replace it with required price bump (configured in pool.cfg.Replacement for blob txns) to all transaction fields - should be successful only if all are bumped
 	{
 		blobTxn := makeBlobTxn()
 		origTip := blobTxn.Tip
@@ -1200,8 +1797,9 @@ func TestBlobTxnReplacement(t *testing.T) {
 		txnSlots := TxnSlots{}
 		txnSlots.Append(&blobTxn, addr[:], true)
 
-		// Get the config of the pool for BlobPriceBump and bump prices
-		requiredPriceBump := pool.cfg.BlobPriceBump
+		// Get the config of the pool for BlobPriceBump and BlobFeeBump and bump prices
+		requiredPriceBump := pool.cfg.Replacement.BlobPriceBump
+		requiredBlobFeeBump := pool.cfg.Replacement.BlobFeeBump
 
 		// Bump the tip only
 		blobTxn.Tip.MulDivOverflow(tip, uint256.NewInt(requiredPriceBump+100), uint256.NewInt(100))
@@ -1222,7 +1820,7 @@ func TestBlobTxnReplacement(t *testing.T) {
 		assert.Equal(txpoolcfg.ReplaceUnderpriced, reasons[0], reasons[0].String())
 
 		// Bump fee cap + blobFee cap
-		blobTxn.BlobFeeCap.MulDivOverflow(blobFeeCap, uint256.NewInt(requiredPriceBump+100), uint256.NewInt(100))
+		blobTxn.BlobFeeCap.MulDivOverflow(blobFeeCap, uint256.NewInt(requiredBlobFeeBump+100), uint256.NewInt(100))
 		reasons, err = pool.AddLocalTxns(ctx, txnSlots)
 		require.NoError(err)
 		assert.Equal(txpoolcfg.NotReplaced, reasons[0], reasons[0].String())
@@ -1240,6 +1838,28 @@ func TestBlobTxnReplacement(t *testing.T) {
 		require.NoError(err)
 		assert.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
 	}
+
+	// The blob fee cap bump is changed at runtime: bumping the tip and the fee cap only is enough without it
+	{
+		blobTxn := makeBlobTxn()
+		blobTxn.Nonce = 0x2
+		blobTxn.IDHash[0] = 0x04
+		blobTxn.Tip.Mul(tip, uint256.NewInt(4))
+		blobTxn.FeeCap.Mul(feeCap, uint256.NewInt(4))
+		blobTxn.BlobFeeCap.Mul(blobFeeCap, uint256.NewInt(2)) // same as the pooled one
+		txnSlots := TxnSlots{}
+		txnSlots.Append(&blobTxn, addr[:], true)
+		reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		assert.Equal(txpoolcfg.ReplaceUnderpriced, reasons[0], reasons[0].String())
+
+		policy := pool.ReplacementPolicy()
+		policy.BlobFeeBump = 0
+		pool.SetReplacementPolicy(policy)
+		reasons, err = pool.AddLocalTxns(ctx, txnSlots)
+		require.NoError(err)
+		assert.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
+	}
 }
 
 // Todo, make the txn more realistic with good values
@@ -1457,6 +2077,279 @@ func TestBlobSlots(t *testing.T) {
 	}
 }
 
+// newBlobTestPool returns a Cancun pool with funded accounts 1..10
+func newBlobTestPool(t *testing.T, cfg txpoolcfg.Config) *TxPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	pool, err := New(ctx, make(chan Announcements, 5), db, coreDB, cfg, kvcache.New(kvcache.DefaultCoherentConfig), testutil.Forks["Cancun"], nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(t, err)
+	pool.blockGasLimit.Store(30000000)
+
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee:  200_000,
+		BlockGasLimit:        math.MaxUint64,
+		PendingBlobFeePerGas: 100_000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})},
+		},
+	}
+	v := accounts3.SerialiseV3(&accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1})
+	var addr [20]byte
+	for i := 0; i < 10; i++ {
+		addr[0] = uint8(i + 1)
+		change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(addr),
+			Data:    v,
+		})
+	}
+	require.NoError(t, pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+	return pool
+}
+
+// addBlobTxn adds a 2-blob txn of the account
+func addBlobTxn(t *testing.T, pool *TxPool, account, id uint8, blobFeeCap uint64) (TxnSlot, txpoolcfg.DiscardReason) {
+	var addr [20]byte
+	addr[0] = account
+	blobTxn := makeBlobTxn()
+	blobTxn.IDHash[0] = id
+	blobTxn.Nonce = 0
+	blobTxn.Gas = 50000
+	blobTxn.BlobFeeCap = *uint256.NewInt(blobFeeCap)
+	txnSlots := TxnSlots{}
+	txnSlots.Append(&blobTxn, addr[:], true)
+	reasons, err := pool.AddLocalTxns(context.Background(), txnSlots)
+	require.NoError(t, err)
+	require.Len(t, reasons, 1)
+	return blobTxn, reasons[0]
+}
+
+func TestBlobPoolEviction(t *testing.T) {
+	cfg := txpoolcfg.DefaultConfig
+	cfg.TotalBlobPoolLimit = 4
+	// blob txns are not limited by other sub-pools
+	cfg.PendingSubPoolLimit, cfg.BaseFeeSubPoolLimit, cfg.QueuedSubPoolLimit = 0, 0, 0
+	pool := newBlobTestPool(t, cfg)
+
+	worst, reason := addBlobTxn(t, pool, 1, 1, 200_000)
+	require.Equal(t, txpoolcfg.Success, reason, reason.String())
+	_, reason = addBlobTxn(t, pool, 2, 2, 300_000)
+	require.Equal(t, txpoolcfg.Success, reason, reason.String())
+	require.Equal(t, 2, pool.blobs.Len())
+
+	// the pool is full: a txn paying no more than the worst is rejected
+	_, reason = addBlobTxn(t, pool, 3, 3, 200_000)
+	require.Equal(t, txpoolcfg.BlobPoolOverflow, reason, reason.String())
+
+	// a better one evicts the worst
+	_, reason = addBlobTxn(t, pool, 4, 4, 250_000)
+	require.Equal(t, txpoolcfg.Success, reason, reason.String())
+	require.Equal(t, 2, pool.blobs.Len())
+	require.Equal(t, uint64(4), pool.blobs.Blobs())
+	require.Equal(t, uint64(250_000), pool.blobs.Worst().TxnSlot.BlobFeeCap.Uint64())
+
+	discardReason, ok := pool.discardReasonsLRU.Get(string(worst.IDHash[:]))
+	require.True(t, ok)
+	require.Equal(t, txpoolcfg.BlobPoolOverflow, discardReason)
+}
+
+func TestBlobPoolDiskOverflow(t *testing.T) {
+	cfg := txpoolcfg.DefaultConfig
+	cfg.BlobMemoryLimit = 2
+	pool := newBlobTestPool(t, cfg)
+	pool.chainID = *uint256.NewInt(5) // of makeBlobTxn, to parse the txns read from the pool db
+
+	_, reason := addBlobTxn(t, pool, 1, 1, 300_000)
+	require.Equal(t, txpoolcfg.Success, reason, reason.String())
+	// the txns have the same blobs, the blob hashes now refer to the second one
+	blobTxn, reason := addBlobTxn(t, pool, 2, 2, 200_000)
+	require.Equal(t, txpoolcfg.Success, reason, reason.String())
+	require.Equal(t, uint64(4), pool.blobs.InMemory())
+
+	_, err := pool.flush(context.Background())
+	require.NoError(t, err)
+
+	// blobs of the txn with the lowest blob fee cap are dropped from memory
+	require.Equal(t, uint64(2), pool.blobs.InMemory())
+	require.Equal(t, uint64(4), pool.blobs.Blobs())
+	mt := pool.byHash[string(blobTxn.IDHash[:])]
+	require.True(t, mt.blobsOnDisk)
+	require.Nil(t, mt.TxnSlot.BlobBundles)
+
+	// and read from the pool db
+	blobBundles := pool.GetBlobs(blobTxn.BlobHashes)
+	require.Len(t, blobBundles, 2)
+	for i, bb := range blobBundles {
+		assert.Equal(t, blobTxn.BlobBundles[i].Blob, bb.Blob)
+		assert.Equal(t, blobTxn.BlobBundles[i].Proofs, bb.Proofs)
+	}
+}
+
+// makeWrappedBlobTxnRlp returns the rlp with the blobs wrapper of a valid 2-blob txn of chain 5, unsigned
+func makeWrappedBlobTxnRlp(t *testing.T) ([]byte, []common.Hash) {
+	txw := &types.BlobTxWrapper{Blobs: make(types.Blobs, 2)}
+	copy(txw.Blobs[0][:], hexutil.MustDecodeHex(testdata.ValidBlob1Hex))
+	copy(txw.Blobs[1][:], hexutil.MustDecodeHex(testdata.ValidBlob2Hex))
+	for _, blob := range txw.Blobs {
+		commitment, err := kzg.Ctx().BlobToKZGCommitment(blob[:], 0)
+		require.NoError(t, err)
+		proof, err := kzg.Ctx().ComputeBlobKZGProof(blob[:], commitment, 0)
+		require.NoError(t, err)
+		txw.Commitments = append(txw.Commitments, types.KZGCommitment(commitment))
+		txw.Proofs = append(txw.Proofs, types.KZGProof(proof))
+		txw.Tx.BlobVersionedHashes = append(txw.Tx.BlobVersionedHashes, common.Hash(kzg.KZGToVersionedHash(commitment)))
+	}
+	txw.Tx.ChainID = uint256.NewInt(5)
+	txw.Tx.GasLimit = 50000
+	txw.Tx.To = &common.Address{}
+	txw.Tx.Value = uint256.NewInt(0)
+	txw.Tx.TipCap, txw.Tx.FeeCap = uint256.NewInt(100_000), uint256.NewInt(300_000)
+	txw.Tx.MaxFeePerBlobGas = uint256.NewInt(300_000)
+	var buf bytes.Buffer
+	require.NoError(t, txw.MarshalBinaryWrapped(&buf))
+	return buf.Bytes(), txw.Tx.BlobVersionedHashes
+}
+
+func TestDumpLoad(t *testing.T) {
+	cfg := txpoolcfg.DefaultConfig
+	cfg.DBDir = t.TempDir()
+	cfg.BlobMemoryLimit = 0 // the dumped blobs are read from the pool db
+	pool := newBlobTestPool(t, cfg)
+	pool.chainID = *uint256.NewInt(5)
+
+	wrapperRlp, blobHashes := makeWrappedBlobTxnRlp(t)
+	blobTxn := &TxnSlot{}
+	parseCtx := NewTxnParseContext(pool.chainID)
+	parseCtx.WithSender(false)
+	_, err := parseCtx.ParseTransaction(wrapperRlp, 0, blobTxn, nil, false, true, nil)
+	require.NoError(t, err)
+	blobBundles := blobTxn.BlobBundles // dropped from the txn on flush
+	var addr [20]byte
+	addr[0] = 1
+	txnSlots := TxnSlots{}
+	txnSlots.Append(blobTxn, addr[:], true)
+	reasons, err := pool.AddLocalTxns(context.Background(), txnSlots)
+	require.NoError(t, err)
+	require.Equal(t, txpoolcfg.Success, reasons[0], reasons[0].String())
+	_, err = pool.flush(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), pool.blobs.InMemory())
+
+	require.NoError(t, pool.dumpFile(context.Background()))
+	require.FileExists(t, filepath.Join(cfg.DBDir, DumpFile))
+
+	restored := newBlobTestPool(t, cfg)
+	restored.chainID = *uint256.NewInt(5)
+	restored.loadFile(context.Background())
+	require.NoFileExists(t, filepath.Join(cfg.DBDir, DumpFile))
+
+	require.Equal(t, 1, restored.blobs.Len())
+	require.Equal(t, uint64(2), restored.blobs.Blobs())
+	require.True(t, restored.IsLocal(blobTxn.IDHash[:]))
+	nonce, inPool := restored.NonceFromAddress(addr)
+	require.True(t, inPool)
+	require.Equal(t, uint64(0), nonce)
+	restoredBundles := restored.GetBlobs(blobHashes)
+	require.Len(t, restoredBundles, 2)
+	for i, bb := range restoredBundles {
+		assert.Equal(t, blobBundles[i].Blob, bb.Blob)
+		assert.Equal(t, blobBundles[i].Proofs, bb.Proofs)
+	}
+
+	// another format isn't loaded
+	var buf bytes.Buffer
+	_, err = restored.Dump(context.Background(), &buf)
+	require.NoError(t, err)
+	dump := buf.Bytes()
+	dump[7]++
+	_, err = restored.Load(context.Background(), bytes.NewReader(dump))
+	require.ErrorIs(t, err, ErrDumpVersion)
+}
+
+func TestForEachPage(t *testing.T) {
+	ch := make(chan Announcements, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ctx, ch, db, coreDB, cfg, sendersCache, chain.TestChainConfig, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(t, err)
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})},
+		},
+	}
+	addr1, addr2 := common.Address{1}, common.Address{2}
+	for _, addr := range []common.Address{addr1, addr2} {
+		acc := accounts3.Account{Balance: *uint256.NewInt(1 * common.Ether), Incarnation: 1}
+		change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(addr),
+			Data:    accounts3.SerialiseV3(&acc),
+		})
+	}
+	require.NoError(t, pool.OnNewBlock(ctx, change, TxnSlots{}, TxnSlots{}, TxnSlots{}))
+
+	// addr2 txn 4 is queued: nonce gap
+	txnSlots := TxnSlots{}
+	for i, txn := range []struct {
+		sender common.Address
+		nonce  uint64
+		typ    byte
+	}{{addr1, 0, LegacyTxnType}, {addr1, 1, LegacyTxnType}, {addr1, 2, LegacyTxnType}, {addr2, 0, DynamicFeeTxnType}, {addr2, 4, DynamicFeeTxnType}} {
+		slot := &TxnSlot{
+			Tip:    *uint256.NewInt(300000),
+			FeeCap: *uint256.NewInt(300000),
+			Gas:    100000,
+			Nonce:  txn.nonce,
+			Type:   txn.typ,
+			Rlp:    []byte{byte(i)},
+		}
+		slot.IDHash[0] = byte(i + 1)
+		txnSlots.Append(slot, txn.sender[:], true)
+	}
+	reasons, err := pool.AddLocalTxns(ctx, txnSlots)
+	require.NoError(t, err)
+	for _, reason := range reasons {
+		require.Equal(t, txpoolcfg.Success, reason, reason.String())
+	}
+
+	tx, err := db.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	page := func(q allQuery) (txns []byte, senders []common.Address) {
+		for {
+			var more bool
+			q.fromSenderID, q.fromNonce, more = pool.forEachPage(q, func(rlp []byte, sender common.Address, _ SubPoolType) {
+				txns = append(txns, rlp[0])
+				senders = append(senders, sender)
+			}, tx)
+			if !more {
+				return txns, senders
+			}
+		}
+	}
+
+	txns, senders := page(allQuery{limit: 2})
+	require.Equal(t, []byte{0, 1, 2, 3, 4}, txns)
+	require.Equal(t, []common.Address{addr1, addr1, addr1, addr2, addr2}, senders)
+
+	txns, _ = page(allQuery{limit: 1, senders: map[common.Address]struct{}{addr2: {}, {3}: {}}})
+	require.Equal(t, []byte{3, 4}, txns)
+
+	txns, _ = page(allQuery{subPools: map[SubPoolType]struct{}{QueuedSubPool: {}}})
+	require.Equal(t, []byte{4}, txns)
+
+	txns, _ = page(allQuery{limit: 2, types: map[byte]struct{}{LegacyTxnType: {}}})
+	require.Equal(t, []byte{0, 1, 2}, txns)
+}
+
 func TestGetBlobsV1(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	ch := make(chan Announcements, 5)
@@ -17,7 +17,6 @@
 package txpool
 
 import (
-	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -57,7 +56,17 @@ var ErrAlreadyKnown = errors.New("already known")
 var ErrRlpTooBig = errors.New("txn rlp too big")
 
 type TxnParseConfig struct {
-	ChainID uint256.Int
+	ChainID      uint256.Int
+	SignerScheme types.SignerScheme // of the legacy txns, nil for EIP-155
+}
+
+// preEip155 - whether V of a legacy txn is unprotected, by the signer scheme of the chain if any
+func (cfg *TxnParseConfig) preEip155(v *uint256.Int) bool {
+	if cfg.SignerScheme != nil {
+		chainID, _, err := cfg.SignerScheme.DecodeLegacyV(v)
+		return err == nil && chainID == nil
+	}
+	return v.Eq(u256.N27) || v.Eq(u256.N28)
 }
 
 type Signature struct {
@@ -95,6 +104,7 @@ func NewTxnParseContext(chainID uint256.Int) *TxnParseContext {
 
 	// behave as of London enabled
 	ctx.cfg.ChainID.Set(&chainID)
+	ctx.cfg.SignerScheme = types.SignerSchemeOf(&chainID)
 	return ctx
 }
 
@@ -290,7 +300,7 @@ func (ctx *TxnParseContext) ParseTransaction(payload []byte, pos int, slot *TxnS
 	return p, err
 }
 
-func parseSignature(payload []byte, pos int, legacy bool, cfgChainId *uint256.Int, sig *Signature) (p int, yParity byte, err error) {
+func parseSignature(payload []byte, pos int, legacy bool, cfg *TxnParseConfig, sig *Signature) (p int, yParity byte, err error) {
 	p = pos
 
 	// Parse V / yParity
@@ -298,12 +308,25 @@ func parseSignature(payload []byte, pos int, legacy bool, cfgChainId *uint256.In
 	if err != nil {
 		return 0, 0, fmt.Errorf("v: %w", err)
 	}
-	if legacy {
+	if legacy && cfg.SignerScheme != nil {
+		chainID, recoveryID, err := cfg.SignerScheme.DecodeLegacyV(&sig.V)
+		if err != nil {
+			return 0, 0, fmt.Errorf("v: %w", err)
+		}
+		if chainID == nil {
+			sig.ChainID.Set(&cfg.ChainID)
+		} else if !chainID.Eq(&cfg.ChainID) {
+			return 0, 0, fmt.Errorf("invalid chainID %s (expected %s)", chainID, &cfg.ChainID)
+		} else {
+			sig.ChainID.Set(chainID)
+		}
+		yParity = recoveryID
+	} else if legacy {
 		preEip155 := sig.V.Eq(u256.N27) || sig.V.Eq(u256.N28)
 		// Compute chainId from V
 		if preEip155 {
 			yParity = byte(sig.V.Uint64() - 27)
-			sig.ChainID.Set(cfgChainId)
+			sig.ChainID.Set(&cfg.ChainID)
 		} else {
 			// EIP-155: Simple replay attack protection
 			// V = ChainID * 2 + 35 + yParity
@@ -313,8 +336,8 @@ func parseSignature(payload []byte, pos int, legacy bool, cfgChainId *uint256.In
 			sig.ChainID.Sub(&sig.V, u256.N35)
 			yParity = byte(sig.ChainID.Uint64() % 2)
 			sig.ChainID.Rsh(&sig.ChainID, 1)
-			if !sig.ChainID.Eq(cfgChainId) {
-				return 0, 0, fmt.Errorf("invalid chainID %s (expected %s)", &sig.ChainID, cfgChainId)
+			if !sig.ChainID.Eq(&cfg.ChainID) {
+				return 0, 0, fmt.Errorf("invalid chainID %s (expected %s)", &sig.ChainID, &cfg.ChainID)
 			}
 		}
 	} else {
@@ -505,6 +528,7 @@ func (ctx *TxnParseContext) parseTransactionBody(payload []byte, pos, p0 int, sl
 			return 0, fmt.Errorf("%w: authorizations len: %s", ErrParseTxn, err) //nolint
 		}
 		authPos := dataPos
+		var auths []types.Authorization
 		for authPos < dataPos+dataLen {
 			var authLen int
 			authPos, authLen, err = rlp.ParseList(payload, authPos)
@@ -533,17 +557,12 @@ func (ctx *TxnParseContext) parseTransactionBody(payload []byte, pos, p0 int, sl
 			}
 
 			sig := Signature{}
-			p2, auth.YParity, err = parseSignature(payload, p2, false /* legacy */, nil /* cfgChainId */, &sig)
+			p2, auth.YParity, err = parseSignature(payload, p2, false /* legacy */, nil /* cfg */, &sig)
 			if err != nil {
 				return 0, fmt.Errorf("%w: authorization signature: %s", ErrParseTxn, err) //nolint
 			}
 			auth.R, auth.S = sig.R, sig.S
-
-			authority, err := auth.RecoverSigner(bytes.NewBuffer(nil), make([]byte, 32))
-			if err != nil {
-				return 0, fmt.Errorf("%w: recover authorization signer: %s stack: %s", ErrParseTxn, err, dbg.Stack()) //nolint
-			}
-			slot.AuthAndNonces = append(slot.AuthAndNonces, AuthAndNonce{authority.String(), auth.Nonce})
+			auths = append(auths, auth)
 			authPos += authLen
 			if authPos != p2 {
 				return 0, fmt.Errorf("%w: authorization: unexpected list items", ErrParseTxn)
@@ -552,6 +571,13 @@ func (ctx *TxnParseContext) parseTransactionBody(payload []byte, pos, p0 int, sl
 		if authPos != dataPos+dataLen {
 			return 0, fmt.Errorf("%w: extraneous space in the authorizations", ErrParseTxn)
 		}
+		authorities, recoverErrs := types.RecoverAuthorities(auths)
+		for i := range auths {
+			if err := recoverErrs[i]; err != nil {
+				return 0, fmt.Errorf("%w: recover authorization signer: %s stack: %s", ErrParseTxn, err, dbg.Stack()) //nolint
+			}
+			slot.AuthAndNonces = append(slot.AuthAndNonces, AuthAndNonce{authorities[i].String(), auths[i].Nonce})
+		}
 		p = dataPos + dataLen
 	}
 	if slot.Type == BlobTxnType {
@@ -583,14 +609,13 @@ func (ctx *TxnParseContext) parseTransactionBody(payload []byte, pos, p0 int, sl
 	sigHashEnd := p
 	sigHashLen := uint(sigHashEnd - sigHashPos)
 	var chainIDBits, chainIDLen int
-	p, vByte, err = parseSignature(payload, p, legacy, &ctx.cfg.ChainID, &ctx.Signature)
+	p, vByte, err = parseSignature(payload, p, legacy, &ctx.cfg, &ctx.Signature)
 	if err != nil {
 		return 0, fmt.Errorf("%w: %s", ErrParseTxn, err) //nolint
 	}
 
 	if legacy {
-		preEip155 := ctx.V.Eq(u256.N27) || ctx.V.Eq(u256.N28)
-		if !preEip155 {
+		if !ctx.cfg.preEip155(&ctx.V) {
 			chainIDBits = ctx.ChainID.BitLen()
 			if chainIDBits <= 7 {
 				chainIDLen = 1
@@ -869,6 +894,7 @@ type TxnSlot struct {
 	Type                byte     // Transaction type
 	Size                uint32   // Size of the payload (without the RLP string envelope for typed transactions)
 	ChainID             uint256.Int
+	Peer                PeerID // sentry peer which delivered the remote transaction, nil for the local ones
 
 	// EIP-4844: Shard Blob Transactions
 	BlobFeeCap  uint256.Int // max_fee_per_blob_gas
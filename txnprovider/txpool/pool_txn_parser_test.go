@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/rlp"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon-lib/types/testdata"
@@ -112,6 +113,34 @@ func TestTransactionSignatureValidity2(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestParseTransactionSignerScheme(t *testing.T) {
+	chainID := uint256.NewInt(4444)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	sign := func() []byte {
+		signer := types.LatestSignerForChainID(chainID.ToBig())
+		txn, err := types.SignTx(types.NewTransaction(0, addr, uint256.NewInt(1), 21000, uint256.NewInt(1), nil), *signer, key)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, txn.MarshalBinary(&buf))
+		return buf.Bytes()
+	}
+	eip155Txn := sign()
+	types.RegisterSignerScheme(chainID.Uint64(), types.PreEIP155Scheme{})
+	t.Cleanup(func() { types.RegisterSignerScheme(chainID.Uint64(), nil) })
+	preEip155Txn := sign()
+
+	ctx := NewTxnParseContext(*chainID)
+	slot, sender := &TxnSlot{}, [20]byte{}
+	_, err = ctx.ParseTransaction(preEip155Txn, 0, slot, sender[:], false /* hasEnvelope */, true /* wrappedWithBlobs */, nil)
+	require.NoError(t, err)
+	require.Equal(t, addr, common.Address(sender))
+
+	_, err = ctx.ParseTransaction(eip155Txn, 0, slot, sender[:], false /* hasEnvelope */, true /* wrappedWithBlobs */, nil)
+	require.ErrorIs(t, err, ErrParseTxn)
+}
+
 func TestTxnSlotsGrowth(t *testing.T) {
 	assert := assert.New(t)
 	s := &TxnSlots{}
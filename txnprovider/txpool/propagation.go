@@ -0,0 +1,183 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+)
+
+const (
+	// maxPropagationEvents - per transaction: popular transactions are announced by most of the peers
+	maxPropagationEvents = 32
+	// maxPropagationTxns - bounds memory if the window is large compared to the inflow of transactions
+	maxPropagationTxns = 1 << 20
+
+	PropagationAnnouncement = "announcement" // hash announced: NewPooledTransactionHashes
+	PropagationTransaction  = "transaction"  // full transaction: Transactions or PooledTransactions
+)
+
+// PropagationEvent - one transaction message received from or sent to peers
+type PropagationEvent struct {
+	Time  time.Time `json:"time"`
+	Kind  string    `json:"kind"`            // PropagationAnnouncement or PropagationTransaction
+	Peer  string    `json:"peer,omitempty"`  // received: sender's peer id
+	Peers int       `json:"peers,omitempty"` // sent: amount of peers the message was sent to
+}
+
+// TxnPropagation - when and from whom a transaction was seen by the pool, and when it was sent to peers.
+// Amount of recorded events is limited to the first maxPropagationEvents of each direction.
+type TxnPropagation struct {
+	Hash          common.Hash        `json:"hash"`
+	FirstSeen     time.Time          `json:"firstSeen"`
+	FirstSeenFrom string             `json:"firstSeenFrom,omitempty"` // empty for local transactions
+	Local         bool               `json:"local"`
+	Received      []PropagationEvent `json:"received"`
+	Sent          []PropagationEvent `json:"sent"`
+}
+
+// PropagationTracker - keeps TxnPropagation of transactions first seen within the window. nil tracker is disabled.
+type PropagationTracker struct {
+	window time.Duration
+
+	mu    sync.Mutex
+	txns  map[common.Hash]*TxnPropagation
+	order []common.Hash // by first seen time
+}
+
+func NewPropagationTracker(window time.Duration) *PropagationTracker {
+	if window <= 0 {
+		return nil
+	}
+	return &PropagationTracker{window: window, txns: map[common.Hash]*TxnPropagation{}}
+}
+
+func peerString(peerID PeerID) string {
+	if peerID == nil {
+		return ""
+	}
+	return hex.EncodeToString(gointerfaces.ConvertH512ToBytes(peerID))
+}
+
+// track - must be called with t.mu held
+func (t *PropagationTracker) track(hash []byte, now time.Time) *TxnPropagation {
+	h := common.BytesToHash(hash)
+	if txn, ok := t.txns[h]; ok {
+		return txn
+	}
+	t.expire(now)
+	txn := &TxnPropagation{Hash: h, FirstSeen: now, Received: []PropagationEvent{}, Sent: []PropagationEvent{}}
+	t.txns[h] = txn
+	t.order = append(t.order, h)
+	return txn
+}
+
+// expire - must be called with t.mu held
+func (t *PropagationTracker) expire(now time.Time) {
+	i := 0
+	for ; i < len(t.order); i++ {
+		txn := t.txns[t.order[i]]
+		if now.Sub(txn.FirstSeen) < t.window && len(t.order)-i < maxPropagationTxns {
+			break
+		}
+		delete(t.txns, t.order[i])
+	}
+	t.order = t.order[i:]
+}
+
+// Received - records a transaction message from a peer
+func (t *PropagationTracker) Received(hash []byte, peerID PeerID, kind string, now time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	txn := t.track(hash, now)
+	peer := peerString(peerID)
+	if len(txn.Received) == 0 && !txn.Local {
+		txn.FirstSeenFrom = peer
+	}
+	if len(txn.Received) < maxPropagationEvents {
+		txn.Received = append(txn.Received, PropagationEvent{Time: now, Kind: kind, Peer: peer})
+	}
+}
+
+// Local - records a transaction submitted to this node
+func (t *PropagationTracker) Local(hash []byte, now time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	txn := t.track(hash, now)
+	if len(txn.Received) == 0 {
+		txn.Local = true
+	}
+}
+
+// Sent - records messages sent to peers: sentTo[i] is amount of peers the i-th hash was sent to
+func (t *PropagationTracker) Sent(hashes Hashes, sentTo []int, kind string, now time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := 0; i < hashes.Len() && i < len(sentTo); i++ {
+		if sentTo[i] == 0 {
+			continue
+		}
+		txn, ok := t.txns[common.BytesToHash(hashes.At(i))]
+		if !ok || len(txn.Sent) >= maxPropagationEvents {
+			continue
+		}
+		txn.Sent = append(txn.Sent, PropagationEvent{Time: now, Kind: kind, Peers: sentTo[i]})
+	}
+}
+
+// Get - returns a copy of the transaction's propagation, if it was first seen within the window
+func (t *PropagationTracker) Get(hash common.Hash, now time.Time) (TxnPropagation, bool) {
+	if t == nil {
+		return TxnPropagation{}, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	txn, ok := t.txns[hash]
+	if !ok || now.Sub(txn.FirstSeen) >= t.window {
+		return TxnPropagation{}, false
+	}
+	res := *txn
+	res.Received = append([]PropagationEvent{}, txn.Received...)
+	res.Sent = append([]PropagationEvent{}, txn.Sent...)
+	return res, true
+}
+
+// TxnPropagationReader - read access to propagation traces. Implemented by TxPool, but it's not part of the
+// txpool gRPC interface: available only to in-process consumers (rpcdaemon embedded into erigon with internal txpool)
+type TxnPropagationReader interface {
+	TxnPropagation(hash common.Hash) (TxnPropagation, bool)
+}
+
+var _ TxnPropagationReader = (*TxPool)(nil)
+
+// TxnPropagation - returns propagation trace of the transaction, false if it's unknown, expired or tracing is disabled
+func (p *TxPool) TxnPropagation(hash common.Hash) (TxnPropagation, bool) {
+	return p.propagation.Get(hash, time.Now())
+}
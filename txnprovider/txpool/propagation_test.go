@@ -0,0 +1,69 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+)
+
+func TestPropagationTracker(t *testing.T) {
+	require.Nil(t, NewPropagationTracker(0))
+	var disabled *PropagationTracker
+	disabled.Received([]byte{1}, nil, PropagationAnnouncement, time.Now())
+	_, ok := disabled.Get(common.Hash{1}, time.Now())
+	require.False(t, ok)
+
+	tracker := NewPropagationTracker(time.Minute)
+	now := time.Unix(1_700_000_000, 0)
+	h1, h2 := common.Hash{1}, common.Hash{2}
+	peer := gointerfaces.ConvertHashToH512([64]byte{0xaa})
+
+	tracker.Received(h1[:], peer, PropagationAnnouncement, now)
+	tracker.Received(h1[:], nil, PropagationTransaction, now.Add(time.Second))
+	tracker.Local(h1[:], now.Add(2*time.Second)) // already received: not local
+	tracker.Local(h2[:], now.Add(3*time.Second))
+	tracker.Sent(append(append(Hashes{}, h1[:]...), h2[:]...), []int{0, 5}, PropagationTransaction, now.Add(4*time.Second))
+
+	res, ok := tracker.Get(h1, now.Add(5*time.Second))
+	require.True(t, ok)
+	require.False(t, res.Local)
+	require.Equal(t, now, res.FirstSeen)
+	require.Equal(t, peerString(peer), res.FirstSeenFrom)
+	require.Len(t, res.Received, 2)
+	require.Equal(t, PropagationTransaction, res.Received[1].Kind)
+	require.Empty(t, res.Sent) // sent to 0 peers
+
+	res, ok = tracker.Get(h2, now.Add(5*time.Second))
+	require.True(t, ok)
+	require.True(t, res.Local)
+	require.Empty(t, res.FirstSeenFrom)
+	require.Equal(t, []PropagationEvent{{Time: now.Add(4 * time.Second), Kind: PropagationTransaction, Peers: 5}}, res.Sent)
+
+	// h1 is out of window, new transaction expires it
+	_, ok = tracker.Get(h1, now.Add(time.Minute))
+	require.False(t, ok)
+	tracker.Received(common.Hash{3}.Bytes(), peer, PropagationAnnouncement, now.Add(time.Minute+time.Second))
+	require.Len(t, tracker.txns, 2)
+	_, ok = tracker.Get(h2, now.Add(time.Minute+time.Second))
+	require.True(t, ok)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+// maxQueuedReapEvery - bound of how late after txpoolcfg.Config.QueuedLifetime a transaction is reaped
+const maxQueuedReapEvery = time.Minute
+
+func queuedReapEvery(lifetime time.Duration) time.Duration {
+	return min(lifetime, maxQueuedReapEvery)
+}
+
+// reapQueued discards the remote transactions which entered the queued sub-pool longer than
+// txpoolcfg.Config.QueuedLifetime ago: a nonce gap which is never filled would keep them forever otherwise. The
+// local transactions and the ones of the priority senders are kept. Returns the number of discarded transactions.
+func (p *TxPool) reapQueued(now time.Time) int {
+	if p.cfg.QueuedLifetime <= 0 {
+		return 0
+	}
+	defer queuedReapTimer.ObserveDuration(time.Now())
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var expired []*metaTxn
+	for _, mt := range p.queued.best.ms {
+		if mt.subPool&IsLocal != 0 || mt.priority || now.Sub(mt.queuedAt) <= p.cfg.QueuedLifetime {
+			continue
+		}
+		expired = append(expired, mt)
+	}
+	for _, mt := range expired {
+		p.queued.Remove(mt, "expired", p.logger)
+		p.discardLocked(mt, txpoolcfg.Expired)
+		if mt.TxnSlot.Traced {
+			p.logger.Info(fmt.Sprintf("TX TRACING: discarded expired txn idHash=%x queued=%s", mt.TxnSlot.IDHash, now.Sub(mt.queuedAt)))
+		}
+	}
+	if len(expired) > 0 {
+		queuedExpired.AddInt(len(expired))
+		p.logger.Debug("[txpool] reaped queued txns", "expired", len(expired), "lifetime", p.cfg.QueuedLifetime)
+	}
+	return len(expired)
+}
@@ -46,6 +46,7 @@ type BySenderAndNonce struct {
 	search            *metaTxn
 	senderIDTxnCount  map[uint64]int    // count of sender's txns in the pool - may differ from nonce
 	senderIDBlobCount map[uint64]uint64 // count of sender's total number of blobs in the pool
+	senderIDGas       map[uint64]uint64 // sum of gas limits of sender's txns in the pool
 }
 
 func (b *BySenderAndNonce) nonce(senderID uint64) (nonce uint64, ok bool) {
@@ -69,6 +70,14 @@ func (b *BySenderAndNonce) ascendAll(f func(*metaTxn) bool) {
 	})
 }
 
+// ascendFrom - all txns starting from the nonce of the sender, then the txns of the next senders
+func (b *BySenderAndNonce) ascendFrom(senderID, nonce uint64, f func(*metaTxn) bool) {
+	s := b.search
+	s.TxnSlot.SenderID = senderID
+	s.TxnSlot.Nonce = nonce
+	b.tree.AscendGreaterOrEqual(s, f)
+}
+
 func (b *BySenderAndNonce) ascend(senderID uint64, f func(*metaTxn) bool) {
 	s := b.search
 	s.TxnSlot.SenderID = senderID
@@ -101,6 +110,10 @@ func (b *BySenderAndNonce) blobCount(senderID uint64) uint64 {
 	return b.senderIDBlobCount[senderID]
 }
 
+func (b *BySenderAndNonce) gas(senderID uint64) uint64 {
+	return b.senderIDGas[senderID]
+}
+
 func (b *BySenderAndNonce) hasTxns(senderID uint64) bool {
 	has := false
 	b.ascend(senderID, func(*metaTxn) bool {
@@ -139,15 +152,21 @@ func (b *BySenderAndNonce) delete(mt *metaTxn, reason txpoolcfg.DiscardReason, l
 			delete(b.senderIDTxnCount, senderID)
 		}
 
-		if mt.TxnSlot.Type == BlobTxnType && mt.TxnSlot.BlobBundles != nil {
+		if mt.TxnSlot.Type == BlobTxnType {
 			accBlobCount := b.senderIDBlobCount[senderID]
-			txnBlobCount := len(mt.TxnSlot.BlobBundles)
-			if txnBlobCount > 1 {
-				b.senderIDBlobCount[senderID] = accBlobCount - uint64(txnBlobCount)
+			txnBlobCount := uint64(len(mt.TxnSlot.BlobHashes)) // blob bundles may be dropped from memory
+			if accBlobCount > txnBlobCount {
+				b.senderIDBlobCount[senderID] = accBlobCount - txnBlobCount
 			} else {
 				delete(b.senderIDBlobCount, senderID)
 			}
 		}
+
+		if accGas := b.senderIDGas[senderID]; accGas > mt.TxnSlot.Gas {
+			b.senderIDGas[senderID] = accGas - mt.TxnSlot.Gas
+		} else {
+			delete(b.senderIDGas, senderID)
+		}
 	}
 }
 
@@ -158,6 +177,7 @@ func (b *BySenderAndNonce) replaceOrInsert(mt *metaTxn, logger log.Logger) *meta
 		if mt.TxnSlot.Traced {
 			logger.Info("TX TRACING: Replaced txn by nonce", "idHash", fmt.Sprintf("%x", mt.TxnSlot.IDHash), "sender", mt.TxnSlot.SenderID, "nonce", mt.TxnSlot.Nonce)
 		}
+		b.senderIDGas[mt.TxnSlot.SenderID] += mt.TxnSlot.Gas - it.TxnSlot.Gas // wraps around if the replaced txn had more gas
 		return it
 	}
 
@@ -166,9 +186,10 @@ func (b *BySenderAndNonce) replaceOrInsert(mt *metaTxn, logger log.Logger) *meta
 	}
 
 	b.senderIDTxnCount[mt.TxnSlot.SenderID]++
-	if mt.TxnSlot.Type == BlobTxnType && mt.TxnSlot.BlobBundles != nil {
-		b.senderIDBlobCount[mt.TxnSlot.SenderID] += uint64(len(mt.TxnSlot.BlobBundles))
+	if mt.TxnSlot.Type == BlobTxnType {
+		b.senderIDBlobCount[mt.TxnSlot.SenderID] += uint64(len(mt.TxnSlot.BlobHashes))
 	}
+	b.senderIDGas[mt.TxnSlot.SenderID] += mt.TxnSlot.Gas
 	return nil
 }
 
@@ -228,6 +249,11 @@ func (sc *sendersBatch) info(cacheView kvcache.CacheView, id uint64) (uint64, ui
 	if !ok {
 		panic("must not happen")
 	}
+	return accountInfo(cacheView, addr)
+}
+
+// accountInfo returns the nonce and the balance of the account in the state
+func accountInfo(cacheView kvcache.CacheView, addr common.Address) (uint64, uint256.Int, error) {
 	encoded, err := cacheView.Get(addr.Bytes())
 	if err != nil {
 		return 0, uint256.Int{}, err
@@ -19,6 +19,7 @@ package txpool
 import (
 	"container/heap"
 	"fmt"
+	"time"
 
 	"github.com/erigontech/erigon-lib/log/v3"
 )
@@ -62,7 +63,8 @@ const (
 type SubPool struct {
 	best  *BestQueue
 	worst *WorstQueue
-	limit int
+	limit int // of transactions which aren't blob transactions, see BlobPool
+	blobs int // blob transactions in the sub-pool
 	t     SubPoolType
 }
 
@@ -92,12 +94,31 @@ func (p *SubPool) Worst() *metaTxn { //nolint
 func (p *SubPool) PopBest() *metaTxn { //nolint
 	i := heap.Pop(p.best).(*metaTxn)
 	heap.Remove(p.worst, i.worstIndex)
+	if i.TxnSlot.Type == BlobTxnType {
+		p.blobs--
+	}
 	return i
 }
 
 func (p *SubPool) PopWorst() *metaTxn { //nolint
 	i := heap.Pop(p.worst).(*metaTxn)
 	heap.Remove(p.best, i.bestIndex)
+	if i.TxnSlot.Type == BlobTxnType {
+		p.blobs--
+	}
+	return i
+}
+
+// PopWorstNonBlob pops the worst transaction which isn't a blob transaction, there must be one
+func (p *SubPool) PopWorstNonBlob(logger log.Logger) *metaTxn {
+	var blobTxns []*metaTxn
+	i := p.PopWorst()
+	for ; i.TxnSlot.Type == BlobTxnType; i = p.PopWorst() {
+		blobTxns = append(blobTxns, i)
+	}
+	for _, mt := range blobTxns {
+		p.Add(mt, "pop-worst-non-blob", logger)
+	}
 	return i
 }
 
@@ -105,13 +126,24 @@ func (p *SubPool) Len() int {
 	return p.best.Len()
 }
 
+// NonBlobLen - number of transactions limited by the sub-pool limit
+func (p *SubPool) NonBlobLen() int {
+	return p.best.Len() - p.blobs
+}
+
 func (p *SubPool) Add(i *metaTxn, reason string, logger log.Logger) {
 	if i.TxnSlot.Traced {
 		logger.Info(fmt.Sprintf("TX TRACING: added to subpool %s", p.t), "idHash", fmt.Sprintf("%x", i.TxnSlot.IDHash), "sender", i.TxnSlot.SenderID, "nonce", i.TxnSlot.Nonce, "reason", reason)
 	}
 	i.currentSubPool = p.t
+	if p.t == QueuedSubPool {
+		i.queuedAt = time.Now()
+	}
 	heap.Push(p.best, i)
 	heap.Push(p.worst, i)
+	if i.TxnSlot.Type == BlobTxnType {
+		p.blobs++
+	}
 }
 
 func (p *SubPool) Remove(i *metaTxn, reason string, logger log.Logger) {
@@ -120,6 +152,9 @@ func (p *SubPool) Remove(i *metaTxn, reason string, logger log.Logger) {
 	}
 	heap.Remove(p.best, i.bestIndex)
 	heap.Remove(p.worst, i.worstIndex)
+	if i.TxnSlot.Type == BlobTxnType {
+		p.blobs--
+	}
 	i.currentSubPool = 0
 }
 
@@ -18,6 +18,7 @@ package txpool
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
@@ -29,7 +30,6 @@ import (
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/holiman/uint256"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
@@ -40,7 +40,9 @@ import (
 	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/gointerfaces"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
@@ -55,11 +57,16 @@ type txPool interface {
 	PeekBest(ctx context.Context, n int, txns *TxnsRlp, onTopOf, availableGas, availableBlobGas uint64, availableRlpSpace int) (bool, error)
 	GetRlp(tx kv.Tx, hash []byte) ([]byte, error)
 	AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcfg.DiscardReason, error)
-	deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx)
+	forEachPage(q allQuery, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx) (nextSenderID, nextNonce uint64, more bool)
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
+	FilterKnownIdHashes(tx kv.Tx, hashes Hashes) (unknownHashes Hashes, err error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
 	GetBlobs(blobhashes []common.Hash) (blobBundles []PoolBlobBundle)
+	SetSenderPolicy(policy txpoolcfg.SenderPolicy)
+	SenderPolicy() txpoolcfg.SenderPolicy
+	SetReplacementPolicy(policy txpoolcfg.ReplacementPolicy)
+	ReplacementPolicy() txpoolcfg.ReplacementPolicy
 }
 
 var _ txpool_proto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -92,26 +99,46 @@ func (*GrpcDisabled) Pending(ctx context.Context, empty *emptypb.Empty) (*txpool
 func (*GrpcDisabled) OnAdd(request *txpool_proto.OnAddRequest, server txpool_proto.Txpool_OnAddServer) error {
 	return ErrPoolDisabled
 }
+func (*GrpcDisabled) OnDrop(request *txpool_proto.OnDropRequest, server txpool_proto.Txpool_OnDropServer) error {
+	return ErrPoolDisabled
+}
 func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusRequest) (*txpool_proto.StatusReply, error) {
 	return nil, ErrPoolDisabled
 }
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) SetPolicy(ctx context.Context, request *txpool_proto.SetPolicyRequest) (*txpool_proto.SetPolicyReply, error) {
+	return nil, ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
-	ctx             context.Context
-	txPool          txPool
-	db              kv.RoDB
-	newSlotsStreams *NewSlotsStreams
+	ctx                context.Context
+	txPool             txPool
+	db                 kv.RoDB
+	newSlotsStreams    *NewSlotsStreams
+	droppedTxnsStreams *DroppedTxnsStreams
 
 	chainID uint256.Int
 	logger  log.Logger
 }
 
-func NewGrpcServer(ctx context.Context, txPool txPool, db kv.RoDB, newSlotsStreams *NewSlotsStreams, chainID uint256.Int, logger log.Logger) *GrpcServer {
-	return &GrpcServer{ctx: ctx, txPool: txPool, db: db, newSlotsStreams: newSlotsStreams, chainID: chainID, logger: logger}
+func NewGrpcServer(ctx context.Context, txPool txPool, db kv.RoDB, newSlotsStreams *NewSlotsStreams, droppedTxnsStreams *DroppedTxnsStreams, chainID uint256.Int, logger log.Logger) *GrpcServer {
+	return &GrpcServer{ctx: ctx, txPool: txPool, db: db, newSlotsStreams: newSlotsStreams, droppedTxnsStreams: droppedTxnsStreams, chainID: chainID, logger: logger}
+}
+
+var _ FeeMarketHistoryReader = (*GrpcServer)(nil)
+
+// FeeMarketHistory - returns persisted summaries of pending sub-pool. Not part of gRPC interface: see FeeMarketHistoryReader
+func (s *GrpcServer) FeeMarketHistory(ctx context.Context, fromTime uint64, limit int) (res []FeeMarketSnapshot, err error) {
+	if err := s.db.View(ctx, func(tx kv.Tx) error {
+		res, err = FeeMarketHistory(tx, fromTime, limit)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 func (s *GrpcServer) Version(context.Context, *emptypb.Empty) (*typesproto.VersionReply, error) {
@@ -129,7 +156,61 @@ func convertSubPoolType(t SubPoolType) txpool_proto.AllReply_TxnType {
 		panic("unknown")
 	}
 }
-func (s *GrpcServer) All(ctx context.Context, _ *txpool_proto.AllRequest) (*txpool_proto.AllReply, error) {
+
+// allCursorLen - the cursor of the All pages: sender id and nonce of the next transaction, big-endian
+const allCursorLen = 16
+
+var ErrAllCursor = errors.New("invalid cursor of All request")
+
+func convertAllSubPool(t txpool_proto.AllReply_TxnType) (SubPoolType, error) {
+	switch t {
+	case txpool_proto.AllReply_PENDING:
+		return PendingSubPool, nil
+	case txpool_proto.AllReply_BASE_FEE:
+		return BaseFeeSubPool, nil
+	case txpool_proto.AllReply_QUEUED:
+		return QueuedSubPool, nil
+	default:
+		return 0, fmt.Errorf("unknown sub-pool %d", t)
+	}
+}
+
+// All returns a page of the pooled transactions, ordered by sender and nonce, see txpool_proto.AllRequest. An empty
+// request returns all of them.
+func (s *GrpcServer) All(ctx context.Context, in *txpool_proto.AllRequest) (*txpool_proto.AllReply, error) {
+	q := allQuery{limit: int(in.Limit)}
+	if len(in.Cursor) > 0 {
+		if len(in.Cursor) != allCursorLen {
+			return nil, fmt.Errorf("%w: length %d", ErrAllCursor, len(in.Cursor))
+		}
+		q.fromSenderID, q.fromNonce = binary.BigEndian.Uint64(in.Cursor[:8]), binary.BigEndian.Uint64(in.Cursor[8:])
+	}
+	if len(in.Senders) > 0 {
+		q.senders = make(map[common.Address]struct{}, len(in.Senders))
+		for _, sender := range in.Senders {
+			q.senders[gointerfaces.ConvertH160toAddress(sender)] = struct{}{}
+		}
+	}
+	if len(in.SubPools) > 0 {
+		q.subPools = make(map[SubPoolType]struct{}, len(in.SubPools))
+		for _, t := range in.SubPools {
+			subPool, err := convertAllSubPool(t)
+			if err != nil {
+				return nil, err
+			}
+			q.subPools[subPool] = struct{}{}
+		}
+	}
+	if len(in.Types) > 0 {
+		q.types = make(map[byte]struct{}, len(in.Types))
+		for _, t := range in.Types {
+			if t > math.MaxUint8 {
+				return nil, fmt.Errorf("unknown txn type %d", t)
+			}
+			q.types[byte(t)] = struct{}{}
+		}
+	}
+
 	tx, err := s.db.BeginRo(ctx)
 	if err != nil {
 		return nil, err
@@ -137,13 +218,17 @@ func (s *GrpcServer) All(ctx context.Context, _ *txpool_proto.AllRequest) (*txpo
 	defer tx.Rollback()
 	reply := &txpool_proto.AllReply{}
 	reply.Txs = make([]*txpool_proto.AllReply_Tx, 0, 32)
-	s.txPool.deprecatedForEach(ctx, func(rlp []byte, sender common.Address, t SubPoolType) {
+	nextSenderID, nextNonce, more := s.txPool.forEachPage(q, func(rlp []byte, sender common.Address, t SubPoolType) {
 		reply.Txs = append(reply.Txs, &txpool_proto.AllReply_Tx{
 			Sender:  gointerfaces.ConvertAddressToH160(sender),
 			TxnType: convertSubPoolType(t),
 			RlpTx:   common.Copy(rlp),
 		})
 	}, tx)
+	if more {
+		reply.NextCursor = binary.BigEndian.AppendUint64(make([]byte, 0, allCursorLen), nextSenderID)
+		reply.NextCursor = binary.BigEndian.AppendUint64(reply.NextCursor, nextNonce)
+	}
 	return reply, nil
 }
 
@@ -166,8 +251,29 @@ func (s *GrpcServer) Pending(ctx context.Context, _ *emptypb.Empty) (*txpool_pro
 	return reply, nil
 }
 
+// FindUnknown returns the hashes unknown to the pool, in the incoming order: not pooled, not waiting for processing,
+// not recently mined blob txns and not recently discarded
 func (s *GrpcServer) FindUnknown(ctx context.Context, in *txpool_proto.TxHashes) (*txpool_proto.TxHashes, error) {
-	return nil, errors.New("unimplemented")
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	hashes := make(Hashes, 0, len(in.Hashes)*length.Hash)
+	for _, h := range in.Hashes {
+		hash := gointerfaces.ConvertH256ToHash(h)
+		hashes = append(hashes, hash[:]...)
+	}
+	unknown, err := s.txPool.FilterKnownIdHashes(tx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	reply := &txpool_proto.TxHashes{Hashes: make([]*typesproto.H256, 0, unknown.Len())}
+	for i := 0; i < unknown.Len(); i++ {
+		reply.Hashes = append(reply.Hashes, gointerfaces.ConvertHashToH256([32]byte(unknown.At(i))))
+	}
+	return reply, nil
 }
 
 func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txpool_proto.AddReply, error) {
@@ -241,19 +347,54 @@ func (s *GrpcServer) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequ
 	blobBundles := s.txPool.GetBlobs(hashes)
 	blobs := make([][]byte, 0)
 	proofs := make([][]byte, 0)
-	for _, bb := range blobBundles {
+	blobsAndProofs := make([]*txpool_proto.BlobAndProofs, len(blobBundles))
+	for i, bb := range blobBundles {
 		blobs = append(blobs, bb.Blob)
 		if len(bb.Proofs) == 0 {
 			proofs = append(proofs, nil)
 		}
-		for _, p := range bb.Proofs {
+		blobsAndProofs[i] = &txpool_proto.BlobAndProofs{Found: len(bb.Blob) > 0, Blob: bb.Blob, Proofs: make([][]byte, len(bb.Proofs))}
+		for j, p := range bb.Proofs {
 			proofs = append(proofs, p[:])
+			blobsAndProofs[i].Proofs[j] = p[:]
 		}
 	}
-	reply := &txpool_proto.GetBlobsReply{Blobs: blobs, Proofs: proofs}
+	reply := &txpool_proto.GetBlobsReply{Blobs: blobs, Proofs: proofs, BlobsAndProofs: blobsAndProofs}
 	return reply, nil
 }
 
+// SetPolicy changes the per-sender limits and the replacement price bumps, those which are set, and returns the
+// policies in effect
+func (s *GrpcServer) SetPolicy(ctx context.Context, in *txpool_proto.SetPolicyRequest) (*txpool_proto.SetPolicyReply, error) {
+	if in.Policy != nil {
+		s.txPool.SetSenderPolicy(txpoolcfg.SenderPolicy{
+			MaxSlots:    in.Policy.MaxSlots,
+			MaxGas:      in.Policy.MaxGas,
+			MaxNonceGap: in.Policy.MaxNonceGap,
+		})
+	}
+	if in.Replacement != nil {
+		s.txPool.SetReplacementPolicy(txpoolcfg.ReplacementPolicy{
+			PriceBump:     in.Replacement.PriceBump,
+			BlobPriceBump: in.Replacement.BlobPriceBump,
+			BlobFeeBump:   in.Replacement.BlobFeeBump,
+		})
+	}
+	policy, replacement := s.txPool.SenderPolicy(), s.txPool.ReplacementPolicy()
+	return &txpool_proto.SetPolicyReply{
+		Policy: &txpool_proto.SenderPolicy{
+			MaxSlots:    policy.MaxSlots,
+			MaxGas:      policy.MaxGas,
+			MaxNonceGap: policy.MaxNonceGap,
+		},
+		Replacement: &txpool_proto.ReplacementPolicy{
+			PriceBump:     replacement.PriceBump,
+			BlobPriceBump: replacement.BlobPriceBump,
+			BlobFeeBump:   replacement.BlobFeeBump,
+		},
+	}, nil
+}
+
 func mapDiscardReasonToProto(reason txpoolcfg.DiscardReason) txpool_proto.ImportResult {
 	switch reason {
 	case txpoolcfg.Success:
@@ -268,6 +409,8 @@ func mapDiscardReasonToProto(reason txpoolcfg.DiscardReason) txpool_proto.Import
 		txpoolcfg.UnmatchedBlobTxExt, txpoolcfg.NoAuthorizations:
 		// TODO(EIP-7702) TypeNotActivated may be transient (e.g. a set code transaction is submitted 1 sec prior to the Pectra activation)
 		return txpool_proto.ImportResult_INVALID
+	case txpoolcfg.WouldRevert:
+		return txpool_proto.ImportResult_WOULD_REVERT
 	default:
 		return txpool_proto.ImportResult_INTERNAL_ERROR
 	}
@@ -286,6 +429,23 @@ func (s *GrpcServer) OnAdd(req *txpool_proto.OnAddRequest, stream txpool_proto.T
 	}
 }
 
+// OnDrop streams the txns removed from the pool: hash, discard reason and the replacing txn if it was replaced
+func (s *GrpcServer) OnDrop(req *txpool_proto.OnDropRequest, stream txpool_proto.Txpool_OnDropServer) error {
+	if s.droppedTxnsStreams == nil {
+		return errors.New("dropped txns are not streamed by this txpool")
+	}
+	s.logger.Info("Dropped txns subscriber joined")
+	//txpool.Run does send messages to this streams
+	remove := s.droppedTxnsStreams.Add(stream)
+	defer remove()
+	select {
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
 func (s *GrpcServer) Transactions(ctx context.Context, in *txpool_proto.TransactionsRequest) (*txpool_proto.TransactionsReply, error) {
 	tx, err := s.db.BeginRo(ctx)
 	if err != nil {
@@ -374,7 +534,52 @@ func (s *NewSlotsStreams) remove(id uint) {
 	delete(s.chans, id)
 }
 
-func StartGrpc(txPoolServer txpool_proto.TxpoolServer, miningServer txpool_proto.MiningServer, addr string, creds *credentials.TransportCredentials, logger log.Logger) (*grpc.Server, error) {
+// DroppedTxnsStreams - subscribers of OnDrop, it's safe to use this class as non-pointer
+type DroppedTxnsStreams struct {
+	chans map[uint]txpool_proto.Txpool_OnDropServer
+	mu    sync.Mutex
+	id    uint
+}
+
+func (s *DroppedTxnsStreams) Add(stream txpool_proto.Txpool_OnDropServer) (remove func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.chans == nil {
+		s.chans = make(map[uint]txpool_proto.Txpool_OnDropServer)
+	}
+	s.id++
+	id := s.id
+	s.chans[id] = stream
+	return func() { s.remove(id) }
+}
+
+func (s *DroppedTxnsStreams) Broadcast(reply *txpool_proto.OnDropReply, logger log.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, stream := range s.chans {
+		err := stream.Send(reply)
+		if err != nil {
+			logger.Debug("failed send to dropped txns stream", "err", err)
+			select {
+			case <-stream.Context().Done():
+				delete(s.chans, id)
+			default:
+			}
+		}
+	}
+}
+
+func (s *DroppedTxnsStreams) remove(id uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.chans[id]
+	if !ok { // double-unsubscribe support
+		return
+	}
+	delete(s.chans, id)
+}
+
+func StartGrpc(txPoolServer txpool_proto.TxpoolServer, miningServer txpool_proto.MiningServer, addr string, sec *grpcutil.Security, logger log.Logger) (*grpc.Server, error) {
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return nil, fmt.Errorf("could not create listener: %w, addr=%s", err, addr)
@@ -406,11 +611,7 @@ func StartGrpc(txPoolServer txpool_proto.TxpoolServer, miningServer txpool_proto
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 	}
-	if creds == nil {
-		// no specific opts
-	} else {
-		opts = append(opts, grpc.Creds(*creds))
-	}
+	opts = append(opts, sec.ServerOptions()...)
 	grpcServer := grpc.NewServer(opts...)
 	reflection.Register(grpcServer) // Register reflection service on gRPC server.
 	if txPoolServer != nil {
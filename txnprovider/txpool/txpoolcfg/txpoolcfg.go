@@ -32,15 +32,40 @@ type Config struct {
 	Disable             bool
 	DBDir               string
 	TracedSenders       []string // List of senders for which txn pool should print out debugging info
+	PrioritySenders     []string // Senders of system and service txns: offered first and not evicted for their fees
 	PendingSubPoolLimit int
 	BaseFeeSubPoolLimit int
 	QueuedSubPoolLimit  int
 	MinFeeCap           uint64
 	AccountSlots        uint64 // Number of executable transaction slots guaranteed per account
 	BlobSlots           uint64 // Total number of blobs (not txns) allowed per account
-	TotalBlobPoolLimit  uint64 // Total number of blobs (not txns) allowed within the txpool
-	PriceBump           uint64 // Price bump percentage to replace an already existing transaction
-	BlobPriceBump       uint64 //Price bump percentage to replace an existing 4844 blob txn (type-3)
+	TotalBlobPoolLimit  uint64 // Total number of blobs (not txns) allowed within the txpool: limit of the blob sub-pool
+	BlobMemoryLimit     uint64 // Number of blobs held in memory, others are read from the pool db when requested
+
+	// price bumps to replace a pooled transaction, can be changed at runtime: see TxPool.SetReplacementPolicy
+	Replacement ReplacementPolicy
+
+	// per-sender limits of the added transactions, can be changed at runtime: see TxPool.SetSenderPolicy
+	SenderPolicy SenderPolicy
+
+	// local transactions are executed against the latest state before they are added, and rejected if they
+	// revert. Needs a simulator: see txpool.WithTxnSimulator
+	SimulateLocalTxns bool
+
+	// parking lot of the queued sub-pool: transactions with a nonce gap or insufficient balance, see Parking
+	Parking Parking
+
+	// remote transactions staying in the queued sub-pool for longer are discarded by a background reaper, 0 - no
+	// limit. The time in the sub-pool is counted from the start of the pool for the transactions of the pool db
+	QueuedLifetime time.Duration
+
+	// per-peer limits of the remote transactions received from the sentries, see PeerIngestion
+	PeerIngestion PeerIngestion
+
+	// order of the pending transactions offered for the blocks, one of Orderings. OrderingGrpc asks the
+	// txpoolproto.Scorer service at OrderingAddr
+	Ordering     string
+	OrderingAddr string
 
 	// regular batch tasks processing
 	SyncToNewPeersEvery    time.Duration
@@ -48,6 +73,13 @@ type Config struct {
 	CommitEvery            time.Duration
 	LogEvery               time.Duration
 
+	// fee market history: summaries of pending sub-pool, persisted for historical analysis
+	FeeMarketHistoryEvery time.Duration // 0 - disabled
+	FeeMarketHistoryLimit int           // how many newest snapshots to keep
+
+	// propagation trace: when and from whom transactions were received, and when sent to peers, kept in memory
+	PropagationTraceWindow time.Duration // 0 - disabled
+
 	//txpool db
 	MdbxPageSize    datasize.ByteSize
 	MdbxDBSizeLimit datasize.ByteSize
@@ -56,16 +88,73 @@ type Config struct {
 
 	NoGossip bool // this mode doesn't broadcast any txns, and if receive remote-txn - skip it
 
+	// the pool isn't dumped to a file of DBDir on shutdown nor loaded from it on startup, see txpool.DumpFile
+	NoDump bool
+
 	// Account Abstraction
 	AllowAA bool
 }
 
+const (
+	OrderingEffectiveTip    = "effectivetip"    // by sub-pool markers, then by effective tip: the order of the pending sub-pool
+	OrderingTipAfterBaseFee = "tipafterbasefee" // by EIP-1559 tip after the base fee only
+	OrderingGrpc            = "grpc"            // by the scores of the external service
+)
+
+var Orderings = []string{OrderingEffectiveTip, OrderingTipAfterBaseFee, OrderingGrpc}
+
+// ReplacementPolicy - price bumps, in percent of the prices of a pooled transaction, a transaction with the same
+// sender and nonce needs to replace it. A blob transaction can be replaced only by a blob one, which bumps both
+// its execution prices and its blob fee cap.
+type ReplacementPolicy struct {
+	PriceBump     uint64 // tip and fee cap of a non-blob transaction
+	BlobPriceBump uint64 // tip and fee cap of a blob transaction
+	BlobFeeBump   uint64 // blob fee cap of a blob transaction
+}
+
+// Parking - limits of the transactions parked in the queued sub-pool: waiting for a nonce gap to be filled or for
+// the balance of the sender. 0 - no limit, all 0 - parked transactions aren't tracked.
+type Parking struct {
+	Limit  int           // parked transactions, the ones parked longest are discarded above it
+	MaxAge time.Duration // parked transactions are discarded after it
+}
+
+// PeerIngestion - limits of the remote transactions per sentry peer. All 0 - disabled.
+type PeerIngestion struct {
+	TxnsPerSecond float64 // transactions accepted from a peer, in bursts up to TxnsBurst. 0 - no limit
+	TxnsBurst     int
+	// penalty points of a peer at which it's penalized in the sentries and its transactions are dropped until
+	// the points decay: 10 per malformed message, 1 per invalid transaction or message over the rate. 0 - no penalties
+	MaxPenalty int
+}
+
+// SenderPolicy - limits of the transactions pooled per sender, enforced on AddLocalTxns and AddRemoteTxns.
+// 0 - no limit.
+type SenderPolicy struct {
+	MaxSlots    uint64 // transactions pooled per sender
+	MaxGas      uint64 // sum of gas limits of the transactions pooled per sender
+	MaxNonceGap uint64 // distance of a transaction nonce ahead of the sender nonce
+}
+
 var DefaultConfig = Config{
 	SyncToNewPeersEvery:    5 * time.Second,
 	ProcessRemoteTxnsEvery: 100 * time.Millisecond,
 	CommitEvery:            15 * time.Second,
 	LogEvery:               30 * time.Second,
 
+	FeeMarketHistoryEvery: 0,
+	FeeMarketHistoryLimit: 7 * 24 * 60, // a week of 1-minute snapshots
+
+	PropagationTraceWindow: 0,
+
+	Ordering: OrderingEffectiveTip,
+
+	PeerIngestion: PeerIngestion{
+		TxnsPerSecond: 1000,
+		TxnsBurst:     4096,
+		MaxPenalty:    100,
+	},
+
 	PendingSubPoolLimit: 10_000,
 	BaseFeeSubPoolLimit: 30_000,
 	QueuedSubPoolLimit:  30_000,
@@ -74,8 +163,13 @@ var DefaultConfig = Config{
 	AccountSlots:       16,   // TODO: to choose right value (16 to be compatible with Geth)
 	BlobSlots:          540,  // Default for a total of 30 txns for 18 blobs each - for hive tests
 	TotalBlobPoolLimit: 5400, // Default for a total of 10 different accounts hitting the above limit
-	PriceBump:          10,   // Price bump percentage to replace an already existing transaction
-	BlobPriceBump:      100,
+	BlobMemoryLimit:    1024, // 128MB of blobs
+
+	Replacement: ReplacementPolicy{
+		PriceBump:     10, // Price bump percentage to replace an already existing transaction
+		BlobPriceBump: 100,
+		BlobFeeBump:   100,
+	},
 
 	NoGossip:     false,
 	MdbxWriteMap: false,
@@ -121,6 +215,13 @@ const (
 	ErrAuthorityReserved DiscardReason = 34 // EIP-7702 transaction with authority already reserved
 	InvalidAA            DiscardReason = 35 // Invalid RIP-7560 transaction
 	ErrGetCode           DiscardReason = 36 // Error getting code during AA validation
+	SenderSlotsExceeded  DiscardReason = 37 // SenderPolicy.MaxSlots
+	SenderGasExceeded    DiscardReason = 38 // SenderPolicy.MaxGas
+	NonceGapTooLarge     DiscardReason = 39 // SenderPolicy.MaxNonceGap
+	WouldRevert          DiscardReason = 40 // Config.SimulateLocalTxns
+	ParkingExpired       DiscardReason = 41 // Parking.MaxAge
+	ParkingOverflow      DiscardReason = 42 // Parking.Limit
+	Expired              DiscardReason = 43 // Config.QueuedLifetime
 )
 
 func (r DiscardReason) String() string {
@@ -199,6 +300,20 @@ func (r DiscardReason) String() string {
 		return "RIP-7560 transaction failed validation"
 	case ErrGetCode:
 		return "error getting account code during RIP-7560 validation"
+	case SenderSlotsExceeded:
+		return "too many transactions of the sender"
+	case SenderGasExceeded:
+		return "gas of the sender transactions exceeds the limit"
+	case NonceGapTooLarge:
+		return "nonce too far ahead of the sender nonce"
+	case WouldRevert:
+		return "execution reverted in simulation"
+	case ParkingExpired:
+		return "parked for too long (nonce gap or insufficient balance)"
+	case ParkingOverflow:
+		return "parking lot is full"
+	case Expired:
+		return "queued for too long"
 	default:
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}